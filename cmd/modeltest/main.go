@@ -11,7 +11,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/MadeByDoug/wls-chatbot/internal/core/datastore"
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	"github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/modeltestdriver"
 	"github.com/MadeByDoug/wls-chatbot/pkg/models/modeltest"
+	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -31,11 +35,18 @@ func newRootCommand() *cobra.Command {
 Golden files capture request/response pairs for mock-based regression testing.
 This tool can run in two modes:
   - live:  Make real API calls and update golden files
-  - mock:  Replay golden files without API calls`,
+  - mock:  Replay golden files without API calls
+
+Requests are issued by one of two drivers:
+  - http:    The tool's own raw HTTP client
+  - adapter: The application's production provider adapters`,
 	}
 
 	cmd.AddCommand(newRunCommand())
 	cmd.AddCommand(newValidateCommand())
+	cmd.AddCommand(newDiffCommand())
+	cmd.AddCommand(newEvalCommand())
+	cmd.AddCommand(newBenchCommand())
 	return cmd
 }
 
@@ -43,11 +54,24 @@ func newRunCommand() *cobra.Command {
 	var config modeltest.RunnerConfig
 	var providersStr string
 	var capsStr string
+	var reportSpec string
+	var updateCatalog bool
+	var catalogDB string
 
 	cmd := &cobra.Command{
 		Use:   "run",
 		Short: "Run capability tests",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			reportTargets, err := modeltest.ParseReportTargets(reportSpec)
+			if err != nil {
+				return err
+			}
+			if updateCatalog && config.Mode != "live" {
+				return fmt.Errorf("--update-catalog requires --mode live")
+			}
+			if updateCatalog && catalogDB == "" {
+				return fmt.Errorf("--update-catalog requires --catalog-db")
+			}
 			if providersStr != "" {
 				config.Providers = strings.Split(providersStr, ",")
 			}
@@ -58,6 +82,13 @@ func newRunCommand() *cobra.Command {
 			}
 
 			runner := modeltest.NewRunner(config)
+			switch config.Driver {
+			case modeltest.DriverHTTP:
+			case modeltest.DriverAdapter:
+				runner.SetAdapterDriver(modeltestdriver.New(corelogger.NewAdapter(zerolog.Nop())))
+			default:
+				return fmt.Errorf("unknown driver %q (want %s or %s)", config.Driver, modeltest.DriverHTTP, modeltest.DriverAdapter)
+			}
 			if err := runner.LoadEmbeddedCatalogPlan(); err != nil {
 				return fmt.Errorf("load embedded catalog plan: %w", err)
 			}
@@ -65,7 +96,7 @@ func newRunCommand() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
 			defer cancel()
 
-			fmt.Printf("Running tests in %s mode...\n", config.Mode)
+			fmt.Printf("Running tests in %s mode with the %s driver...\n", config.Mode, config.Driver)
 			results, err := runner.Run(ctx)
 			if err != nil {
 				return err
@@ -73,6 +104,14 @@ func newRunCommand() *cobra.Command {
 
 			report := modeltest.GenerateReport(results)
 			printReport(report)
+			if err := modeltest.WriteReports(report, reportTargets); err != nil {
+				return err
+			}
+			if updateCatalog {
+				if err := writeCatalogVerifications(catalogDB, report); err != nil {
+					return err
+				}
+			}
 
 			if report.Failed > 0 {
 				return fmt.Errorf("%d tests failed", report.Failed)
@@ -87,6 +126,10 @@ func newRunCommand() *cobra.Command {
 	cmd.Flags().StringVar(&capsStr, "capabilities", "", "Comma-separated list of capabilities to test")
 	cmd.Flags().IntVar(&config.Parallel, "parallel", 1, "Number of parallel tests")
 	cmd.Flags().DurationVar(&config.Timeout, "timeout", 30*time.Second, "Timeout per test")
+	cmd.Flags().StringVar(&config.Driver, "driver", modeltest.DriverHTTP, "Request driver: http (raw client) or adapter (production provider adapters)")
+	cmd.Flags().BoolVar(&updateCatalog, "update-catalog", false, "Mark tested models verified or broken per capability in the app catalog (live mode only)")
+	cmd.Flags().StringVar(&catalogDB, "catalog-db", "", "App database path used by --update-catalog")
+	cmd.Flags().StringVar(&reportSpec, "report", "", "Comma-separated reports as format:path (junit, json, md), e.g. junit:out.xml,md:matrix.md")
 
 	return cmd
 }
@@ -123,6 +166,294 @@ func newValidateCommand() *cobra.Command {
 	return cmd
 }
 
+func newDiffCommand() *cobra.Command {
+	var format string
+	var output string
+	var rulesPath string
+	var ignore []string
+	var noDefaults bool
+
+	cmd := &cobra.Command{
+		Use:   "diff [golden-dir] [recorded-dir]",
+		Short: "Compare new recordings to golden files and report schema drift",
+		Long: `Compares the newest golden file per provider/capability/model in each directory by JSON structure.
+
+Fields are classified as added, removed, or type-changed. Removed fields, type changes
+(other than to or from null), status class changes, and missing exchanges are breaking
+and make the command exit non-zero. Volatile fields such as ids, timestamps, and token
+counts are ignored by default; add rules with --ignore or a YAML file (ignore: [...]).`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rules := modeltest.NewDiffRules(!noDefaults, ignore...)
+			if rulesPath != "" {
+				loaded, err := modeltest.LoadDiffRules(rulesPath, rules)
+				if err != nil {
+					return err
+				}
+				rules = loaded
+			}
+
+			report, err := modeltest.DiffGoldenDirs(args[0], args[1], rules)
+			if err != nil {
+				return err
+			}
+
+			writer := os.Stdout
+			if output != "" {
+				file, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("create report: %w", err)
+				}
+				defer file.Close()
+				writer = file
+			}
+
+			switch format {
+			case "text":
+				err = report.WriteText(writer)
+			case "json":
+				err = report.WriteJSON(writer)
+			default:
+				return fmt.Errorf("unknown format %q (want text or json)", format)
+			}
+			if err != nil {
+				return fmt.Errorf("write report: %w", err)
+			}
+
+			if report.Breaking > 0 {
+				return fmt.Errorf("%d schema-breaking change(s) detected", report.Breaking)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Report format: text or json")
+	cmd.Flags().StringVar(&output, "output", "", "Write the report to a file instead of stdout")
+	cmd.Flags().StringVar(&rulesPath, "rules", "", "YAML file with additional ignore rules")
+	cmd.Flags().StringArrayVar(&ignore, "ignore", nil, "Field path pattern to ignore (repeatable, e.g. choices[].logprobs.**)")
+	cmd.Flags().BoolVar(&noDefaults, "no-default-ignores", false, "Do not ignore ids, timestamps, and token counts by default")
+	return cmd
+}
+
+func newEvalCommand() *cobra.Command {
+	var config modeltest.EvalConfig
+	var providersStr string
+	var modelsStr string
+	var scorecardDir string
+	var catalogDB string
+
+	cmd := &cobra.Command{
+		Use:   "eval [suite.yaml]",
+		Short: "Score models against a prompt suite",
+		Long: `Runs a YAML prompt suite against every planned chat model and prints per-model scorecards.
+
+Cases are scored with exact, contains, regex, json_schema, or judge (LLM-as-judge using the
+suite's judge provider and model). Live mode records golden files; mock mode replays them for CI.
+With --update-catalog-db (live mode only), each scorecard's reliability tier is written to
+model_system_profile in the given app database with system_profile_source "eval".`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if catalogDB != "" && config.Mode != "live" {
+				return fmt.Errorf("--update-catalog-db requires --mode live")
+			}
+			suite, err := modeltest.LoadEvalSuite(args[0])
+			if err != nil {
+				return err
+			}
+			if providersStr != "" {
+				config.Providers = strings.Split(providersStr, ",")
+			}
+			if modelsStr != "" {
+				config.Models = strings.Split(modelsStr, ",")
+			}
+
+			plan, err := modeltest.LoadEmbeddedCatalogPlan()
+			if err != nil {
+				return fmt.Errorf("load embedded catalog plan: %w", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+			defer cancel()
+
+			fmt.Printf("Evaluating suite %q in %s mode...\n", suite.Name, config.Mode)
+			scorecards, err := modeltest.NewEvalRunner(config, plan).Run(ctx, suite)
+			if err != nil {
+				return err
+			}
+			printScorecards(scorecards)
+
+			if scorecardDir != "" {
+				if err := modeltest.SaveScorecards(scorecardDir, scorecards); err != nil {
+					return err
+				}
+				fmt.Printf("\nScorecards written to %s\n", scorecardDir)
+			}
+			if catalogDB != "" {
+				return writeEvalTiers(catalogDB, scorecards)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&config.Mode, "mode", "mock", "Eval mode: live or mock")
+	cmd.Flags().StringVar(&config.OutputDir, "output", "testdata/golden", "Golden file directory")
+	cmd.Flags().StringVar(&scorecardDir, "scorecards", "", "Directory to write scorecard JSON files")
+	cmd.Flags().StringVar(&providersStr, "providers", "", "Comma-separated list of providers to evaluate")
+	cmd.Flags().StringVar(&modelsStr, "models", "", "Comma-separated list of model IDs to evaluate")
+	cmd.Flags().IntVar(&config.Samples, "samples", 0, "Samples per case (overrides the suite)")
+	cmd.Flags().DurationVar(&config.Timeout, "timeout", 60*time.Second, "Timeout per sample")
+	cmd.Flags().StringVar(&catalogDB, "update-catalog-db", "", "App database path to write scored reliability tiers into")
+
+	return cmd
+}
+
+func newBenchCommand() *cobra.Command {
+	var config modeltest.BenchConfig
+	var providersStr string
+	var modelsStr string
+	var format string
+	var output string
+	var catalogDB string
+
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Measure streamed latency and throughput per model",
+		Long: `Streams the same prompt N times against every planned streaming model and reports
+time-to-first-token, total latency, and tokens/sec with p50/p90/p99 percentiles.
+
+With --update-catalog-db, each model's latency tier (derived from p50 TTFT) is written to
+model_system_profile in the given app database with system_profile_source "benchmark".`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "json" && format != "csv" {
+				return fmt.Errorf("unknown format %q (want json or csv)", format)
+			}
+			if providersStr != "" {
+				config.Providers = strings.Split(providersStr, ",")
+			}
+			if modelsStr != "" {
+				config.Models = strings.Split(modelsStr, ",")
+			}
+
+			plan, err := modeltest.LoadEmbeddedCatalogPlan()
+			if err != nil {
+				return fmt.Errorf("load embedded catalog plan: %w", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+			defer cancel()
+
+			results, err := modeltest.NewBenchmarker(config, plan).Run(ctx)
+			if err != nil {
+				return err
+			}
+
+			out := os.Stdout
+			if output != "" {
+				file, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("create report: %w", err)
+				}
+				defer file.Close()
+				out = file
+			}
+			if format == "csv" {
+				err = modeltest.WriteBenchCSV(out, results)
+			} else {
+				err = modeltest.WriteBenchJSON(out, results)
+			}
+			if err != nil {
+				return err
+			}
+
+			if catalogDB != "" {
+				return writeBenchTiers(catalogDB, results)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&config.Runs, "runs", 5, "Streamed runs per model")
+	cmd.Flags().IntVar(&config.Parallel, "parallel", 1, "Maximum concurrent runs")
+	cmd.Flags().StringVar(&config.Prompt, "prompt", "", "Prompt to stream (defaults to a ~150-word writing task)")
+	cmd.Flags().IntVar(&config.MaxTokens, "max-tokens", 0, "Completion token limit per run")
+	cmd.Flags().DurationVar(&config.Timeout, "timeout", 60*time.Second, "Timeout per run")
+	cmd.Flags().StringVar(&providersStr, "providers", "", "Comma-separated list of providers to benchmark")
+	cmd.Flags().StringVar(&modelsStr, "models", "", "Comma-separated list of model IDs to benchmark")
+	cmd.Flags().StringVar(&format, "format", "json", "Report format: json or csv")
+	cmd.Flags().StringVar(&output, "output", "", "Write the report to a file instead of stdout")
+	cmd.Flags().StringVar(&catalogDB, "update-catalog-db", "", "App database path to write measured latency tiers into")
+
+	return cmd
+}
+
+func writeCatalogVerifications(path string, report modeltest.Report) error {
+	db, err := datastore.OpenSQLite(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	verifications := make([]datastore.CapabilityVerification, 0, len(report.Results))
+	for _, result := range report.Results {
+		verifications = append(verifications, datastore.CapabilityVerification{
+			ProviderName: result.Provider,
+			ModelID:      result.Model,
+			Capability:   string(result.Capability),
+			Passed:       result.Success,
+			Error:        result.Error,
+			Source:       "modeltest",
+			CheckedAt:    report.Timestamp,
+		})
+	}
+	skipped, err := datastore.RecordCapabilityVerifications(db, verifications)
+	if err != nil {
+		return err
+	}
+	for _, key := range skipped {
+		fmt.Fprintf(os.Stderr, "skipping %s: not in catalog\n", key)
+	}
+	fmt.Printf("Catalog availability updated in %s\n", path)
+	return nil
+}
+
+func writeBenchTiers(path string, results []modeltest.BenchResult) error {
+	db, err := datastore.OpenSQLite(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	for _, result := range results {
+		if result.LatencyTier == "" {
+			fmt.Fprintf(os.Stderr, "skipping %s/%s: no successful runs\n", result.Provider, result.Model)
+			continue
+		}
+		err := datastore.UpdateModelSystemProfile(db, datastore.SystemProfileUpdate{
+			ProviderName: result.Provider,
+			ModelID:      result.Model,
+			LatencyTier:  result.LatencyTier,
+			Source:       result.SystemProfileSource,
+			AsOf:         result.AsOf,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Latency tiers written to %s\n", path)
+	return nil
+}
+
+func printScorecards(scorecards []modeltest.Scorecard) {
+	fmt.Printf("\n=== Scorecards ===\n")
+	for _, scorecard := range scorecards {
+		fmt.Printf("%s/%s  score=%.2f  pass=%.0f%%  errors=%d  tier=%s\n",
+			scorecard.Provider, scorecard.Model, scorecard.Score, scorecard.PassRate*100, scorecard.Errors, scorecard.ReliabilityTier)
+		for _, caseScore := range scorecard.Cases {
+			fmt.Printf("    %-24s %-12s score=%.2f  pass=%.0f%%\n", caseScore.ID, caseScore.Scorer, caseScore.Score, caseScore.PassRate*100)
+		}
+	}
+}
+
 func printReport(report modeltest.Report) {
 	fmt.Printf("\n=== Test Report ===\n")
 	fmt.Printf("Timestamp: %s\n", report.Timestamp.Format(time.RFC3339))
@@ -162,3 +493,30 @@ func validatePlanFile(path string) error {
 
 	return nil
 }
+
+func writeEvalTiers(path string, scorecards []modeltest.Scorecard) error {
+	db, err := datastore.OpenSQLite(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	for _, scorecard := range scorecards {
+		if len(scorecard.Cases) == 0 {
+			fmt.Fprintf(os.Stderr, "skipping %s/%s: no scored cases\n", scorecard.Provider, scorecard.Model)
+			continue
+		}
+		err := datastore.UpdateModelSystemProfile(db, datastore.SystemProfileUpdate{
+			ProviderName:    scorecard.Provider,
+			ModelID:         scorecard.Model,
+			ReliabilityTier: scorecard.ReliabilityTier,
+			Source:          scorecard.SystemProfileSource,
+			AsOf:            scorecard.AsOf,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Reliability tiers written to %s\n", path)
+	return nil
+}
@@ -61,6 +61,7 @@ function handleChatEvent(event: ChatEvent): void {
 
     switch (event.type) {
         case 'chat.message':
+        case 'chat.message.update':
             handleMessage(event);
             break;
 
@@ -22,6 +22,7 @@ export interface ModelSummary {
     modelId: string;
     displayName: string;
     availabilityState: string;
+    brokenCapabilities?: string[];
     contextWindow: number;
     costTier: string;
     supportsStreaming: boolean;
@@ -10,6 +10,7 @@ import type { Message, MessageMetadata } from './wails';
  */
 export type ChatEventType =
     | 'chat.message'
+    | 'chat.message.update'
     | 'chat.stream.start'
     | 'chat.stream.chunk'
     | 'chat.stream.error'
@@ -141,12 +141,38 @@ export namespace domain {
 		    return a;
 		}
 	}
+	export class Citation {
+	    index: number;
+	    collection: string;
+	    sourcePath: string;
+	    startLine: number;
+	    endLine: number;
+	    score?: number;
+	    snippet?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Citation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.index = source["index"];
+	        this.collection = source["collection"];
+	        this.sourcePath = source["sourcePath"];
+	        this.startLine = source["startLine"];
+	        this.endLine = source["endLine"];
+	        this.score = source["score"];
+	        this.snippet = source["snippet"];
+	    }
+	}
 	export class ConversationSettings {
 	    provider: string;
 	    model: string;
 	    temperature?: number;
 	    maxTokens?: number;
 	    systemPrompt?: string;
+	    knowledgeCollection?: string;
+	    knowledgeTopK?: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new ConversationSettings(source);
@@ -159,6 +185,30 @@ export namespace domain {
 	        this.temperature = source["temperature"];
 	        this.maxTokens = source["maxTokens"];
 	        this.systemPrompt = source["systemPrompt"];
+	        this.knowledgeCollection = source["knowledgeCollection"];
+	        this.knowledgeTopK = source["knowledgeTopK"];
+	    }
+	}
+	export class ModerationOutcome {
+	    stage: string;
+	    action: string;
+	    flagged: boolean;
+	    categories?: string[];
+	    provider?: string;
+	    model?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModerationOutcome(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.stage = source["stage"];
+	        this.action = source["action"];
+	        this.flagged = source["flagged"];
+	        this.categories = source["categories"];
+	        this.provider = source["provider"];
+	        this.model = source["model"];
 	    }
 	}
 	export class MessageMetadata {
@@ -171,6 +221,7 @@ export namespace domain {
 	    finishReason?: string;
 	    statusCode?: number;
 	    errorMessage?: string;
+	    moderation?: ModerationOutcome[];
 	
 	    static createFrom(source: any = {}) {
 	        return new MessageMetadata(source);
@@ -187,7 +238,26 @@ export namespace domain {
 	        this.finishReason = source["finishReason"];
 	        this.statusCode = source["statusCode"];
 	        this.errorMessage = source["errorMessage"];
+	        this.moderation = this.convertValues(source["moderation"], ModerationOutcome);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class Message {
 	    id: string;
@@ -197,6 +267,7 @@ export namespace domain {
 	    timestamp: number;
 	    isStreaming?: boolean;
 	    metadata?: MessageMetadata;
+	    citations?: Citation[];
 	
 	    static createFrom(source: any = {}) {
 	        return new Message(source);
@@ -211,6 +282,7 @@ export namespace domain {
 	        this.timestamp = source["timestamp"];
 	        this.isStreaming = source["isStreaming"];
 	        this.metadata = this.convertValues(source["metadata"], MessageMetadata);
+	        this.citations = this.convertValues(source["citations"], Citation);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -295,6 +367,7 @@ export namespace domain {
 	    }
 	}
 	
+	
 
 }
 
@@ -321,12 +394,117 @@ export namespace logger {
 
 export namespace ports {
 	
+	export class MaskPoint {
+	    x: number;
+	    y: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MaskPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.x = source["x"];
+	        this.y = source["y"];
+	    }
+	}
+	export class MaskPolygon {
+	    points: MaskPoint[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MaskPolygon(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.points = this.convertValues(source["points"], MaskPoint);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MaskRect {
+	    x: number;
+	    y: number;
+	    width: number;
+	    height: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MaskRect(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.x = source["x"];
+	        this.y = source["y"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	    }
+	}
+	export class BuildMaskRequest {
+	    imagePath: string;
+	    rects?: MaskRect[];
+	    polygons?: MaskPolygon[];
+	    editColor?: string;
+	    colorTolerance?: number;
+	    baseMaskPath?: string;
+	    invert?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new BuildMaskRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.imagePath = source["imagePath"];
+	        this.rects = this.convertValues(source["rects"], MaskRect);
+	        this.polygons = this.convertValues(source["polygons"], MaskPolygon);
+	        this.editColor = source["editColor"];
+	        this.colorTolerance = source["colorTolerance"];
+	        this.baseMaskPath = source["baseMaskPath"];
+	        this.invert = source["invert"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class EditImageRequest {
 	    providerName: string;
 	    modelName?: string;
 	    prompt: string;
 	    imagePath: string;
 	    maskPath?: string;
+	    maskRects?: MaskRect[];
 	    n?: number;
 	    size?: string;
 	
@@ -341,9 +519,28 @@ export namespace ports {
 	        this.prompt = source["prompt"];
 	        this.imagePath = source["imagePath"];
 	        this.maskPath = source["maskPath"];
+	        this.maskRects = this.convertValues(source["maskRects"], MaskRect);
 	        this.n = source["n"];
 	        this.size = source["size"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class GenerateImageRequest {
 	    providerName: string;
@@ -387,6 +584,25 @@ export namespace ports {
 	        this.revisedPrompt = source["revisedPrompt"];
 	    }
 	}
+	
+	
+	
+	export class MaskResult {
+	    bytes: number[];
+	    width: number;
+	    height: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MaskResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.bytes = source["bytes"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	    }
+	}
 	export class ModelCapabilities {
 	    supportsStreaming: boolean;
 	    supportsToolCalling: boolean;
@@ -423,6 +639,7 @@ export namespace ports {
 	    requiresToolCalling?: boolean;
 	    requiresStructuredOutput?: boolean;
 	    requiresVision?: boolean;
+	    excludeBroken?: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new ModelListFilter(source);
@@ -439,6 +656,7 @@ export namespace ports {
 	        this.requiresToolCalling = source["requiresToolCalling"];
 	        this.requiresStructuredOutput = source["requiresStructuredOutput"];
 	        this.requiresVision = source["requiresVision"];
+	        this.excludeBroken = source["excludeBroken"];
 	    }
 	}
 	export class ModelSummary {
@@ -449,6 +667,7 @@ export namespace ports {
 	    source: string;
 	    approved: boolean;
 	    availabilityState: string;
+	    brokenCapabilities?: string[];
 	    contextWindow: number;
 	    costTier: string;
 	    capabilities: ModelCapabilities;
@@ -466,6 +685,7 @@ export namespace ports {
 	        this.source = source["source"];
 	        this.approved = source["approved"];
 	        this.availabilityState = source["availabilityState"];
+	        this.brokenCapabilities = source["brokenCapabilities"];
 	        this.contextWindow = source["contextWindow"];
 	        this.costTier = source["costTier"];
 	        this.capabilities = this.convertValues(source["capabilities"], ModelCapabilities);
@@ -503,6 +723,46 @@ export namespace ports {
 	        this.imported = source["imported"];
 	    }
 	}
+	export class UpscaleImageRequest {
+	    providerName: string;
+	    modelName?: string;
+	    imagePath: string;
+	    factor?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpscaleImageRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.providerName = source["providerName"];
+	        this.modelName = source["modelName"];
+	        this.imagePath = source["imagePath"];
+	        this.factor = source["factor"];
+	    }
+	}
+	export class VaryImageRequest {
+	    providerName: string;
+	    modelName?: string;
+	    imagePath: string;
+	    prompt?: string;
+	    n?: number;
+	    size?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new VaryImageRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.providerName = source["providerName"];
+	        this.modelName = source["modelName"];
+	        this.imagePath = source["imagePath"];
+	        this.prompt = source["prompt"];
+	        this.n = source["n"];
+	        this.size = source["size"];
+	    }
+	}
 
 }
 
@@ -526,9 +786,11 @@ export namespace provider {
 	}
 	export class Info {
 	    name: string;
+	    type?: string;
 	    displayName: string;
 	    credentialFields?: core.CredentialField[];
 	    credentialValues?: Record<string, string>;
+	    credentialSources?: Record<string, string>;
 	    models: core.Model[];
 	    resources: core.Model[];
 	    isConnected: boolean;
@@ -542,9 +804,11 @@ export namespace provider {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
+	        this.type = source["type"];
 	        this.displayName = source["displayName"];
 	        this.credentialFields = this.convertValues(source["credentialFields"], core.CredentialField);
 	        this.credentialValues = source["credentialValues"];
+	        this.credentialSources = source["credentialSources"];
 	        this.models = this.convertValues(source["models"], core.Model);
 	        this.resources = this.convertValues(source["resources"], core.Model);
 	        this.isConnected = source["isConnected"];
@@ -1,9 +1,13 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
-import {core} from '../models';
+import {ports} from '../models';
 import {provider} from '../models';
+import {core} from '../models';
 import {domain} from '../models';
-import {ports} from '../models';
+
+export function BuildImageMask(arg1:ports.BuildMaskRequest):Promise<ports.MaskResult>;
+
+export function CloneProvider(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<provider.Info>;
 
 export function ConfigureProvider(arg1:string,arg2:core.ProviderCredentials):Promise<void>;
 
@@ -45,6 +49,8 @@ export function RestoreConversation(arg1:string):Promise<boolean>;
 
 export function SendMessage(arg1:string,arg2:string):Promise<domain.Message>;
 
+export function SendVoiceMessage(arg1:string,arg2:string,arg3:string,arg4:string):Promise<domain.Message>;
+
 export function SetActiveConversation(arg1:string):Promise<void>;
 
 export function SetActiveProvider(arg1:string):Promise<boolean>;
@@ -58,3 +64,7 @@ export function TestProvider(arg1:string):Promise<void>;
 export function UpdateConversationModel(arg1:string,arg2:string):Promise<boolean>;
 
 export function UpdateConversationProvider(arg1:string,arg2:string):Promise<boolean>;
+
+export function UpscaleImage(arg1:ports.UpscaleImageRequest):Promise<ports.ImageBinaryResult>;
+
+export function VaryImage(arg1:ports.VaryImageRequest):Promise<ports.ImageBinaryResult>;
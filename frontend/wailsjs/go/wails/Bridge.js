@@ -2,6 +2,14 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function BuildImageMask(arg1) {
+  return window['go']['wails']['Bridge']['BuildImageMask'](arg1);
+}
+
+export function CloneProvider(arg1, arg2, arg3, arg4) {
+  return window['go']['wails']['Bridge']['CloneProvider'](arg1, arg2, arg3, arg4);
+}
+
 export function ConfigureProvider(arg1, arg2) {
   return window['go']['wails']['Bridge']['ConfigureProvider'](arg1, arg2);
 }
@@ -82,6 +90,10 @@ export function SendMessage(arg1, arg2) {
   return window['go']['wails']['Bridge']['SendMessage'](arg1, arg2);
 }
 
+export function SendVoiceMessage(arg1, arg2, arg3, arg4) {
+  return window['go']['wails']['Bridge']['SendVoiceMessage'](arg1, arg2, arg3, arg4);
+}
+
 export function SetActiveConversation(arg1) {
   return window['go']['wails']['Bridge']['SetActiveConversation'](arg1);
 }
@@ -109,3 +121,11 @@ export function UpdateConversationModel(arg1, arg2) {
 export function UpdateConversationProvider(arg1, arg2) {
   return window['go']['wails']['Bridge']['UpdateConversationProvider'](arg1, arg2);
 }
+
+export function UpscaleImage(arg1) {
+  return window['go']['wails']['Bridge']['UpscaleImage'](arg1);
+}
+
+export function VaryImage(arg1) {
+  return window['go']['wails']['Bridge']['VaryImage'](arg1);
+}
@@ -3,11 +3,19 @@
 package app
 
 import (
+	"context"
+
+	audioports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/audio/ports"
 	chatfeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/app/chat"
 	chatports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/ports"
+	embeddingports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/embedding/ports"
 	imageports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/image/ports"
+	knowledgeports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/ports"
+	mcpports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/mcp/ports"
 	modelinterfaces "github.com/MadeByDoug/wls-chatbot/internal/features/ai/model/ports"
+	moderationports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/moderation/ports"
 	providerfeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/app/provider"
+	rerankports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/rerank/ports"
 )
 
 // App groups feature capabilities behind one application facade.
@@ -15,6 +23,18 @@ type App struct {
 	Providers     *providerfeature.Orchestrator
 	Models        modelinterfaces.ProviderModelInterface
 	Images        imageports.ImageInterface
+	Embeddings    embeddingports.EmbeddingInterface
+	Knowledge     knowledgeports.KnowledgeInterface
+	Rerank        rerankports.RerankInterface
+	Audio         audioports.AudioInterface
+	Moderation    moderationports.ModerationInterface
 	Chat          chatports.ChatInterface
+	MCP           mcpports.MCPInterface
 	Conversations *chatfeature.Orchestrator
+	ConfigWatch   ConfigWatcher // nil when no config file is in use
+}
+
+// ConfigWatcher reloads configuration from its file until the context is canceled.
+type ConfigWatcher interface {
+	Watch(ctx context.Context)
 }
@@ -0,0 +1,38 @@
+// config_watcher.go hot-reloads providers when the declarative config file changes.
+// internal/app/wire/config_watcher.go
+package wire
+
+import (
+	"context"
+
+	"github.com/MadeByDoug/wls-chatbot/internal/app"
+	config "github.com/MadeByDoug/wls-chatbot/internal/core/config"
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+)
+
+// configWatcher reloads the merged configuration into the provider registry on file changes.
+type configWatcher struct {
+	store  config.Store
+	source config.FileSource
+	reload func(config.AppConfig) error
+	logger corelogger.Logger
+}
+
+var _ app.ConfigWatcher = (*configWatcher)(nil)
+
+// Watch polls the config file until ctx is canceled; invalid edits keep the previous providers.
+func (w *configWatcher) Watch(ctx context.Context) {
+
+	fileField := corelogger.LogField{Key: "path", Value: w.source.Path}
+	config.WatchFile(ctx, w.store, w.source, config.DefaultWatchInterval, func(cfg config.AppConfig, err error) {
+		if err != nil {
+			w.logger.Warn("Ignoring invalid config file change", err, fileField)
+			return
+		}
+		if err := w.reload(cfg); err != nil {
+			w.logger.Error("Failed to reload providers from config file", err, fileField)
+			return
+		}
+		w.logger.Info("Reloaded providers from config file", fileField)
+	})
+}
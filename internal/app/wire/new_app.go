@@ -5,23 +5,42 @@ package wire
 import (
 	"database/sql"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/MadeByDoug/wls-chatbot/internal/app"
 	config "github.com/MadeByDoug/wls-chatbot/internal/core/config"
 	coreevents "github.com/MadeByDoug/wls-chatbot/internal/core/events"
 	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	"github.com/MadeByDoug/wls-chatbot/internal/features/ai/audio/adapters/chattranscriber"
+	audiofeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/audio/app/audio"
 	"github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/adapters/chatrepo"
 	chatfeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/app/chat"
+	chatdomain "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/domain"
+	embeddingfeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/embedding/app/embedding"
 	imageresolver "github.com/MadeByDoug/wls-chatbot/internal/features/ai/image/adapters/imageresolver"
 	imagefeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/image/app/image"
+	"github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/adapters/chatretriever"
+	"github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/adapters/docreader"
+	"github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/adapters/knowledgerepo"
+	knowledgefeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/app/knowledge"
+	mcpmodule "github.com/MadeByDoug/wls-chatbot/internal/features/ai/mcp"
+	"github.com/MadeByDoug/wls-chatbot/internal/features/ai/mcp/adapters/chattools"
+	"github.com/MadeByDoug/wls-chatbot/internal/features/ai/mcp/adapters/mcpclient"
+	mcpfeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/mcp/app/mcp"
+	modelcatalog "github.com/MadeByDoug/wls-chatbot/internal/features/ai/model/adapters/catalog"
 	modelio "github.com/MadeByDoug/wls-chatbot/internal/features/ai/model/adapters/io"
 	modelseeder "github.com/MadeByDoug/wls-chatbot/internal/features/ai/model/adapters/seeder"
 	modelfeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/model/app/model"
+	"github.com/MadeByDoug/wls-chatbot/internal/features/ai/moderation/adapters/chatmoderator"
+	moderationfeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/moderation/app/moderation"
 	providersmodule "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers"
 	providercache "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/cache"
+	credsource "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/credsource"
 	securestore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/secretstore"
 	providerfeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/app/provider"
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+	rerankfeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/rerank/app/rerank"
 	"github.com/rs/zerolog"
 )
 
@@ -33,6 +52,8 @@ type Dependencies struct {
 	AppName            string
 	KeyringServiceName string
 	Events             coreevents.Bus
+	Secrets            providercore.SecretStore // optional; selected from WLS_SECRET_* variables when nil
+	ConfigSource       config.FileSource        // optional; enables hot-reload of the declarative config file
 }
 
 // NewApp builds the single composition root for all application capabilities.
@@ -49,7 +70,14 @@ func NewApp(deps Dependencies) (*app.App, error) {
 	}
 
 	coreLog := corelogger.NewAdapter(deps.Log)
-	secrets := securestore.NewKeyringStore(deps.KeyringServiceName)
+	secrets := deps.Secrets
+	if secrets == nil {
+		selected, err := selectSecretStore(deps.AppName, deps.KeyringServiceName)
+		if err != nil {
+			return nil, err
+		}
+		secrets = selected
+	}
 
 	cache, err := providercache.NewSQLiteStore(deps.DB)
 	if err != nil {
@@ -60,10 +88,16 @@ func NewApp(deps Dependencies) (*app.App, error) {
 		return nil, err
 	}
 
-	providerService, registry, err := providersmodule.BuildProviderService(deps.Config, cache, secrets, configStore, coreLog)
+	credentialLayers, err := buildCredentialLayers()
+	if err != nil {
+		return nil, err
+	}
+
+	providerService, registry, err := providersmodule.BuildProviderService(deps.Config, cache, secrets, configStore, credentialLayers, coreLog)
 	if err != nil {
 		return nil, err
 	}
+	providerService.SetInstanceCloner(providersmodule.NewConfigInstanceCloner(configStore, deps.ConfigSource, secrets, credentialLayers, coreLog))
 
 	chatRepo, err := chatrepo.NewRepository(deps.DB)
 	if err != nil {
@@ -74,8 +108,41 @@ func NewApp(deps Dependencies) (*app.App, error) {
 
 	providerOrchestrator := providerfeature.NewOrchestrator(providerService, deps.Events)
 	conversationOrchestrator := chatfeature.NewOrchestrator(chatService, chatCompletionService, deps.Events)
+	policy, err := moderationPolicy(deps.Config)
+	if err != nil {
+		return nil, err
+	}
+	conversationOrchestrator.SetModerationPolicy(policy)
+	mcpService := mcpfeature.NewService(
+		mcpmodule.ServersFromConfig(deps.Config),
+		mcpclient.NewDialer(nil, deps.AppName, ""),
+		mcpmodule.NewConfigServerStore(configStore),
+		coreLog,
+	)
+	conversationOrchestrator.SetToolRunner(chattools.New(mcpService))
+
+	var watcher app.ConfigWatcher
+	if deps.ConfigSource.Path != "" {
+		watcher = &configWatcher{
+			store:  configStore,
+			source: deps.ConfigSource,
+			reload: func(cfg config.AppConfig) error {
+				policy, err := moderationPolicy(cfg)
+				if err != nil {
+					return err
+				}
+				if err := providersmodule.ReloadProviders(cfg, providerOrchestrator, secrets, credentialLayers, coreLog); err != nil {
+					return err
+				}
+				conversationOrchestrator.SetModerationPolicy(policy)
+				mcpService.Reload(mcpmodule.ServersFromConfig(cfg))
+				return nil
+			},
+			logger: coreLog,
+		}
+	}
 	modelService := modelfeature.NewModelService(
-		nil,
+		modelcatalog.NewDatastoreCatalog(deps.DB),
 		deps.DB,
 		deps.AppName,
 		modelio.NewLocalFileSystem(),
@@ -83,12 +150,93 @@ func NewApp(deps Dependencies) (*app.App, error) {
 		modelseeder.NewDatastoreSeeder(),
 	)
 	imageService := imagefeature.NewService(providerOrchestrator, imageresolver.NewHTTPResolver(nil))
+	embeddingService := embeddingfeature.NewService(providerOrchestrator)
+
+	knowledgeRepo, err := knowledgerepo.NewRepository(deps.DB)
+	if err != nil {
+		return nil, err
+	}
+	rerankService := rerankfeature.NewService(providerOrchestrator)
+	knowledgeService := knowledgefeature.NewService(knowledgeRepo, docreader.New(), embeddingService)
+	knowledgeService.SetReranker(rerankService)
+	conversationOrchestrator.SetKnowledgeRetriever(chatretriever.New(knowledgeService))
+	audioService := audiofeature.NewService(providerOrchestrator)
+	conversationOrchestrator.SetVoiceTranscriber(chattranscriber.New(audioService))
+	moderationService := moderationfeature.NewService(providerOrchestrator)
+	conversationOrchestrator.SetContentModerator(chatmoderator.New(moderationService))
 
 	return &app.App{
 		Providers:     providerOrchestrator,
 		Models:        modelService,
 		Images:        imageService,
+		Embeddings:    embeddingService,
+		Knowledge:     knowledgeService,
+		Rerank:        rerankService,
+		Audio:         audioService,
+		Moderation:    moderationService,
 		Chat:          chatCompletionService,
+		MCP:           mcpService,
 		Conversations: conversationOrchestrator,
+		ConfigWatch:   watcher,
+	}, nil
+}
+
+// moderationPolicy converts the moderation config section into the chat screening policy.
+func moderationPolicy(cfg config.AppConfig) (chatdomain.ModerationPolicy, error) {
+
+	if cfg.Moderation == nil {
+		return chatdomain.ModerationPolicy{}, nil
+	}
+	input, err := chatdomain.ParseModerationAction(cfg.Moderation.Input)
+	if err != nil {
+		return chatdomain.ModerationPolicy{}, fmt.Errorf("app wire: moderation input: %w", err)
+	}
+	output, err := chatdomain.ParseModerationAction(cfg.Moderation.Output)
+	if err != nil {
+		return chatdomain.ModerationPolicy{}, fmt.Errorf("app wire: moderation output: %w", err)
+	}
+	return chatdomain.ModerationPolicy{
+		Provider:   strings.TrimSpace(cfg.Moderation.Provider),
+		Model:      strings.TrimSpace(cfg.Moderation.Model),
+		Input:      input,
+		Output:     output,
+		Categories: append([]string(nil), cfg.Moderation.Categories...),
+	}, nil
+}
+
+// selectSecretStore picks the keyring or the encrypted file fallback from environment configuration.
+func selectSecretStore(appName, keyringServiceName string) (providercore.SecretStore, error) {
+
+	defaultPath, err := securestore.DefaultFilePath(appName)
+	if err != nil {
+		return nil, fmt.Errorf("app wire: %w", err)
+	}
+	options, err := securestore.OptionsFromEnv(keyringServiceName, defaultPath)
+	if err != nil {
+		return nil, fmt.Errorf("app wire: %w", err)
+	}
+	secrets, _, err := securestore.Select(options)
+	if err != nil {
+		return nil, fmt.Errorf("app wire: %w", err)
+	}
+	return secrets, nil
+}
+
+// buildCredentialLayers layers environment variables over the .env file named by WLS_ENV_FILE, if any,
+// and enables exec: references for config and secret store values.
+func buildCredentialLayers() (providerfeature.CredentialLayers, error) {
+
+	sources := []providercore.CredentialSource{credsource.NewEnvSource()}
+	if dotEnvPath := strings.TrimSpace(os.Getenv(credsource.EnvDotEnvFile)); dotEnvPath != "" {
+		dotEnv, err := credsource.NewDotEnvSource(dotEnvPath)
+		if err != nil {
+			return providerfeature.CredentialLayers{}, fmt.Errorf("app wire: %w", err)
+		}
+		sources = append(sources, dotEnv)
+	}
+
+	return providerfeature.CredentialLayers{
+		Sources: sources,
+		Runner:  credsource.NewExecRunner(credsource.DefaultExecTimeout),
 	}, nil
 }
@@ -5,6 +5,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -14,7 +15,19 @@ import (
 
 // AppConfig represents the root application configuration.
 type AppConfig struct {
-	Providers []ProviderConfig `json:"providers"`
+	Providers  []ProviderConfig  `json:"providers"`
+	Moderation *ModerationConfig `json:"moderation,omitempty"`
+	MCPServers []MCPServerConfig `json:"mcpServers,omitempty"`
+}
+
+// ModerationConfig selects the provider that screens chat input and output and what to do with flagged content.
+// Input and Output take off, warn, redact, or block; an empty Categories list acts on any flagged category.
+type ModerationConfig struct {
+	Provider   string   `json:"provider" yaml:"provider,omitempty"`
+	Model      string   `json:"model,omitempty" yaml:"model,omitempty"`
+	Input      string   `json:"input,omitempty" yaml:"input,omitempty"`
+	Output     string   `json:"output,omitempty" yaml:"output,omitempty"`
+	Categories []string `json:"categories,omitempty" yaml:"categories,omitempty"`
 }
 
 // UpdateFrequency describes how often provider resources are refreshed.
@@ -41,8 +54,60 @@ type ProviderConfig struct {
 
 // ModelConfig describes a provider model toggle in configuration.
 type ModelConfig struct {
-	ID      string `json:"id"`
-	Enabled bool   `json:"enabled"`
+	ID      string `json:"id" yaml:"id"`
+	Enabled bool   `json:"enabled" yaml:"enabled"`
+}
+
+// providerNamePattern matches provider instance names; it mirrors config.schema.json.
+var providerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// Provider returns the provider config with the given instance name.
+func (c AppConfig) Provider(name string) (ProviderConfig, bool) {
+
+	for _, provider := range c.Providers {
+		if provider.Name == name {
+			return provider, true
+		}
+	}
+	return ProviderConfig{}, false
+}
+
+// ValidateProviderName checks that name is a valid provider instance name.
+func ValidateProviderName(name string) error {
+
+	if !providerNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid provider name %q: use lowercase letters, digits, '.', '_' or '-'", name)
+	}
+	return nil
+}
+
+// CloneProvider returns cfg with a new instance copied from source: same type, base URL, models, and inputs.
+// Credentials are not part of the config and must be copied separately.
+func CloneProvider(cfg AppConfig, source, name, displayName string) (AppConfig, ProviderConfig, error) {
+
+	if err := ValidateProviderName(name); err != nil {
+		return AppConfig{}, ProviderConfig{}, err
+	}
+	original, ok := cfg.Provider(source)
+	if !ok {
+		return AppConfig{}, ProviderConfig{}, fmt.Errorf("provider not found: %s", source)
+	}
+	if _, exists := cfg.Provider(name); exists {
+		return AppConfig{}, ProviderConfig{}, fmt.Errorf("provider already exists: %s", name)
+	}
+
+	clone := cloneProviderConfig(original)
+	clone.Name = name
+	clone.DisplayName = strings.TrimSpace(displayName)
+	if clone.DisplayName == "" {
+		clone.DisplayName = original.DisplayName + " (" + name + ")"
+	}
+
+	updated := cfg
+	updated.Providers = make([]ProviderConfig, 0, len(cfg.Providers)+1)
+	updated.Providers = append(updated.Providers, cfg.Providers...)
+	updated.Providers = append(updated.Providers, clone)
+	return updated, clone, nil
 }
 
 // LoadConfig loads configuration from the provided store.
@@ -196,3 +196,47 @@ func TestLoadConfigPropagatesLoadErrors(t *testing.T) {
 		t.Fatalf("expected load error")
 	}
 }
+
+// TestCloneProviderCopiesSettingsUnderNewName verifies clones keep type, models, and inputs but get their own name.
+func TestCloneProviderCopiesSettingsUnderNewName(t *testing.T) {
+
+	cfg := AppConfig{Providers: []ProviderConfig{{
+		Type:        "openai",
+		Name:        "openai",
+		DisplayName: "OpenAI",
+		BaseURL:     "https://api.openai.com/v1",
+		Models:      []ModelConfig{{ID: "gpt-4o", Enabled: true}},
+		Inputs:      map[string]string{"organization": "org-1"},
+	}}}
+
+	updated, clone, err := CloneProvider(cfg, "openai", "openai-work", "")
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	if len(updated.Providers) != 2 || len(cfg.Providers) != 1 {
+		t.Fatalf("expected clone appended without mutating input, got %d/%d", len(updated.Providers), len(cfg.Providers))
+	}
+	if clone.Type != "openai" || clone.Name != "openai-work" || clone.DisplayName != "OpenAI (openai-work)" || clone.BaseURL != cfg.Providers[0].BaseURL {
+		t.Fatalf("unexpected clone %#v", clone)
+	}
+	clone.Inputs["organization"] = "org-2"
+	clone.Models[0].Enabled = false
+	if cfg.Providers[0].Inputs["organization"] != "org-1" || !cfg.Providers[0].Models[0].Enabled {
+		t.Fatalf("clone shares inputs or models with source")
+	}
+
+	testCases := []struct {
+		name   string
+		source string
+		target string
+	}{
+		{name: "missing source", source: "gemini", target: "gemini-2"},
+		{name: "existing target", source: "openai", target: "openai"},
+		{name: "invalid target", source: "openai", target: "Work Account"},
+	}
+	for _, testCase := range testCases {
+		if _, _, err := CloneProvider(cfg, testCase.source, testCase.target, ""); err == nil {
+			t.Fatalf("%s: expected error", testCase.name)
+		}
+	}
+}
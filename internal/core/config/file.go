@@ -0,0 +1,318 @@
+// file.go loads declarative YAML config files with profiles and merges them over stored configuration.
+// internal/core/config/file.go
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables that select the config file and profile when flags are not given.
+const (
+	EnvConfigFile    = "WLS_CONFIG"
+	EnvConfigProfile = "WLS_PROFILE"
+)
+
+// DefaultConfigFileName is the config file looked up in the app data directory.
+const DefaultConfigFileName = "config.yaml"
+
+// FileSource identifies a config file and the profile applied from it; an empty Path disables the file.
+type FileSource struct {
+	Path    string
+	Profile string
+}
+
+// FileConfig is the declarative config file layout; see config.schema.json.
+type FileConfig struct {
+	Schema     string                 `yaml:"$schema,omitempty"`
+	Defaults   FileDefaults           `yaml:"defaults,omitempty"`
+	Providers  []FileProvider         `yaml:"providers,omitempty"`
+	Moderation *ModerationConfig      `yaml:"moderation,omitempty"`
+	MCPServers []MCPServerConfig      `yaml:"mcpServers,omitempty"`
+	Profiles   map[string]FileProfile `yaml:"profiles,omitempty"`
+}
+
+// FileDefaults holds values applied to providers that do not set them.
+type FileDefaults struct {
+	UpdateFrequency UpdateFrequency `yaml:"updateFrequency,omitempty"`
+}
+
+// FileProvider declares a provider; empty fields keep the stored value.
+type FileProvider struct {
+	Name            string            `yaml:"name"`
+	Type            string            `yaml:"type,omitempty"`
+	DisplayName     string            `yaml:"displayName,omitempty"`
+	BaseURL         string            `yaml:"baseUrl,omitempty"`
+	DefaultModel    string            `yaml:"defaultModel,omitempty"`
+	UpdateFrequency UpdateFrequency   `yaml:"updateFrequency,omitempty"`
+	Models          []ModelConfig     `yaml:"models,omitempty"`
+	Inputs          map[string]string `yaml:"inputs,omitempty"`
+}
+
+// FileProfile overrides defaults, providers, moderation, and MCP servers when selected.
+type FileProfile struct {
+	Defaults   FileDefaults      `yaml:"defaults,omitempty"`
+	Providers  []FileProvider    `yaml:"providers,omitempty"`
+	Moderation *ModerationConfig `yaml:"moderation,omitempty"`
+	MCPServers []MCPServerConfig `yaml:"mcpServers,omitempty"`
+}
+
+// ResolveFileSource picks the config file from the flag, WLS_CONFIG, or appDataDir/config.yaml when it exists,
+// and the profile from the flag or WLS_PROFILE.
+func ResolveFileSource(pathFlag, profileFlag, appDataDir string) FileSource {
+
+	source := FileSource{
+		Path:    strings.TrimSpace(pathFlag),
+		Profile: strings.TrimSpace(profileFlag),
+	}
+	if source.Path == "" {
+		source.Path = strings.TrimSpace(os.Getenv(EnvConfigFile))
+	}
+	if source.Path == "" && appDataDir != "" {
+		candidate := filepath.Join(appDataDir, DefaultConfigFileName)
+		if _, err := os.Stat(candidate); err == nil {
+			source.Path = candidate
+		}
+	}
+	if source.Profile == "" {
+		source.Profile = strings.TrimSpace(os.Getenv(EnvConfigProfile))
+	}
+	return source
+}
+
+// LoadFile reads a YAML (or JSON) config file and validates it against the schema.
+func LoadFile(path string) (FileConfig, error) {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return FileConfig{}, fmt.Errorf("config file: %w", err)
+	}
+	return ParseFile(data)
+}
+
+// ParseFile validates and decodes config file contents.
+func ParseFile(data []byte) (FileConfig, error) {
+
+	var document any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return FileConfig{}, fmt.Errorf("config file: parse: %w", err)
+	}
+	if document == nil {
+		return FileConfig{}, nil
+	}
+	if err := ValidateDocument(document); err != nil {
+		return FileConfig{}, err
+	}
+
+	var file FileConfig
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return FileConfig{}, fmt.Errorf("config file: decode: %w", err)
+	}
+	if err := file.validateInputs(); err != nil {
+		return FileConfig{}, err
+	}
+	return file, nil
+}
+
+// ProfileNames returns the declared profile names in sorted order.
+func (f FileConfig) ProfileNames() []string {
+
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateInputs rejects secret-like inputs, which must come from the secret store or env.
+func (f FileConfig) validateInputs() error {
+
+	check := func(providers []FileProvider, scope string) error {
+		for _, provider := range providers {
+			for key := range provider.Inputs {
+				if providercore.IsSensitiveCredentialName(key) {
+					return fmt.Errorf("config file: %sprovider %s input %q is secret; use the secret store, an env var, or an exec: reference", scope, provider.Name, key)
+				}
+			}
+		}
+		return nil
+	}
+	if err := check(f.Providers, ""); err != nil {
+		return err
+	}
+	for _, name := range f.ProfileNames() {
+		if err := check(f.Profiles[name].Providers, "profile "+name+" "); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Merge overlays the file's base section and the selected profile onto stored configuration.
+// Providers match by name; file values win, stored values fill gaps, and file-only providers are appended.
+func Merge(base AppConfig, file FileConfig, profile string) (AppConfig, error) {
+
+	defaults := file.Defaults
+	overlays := append([]FileProvider(nil), file.Providers...)
+	moderation := applyFileModeration(base.Moderation, file.Moderation)
+	mcpServers := applyFileMCPServers(base.MCPServers, file.MCPServers)
+	if profile != "" {
+		selected, ok := file.Profiles[profile]
+		if !ok {
+			return AppConfig{}, fmt.Errorf("config file: unknown profile %q (available: %s)", profile, strings.Join(file.ProfileNames(), ", "))
+		}
+		if selected.Defaults.UpdateFrequency != "" {
+			defaults.UpdateFrequency = selected.Defaults.UpdateFrequency
+		}
+		overlays = append(overlays, selected.Providers...)
+		moderation = applyFileModeration(moderation, selected.Moderation)
+		mcpServers = applyFileMCPServers(mcpServers, selected.MCPServers)
+	}
+
+	merged := base
+	merged.Providers = make([]ProviderConfig, 0, len(base.Providers)+len(overlays))
+	merged.Moderation = moderation
+	merged.MCPServers = mcpServers
+	indexByName := make(map[string]int, len(base.Providers))
+	for _, provider := range base.Providers {
+		indexByName[provider.Name] = len(merged.Providers)
+		merged.Providers = append(merged.Providers, cloneProviderConfig(provider))
+	}
+
+	for _, overlay := range overlays {
+		index, ok := indexByName[overlay.Name]
+		if !ok {
+			if strings.TrimSpace(overlay.Type) == "" {
+				return AppConfig{}, fmt.Errorf("config file: provider %s is not stored yet and needs a type", overlay.Name)
+			}
+			index = len(merged.Providers)
+			indexByName[overlay.Name] = index
+			merged.Providers = append(merged.Providers, ProviderConfig{Name: overlay.Name})
+		}
+		applyFileProvider(&merged.Providers[index], overlay)
+	}
+
+	if defaults.UpdateFrequency != "" {
+		for index := range merged.Providers {
+			if merged.Providers[index].UpdateFrequency == "" {
+				merged.Providers[index].UpdateFrequency = defaults.UpdateFrequency
+			}
+		}
+	}
+	if moderation != nil && moderation.Provider != "" {
+		if _, ok := merged.Provider(moderation.Provider); !ok {
+			return AppConfig{}, fmt.Errorf("config file: moderation provider %s is not configured", moderation.Provider)
+		}
+	}
+	for _, server := range merged.MCPServers {
+		if err := ValidateMCPServer(server); err != nil {
+			return AppConfig{}, fmt.Errorf("config file: %w", err)
+		}
+	}
+	return merged, nil
+}
+
+// LoadWithFile loads stored configuration and merges the config file when source names one.
+func LoadWithFile(store Store, source FileSource) (AppConfig, error) {
+
+	cfg, err := LoadConfig(store)
+	if err != nil {
+		return AppConfig{}, err
+	}
+	if source.Path == "" {
+		if source.Profile != "" {
+			return AppConfig{}, errors.New("config file: profile selected but no config file found")
+		}
+		return cfg, nil
+	}
+
+	file, err := LoadFile(source.Path)
+	if err != nil {
+		return AppConfig{}, err
+	}
+	return Merge(cfg, file, source.Profile)
+}
+
+// applyFileProvider copies non-empty overlay fields onto a provider config.
+func applyFileProvider(target *ProviderConfig, overlay FileProvider) {
+
+	if overlay.Type != "" {
+		target.Type = overlay.Type
+	}
+	if overlay.DisplayName != "" {
+		target.DisplayName = overlay.DisplayName
+	}
+	if overlay.BaseURL != "" {
+		target.BaseURL = overlay.BaseURL
+	}
+	if overlay.DefaultModel != "" {
+		target.DefaultModel = overlay.DefaultModel
+	}
+	if overlay.UpdateFrequency != "" {
+		target.UpdateFrequency = overlay.UpdateFrequency
+	}
+	if overlay.Models != nil {
+		target.Models = append([]ModelConfig(nil), overlay.Models...)
+	}
+	if len(overlay.Inputs) > 0 {
+		if target.Inputs == nil {
+			target.Inputs = make(map[string]string, len(overlay.Inputs))
+		}
+		for key, value := range overlay.Inputs {
+			target.Inputs[key] = value
+		}
+	}
+	if target.DisplayName == "" {
+		target.DisplayName = target.Name
+	}
+}
+
+// applyFileModeration returns a copy of base with non-empty overlay fields applied.
+func applyFileModeration(base, overlay *ModerationConfig) *ModerationConfig {
+
+	if base == nil && overlay == nil {
+		return nil
+	}
+	merged := ModerationConfig{}
+	if base != nil {
+		merged = *base
+		merged.Categories = append([]string(nil), base.Categories...)
+	}
+	if overlay == nil {
+		return &merged
+	}
+	if overlay.Provider != "" {
+		merged.Provider = overlay.Provider
+	}
+	if overlay.Model != "" {
+		merged.Model = overlay.Model
+	}
+	if overlay.Input != "" {
+		merged.Input = overlay.Input
+	}
+	if overlay.Output != "" {
+		merged.Output = overlay.Output
+	}
+	if overlay.Categories != nil {
+		merged.Categories = append([]string(nil), overlay.Categories...)
+	}
+	return &merged
+}
+
+// cloneProviderConfig copies slices and maps so merges do not mutate stored config.
+func cloneProviderConfig(provider ProviderConfig) ProviderConfig {
+
+	provider.Models = append([]ModelConfig(nil), provider.Models...)
+	if provider.Inputs != nil {
+		provider.Inputs = cloneInputs(provider.Inputs)
+	}
+	return provider
+}
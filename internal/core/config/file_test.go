@@ -0,0 +1,325 @@
+// file_test.go verifies config file schema validation, profiles, merging, and watching.
+// internal/core/config/file_test.go
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleConfigFile = `
+$schema: ./config.schema.json
+defaults:
+  updateFrequency: daily
+providers:
+  - name: openai
+    defaultModel: gpt-4o
+    models:
+      - id: gpt-4o
+        enabled: true
+  - name: local-cf
+    type: cloudflare
+    inputs:
+      account_id: acct-base
+profiles:
+  work:
+    defaults:
+      updateFrequency: weekly
+    providers:
+      - name: local-cf
+        inputs:
+          account_id: acct-work
+  personal: {}
+`
+
+// TestSchemaIsValidJSON verifies the published schema parses.
+func TestSchemaIsValidJSON(t *testing.T) {
+
+	var schema map[string]any
+	if err := json.Unmarshal(Schema(), &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	if schema["$defs"] == nil {
+		t.Fatalf("expected $defs in schema")
+	}
+}
+
+// TestParseFileReportsSchemaIssues verifies violations are reported with JSON pointer paths.
+func TestParseFileReportsSchemaIssues(t *testing.T) {
+
+	testCases := []struct {
+		name     string
+		content  string
+		path     string
+		contains string
+	}{
+		{name: "unknown root key", content: "provider: []", path: "/provider", contains: "unknown property"},
+		{name: "missing name", content: "providers:\n  - type: openai", path: "/providers/0", contains: `"name"`},
+		{name: "bad frequency", content: "defaults:\n  updateFrequency: often", path: "/defaults/updateFrequency", contains: "one of"},
+		{name: "bad type", content: "providers:\n  - name: x\n    type: nope", path: "/providers/0/type", contains: "one of"},
+		{name: "bad name pattern", content: "providers:\n  - name: Bad Name", path: "/providers/0/name", contains: "must match"},
+		{name: "wrong model type", content: "providers:\n  - name: x\n    models:\n      - id: m\n        enabled: 1", path: "/providers/0/models/0/enabled", contains: "expected boolean"},
+		{name: "profile key", content: "profiles:\n  work:\n    extra: true", path: "/profiles/work/extra", contains: "unknown property"},
+		{name: "bad moderation action", content: "moderation:\n  provider: openai\n  output: delete", path: "/moderation/output", contains: "one of"},
+		{name: "bad mcp transport", content: "mcpServers:\n  - name: files\n    transport: sse", path: "/mcpServers/0/transport", contains: "one of"},
+	}
+	for _, testCase := range testCases {
+		_, err := ParseFile([]byte(testCase.content))
+		var schemaErr *SchemaError
+		if !errors.As(err, &schemaErr) {
+			t.Fatalf("%s: expected schema error, got %v", testCase.name, err)
+		}
+		found := false
+		for _, issue := range schemaErr.Issues {
+			if issue.Path == testCase.path && strings.Contains(issue.Message, testCase.contains) {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("%s: expected issue at %s containing %q, got %v", testCase.name, testCase.path, testCase.contains, schemaErr.Issues)
+		}
+	}
+
+	if _, err := ParseFile([]byte("providers:\n  - name: x\n    inputs:\n      api_key: sk")); err == nil || !strings.Contains(err.Error(), "secret") {
+		t.Fatalf("expected secret input to be rejected, got %v", err)
+	}
+}
+
+// TestMergeAppliesProfilesOverStoredConfig verifies file values, profile overrides, defaults, and stored fallbacks.
+func TestMergeAppliesProfilesOverStoredConfig(t *testing.T) {
+
+	file, err := ParseFile([]byte(sampleConfigFile))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	base := AppConfig{Providers: []ProviderConfig{
+		{Type: "openai", Name: "openai", DisplayName: "OpenAI", DefaultModel: "gpt-4.1", UpdateFrequency: UpdateFrequencyHourly, Inputs: map[string]string{"region": "us"}},
+		{Type: "gemini", Name: "gemini", DisplayName: "Gemini"},
+	}}
+
+	merged, err := Merge(base, file, "")
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if len(merged.Providers) != 3 {
+		t.Fatalf("expected stored providers plus file-only provider, got %d", len(merged.Providers))
+	}
+	openai := merged.Providers[0]
+	if openai.DefaultModel != "gpt-4o" || openai.UpdateFrequency != UpdateFrequencyHourly || openai.Inputs["region"] != "us" || len(openai.Models) != 1 {
+		t.Fatalf("unexpected merged openai %#v", openai)
+	}
+	if merged.Providers[1].UpdateFrequency != UpdateFrequencyDaily {
+		t.Fatalf("expected default frequency on stored provider, got %q", merged.Providers[1].UpdateFrequency)
+	}
+	cloudflare := merged.Providers[2]
+	if cloudflare.Type != "cloudflare" || cloudflare.DisplayName != "local-cf" || cloudflare.Inputs["account_id"] != "acct-base" {
+		t.Fatalf("unexpected file-only provider %#v", cloudflare)
+	}
+	if base.Providers[0].DefaultModel != "gpt-4.1" {
+		t.Fatalf("merge mutated stored config")
+	}
+
+	work, err := Merge(base, file, "work")
+	if err != nil {
+		t.Fatalf("merge work: %v", err)
+	}
+	if work.Providers[2].Inputs["account_id"] != "acct-work" || work.Providers[1].UpdateFrequency != UpdateFrequencyWeekly {
+		t.Fatalf("expected work profile overrides, got %#v", work.Providers)
+	}
+
+	if _, err := Merge(base, file, "travel"); err == nil || !strings.Contains(err.Error(), "personal, work") {
+		t.Fatalf("expected unknown profile error listing profiles, got %v", err)
+	}
+	if _, err := Merge(base, FileConfig{Providers: []FileProvider{{Name: "new"}}}, ""); err == nil {
+		t.Fatalf("expected new provider without type to fail")
+	}
+}
+
+// TestMergeAppliesModerationOverrides verifies file and profile moderation settings layer over stored settings.
+func TestMergeAppliesModerationOverrides(t *testing.T) {
+
+	file, err := ParseFile([]byte(`
+moderation:
+  provider: openai
+  input: block
+  categories: [hate]
+profiles:
+  strict:
+    moderation:
+      output: redact
+  edge:
+    moderation:
+      provider: local-cf
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	base := AppConfig{
+		Providers:  []ProviderConfig{{Type: "openai", Name: "openai"}},
+		Moderation: &ModerationConfig{Provider: "openai", Model: "omni-moderation-latest", Output: "warn"},
+	}
+
+	merged, err := Merge(base, file, "strict")
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	moderation := merged.Moderation
+	if moderation == nil || moderation.Model != "omni-moderation-latest" || moderation.Input != "block" || moderation.Output != "redact" || strings.Join(moderation.Categories, ",") != "hate" {
+		t.Fatalf("unexpected merged moderation %#v", moderation)
+	}
+	if base.Moderation.Output != "warn" {
+		t.Fatalf("merge mutated stored moderation")
+	}
+
+	if _, err := Merge(base, file, "edge"); err == nil || !strings.Contains(err.Error(), "moderation provider local-cf") {
+		t.Fatalf("expected unknown moderation provider error, got %v", err)
+	}
+	unset, err := Merge(AppConfig{}, FileConfig{}, "")
+	if err != nil || unset.Moderation != nil {
+		t.Fatalf("expected no moderation without settings, got %#v (%v)", unset.Moderation, err)
+	}
+}
+
+// TestMergeAppliesMCPServerOverrides verifies file and profile MCP servers replace stored servers by name.
+func TestMergeAppliesMCPServerOverrides(t *testing.T) {
+
+	file, err := ParseFile([]byte(`
+mcpServers:
+  - name: files
+    transport: stdio
+    command: mcp-files
+    args: [--root, /srv]
+profiles:
+  remote:
+    mcpServers:
+      - name: search
+        transport: http
+        url: https://mcp.example.com/mcp
+  broken:
+    mcpServers:
+      - name: files
+        transport: http
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	base := AppConfig{MCPServers: []MCPServerConfig{
+		{Name: "files", Transport: MCPTransportStdio, Command: "old-files"},
+		{Name: "git", Transport: MCPTransportStdio, Command: "mcp-git"},
+	}}
+
+	merged, err := Merge(base, file, "remote")
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if len(merged.MCPServers) != 3 || merged.MCPServers[0].Command != "mcp-files" || strings.Join(merged.MCPServers[0].Args, " ") != "--root /srv" || merged.MCPServers[2].URL != "https://mcp.example.com/mcp" {
+		t.Fatalf("unexpected merged servers %#v", merged.MCPServers)
+	}
+	if base.MCPServers[0].Command != "old-files" {
+		t.Fatalf("merge mutated stored servers")
+	}
+
+	if _, err := Merge(base, file, "broken"); err == nil || !strings.Contains(err.Error(), "http(s) url") {
+		t.Fatalf("expected invalid server error, got %v", err)
+	}
+}
+
+// TestResolveFileSourcePrecedence verifies flag, env, and app data dir lookup order.
+func TestResolveFileSourcePrecedence(t *testing.T) {
+
+	dir := t.TempDir()
+	t.Setenv(EnvConfigFile, "")
+	t.Setenv(EnvConfigProfile, "")
+
+	if source := ResolveFileSource("", "", dir); source.Path != "" {
+		t.Fatalf("expected no file when default is absent, got %q", source.Path)
+	}
+	defaultPath := filepath.Join(dir, DefaultConfigFileName)
+	if err := os.WriteFile(defaultPath, []byte("{}"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if source := ResolveFileSource("", "", dir); source.Path != defaultPath {
+		t.Fatalf("expected default path, got %q", source.Path)
+	}
+
+	t.Setenv(EnvConfigFile, "/env/config.yaml")
+	t.Setenv(EnvConfigProfile, "personal")
+	source := ResolveFileSource("", "", dir)
+	if source.Path != "/env/config.yaml" || source.Profile != "personal" {
+		t.Fatalf("expected env source, got %#v", source)
+	}
+	source = ResolveFileSource("/flag.yaml", "work", dir)
+	if source.Path != "/flag.yaml" || source.Profile != "work" {
+		t.Fatalf("expected flag source, got %#v", source)
+	}
+}
+
+// TestWatchFileReloadsOnChange verifies edits trigger a merged reload and invalid edits surface errors.
+func TestWatchFileReloadsOnChange(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("providers:\n  - name: openai\n    defaultModel: a\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	store := &stubStore{cfg: AppConfig{Providers: []ProviderConfig{{Type: "openai", Name: "openai"}}}}
+
+	type reload struct {
+		cfg AppConfig
+		err error
+	}
+	reloads := make(chan reload, 4)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		WatchFile(ctx, store, FileSource{Path: path}, 5*time.Millisecond, func(cfg AppConfig, err error) {
+			select {
+			case reloads <- reload{cfg: cfg, err: err}:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// The watcher takes its baseline asynchronously, so keep editing until it reports a matching reload.
+	editUntilReload := func(content func(attempt int) string, matches func(reload) bool) reload {
+		t.Helper()
+		deadline := time.After(2 * time.Second)
+		for attempt := 0; ; attempt++ {
+			if err := os.WriteFile(path, []byte(content(attempt)), 0o600); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			select {
+			case got := <-reloads:
+				if matches(got) {
+					return got
+				}
+			case <-deadline:
+				t.Fatalf("timed out waiting for reload")
+				return reload{}
+			case <-time.After(20 * time.Millisecond):
+			}
+		}
+	}
+
+	got := editUntilReload(func(attempt int) string {
+		return "providers:\n  - name: openai\n    defaultModel: b" + strconv.Itoa(attempt) + "\n"
+	}, func(got reload) bool { return true })
+	if got.err != nil || !strings.HasPrefix(got.cfg.Providers[0].DefaultModel, "b") {
+		t.Fatalf("expected reload with edited model, got %#v", got)
+	}
+
+	editUntilReload(func(attempt int) string {
+		return "providers: nope" + strconv.Itoa(attempt) + "\n"
+	}, func(got reload) bool { return got.err != nil })
+}
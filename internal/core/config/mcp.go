@@ -0,0 +1,131 @@
+// mcp.go defines Model Context Protocol server configuration and helpers.
+// internal/core/config/mcp.go
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// MCP server transports.
+const (
+	MCPTransportStdio = "stdio"
+	MCPTransportHTTP  = "http"
+)
+
+// MCPServerConfig declares an MCP server whose tools are offered to chat models.
+// Stdio servers are launched from Command; http servers are reached at URL using the streamable HTTP transport.
+type MCPServerConfig struct {
+	Name      string            `json:"name" yaml:"name"`
+	Transport string            `json:"transport" yaml:"transport"`
+	Command   string            `json:"command,omitempty" yaml:"command,omitempty"`
+	Args      []string          `json:"args,omitempty" yaml:"args,omitempty"`
+	Env       map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+	URL       string            `json:"url,omitempty" yaml:"url,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Disabled  bool              `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+// mcpServerNamePattern matches MCP server names; names prefix tool names, so '.' is not allowed. It mirrors config.schema.json.
+var mcpServerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// MCPServer returns the MCP server config with the given name.
+func (c AppConfig) MCPServer(name string) (MCPServerConfig, bool) {
+
+	for _, server := range c.MCPServers {
+		if server.Name == name {
+			return server, true
+		}
+	}
+	return MCPServerConfig{}, false
+}
+
+// ValidateMCPServer checks the server name and that the transport has its required target.
+func ValidateMCPServer(server MCPServerConfig) error {
+
+	if !mcpServerNamePattern.MatchString(server.Name) {
+		return fmt.Errorf("invalid MCP server name %q: use lowercase letters, digits, '_' or '-'", server.Name)
+	}
+	switch server.Transport {
+	case MCPTransportStdio:
+		if strings.TrimSpace(server.Command) == "" {
+			return fmt.Errorf("MCP server %s: command required for stdio transport", server.Name)
+		}
+	case MCPTransportHTTP:
+		parsed, err := url.Parse(strings.TrimSpace(server.URL))
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("MCP server %s: http transport needs an http(s) url", server.Name)
+		}
+	default:
+		return fmt.Errorf("MCP server %s: unsupported transport %q (use %s or %s)", server.Name, server.Transport, MCPTransportStdio, MCPTransportHTTP)
+	}
+	return nil
+}
+
+// AddMCPServer returns cfg with server appended; names must be unique.
+func AddMCPServer(cfg AppConfig, server MCPServerConfig) (AppConfig, error) {
+
+	if err := ValidateMCPServer(server); err != nil {
+		return AppConfig{}, err
+	}
+	if _, exists := cfg.MCPServer(server.Name); exists {
+		return AppConfig{}, fmt.Errorf("MCP server already exists: %s", server.Name)
+	}
+
+	updated := cfg
+	updated.MCPServers = make([]MCPServerConfig, 0, len(cfg.MCPServers)+1)
+	updated.MCPServers = append(updated.MCPServers, cfg.MCPServers...)
+	updated.MCPServers = append(updated.MCPServers, cloneMCPServerConfig(server))
+	return updated, nil
+}
+
+// RemoveMCPServer returns cfg without the named server.
+func RemoveMCPServer(cfg AppConfig, name string) (AppConfig, error) {
+
+	updated := cfg
+	updated.MCPServers = make([]MCPServerConfig, 0, len(cfg.MCPServers))
+	for _, server := range cfg.MCPServers {
+		if server.Name != name {
+			updated.MCPServers = append(updated.MCPServers, server)
+		}
+	}
+	if len(updated.MCPServers) == len(cfg.MCPServers) {
+		return AppConfig{}, fmt.Errorf("MCP server not found: %s", name)
+	}
+	return updated, nil
+}
+
+// applyFileMCPServers returns base with overlay servers replacing same-named entries and new ones appended.
+func applyFileMCPServers(base, overlay []MCPServerConfig) []MCPServerConfig {
+
+	if len(base) == 0 && len(overlay) == 0 {
+		return nil
+	}
+	merged := make([]MCPServerConfig, 0, len(base)+len(overlay))
+	indexByName := make(map[string]int, len(base)+len(overlay))
+	for _, server := range base {
+		indexByName[server.Name] = len(merged)
+		merged = append(merged, cloneMCPServerConfig(server))
+	}
+	for _, server := range overlay {
+		if index, ok := indexByName[server.Name]; ok {
+			merged[index] = cloneMCPServerConfig(server)
+			continue
+		}
+		indexByName[server.Name] = len(merged)
+		merged = append(merged, cloneMCPServerConfig(server))
+	}
+	return merged
+}
+
+// cloneMCPServerConfig copies slices and maps so merges do not mutate stored config.
+func cloneMCPServerConfig(server MCPServerConfig) MCPServerConfig {
+
+	clone := server
+	clone.Args = append([]string(nil), server.Args...)
+	clone.Env = cloneInputs(server.Env)
+	clone.Headers = cloneInputs(server.Headers)
+	return clone
+}
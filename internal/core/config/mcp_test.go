@@ -0,0 +1,70 @@
+// mcp_test.go verifies MCP server configuration helpers.
+// internal/core/config/mcp_test.go
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestValidateMCPServerChecksTransportTargets validates names and per-transport requirements.
+func TestValidateMCPServerChecksTransportTargets(t *testing.T) {
+
+	testCases := []struct {
+		name   string
+		server MCPServerConfig
+		want   string
+	}{
+		{name: "stdio", server: MCPServerConfig{Name: "files", Transport: MCPTransportStdio, Command: "mcp-files"}},
+		{name: "http", server: MCPServerConfig{Name: "search", Transport: MCPTransportHTTP, URL: "http://127.0.0.1:9000/mcp"}},
+		{name: "dotted name", server: MCPServerConfig{Name: "my.files", Transport: MCPTransportStdio, Command: "x"}, want: "invalid MCP server name"},
+		{name: "no command", server: MCPServerConfig{Name: "files", Transport: MCPTransportStdio}, want: "command required"},
+		{name: "relative url", server: MCPServerConfig{Name: "search", Transport: MCPTransportHTTP, URL: "/mcp"}, want: "http(s) url"},
+		{name: "unknown transport", server: MCPServerConfig{Name: "search", Transport: "sse"}, want: "unsupported transport"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ValidateMCPServer(testCase.server)
+			if testCase.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), testCase.want) {
+				t.Fatalf("expected %q, got %v", testCase.want, err)
+			}
+		})
+	}
+}
+
+// TestAddAndRemoveMCPServer validates uniqueness, copy semantics, and removal of unknown names.
+func TestAddAndRemoveMCPServer(t *testing.T) {
+
+	base := AppConfig{Providers: []ProviderConfig{{Name: "openai"}}}
+	server := MCPServerConfig{Name: "files", Transport: MCPTransportStdio, Command: "mcp-files", Env: map[string]string{"ROOT": "/srv"}}
+
+	added, err := AddMCPServer(base, server)
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	server.Env["ROOT"] = "/changed"
+	if stored, ok := added.MCPServer("files"); !ok || stored.Env["ROOT"] != "/srv" || len(added.Providers) != 1 {
+		t.Fatalf("unexpected config %#v", added)
+	}
+	if len(base.MCPServers) != 0 {
+		t.Fatalf("add mutated base config")
+	}
+	if _, err := AddMCPServer(added, server); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected duplicate error, got %v", err)
+	}
+
+	removed, err := RemoveMCPServer(added, "files")
+	if err != nil || len(removed.MCPServers) != 0 {
+		t.Fatalf("unexpected removal %#v (%v)", removed.MCPServers, err)
+	}
+	if _, err := RemoveMCPServer(removed, "files"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,294 @@
+// schema.go validates config file documents against the published JSON Schema.
+// internal/core/config/schema.go
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// schemaDocument is the published JSON Schema for config files.
+//
+//go:embed config.schema.json
+var schemaDocument []byte
+
+var (
+	schemaOnce   sync.Once
+	parsedSchema map[string]any
+	schemaErr    error
+)
+
+// SchemaIssue describes one schema violation at a JSON pointer path.
+type SchemaIssue struct {
+	Path    string
+	Message string
+}
+
+// SchemaError lists every schema violation found in a document.
+type SchemaError struct {
+	Issues []SchemaIssue
+}
+
+// Error joins the issues into one message.
+func (e *SchemaError) Error() string {
+
+	lines := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		lines = append(lines, issue.Path+": "+issue.Message)
+	}
+	return "config schema: " + strings.Join(lines, "; ")
+}
+
+// Schema returns the published JSON Schema document.
+func Schema() []byte {
+
+	return append([]byte(nil), schemaDocument...)
+}
+
+// ValidateDocument checks a decoded YAML or JSON document against the schema.
+// It implements the keywords the schema uses: $ref, type, properties, required,
+// additionalProperties, items, enum, minLength and pattern.
+func ValidateDocument(document any) error {
+
+	schemaOnce.Do(func() {
+		schemaErr = json.Unmarshal(schemaDocument, &parsedSchema)
+	})
+	if schemaErr != nil {
+		return fmt.Errorf("config schema: parse: %w", schemaErr)
+	}
+
+	validator := schemaValidator{root: parsedSchema}
+	validator.validate(parsedSchema, document, "")
+	if len(validator.issues) == 0 {
+		return nil
+	}
+	return &SchemaError{Issues: validator.issues}
+}
+
+// schemaValidator walks a document alongside schema nodes, collecting issues.
+type schemaValidator struct {
+	root   map[string]any
+	issues []SchemaIssue
+}
+
+// validate checks value against schema at path.
+func (v *schemaValidator) validate(schema map[string]any, value any, path string) {
+
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved, err := v.resolve(ref)
+		if err != nil {
+			v.fail(path, err.Error())
+			return
+		}
+		schema = resolved
+	}
+
+	if expected, ok := schema["type"].(string); ok && !matchesSchemaType(expected, value) {
+		v.fail(path, fmt.Sprintf("expected %s, got %s", expected, describeSchemaValue(value)))
+		return
+	}
+
+	if options, ok := schema["enum"].([]any); ok && !containsSchemaValue(options, value) {
+		v.fail(path, fmt.Sprintf("must be one of %s", formatSchemaEnum(options)))
+	}
+
+	if text, ok := value.(string); ok {
+		if minLength, ok := schema["minLength"].(float64); ok && float64(len(text)) < minLength {
+			v.fail(path, fmt.Sprintf("must be at least %d characters", int(minLength)))
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if matched, err := regexp.MatchString(pattern, text); err != nil || !matched {
+				v.fail(path, fmt.Sprintf("must match %s", pattern))
+			}
+		}
+	}
+
+	if object, ok := asSchemaObject(value); ok {
+		v.validateObject(schema, object, path)
+	}
+
+	if items, ok := value.([]any); ok {
+		if itemSchema, ok := schema["items"].(map[string]any); ok {
+			for index, item := range items {
+				v.validate(itemSchema, item, path+"/"+strconv.Itoa(index))
+			}
+		}
+	}
+}
+
+// validateObject checks required, properties and additionalProperties.
+func (v *schemaValidator) validateObject(schema map[string]any, object map[string]any, path string) {
+
+	if required, ok := schema["required"].([]any); ok {
+		for _, name := range required {
+			key, _ := name.(string)
+			if _, present := object[key]; !present {
+				v.fail(path, fmt.Sprintf("missing required property %q", key))
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := path + "/" + escapeSchemaPointer(key)
+		if propertySchema, ok := properties[key].(map[string]any); ok {
+			v.validate(propertySchema, object[key], childPath)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.fail(childPath, "unknown property")
+			}
+		case map[string]any:
+			v.validate(additional, object[key], childPath)
+		}
+	}
+}
+
+// resolve follows a local "#/..." reference.
+func (v *schemaValidator) resolve(ref string) (map[string]any, error) {
+
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported $ref %q", ref)
+	}
+	var node any = v.root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		object, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolved $ref %q", ref)
+		}
+		node = object[part]
+	}
+	resolved, ok := node.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unresolved $ref %q", ref)
+	}
+	return resolved, nil
+}
+
+// fail records a schema issue; the document root is reported as "/".
+func (v *schemaValidator) fail(path, message string) {
+
+	if path == "" {
+		path = "/"
+	}
+	v.issues = append(v.issues, SchemaIssue{Path: path, Message: message})
+}
+
+// asSchemaObject normalizes YAML and JSON object decodings to map[string]any.
+func asSchemaObject(value any) (map[string]any, bool) {
+
+	switch object := value.(type) {
+	case map[string]any:
+		return object, true
+	case map[any]any:
+		converted := make(map[string]any, len(object))
+		for key, item := range object {
+			converted[fmt.Sprint(key)] = item
+		}
+		return converted, true
+	default:
+		return nil, false
+	}
+}
+
+// matchesSchemaType reports whether value has the JSON Schema type.
+func matchesSchemaType(expected string, value any) bool {
+
+	switch expected {
+	case "object":
+		_, ok := asSchemaObject(value)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := schemaNumber(value)
+		return ok
+	case "integer":
+		number, ok := schemaNumber(value)
+		return ok && number == math.Trunc(number)
+	case "null":
+		return value == nil
+	default:
+		return false
+	}
+}
+
+// schemaNumber converts YAML and JSON numeric decodings to float64.
+func schemaNumber(value any) (float64, bool) {
+
+	switch number := value.(type) {
+	case int:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	case uint64:
+		return float64(number), true
+	case float64:
+		return number, true
+	default:
+		return 0, false
+	}
+}
+
+// describeSchemaValue names the JSON type of a decoded value for error messages.
+func describeSchemaValue(value any) string {
+
+	if value == nil {
+		return "null"
+	}
+	for _, candidate := range []string{"object", "array", "string", "boolean", "number"} {
+		if matchesSchemaType(candidate, value) {
+			return candidate
+		}
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// containsSchemaValue reports whether value equals one of the enum options.
+func containsSchemaValue(options []any, value any) bool {
+
+	for _, option := range options {
+		if fmt.Sprint(option) == fmt.Sprint(value) && describeSchemaValue(option) == describeSchemaValue(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatSchemaEnum renders enum options for error messages.
+func formatSchemaEnum(options []any) string {
+
+	parts := make([]string, 0, len(options))
+	for _, option := range options {
+		parts = append(parts, fmt.Sprint(option))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// escapeSchemaPointer escapes a JSON pointer token.
+func escapeSchemaPointer(token string) string {
+
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,61 @@
+// watch.go polls the config file and reports merged configuration after each change.
+// internal/core/config/watch.go
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"os"
+	"time"
+)
+
+// DefaultWatchInterval is how often WatchFile checks the config file.
+const DefaultWatchInterval = 2 * time.Second
+
+// WatchFile reloads configuration whenever the file's contents change until ctx is canceled.
+// onChange receives the re-merged configuration, or the error that prevented it so callers can keep the last good state.
+// Polling keeps editors that replace files atomically working without platform-specific notification APIs.
+func WatchFile(ctx context.Context, store Store, source FileSource, interval time.Duration, onChange func(AppConfig, error)) {
+
+	if source.Path == "" || onChange == nil {
+		return
+	}
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	last := fileDigest(source.Path)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current := fileDigest(source.Path)
+		if bytes.Equal(current, last) {
+			continue
+		}
+		last = current
+		if current == nil {
+			// The file is mid-replace or was removed; keep the last configuration until it returns.
+			continue
+		}
+		onChange(LoadWithFile(store, source))
+	}
+}
+
+// fileDigest hashes file contents, returning nil when the file cannot be read.
+func fileDigest(path string) []byte {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
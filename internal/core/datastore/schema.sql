@@ -109,6 +109,17 @@ CREATE TABLE IF NOT EXISTS model_system_tags (
     FOREIGN KEY (model_catalog_entry_id) REFERENCES model_catalog_entries(id) ON DELETE CASCADE
 );
 
+CREATE TABLE IF NOT EXISTS model_capability_verifications (
+    model_catalog_entry_id TEXT NOT NULL,
+    capability TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('verified', 'broken')),
+    last_error TEXT,
+    verification_source TEXT NOT NULL,
+    verified_at INTEGER NOT NULL,
+    PRIMARY KEY (model_catalog_entry_id, capability),
+    FOREIGN KEY (model_catalog_entry_id) REFERENCES model_catalog_entries(id) ON DELETE CASCADE
+);
+
 CREATE TABLE IF NOT EXISTS model_user_addenda (
     model_catalog_entry_id TEXT PRIMARY KEY,
     notes TEXT,
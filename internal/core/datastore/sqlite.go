@@ -31,6 +31,7 @@ var requiredSchemaTables = []string{
 	"model_capabilities_output_modalities",
 	"model_system_profile",
 	"model_system_tags",
+	"model_capability_verifications",
 	"model_user_addenda",
 	"model_user_tags",
 	"roles",
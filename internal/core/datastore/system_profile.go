@@ -0,0 +1,104 @@
+// system_profile.go writes measured tiers into model_system_profile.
+// internal/core/datastore/system_profile.go
+package datastore
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrCatalogEntryNotFound reports that no catalog entry matches a provider and model.
+var ErrCatalogEntryNotFound = errors.New("no catalog entry")
+
+// unknownProfileTier fills tiers that have never been measured when a profile row is created.
+const unknownProfileTier = "unknown"
+
+// SystemProfileUpdate describes measured tiers for one catalog model; empty tiers keep their stored value.
+type SystemProfileUpdate struct {
+	ProviderName    string
+	ModelID         string
+	LatencyTier     string
+	CostTier        string
+	ReliabilityTier string
+	Source          string
+	AsOf            time.Time
+}
+
+// UpdateModelSystemProfile upserts the system profile of the catalog entry matching the provider name and model ID.
+func UpdateModelSystemProfile(db *sql.DB, update SystemProfileUpdate) error {
+
+	if db == nil {
+		return fmt.Errorf("update system profile: database required")
+	}
+	providerName := strings.TrimSpace(update.ProviderName)
+	modelID := strings.TrimSpace(update.ModelID)
+	source := strings.TrimSpace(update.Source)
+	if providerName == "" || modelID == "" || source == "" {
+		return fmt.Errorf("update system profile: provider, model, and source required")
+	}
+	asOf := update.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+
+	entryID, err := resolveCatalogEntryID(db, providerName, modelID)
+	if err != nil {
+		return fmt.Errorf("update system profile: %w", err)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO model_system_profile (
+			model_catalog_entry_id, latency_tier, cost_tier, reliability_tier, system_profile_source, system_profile_as_of
+		) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(model_catalog_entry_id) DO UPDATE SET
+			latency_tier = COALESCE(NULLIF(?, ''), latency_tier),
+			cost_tier = COALESCE(NULLIF(?, ''), cost_tier),
+			reliability_tier = COALESCE(NULLIF(?, ''), reliability_tier),
+			system_profile_source = excluded.system_profile_source,
+			system_profile_as_of = excluded.system_profile_as_of`,
+		entryID,
+		tierOrUnknown(update.LatencyTier), tierOrUnknown(update.CostTier), tierOrUnknown(update.ReliabilityTier),
+		source, asOf.UnixMilli(),
+		strings.TrimSpace(update.LatencyTier), strings.TrimSpace(update.CostTier), strings.TrimSpace(update.ReliabilityTier),
+	)
+	if err != nil {
+		return fmt.Errorf("update system profile: %w", err)
+	}
+	return nil
+}
+
+// rowQuerier is satisfied by both *sql.DB and *sql.Tx.
+type rowQuerier interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// resolveCatalogEntryID finds the catalog entry ID for a provider name and model ID.
+func resolveCatalogEntryID(querier rowQuerier, providerName string, modelID string) (string, error) {
+
+	var entryID string
+	err := querier.QueryRow(`
+		SELECT e.id FROM model_catalog_entries e
+		JOIN catalog_endpoints ep ON ep.id = e.endpoint_id
+		JOIN catalog_providers p ON p.id = ep.provider_id
+		WHERE p.name = ? AND e.model_id = ?
+		ORDER BY e.id LIMIT 1`, providerName, modelID).Scan(&entryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w for %s/%s", ErrCatalogEntryNotFound, providerName, modelID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("resolve entry: %w", err)
+	}
+	return entryID, nil
+}
+
+// tierOrUnknown substitutes the unknown tier for an empty value.
+func tierOrUnknown(tier string) string {
+
+	if trimmed := strings.TrimSpace(tier); trimmed != "" {
+		return trimmed
+	}
+	return unknownProfileTier
+}
@@ -0,0 +1,61 @@
+// system_profile_test.go verifies measured tier write-back into model_system_profile.
+// internal/core/datastore/system_profile_test.go
+package datastore
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestUpdateModelSystemProfileUpsertsAndPreservesTiers verifies inserts default unmeasured tiers and updates keep them.
+func TestUpdateModelSystemProfileUpsertsAndPreservesTiers(t *testing.T) {
+
+	db, err := OpenSQLite(newTestDatabasePath(t, filepath.Join("profile", "app.db")))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	var entryID, providerName, modelID string
+	err = db.QueryRow(`
+		SELECT e.id, p.name, e.model_id FROM model_catalog_entries e
+		JOIN catalog_endpoints ep ON ep.id = e.endpoint_id
+		JOIN catalog_providers p ON p.id = ep.provider_id
+		ORDER BY e.id LIMIT 1`).Scan(&entryID, &providerName, &modelID)
+	if err != nil {
+		t.Fatalf("select seeded entry: %v", err)
+	}
+
+	benchmarkedAt := time.UnixMilli(1_700_000_000_000)
+	if err := UpdateModelSystemProfile(db, SystemProfileUpdate{
+		ProviderName: providerName, ModelID: modelID, LatencyTier: "low", Source: "benchmark", AsOf: benchmarkedAt,
+	}); err != nil {
+		t.Fatalf("insert profile: %v", err)
+	}
+	if err := UpdateModelSystemProfile(db, SystemProfileUpdate{
+		ProviderName: providerName, ModelID: modelID, ReliabilityTier: "high", Source: "eval", AsOf: benchmarkedAt.Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("update profile: %v", err)
+	}
+
+	var latency, cost, reliability, source string
+	var asOf int64
+	err = db.QueryRow(`
+		SELECT latency_tier, cost_tier, reliability_tier, system_profile_source, system_profile_as_of
+		FROM model_system_profile WHERE model_catalog_entry_id = ?`, entryID).Scan(&latency, &cost, &reliability, &source, &asOf)
+	if err != nil {
+		t.Fatalf("read profile: %v", err)
+	}
+	if latency != "low" || cost != unknownProfileTier || reliability != "high" || source != "eval" || asOf != benchmarkedAt.Add(time.Hour).UnixMilli() {
+		t.Fatalf("unexpected profile: latency=%s cost=%s reliability=%s source=%s as_of=%d", latency, cost, reliability, source, asOf)
+	}
+
+	err = UpdateModelSystemProfile(db, SystemProfileUpdate{ProviderName: providerName, ModelID: "missing-model", LatencyTier: "low", Source: "benchmark"})
+	if err == nil || !strings.Contains(err.Error(), "no catalog entry") {
+		t.Fatalf("expected missing entry error, got %v", err)
+	}
+}
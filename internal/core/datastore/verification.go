@@ -0,0 +1,143 @@
+// verification.go records live capability test outcomes against catalog entries.
+// internal/core/datastore/verification.go
+package datastore
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Availability states derived from capability verifications.
+const (
+	AvailabilityVerified = "verified"
+	AvailabilityBroken   = "broken"
+)
+
+// CapabilityVerification is the outcome of one live capability test for a catalog model.
+type CapabilityVerification struct {
+	ProviderName string
+	ModelID      string
+	Capability   string
+	Passed       bool
+	Error        string
+	Source       string
+	CheckedAt    time.Time
+}
+
+// RecordCapabilityVerifications stores verification outcomes and recomputes availability_state for each touched entry.
+// Outcomes for models missing from the catalog are skipped and returned as "provider/model" keys.
+func RecordCapabilityVerifications(db *sql.DB, verifications []CapabilityVerification) ([]string, error) {
+
+	if db == nil {
+		return nil, fmt.Errorf("record verifications: database required")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("record verifications: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	skippedSet := make(map[string]struct{})
+	touched := make(map[string]time.Time)
+	for _, verification := range verifications {
+		capability := strings.TrimSpace(verification.Capability)
+		source := strings.TrimSpace(verification.Source)
+		if capability == "" || source == "" {
+			return nil, fmt.Errorf("record verifications: capability and source required for %s/%s", verification.ProviderName, verification.ModelID)
+		}
+		entryID, err := resolveCatalogEntryID(tx, strings.TrimSpace(verification.ProviderName), strings.TrimSpace(verification.ModelID))
+		if errors.Is(err, ErrCatalogEntryNotFound) {
+			skippedSet[verification.ProviderName+"/"+verification.ModelID] = struct{}{}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("record verifications: %w", err)
+		}
+
+		checkedAt := verification.CheckedAt
+		if checkedAt.IsZero() {
+			checkedAt = time.Now()
+		}
+		status := AvailabilityVerified
+		var lastError any
+		if !verification.Passed {
+			status = AvailabilityBroken
+			lastError = verification.Error
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO model_capability_verifications (
+				model_catalog_entry_id, capability, status, last_error, verification_source, verified_at
+			) VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT(model_catalog_entry_id, capability) DO UPDATE SET
+				status = excluded.status,
+				last_error = excluded.last_error,
+				verification_source = excluded.verification_source,
+				verified_at = excluded.verified_at`,
+			entryID, capability, status, lastError, source, checkedAt.UnixMilli(),
+		); err != nil {
+			return nil, fmt.Errorf("record verification %s/%s: %w", entryID, capability, err)
+		}
+		if checkedAt.After(touched[entryID]) {
+			touched[entryID] = checkedAt
+		}
+	}
+
+	for entryID, checkedAt := range touched {
+		if _, err := tx.Exec(`
+			UPDATE model_catalog_entries SET
+				availability_state = CASE WHEN EXISTS (
+					SELECT 1 FROM model_capability_verifications
+					WHERE model_catalog_entry_id = ? AND status = ?
+				) THEN ? ELSE ? END,
+				last_seen_at = MAX(last_seen_at, ?)
+			WHERE id = ?`,
+			entryID, AvailabilityBroken, AvailabilityBroken, AvailabilityVerified, checkedAt.UnixMilli(), entryID,
+		); err != nil {
+			return nil, fmt.Errorf("update availability %s: %w", entryID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("record verifications: %w", err)
+	}
+
+	skipped := make([]string, 0, len(skippedSet))
+	for key := range skippedSet {
+		skipped = append(skipped, key)
+	}
+	sort.Strings(skipped)
+	return skipped, nil
+}
+
+// ListBrokenCapabilities returns the capabilities whose last verification failed, keyed by catalog entry ID.
+func ListBrokenCapabilities(db *sql.DB) (map[string][]string, error) {
+
+	if db == nil {
+		return nil, fmt.Errorf("list broken capabilities: database required")
+	}
+	rows, err := db.Query(`
+		SELECT model_catalog_entry_id, capability FROM model_capability_verifications
+		WHERE status = ? ORDER BY model_catalog_entry_id, capability`, AvailabilityBroken)
+	if err != nil {
+		return nil, fmt.Errorf("list broken capabilities: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	broken := make(map[string][]string)
+	for rows.Next() {
+		var entryID, capability string
+		if err := rows.Scan(&entryID, &capability); err != nil {
+			return nil, fmt.Errorf("list broken capabilities: %w", err)
+		}
+		broken[entryID] = append(broken[entryID], capability)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list broken capabilities: %w", err)
+	}
+	return broken, nil
+}
@@ -0,0 +1,77 @@
+// verification_test.go verifies capability verification write-back and availability roll-up.
+// internal/core/datastore/verification_test.go
+package datastore
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestRecordCapabilityVerificationsRollsUpAvailability verifies any broken capability marks the entry broken until it passes again.
+func TestRecordCapabilityVerificationsRollsUpAvailability(t *testing.T) {
+
+	db, err := OpenSQLite(newTestDatabasePath(t, filepath.Join("verify", "app.db")))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	var entryID, providerName, modelID string
+	err = db.QueryRow(`
+		SELECT e.id, p.name, e.model_id FROM model_catalog_entries e
+		JOIN catalog_endpoints ep ON ep.id = e.endpoint_id
+		JOIN catalog_providers p ON p.id = ep.provider_id
+		ORDER BY e.id LIMIT 1`).Scan(&entryID, &providerName, &modelID)
+	if err != nil {
+		t.Fatalf("select seeded entry: %v", err)
+	}
+
+	checkedAt := time.Now()
+	skipped, err := RecordCapabilityVerifications(db, []CapabilityVerification{
+		{ProviderName: providerName, ModelID: modelID, Capability: "chat", Passed: true, Source: "modeltest", CheckedAt: checkedAt},
+		{ProviderName: providerName, ModelID: modelID, Capability: "streaming", Error: "HTTP 500", Source: "modeltest", CheckedAt: checkedAt},
+		{ProviderName: providerName, ModelID: "not-in-catalog", Capability: "chat", Passed: true, Source: "modeltest"},
+	})
+	if err != nil {
+		t.Fatalf("record verifications: %v", err)
+	}
+	if len(skipped) != 1 || skipped[0] != providerName+"/not-in-catalog" {
+		t.Fatalf("expected the unknown model to be skipped, got %v", skipped)
+	}
+	assertAvailability(t, db, entryID, AvailabilityBroken)
+
+	broken, err := ListBrokenCapabilities(db)
+	if err != nil {
+		t.Fatalf("list broken capabilities: %v", err)
+	}
+	if len(broken[entryID]) != 1 || broken[entryID][0] != "streaming" {
+		t.Fatalf("expected streaming to be broken, got %v", broken)
+	}
+
+	if _, err := RecordCapabilityVerifications(db, []CapabilityVerification{
+		{ProviderName: providerName, ModelID: modelID, Capability: "streaming", Passed: true, Source: "modeltest", CheckedAt: checkedAt.Add(time.Minute)},
+	}); err != nil {
+		t.Fatalf("record recovery: %v", err)
+	}
+	assertAvailability(t, db, entryID, AvailabilityVerified)
+	if count := mustCountRows(t, db, "SELECT COUNT(*) FROM model_capability_verifications WHERE last_error IS NOT NULL"); count != 0 {
+		t.Fatalf("expected recovered capability to clear its error, got %d rows with errors", count)
+	}
+}
+
+// assertAvailability fails the test when an entry's availability_state differs from want.
+func assertAvailability(t *testing.T, db *sql.DB, entryID string, want string) {
+
+	t.Helper()
+	var state string
+	if err := db.QueryRow("SELECT availability_state FROM model_catalog_entries WHERE id = ?", entryID).Scan(&state); err != nil {
+		t.Fatalf("read availability: %v", err)
+	}
+	if state != want {
+		t.Fatalf("expected availability %q, got %q", want, state)
+	}
+}
@@ -0,0 +1,40 @@
+// transcriber.go adapts audio transcription to the chat voice port.
+// internal/features/ai/audio/adapters/chattranscriber/transcriber.go
+package chattranscriber
+
+import (
+	"context"
+	"fmt"
+
+	audioports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/audio/ports"
+	chatports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/ports"
+)
+
+// Transcriber serves chat voice attachments from an audio service.
+type Transcriber struct {
+	audio audioports.AudioInterface
+}
+
+var _ chatports.VoiceTranscriber = (*Transcriber)(nil)
+
+// New creates a chat voice transcriber backed by an audio service.
+func New(audio audioports.AudioInterface) *Transcriber {
+
+	return &Transcriber{audio: audio}
+}
+
+// TranscribeVoice transcribes an audio file with the provider's default speech model.
+func (t *Transcriber) TranscribeVoice(ctx context.Context, providerName, audioPath string) (string, error) {
+
+	if t.audio == nil {
+		return "", fmt.Errorf("audio service not configured")
+	}
+	transcript, err := t.audio.Transcribe(ctx, audioports.TranscribeRequest{
+		ProviderName: providerName,
+		AudioPath:    audioPath,
+	})
+	if err != nil {
+		return "", err
+	}
+	return transcript.Text, nil
+}
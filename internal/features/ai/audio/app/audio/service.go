@@ -0,0 +1,132 @@
+// service.go provides speech-to-text and text-to-speech backend operations.
+// internal/features/ai/audio/app/audio/service.go
+package audio
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	audioports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/audio/ports"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// maxAudioBytes matches the largest upload the hosted speech APIs accept.
+const maxAudioBytes = 25 << 20
+
+// AudioProviderOperations defines speech operations required by the audio backend service.
+type AudioProviderOperations interface {
+	Transcribe(ctx context.Context, name string, options providergateway.TranscriptionOptions) (*providergateway.TranscriptionResult, error)
+	Synthesize(ctx context.Context, name string, options providergateway.SpeechOptions) (*providergateway.SpeechResult, error)
+}
+
+// Service handles speech operations for transport adapters.
+type Service struct {
+	providers AudioProviderOperations
+}
+
+var _ audioports.AudioInterface = (*Service)(nil)
+
+// NewService creates an audio backend service from provider dependencies.
+func NewService(providers AudioProviderOperations) *Service {
+
+	return &Service{providers: providers}
+}
+
+// Transcribe reads an audio file and converts its speech to text using a configured provider.
+func (s *Service) Transcribe(ctx context.Context, request audioports.TranscribeRequest) (audioports.Transcript, error) {
+
+	if s.providers == nil {
+		return audioports.Transcript{}, fmt.Errorf("backend service: providers not configured")
+	}
+	path := strings.TrimSpace(request.AudioPath)
+	if path == "" {
+		return audioports.Transcript{}, fmt.Errorf("transcribe: audio path is required")
+	}
+	if providergateway.AudioMIMEType(path) == "" {
+		return audioports.Transcript{}, fmt.Errorf("transcribe: unsupported audio format %q", filepath.Ext(path))
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return audioports.Transcript{}, fmt.Errorf("transcribe: %w", err)
+	}
+	if info.Size() > maxAudioBytes {
+		return audioports.Transcript{}, fmt.Errorf("transcribe: %s is larger than %d MB", filepath.Base(path), maxAudioBytes>>20)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return audioports.Transcript{}, fmt.Errorf("transcribe: %w", err)
+	}
+
+	model := strings.TrimSpace(request.ModelName)
+	result, err := s.providers.Transcribe(ctx, request.ProviderName, providergateway.TranscriptionOptions{
+		Model:    model,
+		Audio:    data,
+		Filename: filepath.Base(path),
+		Language: strings.TrimSpace(request.Language),
+		Prompt:   request.Prompt,
+	})
+	if err != nil {
+		return audioports.Transcript{}, err
+	}
+
+	output := audioports.Transcript{
+		Model:    result.Model,
+		Text:     result.Text,
+		Language: result.Language,
+		Duration: result.Duration,
+	}
+	if output.Model == "" {
+		output.Model = model
+	}
+	return output, nil
+}
+
+// Speak converts text to spoken audio using a configured provider.
+func (s *Service) Speak(ctx context.Context, request audioports.SpeakRequest) (audioports.Speech, error) {
+
+	if s.providers == nil {
+		return audioports.Speech{}, fmt.Errorf("backend service: providers not configured")
+	}
+	if strings.TrimSpace(request.Text) == "" {
+		return audioports.Speech{}, fmt.Errorf("speak: text is required")
+	}
+	if request.Speed < 0 {
+		return audioports.Speech{}, fmt.Errorf("speak: speed must be positive")
+	}
+	format := strings.ToLower(strings.TrimSpace(request.Format))
+	if format != "" && providergateway.AudioMIMEType(format) == "" {
+		return audioports.Speech{}, fmt.Errorf("speak: unsupported audio format %q", request.Format)
+	}
+
+	model := strings.TrimSpace(request.ModelName)
+	result, err := s.providers.Synthesize(ctx, request.ProviderName, providergateway.SpeechOptions{
+		Model:  model,
+		Input:  request.Text,
+		Voice:  strings.TrimSpace(request.Voice),
+		Format: format,
+		Speed:  request.Speed,
+	})
+	if err != nil {
+		return audioports.Speech{}, err
+	}
+	if len(result.Audio) == 0 {
+		return audioports.Speech{}, fmt.Errorf("speak: provider returned no audio")
+	}
+
+	output := audioports.Speech{
+		Model:    result.Model,
+		Format:   result.Format,
+		MIMEType: result.MIMEType,
+		Audio:    result.Audio,
+	}
+	if output.Model == "" {
+		output.Model = model
+	}
+	if output.MIMEType == "" {
+		output.MIMEType = providergateway.AudioMIMEType(output.Format)
+	}
+	return output, nil
+}
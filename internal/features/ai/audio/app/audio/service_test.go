@@ -0,0 +1,138 @@
+// service_test.go verifies audio request validation and result mapping.
+// internal/features/ai/audio/app/audio/service_test.go
+package audio
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	audioports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/audio/ports"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// recordingProviders captures the last speech options and returns fixed results.
+type recordingProviders struct {
+	name          string
+	transcription providergateway.TranscriptionOptions
+	speech        providergateway.SpeechOptions
+}
+
+// Transcribe records its inputs and returns a fixed transcript.
+func (p *recordingProviders) Transcribe(_ context.Context, name string, options providergateway.TranscriptionOptions) (*providergateway.TranscriptionResult, error) {
+
+	p.name = name
+	p.transcription = options
+	return &providergateway.TranscriptionResult{Text: "hello world", Language: "en", Duration: 2}, nil
+}
+
+// Synthesize records its inputs and returns fixed audio bytes.
+func (p *recordingProviders) Synthesize(_ context.Context, name string, options providergateway.SpeechOptions) (*providergateway.SpeechResult, error) {
+
+	p.name = name
+	p.speech = options
+	return &providergateway.SpeechResult{Model: "tts-1", Audio: []byte("mp3"), Format: "mp3"}, nil
+}
+
+// TestTranscribeReadsAudioFile validates file loading, filename forwarding, and result mapping.
+func TestTranscribeReadsAudioFile(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "memo.wav")
+	if err := os.WriteFile(path, []byte("RIFF"), 0o600); err != nil {
+		t.Fatalf("write audio: %v", err)
+	}
+	providers := &recordingProviders{}
+	service := NewService(providers)
+
+	transcript, err := service.Transcribe(context.Background(), audioports.TranscribeRequest{
+		ProviderName: "openai",
+		ModelName:    " whisper-1 ",
+		AudioPath:    path,
+		Language:     "en",
+	})
+	if err != nil {
+		t.Fatalf("transcribe: %v", err)
+	}
+	if providers.name != "openai" || providers.transcription.Model != "whisper-1" || providers.transcription.Filename != "memo.wav" || string(providers.transcription.Audio) != "RIFF" {
+		t.Fatalf("unexpected provider call %q %#v", providers.name, providers.transcription)
+	}
+	if transcript.Text != "hello world" || transcript.Model != "whisper-1" || transcript.Duration != 2 {
+		t.Fatalf("unexpected transcript %#v", transcript)
+	}
+}
+
+// TestSpeakMapsRequestAndResult validates format normalization and MIME type fallback.
+func TestSpeakMapsRequestAndResult(t *testing.T) {
+
+	providers := &recordingProviders{}
+	service := NewService(providers)
+
+	speech, err := service.Speak(context.Background(), audioports.SpeakRequest{
+		ProviderName: "openai",
+		Text:         "Good morning",
+		Voice:        " nova ",
+		Format:       "MP3",
+		Speed:        1.25,
+	})
+	if err != nil {
+		t.Fatalf("speak: %v", err)
+	}
+	if providers.speech.Format != "mp3" || providers.speech.Voice != "nova" || providers.speech.Speed != 1.25 {
+		t.Fatalf("unexpected provider call %#v", providers.speech)
+	}
+	if speech.MIMEType != "audio/mpeg" || string(speech.Audio) != "mp3" || speech.Model != "tts-1" {
+		t.Fatalf("unexpected speech %#v", speech)
+	}
+}
+
+// TestAudioRejectsInvalidRequests validates request checks before provider calls.
+func TestAudioRejectsInvalidRequests(t *testing.T) {
+
+	dir := t.TempDir()
+	textPath := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(textPath, []byte("text"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	testCases := []struct {
+		name string
+		call func(*Service) error
+		want string
+	}{
+		{name: "missing path", call: func(s *Service) error {
+			_, err := s.Transcribe(context.Background(), audioports.TranscribeRequest{ProviderName: "openai"})
+			return err
+		}, want: "audio path is required"},
+		{name: "unsupported extension", call: func(s *Service) error {
+			_, err := s.Transcribe(context.Background(), audioports.TranscribeRequest{ProviderName: "openai", AudioPath: textPath})
+			return err
+		}, want: "unsupported audio format"},
+		{name: "missing file", call: func(s *Service) error {
+			_, err := s.Transcribe(context.Background(), audioports.TranscribeRequest{ProviderName: "openai", AudioPath: filepath.Join(dir, "gone.mp3")})
+			return err
+		}, want: "no such file"},
+		{name: "empty text", call: func(s *Service) error {
+			_, err := s.Speak(context.Background(), audioports.SpeakRequest{ProviderName: "openai", Text: "  "})
+			return err
+		}, want: "text is required"},
+		{name: "unknown format", call: func(s *Service) error {
+			_, err := s.Speak(context.Background(), audioports.SpeakRequest{ProviderName: "openai", Text: "hi", Format: "midi"})
+			return err
+		}, want: "unsupported audio format"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			providers := &recordingProviders{}
+			err := testCase.call(NewService(providers))
+			if err == nil || !strings.Contains(err.Error(), testCase.want) {
+				t.Fatalf("expected %q error, got %v", testCase.want, err)
+			}
+			if providers.name != "" {
+				t.Fatalf("provider should not be called")
+			}
+		})
+	}
+}
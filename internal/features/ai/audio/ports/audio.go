@@ -0,0 +1,46 @@
+// audio.go defines speech-to-text and text-to-speech transport contracts.
+// internal/features/ai/audio/ports/audio.go
+package ports
+
+import "context"
+
+// AudioInterface defines speech capabilities shared across transports.
+type AudioInterface interface {
+	Transcribe(ctx context.Context, request TranscribeRequest) (Transcript, error)
+	Speak(ctx context.Context, request SpeakRequest) (Speech, error)
+}
+
+// TranscribeRequest contains inputs for transcribing one audio file.
+type TranscribeRequest struct {
+	ProviderName string `json:"providerName"`
+	ModelName    string `json:"modelName,omitempty"`
+	AudioPath    string `json:"audioPath"`
+	Language     string `json:"language,omitempty"`
+	Prompt       string `json:"prompt,omitempty"`
+}
+
+// Transcript contains the recognized text for one audio file.
+type Transcript struct {
+	Model    string  `json:"model,omitempty"`
+	Text     string  `json:"text"`
+	Language string  `json:"language,omitempty"`
+	Duration float64 `json:"duration,omitempty"`
+}
+
+// SpeakRequest contains inputs for synthesizing speech from text.
+type SpeakRequest struct {
+	ProviderName string  `json:"providerName"`
+	ModelName    string  `json:"modelName,omitempty"`
+	Text         string  `json:"text"`
+	Voice        string  `json:"voice,omitempty"`
+	Format       string  `json:"format,omitempty"`
+	Speed        float64 `json:"speed,omitempty"`
+}
+
+// Speech contains synthesized audio and its container format.
+type Speech struct {
+	Model    string `json:"model,omitempty"`
+	Format   string `json:"format"`
+	MIMEType string `json:"mimeType"`
+	Audio    []byte `json:"audio"`
+}
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	chatdomain "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/domain"
 	chatports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/ports"
@@ -74,6 +75,41 @@ CREATE TABLE IF NOT EXISTS chat_message_blocks (
 
 CREATE UNIQUE INDEX IF NOT EXISTS idx_chat_message_blocks_order
 ON chat_message_blocks (message_id, block_index);
+
+CREATE TABLE IF NOT EXISTS chat_conversation_knowledge (
+	conversation_id TEXT PRIMARY KEY,
+	collection TEXT NOT NULL,
+	top_k INTEGER NOT NULL,
+	FOREIGN KEY (conversation_id) REFERENCES chat_conversations(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS chat_message_citations (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	message_id TEXT NOT NULL,
+	citation_index INTEGER NOT NULL,
+	collection TEXT NOT NULL,
+	source_path TEXT NOT NULL,
+	start_line INTEGER NOT NULL,
+	end_line INTEGER NOT NULL,
+	score REAL NOT NULL,
+	snippet TEXT,
+	FOREIGN KEY (message_id) REFERENCES chat_messages(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_chat_message_citations_order
+ON chat_message_citations (message_id, citation_index);
+
+CREATE TABLE IF NOT EXISTS chat_message_moderation (
+	message_id TEXT NOT NULL,
+	stage TEXT NOT NULL,
+	action TEXT NOT NULL,
+	flagged INTEGER NOT NULL,
+	categories TEXT,
+	provider TEXT,
+	model TEXT,
+	PRIMARY KEY (message_id, stage),
+	FOREIGN KEY (message_id) REFERENCES chat_messages(id) ON DELETE CASCADE
+);
 `
 
 // Repository stores conversations in SQLite.
@@ -107,6 +143,9 @@ func (r *Repository) Create(conv *chatdomain.Conversation) error {
 		if err := insertConversation(tx, conv); err != nil {
 			return err
 		}
+		if err := replaceConversationKnowledge(tx, conv); err != nil {
+			return err
+		}
 		if err := replaceMessages(tx, conv.ID, conv.Messages); err != nil {
 			return err
 		}
@@ -151,6 +190,9 @@ func (r *Repository) Get(id string) (*chatdomain.Conversation, error) {
 	}
 	conv.IsArchived = isArchived == 1
 
+	if err := loadConversationKnowledge(r.db, &conv); err != nil {
+		return nil, err
+	}
 	messages, err := loadMessages(r.db, conv.ID)
 	if err != nil {
 		return nil, err
@@ -205,6 +247,9 @@ func (r *Repository) List() ([]*chatdomain.Conversation, error) {
 	}
 
 	for _, conv := range conversations {
+		if err := loadConversationKnowledge(r.db, conv); err != nil {
+			return nil, err
+		}
 		messages, err := loadMessages(r.db, conv.ID)
 		if err != nil {
 			return nil, err
@@ -226,6 +271,9 @@ func (r *Repository) Update(conv *chatdomain.Conversation) error {
 		if err := upsertConversation(tx, conv); err != nil {
 			return err
 		}
+		if err := replaceConversationKnowledge(tx, conv); err != nil {
+			return err
+		}
 		if err := replaceMessages(tx, conv.ID, conv.Messages); err != nil {
 			return err
 		}
@@ -412,11 +460,86 @@ func replaceMessages(tx *sql.Tx, conversationID string, messages []*chatdomain.M
 				return err
 			}
 		}
+		for _, citation := range message.Citations {
+			if err := insertMessageCitation(tx, message.ID, citation); err != nil {
+				return err
+			}
+		}
+		if message.Metadata != nil {
+			for _, outcome := range message.Metadata.Moderation {
+				if err := insertMessageModeration(tx, message.ID, outcome); err != nil {
+					return err
+				}
+			}
+		}
 	}
 
 	return nil
 }
 
+// insertMessageModeration inserts one screening outcome row for a message.
+func insertMessageModeration(tx *sql.Tx, messageID string, outcome chatdomain.ModerationOutcome) error {
+
+	if _, err := tx.Exec(
+		`INSERT OR REPLACE INTO chat_message_moderation
+		 (message_id, stage, action, flagged, categories, provider, model)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		messageID,
+		string(outcome.Stage),
+		string(outcome.Action),
+		boolToInt(outcome.Flagged),
+		newNullString(strings.Join(outcome.Categories, ",")),
+		newNullString(outcome.Provider),
+		newNullString(outcome.Model),
+	); err != nil {
+		return fmt.Errorf("chat repo: insert moderation: %w", err)
+	}
+	return nil
+}
+
+// replaceConversationKnowledge stores or clears the knowledge collection attached to a conversation.
+func replaceConversationKnowledge(tx *sql.Tx, conv *chatdomain.Conversation) error {
+
+	if _, err := tx.Exec("DELETE FROM chat_conversation_knowledge WHERE conversation_id = ?", conv.ID); err != nil {
+		return fmt.Errorf("chat repo: delete conversation knowledge: %w", err)
+	}
+	if conv.Settings.KnowledgeCollection == "" {
+		return nil
+	}
+
+	if _, err := tx.Exec(
+		`INSERT INTO chat_conversation_knowledge (conversation_id, collection, top_k) VALUES (?, ?, ?)`,
+		conv.ID,
+		conv.Settings.KnowledgeCollection,
+		conv.Settings.KnowledgeTopK,
+	); err != nil {
+		return fmt.Errorf("chat repo: insert conversation knowledge: %w", err)
+	}
+	return nil
+}
+
+// insertMessageCitation inserts one citation row for a message.
+func insertMessageCitation(tx *sql.Tx, messageID string, citation chatdomain.Citation) error {
+
+	_, err := tx.Exec(
+		`INSERT INTO chat_message_citations
+		 (message_id, citation_index, collection, source_path, start_line, end_line, score, snippet)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		messageID,
+		citation.Index,
+		citation.Collection,
+		citation.SourcePath,
+		citation.StartLine,
+		citation.EndLine,
+		citation.Score,
+		nullableString(citation.Snippet),
+	)
+	if err != nil {
+		return fmt.Errorf("chat repo: insert citation: %w", err)
+	}
+	return nil
+}
+
 // insertMessageBlock inserts one block row for a message.
 func insertMessageBlock(tx *sql.Tx, messageID string, blockIndex int, block chatdomain.Block) error {
 
@@ -590,6 +713,23 @@ func loadMessages(db *sql.DB, conversationID string) ([]*chatdomain.Message, err
 			return nil, err
 		}
 		msg.Blocks = blocks
+
+		citations, err := loadCitations(db, msg.ID)
+		if err != nil {
+			return nil, err
+		}
+		msg.Citations = citations
+
+		outcomes, err := loadModeration(db, msg.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(outcomes) > 0 {
+			if msg.Metadata == nil {
+				msg.Metadata = &chatdomain.MessageMetadata{}
+			}
+			msg.Metadata.Moderation = outcomes
+		}
 	}
 
 	return messages, nil
@@ -699,6 +839,108 @@ func loadBlocks(db *sql.DB, messageID string) ([]chatdomain.Block, error) {
 	return blocks, nil
 }
 
+// loadConversationKnowledge fills a conversation's attached knowledge collection, if any.
+func loadConversationKnowledge(db *sql.DB, conv *chatdomain.Conversation) error {
+
+	err := db.QueryRow(
+		`SELECT collection, top_k FROM chat_conversation_knowledge WHERE conversation_id = ?`,
+		conv.ID,
+	).Scan(&conv.Settings.KnowledgeCollection, &conv.Settings.KnowledgeTopK)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("chat repo: get conversation knowledge: %w", err)
+	}
+	return nil
+}
+
+// loadCitations fetches all citations for one message.
+func loadCitations(db *sql.DB, messageID string) ([]chatdomain.Citation, error) {
+
+	rows, err := db.Query(
+		`SELECT citation_index, collection, source_path, start_line, end_line, score, snippet
+		 FROM chat_message_citations
+		 WHERE message_id = ?
+		 ORDER BY citation_index ASC`,
+		messageID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("chat repo: list citations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var citations []chatdomain.Citation
+	for rows.Next() {
+		var citation chatdomain.Citation
+		var snippet sql.NullString
+		if err := rows.Scan(
+			&citation.Index,
+			&citation.Collection,
+			&citation.SourcePath,
+			&citation.StartLine,
+			&citation.EndLine,
+			&citation.Score,
+			&snippet,
+		); err != nil {
+			return nil, fmt.Errorf("chat repo: scan citation: %w", err)
+		}
+		citation.Snippet = nullableValue(snippet)
+		citations = append(citations, citation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("chat repo: citation rows: %w", err)
+	}
+
+	return citations, nil
+}
+
+// loadModeration fetches screening outcomes for one message, input stage first.
+func loadModeration(db *sql.DB, messageID string) ([]chatdomain.ModerationOutcome, error) {
+
+	rows, err := db.Query(
+		`SELECT stage, action, flagged, categories, provider, model
+		 FROM chat_message_moderation
+		 WHERE message_id = ?
+		 ORDER BY CASE stage WHEN 'input' THEN 0 ELSE 1 END`,
+		messageID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("chat repo: list moderation: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var outcomes []chatdomain.ModerationOutcome
+	for rows.Next() {
+		var (
+			outcome    chatdomain.ModerationOutcome
+			stage      string
+			action     string
+			flagged    int
+			categories sql.NullString
+			provider   sql.NullString
+			model      sql.NullString
+		)
+		if err := rows.Scan(&stage, &action, &flagged, &categories, &provider, &model); err != nil {
+			return nil, fmt.Errorf("chat repo: scan moderation: %w", err)
+		}
+		outcome.Stage = chatdomain.ModerationStage(stage)
+		outcome.Action = chatdomain.ModerationAction(action)
+		outcome.Flagged = flagged == 1
+		if value := nullableValue(categories); value != "" {
+			outcome.Categories = strings.Split(value, ",")
+		}
+		outcome.Provider = nullableValue(provider)
+		outcome.Model = nullableValue(model)
+		outcomes = append(outcomes, outcome)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("chat repo: moderation rows: %w", err)
+	}
+
+	return outcomes, nil
+}
+
 // hasMetadata reports whether metadata carries meaningful values.
 func hasMetadata(meta *chatdomain.MessageMetadata) bool {
 
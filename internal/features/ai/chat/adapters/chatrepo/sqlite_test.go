@@ -4,6 +4,7 @@ package chatrepo
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/MadeByDoug/wls-chatbot/internal/core/datastore"
@@ -170,6 +171,111 @@ func TestRepositoryUpdateReplacesMessages(t *testing.T) {
 	}
 }
 
+// TestRepositoryKnowledgeSettingsAndCitationsRoundTrip verifies attached collections and message citations persist.
+func TestRepositoryKnowledgeSettingsAndCitationsRoundTrip(t *testing.T) {
+
+	repo := newTestRepository(t)
+	conv := &chatcore.Conversation{
+		ID:    "conv-kb",
+		Title: "Docs",
+		Settings: chatcore.ConversationSettings{
+			Provider:            "openai",
+			Model:               "gpt-4o",
+			KnowledgeCollection: "handbook",
+			KnowledgeTopK:       6,
+		},
+		CreatedAt: 1,
+		UpdatedAt: 1,
+		Messages: []*chatcore.Message{
+			{
+				ID:             "msg-answer",
+				ConversationID: "conv-kb",
+				Role:           chatcore.RoleAssistant,
+				Blocks:         []chatcore.Block{{Type: chatcore.BlockTypeText, Content: "See [1]."}},
+				Timestamp:      2,
+				Citations: []chatcore.Citation{
+					{Index: 2, Collection: "handbook", SourcePath: "/docs/b.md", StartLine: 9, EndLine: 12, Score: 0.5},
+					{Index: 1, Collection: "handbook", SourcePath: "/docs/a.md", StartLine: 1, EndLine: 4, Score: 0.9, Snippet: "Vacation policy"},
+				},
+			},
+		},
+	}
+	if err := repo.Create(conv); err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+
+	loaded, err := repo.Get("conv-kb")
+	if err != nil || loaded == nil {
+		t.Fatalf("get conversation: %v", err)
+	}
+	if loaded.Settings.KnowledgeCollection != "handbook" || loaded.Settings.KnowledgeTopK != 6 {
+		t.Fatalf("unexpected knowledge settings: %+v", loaded.Settings)
+	}
+	citations := loaded.Messages[0].Citations
+	if len(citations) != 2 || citations[0] != conv.Messages[0].Citations[1] || citations[1] != conv.Messages[0].Citations[0] {
+		t.Fatalf("unexpected citations: %+v", citations)
+	}
+
+	conv.Settings.KnowledgeCollection = ""
+	conv.Messages[0].Citations = nil
+	if err := repo.Update(conv); err != nil {
+		t.Fatalf("update conversation: %v", err)
+	}
+	listed, err := repo.List()
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("list conversations: %v", err)
+	}
+	if listed[0].Settings.KnowledgeCollection != "" || listed[0].Messages[0].Citations != nil {
+		t.Fatalf("expected knowledge detached and citations cleared, got %+v", listed[0])
+	}
+}
+
+// TestRepositoryModerationOutcomesRoundTrip verifies screening outcomes persist on messages without other metadata.
+func TestRepositoryModerationOutcomesRoundTrip(t *testing.T) {
+
+	repo := newTestRepository(t)
+	outcomes := []chatcore.ModerationOutcome{
+		{Stage: chatcore.ModerationStageOutput, Action: chatcore.ModerationActionRedact, Flagged: true, Categories: []string{"hate", "violence"}, Provider: "openai", Model: "omni-moderation-latest"},
+		{Stage: chatcore.ModerationStageInput, Action: chatcore.ModerationActionAllow, Provider: "openai"},
+	}
+	conv := &chatcore.Conversation{
+		ID:        "conv-mod",
+		Title:     "Screened",
+		Settings:  chatcore.ConversationSettings{Provider: "openai", Model: "gpt-4o"},
+		CreatedAt: 1,
+		UpdatedAt: 1,
+		Messages: []*chatcore.Message{
+			{
+				ID:             "msg-reply",
+				ConversationID: "conv-mod",
+				Role:           chatcore.RoleAssistant,
+				Blocks:         []chatcore.Block{{Type: chatcore.BlockTypeText, Content: "[Content redacted by moderation: hate, violence]"}},
+				Timestamp:      2,
+				Metadata:       &chatcore.MessageMetadata{Moderation: outcomes},
+			},
+		},
+	}
+	if err := repo.Create(conv); err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+
+	loaded, err := repo.Get("conv-mod")
+	if err != nil || loaded == nil {
+		t.Fatalf("get conversation: %v", err)
+	}
+	metadata := loaded.Messages[0].Metadata
+	if metadata == nil || len(metadata.Moderation) != 2 {
+		t.Fatalf("expected two moderation outcomes, got %+v", metadata)
+	}
+	input, output := metadata.Moderation[0], metadata.Moderation[1]
+	if input.Stage != chatcore.ModerationStageInput || input.Action != chatcore.ModerationActionAllow || input.Flagged || input.Categories != nil {
+		t.Fatalf("unexpected input outcome: %+v", input)
+	}
+	if output.Action != chatcore.ModerationActionRedact || !output.Flagged || strings.Join(output.Categories, ",") != "hate,violence" || output.Model != "omni-moderation-latest" {
+		t.Fatalf("unexpected output outcome: %+v", output)
+	}
+}
+
 // TestRepositoryDeleteRemovesConversation verifies hard deletion behavior.
 func TestRepositoryDeleteRemovesConversation(t *testing.T) {
 
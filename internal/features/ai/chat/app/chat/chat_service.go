@@ -95,21 +95,44 @@ func toProviderMessages(messages []aiinterfaces.ChatMessage) ([]providergateway.
 
 	converted := make([]providergateway.ProviderMessage, 0, len(messages))
 	for _, message := range messages {
-		if strings.TrimSpace(message.Content) == "" {
+		if strings.TrimSpace(message.Content) == "" && len(message.ToolCalls) == 0 && message.Role != aiinterfaces.ChatRoleTool {
 			continue
 		}
 		role, err := toProviderRole(message.Role)
 		if err != nil {
 			return nil, err
 		}
+		if role == providergateway.RoleTool && strings.TrimSpace(message.ToolCallID) == "" {
+			return nil, fmt.Errorf("tool message requires a tool call id")
+		}
 		converted = append(converted, providergateway.ProviderMessage{
-			Role:    role,
-			Content: message.Content,
+			Role:       role,
+			Content:    message.Content,
+			ToolCalls:  toProviderToolCalls(message.ToolCalls),
+			ToolCallID: message.ToolCallID,
 		})
 	}
 	return converted, nil
 }
 
+// toProviderToolCalls converts the tool calls of an assistant message into provider tool calls.
+func toProviderToolCalls(calls []aiinterfaces.ChatToolCall) []providergateway.ToolCall {
+
+	if len(calls) == 0 {
+		return nil
+	}
+
+	converted := make([]providergateway.ToolCall, 0, len(calls))
+	for _, call := range calls {
+		converted = append(converted, providergateway.ToolCall{
+			ID:        call.ID,
+			Name:      call.Name,
+			Arguments: call.Arguments,
+		})
+	}
+	return converted
+}
+
 // toProviderRole converts transport chat roles into provider roles.
 func toProviderRole(role aiinterfaces.ChatRole) (providergateway.Role, error) {
 
@@ -7,7 +7,7 @@ import (
 	chatdomain "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/domain"
 )
 
-// MessageEventPayload represents message creation and stream-start event payloads.
+// MessageEventPayload represents message creation, update, and stream-start event payloads.
 type MessageEventPayload struct {
 	ConversationID string              `json:"conversationId"`
 	MessageID      string              `json:"messageId"`
@@ -37,6 +37,7 @@ type ConversationTitleEventPayload struct {
 
 var (
 	SignalMessageCreated    = coreevents.MustRegister[MessageEventPayload]("chat.message")
+	SignalMessageUpdated    = coreevents.MustRegister[MessageEventPayload]("chat.message.update")
 	SignalStreamStarted     = coreevents.MustRegister[MessageEventPayload]("chat.stream.start")
 	SignalStreamChunk       = coreevents.MustRegister[StreamChunkEventPayload]("chat.stream.chunk")
 	SignalStreamError       = coreevents.MustRegister[StreamChunkEventPayload]("chat.stream.error")
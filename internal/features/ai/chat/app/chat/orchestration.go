@@ -4,9 +4,11 @@ package chat
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	coreevents "github.com/MadeByDoug/wls-chatbot/internal/core/events"
@@ -14,12 +16,29 @@ import (
 	chatports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/ports"
 )
 
+// maxToolRounds bounds how many times one reply may call tools and continue.
+const maxToolRounds = 8
+
+// finishReasonToolRoundLimit ends a reply whose model still asked for tools after maxToolRounds rounds.
+const finishReasonToolRoundLimit = "tool_round_limit"
+
+// outputScreeningTimeout bounds how long a finished reply waits on the moderation provider.
+const outputScreeningTimeout = 30 * time.Second
+
 // Orchestrator coordinates chat workflows and event emission.
 type Orchestrator struct {
 	service *Service
 	chat    chatports.ChatInterface
 	emitter coreevents.Bus
 	stream  *streamManager
+
+	knowledge chatports.KnowledgeRetriever
+	voice     chatports.VoiceTranscriber
+	tools     chatports.ToolRunner
+
+	moderationMu sync.RWMutex
+	moderator    chatports.ContentModerator
+	moderation   chatdomain.ModerationPolicy
 }
 
 // NewOrchestrator creates a chat orchestrator with required dependencies.
@@ -33,6 +52,40 @@ func NewOrchestrator(chatService *Service, completionService chatports.ChatInter
 	}
 }
 
+// SetKnowledgeRetriever enables grounding replies in a conversation's attached knowledge collection.
+func (o *Orchestrator) SetKnowledgeRetriever(retriever chatports.KnowledgeRetriever) {
+
+	o.knowledge = retriever
+}
+
+// SetVoiceTranscriber enables sending voice attachments as transcribed user messages.
+func (o *Orchestrator) SetVoiceTranscriber(transcriber chatports.VoiceTranscriber) {
+
+	o.voice = transcriber
+}
+
+// SetToolRunner offers external tools to models and executes the calls they make while replying.
+func (o *Orchestrator) SetToolRunner(runner chatports.ToolRunner) {
+
+	o.tools = runner
+}
+
+// SetContentModerator sets the screening backend used by the moderation policy.
+func (o *Orchestrator) SetContentModerator(moderator chatports.ContentModerator) {
+
+	o.moderationMu.Lock()
+	defer o.moderationMu.Unlock()
+	o.moderator = moderator
+}
+
+// SetModerationPolicy replaces the input and output screening policy; it is safe to call while streams run.
+func (o *Orchestrator) SetModerationPolicy(policy chatdomain.ModerationPolicy) {
+
+	o.moderationMu.Lock()
+	defer o.moderationMu.Unlock()
+	o.moderation = policy
+}
+
 // CreateConversation creates a new conversation with the given settings.
 func (o *Orchestrator) CreateConversation(providerName, model string) (*chatdomain.Conversation, error) {
 
@@ -88,6 +141,12 @@ func (o *Orchestrator) UpdateConversationProvider(conversationID, provider strin
 	return o.service.UpdateConversationProvider(conversationID, provider)
 }
 
+// UpdateConversationKnowledge attaches a knowledge collection to a conversation; an empty name detaches it.
+func (o *Orchestrator) UpdateConversationKnowledge(conversationID, collection string, topK int) bool {
+
+	return o.service.UpdateConversationKnowledge(conversationID, strings.TrimSpace(collection), topK)
+}
+
 // DeleteConversation archives a conversation by ID.
 func (o *Orchestrator) DeleteConversation(id string) bool {
 
@@ -126,12 +185,26 @@ func (o *Orchestrator) SendMessage(ctx context.Context, conversationID, content
 		return nil, fmt.Errorf("conversation archived: %s", conversationID)
 	}
 
+	screening, err := o.screen(ctx, chatdomain.ModerationStageInput, content)
+	if err != nil {
+		return nil, err
+	}
+	if screening != nil && screening.Action == chatdomain.ModerationActionRedact {
+		content = chatdomain.ModerationRedactionNotice(screening.Categories)
+	}
+	blocked := screening != nil && screening.Action == chatdomain.ModerationActionBlock
+
 	userMsg := o.service.AddMessage(conversationID, chatdomain.RoleUser, content)
 	if userMsg == nil {
 		return nil, fmt.Errorf("failed to persist user message for conversation: %s", conversationID)
 	}
+	if screening != nil {
+		o.recordInputScreening(conversationID, userMsg, *screening)
+	}
 
-	o.maybeAutoTitle(conversationID, userMsg)
+	if !blocked {
+		o.maybeAutoTitle(conversationID, userMsg)
+	}
 
 	coreevents.Emit(o.emitter, SignalMessageCreated, MessageEventPayload{
 		ConversationID: conversationID,
@@ -139,6 +212,9 @@ func (o *Orchestrator) SendMessage(ctx context.Context, conversationID, content
 		Timestamp:      time.Now().UnixMilli(),
 		Message:        userMsg,
 	})
+	if blocked {
+		return userMsg, nil
+	}
 
 	conv := o.service.GetConversation(conversationID)
 	if conv == nil {
@@ -150,10 +226,17 @@ func (o *Orchestrator) SendMessage(ctx context.Context, conversationID, content
 		return userMsg, nil
 	}
 
+	passages, retrieveErr := o.retrievePassages(ctx, conv.Settings, content)
+
 	streamMsg := o.service.CreateStreamingMessage(conversationID, chatdomain.RoleAssistant)
 	if streamMsg == nil {
 		return userMsg, nil
 	}
+	if citations := citationsFromPassages(passages); len(citations) > 0 {
+		if o.service.SetMessageCitations(conversationID, streamMsg.ID, citations) {
+			streamMsg.Citations = citations
+		}
+	}
 
 	coreevents.Emit(o.emitter, SignalStreamStarted, MessageEventPayload{
 		ConversationID: conversationID,
@@ -169,21 +252,36 @@ func (o *Orchestrator) SendMessage(ctx context.Context, conversationID, content
 		_ = o.service.FinalizeMessage(conversationID, streamMsg.ID, metadata)
 		return userMsg, nil
 	}
+	if retrieveErr != nil {
+		o.emitStreamError(conversationID, streamMsg.ID, retrieveErr)
+		metadata := o.buildMetadata(providerName, conv.Settings.Model, "error", nil, time.Now(), retrieveErr)
+		_ = o.service.FinalizeMessage(conversationID, streamMsg.ID, metadata)
+		return userMsg, nil
+	}
+	// Tools are listed under the stream context so stopping the reply also abandons slow tool servers.
+	ctx, cancel := context.WithCancel(ctx)
+	o.stream.start(conversationID, streamMsg.ID, cancel)
+	tools := o.listTools(ctx)
+	if o.stream.wasCancelled(conversationID, streamMsg.ID) {
+		o.stream.clear(conversationID, streamMsg.ID)
+		metadata := o.buildMetadata(providerName, conv.Settings.Model, "cancelled", nil, time.Now(), nil)
+		_ = o.service.FinalizeMessage(conversationID, streamMsg.ID, metadata)
+		o.emitStreamComplete(conversationID, streamMsg.ID, metadata)
+		return userMsg, nil
+	}
 
 	chatRequest := chatports.ChatRequest{
 		ProviderName: providerName,
 		ModelName:    conv.Settings.Model,
-		Messages:     o.buildChatMessages(conv, streamMsg.ID),
+		Messages:     o.buildChatMessages(conv, streamMsg.ID, passages),
 		Options: chatports.ChatOptions{
 			Temperature: conv.Settings.Temperature,
 			MaxTokens:   conv.Settings.MaxTokens,
 			Stream:      true,
+			Tools:       tools,
 		},
 	}
 
-	ctx, cancel := context.WithCancel(ctx)
-	o.stream.start(conversationID, streamMsg.ID, cancel)
-
 	chunks, err := o.chat.Chat(ctx, chatRequest)
 	if err != nil {
 		o.stream.clear(conversationID, streamMsg.ID)
@@ -193,11 +291,54 @@ func (o *Orchestrator) SendMessage(ctx context.Context, conversationID, content
 		return userMsg, nil
 	}
 
-	go o.consumeStream(conversationID, streamMsg.ID, providerName, conv.Settings.Model, chunks)
+	go o.consumeStream(ctx, conversationID, streamMsg.ID, chatRequest, chunks)
 
 	return userMsg, nil
 }
 
+// SendVoiceMessage transcribes a voice attachment and sends it as a user message after any typed content.
+// An empty provider name transcribes with the conversation's provider.
+func (o *Orchestrator) SendVoiceMessage(ctx context.Context, conversationID, content, audioPath, providerName string) (*chatdomain.Message, error) {
+
+	conversationID = strings.TrimSpace(conversationID)
+	audioPath = strings.TrimSpace(audioPath)
+	if conversationID == "" {
+		return nil, errors.New("conversation ID required")
+	}
+	if audioPath == "" {
+		return nil, errors.New("voice attachment required")
+	}
+	if o.voice == nil {
+		return nil, errors.New("voice transcription not configured")
+	}
+
+	conversation := o.service.GetConversation(conversationID)
+	if conversation == nil {
+		return nil, fmt.Errorf("conversation not found: %s", conversationID)
+	}
+	providerName = strings.TrimSpace(providerName)
+	if providerName == "" {
+		providerName = strings.TrimSpace(conversation.Settings.Provider)
+	}
+	if providerName == "" {
+		return nil, errors.New("transcription provider required")
+	}
+
+	transcript, err := o.voice.TranscribeVoice(ctx, providerName, audioPath)
+	if err != nil {
+		return nil, fmt.Errorf("transcribe voice attachment: %w", err)
+	}
+	transcript = strings.TrimSpace(transcript)
+	if transcript == "" {
+		return nil, errors.New("voice attachment contained no speech")
+	}
+
+	if typed := strings.TrimSpace(content); typed != "" {
+		transcript = typed + "\n\n" + transcript
+	}
+	return o.SendMessage(ctx, conversationID, transcript)
+}
+
 // StopStream cancels the currently running stream.
 func (o *Orchestrator) StopStream() {
 
@@ -246,14 +387,37 @@ func (o *Orchestrator) emitStreamComplete(conversationID, messageID string, meta
 	})
 }
 
-// buildChatMessages builds the chat request message list.
-func (o *Orchestrator) buildChatMessages(conv *chatdomain.Conversation, streamingMessageID string) []chatports.ChatMessage {
+// retrievePassages fetches knowledge-base passages when the conversation has a collection attached.
+func (o *Orchestrator) retrievePassages(ctx context.Context, settings chatdomain.ConversationSettings, query string) ([]chatports.RetrievedPassage, error) {
+
+	collection := strings.TrimSpace(settings.KnowledgeCollection)
+	if collection == "" {
+		return nil, nil
+	}
+	if o.knowledge == nil {
+		return nil, fmt.Errorf("knowledge base not configured for collection %s", collection)
+	}
+	passages, err := o.knowledge.Retrieve(ctx, collection, query, settings.KnowledgeTopK)
+	if err != nil {
+		return nil, fmt.Errorf("knowledge retrieval from %s: %w", collection, err)
+	}
+	return passages, nil
+}
+
+// buildChatMessages builds the chat request message list, merging retrieved passages into the system message.
+func (o *Orchestrator) buildChatMessages(conv *chatdomain.Conversation, streamingMessageID string, passages []chatports.RetrievedPassage) []chatports.ChatMessage {
 
 	conv.Lock()
 	defer conv.Unlock()
 
 	messages := make([]chatports.ChatMessage, 0, len(conv.Messages)+1)
 	systemPrompt := strings.TrimSpace(conv.Settings.SystemPrompt)
+	if knowledge := knowledgeContext(passages); knowledge != "" {
+		if systemPrompt != "" {
+			systemPrompt += "\n\n"
+		}
+		systemPrompt += knowledge
+	}
 	if systemPrompt != "" {
 		messages = append(messages, chatports.ChatMessage{
 			Role:    chatports.ChatRoleSystem,
@@ -277,6 +441,56 @@ func (o *Orchestrator) buildChatMessages(conv *chatdomain.Conversation, streamin
 	return messages
 }
 
+// knowledgeContext formats retrieved passages as numbered sources the model is asked to cite.
+func knowledgeContext(passages []chatports.RetrievedPassage) string {
+
+	if len(passages) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Answer using the numbered sources below when they are relevant, and cite them inline as [1], [2], and so on. ")
+	builder.WriteString("If the sources do not contain the answer, say so rather than guessing.")
+	for index, passage := range passages {
+		fmt.Fprintf(&builder, "\n\n[%d] %s (lines %d-%d)\n%s", index+1, passage.SourcePath, passage.StartLine, passage.EndLine, strings.TrimSpace(passage.Content))
+	}
+	return builder.String()
+}
+
+// citationsFromPassages maps retrieved passages to message citations numbered as in knowledgeContext.
+func citationsFromPassages(passages []chatports.RetrievedPassage) []chatdomain.Citation {
+
+	if len(passages) == 0 {
+		return nil
+	}
+
+	citations := make([]chatdomain.Citation, 0, len(passages))
+	for index, passage := range passages {
+		citations = append(citations, chatdomain.Citation{
+			Index:      index + 1,
+			Collection: passage.Collection,
+			SourcePath: passage.SourcePath,
+			StartLine:  passage.StartLine,
+			EndLine:    passage.EndLine,
+			Score:      passage.Score,
+			Snippet:    citationSnippet(passage.Content),
+		})
+	}
+	return citations
+}
+
+// citationSnippet shortens passage text for display next to a citation.
+func citationSnippet(content string) string {
+
+	const maxSnippetRunes = 240
+	snippet := strings.Join(strings.Fields(content), " ")
+	runes := []rune(snippet)
+	if len(runes) <= maxSnippetRunes {
+		return snippet
+	}
+	return string(runes[:maxSnippetRunes]) + "..."
+}
+
 // textFromBlocks builds a text-only content string from message blocks.
 func textFromBlocks(blocks []chatdomain.Block) string {
 
@@ -294,51 +508,87 @@ func textFromBlocks(blocks []chatdomain.Block) string {
 }
 
 // consumeStream handles incoming chat chunks and emits events.
-func (o *Orchestrator) consumeStream(conversationID, messageID, providerName, fallbackModel string, chunks <-chan chatports.ChatChunk) {
+// When the model calls tools, the calls run and the request continues with their results for up to maxToolRounds rounds;
+// calls past the limit are not run and the reply finishes with finishReasonToolRoundLimit.
+// When output screening can redact or block, text is stored but not streamed to the UI until the reply has been screened,
+// including replies that end early through cancellation or a stream error.
+func (o *Orchestrator) consumeStream(ctx context.Context, conversationID, messageID string, request chatports.ChatRequest, chunks <-chan chatports.ChatChunk) {
 
 	defer o.stream.clear(conversationID, messageID)
+	withhold := o.withholdsOutput()
 
+	providerName, fallbackModel := request.ProviderName, request.ModelName
 	start := time.Now()
 	var (
 		finishReason string
 		usage        *chatports.ChatUsage
 		model        string
+		wroteText    bool
+		actionIDs    = make(map[string]bool)
 	)
 
-	for chunk := range chunks {
-		if chunk.Error != "" {
-			chunkErr := errors.New(chunk.Error)
-			if isContextCanceledMessage(chunk.Error) {
-				metadata := o.buildMetadata(providerName, chooseModel(model, fallbackModel), "cancelled", usage, start, nil)
-				_ = o.service.FinalizeMessage(conversationID, messageID, metadata)
-				o.emitStreamComplete(conversationID, messageID, metadata)
-			} else {
-				o.emitStreamError(conversationID, messageID, chunkErr)
-				metadata := o.buildMetadata(providerName, chooseModel(model, fallbackModel), "error", usage, start, chunkErr)
-				_ = o.service.FinalizeMessage(conversationID, messageID, metadata)
+	for round := 1; ; round++ {
+		var (
+			roundText  strings.Builder
+			roundUsage *chatports.ChatUsage
+			toolCalls  []chatports.ChatToolCall
+		)
+		for chunk := range chunks {
+			if chunk.Error != "" {
+				usage = addUsage(usage, roundUsage)
+				o.finishStreamWithError(ctx, conversationID, messageID, providerName, chooseModel(model, fallbackModel), usage, start, errors.New(chunk.Error), withhold)
+				return
 			}
-			return
-		}
 
-		if chunk.Content != "" {
-			if !o.service.AppendToMessage(conversationID, messageID, 0, chunk.Content) {
-				err := fmt.Errorf("failed to persist stream chunk")
-				o.emitStreamError(conversationID, messageID, err)
-				metadata := o.buildMetadata(providerName, chooseModel(model, fallbackModel), "error", usage, start, err)
-				_ = o.service.FinalizeMessage(conversationID, messageID, metadata)
-				return
+			if chunk.Content != "" {
+				content := chunk.Content
+				if roundText.Len() == 0 && wroteText {
+					content = "\n\n" + content
+				}
+				if !o.service.AppendToMessage(conversationID, messageID, 0, content) {
+					err := fmt.Errorf("failed to persist stream chunk")
+					o.finishStreamWithError(ctx, conversationID, messageID, providerName, chooseModel(model, fallbackModel), addUsage(usage, roundUsage), start, err, withhold)
+					return
+				}
+				if !withhold {
+					o.emitStreamChunk(conversationID, messageID, 0, content)
+				}
+				roundText.WriteString(chunk.Content)
+				wroteText = true
 			}
-			o.emitStreamChunk(conversationID, messageID, 0, chunk.Content)
+			if chunk.Model != "" {
+				model = chunk.Model
+			}
+			if chunk.Usage != nil {
+				roundUsage = chunk.Usage
+			}
+			if chunk.FinishReason != "" {
+				finishReason = chunk.FinishReason
+			}
+			toolCalls = append(toolCalls, chunk.ToolCalls...)
 		}
-		if chunk.Model != "" {
-			model = chunk.Model
+		usage = addUsage(usage, roundUsage)
+
+		if len(toolCalls) == 0 || o.tools == nil || o.stream.wasCancelled(conversationID, messageID) {
+			break
 		}
-		if chunk.Usage != nil {
-			usage = chunk.Usage
+		if round > maxToolRounds {
+			finishReason = finishReasonToolRoundLimit
+			break
 		}
-		if chunk.FinishReason != "" {
-			finishReason = chunk.FinishReason
+		request.Messages = append(request.Messages, o.runToolCalls(ctx, conversationID, messageID, round, roundText.String(), toolCalls, actionIDs, withhold)...)
+		if o.stream.wasCancelled(conversationID, messageID) {
+			finishReason = "cancelled"
+			break
+		}
+
+		next, err := o.chat.Chat(ctx, request)
+		if err != nil {
+			o.finishStreamWithError(ctx, conversationID, messageID, providerName, chooseModel(model, fallbackModel), usage, start, err, withhold)
+			return
 		}
+		chunks = next
+		finishReason = ""
 	}
 
 	if finishReason == "" && o.stream.wasCancelled(conversationID, messageID) {
@@ -346,12 +596,282 @@ func (o *Orchestrator) consumeStream(conversationID, messageID, providerName, fa
 	}
 
 	metadata := o.buildMetadata(providerName, chooseModel(model, fallbackModel), finishReason, usage, start, nil)
+	replaced := o.screenOutput(ctx, conversationID, messageID, metadata)
 	if !o.service.FinalizeMessage(conversationID, messageID, metadata) {
 		err := fmt.Errorf("failed to persist stream completion")
 		o.emitStreamError(conversationID, messageID, err)
 		return
 	}
 	o.emitStreamComplete(conversationID, messageID, metadata)
+	if replaced || withhold {
+		o.emitMessageUpdated(conversationID, messageID, false)
+	}
+}
+
+// finishStreamWithError screens and finalizes a reply whose stream failed, treating cancellation as a normal stop.
+// The partial text is screened like a completed reply so redact and block policies never publish it unscreened.
+func (o *Orchestrator) finishStreamWithError(ctx context.Context, conversationID, messageID, providerName, model string, usage *chatports.ChatUsage, start time.Time, err error, withhold bool) {
+
+	var metadata *chatdomain.MessageMetadata
+	if isContextCanceledMessage(err.Error()) {
+		metadata = o.buildMetadata(providerName, model, "cancelled", usage, start, nil)
+	} else {
+		o.emitStreamError(conversationID, messageID, err)
+		metadata = o.buildMetadata(providerName, model, "error", usage, start, err)
+	}
+
+	replaced := o.screenOutput(ctx, conversationID, messageID, metadata)
+	_ = o.service.FinalizeMessage(conversationID, messageID, metadata)
+	if metadata.FinishReason == "cancelled" {
+		o.emitStreamComplete(conversationID, messageID, metadata)
+	}
+	if replaced || withhold {
+		o.emitMessageUpdated(conversationID, messageID, false)
+	}
+}
+
+// listTools returns the tools offered to the model, or nil when no tool runner is set.
+// Tools are optional, so a listing failure sends the message without them rather than failing the reply.
+func (o *Orchestrator) listTools(ctx context.Context) []chatports.ChatTool {
+
+	if o.tools == nil {
+		return nil
+	}
+	tools, err := o.tools.ListTools(ctx)
+	if err != nil {
+		return nil
+	}
+	return tools
+}
+
+// runToolCalls executes one round of tool calls, recording each as an action block on the reply.
+// The calls go back to the model as an assistant turn carrying them, followed by one tool turn per
+// result, so tool output is never presented as something the user wrote.
+// actionIDs holds the action IDs already used on the reply; a missing or repeated call ID is replaced
+// so one round's action block never overwrites another's.
+// withhold keeps unscreened reply text out of the published updates.
+func (o *Orchestrator) runToolCalls(ctx context.Context, conversationID, messageID string, round int, text string, calls []chatports.ChatToolCall, actionIDs map[string]bool, withhold bool) []chatports.ChatMessage {
+
+	assistant := chatports.ChatMessage{Role: chatports.ChatRoleAssistant, Content: strings.TrimSpace(text)}
+	results := make([]chatports.ChatMessage, 0, len(calls))
+	for index, call := range calls {
+		arguments, _ := json.Marshal(call.Arguments)
+		action := chatdomain.ActionExecution{
+			ID:          strings.TrimSpace(call.ID),
+			ToolName:    call.Name,
+			Description: "Arguments: " + string(arguments),
+			Args:        call.Arguments,
+			Status:      chatdomain.ActionStatusRunning,
+			StartedAt:   time.Now().UnixMilli(),
+		}
+		if action.ID == "" || actionIDs[action.ID] {
+			action.ID = fmt.Sprintf("%s-tool-%d-%d", messageID, round, index+1)
+		}
+		actionIDs[action.ID] = true
+		o.service.SetMessageAction(conversationID, messageID, action)
+		o.emitMessageUpdated(conversationID, messageID, withhold)
+
+		output, err := o.tools.CallTool(ctx, call)
+		action.CompletedAt = time.Now().UnixMilli()
+		action.Status = chatdomain.ActionStatusCompleted
+		action.Result = output
+		if err != nil {
+			action.Status = chatdomain.ActionStatusFailed
+			action.Result = err.Error()
+		}
+		o.service.SetMessageAction(conversationID, messageID, action)
+		o.emitMessageUpdated(conversationID, messageID, withhold)
+
+		call.ID = action.ID
+		assistant.ToolCalls = append(assistant.ToolCalls, call)
+		content := action.Result
+		if action.Status == chatdomain.ActionStatusFailed {
+			content = "error: " + action.Result
+		}
+		results = append(results, chatports.ChatMessage{Role: chatports.ChatRoleTool, Content: content, ToolCallID: action.ID})
+	}
+
+	return append([]chatports.ChatMessage{assistant}, results...)
+}
+
+// addUsage sums token usage across tool rounds.
+func addUsage(total, round *chatports.ChatUsage) *chatports.ChatUsage {
+
+	if round == nil {
+		return total
+	}
+	if total == nil {
+		sum := *round
+		return &sum
+	}
+	return &chatports.ChatUsage{
+		InputTokens:  total.InputTokens + round.InputTokens,
+		OutputTokens: total.OutputTokens + round.OutputTokens,
+		TotalTokens:  total.TotalTokens + round.TotalTokens,
+	}
+}
+
+// screen runs one moderation stage over text; it returns nil when the stage is off.
+// Outcomes that flag nothing the policy acts on are recorded with the allow action.
+func (o *Orchestrator) screen(ctx context.Context, stage chatdomain.ModerationStage, text string) (*chatdomain.ModerationOutcome, error) {
+
+	o.moderationMu.RLock()
+	moderator, policy := o.moderator, o.moderation
+	o.moderationMu.RUnlock()
+
+	action := policy.ActionFor(stage)
+	if action == chatdomain.ModerationActionOff {
+		return nil, nil
+	}
+	if moderator == nil {
+		return nil, errors.New("moderation not configured")
+	}
+
+	verdict, err := moderator.Moderate(ctx, policy.Provider, policy.Model, text)
+	if err != nil {
+		return nil, fmt.Errorf("moderate %s: %w", stage, err)
+	}
+
+	outcome := &chatdomain.ModerationOutcome{
+		Stage:      stage,
+		Action:     chatdomain.ModerationActionAllow,
+		Flagged:    verdict.Flagged,
+		Categories: verdict.Categories,
+		Provider:   policy.Provider,
+		Model:      chooseModel(verdict.Model, policy.Model),
+	}
+	if matched, ok := policy.Triggered(verdict.Flagged, verdict.Categories); ok {
+		outcome.Action = action
+		outcome.Categories = matched
+	}
+	return outcome, nil
+}
+
+// recordInputScreening stores an input outcome on the user message, replacing its content when blocked.
+func (o *Orchestrator) recordInputScreening(conversationID string, userMsg *chatdomain.Message, outcome chatdomain.ModerationOutcome) {
+
+	if outcome.Action == chatdomain.ModerationActionBlock {
+		blocks := []chatdomain.Block{{
+			Type:    chatdomain.BlockTypeError,
+			Content: chatdomain.ModerationBlockNotice(outcome.Stage, outcome.Categories),
+		}}
+		if o.service.ReplaceMessageBlocks(conversationID, userMsg.ID, blocks) {
+			userMsg.Blocks = blocks
+		}
+	}
+	metadata := &chatdomain.MessageMetadata{Moderation: []chatdomain.ModerationOutcome{outcome}}
+	if o.service.FinalizeMessage(conversationID, userMsg.ID, metadata) {
+		userMsg.Metadata = metadata
+	}
+}
+
+// withholdsOutput reports whether output screening may replace a reply, so its text must not be streamed before screening.
+func (o *Orchestrator) withholdsOutput() bool {
+
+	o.moderationMu.RLock()
+	action := o.moderation.ActionFor(chatdomain.ModerationStageOutput)
+	o.moderationMu.RUnlock()
+	return action == chatdomain.ModerationActionRedact || action == chatdomain.ModerationActionBlock
+}
+
+// screenOutput screens a completed reply, records the outcome in metadata, and reports whether the blocks were replaced.
+// Screening is bounded by outputScreeningTimeout but outlives stream cancellation, so stopped and failed replies
+// are screened too. When it fails, redact and block policies withhold the reply rather than show unscreened text.
+func (o *Orchestrator) screenOutput(ctx context.Context, conversationID, messageID string, metadata *chatdomain.MessageMetadata) bool {
+
+	conv := o.service.GetConversation(conversationID)
+	if conv == nil {
+		return false
+	}
+	var text string
+	conv.Lock()
+	for _, msg := range conv.Messages {
+		if msg.ID == messageID {
+			text = textFromBlocks(msg.Blocks)
+			break
+		}
+	}
+	conv.Unlock()
+	if strings.TrimSpace(text) == "" {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), outputScreeningTimeout)
+	defer cancel()
+	outcome, err := o.screen(ctx, chatdomain.ModerationStageOutput, text)
+	if err != nil {
+		if metadata.ErrorMessage == "" {
+			metadata.ErrorMessage = err.Error()
+		}
+		o.moderationMu.RLock()
+		action := o.moderation.ActionFor(chatdomain.ModerationStageOutput)
+		o.moderationMu.RUnlock()
+		if action == chatdomain.ModerationActionWarn {
+			return false
+		}
+		return o.service.ReplaceMessageBlocks(conversationID, messageID, []chatdomain.Block{{
+			Type:    chatdomain.BlockTypeError,
+			Content: "Response withheld: " + err.Error(),
+		}})
+	}
+	if outcome == nil {
+		return false
+	}
+	metadata.Moderation = append(metadata.Moderation, *outcome)
+
+	switch outcome.Action {
+	case chatdomain.ModerationActionRedact:
+		return o.service.ReplaceMessageBlocks(conversationID, messageID, []chatdomain.Block{{
+			Type:    chatdomain.BlockTypeText,
+			Content: chatdomain.ModerationRedactionNotice(outcome.Categories),
+		}})
+	case chatdomain.ModerationActionBlock:
+		return o.service.ReplaceMessageBlocks(conversationID, messageID, []chatdomain.Block{{
+			Type:    chatdomain.BlockTypeError,
+			Content: chatdomain.ModerationBlockNotice(outcome.Stage, outcome.Categories),
+		}})
+	default:
+		return false
+	}
+}
+
+// emitMessageUpdated publishes the stored message after its content changed outside the stream.
+// withholdText drops text blocks that have not been screened yet.
+func (o *Orchestrator) emitMessageUpdated(conversationID, messageID string, withholdText bool) {
+
+	conv := o.service.GetConversation(conversationID)
+	if conv == nil {
+		return
+	}
+	conv.Lock()
+	var message *chatdomain.Message
+	for _, msg := range conv.Messages {
+		if msg.ID == messageID {
+			message = msg
+			break
+		}
+	}
+	conv.Unlock()
+	if message == nil {
+		return
+	}
+	if withholdText {
+		withheld := *message
+		withheld.Blocks = make([]chatdomain.Block, 0, len(message.Blocks))
+		for _, block := range message.Blocks {
+			if block.Type != chatdomain.BlockTypeText {
+				withheld.Blocks = append(withheld.Blocks, block)
+			}
+		}
+		message = &withheld
+	}
+
+	coreevents.Emit(o.emitter, SignalMessageUpdated, MessageEventPayload{
+		ConversationID: conversationID,
+		MessageID:      messageID,
+		Timestamp:      time.Now().UnixMilli(),
+		Message:        message,
+	})
 }
 
 // buildMetadata builds message metadata from provider results.
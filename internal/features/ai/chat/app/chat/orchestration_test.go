@@ -0,0 +1,811 @@
+// orchestration_test.go verifies knowledge-grounded, voice, moderated, and tool-calling chat orchestration.
+// internal/features/ai/chat/app/chat/orchestration_test.go
+package chat
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MadeByDoug/wls-chatbot/internal/core/datastore"
+	coreevents "github.com/MadeByDoug/wls-chatbot/internal/core/events"
+	"github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/adapters/chatrepo"
+	chatdomain "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/domain"
+	chatports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/ports"
+)
+
+// TestSendMessageInjectsKnowledgeAndRecordsCitations validates retrieval, prompt injection, and citation persistence.
+func TestSendMessageInjectsKnowledgeAndRecordsCitations(t *testing.T) {
+
+	completion := &fakeChat{requests: make(chan chatports.ChatRequest, 1)}
+	retriever := &fakeRetriever{passages: []chatports.RetrievedPassage{
+		{Collection: "handbook", SourcePath: "/docs/leave.md", StartLine: 3, EndLine: 8, Content: "Employees get 25 days of leave.", Score: 0.91},
+		{Collection: "handbook", SourcePath: "/docs/pay.md", StartLine: 1, EndLine: 2, Content: "Payday is monthly.", Score: 0.42},
+	}}
+	orchestrator := NewOrchestrator(NewService(newTestChatRepository(t)), completion, nil)
+	orchestrator.SetKnowledgeRetriever(retriever)
+
+	conv, err := orchestrator.service.CreateConversation(chatdomain.ConversationSettings{Provider: "openai", Model: "gpt-4o", SystemPrompt: "Be brief."})
+	if err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+	if !orchestrator.UpdateConversationKnowledge(conv.ID, " handbook ", 2) {
+		t.Fatalf("expected knowledge collection to attach")
+	}
+
+	if _, err := orchestrator.SendMessage(context.Background(), conv.ID, "How much leave do I get?"); err != nil {
+		t.Fatalf("send message: %v", err)
+	}
+
+	var request chatports.ChatRequest
+	select {
+	case request = <-completion.requests:
+	case <-time.After(time.Second):
+		t.Fatalf("chat was not called")
+	}
+	if retriever.collection != "handbook" || retriever.topK != 2 || retriever.query != "How much leave do I get?" {
+		t.Fatalf("unexpected retrieval %#v", retriever)
+	}
+	if len(request.Messages) != 2 || request.Messages[0].Role != chatports.ChatRoleSystem {
+		t.Fatalf("unexpected messages %#v", request.Messages)
+	}
+	system := request.Messages[0].Content
+	if !strings.HasPrefix(system, "Be brief.\n\n") || !strings.Contains(system, "[1] /docs/leave.md (lines 3-8)\nEmployees get 25 days of leave.") || !strings.Contains(system, "[2] /docs/pay.md") {
+		t.Fatalf("expected cited passages in system prompt, got %q", system)
+	}
+
+	stored := waitForFinalized(t, orchestrator, conv.ID)
+	citations := stored.Citations
+	if len(citations) != 2 || citations[0].Index != 1 || citations[0].SourcePath != "/docs/leave.md" || citations[1].StartLine != 1 {
+		t.Fatalf("unexpected citations %#v", citations)
+	}
+	if textFromBlocks(stored.Blocks) != "You get 25 days [1]." {
+		t.Fatalf("unexpected reply %#v", stored.Blocks)
+	}
+}
+
+// TestSendMessageFailsWhenRetrievalFails validates retrieval errors finalize the reply without calling chat.
+func TestSendMessageFailsWhenRetrievalFails(t *testing.T) {
+
+	completion := &fakeChat{requests: make(chan chatports.ChatRequest, 1)}
+	orchestrator := NewOrchestrator(NewService(newTestChatRepository(t)), completion, nil)
+	orchestrator.SetKnowledgeRetriever(&fakeRetriever{err: errors.New("collection handbook not found")})
+
+	conv, err := orchestrator.service.CreateConversation(chatdomain.ConversationSettings{Provider: "openai", Model: "gpt-4o"})
+	if err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+	orchestrator.UpdateConversationKnowledge(conv.ID, "handbook", 0)
+
+	if _, err := orchestrator.SendMessage(context.Background(), conv.ID, "hello"); err != nil {
+		t.Fatalf("send message: %v", err)
+	}
+
+	stored := waitForFinalized(t, orchestrator, conv.ID)
+	if stored.Metadata == nil || !strings.Contains(stored.Metadata.ErrorMessage, "collection handbook not found") {
+		t.Fatalf("expected retrieval error metadata, got %#v", stored.Metadata)
+	}
+	if len(completion.requests) != 0 {
+		t.Fatalf("chat should not be called when retrieval fails")
+	}
+}
+
+// TestSendVoiceMessageTranscribesIntoUserMessage validates provider fallback and typed-content joining.
+func TestSendVoiceMessageTranscribesIntoUserMessage(t *testing.T) {
+
+	completion := &fakeChat{requests: make(chan chatports.ChatRequest, 1)}
+	transcriber := &fakeTranscriber{text: " what is on my calendar? "}
+	orchestrator := NewOrchestrator(NewService(newTestChatRepository(t)), completion, nil)
+	orchestrator.SetVoiceTranscriber(transcriber)
+
+	conv, err := orchestrator.service.CreateConversation(chatdomain.ConversationSettings{Provider: "openai", Model: "gpt-4o"})
+	if err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+
+	userMsg, err := orchestrator.SendVoiceMessage(context.Background(), conv.ID, "Quick question:", "/tmp/memo.m4a", "")
+	if err != nil {
+		t.Fatalf("send voice message: %v", err)
+	}
+	if transcriber.provider != "openai" || transcriber.path != "/tmp/memo.m4a" {
+		t.Fatalf("unexpected transcription call %#v", transcriber)
+	}
+	if textFromBlocks(userMsg.Blocks) != "Quick question:\n\nwhat is on my calendar?" {
+		t.Fatalf("unexpected user message %#v", userMsg.Blocks)
+	}
+	waitForFinalized(t, orchestrator, conv.ID)
+
+	transcriber.text = "   "
+	if _, err := orchestrator.SendVoiceMessage(context.Background(), conv.ID, "", "/tmp/silence.wav", "groq"); err == nil || !strings.Contains(err.Error(), "no speech") {
+		t.Fatalf("expected empty transcript error, got %v", err)
+	}
+	if transcriber.provider != "groq" {
+		t.Fatalf("expected explicit provider, got %q", transcriber.provider)
+	}
+}
+
+// TestSendMessageBlocksFlaggedInput validates blocked input is stored as an error block without calling chat.
+func TestSendMessageBlocksFlaggedInput(t *testing.T) {
+
+	completion := &fakeChat{requests: make(chan chatports.ChatRequest, 1)}
+	moderator := &fakeModerator{flagged: map[string][]string{"something hateful": {"hate", "violence"}}}
+	orchestrator := NewOrchestrator(NewService(newTestChatRepository(t)), completion, nil)
+	orchestrator.SetContentModerator(moderator)
+	orchestrator.SetModerationPolicy(chatdomain.ModerationPolicy{Provider: "openai", Input: chatdomain.ModerationActionBlock, Categories: []string{"hate"}})
+
+	conv, err := orchestrator.service.CreateConversation(chatdomain.ConversationSettings{Provider: "openai", Model: "gpt-4o"})
+	if err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+
+	userMsg, err := orchestrator.SendMessage(context.Background(), conv.ID, "something hateful")
+	if err != nil {
+		t.Fatalf("send message: %v", err)
+	}
+	if len(userMsg.Blocks) != 1 || userMsg.Blocks[0].Type != chatdomain.BlockTypeError || userMsg.Blocks[0].Content != "Message blocked by moderation: hate" {
+		t.Fatalf("unexpected blocked message %#v", userMsg.Blocks)
+	}
+	if moderator.provider != "openai" {
+		t.Fatalf("expected policy provider, got %q", moderator.provider)
+	}
+
+	stored := orchestrator.GetConversation(conv.ID)
+	if len(stored.Messages) != 1 || len(completion.requests) != 0 {
+		t.Fatalf("expected no reply for blocked input, got %d messages", len(stored.Messages))
+	}
+	metadata := stored.Messages[0].Metadata
+	if metadata == nil || len(metadata.Moderation) != 1 || metadata.Moderation[0].Action != chatdomain.ModerationActionBlock || metadata.Moderation[0].Stage != chatdomain.ModerationStageInput {
+		t.Fatalf("expected input block outcome, got %#v", metadata)
+	}
+}
+
+// TestSendMessageRedactsFlaggedOutput validates output redaction and allow outcomes for clean input.
+func TestSendMessageRedactsFlaggedOutput(t *testing.T) {
+
+	completion := &fakeChat{requests: make(chan chatports.ChatRequest, 1)}
+	moderator := &fakeModerator{flagged: map[string][]string{"You get 25 days [1].": {"harassment"}}}
+	bus := &recordingBus{}
+	orchestrator := NewOrchestrator(NewService(newTestChatRepository(t)), completion, bus)
+	orchestrator.SetContentModerator(moderator)
+	orchestrator.SetModerationPolicy(chatdomain.ModerationPolicy{Provider: "openai", Model: "omni-moderation-latest", Input: chatdomain.ModerationActionWarn, Output: chatdomain.ModerationActionRedact})
+
+	conv, err := orchestrator.service.CreateConversation(chatdomain.ConversationSettings{Provider: "openai", Model: "gpt-4o"})
+	if err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+
+	userMsg, err := orchestrator.SendMessage(context.Background(), conv.ID, "How much leave do I get?")
+	if err != nil {
+		t.Fatalf("send message: %v", err)
+	}
+	if userMsg.Metadata == nil || len(userMsg.Metadata.Moderation) != 1 || userMsg.Metadata.Moderation[0].Action != chatdomain.ModerationActionAllow {
+		t.Fatalf("expected allow outcome on user message, got %#v", userMsg.Metadata)
+	}
+
+	stored := waitForFinalized(t, orchestrator, conv.ID)
+	if textFromBlocks(stored.Blocks) != "[Content redacted by moderation: harassment]" {
+		t.Fatalf("expected redacted reply, got %#v", stored.Blocks)
+	}
+	if stored.Metadata == nil || len(stored.Metadata.Moderation) != 1 {
+		t.Fatalf("expected output outcome, got %#v", stored.Metadata)
+	}
+	outcome := stored.Metadata.Moderation[0]
+	if outcome.Stage != chatdomain.ModerationStageOutput || outcome.Action != chatdomain.ModerationActionRedact || !outcome.Flagged || outcome.Model != "omni-moderation-latest" {
+		t.Fatalf("unexpected output outcome %#v", outcome)
+	}
+	if stored.Metadata.FinishReason != "stop" {
+		t.Fatalf("expected stream metadata to be kept, got %#v", stored.Metadata)
+	}
+	if !moderator.deadline {
+		t.Fatalf("expected output screening to run with a deadline")
+	}
+
+	updated := waitForEvent(t, bus, "chat.message.update")
+	if len(bus.named("chat.stream.chunk")) != 0 {
+		t.Fatalf("expected unscreened chunks to be withheld from the UI")
+	}
+	if message := updated.(MessageEventPayload).Message; textFromBlocks(message.Blocks) != "[Content redacted by moderation: harassment]" {
+		t.Fatalf("expected redacted update, got %#v", message.Blocks)
+	}
+}
+
+// TestSendMessageStreamsOutputScreenedWithWarn validates warn-only output screening still streams chunks.
+func TestSendMessageStreamsOutputScreenedWithWarn(t *testing.T) {
+
+	bus := &recordingBus{}
+	orchestrator := NewOrchestrator(NewService(newTestChatRepository(t)), &fakeChat{requests: make(chan chatports.ChatRequest, 1)}, bus)
+	orchestrator.SetContentModerator(&fakeModerator{flagged: map[string][]string{"You get 25 days [1].": {"harassment"}}})
+	orchestrator.SetModerationPolicy(chatdomain.ModerationPolicy{Provider: "openai", Output: chatdomain.ModerationActionWarn})
+
+	conv, err := orchestrator.service.CreateConversation(chatdomain.ConversationSettings{Provider: "openai", Model: "gpt-4o"})
+	if err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+	if _, err := orchestrator.SendMessage(context.Background(), conv.ID, "How much leave do I get?"); err != nil {
+		t.Fatalf("send message: %v", err)
+	}
+
+	waitForEvent(t, bus, "chat.stream.complete")
+	if len(bus.named("chat.stream.chunk")) == 0 {
+		t.Fatalf("expected warn screening to stream chunks")
+	}
+}
+
+// TestSendMessageScreensCancelledOutput validates a reply stopped mid-stream is screened before it is shown or kept.
+func TestSendMessageScreensCancelledOutput(t *testing.T) {
+
+	completion := &cancellableChat{content: "You get 25 days [1].", sent: make(chan struct{})}
+	moderator := &fakeModerator{flagged: map[string][]string{"You get 25 days [1].": {"harassment"}}}
+	bus := &recordingBus{}
+	orchestrator := NewOrchestrator(NewService(newTestChatRepository(t)), completion, bus)
+	orchestrator.SetContentModerator(moderator)
+	orchestrator.SetModerationPolicy(chatdomain.ModerationPolicy{Provider: "openai", Output: chatdomain.ModerationActionBlock})
+
+	conv, err := orchestrator.service.CreateConversation(chatdomain.ConversationSettings{Provider: "openai", Model: "gpt-4o"})
+	if err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+	if _, err := orchestrator.SendMessage(context.Background(), conv.ID, "How much leave do I get?"); err != nil {
+		t.Fatalf("send message: %v", err)
+	}
+	<-completion.sent
+	orchestrator.StopStream()
+
+	stored := waitForFinalized(t, orchestrator, conv.ID)
+	assertOutputBlocked(t, bus, stored)
+	if stored.Metadata.FinishReason != "cancelled" {
+		t.Fatalf("expected cancelled finish reason, got %#v", stored.Metadata)
+	}
+}
+
+// TestSendMessageScreensOutputBeforeStreamError validates partial text from a failed stream is screened before it is shown or kept.
+func TestSendMessageScreensOutputBeforeStreamError(t *testing.T) {
+
+	completion := &scriptedChat{rounds: [][]chatports.ChatChunk{{
+		{Content: "You get 25 days [1]."},
+		{Error: "upstream connection reset"},
+	}}}
+	bus := &recordingBus{}
+	orchestrator := NewOrchestrator(NewService(newTestChatRepository(t)), completion, bus)
+	orchestrator.SetContentModerator(&fakeModerator{flagged: map[string][]string{"You get 25 days [1].": {"harassment"}}})
+	orchestrator.SetModerationPolicy(chatdomain.ModerationPolicy{Provider: "openai", Output: chatdomain.ModerationActionBlock})
+
+	conv, err := orchestrator.service.CreateConversation(chatdomain.ConversationSettings{Provider: "openai", Model: "gpt-4o"})
+	if err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+	if _, err := orchestrator.SendMessage(context.Background(), conv.ID, "How much leave do I get?"); err != nil {
+		t.Fatalf("send message: %v", err)
+	}
+
+	stored := waitForFinalized(t, orchestrator, conv.ID)
+	assertOutputBlocked(t, bus, stored)
+	if stored.Metadata.FinishReason != "error" {
+		t.Fatalf("expected error finish reason, got %#v", stored.Metadata)
+	}
+	if errorEvents := bus.named("chat.stream.error"); len(errorEvents) != 1 || errorEvents[0].(StreamChunkEventPayload).Error != "upstream connection reset" {
+		t.Fatalf("expected one stream error event, got %#v", errorEvents)
+	}
+}
+
+// TestSendMessageFailsWhenModerationFails validates input screening errors reject the message before it is stored.
+func TestSendMessageFailsWhenModerationFails(t *testing.T) {
+
+	orchestrator := NewOrchestrator(NewService(newTestChatRepository(t)), &fakeChat{requests: make(chan chatports.ChatRequest, 1)}, nil)
+	orchestrator.SetContentModerator(&fakeModerator{err: errors.New("rate limited")})
+	orchestrator.SetModerationPolicy(chatdomain.ModerationPolicy{Provider: "openai", Input: chatdomain.ModerationActionRedact})
+
+	conv, err := orchestrator.service.CreateConversation(chatdomain.ConversationSettings{Provider: "openai", Model: "gpt-4o"})
+	if err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+
+	if _, err := orchestrator.SendMessage(context.Background(), conv.ID, "hello"); err == nil || !strings.Contains(err.Error(), "moderate input: rate limited") {
+		t.Fatalf("expected moderation error, got %v", err)
+	}
+	if stored := orchestrator.GetConversation(conv.ID); len(stored.Messages) != 0 {
+		t.Fatalf("expected no stored messages, got %d", len(stored.Messages))
+	}
+}
+
+// TestSendMessageRunsToolCallsAndContinues validates tool declarations, action blocks, and result feedback.
+func TestSendMessageRunsToolCallsAndContinues(t *testing.T) {
+
+	completion := &scriptedChat{rounds: [][]chatports.ChatChunk{
+		{
+			{Content: "Let me check."},
+			{ToolCalls: []chatports.ChatToolCall{{ID: "call-1", Name: "files__read_file", Arguments: map[string]interface{}{"path": "todo.txt"}}}},
+			{ToolCalls: []chatports.ChatToolCall{{Name: "files__delete"}}, FinishReason: "tool_calls", Usage: &chatports.ChatUsage{InputTokens: 10, OutputTokens: 5}},
+		},
+		{
+			{Content: "You have two tasks."},
+			{FinishReason: "stop", Usage: &chatports.ChatUsage{InputTokens: 30, OutputTokens: 7}},
+		},
+	}}
+	runner := &fakeToolRunner{
+		tools:   []chatports.ChatTool{{Name: "files__read_file", InputSchema: map[string]interface{}{"type": "object"}}},
+		outputs: map[string]string{"files__read_file": "buy milk\nwalk dog"},
+	}
+	orchestrator := NewOrchestrator(NewService(newTestChatRepository(t)), completion, nil)
+	orchestrator.SetToolRunner(runner)
+
+	conv, err := orchestrator.service.CreateConversation(chatdomain.ConversationSettings{Provider: "openai", Model: "gpt-4o"})
+	if err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+	if _, err := orchestrator.SendMessage(context.Background(), conv.ID, "What is on my list?"); err != nil {
+		t.Fatalf("send message: %v", err)
+	}
+
+	stored := waitForFinalized(t, orchestrator, conv.ID)
+	if textFromBlocks(stored.Blocks) != "Let me check.\n\nYou have two tasks." {
+		t.Fatalf("unexpected reply text %q", textFromBlocks(stored.Blocks))
+	}
+	if len(stored.Blocks) != 3 || stored.Blocks[1].Type != chatdomain.BlockTypeAction || stored.Blocks[2].Type != chatdomain.BlockTypeAction {
+		t.Fatalf("expected text and two action blocks, got %#v", stored.Blocks)
+	}
+	read, failed := stored.Blocks[1].Action, stored.Blocks[2].Action
+	if read.ID != "call-1" || read.Status != chatdomain.ActionStatusCompleted || read.Result != "buy milk\nwalk dog" || read.Description != `Arguments: {"path":"todo.txt"}` || read.CompletedAt == 0 {
+		t.Fatalf("unexpected completed action %#v", read)
+	}
+	if failed.ID == "" || failed.Status != chatdomain.ActionStatusFailed || !strings.Contains(failed.Result, "unknown tool") {
+		t.Fatalf("unexpected failed action %#v", failed)
+	}
+	if stored.Metadata == nil || stored.Metadata.FinishReason != "stop" || stored.Metadata.TokensIn != 40 || stored.Metadata.TokensOut != 12 {
+		t.Fatalf("unexpected metadata %#v", stored.Metadata)
+	}
+
+	requests := completion.recorded()
+	if len(requests) != 2 || len(requests[0].Options.Tools) != 1 || requests[0].Options.Tools[0].Name != "files__read_file" {
+		t.Fatalf("unexpected requests %#v", requests)
+	}
+	followUp := requests[1].Messages
+	if len(followUp) != 4 || followUp[1].Role != chatports.ChatRoleAssistant || followUp[2].Role != chatports.ChatRoleTool || followUp[3].Role != chatports.ChatRoleTool {
+		t.Fatalf("unexpected follow-up messages %#v", followUp)
+	}
+	calls := followUp[1].ToolCalls
+	if followUp[1].Content != "Let me check." || len(calls) != 2 || calls[0].ID != "call-1" || calls[0].Name != "files__read_file" || calls[1].ID != failed.ID {
+		t.Fatalf("unexpected assistant tool turn %#v", followUp[1])
+	}
+	if followUp[2].ToolCallID != "call-1" || followUp[2].Content != "buy milk\nwalk dog" {
+		t.Fatalf("unexpected tool result %#v", followUp[2])
+	}
+	if followUp[3].ToolCallID != failed.ID || !strings.HasPrefix(followUp[3].Content, "error: ") || !strings.Contains(followUp[3].Content, "unknown tool") {
+		t.Fatalf("unexpected failed tool result %#v", followUp[3])
+	}
+}
+
+// TestSendMessageKeepsActionsFromEveryToolRound validates repeated provider call IDs do not overwrite earlier action blocks.
+func TestSendMessageKeepsActionsFromEveryToolRound(t *testing.T) {
+
+	completion := &scriptedChat{rounds: [][]chatports.ChatChunk{
+		{{ToolCalls: []chatports.ChatToolCall{{ID: "call_0", Name: "files__read_file", Arguments: map[string]interface{}{"path": "a.txt"}}}, FinishReason: "tool_calls"}},
+		{{ToolCalls: []chatports.ChatToolCall{{ID: "call_0", Name: "files__read_file", Arguments: map[string]interface{}{"path": "b.txt"}}}, FinishReason: "tool_calls"}},
+		{{Content: "Both read."}, {FinishReason: "stop"}},
+	}}
+	runner := &fakeToolRunner{outputs: map[string]string{"files__read_file": "contents"}}
+	orchestrator := NewOrchestrator(NewService(newTestChatRepository(t)), completion, nil)
+	orchestrator.SetToolRunner(runner)
+
+	conv, err := orchestrator.service.CreateConversation(chatdomain.ConversationSettings{Provider: "ollama", Model: "llama3.2"})
+	if err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+	if _, err := orchestrator.SendMessage(context.Background(), conv.ID, "Read both files"); err != nil {
+		t.Fatalf("send message: %v", err)
+	}
+
+	stored := waitForFinalized(t, orchestrator, conv.ID)
+	var actions []*chatdomain.ActionExecution
+	for _, block := range stored.Blocks {
+		if block.Type == chatdomain.BlockTypeAction {
+			actions = append(actions, block.Action)
+		}
+	}
+	if len(actions) != 2 || actions[0].ID != "call_0" || actions[1].ID == "call_0" {
+		t.Fatalf("expected an action block per round with distinct IDs, got %#v", actions)
+	}
+	if actions[0].Description != `Arguments: {"path":"a.txt"}` || actions[1].Description != `Arguments: {"path":"b.txt"}` {
+		t.Fatalf("expected each round's arguments to be kept, got %q and %q", actions[0].Description, actions[1].Description)
+	}
+
+	requests := completion.recorded()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(requests))
+	}
+	final := requests[2].Messages
+	if len(final) != 5 || final[3].ToolCalls[0].ID != actions[1].ID || final[4].ToolCallID != actions[1].ID {
+		t.Fatalf("expected the second round to use the replaced call ID, got %#v", final)
+	}
+}
+
+// TestSendMessageStopsAtToolRoundLimit validates tool calls past the round limit are not run and the finish reason says so.
+func TestSendMessageStopsAtToolRoundLimit(t *testing.T) {
+
+	rounds := make([][]chatports.ChatChunk, maxToolRounds+1)
+	for index := range rounds {
+		rounds[index] = []chatports.ChatChunk{
+			{ToolCalls: []chatports.ChatToolCall{{Name: "files__read_file"}}, FinishReason: "tool_calls"},
+		}
+	}
+	completion := &scriptedChat{rounds: rounds}
+	runner := &fakeToolRunner{outputs: map[string]string{"files__read_file": "again"}}
+	orchestrator := NewOrchestrator(NewService(newTestChatRepository(t)), completion, nil)
+	orchestrator.SetToolRunner(runner)
+
+	conv, err := orchestrator.service.CreateConversation(chatdomain.ConversationSettings{Provider: "ollama", Model: "llama3.2"})
+	if err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+	if _, err := orchestrator.SendMessage(context.Background(), conv.ID, "Loop forever"); err != nil {
+		t.Fatalf("send message: %v", err)
+	}
+
+	stored := waitForFinalized(t, orchestrator, conv.ID)
+	if stored.Metadata == nil || stored.Metadata.FinishReason != finishReasonToolRoundLimit {
+		t.Fatalf("expected tool round limit finish reason, got %#v", stored.Metadata)
+	}
+	if requests := completion.recorded(); len(requests) != maxToolRounds+1 {
+		t.Fatalf("expected %d requests, got %d", maxToolRounds+1, len(requests))
+	}
+	actions := 0
+	for _, block := range stored.Blocks {
+		if block.Type == chatdomain.BlockTypeAction {
+			actions++
+		}
+	}
+	if actions != maxToolRounds {
+		t.Fatalf("expected %d tool calls to run, got %d", maxToolRounds, actions)
+	}
+}
+
+// TestSendMessageContinuesWhenToolListingFails validates tool discovery errors send the message without tools.
+func TestSendMessageContinuesWhenToolListingFails(t *testing.T) {
+
+	completion := &fakeChat{requests: make(chan chatports.ChatRequest, 1)}
+	orchestrator := NewOrchestrator(NewService(newTestChatRepository(t)), completion, nil)
+	orchestrator.SetToolRunner(&fakeToolRunner{err: errors.New("mcp server files: connection refused")})
+
+	conv, err := orchestrator.service.CreateConversation(chatdomain.ConversationSettings{Provider: "openai", Model: "gpt-4o"})
+	if err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+	if _, err := orchestrator.SendMessage(context.Background(), conv.ID, "hello"); err != nil {
+		t.Fatalf("send message: %v", err)
+	}
+
+	stored := waitForFinalized(t, orchestrator, conv.ID)
+	if stored.Metadata == nil || stored.Metadata.ErrorMessage != "" || textFromBlocks(stored.Blocks) == "" {
+		t.Fatalf("expected a normal reply, got %#v", stored)
+	}
+	select {
+	case request := <-completion.requests:
+		if len(request.Options.Tools) != 0 {
+			t.Fatalf("expected no tools, got %#v", request.Options.Tools)
+		}
+	default:
+		t.Fatalf("chat should be called without tools")
+	}
+}
+
+// TestStopStreamAbortsToolListing validates stopping a reply abandons a hung tool listing before the model is called.
+func TestStopStreamAbortsToolListing(t *testing.T) {
+
+	completion := &fakeChat{requests: make(chan chatports.ChatRequest, 1)}
+	bus := &recordingBus{}
+	orchestrator := NewOrchestrator(NewService(newTestChatRepository(t)), completion, bus)
+	tools := &hangingToolRunner{listing: make(chan struct{})}
+	orchestrator.SetToolRunner(tools)
+
+	conv, err := orchestrator.service.CreateConversation(chatdomain.ConversationSettings{Provider: "openai", Model: "gpt-4o"})
+	if err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+
+	sent := make(chan error, 1)
+	go func() {
+		_, err := orchestrator.SendMessage(context.Background(), conv.ID, "What is in my files?")
+		sent <- err
+	}()
+	<-tools.listing
+	orchestrator.StopStream()
+
+	select {
+	case err := <-sent:
+		if err != nil {
+			t.Fatalf("send message: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("stopping the reply did not abort tool listing")
+	}
+	stored := waitForFinalized(t, orchestrator, conv.ID)
+	if stored.Metadata == nil || stored.Metadata.FinishReason != "cancelled" {
+		t.Fatalf("expected cancelled reply, got %#v", stored.Metadata)
+	}
+	waitForEvent(t, bus, "chat.stream.complete")
+	select {
+	case <-completion.requests:
+		t.Fatalf("chat should not be called after the reply was stopped")
+	default:
+	}
+}
+
+// fakeChat streams a fixed reply and records requests.
+type fakeChat struct {
+	requests chan chatports.ChatRequest
+}
+
+// Chat records the request and returns one content chunk.
+func (f *fakeChat) Chat(_ context.Context, request chatports.ChatRequest) (<-chan chatports.ChatChunk, error) {
+
+	f.requests <- request
+	chunks := make(chan chatports.ChatChunk, 2)
+	chunks <- chatports.ChatChunk{Content: "You get 25 days [1]."}
+	chunks <- chatports.ChatChunk{FinishReason: "stop"}
+	close(chunks)
+	return chunks, nil
+}
+
+// scriptedChat streams one scripted round of chunks per call and records requests.
+type scriptedChat struct {
+	mu       sync.Mutex
+	rounds   [][]chatports.ChatChunk
+	requests []chatports.ChatRequest
+}
+
+// Chat records the request and streams the next scripted round.
+func (f *scriptedChat) Chat(_ context.Context, request chatports.ChatRequest) (<-chan chatports.ChatChunk, error) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	request.Messages = append([]chatports.ChatMessage(nil), request.Messages...)
+	f.requests = append(f.requests, request)
+	if len(f.rounds) == 0 {
+		return nil, errors.New("no scripted round left")
+	}
+	round := f.rounds[0]
+	f.rounds = f.rounds[1:]
+
+	chunks := make(chan chatports.ChatChunk, len(round))
+	for _, chunk := range round {
+		chunks <- chunk
+	}
+	close(chunks)
+	return chunks, nil
+}
+
+// recorded returns the requests seen so far.
+func (f *scriptedChat) recorded() []chatports.ChatRequest {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]chatports.ChatRequest(nil), f.requests...)
+}
+
+// cancellableChat streams one content chunk and then holds the stream open until the request is cancelled.
+type cancellableChat struct {
+	content string
+	sent    chan struct{}
+}
+
+// Chat streams the content chunk, signals sent, and closes the stream once ctx is cancelled.
+func (f *cancellableChat) Chat(ctx context.Context, _ chatports.ChatRequest) (<-chan chatports.ChatChunk, error) {
+
+	chunks := make(chan chatports.ChatChunk, 1)
+	go func() {
+		defer close(chunks)
+		chunks <- chatports.ChatChunk{Content: f.content}
+		close(f.sent)
+		<-ctx.Done()
+	}()
+	return chunks, nil
+}
+
+// fakeToolRunner offers fixed tools and returns outputs by tool name.
+type fakeToolRunner struct {
+	tools   []chatports.ChatTool
+	outputs map[string]string
+	err     error
+}
+
+// ListTools returns the configured tools or error.
+func (f *fakeToolRunner) ListTools(_ context.Context) ([]chatports.ChatTool, error) {
+
+	return f.tools, f.err
+}
+
+// CallTool returns the configured output for the tool or an unknown tool error.
+func (f *fakeToolRunner) CallTool(_ context.Context, call chatports.ChatToolCall) (string, error) {
+
+	output, ok := f.outputs[call.Name]
+	if !ok {
+		return "", errors.New("unknown tool: " + call.Name)
+	}
+	return output, nil
+}
+
+// hangingToolRunner signals listing and then blocks until the listing context ends.
+type hangingToolRunner struct {
+	listing chan struct{}
+}
+
+// ListTools signals the listing and waits for cancellation.
+func (f *hangingToolRunner) ListTools(ctx context.Context) ([]chatports.ChatTool, error) {
+
+	close(f.listing)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// CallTool is never reached.
+func (f *hangingToolRunner) CallTool(context.Context, chatports.ChatToolCall) (string, error) {
+
+	return "", errors.New("unexpected tool call")
+}
+
+// fakeRetriever returns fixed passages and records the last query.
+type fakeRetriever struct {
+	passages   []chatports.RetrievedPassage
+	err        error
+	collection string
+	query      string
+	topK       int
+}
+
+// Retrieve records its inputs and returns the configured result.
+func (f *fakeRetriever) Retrieve(_ context.Context, collection, query string, topK int) ([]chatports.RetrievedPassage, error) {
+
+	f.collection, f.query, f.topK = collection, query, topK
+	return f.passages, f.err
+}
+
+// fakeTranscriber returns a fixed transcript and records the last request.
+type fakeTranscriber struct {
+	text     string
+	provider string
+	path     string
+}
+
+// TranscribeVoice records its inputs and returns the configured transcript.
+func (f *fakeTranscriber) TranscribeVoice(_ context.Context, providerName, audioPath string) (string, error) {
+
+	f.provider, f.path = providerName, audioPath
+	return f.text, nil
+}
+
+// fakeModerator flags texts listed in flagged and records the last provider and whether the call had a deadline.
+type fakeModerator struct {
+	flagged  map[string][]string
+	err      error
+	provider string
+	deadline bool
+}
+
+// Moderate records the call and returns the configured verdict for text, failing like a real client when ctx is done.
+func (f *fakeModerator) Moderate(ctx context.Context, providerName, _, text string) (chatports.ModerationVerdict, error) {
+
+	f.provider = providerName
+	_, f.deadline = ctx.Deadline()
+	if err := ctx.Err(); err != nil {
+		return chatports.ModerationVerdict{}, err
+	}
+	if f.err != nil {
+		return chatports.ModerationVerdict{}, f.err
+	}
+	categories, flagged := f.flagged[text]
+	return chatports.ModerationVerdict{Flagged: flagged, Categories: categories}, nil
+}
+
+// recordingBus records emitted events in order.
+type recordingBus struct {
+	mu     sync.Mutex
+	events []recordedEvent
+}
+
+// recordedEvent is one emitted signal and payload.
+type recordedEvent struct {
+	name    coreevents.Name
+	payload interface{}
+}
+
+// Emit records the event.
+func (b *recordingBus) Emit(name coreevents.Name, payload interface{}) {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, recordedEvent{name: name, payload: payload})
+}
+
+// named returns the payloads emitted under name.
+func (b *recordingBus) named(name coreevents.Name) []interface{} {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var payloads []interface{}
+	for _, event := range b.events {
+		if event.name == name {
+			payloads = append(payloads, event.payload)
+		}
+	}
+	return payloads
+}
+
+// waitForEvent polls until the bus has recorded an event under name and returns the latest payload.
+func waitForEvent(t *testing.T, bus *recordingBus, name coreevents.Name) interface{} {
+
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if payloads := bus.named(name); len(payloads) > 0 {
+			return payloads[len(payloads)-1]
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("event %s was not emitted", name)
+	return nil
+}
+
+// waitForFinalized polls until the assistant reply stops streaming and returns it.
+func waitForFinalized(t *testing.T, orchestrator *Orchestrator, conversationID string) *chatdomain.Message {
+
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		conv := orchestrator.GetConversation(conversationID)
+		if conv != nil && len(conv.Messages) == 2 {
+			// Messages stored in the same millisecond load in ID order, so find the reply by role.
+			for _, msg := range conv.Messages {
+				if msg.Role == chatdomain.RoleAssistant && !msg.IsStreaming {
+					return msg
+				}
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("assistant reply was not finalized")
+	return nil
+}
+
+// assertOutputBlocked checks a flagged reply was replaced by the block notice and never reached the UI unscreened.
+func assertOutputBlocked(t *testing.T, bus *recordingBus, stored *chatdomain.Message) {
+
+	t.Helper()
+	notice := chatdomain.ModerationBlockNotice(chatdomain.ModerationStageOutput, []string{"harassment"})
+	if len(stored.Blocks) != 1 || stored.Blocks[0].Type != chatdomain.BlockTypeError || stored.Blocks[0].Content != notice {
+		t.Fatalf("expected stored block notice, got %#v", stored.Blocks)
+	}
+	if stored.Metadata == nil || len(stored.Metadata.Moderation) != 1 || stored.Metadata.Moderation[0].Action != chatdomain.ModerationActionBlock {
+		t.Fatalf("expected output block outcome, got %#v", stored.Metadata)
+	}
+	if len(bus.named("chat.stream.chunk")) != 0 {
+		t.Fatalf("expected unscreened chunks to be withheld from the UI")
+	}
+
+	updated := waitForEvent(t, bus, "chat.message.update").(MessageEventPayload).Message
+	if strings.Contains(textFromBlocks(updated.Blocks), "25 days") || len(updated.Blocks) != 1 || updated.Blocks[0].Content != notice {
+		t.Fatalf("expected screened update, got %#v", updated.Blocks)
+	}
+}
+
+// newTestChatRepository creates an isolated SQLite-backed chat repository.
+func newTestChatRepository(t *testing.T) *chatrepo.Repository {
+
+	t.Helper()
+	db, err := datastore.OpenSQLite(filepath.Join(t.TempDir(), "chat.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	repo, err := chatrepo.NewRepository(db)
+	if err != nil {
+		t.Fatalf("new repository: %v", err)
+	}
+	return repo
+}
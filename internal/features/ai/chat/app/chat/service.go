@@ -231,6 +231,116 @@ func (s *Service) FinalizeMessage(conversationID, messageID string, metadata *ch
 	return updated
 }
 
+// SetMessageCitations records the knowledge-base sources a message was grounded on.
+func (s *Service) SetMessageCitations(conversationID, messageID string, citations []chatdomain.Citation) bool {
+
+	conv, err := s.repo.Get(conversationID)
+	if err != nil {
+		return false
+	}
+	if conv == nil {
+		return false
+	}
+
+	conv.Lock()
+	defer conv.Unlock()
+
+	updated := false
+	for _, msg := range conv.Messages {
+		if msg.ID == messageID {
+			msg.Citations = citations
+			updated = true
+			break
+		}
+	}
+
+	if updated {
+		if err := s.repo.Update(conv); err != nil {
+			return false
+		}
+	}
+
+	return updated
+}
+
+// ReplaceMessageBlocks replaces the content blocks of a message, as when moderation redacts or blocks it.
+func (s *Service) ReplaceMessageBlocks(conversationID, messageID string, blocks []chatdomain.Block) bool {
+
+	conv, err := s.repo.Get(conversationID)
+	if err != nil {
+		return false
+	}
+	if conv == nil {
+		return false
+	}
+
+	conv.Lock()
+	defer conv.Unlock()
+
+	updated := false
+	for _, msg := range conv.Messages {
+		if msg.ID == messageID {
+			msg.Blocks = blocks
+			updated = true
+			break
+		}
+	}
+
+	if updated {
+		if err := s.repo.Update(conv); err != nil {
+			return false
+		}
+	}
+
+	return updated
+}
+
+// SetMessageAction adds an action block to a message or updates the block with the same action ID.
+// Block 0 stays the text block streamed content is appended to.
+func (s *Service) SetMessageAction(conversationID, messageID string, action chatdomain.ActionExecution) bool {
+
+	conv, err := s.repo.Get(conversationID)
+	if err != nil {
+		return false
+	}
+	if conv == nil {
+		return false
+	}
+
+	conv.Lock()
+	defer conv.Unlock()
+
+	updated := false
+	for _, msg := range conv.Messages {
+		if msg.ID != messageID {
+			continue
+		}
+		if len(msg.Blocks) == 0 {
+			msg.Blocks = append(msg.Blocks, chatdomain.Block{Type: chatdomain.BlockTypeText})
+		}
+		for index := range msg.Blocks {
+			if msg.Blocks[index].Action != nil && msg.Blocks[index].Action.ID == action.ID {
+				msg.Blocks[index].Action = &action
+				updated = true
+				break
+			}
+		}
+		if !updated {
+			msg.Blocks = append(msg.Blocks, chatdomain.Block{Type: chatdomain.BlockTypeAction, Action: &action})
+			updated = true
+		}
+		break
+	}
+
+	if updated {
+		if err := s.repo.Update(conv); err != nil {
+			return false
+		}
+	}
+
+	return updated
+}
+
 // DeleteConversation moves a conversation into the recycle bin.
 func (s *Service) DeleteConversation(id string) bool {
 
@@ -323,3 +433,27 @@ func (s *Service) UpdateConversationProvider(id, provider string) bool {
 	}
 	return false
 }
+
+// UpdateConversationKnowledge attaches a knowledge collection to a conversation; an empty name detaches it.
+func (s *Service) UpdateConversationKnowledge(id, collection string, topK int) bool {
+
+	if topK < 0 {
+		return false
+	}
+	conv, err := s.repo.Get(id)
+	if err != nil {
+		return false
+	}
+	if conv != nil && !conv.CheckIsArchived() {
+		conv.Lock()
+		defer conv.Unlock()
+		conv.Settings.KnowledgeCollection = collection
+		conv.Settings.KnowledgeTopK = topK
+		if collection == "" {
+			conv.Settings.KnowledgeTopK = 0
+		}
+		conv.UpdatedAt = time.Now().UnixMilli()
+		return s.repo.Update(conv) == nil
+	}
+	return false
+}
@@ -0,0 +1,15 @@
+// citation.go defines knowledge-base sources cited by assistant messages.
+// internal/features/ai/chat/domain/citation.go
+package domain
+
+// Citation links an assistant reply to a document passage it was grounded on.
+// Index matches the [n] marker the model was asked to use in its answer.
+type Citation struct {
+	Index      int     `json:"index"`
+	Collection string  `json:"collection"`
+	SourcePath string  `json:"sourcePath"`
+	StartLine  int     `json:"startLine"`
+	EndLine    int     `json:"endLine"`
+	Score      float64 `json:"score,omitempty"`
+	Snippet    string  `json:"snippet,omitempty"`
+}
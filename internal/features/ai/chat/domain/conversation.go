@@ -17,6 +17,9 @@ type ConversationSettings struct {
 	Temperature  float64 `json:"temperature,omitempty"`
 	MaxTokens    int     `json:"maxTokens,omitempty"`
 	SystemPrompt string  `json:"systemPrompt,omitempty"`
+	// KnowledgeCollection names a knowledge-base collection whose passages ground replies.
+	KnowledgeCollection string `json:"knowledgeCollection,omitempty"`
+	KnowledgeTopK       int    `json:"knowledgeTopK,omitempty"`
 }
 
 // Conversation represents a chat conversation.
@@ -175,6 +178,7 @@ func cloneMessage(message *Message) *Message {
 		Timestamp:      message.Timestamp,
 		IsStreaming:    message.IsStreaming,
 		Metadata:       cloneMetadata(message.Metadata),
+		Citations:      cloneCitations(message.Citations),
 	}
 }
 
@@ -227,6 +231,18 @@ func cloneAction(action *ActionExecution) *ActionExecution {
 	return &clone
 }
 
+// cloneCitations copies citations for snapshots.
+func cloneCitations(citations []Citation) []Citation {
+
+	if citations == nil {
+		return nil
+	}
+
+	cloned := make([]Citation, len(citations))
+	copy(cloned, citations)
+	return cloned
+}
+
 // cloneMetadata deep copies message metadata for snapshots.
 func cloneMetadata(metadata *MessageMetadata) *MessageMetadata {
 
@@ -235,5 +251,12 @@ func cloneMetadata(metadata *MessageMetadata) *MessageMetadata {
 	}
 
 	clone := *metadata
+	if metadata.Moderation != nil {
+		clone.Moderation = make([]ModerationOutcome, len(metadata.Moderation))
+		for index, outcome := range metadata.Moderation {
+			outcome.Categories = append([]string(nil), outcome.Categories...)
+			clone.Moderation[index] = outcome
+		}
+	}
 	return &clone
 }
@@ -84,6 +84,7 @@ type Message struct {
 	Timestamp      int64            `json:"timestamp"`
 	IsStreaming    bool             `json:"isStreaming,omitempty"`
 	Metadata       *MessageMetadata `json:"metadata,omitempty"`
+	Citations      []Citation       `json:"citations,omitempty"`
 }
 
 // NewMessage creates a new message with the given role and content.
@@ -13,4 +13,6 @@ type MessageMetadata struct {
 	FinishReason string `json:"finishReason,omitempty"`
 	StatusCode   int    `json:"statusCode,omitempty"`
 	ErrorMessage string `json:"errorMessage,omitempty"`
+
+	Moderation []ModerationOutcome `json:"moderation,omitempty"`
 }
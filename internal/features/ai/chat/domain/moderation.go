@@ -0,0 +1,125 @@
+// moderation.go defines content screening policy and outcome entities for chat messages.
+// internal/features/ai/chat/domain/moderation.go
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ModerationAction is what the orchestrator does with flagged content.
+type ModerationAction string
+
+const (
+	ModerationActionOff    ModerationAction = "off"
+	ModerationActionWarn   ModerationAction = "warn"
+	ModerationActionRedact ModerationAction = "redact"
+	ModerationActionBlock  ModerationAction = "block"
+	// ModerationActionAllow is recorded when screening ran and nothing was triggered.
+	ModerationActionAllow ModerationAction = "allow"
+)
+
+// ModerationStage identifies which side of the exchange was screened.
+type ModerationStage string
+
+const (
+	ModerationStageInput  ModerationStage = "input"
+	ModerationStageOutput ModerationStage = "output"
+)
+
+// ModerationPolicy configures screening of user input and model output.
+// An empty Categories list acts on any flagged category.
+type ModerationPolicy struct {
+	Provider   string           `json:"provider"`
+	Model      string           `json:"model,omitempty"`
+	Input      ModerationAction `json:"input,omitempty"`
+	Output     ModerationAction `json:"output,omitempty"`
+	Categories []string         `json:"categories,omitempty"`
+}
+
+// ModerationOutcome records one screening pass on a message exchange.
+type ModerationOutcome struct {
+	Stage      ModerationStage  `json:"stage"`
+	Action     ModerationAction `json:"action"`
+	Flagged    bool             `json:"flagged"`
+	Categories []string         `json:"categories,omitempty"`
+	Provider   string           `json:"provider,omitempty"`
+	Model      string           `json:"model,omitempty"`
+}
+
+// ParseModerationAction normalizes an action name; empty means off.
+func ParseModerationAction(value string) (ModerationAction, error) {
+
+	action := ModerationAction(strings.ToLower(strings.TrimSpace(value)))
+	switch action {
+	case "":
+		return ModerationActionOff, nil
+	case ModerationActionOff, ModerationActionWarn, ModerationActionRedact, ModerationActionBlock:
+		return action, nil
+	default:
+		return "", fmt.Errorf("unknown moderation action %q (use off, warn, redact, or block)", value)
+	}
+}
+
+// ActionFor returns the configured action for a stage, treating unset as off.
+func (p ModerationPolicy) ActionFor(stage ModerationStage) ModerationAction {
+
+	if strings.TrimSpace(p.Provider) == "" {
+		return ModerationActionOff
+	}
+	action := p.Input
+	if stage == ModerationStageOutput {
+		action = p.Output
+	}
+	if action == "" {
+		return ModerationActionOff
+	}
+	return action
+}
+
+// Triggered returns the flagged categories the policy acts on; ok is false when none apply.
+func (p ModerationPolicy) Triggered(flagged bool, categories []string) ([]string, bool) {
+
+	if !flagged {
+		return nil, false
+	}
+	if len(p.Categories) == 0 {
+		return categories, true
+	}
+	watched := make(map[string]bool, len(p.Categories))
+	for _, category := range p.Categories {
+		watched[strings.ToLower(strings.TrimSpace(category))] = true
+	}
+	var matched []string
+	for _, category := range categories {
+		if watched[strings.ToLower(category)] {
+			matched = append(matched, category)
+		}
+	}
+	return matched, len(matched) > 0
+}
+
+// ModerationRedactionNotice replaces redacted content.
+func ModerationRedactionNotice(categories []string) string {
+
+	return "[Content redacted by moderation: " + formatModerationCategories(categories) + "]"
+}
+
+// ModerationBlockNotice explains why a message was blocked.
+func ModerationBlockNotice(stage ModerationStage, categories []string) string {
+
+	subject := "Message"
+	if stage == ModerationStageOutput {
+		subject = "Response"
+	}
+	return subject + " blocked by moderation: " + formatModerationCategories(categories)
+}
+
+// formatModerationCategories joins categories for notices.
+func formatModerationCategories(categories []string) string {
+
+	if len(categories) == 0 {
+		return "unspecified"
+	}
+	return strings.Join(categories, ", ")
+}
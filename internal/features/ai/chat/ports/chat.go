@@ -18,9 +18,12 @@ type ChatRequest struct {
 }
 
 // ChatMessage represents a single chat message payload.
+// Assistant messages may carry tool calls; tool messages answer one call by ToolCallID.
 type ChatMessage struct {
-	Role    ChatRole `json:"role"`
-	Content string   `json:"content"`
+	Role       ChatRole       `json:"role"`
+	Content    string         `json:"content"`
+	ToolCalls  []ChatToolCall `json:"toolCalls,omitempty"`
+	ToolCallID string         `json:"toolCallId,omitempty"`
 }
 
 // ChatRole represents the sender role for chat messages.
@@ -0,0 +1,20 @@
+// knowledge.go defines the retrieval port used to ground chat replies in documents.
+// internal/features/ai/chat/ports/knowledge.go
+package ports
+
+import "context"
+
+// KnowledgeRetriever finds document passages relevant to a user message.
+type KnowledgeRetriever interface {
+	Retrieve(ctx context.Context, collection, query string, topK int) ([]RetrievedPassage, error)
+}
+
+// RetrievedPassage is one document excerpt returned by a knowledge retriever.
+type RetrievedPassage struct {
+	Collection string  `json:"collection"`
+	SourcePath string  `json:"sourcePath"`
+	StartLine  int     `json:"startLine"`
+	EndLine    int     `json:"endLine"`
+	Content    string  `json:"content"`
+	Score      float64 `json:"score"`
+}
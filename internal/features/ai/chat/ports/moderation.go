@@ -0,0 +1,17 @@
+// moderation.go defines the content screening port used by the chat moderation hook.
+// internal/features/ai/chat/ports/moderation.go
+package ports
+
+import "context"
+
+// ModerationVerdict is the screening result for one piece of message text.
+type ModerationVerdict struct {
+	Flagged    bool
+	Categories []string
+	Model      string
+}
+
+// ContentModerator screens message text with a configured moderation provider.
+type ContentModerator interface {
+	Moderate(ctx context.Context, providerName, model, text string) (ModerationVerdict, error)
+}
@@ -0,0 +1,11 @@
+// tools.go defines the tool port used to let models call external tools during a reply.
+// internal/features/ai/chat/ports/tools.go
+package ports
+
+import "context"
+
+// ToolRunner lists the tools offered to models and executes the calls they make.
+type ToolRunner interface {
+	ListTools(ctx context.Context) ([]ChatTool, error)
+	CallTool(ctx context.Context, call ChatToolCall) (string, error)
+}
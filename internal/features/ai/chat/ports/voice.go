@@ -0,0 +1,10 @@
+// voice.go defines the transcription port used to turn voice attachments into user messages.
+// internal/features/ai/chat/ports/voice.go
+package ports
+
+import "context"
+
+// VoiceTranscriber converts a recorded voice attachment into message text.
+type VoiceTranscriber interface {
+	TranscribeVoice(ctx context.Context, providerName, audioPath string) (string, error)
+}
@@ -0,0 +1,96 @@
+// service.go provides text embedding backend operations.
+// internal/features/ai/embedding/app/embedding/service.go
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	embeddingports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/embedding/ports"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// EmbeddingProviderOperations defines embedding operations required by the embedding backend service.
+// Implementations batch inputs under the provider's per-request limit.
+type EmbeddingProviderOperations interface {
+	Embed(ctx context.Context, name string, options providergateway.EmbeddingOptions) (*providergateway.EmbeddingResult, error)
+}
+
+// Service handles text embedding operations for transport adapters.
+type Service struct {
+	providers EmbeddingProviderOperations
+}
+
+var _ embeddingports.EmbeddingInterface = (*Service)(nil)
+
+// NewService creates an embedding backend service from provider dependencies.
+func NewService(providers EmbeddingProviderOperations) *Service {
+
+	return &Service{providers: providers}
+}
+
+// Embed validates a request and embeds its texts using a configured provider.
+func (s *Service) Embed(ctx context.Context, request embeddingports.EmbedRequest) (embeddingports.EmbedResult, error) {
+
+	if s.providers == nil {
+		return embeddingports.EmbedResult{}, fmt.Errorf("backend service: providers not configured")
+	}
+	if len(request.Input) == 0 {
+		return embeddingports.EmbedResult{}, fmt.Errorf("embed: at least one input is required")
+	}
+	for index, text := range request.Input {
+		if strings.TrimSpace(text) == "" {
+			return embeddingports.EmbedResult{}, fmt.Errorf("embed: input %d is empty", index)
+		}
+	}
+	if request.Dimensions < 0 {
+		return embeddingports.EmbedResult{}, fmt.Errorf("embed: dimensions must be positive")
+	}
+	inputType, err := gatewayInputType(request.InputType)
+	if err != nil {
+		return embeddingports.EmbedResult{}, err
+	}
+
+	model := strings.TrimSpace(request.ModelName)
+	result, err := s.providers.Embed(ctx, request.ProviderName, providergateway.EmbeddingOptions{
+		Model:      model,
+		Input:      request.Input,
+		Dimensions: request.Dimensions,
+		InputType:  inputType,
+	})
+	if err != nil {
+		return embeddingports.EmbedResult{}, err
+	}
+
+	output := embeddingports.EmbedResult{
+		Model:      result.Model,
+		Embeddings: result.Embeddings,
+	}
+	if output.Model == "" {
+		output.Model = model
+	}
+	if len(result.Embeddings) > 0 {
+		output.Dimensions = len(result.Embeddings[0])
+	}
+	if result.Usage != nil {
+		output.PromptTokens = result.Usage.PromptTokens
+		output.TotalTokens = result.Usage.TotalTokens
+	}
+	return output, nil
+}
+
+// gatewayInputType maps a transport input type to its gateway equivalent.
+func gatewayInputType(inputType embeddingports.EmbeddingInputType) (providergateway.EmbeddingInputType, error) {
+
+	switch embeddingports.EmbeddingInputType(strings.ToLower(strings.TrimSpace(string(inputType)))) {
+	case "":
+		return "", nil
+	case embeddingports.EmbeddingInputDocument:
+		return providergateway.EmbeddingInputDocument, nil
+	case embeddingports.EmbeddingInputQuery:
+		return providergateway.EmbeddingInputQuery, nil
+	default:
+		return "", fmt.Errorf("embed: unknown input type %q (use document or query)", inputType)
+	}
+}
@@ -0,0 +1,76 @@
+// service_test.go verifies embedding request validation and result mapping.
+// internal/features/ai/embedding/app/embedding/service_test.go
+package embedding
+
+import (
+	"context"
+	"testing"
+
+	embeddingports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/embedding/ports"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// recordingProviders captures the last embedding options and returns fixed vectors.
+type recordingProviders struct {
+	name    string
+	options providergateway.EmbeddingOptions
+}
+
+// Embed records its inputs and returns a three-dimensional vector per input.
+func (p *recordingProviders) Embed(_ context.Context, name string, options providergateway.EmbeddingOptions) (*providergateway.EmbeddingResult, error) {
+
+	p.name = name
+	p.options = options
+	result := &providergateway.EmbeddingResult{Usage: &providergateway.UsageStats{PromptTokens: 4, TotalTokens: 4}}
+	for range options.Input {
+		result.Embeddings = append(result.Embeddings, []float32{0, 1, 2})
+	}
+	return result, nil
+}
+
+// TestEmbedMapsRequestAndResult validates input type mapping, dimensions, and usage.
+func TestEmbedMapsRequestAndResult(t *testing.T) {
+
+	providers := &recordingProviders{}
+	service := NewService(providers)
+
+	result, err := service.Embed(context.Background(), embeddingports.EmbedRequest{
+		ProviderName: "openai",
+		ModelName:    " text-embedding-3-small ",
+		Input:        []string{"alpha", "beta"},
+		InputType:    "Query",
+	})
+	if err != nil {
+		t.Fatalf("embed: %v", err)
+	}
+	if providers.name != "openai" || providers.options.Model != "text-embedding-3-small" || providers.options.InputType != providergateway.EmbeddingInputQuery {
+		t.Fatalf("unexpected provider call %q %#v", providers.name, providers.options)
+	}
+	if result.Dimensions != 3 || len(result.Embeddings) != 2 || result.TotalTokens != 4 || result.Model != "text-embedding-3-small" {
+		t.Fatalf("unexpected result %#v", result)
+	}
+}
+
+// TestEmbedRejectsInvalidRequests validates request checks before provider calls.
+func TestEmbedRejectsInvalidRequests(t *testing.T) {
+
+	testCases := []struct {
+		name    string
+		request embeddingports.EmbedRequest
+	}{
+		{name: "no input", request: embeddingports.EmbedRequest{ProviderName: "openai"}},
+		{name: "blank input", request: embeddingports.EmbedRequest{ProviderName: "openai", Input: []string{"a", "  "}}},
+		{name: "negative dimensions", request: embeddingports.EmbedRequest{ProviderName: "openai", Input: []string{"a"}, Dimensions: -1}},
+		{name: "unknown input type", request: embeddingports.EmbedRequest{ProviderName: "openai", Input: []string{"a"}, InputType: "passage"}},
+	}
+
+	for _, testCase := range testCases {
+		providers := &recordingProviders{}
+		if _, err := NewService(providers).Embed(context.Background(), testCase.request); err == nil {
+			t.Fatalf("%s: expected error", testCase.name)
+		}
+		if providers.name != "" {
+			t.Fatalf("%s: provider should not be called", testCase.name)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// embedding.go defines text embedding transport contracts.
+// internal/features/ai/embedding/ports/embedding.go
+package ports
+
+import "context"
+
+// EmbeddingInterface defines text embedding capabilities shared across transports.
+type EmbeddingInterface interface {
+	Embed(ctx context.Context, request EmbedRequest) (EmbedResult, error)
+}
+
+// EmbeddingInputType hints whether texts are stored documents or search queries.
+type EmbeddingInputType string
+
+const (
+	EmbeddingInputDocument EmbeddingInputType = "document"
+	EmbeddingInputQuery    EmbeddingInputType = "query"
+)
+
+// EmbedRequest contains inputs for embedding one or more texts.
+type EmbedRequest struct {
+	ProviderName string             `json:"providerName"`
+	ModelName    string             `json:"modelName,omitempty"`
+	Input        []string           `json:"input"`
+	Dimensions   int                `json:"dimensions,omitempty"`
+	InputType    EmbeddingInputType `json:"inputType,omitempty"`
+}
+
+// EmbedResult contains one vector per input text, in input order.
+type EmbedResult struct {
+	Model        string      `json:"model,omitempty"`
+	Dimensions   int         `json:"dimensions"`
+	Embeddings   [][]float32 `json:"embeddings"`
+	PromptTokens int         `json:"promptTokens,omitempty"`
+	TotalTokens  int         `json:"totalTokens,omitempty"`
+}
@@ -0,0 +1,210 @@
+// mask.go provides edit-mask authoring and validation for image backend operations.
+// internal/features/ai/image/app/image/mask.go
+package image
+
+import (
+	"context"
+	"fmt"
+	stdimage "image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"strings"
+
+	imagedomain "github.com/MadeByDoug/wls-chatbot/internal/features/ai/image/domain"
+	imageports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/image/ports"
+)
+
+// BuildMask renders a PNG alpha mask sized to the requested source image.
+func (s *Service) BuildMask(_ context.Context, request imageports.BuildMaskRequest) (imageports.MaskResult, error) {
+
+	source, err := decodeImageFile(request.ImagePath)
+	if err != nil {
+		return imageports.MaskResult{}, err
+	}
+
+	spec, err := maskSpecFromRequest(request)
+	if err != nil {
+		return imageports.MaskResult{}, err
+	}
+
+	mask, err := imagedomain.BuildMask(source, spec)
+	if err != nil {
+		return imageports.MaskResult{}, fmt.Errorf("backend service: %w", err)
+	}
+
+	encoded, err := imagedomain.EncodeMaskPNG(mask)
+	if err != nil {
+		return imageports.MaskResult{}, fmt.Errorf("backend service: %w", err)
+	}
+
+	return imageports.MaskResult{
+		Bytes:  encoded,
+		Width:  mask.Bounds().Dx(),
+		Height: mask.Bounds().Dy(),
+	}, nil
+}
+
+// maskSpecFromRequest converts a transport mask request into a domain mask spec.
+func maskSpecFromRequest(request imageports.BuildMaskRequest) (imagedomain.MaskSpec, error) {
+
+	spec := imagedomain.MaskSpec{
+		Rects:          toDomainRects(request.Rects),
+		ColorTolerance: request.ColorTolerance,
+		Invert:         request.Invert,
+	}
+
+	for _, polygon := range request.Polygons {
+		points := make([]imagedomain.Point, 0, len(polygon.Points))
+		for _, point := range polygon.Points {
+			points = append(points, imagedomain.Point{X: point.X, Y: point.Y})
+		}
+		spec.Polygons = append(spec.Polygons, points)
+	}
+
+	if strings.TrimSpace(request.EditColor) != "" {
+		editColor, err := imagedomain.ParseHexColor(request.EditColor)
+		if err != nil {
+			return imagedomain.MaskSpec{}, fmt.Errorf("backend service: %w", err)
+		}
+		spec.EditColor = &editColor
+	}
+
+	if strings.TrimSpace(request.BaseMaskPath) != "" {
+		base, err := decodeImageFile(request.BaseMaskPath)
+		if err != nil {
+			return imagedomain.MaskSpec{}, err
+		}
+		spec.Base = base
+	}
+
+	return spec, nil
+}
+
+// prepareEditMask resolves the mask file for an edit request, rendering rectangles to a temporary file when needed.
+func prepareEditMask(request imageports.EditImageRequest) (string, func(), error) {
+
+	noop := func() {}
+	maskPath := strings.TrimSpace(request.MaskPath)
+
+	if len(request.MaskRects) == 0 {
+		if maskPath == "" {
+			return "", noop, nil
+		}
+		if err := validateMaskFile(request.ImagePath, maskPath); err != nil {
+			return "", noop, err
+		}
+		return maskPath, noop, nil
+	}
+	if maskPath != "" {
+		return "", noop, fmt.Errorf("backend service: mask path and mask rectangles are mutually exclusive")
+	}
+
+	source, err := decodeImageFile(request.ImagePath)
+	if err != nil {
+		return "", noop, err
+	}
+	mask, err := imagedomain.BuildMask(source, imagedomain.MaskSpec{Rects: toDomainRects(request.MaskRects)})
+	if err != nil {
+		return "", noop, fmt.Errorf("backend service: %w", err)
+	}
+	encoded, err := imagedomain.EncodeMaskPNG(mask)
+	if err != nil {
+		return "", noop, fmt.Errorf("backend service: %w", err)
+	}
+
+	file, err := os.CreateTemp("", "image-mask-*.png")
+	if err != nil {
+		return "", noop, fmt.Errorf("backend service: create mask file: %w", err)
+	}
+	cleanup := func() { _ = os.Remove(file.Name()) }
+	if _, err := file.Write(encoded); err != nil {
+		_ = file.Close()
+		cleanup()
+		return "", noop, fmt.Errorf("backend service: write mask file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("backend service: write mask file: %w", err)
+	}
+
+	return file.Name(), cleanup, nil
+}
+
+// validateMaskFile ensures a mask file matches the dimensions of its source image.
+func validateMaskFile(imagePath string, maskPath string) error {
+
+	imageConfig, err := decodeImageFileConfig(imagePath)
+	if err != nil {
+		return err
+	}
+	maskConfig, err := decodeImageFileConfig(maskPath)
+	if err != nil {
+		return err
+	}
+
+	if err := imagedomain.ValidateDimensions(
+		stdimage.Rect(0, 0, imageConfig.Width, imageConfig.Height),
+		stdimage.Rect(0, 0, maskConfig.Width, maskConfig.Height),
+	); err != nil {
+		return fmt.Errorf("backend service: %w", err)
+	}
+	return nil
+}
+
+// decodeImageFile reads and decodes an image file from disk.
+func decodeImageFile(path string) (stdimage.Image, error) {
+
+	file, err := openImageFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	decoded, _, err := stdimage.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("backend service: decode image %q: %w", path, err)
+	}
+	return decoded, nil
+}
+
+// decodeImageFileConfig reads image dimensions from a file without decoding pixels.
+func decodeImageFileConfig(path string) (stdimage.Config, error) {
+
+	file, err := openImageFile(path)
+	if err != nil {
+		return stdimage.Config{}, err
+	}
+	defer func() { _ = file.Close() }()
+
+	config, _, err := stdimage.DecodeConfig(file)
+	if err != nil {
+		return stdimage.Config{}, fmt.Errorf("backend service: decode image %q: %w", path, err)
+	}
+	return config, nil
+}
+
+// openImageFile opens an image file path after validating it is present.
+func openImageFile(path string) (*os.File, error) {
+
+	trimmed := strings.TrimSpace(path)
+	if trimmed == "" {
+		return nil, fmt.Errorf("backend service: image path required")
+	}
+	file, err := os.Open(trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("backend service: open image: %w", err)
+	}
+	return file, nil
+}
+
+// toDomainRects converts transport rectangles into domain rectangles.
+func toDomainRects(rects []imageports.MaskRect) []imagedomain.Rect {
+
+	converted := make([]imagedomain.Rect, 0, len(rects))
+	for _, rect := range rects {
+		converted = append(converted, imagedomain.Rect{X: rect.X, Y: rect.Y, Width: rect.Width, Height: rect.Height})
+	}
+	return converted
+}
@@ -0,0 +1,222 @@
+// mask_test.go verifies edit requests resolve, render, and validate their masks before reaching providers.
+// internal/features/ai/image/app/image/mask_test.go
+package image
+
+import (
+	"context"
+	stdimage "image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	imageports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/image/ports"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// TestEditImageRendersMaskRects verifies rectangles become a temporary mask file that is removed after the edit.
+func TestEditImageRendersMaskRects(t *testing.T) {
+
+	imagePath := writeTestPNG(t, "source.png", 8, 6)
+	providers := &fakeImageProviders{}
+	service := NewService(providers, fakeResolver{})
+
+	_, err := service.EditImage(context.Background(), imageports.EditImageRequest{
+		ProviderName: "openai",
+		Prompt:       "fill",
+		ImagePath:    imagePath,
+		MaskRects:    []imageports.MaskRect{{X: 1, Y: 1, Width: 2, Height: 2}},
+	})
+	if err != nil {
+		t.Fatalf("edit image: %v", err)
+	}
+
+	if providers.editOptions.Mask == "" {
+		t.Fatalf("expected a rendered mask path")
+	}
+	if providers.maskWidth != 8 || providers.maskHeight != 6 {
+		t.Fatalf("expected 8x6 mask, got %dx%d", providers.maskWidth, providers.maskHeight)
+	}
+	if providers.maskAlpha(1, 1) != 0 || providers.maskAlpha(5, 5) != 255 {
+		t.Fatalf("expected rectangle editable and remainder preserved")
+	}
+	if _, err := os.Stat(providers.editOptions.Mask); !os.IsNotExist(err) {
+		t.Fatalf("expected temporary mask to be removed, stat err %v", err)
+	}
+}
+
+// TestEditImageMaskValidation verifies supplied masks are checked before any provider call.
+func TestEditImageMaskValidation(t *testing.T) {
+
+	imagePath := writeTestPNG(t, "source.png", 8, 6)
+	matchingMask := writeTestPNG(t, "matching.png", 8, 6)
+	smallMask := writeTestPNG(t, "small.png", 4, 4)
+
+	testCases := []struct {
+		name      string
+		request   imageports.EditImageRequest
+		wantMask  string
+		wantError string
+	}{
+		{
+			name:     "no mask",
+			request:  imageports.EditImageRequest{ImagePath: imagePath},
+			wantMask: "",
+		},
+		{
+			name:     "matching mask file",
+			request:  imageports.EditImageRequest{ImagePath: imagePath, MaskPath: matchingMask},
+			wantMask: matchingMask,
+		},
+		{
+			name:      "mismatched mask file",
+			request:   imageports.EditImageRequest{ImagePath: imagePath, MaskPath: smallMask},
+			wantError: "do not match image dimensions",
+		},
+		{
+			name:      "missing mask file",
+			request:   imageports.EditImageRequest{ImagePath: imagePath, MaskPath: filepath.Join(t.TempDir(), "missing.png")},
+			wantError: "open image",
+		},
+		{
+			name: "mask path with rectangles",
+			request: imageports.EditImageRequest{
+				ImagePath: imagePath,
+				MaskPath:  matchingMask,
+				MaskRects: []imageports.MaskRect{{Width: 1, Height: 1}},
+			},
+			wantError: "mutually exclusive",
+		},
+		{
+			name: "rectangles without source image",
+			request: imageports.EditImageRequest{
+				MaskRects: []imageports.MaskRect{{Width: 1, Height: 1}},
+			},
+			wantError: "image path required",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			providers := &fakeImageProviders{}
+			service := NewService(providers, fakeResolver{})
+
+			_, err := service.EditImage(context.Background(), testCase.request)
+			if testCase.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.wantError) {
+					t.Fatalf("expected error containing %q, got %v", testCase.wantError, err)
+				}
+				if providers.editCalls != 0 {
+					t.Fatalf("expected no provider call, got %d", providers.editCalls)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("edit image: %v", err)
+			}
+			if providers.editOptions.Mask != testCase.wantMask {
+				t.Fatalf("expected mask %q, got %q", testCase.wantMask, providers.editOptions.Mask)
+			}
+		})
+	}
+}
+
+// TestValidateMaskFileRejectsUndecodableImages verifies non-image files are reported instead of compared.
+func TestValidateMaskFileRejectsUndecodableImages(t *testing.T) {
+
+	imagePath := writeTestPNG(t, "source.png", 4, 4)
+	textPath := filepath.Join(t.TempDir(), "mask.png")
+	if err := os.WriteFile(textPath, []byte("not an image"), 0o600); err != nil {
+		t.Fatalf("write mask: %v", err)
+	}
+
+	err := validateMaskFile(imagePath, textPath)
+	if err == nil || !strings.Contains(err.Error(), "decode image") {
+		t.Fatalf("expected decode error, got %v", err)
+	}
+}
+
+// writeTestPNG writes an opaque PNG of the given size and returns its path.
+func writeTestPNG(t *testing.T, name string, width, height int) string {
+
+	t.Helper()
+	source := stdimage.NewNRGBA(stdimage.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			source.SetNRGBA(x, y, color.NRGBA{R: 200, G: 200, B: 200, A: 255})
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), name)
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create image: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+	if err := png.Encode(file, source); err != nil {
+		t.Fatalf("encode image: %v", err)
+	}
+	return path
+}
+
+// fakeImageProviders records edit requests and decodes the mask file while it still exists.
+type fakeImageProviders struct {
+	editCalls   int
+	editOptions providergateway.ImageEditOptions
+	maskWidth   int
+	maskHeight  int
+	mask        stdimage.Image
+}
+
+// GenerateImage is unused by mask tests.
+func (f *fakeImageProviders) GenerateImage(context.Context, string, providergateway.ImageGenerationOptions) (*providergateway.ImageResult, error) {
+
+	return nil, nil
+}
+
+// EditImage records the edit options and reads the mask the service prepared.
+func (f *fakeImageProviders) EditImage(_ context.Context, _ string, options providergateway.ImageEditOptions) (*providergateway.ImageResult, error) {
+
+	f.editCalls++
+	f.editOptions = options
+	if options.Mask != "" {
+		mask, err := decodeImageFile(options.Mask)
+		if err != nil {
+			return nil, err
+		}
+		f.mask = mask
+		f.maskWidth, f.maskHeight = mask.Bounds().Dx(), mask.Bounds().Dy()
+	}
+	return &providergateway.ImageResult{Data: []providergateway.ImageData{{B64JSON: "AAA="}}}, nil
+}
+
+// VaryImage is unused by mask tests.
+func (f *fakeImageProviders) VaryImage(context.Context, string, providergateway.ImageVariationOptions) (*providergateway.ImageResult, error) {
+
+	return nil, nil
+}
+
+// UpscaleImage is unused by mask tests.
+func (f *fakeImageProviders) UpscaleImage(context.Context, string, providergateway.ImageUpscaleOptions) (*providergateway.ImageResult, error) {
+
+	return nil, nil
+}
+
+// maskAlpha returns the alpha channel of the recorded mask at a pixel.
+func (f *fakeImageProviders) maskAlpha(x, y int) uint8 {
+
+	_, _, _, alpha := f.mask.At(x, y).RGBA()
+	return uint8(alpha >> 8)
+}
+
+// fakeResolver returns fixed bytes for any image payload.
+type fakeResolver struct{}
+
+// Resolve returns placeholder image bytes.
+func (fakeResolver) Resolve(context.Context, providergateway.ImageData) ([]byte, error) {
+
+	return []byte("image"), nil
+}
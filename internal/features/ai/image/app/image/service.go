@@ -14,6 +14,8 @@ import (
 type ImageProviderOperations interface {
 	GenerateImage(ctx context.Context, name string, options providergateway.ImageGenerationOptions) (*providergateway.ImageResult, error)
 	EditImage(ctx context.Context, name string, options providergateway.ImageEditOptions) (*providergateway.ImageResult, error)
+	VaryImage(ctx context.Context, name string, options providergateway.ImageVariationOptions) (*providergateway.ImageResult, error)
+	UpscaleImage(ctx context.Context, name string, options providergateway.ImageUpscaleOptions) (*providergateway.ImageResult, error)
 }
 
 // Service handles image generation and editing operations for transport adapters.
@@ -70,10 +72,16 @@ func (s *Service) EditImage(ctx context.Context, request imageports.EditImageReq
 		return imageports.ImageBinaryResult{}, fmt.Errorf("backend service: image bytes resolver not configured")
 	}
 
+	maskPath, cleanup, err := prepareEditMask(request)
+	if err != nil {
+		return imageports.ImageBinaryResult{}, err
+	}
+	defer cleanup()
+
 	result, err := s.providers.EditImage(ctx, request.ProviderName, providergateway.ImageEditOptions{
 		Model:  request.ModelName,
 		Image:  request.ImagePath,
-		Mask:   request.MaskPath,
+		Mask:   maskPath,
 		Prompt: request.Prompt,
 		N:      maxCount(request.N),
 		Size:   request.Size,
@@ -85,6 +93,52 @@ func (s *Service) EditImage(ctx context.Context, request imageports.EditImageReq
 	return firstImageBinaryResult(ctx, s.imageResolver, result)
 }
 
+// VaryImage creates a variation of an image using a provider that supports variations.
+func (s *Service) VaryImage(ctx context.Context, request imageports.VaryImageRequest) (imageports.ImageBinaryResult, error) {
+
+	if s.providers == nil {
+		return imageports.ImageBinaryResult{}, fmt.Errorf("backend service: providers not configured")
+	}
+	if s.imageResolver == nil {
+		return imageports.ImageBinaryResult{}, fmt.Errorf("backend service: image bytes resolver not configured")
+	}
+
+	result, err := s.providers.VaryImage(ctx, request.ProviderName, providergateway.ImageVariationOptions{
+		Model:  request.ModelName,
+		Image:  request.ImagePath,
+		Prompt: request.Prompt,
+		N:      maxCount(request.N),
+		Size:   request.Size,
+	})
+	if err != nil {
+		return imageports.ImageBinaryResult{}, err
+	}
+
+	return firstImageBinaryResult(ctx, s.imageResolver, result)
+}
+
+// UpscaleImage upscales an image using a provider that supports upscaling.
+func (s *Service) UpscaleImage(ctx context.Context, request imageports.UpscaleImageRequest) (imageports.ImageBinaryResult, error) {
+
+	if s.providers == nil {
+		return imageports.ImageBinaryResult{}, fmt.Errorf("backend service: providers not configured")
+	}
+	if s.imageResolver == nil {
+		return imageports.ImageBinaryResult{}, fmt.Errorf("backend service: image bytes resolver not configured")
+	}
+
+	result, err := s.providers.UpscaleImage(ctx, request.ProviderName, providergateway.ImageUpscaleOptions{
+		Model:  request.ModelName,
+		Image:  request.ImagePath,
+		Factor: request.Factor,
+	})
+	if err != nil {
+		return imageports.ImageBinaryResult{}, err
+	}
+
+	return firstImageBinaryResult(ctx, s.imageResolver, result)
+}
+
 // maxCount normalizes optional image count values.
 func maxCount(count int) int {
 
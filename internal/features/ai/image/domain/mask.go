@@ -0,0 +1,243 @@
+// mask.go builds provider-compatible alpha masks for image editing.
+// internal/features/ai/image/domain/mask.go
+package domain
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strconv"
+	"strings"
+)
+
+// Masks follow the OpenAI convention: fully transparent pixels mark the region
+// the provider may edit, fully opaque pixels are preserved.
+const (
+	maskEditAlpha     uint8 = 0x00
+	maskPreserveAlpha uint8 = 0xff
+)
+
+// Point is a pixel coordinate within the source image.
+type Point struct {
+	X int
+	Y int
+}
+
+// Rect is an axis-aligned pixel rectangle within the source image.
+type Rect struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// MaskSpec describes which regions of a source image should be editable.
+type MaskSpec struct {
+	Rects          []Rect
+	Polygons       [][]Point
+	EditColor      *color.NRGBA
+	ColorTolerance int
+	Base           image.Image
+	Invert         bool
+}
+
+// BuildMask renders an alpha mask sized to the source bounds from a mask spec.
+func BuildMask(source image.Image, spec MaskSpec) (*image.NRGBA, error) {
+
+	if source == nil {
+		return nil, fmt.Errorf("mask: source image required")
+	}
+	bounds := source.Bounds()
+	if bounds.Empty() {
+		return nil, fmt.Errorf("mask: source image is empty")
+	}
+	if len(spec.Rects) == 0 && len(spec.Polygons) == 0 && spec.EditColor == nil && spec.Base == nil {
+		return nil, fmt.Errorf("mask: at least one region, color rule, or base mask required")
+	}
+	if spec.ColorTolerance < 0 || spec.ColorTolerance > 255 {
+		return nil, fmt.Errorf("mask: color tolerance must be between 0 and 255")
+	}
+
+	mask := newOpaqueMask(bounds)
+	if spec.Base != nil {
+		if err := ValidateDimensions(bounds, spec.Base.Bounds()); err != nil {
+			return nil, err
+		}
+		copyAlpha(mask, spec.Base)
+	}
+
+	for _, rect := range spec.Rects {
+		if rect.Width <= 0 || rect.Height <= 0 {
+			return nil, fmt.Errorf("mask: rectangle %dx%d must have positive size", rect.Width, rect.Height)
+		}
+		region := image.Rect(rect.X, rect.Y, rect.X+rect.Width, rect.Y+rect.Height).Add(bounds.Min).Intersect(bounds)
+		fillRegion(mask, region, func(int, int) bool { return true })
+	}
+
+	for _, polygon := range spec.Polygons {
+		if len(polygon) < 3 {
+			return nil, fmt.Errorf("mask: polygon requires at least 3 points, got %d", len(polygon))
+		}
+		region := polygonBounds(polygon).Add(bounds.Min).Intersect(bounds)
+		fillRegion(mask, region, func(x int, y int) bool {
+			return pointInPolygon(float64(x-bounds.Min.X)+0.5, float64(y-bounds.Min.Y)+0.5, polygon)
+		})
+	}
+
+	if spec.EditColor != nil {
+		target := *spec.EditColor
+		fillRegion(mask, bounds, func(x int, y int) bool {
+			return colorWithinTolerance(color.NRGBAModel.Convert(source.At(x, y)).(color.NRGBA), target, spec.ColorTolerance)
+		})
+	}
+
+	if spec.Invert {
+		return InvertMask(mask), nil
+	}
+	return mask, nil
+}
+
+// InvertMask flips editable and preserved regions of an existing alpha mask.
+func InvertMask(mask image.Image) *image.NRGBA {
+
+	bounds := mask.Bounds()
+	inverted := image.NewNRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			_, _, _, alpha := mask.At(x, y).RGBA()
+			inverted.SetNRGBA(x, y, color.NRGBA{A: maskPreserveAlpha - uint8(alpha>>8)})
+		}
+	}
+	return inverted
+}
+
+// ValidateDimensions reports an error when mask and image sizes differ.
+func ValidateDimensions(imageBounds image.Rectangle, maskBounds image.Rectangle) error {
+
+	if imageBounds.Dx() != maskBounds.Dx() || imageBounds.Dy() != maskBounds.Dy() {
+		return fmt.Errorf(
+			"mask: dimensions %dx%d do not match image dimensions %dx%d",
+			maskBounds.Dx(), maskBounds.Dy(), imageBounds.Dx(), imageBounds.Dy(),
+		)
+	}
+	return nil
+}
+
+// EncodeMaskPNG encodes a mask as an RGBA PNG with an alpha channel.
+func EncodeMaskPNG(mask image.Image) ([]byte, error) {
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, mask); err != nil {
+		return nil, fmt.Errorf("mask: encode png: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// ParseHexColor parses #rgb or #rrggbb color values.
+func ParseHexColor(value string) (color.NRGBA, error) {
+
+	trimmed := strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if len(trimmed) == 3 {
+		trimmed = string([]byte{trimmed[0], trimmed[0], trimmed[1], trimmed[1], trimmed[2], trimmed[2]})
+	}
+	if len(trimmed) != 6 {
+		return color.NRGBA{}, fmt.Errorf("mask: invalid color %q: expected #rrggbb", value)
+	}
+
+	parsed, err := strconv.ParseUint(trimmed, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, fmt.Errorf("mask: invalid color %q: %w", value, err)
+	}
+	return color.NRGBA{
+		R: uint8(parsed >> 16),
+		G: uint8(parsed >> 8),
+		B: uint8(parsed),
+		A: 0xff,
+	}, nil
+}
+
+// newOpaqueMask creates a mask that preserves every pixel.
+func newOpaqueMask(bounds image.Rectangle) *image.NRGBA {
+
+	mask := image.NewNRGBA(bounds)
+	for index := 3; index < len(mask.Pix); index += 4 {
+		mask.Pix[index] = maskPreserveAlpha
+	}
+	return mask
+}
+
+// copyAlpha copies the alpha channel of a base mask onto a mask of equal size.
+func copyAlpha(mask *image.NRGBA, base image.Image) {
+
+	bounds := mask.Bounds()
+	baseBounds := base.Bounds()
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			_, _, _, alpha := base.At(baseBounds.Min.X+x, baseBounds.Min.Y+y).RGBA()
+			mask.SetNRGBA(bounds.Min.X+x, bounds.Min.Y+y, color.NRGBA{A: uint8(alpha >> 8)})
+		}
+	}
+}
+
+// fillRegion marks pixels in a region as editable when the predicate matches.
+func fillRegion(mask *image.NRGBA, region image.Rectangle, include func(x int, y int) bool) {
+
+	for y := region.Min.Y; y < region.Max.Y; y++ {
+		for x := region.Min.X; x < region.Max.X; x++ {
+			if include(x, y) {
+				mask.SetNRGBA(x, y, color.NRGBA{A: maskEditAlpha})
+			}
+		}
+	}
+}
+
+// polygonBounds returns the pixel bounding box of a polygon.
+func polygonBounds(polygon []Point) image.Rectangle {
+
+	minX, minY := polygon[0].X, polygon[0].Y
+	maxX, maxY := minX, minY
+	for _, point := range polygon[1:] {
+		minX, maxX = min(minX, point.X), max(maxX, point.X)
+		minY, maxY = min(minY, point.Y), max(maxY, point.Y)
+	}
+	return image.Rect(minX, minY, maxX+1, maxY+1)
+}
+
+// pointInPolygon reports whether a point lies inside a polygon using the even-odd rule.
+func pointInPolygon(x float64, y float64, polygon []Point) bool {
+
+	inside := false
+	previous := polygon[len(polygon)-1]
+	for _, current := range polygon {
+		currentX, currentY := float64(current.X), float64(current.Y)
+		previousX, previousY := float64(previous.X), float64(previous.Y)
+		if (currentY > y) != (previousY > y) {
+			crossX := currentX + (y-currentY)*(previousX-currentX)/(previousY-currentY)
+			if x < crossX {
+				inside = !inside
+			}
+		}
+		previous = current
+	}
+	return inside
+}
+
+// colorWithinTolerance reports whether every RGB channel is within tolerance of the target.
+func colorWithinTolerance(value color.NRGBA, target color.NRGBA, tolerance int) bool {
+
+	return channelDistance(value.R, target.R) <= tolerance &&
+		channelDistance(value.G, target.G) <= tolerance &&
+		channelDistance(value.B, target.B) <= tolerance
+}
+
+// channelDistance returns the absolute difference between two color channels.
+func channelDistance(left uint8, right uint8) int {
+
+	distance := int(left) - int(right)
+	if distance < 0 {
+		return -distance
+	}
+	return distance
+}
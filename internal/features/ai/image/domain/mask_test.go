@@ -0,0 +1,150 @@
+// mask_test.go verifies edit-mask rendering, inversion, and dimension validation.
+// internal/features/ai/image/domain/mask_test.go
+package domain
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+// TestBuildMaskMarksRegionsEditable validates rectangle, polygon, and color rules produce transparent pixels.
+func TestBuildMaskMarksRegionsEditable(t *testing.T) {
+
+	source := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			source.SetNRGBA(x, y, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+		}
+	}
+	source.SetNRGBA(9, 9, color.NRGBA{R: 250, G: 0, B: 250, A: 255})
+	magenta := color.NRGBA{R: 255, G: 0, B: 255, A: 255}
+
+	testCases := []struct {
+		name      string
+		spec      MaskSpec
+		editable  []image.Point
+		preserved []image.Point
+	}{
+		{
+			name:      "rectangle",
+			spec:      MaskSpec{Rects: []Rect{{X: 2, Y: 2, Width: 3, Height: 3}}},
+			editable:  []image.Point{{X: 2, Y: 2}, {X: 4, Y: 4}},
+			preserved: []image.Point{{X: 1, Y: 1}, {X: 5, Y: 5}},
+		},
+		{
+			name:      "rectangle clipped to bounds",
+			spec:      MaskSpec{Rects: []Rect{{X: 8, Y: 8, Width: 5, Height: 5}}},
+			editable:  []image.Point{{X: 9, Y: 9}},
+			preserved: []image.Point{{X: 7, Y: 7}},
+		},
+		{
+			name:      "polygon",
+			spec:      MaskSpec{Polygons: [][]Point{{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 0, Y: 10}}}},
+			editable:  []image.Point{{X: 0, Y: 0}, {X: 3, Y: 3}},
+			preserved: []image.Point{{X: 9, Y: 9}, {X: 6, Y: 6}},
+		},
+		{
+			name:      "edit color with tolerance",
+			spec:      MaskSpec{EditColor: &magenta, ColorTolerance: 5},
+			editable:  []image.Point{{X: 9, Y: 9}},
+			preserved: []image.Point{{X: 0, Y: 0}},
+		},
+		{
+			name:      "inverted rectangle",
+			spec:      MaskSpec{Rects: []Rect{{X: 0, Y: 0, Width: 2, Height: 2}}, Invert: true},
+			editable:  []image.Point{{X: 5, Y: 5}},
+			preserved: []image.Point{{X: 1, Y: 1}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		mask, err := BuildMask(source, testCase.spec)
+		if err != nil {
+			t.Fatalf("%s: build mask: %v", testCase.name, err)
+		}
+		if mask.Bounds() != source.Bounds() {
+			t.Fatalf("%s: expected mask bounds %v, got %v", testCase.name, source.Bounds(), mask.Bounds())
+		}
+		for _, point := range testCase.editable {
+			if alpha := mask.NRGBAAt(point.X, point.Y).A; alpha != maskEditAlpha {
+				t.Fatalf("%s: expected %v editable, got alpha %d", testCase.name, point, alpha)
+			}
+		}
+		for _, point := range testCase.preserved {
+			if alpha := mask.NRGBAAt(point.X, point.Y).A; alpha != maskPreserveAlpha {
+				t.Fatalf("%s: expected %v preserved, got alpha %d", testCase.name, point, alpha)
+			}
+		}
+	}
+}
+
+// TestBuildMaskRejectsMismatchedBaseMask validates base masks must match the source dimensions.
+func TestBuildMaskRejectsMismatchedBaseMask(t *testing.T) {
+
+	source := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	base := image.NewNRGBA(image.Rect(0, 0, 4, 8))
+
+	if _, err := BuildMask(source, MaskSpec{Base: base}); err == nil {
+		t.Fatalf("expected dimension mismatch error")
+	}
+}
+
+// TestInvertMaskRoundTripsThroughPNG validates inverted masks keep an alpha channel after encoding.
+func TestInvertMaskRoundTripsThroughPNG(t *testing.T) {
+
+	source := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	mask, err := BuildMask(source, MaskSpec{Rects: []Rect{{X: 0, Y: 0, Width: 2, Height: 4}}})
+	if err != nil {
+		t.Fatalf("build mask: %v", err)
+	}
+
+	encoded, err := EncodeMaskPNG(InvertMask(mask))
+	if err != nil {
+		t.Fatalf("encode mask: %v", err)
+	}
+	decoded, err := png.Decode(bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("decode mask: %v", err)
+	}
+
+	if _, _, _, alpha := decoded.At(0, 0).RGBA(); alpha != 0xffff {
+		t.Fatalf("expected inverted left half preserved, got alpha %d", alpha)
+	}
+	if _, _, _, alpha := decoded.At(3, 0).RGBA(); alpha != 0 {
+		t.Fatalf("expected inverted right half editable, got alpha %d", alpha)
+	}
+}
+
+// TestParseHexColorAcceptsShortAndLongForms validates hex color parsing.
+func TestParseHexColorAcceptsShortAndLongForms(t *testing.T) {
+
+	testCases := []struct {
+		value    string
+		expected color.NRGBA
+		wantErr  bool
+	}{
+		{value: "#ff00aa", expected: color.NRGBA{R: 0xff, G: 0x00, B: 0xaa, A: 0xff}},
+		{value: "0f0", expected: color.NRGBA{R: 0x00, G: 0xff, B: 0x00, A: 0xff}},
+		{value: "#12345", wantErr: true},
+		{value: "#zzzzzz", wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		parsed, err := ParseHexColor(testCase.value)
+		if testCase.wantErr {
+			if err == nil {
+				t.Fatalf("%s: expected error", testCase.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: parse color: %v", testCase.value, err)
+		}
+		if parsed != testCase.expected {
+			t.Fatalf("%s: expected %#v, got %#v", testCase.value, testCase.expected, parsed)
+		}
+	}
+}
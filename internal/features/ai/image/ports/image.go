@@ -10,6 +10,9 @@ import (
 type ImageInterface interface {
 	GenerateImage(ctx context.Context, request GenerateImageRequest) (ImageBinaryResult, error)
 	EditImage(ctx context.Context, request EditImageRequest) (ImageBinaryResult, error)
+	VaryImage(ctx context.Context, request VaryImageRequest) (ImageBinaryResult, error)
+	UpscaleImage(ctx context.Context, request UpscaleImageRequest) (ImageBinaryResult, error)
+	BuildMask(ctx context.Context, request BuildMaskRequest) (MaskResult, error)
 }
 
 // GenerateImageRequest contains image generation inputs.
@@ -27,15 +30,72 @@ type GenerateImageRequest struct {
 
 // EditImageRequest contains image edit inputs.
 type EditImageRequest struct {
+	ProviderName string     `json:"providerName"`
+	ModelName    string     `json:"modelName,omitempty"`
+	Prompt       string     `json:"prompt"`
+	ImagePath    string     `json:"imagePath"`
+	MaskPath     string     `json:"maskPath,omitempty"`
+	MaskRects    []MaskRect `json:"maskRects,omitempty"`
+	N            int        `json:"n,omitempty"`
+	Size         string     `json:"size,omitempty"`
+}
+
+// VaryImageRequest contains image variation inputs.
+type VaryImageRequest struct {
 	ProviderName string `json:"providerName"`
 	ModelName    string `json:"modelName,omitempty"`
-	Prompt       string `json:"prompt"`
 	ImagePath    string `json:"imagePath"`
-	MaskPath     string `json:"maskPath,omitempty"`
+	Prompt       string `json:"prompt,omitempty"`
 	N            int    `json:"n,omitempty"`
 	Size         string `json:"size,omitempty"`
 }
 
+// UpscaleImageRequest contains image upscaling inputs.
+type UpscaleImageRequest struct {
+	ProviderName string `json:"providerName"`
+	ModelName    string `json:"modelName,omitempty"`
+	ImagePath    string `json:"imagePath"`
+	Factor       int    `json:"factor,omitempty"`
+}
+
+// MaskPoint is a pixel coordinate within the source image.
+type MaskPoint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// MaskRect is a pixel rectangle within the source image.
+type MaskRect struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// MaskPolygon is a closed pixel polygon within the source image.
+type MaskPolygon struct {
+	Points []MaskPoint `json:"points"`
+}
+
+// BuildMaskRequest contains inputs for rendering an edit mask sized to a source image.
+// Rects, polygons, and pixels matching EditColor become editable; BaseMaskPath seeds the mask from an existing file.
+type BuildMaskRequest struct {
+	ImagePath      string        `json:"imagePath"`
+	Rects          []MaskRect    `json:"rects,omitempty"`
+	Polygons       []MaskPolygon `json:"polygons,omitempty"`
+	EditColor      string        `json:"editColor,omitempty"`
+	ColorTolerance int           `json:"colorTolerance,omitempty"`
+	BaseMaskPath   string        `json:"baseMaskPath,omitempty"`
+	Invert         bool          `json:"invert,omitempty"`
+}
+
+// MaskResult contains an encoded PNG alpha mask and its dimensions.
+type MaskResult struct {
+	Bytes  []byte `json:"bytes"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
 // ImageBinaryResult contains binary image output metadata.
 type ImageBinaryResult struct {
 	Bytes         []byte `json:"bytes"`
@@ -0,0 +1,53 @@
+// retriever.go adapts knowledge-base search to the chat retrieval port.
+// internal/features/ai/knowledge/adapters/chatretriever/retriever.go
+package chatretriever
+
+import (
+	"context"
+	"fmt"
+
+	chatports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/ports"
+	knowledgeports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/ports"
+)
+
+// Retriever serves chat retrieval requests from a knowledge service.
+type Retriever struct {
+	knowledge knowledgeports.KnowledgeInterface
+}
+
+var _ chatports.KnowledgeRetriever = (*Retriever)(nil)
+
+// New creates a chat retriever backed by a knowledge service.
+func New(knowledge knowledgeports.KnowledgeInterface) *Retriever {
+
+	return &Retriever{knowledge: knowledge}
+}
+
+// Retrieve searches a collection and maps passages to the chat port shape.
+func (r *Retriever) Retrieve(ctx context.Context, collection, query string, topK int) ([]chatports.RetrievedPassage, error) {
+
+	if r.knowledge == nil {
+		return nil, fmt.Errorf("knowledge service not configured")
+	}
+	passages, err := r.knowledge.Search(ctx, knowledgeports.SearchRequest{
+		Collection: collection,
+		Query:      query,
+		TopK:       topK,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]chatports.RetrievedPassage, 0, len(passages))
+	for _, passage := range passages {
+		output = append(output, chatports.RetrievedPassage{
+			Collection: passage.Collection,
+			SourcePath: passage.SourcePath,
+			StartLine:  passage.StartLine,
+			EndLine:    passage.EndLine,
+			Content:    passage.Content,
+			Score:      passage.Score,
+		})
+	}
+	return output, nil
+}
@@ -0,0 +1,266 @@
+// pdf.go extracts plain text from the content streams of simple PDF files.
+// internal/features/ai/knowledge/adapters/docreader/pdf.go
+package docreader
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// maxInflatedBytes bounds the decompressed size of one content stream.
+const maxInflatedBytes = 4 * maxFileBytes
+
+// streamPattern matches a stream dictionary and the start of its data.
+var streamPattern = regexp.MustCompile(`(?s)<<((?:[^<>]|<<[^<>]*>>|<[^<>]*>)*)>>\s*stream\r?\n`)
+
+// ExtractPDFText returns text drawn by Tj, TJ, ' and " operators in a PDF's content streams.
+// Only uncompressed and FlateDecode streams are read; scanned PDFs yield no text.
+func ExtractPDFText(data []byte) (string, error) {
+
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return "", errors.New("not a PDF file")
+	}
+
+	var text strings.Builder
+	for _, match := range streamPattern.FindAllSubmatchIndex(data, -1) {
+		dictionary := string(data[match[2]:match[3]])
+		start := match[1]
+		end := bytes.Index(data[start:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		content := data[start : start+end]
+		if strings.Contains(dictionary, "/Subtype") || strings.Contains(dictionary, "/Type") {
+			// Images, fonts, and object streams are not page content.
+			continue
+		}
+
+		switch {
+		case strings.Contains(dictionary, "/FlateDecode"):
+			inflated, err := inflate(content)
+			if err != nil {
+				continue
+			}
+			content = inflated
+		case strings.Contains(dictionary, "/Filter"):
+			continue
+		}
+
+		if extracted := strings.TrimRight(contentStreamText(content), "\n"); strings.TrimSpace(extracted) != "" {
+			text.WriteString(extracted)
+			text.WriteString("\n")
+		}
+	}
+
+	result := strings.TrimSpace(text.String())
+	if result == "" {
+		return "", errors.New("no extractable text in PDF")
+	}
+	return result, nil
+}
+
+// inflate decompresses a FlateDecode stream, tolerating trailing garbage after the zlib data.
+// Streams that inflate past maxInflatedBytes are rejected rather than read into memory.
+func inflate(content []byte) ([]byte, error) {
+
+	reader, err := zlib.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("inflate stream: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	inflated, err := io.ReadAll(io.LimitReader(reader, maxInflatedBytes+1))
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && len(inflated) == 0 {
+		return nil, fmt.Errorf("inflate stream: %w", err)
+	}
+	if len(inflated) > maxInflatedBytes {
+		return nil, fmt.Errorf("inflate stream: exceeds %d MB", maxInflatedBytes>>20)
+	}
+	return inflated, nil
+}
+
+// contentStreamText walks content-stream tokens and collects shown strings.
+func contentStreamText(content []byte) string {
+
+	var text strings.Builder
+	var operands []string
+	lineHasText := false
+	newLine := func() {
+		if lineHasText {
+			text.WriteString("\n")
+			lineHasText = false
+		}
+	}
+	show := func(value string) {
+		text.WriteString(value)
+		if value != "" {
+			lineHasText = true
+		}
+	}
+
+	for position := 0; position < len(content); {
+		current := content[position]
+		switch {
+		case current == '(':
+			value, next := readLiteralString(content, position)
+			operands = append(operands, value)
+			position = next
+		case current == '<' && position+1 < len(content) && content[position+1] != '<':
+			value, next := readHexString(content, position)
+			operands = append(operands, value)
+			position = next
+		case current == '[' || current == ']':
+			position++
+		case current == '%':
+			for position < len(content) && content[position] != '\n' && content[position] != '\r' {
+				position++
+			}
+		case isDelimiterOrSpace(current):
+			position++
+		default:
+			start := position
+			for position < len(content) && !isDelimiterOrSpace(content[position]) && content[position] != '(' && content[position] != '<' && content[position] != '[' && content[position] != ']' {
+				position++
+			}
+			if position == start {
+				position++
+				continue
+			}
+			token := string(content[start:position])
+			if token[0] == '/' || isNumeric(token) {
+				continue
+			}
+			switch token {
+			case "Tj", "TJ":
+				for _, operand := range operands {
+					show(operand)
+				}
+			case "'", "\"":
+				newLine()
+				for _, operand := range operands {
+					show(operand)
+				}
+			case "Td", "TD", "T*", "ET":
+				newLine()
+			}
+			operands = operands[:0]
+		}
+	}
+	return text.String()
+}
+
+// readLiteralString decodes a parenthesized PDF string starting at position.
+func readLiteralString(content []byte, position int) (string, int) {
+
+	var value strings.Builder
+	depth := 0
+	for position < len(content) {
+		current := content[position]
+		switch {
+		case current == '\\' && position+1 < len(content):
+			position++
+			escaped := content[position]
+			switch escaped {
+			case 'n':
+				value.WriteByte('\n')
+			case 'r':
+				value.WriteByte('\r')
+			case 't':
+				value.WriteByte('\t')
+			case 'b', 'f':
+			case '\r', '\n':
+			default:
+				if escaped >= '0' && escaped <= '7' {
+					code := 0
+					digits := 0
+					for digits < 3 && position < len(content) && content[position] >= '0' && content[position] <= '7' {
+						code = code*8 + int(content[position]-'0')
+						position++
+						digits++
+					}
+					value.WriteRune(rune(code & 0xff))
+					continue
+				}
+				value.WriteByte(escaped)
+			}
+		case current == '(':
+			if depth > 0 {
+				value.WriteByte(current)
+			}
+			depth++
+		case current == ')':
+			depth--
+			if depth == 0 {
+				return value.String(), position + 1
+			}
+			value.WriteByte(current)
+		default:
+			value.WriteByte(current)
+		}
+		position++
+	}
+	return value.String(), position
+}
+
+// readHexString decodes a <hex> PDF string starting at position.
+func readHexString(content []byte, position int) (string, int) {
+
+	end := bytes.IndexByte(content[position:], '>')
+	if end < 0 {
+		return "", len(content)
+	}
+	digits := make([]byte, 0, end)
+	for _, current := range content[position+1 : position+end] {
+		if hexValue(current) >= 0 {
+			digits = append(digits, current)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	var value strings.Builder
+	for index := 0; index < len(digits); index += 2 {
+		value.WriteRune(rune(hexValue(digits[index])<<4 | hexValue(digits[index+1])))
+	}
+	return value.String(), position + end + 1
+}
+
+// hexValue returns the value of a hex digit, or -1.
+func hexValue(current byte) int {
+
+	switch {
+	case current >= '0' && current <= '9':
+		return int(current - '0')
+	case current >= 'a' && current <= 'f':
+		return int(current-'a') + 10
+	case current >= 'A' && current <= 'F':
+		return int(current-'A') + 10
+	}
+	return -1
+}
+
+// isDelimiterOrSpace reports PDF whitespace and delimiters that end a token.
+func isDelimiterOrSpace(current byte) bool {
+
+	switch current {
+	case ' ', '\t', '\r', '\n', '\f', 0, '{', '}', ')', '>':
+		return true
+	}
+	return false
+}
+
+// isNumeric reports whether a token is a PDF number.
+func isNumeric(token string) bool {
+
+	for _, current := range token {
+		if (current < '0' || current > '9') && current != '.' && current != '-' && current != '+' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+// reader.go expands ingest paths into supported files and reads their text.
+// internal/features/ai/knowledge/adapters/docreader/reader.go
+package docreader
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	knowledgeports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/ports"
+)
+
+// maxFileBytes bounds the size of a single ingested file.
+const maxFileBytes = 20 << 20
+
+// textExtensions lists Markdown, plain-text, and source-code files read verbatim.
+var textExtensions = map[string]bool{
+	".md": true, ".markdown": true, ".mdx": true, ".txt": true, ".rst": true, ".adoc": true,
+	".go": true, ".py": true, ".js": true, ".jsx": true, ".ts": true, ".tsx": true, ".java": true,
+	".kt": true, ".c": true, ".h": true, ".cc": true, ".cpp": true, ".hpp": true, ".cs": true,
+	".rs": true, ".rb": true, ".php": true, ".swift": true, ".sh": true, ".sql": true,
+	".html": true, ".css": true, ".json": true, ".yaml": true, ".yml": true, ".toml": true,
+}
+
+// skippedDirectories are never descended into when walking a directory.
+var skippedDirectories = map[string]bool{
+	"node_modules": true, "vendor": true, "dist": true, "build": true,
+}
+
+// Reader reads documents from the local filesystem.
+type Reader struct{}
+
+var _ knowledgeports.DocumentReader = (*Reader)(nil)
+
+// New creates a filesystem document reader.
+func New() *Reader {
+
+	return &Reader{}
+}
+
+// ListFiles expands files and directories into absolute paths of supported files.
+func (r *Reader) ListFiles(paths []string) ([]string, []string, error) {
+
+	seen := make(map[string]bool)
+	files := make([]string, 0)
+	skipped := make([]string, 0)
+	add := func(path string) {
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+		if supported(path) {
+			files = append(files, path)
+		} else {
+			skipped = append(skipped, path)
+		}
+	}
+
+	for _, path := range paths {
+		absolute, err := filepath.Abs(strings.TrimSpace(path))
+		if err != nil {
+			return nil, nil, fmt.Errorf("resolve %s: %w", path, err)
+		}
+		info, err := os.Stat(absolute)
+		if err != nil {
+			return nil, nil, fmt.Errorf("stat %s: %w", path, err)
+		}
+		if !info.IsDir() {
+			add(absolute)
+			continue
+		}
+
+		err = filepath.WalkDir(absolute, func(current string, entry fs.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+			name := entry.Name()
+			if entry.IsDir() {
+				if current != absolute && (strings.HasPrefix(name, ".") || skippedDirectories[name]) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasPrefix(name, ".") || !entry.Type().IsRegular() {
+				return nil
+			}
+			add(current)
+			return nil
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("walk %s: %w", path, err)
+		}
+	}
+
+	sort.Strings(files)
+	sort.Strings(skipped)
+	return files, skipped, nil
+}
+
+// ReadText returns the text of a supported file, extracting PDF text streams when needed.
+func (r *Reader) ReadText(path string) (string, error) {
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("stat %s: %w", path, err)
+	}
+	if info.Size() > maxFileBytes {
+		return "", fmt.Errorf("%s is larger than %d MB", path, maxFileBytes>>20)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", path, err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".pdf") {
+		return ExtractPDFText(data)
+	}
+	if !utf8.Valid(data) {
+		return "", fmt.Errorf("%s is not valid UTF-8 text", path)
+	}
+	return string(data), nil
+}
+
+// supported reports whether a file extension can be ingested.
+func supported(path string) bool {
+
+	extension := strings.ToLower(filepath.Ext(path))
+	return textExtensions[extension] || extension == ".pdf"
+}
@@ -0,0 +1,104 @@
+// reader_test.go verifies file discovery and PDF text extraction.
+// internal/features/ai/knowledge/adapters/docreader/reader_test.go
+package docreader
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// TestListFilesWalksDirectoriesAndReportsSkipped validates filtering of hidden, vendored, and unsupported files.
+func TestListFilesWalksDirectoriesAndReportsSkipped(t *testing.T) {
+
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "guide.md"), "# Guide")
+	writeFile(t, filepath.Join(root, "src", "main.go"), "package main")
+	writeFile(t, filepath.Join(root, "logo.png"), "png")
+	writeFile(t, filepath.Join(root, ".git", "config"), "[core]")
+	writeFile(t, filepath.Join(root, "node_modules", "lib.js"), "x")
+	writeFile(t, filepath.Join(root, ".env"), "SECRET=1")
+	extra := filepath.Join(t.TempDir(), "notes.txt")
+	writeFile(t, extra, "notes")
+
+	files, skipped, err := New().ListFiles([]string{root, extra, filepath.Join(root, "guide.md")})
+	if err != nil {
+		t.Fatalf("list files: %v", err)
+	}
+
+	want := []string{filepath.Join(root, "guide.md"), filepath.Join(root, "src", "main.go"), extra}
+	sort.Strings(want)
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected files %v", files)
+	}
+	if len(skipped) != 1 || skipped[0] != filepath.Join(root, "logo.png") {
+		t.Fatalf("unexpected skipped %v", skipped)
+	}
+
+	if _, _, err := New().ListFiles([]string{filepath.Join(root, "missing")}); err == nil {
+		t.Fatalf("expected error for missing path")
+	}
+}
+
+// TestReadTextExtractsPDFContentStreams validates compressed and plain content-stream text extraction.
+func TestReadTextExtractsPDFContentStreams(t *testing.T) {
+
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	_, _ = writer.Write([]byte("BT /F1 12 Tf 72 712 Td (Hello \\(PDF\\)) Tj 0 -14 Td [(Wor) -20 (ld)] TJ ET"))
+	_ = writer.Close()
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+	pdf.WriteString("4 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>\nendobj\n")
+	fmt.Fprintf(&pdf, "5 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", compressed.Len())
+	pdf.Write(compressed.Bytes())
+	pdf.WriteString("\nendstream\nendobj\n")
+	plain := "BT <4869> Tj T* (second page) ' ET"
+	fmt.Fprintf(&pdf, "6 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n%%%%EOF\n", len(plain), plain)
+
+	path := filepath.Join(t.TempDir(), "doc.pdf")
+	writeFile(t, path, pdf.String())
+
+	text, err := New().ReadText(path)
+	if err != nil {
+		t.Fatalf("read pdf: %v", err)
+	}
+	if text != "Hello (PDF)\nWorld\nHi\nsecond page" {
+		t.Fatalf("unexpected text %q", text)
+	}
+
+	if _, err := ExtractPDFText([]byte("%PDF-1.4\n%%EOF")); err == nil {
+		t.Fatalf("expected error for PDF without text")
+	}
+}
+
+// TestInflateRejectsOversizedStreams validates that decompression stops at maxInflatedBytes.
+func TestInflateRejectsOversizedStreams(t *testing.T) {
+
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	_, _ = writer.Write(make([]byte, maxInflatedBytes+1))
+	_ = writer.Close()
+
+	if _, err := inflate(compressed.Bytes()); err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Fatalf("expected oversized stream error, got %v", err)
+	}
+}
+
+// writeFile creates a file and its parent directories.
+func writeFile(t *testing.T, path, content string) {
+
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+}
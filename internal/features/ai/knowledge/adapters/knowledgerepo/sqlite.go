@@ -0,0 +1,332 @@
+// sqlite.go persists knowledge-base collections and chunk vectors in SQLite.
+// internal/features/ai/knowledge/adapters/knowledgerepo/sqlite.go
+package knowledgerepo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	knowledgedomain "github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/domain"
+	knowledgeports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/ports"
+)
+
+const knowledgeSchema = `
+CREATE TABLE IF NOT EXISTS knowledge_collections (
+	name TEXT PRIMARY KEY,
+	provider TEXT NOT NULL,
+	model TEXT NOT NULL,
+	dimensions INTEGER NOT NULL,
+	vector_dimensions INTEGER NOT NULL DEFAULT 0,
+	index_kind TEXT NOT NULL,
+	chunk_size INTEGER NOT NULL,
+	chunk_overlap INTEGER NOT NULL,
+	created_at INTEGER NOT NULL,
+	updated_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS knowledge_documents (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	collection TEXT NOT NULL,
+	path TEXT NOT NULL,
+	content_hash TEXT NOT NULL,
+	ingested_at INTEGER NOT NULL,
+	FOREIGN KEY (collection) REFERENCES knowledge_collections(name) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_knowledge_documents_path
+ON knowledge_documents (collection, path);
+
+CREATE TABLE IF NOT EXISTS knowledge_chunks (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	document_id INTEGER NOT NULL,
+	chunk_index INTEGER NOT NULL,
+	start_line INTEGER NOT NULL,
+	end_line INTEGER NOT NULL,
+	content TEXT NOT NULL,
+	vector BLOB NOT NULL,
+	FOREIGN KEY (document_id) REFERENCES knowledge_documents(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_knowledge_chunks_document
+ON knowledge_chunks (document_id, chunk_index);
+`
+
+// Repository stores knowledge-base data in SQLite.
+type Repository struct {
+	db *sql.DB
+}
+
+var _ knowledgeports.KnowledgeRepository = (*Repository)(nil)
+
+// NewRepository creates a SQLite-backed knowledge repository.
+func NewRepository(db *sql.DB) (*Repository, error) {
+
+	if db == nil {
+		return nil, fmt.Errorf("knowledge repo: db required")
+	}
+	if _, err := db.Exec(knowledgeSchema); err != nil {
+		return nil, fmt.Errorf("knowledge repo: ensure schema: %w", err)
+	}
+	return &Repository{db: db}, nil
+}
+
+// SaveCollection inserts or updates collection settings.
+func (r *Repository) SaveCollection(ctx context.Context, collection knowledgedomain.Collection) error {
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO knowledge_collections (name, provider, model, dimensions, vector_dimensions, index_kind, chunk_size, chunk_overlap, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(name) DO UPDATE SET
+		  provider = excluded.provider,
+		  model = excluded.model,
+		  dimensions = excluded.dimensions,
+		  vector_dimensions = excluded.vector_dimensions,
+		  index_kind = excluded.index_kind,
+		  chunk_size = excluded.chunk_size,
+		  chunk_overlap = excluded.chunk_overlap,
+		  updated_at = excluded.updated_at`,
+		collection.Name,
+		collection.ProviderName,
+		collection.ModelName,
+		collection.Dimensions,
+		collection.VectorDimensions,
+		string(collection.Index),
+		collection.ChunkSize,
+		collection.ChunkOverlap,
+		collection.CreatedAt,
+		collection.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("knowledge repo: save collection: %w", err)
+	}
+	return nil
+}
+
+// collectionQuery selects collection settings with document and chunk counts.
+const collectionQuery = `
+SELECT c.name, c.provider, c.model, c.dimensions, c.vector_dimensions, c.index_kind, c.chunk_size, c.chunk_overlap, c.created_at, c.updated_at,
+       (SELECT COUNT(*) FROM knowledge_documents d WHERE d.collection = c.name),
+       (SELECT COUNT(*) FROM knowledge_chunks k JOIN knowledge_documents d ON d.id = k.document_id WHERE d.collection = c.name)
+FROM knowledge_collections c`
+
+// scanCollection reads one collectionQuery row.
+func scanCollection(scanner interface{ Scan(...any) error }) (knowledgedomain.Collection, error) {
+
+	var collection knowledgedomain.Collection
+	var index string
+	err := scanner.Scan(
+		&collection.Name,
+		&collection.ProviderName,
+		&collection.ModelName,
+		&collection.Dimensions,
+		&collection.VectorDimensions,
+		&index,
+		&collection.ChunkSize,
+		&collection.ChunkOverlap,
+		&collection.CreatedAt,
+		&collection.UpdatedAt,
+		&collection.Documents,
+		&collection.Chunks,
+	)
+	collection.Index = knowledgedomain.IndexKind(index)
+	return collection, err
+}
+
+// GetCollection returns a collection by name, or nil when it does not exist.
+func (r *Repository) GetCollection(ctx context.Context, name string) (*knowledgedomain.Collection, error) {
+
+	collection, err := scanCollection(r.db.QueryRowContext(ctx, collectionQuery+" WHERE c.name = ?", name))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("knowledge repo: get collection: %w", err)
+	}
+	return &collection, nil
+}
+
+// ListCollections returns every collection ordered by name.
+func (r *Repository) ListCollections(ctx context.Context) ([]knowledgedomain.Collection, error) {
+
+	rows, err := r.db.QueryContext(ctx, collectionQuery+" ORDER BY c.name ASC")
+	if err != nil {
+		return nil, fmt.Errorf("knowledge repo: list collections: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	collections := make([]knowledgedomain.Collection, 0)
+	for rows.Next() {
+		collection, err := scanCollection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("knowledge repo: scan collection: %w", err)
+		}
+		collections = append(collections, collection)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("knowledge repo: collection rows: %w", err)
+	}
+	return collections, nil
+}
+
+// DeleteCollection removes a collection with its documents and chunks.
+func (r *Repository) DeleteCollection(ctx context.Context, name string) error {
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM knowledge_chunks WHERE document_id IN (SELECT id FROM knowledge_documents WHERE collection = ?)`, name); err != nil {
+			return fmt.Errorf("knowledge repo: delete chunks: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM knowledge_documents WHERE collection = ?`, name); err != nil {
+			return fmt.Errorf("knowledge repo: delete documents: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM knowledge_collections WHERE name = ?`, name); err != nil {
+			return fmt.Errorf("knowledge repo: delete collection: %w", err)
+		}
+		return nil
+	})
+}
+
+// DocumentHash returns the stored content hash for a document, or "" when it was never ingested.
+func (r *Repository) DocumentHash(ctx context.Context, collection, path string) (string, error) {
+
+	var hash string
+	err := r.db.QueryRowContext(ctx,
+		`SELECT content_hash FROM knowledge_documents WHERE collection = ? AND path = ?`, collection, path).Scan(&hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("knowledge repo: document hash: %w", err)
+	}
+	return hash, nil
+}
+
+// ReplaceDocument stores a document and its chunks, replacing any earlier version of the same path.
+func (r *Repository) ReplaceDocument(ctx context.Context, collection string, document knowledgedomain.Document, chunks []knowledgedomain.Chunk) error {
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM knowledge_chunks WHERE document_id IN (SELECT id FROM knowledge_documents WHERE collection = ? AND path = ?)`,
+			collection, document.Path); err != nil {
+			return fmt.Errorf("knowledge repo: delete old chunks: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM knowledge_documents WHERE collection = ? AND path = ?`, collection, document.Path); err != nil {
+			return fmt.Errorf("knowledge repo: delete old document: %w", err)
+		}
+
+		result, err := tx.ExecContext(ctx,
+			`INSERT INTO knowledge_documents (collection, path, content_hash, ingested_at) VALUES (?, ?, ?, ?)`,
+			collection, document.Path, document.ContentHash, document.IngestedAt)
+		if err != nil {
+			return fmt.Errorf("knowledge repo: insert document: %w", err)
+		}
+		documentID, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("knowledge repo: document id: %w", err)
+		}
+
+		statement, err := tx.PrepareContext(ctx,
+			`INSERT INTO knowledge_chunks (document_id, chunk_index, start_line, end_line, content, vector) VALUES (?, ?, ?, ?, ?, ?)`)
+		if err != nil {
+			return fmt.Errorf("knowledge repo: prepare chunk insert: %w", err)
+		}
+		defer func() { _ = statement.Close() }()
+		for _, chunk := range chunks {
+			if _, err := statement.ExecContext(ctx, documentID, chunk.Index, chunk.StartLine, chunk.EndLine, chunk.Content, encodeVector(chunk.Vector)); err != nil {
+				return fmt.Errorf("knowledge repo: insert chunk: %w", err)
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE knowledge_collections SET updated_at = ? WHERE name = ?`, document.IngestedAt, collection); err != nil {
+			return fmt.Errorf("knowledge repo: touch collection: %w", err)
+		}
+		return nil
+	})
+}
+
+// LoadChunks returns every chunk of a collection with its vector.
+func (r *Repository) LoadChunks(ctx context.Context, collection string) ([]knowledgedomain.Chunk, error) {
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT k.id, d.path, k.chunk_index, k.start_line, k.end_line, k.content, k.vector
+		 FROM knowledge_chunks k
+		 JOIN knowledge_documents d ON d.id = k.document_id
+		 WHERE d.collection = ?
+		 ORDER BY k.id ASC`,
+		collection,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("knowledge repo: load chunks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	chunks := make([]knowledgedomain.Chunk, 0)
+	for rows.Next() {
+		var chunk knowledgedomain.Chunk
+		var vector []byte
+		if err := rows.Scan(&chunk.ID, &chunk.DocumentPath, &chunk.Index, &chunk.StartLine, &chunk.EndLine, &chunk.Content, &vector); err != nil {
+			return nil, fmt.Errorf("knowledge repo: scan chunk: %w", err)
+		}
+		decoded, err := decodeVector(vector)
+		if err != nil {
+			return nil, fmt.Errorf("knowledge repo: chunk %d: %w", chunk.ID, err)
+		}
+		chunk.Vector = decoded
+		chunks = append(chunks, chunk)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("knowledge repo: chunk rows: %w", err)
+	}
+	return chunks, nil
+}
+
+// encodeVector packs a vector as little-endian float32 values.
+func encodeVector(vector []float32) []byte {
+
+	data := make([]byte, 4*len(vector))
+	for index, value := range vector {
+		binary.LittleEndian.PutUint32(data[index*4:], math.Float32bits(value))
+	}
+	return data
+}
+
+// decodeVector unpacks little-endian float32 values.
+func decodeVector(data []byte) ([]float32, error) {
+
+	if len(data)%4 != 0 {
+		return nil, fmt.Errorf("vector blob length %d is not a multiple of 4", len(data))
+	}
+	vector := make([]float32, len(data)/4)
+	for index := range vector {
+		vector[index] = math.Float32frombits(binary.LittleEndian.Uint32(data[index*4:]))
+	}
+	return vector, nil
+}
+
+// withTx executes a function in a transaction.
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) (err error) {
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("knowledge repo: begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("knowledge repo: commit tx: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// sqlite_test.go verifies knowledge repository persistence behavior.
+// internal/features/ai/knowledge/adapters/knowledgerepo/sqlite_test.go
+package knowledgerepo
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/MadeByDoug/wls-chatbot/internal/core/datastore"
+	knowledgedomain "github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/domain"
+)
+
+// TestRepositoryDocumentRoundTrip verifies collection counts, vector encoding, and document replacement.
+func TestRepositoryDocumentRoundTrip(t *testing.T) {
+
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	collection := knowledgedomain.Collection{
+		Name:             "docs",
+		ProviderName:     "openai",
+		ModelName:        "text-embedding-3-small",
+		VectorDimensions: 3,
+		Index:            knowledgedomain.IndexHNSW,
+		ChunkSize:        800,
+		ChunkOverlap:     100,
+		CreatedAt:        1,
+		UpdatedAt:        1,
+	}
+	if err := repo.SaveCollection(ctx, collection); err != nil {
+		t.Fatalf("save collection: %v", err)
+	}
+
+	first := []knowledgedomain.Chunk{
+		{Index: 0, StartLine: 1, EndLine: 4, Content: "alpha", Vector: []float32{0.5, -1.25, 3}},
+		{Index: 1, StartLine: 4, EndLine: 9, Content: "beta", Vector: []float32{1, 0, 0}},
+	}
+	if err := repo.ReplaceDocument(ctx, "docs", knowledgedomain.Document{Path: "/a.md", ContentHash: "h1", IngestedAt: 5}, first); err != nil {
+		t.Fatalf("replace document: %v", err)
+	}
+	if err := repo.ReplaceDocument(ctx, "docs", knowledgedomain.Document{Path: "/b.md", ContentHash: "h2", IngestedAt: 6}, first[:1]); err != nil {
+		t.Fatalf("replace document: %v", err)
+	}
+	replacement := []knowledgedomain.Chunk{{Index: 0, StartLine: 1, EndLine: 2, Content: "gamma", Vector: []float32{0, 1, 0}}}
+	if err := repo.ReplaceDocument(ctx, "docs", knowledgedomain.Document{Path: "/a.md", ContentHash: "h3", IngestedAt: 7}, replacement); err != nil {
+		t.Fatalf("replace document: %v", err)
+	}
+
+	stored, err := repo.GetCollection(ctx, "docs")
+	if err != nil || stored == nil {
+		t.Fatalf("get collection: %v", err)
+	}
+	if stored.Index != knowledgedomain.IndexHNSW || stored.Documents != 2 || stored.Chunks != 2 || stored.UpdatedAt != 7 || stored.VectorDimensions != 3 {
+		t.Fatalf("unexpected collection %#v", stored)
+	}
+	hash, err := repo.DocumentHash(ctx, "docs", "/a.md")
+	if err != nil || hash != "h3" {
+		t.Fatalf("expected replaced hash h3, got %q (%v)", hash, err)
+	}
+
+	chunks, err := repo.LoadChunks(ctx, "docs")
+	if err != nil {
+		t.Fatalf("load chunks: %v", err)
+	}
+	if len(chunks) != 2 || chunks[0].DocumentPath != "/b.md" || chunks[1].Content != "gamma" {
+		t.Fatalf("unexpected chunks %#v", chunks)
+	}
+	if vector := chunks[0].Vector; len(vector) != 3 || vector[0] != 0.5 || vector[1] != -1.25 || vector[2] != 3 {
+		t.Fatalf("vector did not round-trip: %#v", vector)
+	}
+}
+
+// TestRepositoryDeleteCollectionCascades verifies deletion removes documents and chunks.
+func TestRepositoryDeleteCollectionCascades(t *testing.T) {
+
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	if err := repo.SaveCollection(ctx, knowledgedomain.Collection{Name: "notes", ProviderName: "gemini", Index: knowledgedomain.IndexFlat}); err != nil {
+		t.Fatalf("save collection: %v", err)
+	}
+	chunks := []knowledgedomain.Chunk{{Content: "x", Vector: []float32{1}}}
+	if err := repo.ReplaceDocument(ctx, "notes", knowledgedomain.Document{Path: "/n.txt", ContentHash: "h"}, chunks); err != nil {
+		t.Fatalf("replace document: %v", err)
+	}
+
+	if err := repo.DeleteCollection(ctx, "notes"); err != nil {
+		t.Fatalf("delete collection: %v", err)
+	}
+	if stored, err := repo.GetCollection(ctx, "notes"); err != nil || stored != nil {
+		t.Fatalf("expected collection gone, got %#v (%v)", stored, err)
+	}
+	if hash, err := repo.DocumentHash(ctx, "notes", "/n.txt"); err != nil || hash != "" {
+		t.Fatalf("expected document gone, got %q (%v)", hash, err)
+	}
+	if remaining, err := repo.LoadChunks(ctx, "notes"); err != nil || len(remaining) != 0 {
+		t.Fatalf("expected chunks gone, got %#v (%v)", remaining, err)
+	}
+}
+
+// newTestRepository creates a repository backed by a temporary SQLite file.
+func newTestRepository(t *testing.T) *Repository {
+
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "knowledgerepo.db")
+	db, err := datastore.OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	repo, err := NewRepository(db)
+	if err != nil {
+		t.Fatalf("new repository: %v", err)
+	}
+
+	return repo
+}
@@ -0,0 +1,428 @@
+// service.go provides knowledge-base ingestion and retrieval operations.
+// internal/features/ai/knowledge/app/knowledge/service.go
+package knowledge
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	embeddingports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/embedding/ports"
+	knowledgedomain "github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/domain"
+	knowledgeports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/ports"
+	rerankports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/rerank/ports"
+)
+
+// rerankCandidateFactor widens the vector search when results are reranked, so relevant chunks ranked
+// just below TopK by similarity can still be promoted.
+const rerankCandidateFactor = 4
+
+// Service ingests documents into collections and searches them by similarity.
+type Service struct {
+	repo       knowledgeports.KnowledgeRepository
+	reader     knowledgeports.DocumentReader
+	embeddings embeddingports.EmbeddingInterface
+	reranker   rerankports.RerankInterface
+
+	mu      sync.Mutex
+	indexes map[string]*cachedIndex
+}
+
+// cachedIndex holds an in-memory vector index built from a collection's stored chunks.
+type cachedIndex struct {
+	updatedAt int64
+	chunks    []knowledgedomain.Chunk
+	index     knowledgedomain.VectorIndex
+}
+
+var _ knowledgeports.KnowledgeInterface = (*Service)(nil)
+
+// NewService creates a knowledge service from storage, document, and embedding dependencies.
+func NewService(repo knowledgeports.KnowledgeRepository, reader knowledgeports.DocumentReader, embeddings embeddingports.EmbeddingInterface) *Service {
+
+	return &Service{
+		repo:       repo,
+		reader:     reader,
+		embeddings: embeddings,
+		indexes:    make(map[string]*cachedIndex),
+	}
+}
+
+// SetReranker enables reordering search results with a rerank provider when a search request names one.
+func (s *Service) SetReranker(reranker rerankports.RerankInterface) {
+
+	s.reranker = reranker
+}
+
+// CreateCollection validates settings and stores a new, empty collection.
+func (s *Service) CreateCollection(ctx context.Context, request knowledgeports.CreateCollectionRequest) (knowledgeports.Collection, error) {
+
+	if err := s.ensureConfigured(); err != nil {
+		return knowledgeports.Collection{}, err
+	}
+	collection := knowledgedomain.Collection{
+		Name:         request.Name,
+		ProviderName: request.ProviderName,
+		ModelName:    request.ModelName,
+		Dimensions:   request.Dimensions,
+		Index:        knowledgedomain.IndexKind(strings.ToLower(strings.TrimSpace(request.Index))),
+		ChunkSize:    request.ChunkSize,
+		ChunkOverlap: request.ChunkOverlap,
+	}
+	if err := collection.Normalize(); err != nil {
+		return knowledgeports.Collection{}, fmt.Errorf("create collection: %w", err)
+	}
+
+	existing, err := s.repo.GetCollection(ctx, collection.Name)
+	if err != nil {
+		return knowledgeports.Collection{}, err
+	}
+	if existing != nil {
+		return knowledgeports.Collection{}, fmt.Errorf("create collection: %s already exists", collection.Name)
+	}
+
+	now := time.Now().UnixMilli()
+	collection.CreatedAt = now
+	collection.UpdatedAt = now
+	if err := s.repo.SaveCollection(ctx, collection); err != nil {
+		return knowledgeports.Collection{}, err
+	}
+	return toPortCollection(collection), nil
+}
+
+// ListCollections returns every collection with document and chunk counts.
+func (s *Service) ListCollections(ctx context.Context) ([]knowledgeports.Collection, error) {
+
+	if err := s.ensureConfigured(); err != nil {
+		return nil, err
+	}
+	collections, err := s.repo.ListCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]knowledgeports.Collection, 0, len(collections))
+	for _, collection := range collections {
+		output = append(output, toPortCollection(collection))
+	}
+	return output, nil
+}
+
+// DeleteCollection removes a collection and everything ingested into it.
+func (s *Service) DeleteCollection(ctx context.Context, name string) error {
+
+	collection, err := s.getCollection(ctx, name)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteCollection(ctx, collection.Name); err != nil {
+		return err
+	}
+	s.invalidate(collection.Name)
+	return nil
+}
+
+// Ingest reads, chunks, and embeds files into a collection, skipping documents whose content is unchanged.
+func (s *Service) Ingest(ctx context.Context, request knowledgeports.IngestRequest) (knowledgeports.IngestResult, error) {
+
+	collection, err := s.getCollection(ctx, request.Collection)
+	if err != nil {
+		return knowledgeports.IngestResult{}, err
+	}
+	if len(request.Paths) == 0 {
+		return knowledgeports.IngestResult{}, fmt.Errorf("ingest: at least one path is required")
+	}
+	files, skipped, err := s.reader.ListFiles(request.Paths)
+	if err != nil {
+		return knowledgeports.IngestResult{}, fmt.Errorf("ingest: %w", err)
+	}
+	defer s.invalidate(collection.Name)
+
+	result := knowledgeports.IngestResult{Skipped: skipped}
+	for _, path := range files {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		text, err := s.reader.ReadText(path)
+		if err != nil {
+			result.Skipped = append(result.Skipped, path)
+			continue
+		}
+		hash := contentHash(text)
+		storedHash, err := s.repo.DocumentHash(ctx, collection.Name, path)
+		if err != nil {
+			return result, err
+		}
+		if storedHash == hash {
+			result.Unchanged++
+			continue
+		}
+
+		textChunks := knowledgedomain.ChunkText(text, collection.ChunkSize, collection.ChunkOverlap)
+		if len(textChunks) == 0 {
+			result.Skipped = append(result.Skipped, path)
+			continue
+		}
+		chunks, err := s.embedChunks(ctx, collection, path, textChunks)
+		if err != nil {
+			return result, fmt.Errorf("ingest %s: %w", path, err)
+		}
+
+		document := knowledgedomain.Document{Path: path, ContentHash: hash, IngestedAt: time.Now().UnixMilli()}
+		if err := s.repo.ReplaceDocument(ctx, collection.Name, document, chunks); err != nil {
+			return result, err
+		}
+		result.Documents++
+		result.Chunks += len(chunks)
+	}
+	return result, nil
+}
+
+// Search embeds a query and returns the most similar passages in a collection, optionally reranked.
+func (s *Service) Search(ctx context.Context, request knowledgeports.SearchRequest) ([]knowledgeports.Passage, error) {
+
+	collection, err := s.getCollection(ctx, request.Collection)
+	if err != nil {
+		return nil, err
+	}
+	query := strings.TrimSpace(request.Query)
+	if query == "" {
+		return nil, fmt.Errorf("search: query is required")
+	}
+	rerankProvider := strings.TrimSpace(request.RerankProvider)
+	if rerankProvider != "" && s.reranker == nil {
+		return nil, fmt.Errorf("search: reranking not configured")
+	}
+	if collection.Chunks == 0 {
+		return []knowledgeports.Passage{}, nil
+	}
+
+	embedded, err := s.embeddings.Embed(ctx, embeddingports.EmbedRequest{
+		ProviderName: collection.ProviderName,
+		ModelName:    collection.ModelName,
+		Input:        []string{query},
+		Dimensions:   collection.Dimensions,
+		InputType:    embeddingports.EmbeddingInputQuery,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+	if len(embedded.Embeddings) != 1 {
+		return nil, fmt.Errorf("search: expected 1 query embedding, got %d", len(embedded.Embeddings))
+	}
+
+	if dimensions := collection.VectorDimensions; dimensions > 0 {
+		if err := knowledgedomain.CheckDimensions(embedded.Embeddings[0], dimensions); err != nil {
+			return nil, fmt.Errorf("search: collection %s: %w; re-ingest it after changing the embedding model", collection.Name, err)
+		}
+	}
+
+	cached, err := s.loadIndex(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	topK := knowledgedomain.ClampTopK(request.TopK)
+	candidates := topK
+	if rerankProvider != "" {
+		candidates = min(topK*rerankCandidateFactor, knowledgedomain.MaxTopK)
+	}
+	hits := cached.index.Search(embedded.Embeddings[0], candidates)
+	passages := make([]knowledgeports.Passage, 0, len(hits))
+	for _, hit := range hits {
+		chunk := cached.chunks[hit.ID]
+		passages = append(passages, knowledgeports.Passage{
+			Collection: collection.Name,
+			SourcePath: chunk.DocumentPath,
+			StartLine:  chunk.StartLine,
+			EndLine:    chunk.EndLine,
+			Content:    chunk.Content,
+			Score:      hit.Score,
+		})
+	}
+	if rerankProvider == "" || len(passages) == 0 {
+		return passages, nil
+	}
+	return s.rerankPassages(ctx, rerankProvider, strings.TrimSpace(request.RerankModel), query, passages, topK)
+}
+
+// rerankPassages reorders passages by rerank score and keeps the topK best.
+func (s *Service) rerankPassages(ctx context.Context, providerName, model, query string, passages []knowledgeports.Passage, topK int) ([]knowledgeports.Passage, error) {
+
+	documents := make([]string, len(passages))
+	for index, passage := range passages {
+		documents[index] = passage.Content
+	}
+	ranked, err := s.reranker.Rerank(ctx, rerankports.RerankRequest{
+		ProviderName: providerName,
+		ModelName:    model,
+		Query:        query,
+		Documents:    documents,
+		TopN:         topK,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search: rerank: %w", err)
+	}
+
+	reranked := make([]knowledgeports.Passage, 0, min(len(ranked.Results), topK))
+	for _, result := range ranked.Results {
+		if len(reranked) == topK {
+			break
+		}
+		passage := passages[result.Index]
+		passage.Score = result.Score
+		reranked = append(reranked, passage)
+	}
+	return reranked, nil
+}
+
+// embedChunks embeds chunk texts and records the collection's model and vector size on first use.
+// Vectors whose size differs from the collection's are rejected, since they cannot be compared.
+func (s *Service) embedChunks(ctx context.Context, collection *knowledgedomain.Collection, path string, textChunks []knowledgedomain.TextChunk) ([]knowledgedomain.Chunk, error) {
+
+	input := make([]string, len(textChunks))
+	for index, textChunk := range textChunks {
+		input[index] = textChunk.Content
+	}
+	embedded, err := s.embeddings.Embed(ctx, embeddingports.EmbedRequest{
+		ProviderName: collection.ProviderName,
+		ModelName:    collection.ModelName,
+		Input:        input,
+		Dimensions:   collection.Dimensions,
+		InputType:    embeddingports.EmbeddingInputDocument,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(embedded.Embeddings) != len(textChunks) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(textChunks), len(embedded.Embeddings))
+	}
+
+	if collection.Dimensions > 0 && embedded.Dimensions != collection.Dimensions {
+		return nil, fmt.Errorf("embedding has %d dimensions, collection %s uses %d", embedded.Dimensions, collection.Name, collection.Dimensions)
+	}
+	dimensions := collection.VectorDimensions
+	if dimensions == 0 && len(embedded.Embeddings) > 0 {
+		dimensions = len(embedded.Embeddings[0])
+	}
+	for _, vector := range embedded.Embeddings {
+		if err := knowledgedomain.CheckDimensions(vector, dimensions); err != nil {
+			return nil, fmt.Errorf("collection %s: %w", collection.Name, err)
+		}
+	}
+
+	pin := collection.VectorDimensions != dimensions
+	collection.VectorDimensions = dimensions
+	if collection.ModelName == "" && embedded.Model != "" {
+		// Pin the provider's default model so later queries embed into the same space.
+		collection.ModelName = embedded.Model
+		pin = true
+	}
+	if pin {
+		if err := s.repo.SaveCollection(ctx, *collection); err != nil {
+			return nil, err
+		}
+	}
+
+	chunks := make([]knowledgedomain.Chunk, len(textChunks))
+	for index, textChunk := range textChunks {
+		chunks[index] = knowledgedomain.Chunk{
+			DocumentPath: path,
+			Index:        index,
+			StartLine:    textChunk.StartLine,
+			EndLine:      textChunk.EndLine,
+			Content:      textChunk.Content,
+			Vector:       embedded.Embeddings[index],
+		}
+	}
+	return chunks, nil
+}
+
+// loadIndex returns a cached vector index for a collection, rebuilding it when the collection changed.
+func (s *Service) loadIndex(ctx context.Context, collection *knowledgedomain.Collection) (*cachedIndex, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cached, ok := s.indexes[collection.Name]; ok && cached.updatedAt == collection.UpdatedAt && len(cached.chunks) == collection.Chunks {
+		return cached, nil
+	}
+
+	chunks, err := s.repo.LoadChunks(ctx, collection.Name)
+	if err != nil {
+		return nil, err
+	}
+	index := knowledgedomain.NewVectorIndex(collection.Index)
+	for id, chunk := range chunks {
+		index.Add(id, chunk.Vector)
+	}
+	cached := &cachedIndex{updatedAt: collection.UpdatedAt, chunks: chunks, index: index}
+	s.indexes[collection.Name] = cached
+	return cached, nil
+}
+
+// invalidate drops a collection's cached index.
+func (s *Service) invalidate(name string) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.indexes, name)
+}
+
+// getCollection loads a collection by name or reports that it does not exist.
+func (s *Service) getCollection(ctx context.Context, name string) (*knowledgedomain.Collection, error) {
+
+	if err := s.ensureConfigured(); err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("knowledge: collection name required")
+	}
+	collection, err := s.repo.GetCollection(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if collection == nil {
+		return nil, fmt.Errorf("knowledge: collection %s not found", name)
+	}
+	return collection, nil
+}
+
+// ensureConfigured verifies the service dependencies are present.
+func (s *Service) ensureConfigured() error {
+
+	if s.repo == nil || s.reader == nil || s.embeddings == nil {
+		return fmt.Errorf("backend service: knowledge base not configured")
+	}
+	return nil
+}
+
+// contentHash fingerprints document text for change detection.
+func contentHash(text string) string {
+
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// toPortCollection maps a domain collection to its transport shape.
+func toPortCollection(collection knowledgedomain.Collection) knowledgeports.Collection {
+
+	return knowledgeports.Collection{
+		Name:         collection.Name,
+		ProviderName: collection.ProviderName,
+		ModelName:    collection.ModelName,
+		Dimensions:   collection.Dimensions,
+		Index:        string(collection.Index),
+		ChunkSize:    collection.ChunkSize,
+		ChunkOverlap: collection.ChunkOverlap,
+		Documents:    collection.Documents,
+		Chunks:       collection.Chunks,
+		CreatedAt:    collection.CreatedAt,
+		UpdatedAt:    collection.UpdatedAt,
+	}
+}
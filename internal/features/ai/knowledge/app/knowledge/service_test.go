@@ -0,0 +1,348 @@
+// service_test.go verifies knowledge-base ingestion and search orchestration.
+// internal/features/ai/knowledge/app/knowledge/service_test.go
+package knowledge
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	embeddingports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/embedding/ports"
+	knowledgedomain "github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/domain"
+	knowledgeports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/ports"
+	rerankports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/rerank/ports"
+)
+
+// TestServiceIngestSkipsUnchangedAndSearchesByTopic validates hash skipping, model pinning, and ranked citations.
+func TestServiceIngestSkipsUnchangedAndSearchesByTopic(t *testing.T) {
+
+	ctx := context.Background()
+	repo := newFakeRepository()
+	reader := &fakeReader{files: map[string]string{
+		"/docs/cats.md":  "Cats purr and nap in the sun.",
+		"/docs/rust.txt": "Rust has a borrow checker.",
+		"/docs/empty.md": "   ",
+	}, skipped: []string{"/docs/logo.png"}}
+	embedder := &fakeEmbedder{}
+	service := NewService(repo, reader, embedder)
+
+	if _, err := service.CreateCollection(ctx, knowledgeports.CreateCollectionRequest{Name: "docs", ProviderName: "openai", Index: "HNSW"}); err != nil {
+		t.Fatalf("create collection: %v", err)
+	}
+	if _, err := service.CreateCollection(ctx, knowledgeports.CreateCollectionRequest{Name: "docs", ProviderName: "openai"}); err == nil {
+		t.Fatalf("expected duplicate collection error")
+	}
+
+	result, err := service.Ingest(ctx, knowledgeports.IngestRequest{Collection: "docs", Paths: []string{"/docs"}})
+	if err != nil {
+		t.Fatalf("ingest: %v", err)
+	}
+	if result.Documents != 2 || result.Chunks != 2 || result.Unchanged != 0 || len(result.Skipped) != 2 {
+		t.Fatalf("unexpected first ingest %#v", result)
+	}
+	if embedder.requests[0].InputType != embeddingports.EmbeddingInputDocument {
+		t.Fatalf("expected document input type, got %q", embedder.requests[0].InputType)
+	}
+	if repo.collections["docs"].ModelName != "fake-embed" {
+		t.Fatalf("expected default model to be pinned, got %q", repo.collections["docs"].ModelName)
+	}
+
+	result, err = service.Ingest(ctx, knowledgeports.IngestRequest{Collection: "docs", Paths: []string{"/docs"}})
+	if err != nil {
+		t.Fatalf("re-ingest: %v", err)
+	}
+	if result.Documents != 0 || result.Unchanged != 2 {
+		t.Fatalf("expected unchanged documents to be skipped, got %#v", result)
+	}
+
+	passages, err := service.Search(ctx, knowledgeports.SearchRequest{Collection: "docs", Query: "why do cats purr", TopK: 1})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(passages) != 1 || passages[0].SourcePath != "/docs/cats.md" || passages[0].StartLine != 1 || passages[0].Collection != "docs" {
+		t.Fatalf("unexpected passages %#v", passages)
+	}
+	last := embedder.requests[len(embedder.requests)-1]
+	if last.InputType != embeddingports.EmbeddingInputQuery || last.ModelName != "fake-embed" {
+		t.Fatalf("unexpected query request %#v", last)
+	}
+
+	reader.files["/docs/rust.txt"] = "Cats also like boxes."
+	if _, err := service.Ingest(ctx, knowledgeports.IngestRequest{Collection: "docs", Paths: []string{"/docs"}}); err != nil {
+		t.Fatalf("update ingest: %v", err)
+	}
+	passages, err = service.Search(ctx, knowledgeports.SearchRequest{Collection: "docs", Query: "cats", TopK: 5})
+	if err != nil {
+		t.Fatalf("search after update: %v", err)
+	}
+	if len(passages) != 2 || passages[1].Content == "Rust has a borrow checker." {
+		t.Fatalf("expected refreshed index, got %#v", passages)
+	}
+}
+
+// TestServiceSearchReranksWiderCandidateSet validates candidate widening, rerank ordering, and rerank scores.
+func TestServiceSearchReranksWiderCandidateSet(t *testing.T) {
+
+	ctx := context.Background()
+	reader := &fakeReader{files: map[string]string{
+		"/docs/cats.md":   "Cats purr and nap in the sun.",
+		"/docs/kitten.md": "A cat sleeps most of the day.",
+		"/docs/rust.txt":  "Rust has a borrow checker.",
+	}}
+	service := NewService(newFakeRepository(), reader, &fakeEmbedder{})
+	reranker := &fakeReranker{preferred: "Rust"}
+	service.SetReranker(reranker)
+
+	if _, err := service.CreateCollection(ctx, knowledgeports.CreateCollectionRequest{Name: "docs", ProviderName: "openai"}); err != nil {
+		t.Fatalf("create collection: %v", err)
+	}
+	if _, err := service.Ingest(ctx, knowledgeports.IngestRequest{Collection: "docs", Paths: []string{"/docs"}}); err != nil {
+		t.Fatalf("ingest: %v", err)
+	}
+
+	passages, err := service.Search(ctx, knowledgeports.SearchRequest{Collection: "docs", Query: "cats", TopK: 1, RerankProvider: " cohere ", RerankModel: "rerank-v3.5"})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(reranker.request.Documents) != 3 || reranker.request.ProviderName != "cohere" || reranker.request.ModelName != "rerank-v3.5" || reranker.request.TopN != 1 {
+		t.Fatalf("unexpected rerank request %#v", reranker.request)
+	}
+	if len(passages) != 1 || passages[0].SourcePath != "/docs/rust.txt" || passages[0].Score != 1 {
+		t.Fatalf("expected reranked passage, got %#v", passages)
+	}
+}
+
+// TestServiceValidatesRequests validates missing collections, empty queries, and dimension mismatches.
+func TestServiceValidatesRequests(t *testing.T) {
+
+	ctx := context.Background()
+	repo := newFakeRepository()
+	reader := &fakeReader{files: map[string]string{"/a.md": "alpha"}}
+	service := NewService(repo, reader, &fakeEmbedder{})
+	if _, err := service.CreateCollection(ctx, knowledgeports.CreateCollectionRequest{Name: "fixed", ProviderName: "openai", Dimensions: 8}); err != nil {
+		t.Fatalf("create collection: %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		run     func() error
+		wantErr string
+	}{
+		{name: "bad name", run: func() error {
+			_, err := service.CreateCollection(ctx, knowledgeports.CreateCollectionRequest{Name: "../x", ProviderName: "openai"})
+			return err
+		}, wantErr: "invalid collection name"},
+		{name: "missing collection", run: func() error {
+			_, err := service.Search(ctx, knowledgeports.SearchRequest{Collection: "nope", Query: "q"})
+			return err
+		}, wantErr: "not found"},
+		{name: "empty query", run: func() error {
+			_, err := service.Search(ctx, knowledgeports.SearchRequest{Collection: "fixed", Query: " "})
+			return err
+		}, wantErr: "query is required"},
+		{name: "rerank without reranker", run: func() error {
+			_, err := service.Search(ctx, knowledgeports.SearchRequest{Collection: "fixed", Query: "q", RerankProvider: "cohere"})
+			return err
+		}, wantErr: "reranking not configured"},
+		{name: "dimension mismatch", run: func() error {
+			_, err := service.Ingest(ctx, knowledgeports.IngestRequest{Collection: "fixed", Paths: []string{"/a.md"}})
+			return err
+		}, wantErr: "dimensions"},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.run()
+		if err == nil || !strings.Contains(err.Error(), testCase.wantErr) {
+			t.Fatalf("%s: expected error containing %q, got %v", testCase.name, testCase.wantErr, err)
+		}
+	}
+}
+
+// TestServiceRejectsMismatchedVectorSizes validates that the first ingest pins the vector size for later chunks and queries.
+func TestServiceRejectsMismatchedVectorSizes(t *testing.T) {
+
+	ctx := context.Background()
+	repo := newFakeRepository()
+	reader := &fakeReader{files: map[string]string{"/cats.md": "Cats purr."}}
+	embedder := &fakeEmbedder{}
+	service := NewService(repo, reader, embedder)
+	if _, err := service.CreateCollection(ctx, knowledgeports.CreateCollectionRequest{Name: "docs", ProviderName: "openai"}); err != nil {
+		t.Fatalf("create collection: %v", err)
+	}
+	if _, err := service.Ingest(ctx, knowledgeports.IngestRequest{Collection: "docs", Paths: []string{"/cats.md"}}); err != nil {
+		t.Fatalf("ingest: %v", err)
+	}
+	if repo.collections["docs"].VectorDimensions != 2 {
+		t.Fatalf("expected vector size to be pinned, got %d", repo.collections["docs"].VectorDimensions)
+	}
+
+	embedder.extra = 1
+	reader.files["/rust.md"] = "Rust has a borrow checker."
+	if _, err := service.Ingest(ctx, knowledgeports.IngestRequest{Collection: "docs", Paths: []string{"/rust.md"}}); err == nil || !strings.Contains(err.Error(), "3 dimensions, expected 2") {
+		t.Fatalf("expected chunk size mismatch, got %v", err)
+	}
+	if _, err := service.Search(ctx, knowledgeports.SearchRequest{Collection: "docs", Query: "cats"}); err == nil || !strings.Contains(err.Error(), "3 dimensions, expected 2") {
+		t.Fatalf("expected query size mismatch, got %v", err)
+	}
+}
+
+// fakeEmbedder maps texts onto two topic axes: cats and everything else.
+// extra pads every vector with that many zeros to simulate a model of a different size.
+type fakeEmbedder struct {
+	requests []embeddingports.EmbedRequest
+	extra    int
+}
+
+// Embed returns one deterministic vector per input.
+func (f *fakeEmbedder) Embed(_ context.Context, request embeddingports.EmbedRequest) (embeddingports.EmbedResult, error) {
+
+	f.requests = append(f.requests, request)
+	embeddings := make([][]float32, len(request.Input))
+	for index, text := range request.Input {
+		if strings.Contains(strings.ToLower(text), "cat") {
+			embeddings[index] = []float32{1, 0.1}
+		} else {
+			embeddings[index] = []float32{0.1, 1}
+		}
+		embeddings[index] = append(embeddings[index], make([]float32, f.extra)...)
+	}
+	return embeddingports.EmbedResult{Model: "fake-embed", Dimensions: 2 + f.extra, Embeddings: embeddings}, nil
+}
+
+// fakeReranker scores documents containing preferred highest and records the last request.
+type fakeReranker struct {
+	preferred string
+	request   rerankports.RerankRequest
+}
+
+// Rerank ranks the preferred document first and the rest in their original order.
+func (f *fakeReranker) Rerank(_ context.Context, request rerankports.RerankRequest) (rerankports.RerankResult, error) {
+
+	f.request = request
+	result := rerankports.RerankResult{}
+	for index, document := range request.Documents {
+		ranked := rerankports.RankedDocument{Index: index, Score: 0.1, Document: document}
+		if strings.Contains(document, f.preferred) {
+			ranked.Score = 1
+			result.Results = append([]rerankports.RankedDocument{ranked}, result.Results...)
+			continue
+		}
+		result.Results = append(result.Results, ranked)
+	}
+	return result, nil
+}
+
+// fakeReader serves in-memory documents.
+type fakeReader struct {
+	files   map[string]string
+	skipped []string
+}
+
+// ListFiles returns every in-memory document.
+func (f *fakeReader) ListFiles(_ []string) ([]string, []string, error) {
+
+	files := make([]string, 0, len(f.files))
+	for path := range f.files {
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	return files, append([]string(nil), f.skipped...), nil
+}
+
+// ReadText returns an in-memory document.
+func (f *fakeReader) ReadText(path string) (string, error) {
+
+	text, ok := f.files[path]
+	if !ok {
+		return "", errors.New("missing")
+	}
+	return text, nil
+}
+
+// fakeRepository keeps collections and chunks in memory.
+type fakeRepository struct {
+	collections map[string]knowledgedomain.Collection
+	hashes      map[string]string
+	chunks      map[string][]knowledgedomain.Chunk
+	tick        int64
+}
+
+// newFakeRepository creates an empty in-memory repository.
+func newFakeRepository() *fakeRepository {
+
+	return &fakeRepository{
+		collections: make(map[string]knowledgedomain.Collection),
+		hashes:      make(map[string]string),
+		chunks:      make(map[string][]knowledgedomain.Chunk),
+	}
+}
+
+// SaveCollection stores a collection.
+func (f *fakeRepository) SaveCollection(_ context.Context, collection knowledgedomain.Collection) error {
+
+	f.collections[collection.Name] = collection
+	return nil
+}
+
+// GetCollection returns a collection with computed counts.
+func (f *fakeRepository) GetCollection(_ context.Context, name string) (*knowledgedomain.Collection, error) {
+
+	collection, ok := f.collections[name]
+	if !ok {
+		return nil, nil
+	}
+	documents := make(map[string]bool)
+	collection.Chunks = 0
+	for _, chunk := range f.chunks[name] {
+		documents[chunk.DocumentPath] = true
+		collection.Chunks++
+	}
+	collection.Documents = len(documents)
+	return &collection, nil
+}
+
+// ListCollections is unused by these tests.
+func (f *fakeRepository) ListCollections(_ context.Context) ([]knowledgedomain.Collection, error) {
+
+	return nil, nil
+}
+
+// DeleteCollection drops a collection and its chunks.
+func (f *fakeRepository) DeleteCollection(_ context.Context, name string) error {
+
+	delete(f.collections, name)
+	delete(f.chunks, name)
+	return nil
+}
+
+// DocumentHash returns a stored document hash.
+func (f *fakeRepository) DocumentHash(_ context.Context, collection, path string) (string, error) {
+
+	return f.hashes[collection+"|"+path], nil
+}
+
+// ReplaceDocument swaps a document's chunks and advances the collection timestamp.
+func (f *fakeRepository) ReplaceDocument(_ context.Context, collection string, document knowledgedomain.Document, chunks []knowledgedomain.Chunk) error {
+
+	f.hashes[collection+"|"+document.Path] = document.ContentHash
+	kept := make([]knowledgedomain.Chunk, 0)
+	for _, chunk := range f.chunks[collection] {
+		if chunk.DocumentPath != document.Path {
+			kept = append(kept, chunk)
+		}
+	}
+	f.chunks[collection] = append(kept, chunks...)
+	f.tick++
+	stored := f.collections[collection]
+	stored.UpdatedAt = f.tick
+	f.collections[collection] = stored
+	return nil
+}
+
+// LoadChunks returns a copy of a collection's chunks.
+func (f *fakeRepository) LoadChunks(_ context.Context, collection string) ([]knowledgedomain.Chunk, error) {
+
+	return append([]knowledgedomain.Chunk(nil), f.chunks[collection]...), nil
+}
@@ -0,0 +1,130 @@
+// chunker.go splits document text into overlapping, line-addressed passages.
+// internal/features/ai/knowledge/domain/chunker.go
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TextChunk is a passage of a document with the 1-based line range it covers.
+type TextChunk struct {
+	StartLine int
+	EndLine   int
+	Content   string
+}
+
+// sourceLine is one line of the source with its 1-based number.
+type sourceLine struct {
+	number int
+	text   string
+}
+
+// ChunkText splits text into passages of at most size characters, breaking on line
+// boundaries and preferring blank lines. Consecutive passages share up to overlap
+// characters of trailing lines so context is not lost at a boundary. Lines longer
+// than size are split on their own.
+func ChunkText(text string, size, overlap int) []TextChunk {
+
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+
+	lines := splitLines(text, size)
+	chunks := make([]TextChunk, 0)
+	start := 0
+	for start < len(lines) {
+		end, length := start, 0
+		lastBlank := -1
+		for end < len(lines) {
+			added := utf8.RuneCountInString(lines[end].text) + 1
+			if length > 0 && length+added > size {
+				break
+			}
+			length += added
+			if strings.TrimSpace(lines[end].text) == "" && end > start {
+				lastBlank = end
+			}
+			end++
+		}
+		// Prefer ending on a paragraph break when the window is at least half full.
+		if end < len(lines) && lastBlank > start {
+			blankLength := 0
+			for _, line := range lines[start:lastBlank] {
+				blankLength += utf8.RuneCountInString(line.text) + 1
+			}
+			if blankLength >= size/2 {
+				end = lastBlank
+			}
+		}
+
+		if chunk, ok := buildChunk(lines[start:end]); ok {
+			chunks = append(chunks, chunk)
+		}
+		if end >= len(lines) {
+			break
+		}
+
+		next := end
+		carried := 0
+		for next > start+1 {
+			added := utf8.RuneCountInString(lines[next-1].text) + 1
+			if carried+added > overlap {
+				break
+			}
+			carried += added
+			next--
+		}
+		start = next
+	}
+	return chunks
+}
+
+// splitLines numbers the source lines and breaks any line longer than size into pieces.
+func splitLines(text string, size int) []sourceLine {
+
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	raw := strings.Split(text, "\n")
+	lines := make([]sourceLine, 0, len(raw))
+	for index, line := range raw {
+		runes := []rune(line)
+		if len(runes) <= size {
+			lines = append(lines, sourceLine{number: index + 1, text: line})
+			continue
+		}
+		for offset := 0; offset < len(runes); offset += size {
+			lines = append(lines, sourceLine{number: index + 1, text: string(runes[offset:min(offset+size, len(runes))])})
+		}
+	}
+	return lines
+}
+
+// buildChunk joins lines into a chunk, skipping windows that hold only whitespace.
+func buildChunk(lines []sourceLine) (TextChunk, bool) {
+
+	first, last := -1, -1
+	for index, line := range lines {
+		if strings.TrimSpace(line.text) != "" {
+			if first < 0 {
+				first = index
+			}
+			last = index
+		}
+	}
+	if first < 0 {
+		return TextChunk{}, false
+	}
+
+	parts := make([]string, 0, last-first+1)
+	for _, line := range lines[first : last+1] {
+		parts = append(parts, line.text)
+	}
+	return TextChunk{
+		StartLine: lines[first].number,
+		EndLine:   lines[last].number,
+		Content:   strings.Join(parts, "\n"),
+	}, true
+}
@@ -0,0 +1,63 @@
+// chunker_test.go verifies line-addressed chunking with overlap.
+// internal/features/ai/knowledge/domain/chunker_test.go
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestChunkTextTracksLinesAndOverlap validates chunk sizes, line ranges, and shared context.
+func TestChunkTextTracksLinesAndOverlap(t *testing.T) {
+
+	lines := make([]string, 0, 20)
+	for index := 1; index <= 20; index++ {
+		lines = append(lines, "line "+strings.Repeat("x", 5)+string(rune('a'+index-1)))
+	}
+	chunks := ChunkText(strings.Join(lines, "\n"), 60, 24)
+
+	if len(chunks) < 4 {
+		t.Fatalf("expected several chunks, got %d", len(chunks))
+	}
+	if chunks[0].StartLine != 1 || !strings.HasPrefix(chunks[0].Content, "line xxxxxa") {
+		t.Fatalf("unexpected first chunk %#v", chunks[0])
+	}
+	for index, chunk := range chunks {
+		if len([]rune(chunk.Content)) > 60 {
+			t.Fatalf("chunk %d exceeds size: %d", index, len(chunk.Content))
+		}
+		if index > 0 && chunk.StartLine > chunks[index-1].EndLine {
+			t.Fatalf("chunk %d does not overlap previous: %d > %d", index, chunk.StartLine, chunks[index-1].EndLine)
+		}
+	}
+	if chunks[len(chunks)-1].EndLine != 20 {
+		t.Fatalf("expected last chunk to end on line 20, got %d", chunks[len(chunks)-1].EndLine)
+	}
+}
+
+// TestChunkTextEdgeCases validates blank input, paragraph breaks, and oversized lines.
+func TestChunkTextEdgeCases(t *testing.T) {
+
+	testCases := []struct {
+		name      string
+		text      string
+		size      int
+		wantCount int
+		wantFirst TextChunk
+	}{
+		{name: "blank", text: "\n  \n", size: 50, wantCount: 0},
+		{name: "single", text: "\n\nhello\nworld\n", size: 50, wantCount: 1, wantFirst: TextChunk{StartLine: 3, EndLine: 4, Content: "hello\nworld"}},
+		{name: "paragraph break", text: "aaaaaaaaaa\nbbbbbbbbbb\n\ncccccccccc\ndddddddddd", size: 40, wantCount: 2, wantFirst: TextChunk{StartLine: 1, EndLine: 2, Content: "aaaaaaaaaa\nbbbbbbbbbb"}},
+		{name: "long line", text: strings.Repeat("z", 25), size: 10, wantCount: 3, wantFirst: TextChunk{StartLine: 1, EndLine: 1, Content: strings.Repeat("z", 10)}},
+	}
+
+	for _, testCase := range testCases {
+		chunks := ChunkText(testCase.text, testCase.size, 0)
+		if len(chunks) != testCase.wantCount {
+			t.Fatalf("%s: expected %d chunks, got %#v", testCase.name, testCase.wantCount, chunks)
+		}
+		if testCase.wantCount > 0 && chunks[0] != testCase.wantFirst {
+			t.Fatalf("%s: expected first chunk %#v, got %#v", testCase.name, testCase.wantFirst, chunks[0])
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// collection.go defines knowledge-base collections, documents, and embedded chunks.
+// internal/features/ai/knowledge/domain/collection.go
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// IndexKind selects how a collection searches its vectors.
+type IndexKind string
+
+const (
+	// IndexFlat compares the query against every chunk; exact and fine for small collections.
+	IndexFlat IndexKind = "flat"
+	// IndexHNSW searches a hierarchical navigable small-world graph; approximate but sublinear.
+	IndexHNSW IndexKind = "hnsw"
+)
+
+const (
+	DefaultChunkSize    = 1200
+	DefaultChunkOverlap = 200
+	DefaultTopK         = 4
+	MaxTopK             = 50
+)
+
+var collectionNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,63}$`)
+
+// Collection groups documents embedded with one provider model.
+// Dimensions is the output size requested from the provider, if any; VectorDimensions is the length
+// of the stored vectors, recorded on first ingest so vectors from a different model or size are rejected.
+type Collection struct {
+	Name             string
+	ProviderName     string
+	ModelName        string
+	Dimensions       int
+	VectorDimensions int
+	Index            IndexKind
+	ChunkSize        int
+	ChunkOverlap     int
+	Documents        int
+	Chunks           int
+	CreatedAt        int64
+	UpdatedAt        int64
+}
+
+// Document is one ingested source file.
+type Document struct {
+	Path        string
+	ContentHash string
+	IngestedAt  int64
+}
+
+// Chunk is one embedded passage of a document.
+type Chunk struct {
+	ID           int64
+	DocumentPath string
+	Index        int
+	StartLine    int
+	EndLine      int
+	Content      string
+	Vector       []float32
+}
+
+// Normalize fills defaults and validates collection settings.
+func (c *Collection) Normalize() error {
+
+	c.Name = strings.TrimSpace(c.Name)
+	c.ProviderName = strings.TrimSpace(c.ProviderName)
+	c.ModelName = strings.TrimSpace(c.ModelName)
+	if !collectionNamePattern.MatchString(c.Name) {
+		return fmt.Errorf("invalid collection name %q: use letters, digits, '.', '_' or '-'", c.Name)
+	}
+	if c.ProviderName == "" {
+		return fmt.Errorf("collection %s: embedding provider required", c.Name)
+	}
+	if c.Dimensions < 0 {
+		return fmt.Errorf("collection %s: dimensions must be positive", c.Name)
+	}
+	switch c.Index {
+	case "":
+		c.Index = IndexFlat
+	case IndexFlat, IndexHNSW:
+	default:
+		return fmt.Errorf("collection %s: unknown index %q (use flat or hnsw)", c.Name, c.Index)
+	}
+	if c.ChunkOverlap < 0 {
+		return fmt.Errorf("collection %s: chunk overlap must not be negative", c.Name)
+	}
+	if c.ChunkSize <= 0 {
+		c.ChunkSize = DefaultChunkSize
+		if c.ChunkOverlap == 0 {
+			c.ChunkOverlap = DefaultChunkOverlap
+		}
+	}
+	if c.ChunkOverlap >= c.ChunkSize {
+		return fmt.Errorf("collection %s: chunk overlap must be smaller than chunk size", c.Name)
+	}
+	return nil
+}
+
+// ClampTopK bounds a requested passage count to a usable range.
+func ClampTopK(topK int) int {
+
+	if topK <= 0 {
+		return DefaultTopK
+	}
+	return min(topK, MaxTopK)
+}
@@ -0,0 +1,244 @@
+// hnsw.go implements an in-memory hierarchical navigable small-world vector index.
+// internal/features/ai/knowledge/domain/hnsw.go
+package domain
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// HNSWConfig tunes graph connectivity and search breadth.
+type HNSWConfig struct {
+	// M is the number of neighbors kept per node on upper layers; layer 0 keeps 2*M.
+	M int
+	// EfConstruction is the candidate list size used while inserting.
+	EfConstruction int
+	// EfSearch is the minimum candidate list size used while searching.
+	EfSearch int
+	// Seed makes level assignment reproducible.
+	Seed int64
+}
+
+// DefaultHNSWConfig returns settings that give high recall for collections up to a few hundred thousand chunks.
+func DefaultHNSWConfig() HNSWConfig {
+
+	return HNSWConfig{M: 16, EfConstruction: 100, EfSearch: 64, Seed: 1}
+}
+
+// hnswNode is one stored vector and its neighbor lists per layer.
+type hnswNode struct {
+	id        int
+	vector    []float32
+	neighbors [][]int
+}
+
+// HNSWIndex is an approximate nearest-neighbor index over cosine similarity.
+type HNSWIndex struct {
+	config    HNSWConfig
+	levelMult float64
+	random    *rand.Rand
+	nodes     []hnswNode
+	entry     int
+	maxLevel  int
+}
+
+// NewHNSWIndex creates an empty HNSW index.
+func NewHNSWIndex(config HNSWConfig) *HNSWIndex {
+
+	defaults := DefaultHNSWConfig()
+	if config.M < 2 {
+		config.M = defaults.M
+	}
+	if config.EfConstruction <= 0 {
+		config.EfConstruction = defaults.EfConstruction
+	}
+	if config.EfSearch <= 0 {
+		config.EfSearch = defaults.EfSearch
+	}
+	return &HNSWIndex{
+		config:    config,
+		levelMult: 1 / math.Log(float64(config.M)),
+		random:    rand.New(rand.NewSource(config.Seed)),
+		entry:     -1,
+	}
+}
+
+// Len returns the number of stored vectors.
+func (h *HNSWIndex) Len() int {
+
+	return len(h.nodes)
+}
+
+// Add inserts a normalized copy of vector under id.
+func (h *HNSWIndex) Add(id int, vector []float32) {
+
+	level := int(math.Floor(-math.Log(1-h.random.Float64()) * h.levelMult))
+	index := len(h.nodes)
+	h.nodes = append(h.nodes, hnswNode{
+		id:        id,
+		vector:    Normalize(vector),
+		neighbors: make([][]int, level+1),
+	})
+	if h.entry < 0 {
+		h.entry = index
+		h.maxLevel = level
+		return
+	}
+
+	query := h.nodes[index].vector
+	entry := h.entry
+	for layer := h.maxLevel; layer > level; layer-- {
+		entry = h.searchLayer(query, []int{entry}, 1, layer)[0].node
+	}
+
+	entries := []int{entry}
+	for layer := min(level, h.maxLevel); layer >= 0; layer-- {
+		candidates := h.searchLayer(query, entries, h.config.EfConstruction, layer)
+		selected := make([]int, 0, h.config.M)
+		for _, candidate := range candidates[:min(h.config.M, len(candidates))] {
+			selected = append(selected, candidate.node)
+		}
+		h.nodes[index].neighbors[layer] = selected
+		for _, neighbor := range selected {
+			h.connect(neighbor, index, layer)
+		}
+		entries = entries[:0]
+		for _, candidate := range candidates {
+			entries = append(entries, candidate.node)
+		}
+	}
+
+	if level > h.maxLevel {
+		h.entry = index
+		h.maxLevel = level
+	}
+}
+
+// Search returns the k stored vectors closest to the query.
+func (h *HNSWIndex) Search(query []float32, k int) []ScoredID {
+
+	if k <= 0 || h.entry < 0 {
+		return nil
+	}
+	query = Normalize(query)
+	entry := h.entry
+	for layer := h.maxLevel; layer > 0; layer-- {
+		entry = h.searchLayer(query, []int{entry}, 1, layer)[0].node
+	}
+	candidates := h.searchLayer(query, []int{entry}, max(h.config.EfSearch, k), 0)
+
+	hits := make([]ScoredID, 0, min(k, len(candidates)))
+	for _, candidate := range candidates[:min(k, len(candidates))] {
+		hits = append(hits, ScoredID{ID: h.nodes[candidate.node].id, Score: 1 - candidate.distance})
+	}
+	sortHits(hits)
+	return hits
+}
+
+// connect adds a back-link from node to neighbor and prunes the list to the layer's capacity.
+func (h *HNSWIndex) connect(node, neighbor, layer int) {
+
+	links := append(h.nodes[node].neighbors[layer], neighbor)
+	capacity := h.config.M
+	if layer == 0 {
+		capacity = 2 * h.config.M
+	}
+	if len(links) > capacity {
+		origin := h.nodes[node].vector
+		sort.Slice(links, func(i, j int) bool {
+			return h.distance(origin, links[i]) < h.distance(origin, links[j])
+		})
+		links = links[:capacity]
+	}
+	h.nodes[node].neighbors[layer] = links
+}
+
+// distance returns the cosine distance between a normalized query and a stored node.
+func (h *HNSWIndex) distance(query []float32, node int) float64 {
+
+	return 1 - dot(query, h.nodes[node].vector)
+}
+
+// searchLayer runs a best-first search on one layer and returns up to ef nodes ordered nearest first.
+func (h *HNSWIndex) searchLayer(query []float32, entries []int, ef, layer int) []hnswCandidate {
+
+	visited := make(map[int]struct{}, ef*4)
+	frontier := &candidateHeap{nearestFirst: true}
+	results := &candidateHeap{}
+	for _, entry := range entries {
+		if _, seen := visited[entry]; seen {
+			continue
+		}
+		visited[entry] = struct{}{}
+		candidate := hnswCandidate{node: entry, distance: h.distance(query, entry)}
+		heap.Push(frontier, candidate)
+		heap.Push(results, candidate)
+		if results.Len() > ef {
+			heap.Pop(results)
+		}
+	}
+
+	for frontier.Len() > 0 {
+		current := heap.Pop(frontier).(hnswCandidate)
+		if results.Len() >= ef && current.distance > results.items[0].distance {
+			break
+		}
+		if layer >= len(h.nodes[current.node].neighbors) {
+			continue
+		}
+		for _, neighbor := range h.nodes[current.node].neighbors[layer] {
+			if _, seen := visited[neighbor]; seen {
+				continue
+			}
+			visited[neighbor] = struct{}{}
+			distance := h.distance(query, neighbor)
+			if results.Len() < ef || distance < results.items[0].distance {
+				heap.Push(frontier, hnswCandidate{node: neighbor, distance: distance})
+				heap.Push(results, hnswCandidate{node: neighbor, distance: distance})
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	ordered := make([]hnswCandidate, len(results.items))
+	copy(ordered, results.items)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].distance < ordered[j].distance
+	})
+	return ordered
+}
+
+// hnswCandidate is a node and its distance to the current query.
+type hnswCandidate struct {
+	node     int
+	distance float64
+}
+
+// candidateHeap is a heap of candidates; nearestFirst selects a min-heap, otherwise a max-heap.
+type candidateHeap struct {
+	items        []hnswCandidate
+	nearestFirst bool
+}
+
+func (c *candidateHeap) Len() int { return len(c.items) }
+
+func (c *candidateHeap) Less(i, j int) bool {
+	if c.nearestFirst {
+		return c.items[i].distance < c.items[j].distance
+	}
+	return c.items[i].distance > c.items[j].distance
+}
+
+func (c *candidateHeap) Swap(i, j int) { c.items[i], c.items[j] = c.items[j], c.items[i] }
+
+func (c *candidateHeap) Push(value any) { c.items = append(c.items, value.(hnswCandidate)) }
+
+func (c *candidateHeap) Pop() any {
+	last := c.items[len(c.items)-1]
+	c.items = c.items[:len(c.items)-1]
+	return last
+}
@@ -0,0 +1,127 @@
+// vector.go provides cosine similarity and exact vector search.
+// internal/features/ai/knowledge/domain/vector.go
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ScoredID is a search hit with its cosine similarity to the query.
+type ScoredID struct {
+	ID    int
+	Score float64
+}
+
+// VectorIndex finds the stored vectors most similar to a query.
+type VectorIndex interface {
+	Add(id int, vector []float32)
+	Search(query []float32, k int) []ScoredID
+	Len() int
+}
+
+// Normalize returns a unit-length copy of vector; zero vectors are returned unchanged.
+func Normalize(vector []float32) []float32 {
+
+	var sum float64
+	for _, value := range vector {
+		sum += float64(value) * float64(value)
+	}
+	normalized := make([]float32, len(vector))
+	if sum == 0 {
+		copy(normalized, vector)
+		return normalized
+	}
+	norm := math.Sqrt(sum)
+	for index, value := range vector {
+		normalized[index] = float32(float64(value) / norm)
+	}
+	return normalized
+}
+
+// dot returns the dot product over the shorter of two vectors; callers compare vectors of the
+// same length, which the knowledge service checks before adding or searching.
+func dot(a, b []float32) float64 {
+
+	var sum float64
+	for index := 0; index < len(a) && index < len(b); index++ {
+		sum += float64(a[index]) * float64(b[index])
+	}
+	return sum
+}
+
+// CheckDimensions reports an error when vector does not have the expected length.
+func CheckDimensions(vector []float32, expected int) error {
+
+	if len(vector) != expected {
+		return fmt.Errorf("embedding has %d dimensions, expected %d", len(vector), expected)
+	}
+	return nil
+}
+
+// CosineSimilarity returns the cosine of the angle between two vectors.
+func CosineSimilarity(a, b []float32) float64 {
+
+	return dot(Normalize(a), Normalize(b))
+}
+
+// NewVectorIndex creates an empty index of the given kind.
+func NewVectorIndex(kind IndexKind) VectorIndex {
+
+	if kind == IndexHNSW {
+		return NewHNSWIndex(DefaultHNSWConfig())
+	}
+	return NewFlatIndex()
+}
+
+// FlatIndex is an exact brute-force index.
+type FlatIndex struct {
+	ids     []int
+	vectors [][]float32
+}
+
+// NewFlatIndex creates an empty brute-force index.
+func NewFlatIndex() *FlatIndex {
+
+	return &FlatIndex{}
+}
+
+// Add stores a normalized copy of vector under id.
+func (f *FlatIndex) Add(id int, vector []float32) {
+
+	f.ids = append(f.ids, id)
+	f.vectors = append(f.vectors, Normalize(vector))
+}
+
+// Search scores every stored vector and returns the k best.
+func (f *FlatIndex) Search(query []float32, k int) []ScoredID {
+
+	if k <= 0 || len(f.vectors) == 0 {
+		return nil
+	}
+	query = Normalize(query)
+	hits := make([]ScoredID, len(f.vectors))
+	for index, vector := range f.vectors {
+		hits[index] = ScoredID{ID: f.ids[index], Score: dot(query, vector)}
+	}
+	sortHits(hits)
+	return hits[:min(k, len(hits))]
+}
+
+// Len returns the number of stored vectors.
+func (f *FlatIndex) Len() int {
+
+	return len(f.vectors)
+}
+
+// sortHits orders hits by descending score, breaking ties by ID for stable output.
+func sortHits(hits []ScoredID) {
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+}
@@ -0,0 +1,79 @@
+// vector_test.go verifies exact and HNSW vector search.
+// internal/features/ai/knowledge/domain/vector_test.go
+package domain
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestFlatIndexRanksByCosine validates exact ranking and k truncation.
+func TestFlatIndexRanksByCosine(t *testing.T) {
+
+	index := NewFlatIndex()
+	index.Add(1, []float32{1, 0})
+	index.Add(2, []float32{0.7, 0.7})
+	index.Add(3, []float32{0, 1})
+	index.Add(4, []float32{-1, 0})
+
+	hits := index.Search([]float32{2, 0.1}, 2)
+	if len(hits) != 2 || hits[0].ID != 1 || hits[1].ID != 2 {
+		t.Fatalf("unexpected hits %#v", hits)
+	}
+	if hits[0].Score < 0.99 || hits[0].Score > 1.0001 {
+		t.Fatalf("expected near-unit similarity, got %f", hits[0].Score)
+	}
+	if got := index.Search([]float32{1, 0}, 0); got != nil {
+		t.Fatalf("expected no hits for k=0, got %#v", got)
+	}
+}
+
+// TestHNSWIndexRecallMatchesFlat validates approximate search finds most exact neighbors.
+func TestHNSWIndexRecallMatchesFlat(t *testing.T) {
+
+	random := rand.New(rand.NewSource(7))
+	randomVector := func() []float32 {
+		vector := make([]float32, 32)
+		for index := range vector {
+			vector[index] = float32(random.NormFloat64())
+		}
+		return vector
+	}
+
+	flat := NewFlatIndex()
+	hnsw := NewHNSWIndex(DefaultHNSWConfig())
+	for id := 0; id < 2000; id++ {
+		vector := randomVector()
+		flat.Add(id, vector)
+		hnsw.Add(id, vector)
+	}
+	if hnsw.Len() != 2000 {
+		t.Fatalf("expected 2000 nodes, got %d", hnsw.Len())
+	}
+
+	const k = 10
+	found, total := 0, 0
+	for query := 0; query < 50; query++ {
+		vector := randomVector()
+		exact := make(map[int]bool, k)
+		for _, hit := range flat.Search(vector, k) {
+			exact[hit.ID] = true
+		}
+		approximate := hnsw.Search(vector, k)
+		if len(approximate) != k {
+			t.Fatalf("expected %d hits, got %d", k, len(approximate))
+		}
+		for index, hit := range approximate {
+			if index > 0 && hit.Score > approximate[index-1].Score {
+				t.Fatalf("hits not ordered by score: %#v", approximate)
+			}
+			if exact[hit.ID] {
+				found++
+			}
+		}
+		total += k
+	}
+	if recall := float64(found) / float64(total); recall < 0.9 {
+		t.Fatalf("expected recall >= 0.9, got %.2f", recall)
+	}
+}
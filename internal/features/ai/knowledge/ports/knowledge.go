@@ -0,0 +1,75 @@
+// knowledge.go defines knowledge-base transport contracts.
+// internal/features/ai/knowledge/ports/knowledge.go
+package ports
+
+import "context"
+
+// KnowledgeInterface defines knowledge-base capabilities shared across transports.
+type KnowledgeInterface interface {
+	CreateCollection(ctx context.Context, request CreateCollectionRequest) (Collection, error)
+	ListCollections(ctx context.Context) ([]Collection, error)
+	DeleteCollection(ctx context.Context, name string) error
+	Ingest(ctx context.Context, request IngestRequest) (IngestResult, error)
+	Search(ctx context.Context, request SearchRequest) ([]Passage, error)
+}
+
+// CreateCollectionRequest contains inputs for a new collection.
+// Index is "flat" (exact, default) or "hnsw" (approximate, for large collections).
+type CreateCollectionRequest struct {
+	Name         string `json:"name"`
+	ProviderName string `json:"providerName"`
+	ModelName    string `json:"modelName,omitempty"`
+	Dimensions   int    `json:"dimensions,omitempty"`
+	Index        string `json:"index,omitempty"`
+	ChunkSize    int    `json:"chunkSize,omitempty"`
+	ChunkOverlap int    `json:"chunkOverlap,omitempty"`
+}
+
+// Collection describes a knowledge-base collection and its contents.
+type Collection struct {
+	Name         string `json:"name"`
+	ProviderName string `json:"providerName"`
+	ModelName    string `json:"modelName,omitempty"`
+	Dimensions   int    `json:"dimensions,omitempty"`
+	Index        string `json:"index"`
+	ChunkSize    int    `json:"chunkSize"`
+	ChunkOverlap int    `json:"chunkOverlap"`
+	Documents    int    `json:"documents"`
+	Chunks       int    `json:"chunks"`
+	CreatedAt    int64  `json:"createdAt"`
+	UpdatedAt    int64  `json:"updatedAt"`
+}
+
+// IngestRequest lists files or directories to add to a collection.
+type IngestRequest struct {
+	Collection string   `json:"collection"`
+	Paths      []string `json:"paths"`
+}
+
+// IngestResult summarizes an ingest run.
+type IngestResult struct {
+	Documents int      `json:"documents"`
+	Chunks    int      `json:"chunks"`
+	Unchanged int      `json:"unchanged"`
+	Skipped   []string `json:"skipped,omitempty"`
+}
+
+// SearchRequest contains a query against one collection.
+// When RerankProvider is set, a wider candidate set is reordered by that provider before TopK is applied.
+type SearchRequest struct {
+	Collection     string `json:"collection"`
+	Query          string `json:"query"`
+	TopK           int    `json:"topK,omitempty"`
+	RerankProvider string `json:"rerankProvider,omitempty"`
+	RerankModel    string `json:"rerankModel,omitempty"`
+}
+
+// Passage is a retrieved chunk with its source location and similarity score, or rerank score when reranked.
+type Passage struct {
+	Collection string  `json:"collection"`
+	SourcePath string  `json:"sourcePath"`
+	StartLine  int     `json:"startLine"`
+	EndLine    int     `json:"endLine"`
+	Content    string  `json:"content"`
+	Score      float64 `json:"score"`
+}
@@ -0,0 +1,28 @@
+// repository.go defines knowledge-base persistence and document source ports.
+// internal/features/ai/knowledge/ports/repository.go
+package ports
+
+import (
+	"context"
+
+	knowledgedomain "github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/domain"
+)
+
+// KnowledgeRepository stores collections, documents, and embedded chunks.
+type KnowledgeRepository interface {
+	SaveCollection(ctx context.Context, collection knowledgedomain.Collection) error
+	GetCollection(ctx context.Context, name string) (*knowledgedomain.Collection, error)
+	ListCollections(ctx context.Context) ([]knowledgedomain.Collection, error)
+	DeleteCollection(ctx context.Context, name string) error
+	DocumentHash(ctx context.Context, collection, path string) (string, error)
+	ReplaceDocument(ctx context.Context, collection string, document knowledgedomain.Document, chunks []knowledgedomain.Chunk) error
+	LoadChunks(ctx context.Context, collection string) ([]knowledgedomain.Chunk, error)
+}
+
+// DocumentReader finds ingestible files and extracts their text.
+type DocumentReader interface {
+	// ListFiles expands paths into supported files; unsupported files are returned as skipped.
+	ListFiles(paths []string) (files []string, skipped []string, err error)
+	// ReadText returns the plain text of a supported file.
+	ReadText(path string) (string, error)
+}
@@ -0,0 +1,64 @@
+// runner.go adapts MCP tool discovery and routing to the chat tool runner port.
+// internal/features/ai/mcp/adapters/chattools/runner.go
+package chattools
+
+import (
+	"context"
+	"fmt"
+
+	chatports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/ports"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// ToolSource lists and calls tools in provider gateway form.
+type ToolSource interface {
+	Tools(ctx context.Context) ([]providergateway.Tool, error)
+	CallTool(ctx context.Context, call providergateway.ToolCall) (string, error)
+}
+
+// Runner serves chat tool calls from a tool source such as the MCP service.
+type Runner struct {
+	source ToolSource
+}
+
+var _ chatports.ToolRunner = (*Runner)(nil)
+
+// New creates a chat tool runner backed by a tool source.
+func New(source ToolSource) *Runner {
+
+	return &Runner{source: source}
+}
+
+// ListTools returns the source's tools as chat tool declarations.
+func (r *Runner) ListTools(ctx context.Context) ([]chatports.ChatTool, error) {
+
+	if r.source == nil {
+		return nil, fmt.Errorf("tool source not configured")
+	}
+	tools, err := r.source.Tools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	converted := make([]chatports.ChatTool, 0, len(tools))
+	for _, tool := range tools {
+		converted = append(converted, chatports.ChatTool{
+			Name:        tool.Name,
+			Description: tool.Description,
+			InputSchema: tool.Parameters,
+		})
+	}
+	return converted, nil
+}
+
+// CallTool executes one model tool call and returns its output.
+func (r *Runner) CallTool(ctx context.Context, call chatports.ChatToolCall) (string, error) {
+
+	if r.source == nil {
+		return "", fmt.Errorf("tool source not configured")
+	}
+	return r.source.CallTool(ctx, providergateway.ToolCall{
+		ID:        call.ID,
+		Name:      call.Name,
+		Arguments: call.Arguments,
+	})
+}
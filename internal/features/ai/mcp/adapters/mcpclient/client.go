@@ -0,0 +1,173 @@
+// client.go implements MCP client sessions over stdio and streamable HTTP transports.
+// internal/features/ai/mcp/adapters/mcpclient/client.go
+package mcpclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	mcpports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/mcp/ports"
+)
+
+// maxToolPages bounds tools/list pagination against servers that never stop returning cursors.
+const maxToolPages = 100
+
+// transport carries JSON-RPC messages to one server.
+type transport interface {
+	roundTrip(ctx context.Context, request rpcRequest) (rpcMessage, error)
+	notify(ctx context.Context, request rpcRequest) error
+	setProtocolVersion(version string)
+	close() error
+}
+
+// Client is an initialized MCP session.
+type Client struct {
+	transport transport
+	nextID    atomic.Int64
+	info      mcpports.ServerInfo
+}
+
+var _ mcpports.Session = (*Client)(nil)
+
+// Dialer launches or connects to configured servers.
+type Dialer struct {
+	httpClient    *http.Client
+	clientName    string
+	clientVersion string
+}
+
+var _ mcpports.Dialer = (*Dialer)(nil)
+
+// NewDialer creates a dialer that identifies itself to servers as clientName; a nil httpClient uses a default client.
+func NewDialer(httpClient *http.Client, clientName, clientVersion string) *Dialer {
+
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return &Dialer{httpClient: httpClient, clientName: clientName, clientVersion: clientVersion}
+}
+
+// Dial opens the server's transport and completes the initialize handshake.
+func (d *Dialer) Dial(ctx context.Context, server mcpports.ServerConfig) (mcpports.Session, error) {
+
+	var (
+		conn transport
+		err  error
+	)
+	switch server.Transport {
+	case mcpports.TransportStdio:
+		conn, err = startStdio(server)
+	case mcpports.TransportHTTP:
+		conn = newHTTPTransport(d.httpClient, server.URL, server.Headers)
+	default:
+		err = fmt.Errorf("unsupported transport %q", server.Transport)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mcp server %s: %w", server.Name, err)
+	}
+
+	client, err := newClient(ctx, conn, implementation{Name: d.clientName, Version: d.clientVersion})
+	if err != nil {
+		_ = conn.close()
+		return nil, fmt.Errorf("mcp server %s: %w", server.Name, err)
+	}
+	return client, nil
+}
+
+// newClient initializes a session over an open transport.
+func newClient(ctx context.Context, conn transport, clientInfo implementation) (*Client, error) {
+
+	client := &Client{transport: conn}
+
+	var result initializeResult
+	err := client.call(ctx, "initialize", initializeParams{
+		ProtocolVersion: protocolVersion,
+		Capabilities:    map[string]any{},
+		ClientInfo:      clientInfo,
+	}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("initialize: %w", err)
+	}
+	conn.setProtocolVersion(result.ProtocolVersion)
+	if err := conn.notify(ctx, rpcRequest{JSONRPC: "2.0", Method: "notifications/initialized"}); err != nil {
+		return nil, fmt.Errorf("initialized notification: %w", err)
+	}
+
+	client.info = mcpports.ServerInfo{
+		Name:            result.ServerInfo.Name,
+		Version:         result.ServerInfo.Version,
+		ProtocolVersion: result.ProtocolVersion,
+	}
+	return client, nil
+}
+
+// Info returns the server identity reported during initialization.
+func (c *Client) Info() mcpports.ServerInfo {
+
+	return c.info
+}
+
+// ListTools returns every tool the server offers, following pagination cursors.
+func (c *Client) ListTools(ctx context.Context) ([]mcpports.ToolDescriptor, error) {
+
+	var tools []mcpports.ToolDescriptor
+	cursor := ""
+	for page := 0; page < maxToolPages; page++ {
+		var params any
+		if cursor != "" {
+			params = map[string]string{"cursor": cursor}
+		}
+		var result listToolsResult
+		if err := c.call(ctx, "tools/list", params, &result); err != nil {
+			return nil, fmt.Errorf("list tools: %w", err)
+		}
+		tools = append(tools, result.Tools...)
+		if result.NextCursor == "" {
+			return tools, nil
+		}
+		cursor = result.NextCursor
+	}
+	return nil, fmt.Errorf("list tools: more than %d pages", maxToolPages)
+}
+
+// CallTool invokes a tool; failures reported by the tool come back as a result with IsError set.
+func (c *Client) CallTool(ctx context.Context, name string, arguments map[string]interface{}) (mcpports.ToolResult, error) {
+
+	if arguments == nil {
+		arguments = map[string]interface{}{}
+	}
+	var result callToolResult
+	if err := c.call(ctx, "tools/call", callToolParams{Name: name, Arguments: arguments}, &result); err != nil {
+		return mcpports.ToolResult{}, fmt.Errorf("call tool %s: %w", name, err)
+	}
+	return mcpports.ToolResult{Content: flattenContent(result), IsError: result.IsError}, nil
+}
+
+// Close ends the session and releases the transport.
+func (c *Client) Close() error {
+
+	return c.transport.close()
+}
+
+// call sends one request and decodes its result.
+func (c *Client) call(ctx context.Context, method string, params any, result any) error {
+
+	id := c.nextID.Add(1)
+	response, err := c.transport.roundTrip(ctx, rpcRequest{JSONRPC: "2.0", ID: &id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	if response.Error != nil {
+		return response.Error
+	}
+	if result == nil || len(response.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("decode %s result: %w", method, err)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+// client_test.go verifies MCP sessions against an in-process fake server over both transports.
+// internal/features/ai/mcp/adapters/mcpclient/client_test.go
+package mcpclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	mcpports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/mcp/ports"
+)
+
+// fakeServer answers MCP requests the way a minimal tool server would.
+type fakeServer struct {
+	mu            sync.Mutex
+	methods       []string
+	lastArguments map[string]interface{}
+}
+
+// handle returns the response for one request, or nil for notifications.
+func (s *fakeServer) handle(message rpcMessage, params json.RawMessage) *rpcReply {
+
+	s.mu.Lock()
+	s.methods = append(s.methods, message.Method)
+	s.mu.Unlock()
+	if len(message.ID) == 0 {
+		return nil
+	}
+
+	reply := &rpcReply{JSONRPC: "2.0", ID: message.ID}
+	switch message.Method {
+	case "initialize":
+		reply.Result = map[string]any{
+			"protocolVersion": protocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "fake-tools", "version": "1.2.0"},
+		}
+	case "tools/list":
+		var request struct {
+			Cursor string `json:"cursor"`
+		}
+		_ = json.Unmarshal(params, &request)
+		if request.Cursor == "" {
+			reply.Result = map[string]any{
+				"tools":      []map[string]any{{"name": "echo", "description": "Echo text", "inputSchema": map[string]any{"type": "object", "properties": map[string]any{"text": map[string]any{"type": "string"}}}}},
+				"nextCursor": "page-2",
+			}
+		} else {
+			reply.Result = map[string]any{"tools": []map[string]any{{"name": "fail", "inputSchema": map[string]any{"type": "object"}}}}
+		}
+	case "tools/call":
+		var request callToolParams
+		_ = json.Unmarshal(params, &request)
+		s.mu.Lock()
+		s.lastArguments = request.Arguments
+		s.mu.Unlock()
+		switch request.Name {
+		case "missing":
+			reply.Error = &rpcError{Code: -32602, Message: "unknown tool: missing"}
+		case "fail":
+			reply.Result = map[string]any{"content": []map[string]any{{"type": "text", "text": "disk full"}}, "isError": true}
+		default:
+			reply.Result = map[string]any{"content": []map[string]any{
+				{"type": "text", "text": fmt.Sprint(request.Arguments["text"])},
+				{"type": "image", "data": "AAAA", "mimeType": "image/png"},
+			}}
+		}
+	default:
+		reply.Error = &rpcError{Code: rpcMethodNotFound, Message: "unknown method"}
+	}
+	return reply
+}
+
+// decodeIncoming splits a raw request into its envelope and params.
+func decodeIncoming(t *testing.T, data []byte) (rpcMessage, json.RawMessage) {
+
+	t.Helper()
+	var envelope struct {
+		rpcMessage
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		t.Errorf("decode request: %v", err)
+	}
+	return envelope.rpcMessage, envelope.Params
+}
+
+// serveStream runs the fake server over a pipe pair, pinging the client before answering tools/list.
+func serveStream(t *testing.T, server *fakeServer, in io.Reader, out io.Writer) {
+
+	reader := bufio.NewReader(in)
+	encoder := json.NewEncoder(out)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+		message, params := decodeIncoming(t, line)
+		if message.Method == "" {
+			continue
+		}
+		if message.Method == "tools/list" {
+			_ = encoder.Encode(map[string]any{"jsonrpc": "2.0", "id": "ping-1", "method": "ping"})
+			_ = encoder.Encode(map[string]any{"jsonrpc": "2.0", "method": "notifications/message", "params": map[string]any{"level": "info"}})
+		}
+		if reply := server.handle(message, params); reply != nil {
+			_ = encoder.Encode(reply)
+		}
+	}
+}
+
+// exerciseSession runs the shared session assertions.
+func exerciseSession(t *testing.T, session mcpports.Session, server *fakeServer) {
+
+	t.Helper()
+	ctx := context.Background()
+	if info := session.Info(); info.Name != "fake-tools" || info.Version != "1.2.0" || info.ProtocolVersion != protocolVersion {
+		t.Fatalf("unexpected server info %#v", info)
+	}
+
+	tools, err := session.ListTools(ctx)
+	if err != nil {
+		t.Fatalf("list tools: %v", err)
+	}
+	if len(tools) != 2 || tools[0].Name != "echo" || tools[0].Description != "Echo text" || tools[1].Name != "fail" || tools[0].InputSchema["type"] != "object" {
+		t.Fatalf("unexpected tools %#v", tools)
+	}
+
+	result, err := session.CallTool(ctx, "echo", map[string]interface{}{"text": "hello"})
+	if err != nil {
+		t.Fatalf("call echo: %v", err)
+	}
+	if result.IsError || result.Content != "hello\n[image: image/png]" {
+		t.Fatalf("unexpected echo result %#v", result)
+	}
+	server.mu.Lock()
+	arguments := server.lastArguments
+	server.mu.Unlock()
+	if arguments["text"] != "hello" {
+		t.Fatalf("unexpected forwarded arguments %#v", arguments)
+	}
+
+	failed, err := session.CallTool(ctx, "fail", nil)
+	if err != nil || !failed.IsError || failed.Content != "disk full" {
+		t.Fatalf("unexpected failure result %#v (%v)", failed, err)
+	}
+	if _, err := session.CallTool(ctx, "missing", nil); err == nil || !strings.Contains(err.Error(), "unknown tool: missing") {
+		t.Fatalf("expected protocol error for unknown tool, got %v", err)
+	}
+}
+
+// TestStreamSessionAgainstFakeServer validates the stdio framing, server pings, and tool round trips.
+func TestStreamSessionAgainstFakeServer(t *testing.T) {
+
+	server := &fakeServer{}
+	clientToServer, serverIn := io.Pipe()
+	serverToClient, clientIn := io.Pipe()
+	go serveStream(t, server, clientToServer, clientIn)
+
+	conn := newStreamTransport(serverToClient, serverIn, func() error {
+		_ = serverIn.Close()
+		return clientIn.Close()
+	})
+	session, err := newClient(context.Background(), conn, implementation{Name: "test"})
+	if err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	exerciseSession(t, session, server)
+
+	if err := session.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if _, err := session.ListTools(context.Background()); err == nil {
+		t.Fatalf("expected error after close")
+	}
+	server.mu.Lock()
+	methods := strings.Join(server.methods, ",")
+	server.mu.Unlock()
+	if !strings.HasPrefix(methods, "initialize,notifications/initialized,tools/list") {
+		t.Fatalf("unexpected method order %s", methods)
+	}
+}
+
+// TestHTTPSessionAgainstFakeServer validates session headers, JSON and event-stream responses, and session teardown.
+func TestHTTPSessionAgainstFakeServer(t *testing.T) {
+
+	server := &fakeServer{}
+	var (
+		mu        sync.Mutex
+		deleted   bool
+		headerErr string
+	)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer local" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			mu.Lock()
+			deleted = r.Header.Get(headerSessionID) == "session-1"
+			mu.Unlock()
+			return
+		}
+
+		data, _ := io.ReadAll(r.Body)
+		message, params := decodeIncoming(t, data)
+		if message.Method != "initialize" && (r.Header.Get(headerSessionID) != "session-1" || r.Header.Get(headerProtocolVersion) != protocolVersion) {
+			mu.Lock()
+			headerErr = "missing session headers on " + message.Method
+			mu.Unlock()
+		}
+		reply := server.handle(message, params)
+		if reply == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		encoded, _ := json.Marshal(reply)
+		if message.Method == "tools/call" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", `{"jsonrpc":"2.0","method":"notifications/progress","params":{}}`)
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", encoded)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if message.Method == "initialize" {
+			w.Header().Set(headerSessionID, "session-1")
+		}
+		_, _ = w.Write(encoded)
+	}))
+	defer httpServer.Close()
+
+	dialer := NewDialer(httpServer.Client(), "test", "0.0.1")
+	session, err := dialer.Dial(context.Background(), mcpports.ServerConfig{
+		Name:      "remote",
+		Transport: mcpports.TransportHTTP,
+		URL:       httpServer.URL + "/mcp",
+		Headers:   map[string]string{"Authorization": "Bearer local"},
+	})
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	exerciseSession(t, session, server)
+	if err := session.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if headerErr != "" {
+		t.Fatalf("%s", headerErr)
+	}
+	if !deleted {
+		t.Fatalf("expected session delete on close")
+	}
+}
+
+// TestServerEnvDropsUnlistedVariables verifies stdio servers inherit only basic variables plus their configured Env.
+func TestServerEnvDropsUnlistedVariables(t *testing.T) {
+
+	env := serverEnv(
+		[]string{"PATH=/usr/bin", "HOME=/home/me", "LC_ALL=C.UTF-8", "OPENAI_API_KEY=sk-secret", "WLS_SECRET_PASSPHRASE=hunter2"},
+		map[string]string{"GITHUB_TOKEN": "ghp-configured"},
+	)
+	slices.Sort(env)
+	expected := []string{"GITHUB_TOKEN=ghp-configured", "HOME=/home/me", "LC_ALL=C.UTF-8", "PATH=/usr/bin"}
+	if !slices.Equal(env, expected) {
+		t.Fatalf("expected %v, got %v", expected, env)
+	}
+}
+
+// TestDialRejectsUnreachableServers validates dial errors name the server.
+func TestDialRejectsUnreachableServers(t *testing.T) {
+
+	testCases := []struct {
+		name   string
+		server mcpports.ServerConfig
+		want   string
+	}{
+		{name: "missing command", server: mcpports.ServerConfig{Name: "files", Transport: mcpports.TransportStdio, Command: "wls-no-such-mcp-server"}, want: "mcp server files: start"},
+		{name: "unknown transport", server: mcpports.ServerConfig{Name: "odd", Transport: "sse"}, want: "unsupported transport"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := NewDialer(nil, "test", "").Dial(context.Background(), testCase.server)
+			if err == nil || !strings.Contains(err.Error(), testCase.want) {
+				t.Fatalf("expected %q, got %v", testCase.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// http.go implements the MCP streamable HTTP transport.
+// internal/features/ai/mcp/adapters/mcpclient/http.go
+package mcpclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Streamable HTTP headers.
+const (
+	headerSessionID       = "Mcp-Session-Id"
+	headerProtocolVersion = "MCP-Protocol-Version"
+)
+
+// httpTransport posts each JSON-RPC message to the server endpoint.
+// Responses arrive as a JSON body or as a server-sent event stream carrying the response.
+type httpTransport struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+
+	mu              sync.Mutex
+	sessionID       string
+	protocolVersion string
+}
+
+// newHTTPTransport creates a transport for the endpoint with extra request headers.
+func newHTTPTransport(client *http.Client, url string, headers map[string]string) *httpTransport {
+
+	return &httpTransport{client: client, url: strings.TrimSpace(url), headers: headers}
+}
+
+// roundTrip posts a request and reads its response from the body or event stream.
+func (t *httpTransport) roundTrip(ctx context.Context, request rpcRequest) (rpcMessage, error) {
+
+	resp, err := t.post(ctx, request)
+	if err != nil {
+		return rpcMessage{}, err
+	}
+	defer resp.Body.Close()
+
+	key := strconv.FormatInt(*request.ID, 10)
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "text/event-stream" {
+		return readEventStream(resp.Body, key)
+	}
+
+	var message rpcMessage
+	if err := json.NewDecoder(resp.Body).Decode(&message); err != nil {
+		return rpcMessage{}, fmt.Errorf("decode response: %w", err)
+	}
+	if idKey(message.ID) != key {
+		return rpcMessage{}, fmt.Errorf("response id %s does not match request %s", idKey(message.ID), key)
+	}
+	return message, nil
+}
+
+// notify posts a notification; servers acknowledge with 202 Accepted.
+func (t *httpTransport) notify(ctx context.Context, request rpcRequest) error {
+
+	resp, err := t.post(ctx, request)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+// setProtocolVersion records the negotiated version sent on later requests.
+func (t *httpTransport) setProtocolVersion(version string) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.protocolVersion = version
+}
+
+// close ends the server session when the server assigned one.
+func (t *httpTransport) close() error {
+
+	t.mu.Lock()
+	sessionID := t.sessionID
+	t.mu.Unlock()
+	if sessionID == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, t.url, nil)
+	if err != nil {
+		return err
+	}
+	t.setHeaders(req)
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("end session: %w", err)
+	}
+	return resp.Body.Close()
+}
+
+// post sends one message and checks the status, remembering any session id the server assigns.
+func (t *httpTransport) post(ctx context.Context, message rpcRequest) (*http.Response, error) {
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("encode message: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	t.setHeaders(req)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("post %s: %w", message.Method, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("post %s: status %d: %s", message.Method, resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	if sessionID := resp.Header.Get(headerSessionID); sessionID != "" {
+		t.mu.Lock()
+		t.sessionID = sessionID
+		t.mu.Unlock()
+	}
+	return resp, nil
+}
+
+// setHeaders applies configured headers plus session and protocol headers.
+func (t *httpTransport) setHeaders(req *http.Request) {
+
+	for key, value := range t.headers {
+		req.Header.Set(key, value)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sessionID != "" {
+		req.Header.Set(headerSessionID, t.sessionID)
+	}
+	if t.protocolVersion != "" {
+		req.Header.Set(headerProtocolVersion, t.protocolVersion)
+	}
+}
+
+// readEventStream reads events until the response with the given id arrives; other messages are skipped.
+func readEventStream(body io.Reader, key string) (rpcMessage, error) {
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "data:") {
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+			continue
+		}
+		if line != "" || data.Len() == 0 {
+			continue
+		}
+
+		var message rpcMessage
+		err := json.Unmarshal([]byte(data.String()), &message)
+		data.Reset()
+		if err == nil && message.isResponse() && idKey(message.ID) == key {
+			return message, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return rpcMessage{}, fmt.Errorf("read event stream: %w", err)
+	}
+	var message rpcMessage
+	if err := json.Unmarshal([]byte(data.String()), &message); err == nil && message.isResponse() && idKey(message.ID) == key {
+		return message, nil
+	}
+	return rpcMessage{}, fmt.Errorf("event stream ended without response %s", key)
+}
@@ -0,0 +1,160 @@
+// protocol.go defines the JSON-RPC messages and MCP payloads exchanged with servers.
+// internal/features/ai/mcp/adapters/mcpclient/protocol.go
+package mcpclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	mcpports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/mcp/ports"
+)
+
+// protocolVersion is the MCP revision requested during initialization.
+const protocolVersion = "2025-03-26"
+
+// JSON-RPC error codes used when answering server-initiated requests.
+const (
+	rpcMethodNotFound = -32601
+)
+
+// rpcRequest is an outgoing JSON-RPC request, or a notification when ID is nil.
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      *int64 `json:"id,omitempty"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+// rpcMessage is any incoming JSON-RPC message: a response, a request, or a notification.
+type rpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcReply answers a server-initiated request.
+type rpcReply struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC error object.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements error.
+func (e *rpcError) Error() string {
+
+	return fmt.Sprintf("mcp error %d: %s", e.Code, e.Message)
+}
+
+// isResponse reports whether the message answers a request.
+func (m rpcMessage) isResponse() bool {
+
+	return m.Method == "" && len(m.ID) > 0
+}
+
+// isRequest reports whether the message is a server-initiated request that needs a reply.
+func (m rpcMessage) isRequest() bool {
+
+	return m.Method != "" && len(m.ID) > 0 && string(m.ID) != "null"
+}
+
+// idKey normalizes a raw JSON-RPC id for matching responses to pending requests.
+func idKey(raw json.RawMessage) string {
+
+	return strings.TrimSpace(string(raw))
+}
+
+// replyTo builds the client's answer to a server-initiated request; only ping is supported.
+func replyTo(message rpcMessage) rpcReply {
+
+	reply := rpcReply{JSONRPC: "2.0", ID: message.ID}
+	if message.Method == "ping" {
+		reply.Result = struct{}{}
+		return reply
+	}
+	reply.Error = &rpcError{Code: rpcMethodNotFound, Message: "method not supported by client: " + message.Method}
+	return reply
+}
+
+// initializeParams is the initialize request payload.
+type initializeParams struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ClientInfo      implementation `json:"clientInfo"`
+}
+
+// implementation names a client or server.
+type implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// initializeResult is the initialize response payload.
+type initializeResult struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	ServerInfo      implementation `json:"serverInfo"`
+}
+
+// listToolsResult is one page of tools/list.
+type listToolsResult struct {
+	Tools      []mcpports.ToolDescriptor `json:"tools"`
+	NextCursor string                    `json:"nextCursor,omitempty"`
+}
+
+// callToolParams is the tools/call request payload.
+type callToolParams struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+// callToolResult is the tools/call response payload.
+type callToolResult struct {
+	Content           []contentPart   `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent,omitempty"`
+	IsError           bool            `json:"isError,omitempty"`
+}
+
+// contentPart is one item of tool output.
+type contentPart struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+	URI      string `json:"uri,omitempty"`
+	Resource *struct {
+		URI  string `json:"uri"`
+		Text string `json:"text,omitempty"`
+	} `json:"resource,omitempty"`
+}
+
+// flattenContent renders tool output as text; binary parts are summarized rather than inlined.
+func flattenContent(result callToolResult) string {
+
+	parts := make([]string, 0, len(result.Content))
+	for _, part := range result.Content {
+		switch {
+		case part.Type == "text":
+			parts = append(parts, part.Text)
+		case part.Type == "resource" && part.Resource != nil && part.Resource.Text != "":
+			parts = append(parts, part.Resource.Text)
+		case part.Type == "resource" && part.Resource != nil:
+			parts = append(parts, "[resource: "+part.Resource.URI+"]")
+		case part.Type == "resource_link":
+			parts = append(parts, "[resource: "+part.URI+"]")
+		default:
+			parts = append(parts, "["+part.Type+": "+part.MimeType+"]")
+		}
+	}
+	if len(parts) == 0 && len(result.StructuredContent) > 0 {
+		return string(result.StructuredContent)
+	}
+	return strings.Join(parts, "\n")
+}
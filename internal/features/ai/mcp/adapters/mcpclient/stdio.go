@@ -0,0 +1,235 @@
+// stdio.go implements the MCP stdio transport: newline-delimited JSON-RPC over a child process's pipes.
+// internal/features/ai/mcp/adapters/mcpclient/stdio.go
+package mcpclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	mcpports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/mcp/ports"
+)
+
+// stdioShutdownGrace is how long a server may take to exit after stdin closes before it is killed.
+const stdioShutdownGrace = 2 * time.Second
+
+// inheritedEnv lists the process variables a server inherits so it can find executables, its home
+// directory and the user's locale; everything else, including provider API keys, must come from
+// the server's configured Env.
+var inheritedEnv = []string{
+	"PATH", "HOME", "USER", "LOGNAME", "SHELL", "TMPDIR", "TZ", "LANG", "LANGUAGE",
+	"SYSTEMROOT", "COMSPEC", "PATHEXT", "TEMP", "TMP", "USERPROFILE", "APPDATA", "LOCALAPPDATA",
+}
+
+// errTransportClosed reports that the server connection ended.
+var errTransportClosed = errors.New("mcp transport closed")
+
+// streamTransport exchanges newline-delimited JSON-RPC messages over a byte stream pair.
+type streamTransport struct {
+	writeMu sync.Mutex
+	writer  io.Writer
+	closer  func() error
+
+	mu      sync.Mutex
+	pending map[string]chan rpcMessage
+	done    chan struct{}
+	readErr error
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// startStdio launches the server process and attaches a stream transport to its stdin and stdout.
+// Server stderr is discarded; servers use it for logs.
+func startStdio(server mcpports.ServerConfig) (*streamTransport, error) {
+
+	cmd := exec.Command(server.Command, server.Args...)
+	cmd.Env = serverEnv(os.Environ(), server.Env)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("stdout pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start %s: %w", server.Command, err)
+	}
+
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+	closer := func() error {
+		_ = stdin.Close()
+		select {
+		case <-exited:
+		case <-time.After(stdioShutdownGrace):
+			_ = cmd.Process.Kill()
+			<-exited
+		}
+		return nil
+	}
+	return newStreamTransport(stdout, stdin, closer), nil
+}
+
+// serverEnv keeps the inheritedEnv and LC_* locale entries of environ and appends the configured variables.
+func serverEnv(environ []string, configured map[string]string) []string {
+
+	env := make([]string, 0, len(inheritedEnv)+len(configured))
+	for _, entry := range environ {
+		key, _, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		upper := strings.ToUpper(key)
+		if strings.HasPrefix(upper, "LC_") || slices.Contains(inheritedEnv, upper) {
+			env = append(env, entry)
+		}
+	}
+	for key, value := range configured {
+		env = append(env, key+"="+value)
+	}
+	return env
+}
+
+// newStreamTransport starts reading messages from reader; closer releases the underlying connection.
+func newStreamTransport(reader io.Reader, writer io.Writer, closer func() error) *streamTransport {
+
+	t := &streamTransport{
+		writer:  writer,
+		closer:  closer,
+		pending: make(map[string]chan rpcMessage),
+		done:    make(chan struct{}),
+	}
+	go t.readLoop(reader)
+	return t
+}
+
+// readLoop dispatches responses to waiting callers and answers server requests until the stream ends.
+func (t *streamTransport) readLoop(reader io.Reader) {
+
+	buffered := bufio.NewReader(reader)
+	var err error
+	for {
+		var line []byte
+		line, err = buffered.ReadBytes('\n')
+		if len(line) > 0 {
+			t.dispatch(line)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	t.mu.Lock()
+	if errors.Is(err, io.EOF) {
+		err = errTransportClosed
+	}
+	t.readErr = err
+	t.mu.Unlock()
+	close(t.done)
+}
+
+// dispatch handles one received line; malformed lines are skipped.
+func (t *streamTransport) dispatch(line []byte) {
+
+	var message rpcMessage
+	if err := json.Unmarshal(line, &message); err != nil {
+		return
+	}
+	switch {
+	case message.isRequest():
+		// Reply off the read loop so a server blocked on writing cannot deadlock with this reply.
+		go func() { _ = t.write(replyTo(message)) }()
+	case message.isResponse():
+		t.mu.Lock()
+		waiter, ok := t.pending[idKey(message.ID)]
+		delete(t.pending, idKey(message.ID))
+		t.mu.Unlock()
+		if ok {
+			waiter <- message
+		}
+	}
+}
+
+// roundTrip writes a request and waits for the response with the same id.
+func (t *streamTransport) roundTrip(ctx context.Context, request rpcRequest) (rpcMessage, error) {
+
+	key := strconv.FormatInt(*request.ID, 10)
+	waiter := make(chan rpcMessage, 1)
+	t.mu.Lock()
+	t.pending[key] = waiter
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		delete(t.pending, key)
+		t.mu.Unlock()
+	}()
+
+	if err := t.write(request); err != nil {
+		return rpcMessage{}, err
+	}
+	select {
+	case response := <-waiter:
+		return response, nil
+	case <-ctx.Done():
+		return rpcMessage{}, ctx.Err()
+	case <-t.done:
+		t.mu.Lock()
+		err := t.readErr
+		t.mu.Unlock()
+		return rpcMessage{}, err
+	}
+}
+
+// notify writes a notification.
+func (t *streamTransport) notify(_ context.Context, request rpcRequest) error {
+
+	return t.write(request)
+}
+
+// setProtocolVersion is a no-op; the stdio transport carries no version header.
+func (t *streamTransport) setProtocolVersion(string) {
+
+}
+
+// close releases the connection once.
+func (t *streamTransport) close() error {
+
+	t.closeOnce.Do(func() {
+		if t.closer != nil {
+			t.closeErr = t.closer()
+		}
+	})
+	return t.closeErr
+}
+
+// write encodes one message as a single line.
+func (t *streamTransport) write(message any) error {
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("encode message: %w", err)
+	}
+	data = append(data, '\n')
+
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	if _, err := t.writer.Write(data); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,407 @@
+// service.go manages configured MCP servers, exposes their tools to models, and routes tool calls.
+// internal/features/ai/mcp/app/mcp/service.go
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	mcpports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/mcp/ports"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// serverTimeout bounds connecting to one server and listing its tools so a hung server cannot stall chat.
+const serverTimeout = 15 * time.Second
+
+// toolCallTimeout bounds one tool call so a hung tool cannot stall a reply until the user stops it.
+const toolCallTimeout = 2 * time.Minute
+
+// Service implements MCP server management and tool routing.
+// Sessions are opened on first use and kept until the server is removed, reconfigured, or fails.
+// Dials run outside the lock so a slow server does not stall the others.
+type Service struct {
+	dialer      mcpports.Dialer
+	store       mcpports.ServerStore
+	logger      corelogger.Logger
+	timeout     time.Duration
+	callTimeout time.Duration
+
+	mu       sync.Mutex
+	servers  []mcpports.ServerConfig
+	sessions map[string]mcpports.Session
+	dialing  map[string]*dialCall
+}
+
+// dialCall is one in-flight dial shared by every caller that needs the same server.
+type dialCall struct {
+	done    chan struct{}
+	session mcpports.Session
+	err     error
+}
+
+var _ mcpports.MCPInterface = (*Service)(nil)
+
+// NewService creates an MCP service over the configured servers; logger may be nil.
+func NewService(servers []mcpports.ServerConfig, dialer mcpports.Dialer, store mcpports.ServerStore, logger corelogger.Logger) *Service {
+
+	return &Service{
+		dialer:      dialer,
+		store:       store,
+		logger:      logger,
+		timeout:     serverTimeout,
+		callTimeout: toolCallTimeout,
+		servers:     append([]mcpports.ServerConfig(nil), servers...),
+		sessions:    make(map[string]mcpports.Session),
+		dialing:     make(map[string]*dialCall),
+	}
+}
+
+// ListServers returns the configured servers in configuration order.
+func (s *Service) ListServers(_ context.Context) ([]mcpports.ServerConfig, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]mcpports.ServerConfig(nil), s.servers...), nil
+}
+
+// AddServer persists a new server; its tools are offered from the next chat request.
+func (s *Service) AddServer(_ context.Context, server mcpports.ServerConfig) error {
+
+	server.Name = strings.TrimSpace(server.Name)
+	if server.Name == "" {
+		return errors.New("server name required")
+	}
+	if strings.Contains(server.Name, mcpports.ToolNameSeparator) {
+		return fmt.Errorf("server name must not contain %q", mcpports.ToolNameSeparator)
+	}
+	if s.store == nil {
+		return errors.New("backend service: MCP server store not configured")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.serverLocked(server.Name); ok {
+		return fmt.Errorf("MCP server already exists: %s", server.Name)
+	}
+	if err := s.store.AddServer(server); err != nil {
+		return err
+	}
+	s.servers = append(s.servers, server)
+	return nil
+}
+
+// RemoveServer deletes a server from configuration and closes its session.
+func (s *Service) RemoveServer(_ context.Context, name string) error {
+
+	name = strings.TrimSpace(name)
+	if s.store == nil {
+		return errors.New("backend service: MCP server store not configured")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.serverLocked(name); !ok {
+		return fmt.Errorf("MCP server not found: %s", name)
+	}
+	if err := s.store.RemoveServer(name); err != nil {
+		return err
+	}
+	kept := s.servers[:0]
+	for _, server := range s.servers {
+		if server.Name != name {
+			kept = append(kept, server)
+		}
+	}
+	s.servers = kept
+	s.closeSessionLocked(name)
+	return nil
+}
+
+// TestServer opens a fresh session, lists its tools, and closes it; disabled servers can be tested too.
+func (s *Service) TestServer(ctx context.Context, name string) (mcpports.TestResult, error) {
+
+	if s.dialer == nil {
+		return mcpports.TestResult{}, errors.New("backend service: MCP client not configured")
+	}
+	s.mu.Lock()
+	server, ok := s.serverLocked(strings.TrimSpace(name))
+	s.mu.Unlock()
+	if !ok {
+		return mcpports.TestResult{}, fmt.Errorf("MCP server not found: %s", name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	session, err := s.dialer.Dial(ctx, server)
+	if err != nil {
+		return mcpports.TestResult{}, err
+	}
+	defer session.Close()
+
+	tools, err := session.ListTools(ctx)
+	if err != nil {
+		return mcpports.TestResult{}, fmt.Errorf("MCP server %s: %w", server.Name, err)
+	}
+	return mcpports.TestResult{Server: server.Name, Info: session.Info(), Tools: tools}, nil
+}
+
+// Tools lists the tools of every enabled server as model tool declarations named server__tool.
+// Servers are listed concurrently, each bounded by the server timeout; servers that cannot be reached
+// or listed in time are logged and skipped so one broken server does not block chat.
+func (s *Service) Tools(ctx context.Context) ([]providergateway.Tool, error) {
+
+	s.mu.Lock()
+	servers := append([]mcpports.ServerConfig(nil), s.servers...)
+	s.mu.Unlock()
+
+	listed := make([][]providergateway.Tool, len(servers))
+	var wg sync.WaitGroup
+	for index, server := range servers {
+		if server.Disabled {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, server mcpports.ServerConfig) {
+			defer wg.Done()
+			listed[index] = s.serverTools(ctx, server)
+		}(index, server)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var tools []providergateway.Tool
+	for _, serverTools := range listed {
+		tools = append(tools, serverTools...)
+	}
+	return tools, nil
+}
+
+// serverTools lists one server's tools within the server timeout, returning nil when it is unavailable.
+func (s *Service) serverTools(ctx context.Context, server mcpports.ServerConfig) []providergateway.Tool {
+
+	listCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	session, err := s.session(listCtx, server.Name)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logWarn("Skipping unavailable MCP server", err, server.Name)
+		}
+		return nil
+	}
+	descriptors, err := session.ListTools(listCtx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.dropSession(server.Name, session)
+			s.logWarn("Skipping MCP server whose tools could not be listed", err, server.Name)
+		}
+		return nil
+	}
+
+	tools := make([]providergateway.Tool, 0, len(descriptors))
+	for _, descriptor := range descriptors {
+		tools = append(tools, providergateway.Tool{
+			Name:        server.Name + mcpports.ToolNameSeparator + descriptor.Name,
+			Description: descriptor.Description,
+			Parameters:  ToolParameters(descriptor.InputSchema),
+		})
+	}
+	return tools
+}
+
+// CallTool routes a model tool call to the server named by its prefix and returns the tool output.
+// Failures reported by the tool are returned as errors carrying the tool's message; a call that
+// outlives the tool call timeout fails and its session is dropped.
+func (s *Service) CallTool(ctx context.Context, call providergateway.ToolCall) (string, error) {
+
+	serverName, toolName, ok := strings.Cut(call.Name, mcpports.ToolNameSeparator)
+	if !ok || serverName == "" || toolName == "" {
+		return "", fmt.Errorf("tool %s is not provided by an MCP server", call.Name)
+	}
+
+	session, err := s.session(ctx, serverName)
+	if err != nil {
+		return "", err
+	}
+	callCtx, cancel := context.WithTimeout(ctx, s.callTimeout)
+	defer cancel()
+	result, err := session.CallTool(callCtx, toolName, call.Arguments)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.dropSession(serverName, session)
+		}
+		if ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("MCP server %s: tool %s timed out after %s", serverName, toolName, s.callTimeout)
+		}
+		return "", fmt.Errorf("MCP server %s: %w", serverName, err)
+	}
+	if result.IsError {
+		return "", fmt.Errorf("tool %s failed: %s", call.Name, result.Content)
+	}
+	return result.Content, nil
+}
+
+// Reload replaces the server list, closing sessions of servers that were removed or changed.
+func (s *Service) Reload(servers []mcpports.ServerConfig) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	next := make(map[string]mcpports.ServerConfig, len(servers))
+	for _, server := range servers {
+		next[server.Name] = server
+	}
+	for _, server := range s.servers {
+		updated, ok := next[server.Name]
+		if !ok || updated.Disabled || !reflect.DeepEqual(updated, server) {
+			s.closeSessionLocked(server.Name)
+		}
+	}
+	s.servers = append([]mcpports.ServerConfig(nil), servers...)
+}
+
+// Close ends every open session; dials still in flight are closed when they finish.
+func (s *Service) Close() error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name := range s.sessions {
+		s.closeSessionLocked(name)
+	}
+	for name := range s.dialing {
+		delete(s.dialing, name)
+	}
+	return nil
+}
+
+// session returns the open session for an enabled server, dialing it when needed.
+// Concurrent callers for the same server share one dial, bounded by the server timeout; a dial whose
+// server is removed or reconfigured meanwhile is discarded.
+func (s *Service) session(ctx context.Context, name string) (mcpports.Session, error) {
+
+	if s.dialer == nil {
+		return nil, errors.New("backend service: MCP client not configured")
+	}
+
+	s.mu.Lock()
+	server, ok := s.serverLocked(name)
+	if !ok {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("MCP server not found: %s", name)
+	}
+	if server.Disabled {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("MCP server disabled: %s", name)
+	}
+	if session, ok := s.sessions[name]; ok {
+		s.mu.Unlock()
+		return session, nil
+	}
+	call, inFlight := s.dialing[name]
+	if !inFlight {
+		call = &dialCall{done: make(chan struct{})}
+		s.dialing[name] = call
+	}
+	s.mu.Unlock()
+
+	if inFlight {
+		select {
+		case <-call.done:
+			return call.session, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	session, err := s.dialer.Dial(dialCtx, server)
+	cancel()
+
+	s.mu.Lock()
+	current := s.dialing[name] == call
+	if current {
+		delete(s.dialing, name)
+		if err == nil {
+			s.sessions[name] = session
+		}
+	}
+	s.mu.Unlock()
+	if err == nil && !current {
+		_ = session.Close()
+		session, err = nil, fmt.Errorf("MCP server %s was removed or reconfigured while connecting", name)
+	}
+
+	call.session, call.err = session, err
+	close(call.done)
+	return session, err
+}
+
+// dropSession closes a failed session so the next use reconnects.
+func (s *Service) dropSession(name string, session mcpports.Session) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sessions[name] == session {
+		s.closeSessionLocked(name)
+	}
+}
+
+// closeSessionLocked closes and forgets a session and abandons any in-flight dial; callers hold s.mu.
+func (s *Service) closeSessionLocked(name string) {
+
+	delete(s.dialing, name)
+	if session, ok := s.sessions[name]; ok {
+		_ = session.Close()
+		delete(s.sessions, name)
+	}
+}
+
+// logWarn reports a server problem when a logger is configured.
+func (s *Service) logWarn(message string, err error, server string) {
+
+	if s.logger == nil {
+		return
+	}
+	s.logger.Warn(message, err, corelogger.LogField{Key: "server", Value: server})
+}
+
+// serverLocked finds a configured server; callers hold s.mu.
+func (s *Service) serverLocked(name string) (mcpports.ServerConfig, bool) {
+
+	for _, server := range s.servers {
+		if server.Name == name {
+			return server, true
+		}
+	}
+	return mcpports.ServerConfig{}, false
+}
+
+// ToolParameters converts an MCP input schema into model tool parameters.
+// Schemas are copied, "$schema" is dropped because some providers reject it, and a missing
+// schema or property list becomes an empty object so strict providers accept the declaration.
+func ToolParameters(schema map[string]interface{}) map[string]interface{} {
+
+	parameters := make(map[string]interface{}, len(schema)+2)
+	for key, value := range schema {
+		if key == "$schema" {
+			continue
+		}
+		parameters[key] = value
+	}
+	if _, ok := parameters["type"]; !ok {
+		parameters["type"] = "object"
+	}
+	if parameters["type"] == "object" {
+		if _, ok := parameters["properties"]; !ok {
+			parameters["properties"] = map[string]interface{}{}
+		}
+	}
+	return parameters
+}
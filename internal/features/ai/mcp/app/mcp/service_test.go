@@ -0,0 +1,472 @@
+// service_test.go verifies MCP tool discovery, call routing, session reuse, and server management.
+// internal/features/ai/mcp/app/mcp/service_test.go
+package mcp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	mcpports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/mcp/ports"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// fakeSession serves a fixed tool list and records calls.
+type fakeSession struct {
+	tools   []mcpports.ToolDescriptor
+	results map[string]mcpports.ToolResult
+	callErr error
+	calls   []string
+	closed  bool
+}
+
+// Info returns a fixed identity.
+func (s *fakeSession) Info() mcpports.ServerInfo {
+
+	return mcpports.ServerInfo{Name: "fake", Version: "1.0.0"}
+}
+
+// ListTools returns the configured tools.
+func (s *fakeSession) ListTools(_ context.Context) ([]mcpports.ToolDescriptor, error) {
+
+	return s.tools, nil
+}
+
+// CallTool records the call and returns the configured result.
+func (s *fakeSession) CallTool(_ context.Context, name string, _ map[string]interface{}) (mcpports.ToolResult, error) {
+
+	s.calls = append(s.calls, name)
+	if s.callErr != nil {
+		return mcpports.ToolResult{}, s.callErr
+	}
+	return s.results[name], nil
+}
+
+// Close marks the session closed.
+func (s *fakeSession) Close() error {
+
+	s.closed = true
+	return nil
+}
+
+// fakeDialer hands out sessions per server name and counts dials.
+type fakeDialer struct {
+	mu       sync.Mutex
+	sessions map[string]*fakeSession
+	dials    map[string]int
+}
+
+// Dial returns the session for the server.
+func (d *fakeDialer) Dial(_ context.Context, server mcpports.ServerConfig) (mcpports.Session, error) {
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.dials[server.Name]++
+	session, ok := d.sessions[server.Name]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return session, nil
+}
+
+// fakeStore records persisted changes.
+type fakeStore struct {
+	added   []string
+	removed []string
+}
+
+// AddServer records the added server.
+func (s *fakeStore) AddServer(server mcpports.ServerConfig) error {
+
+	s.added = append(s.added, server.Name)
+	return nil
+}
+
+// RemoveServer records the removed server.
+func (s *fakeStore) RemoveServer(name string) error {
+
+	s.removed = append(s.removed, name)
+	return nil
+}
+
+// newTestService builds a service over two servers, one of them disabled.
+func newTestService() (*Service, *fakeDialer, *fakeSession) {
+
+	files := &fakeSession{
+		tools: []mcpports.ToolDescriptor{
+			{Name: "read_file", Description: "Read a file", InputSchema: map[string]interface{}{"$schema": "http://json-schema.org/draft-07/schema#", "type": "object", "properties": map[string]interface{}{"path": map[string]interface{}{"type": "string"}}}},
+			{Name: "list_roots"},
+		},
+		results: map[string]mcpports.ToolResult{
+			"read_file":  {Content: "hello"},
+			"list_roots": {Content: "permission denied", IsError: true},
+		},
+	}
+	dialer := &fakeDialer{sessions: map[string]*fakeSession{"files": files, "web": {}}, dials: map[string]int{}}
+	service := NewService([]mcpports.ServerConfig{
+		{Name: "files", Transport: mcpports.TransportStdio, Command: "files-server"},
+		{Name: "web", Transport: mcpports.TransportHTTP, URL: "http://localhost:9000/mcp", Disabled: true},
+	}, dialer, &fakeStore{}, nil)
+	return service, dialer, files
+}
+
+// TestToolsPrefixServerNamesAndNormalizeSchemas validates tool naming, schema cleanup, and disabled servers.
+func TestToolsPrefixServerNamesAndNormalizeSchemas(t *testing.T) {
+
+	service, dialer, _ := newTestService()
+
+	tools, err := service.Tools(context.Background())
+	if err != nil {
+		t.Fatalf("tools: %v", err)
+	}
+	if len(tools) != 2 || tools[0].Name != "files__read_file" || tools[0].Description != "Read a file" || tools[1].Name != "files__list_roots" {
+		t.Fatalf("unexpected tools %#v", tools)
+	}
+	if _, ok := tools[0].Parameters["$schema"]; ok {
+		t.Fatalf("expected $schema to be dropped: %#v", tools[0].Parameters)
+	}
+	if tools[1].Parameters["type"] != "object" || tools[1].Parameters["properties"] == nil {
+		t.Fatalf("expected empty object schema, got %#v", tools[1].Parameters)
+	}
+	if dialer.dials["web"] != 0 {
+		t.Fatalf("disabled server should not be dialed")
+	}
+
+	if _, err := service.Tools(context.Background()); err != nil {
+		t.Fatalf("tools again: %v", err)
+	}
+	if dialer.dials["files"] != 1 {
+		t.Fatalf("expected session reuse, got %d dials", dialer.dials["files"])
+	}
+}
+
+// TestToolsSkipsUnavailableServers validates a server that cannot be dialed does not hide healthy servers' tools.
+func TestToolsSkipsUnavailableServers(t *testing.T) {
+
+	service, dialer, _ := newTestService()
+	service.Reload(append(service.servers, mcpports.ServerConfig{Name: "broken", Transport: mcpports.TransportStdio, Command: "missing"}))
+
+	tools, err := service.Tools(context.Background())
+	if err != nil {
+		t.Fatalf("tools: %v", err)
+	}
+	if len(tools) != 2 || tools[0].Name != "files__read_file" {
+		t.Fatalf("expected the healthy server's tools, got %#v", tools)
+	}
+	if dialer.dials["broken"] != 1 {
+		t.Fatalf("expected broken server to be attempted once, got %d", dialer.dials["broken"])
+	}
+}
+
+// hungSession never answers a tools listing until its context ends.
+type hungSession struct {
+	fakeSession
+}
+
+// ListTools waits for ctx and reports why it ended.
+func (s *hungSession) ListTools(ctx context.Context) ([]mcpports.ToolDescriptor, error) {
+
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// CallTool waits for ctx and reports why it ended.
+func (s *hungSession) CallTool(ctx context.Context, _ string, _ map[string]interface{}) (mcpports.ToolResult, error) {
+
+	<-ctx.Done()
+	return mcpports.ToolResult{}, ctx.Err()
+}
+
+// hangingDialer never completes dials to "hung", returns a hung session for "stuck", and serves "files" normally.
+type hangingDialer struct {
+	stuck *hungSession
+	files *fakeSession
+}
+
+// Dial returns or withholds a session depending on the server.
+func (d *hangingDialer) Dial(ctx context.Context, server mcpports.ServerConfig) (mcpports.Session, error) {
+
+	switch server.Name {
+	case "stuck":
+		return d.stuck, nil
+	case "files":
+		return d.files, nil
+	default:
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+}
+
+// TestToolsBoundsHungServers validates a hung dial or listing is abandoned after the server timeout while healthy tools are kept.
+func TestToolsBoundsHungServers(t *testing.T) {
+
+	dialer := &hangingDialer{stuck: &hungSession{}, files: &fakeSession{tools: []mcpports.ToolDescriptor{{Name: "read_file"}}}}
+	service := NewService([]mcpports.ServerConfig{
+		{Name: "hung", Transport: mcpports.TransportStdio, Command: "hung-server"},
+		{Name: "stuck", Transport: mcpports.TransportStdio, Command: "stuck-server"},
+		{Name: "files", Transport: mcpports.TransportStdio, Command: "files-server"},
+	}, dialer, &fakeStore{}, nil)
+	service.timeout = 50 * time.Millisecond
+
+	started := time.Now()
+	tools, err := service.Tools(context.Background())
+	if err != nil {
+		t.Fatalf("tools: %v", err)
+	}
+	if len(tools) != 1 || tools[0].Name != "files__read_file" {
+		t.Fatalf("expected only the healthy server's tools, got %#v", tools)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("expected hung servers to be listed concurrently within the timeout, took %v", elapsed)
+	}
+	if !dialer.stuck.closed {
+		t.Fatalf("expected the session that timed out listing to be closed")
+	}
+}
+
+// TestCallToolBoundsHungTools validates a hung tool call fails after the call timeout and its session is dropped.
+func TestCallToolBoundsHungTools(t *testing.T) {
+
+	dialer := &hangingDialer{stuck: &hungSession{}}
+	service := NewService([]mcpports.ServerConfig{{Name: "stuck", Transport: mcpports.TransportStdio, Command: "stuck-server"}}, dialer, &fakeStore{}, nil)
+	service.callTimeout = 50 * time.Millisecond
+
+	_, err := service.CallTool(context.Background(), providergateway.ToolCall{Name: "stuck__search"})
+	if err == nil || !strings.Contains(err.Error(), "tool search timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	if !dialer.stuck.closed {
+		t.Fatalf("expected the hung session to be closed")
+	}
+}
+
+// TestToolsStopsWhenCancelled validates cancelling the caller abandons a hung server instead of waiting for the timeout.
+func TestToolsStopsWhenCancelled(t *testing.T) {
+
+	service := NewService([]mcpports.ServerConfig{{Name: "hung", Transport: mcpports.TransportStdio, Command: "hung-server"}}, &hangingDialer{}, &fakeStore{}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if _, err := service.Tools(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+}
+
+// blockingDialer holds dials for one server until released and counts them.
+type blockingDialer struct {
+	mu      sync.Mutex
+	dials   int
+	started chan struct{}
+	release chan struct{}
+	session *fakeSession
+}
+
+// Dial blocks for the slow server and returns its session once released.
+func (d *blockingDialer) Dial(_ context.Context, server mcpports.ServerConfig) (mcpports.Session, error) {
+
+	if server.Name != "slow" {
+		return &fakeSession{results: map[string]mcpports.ToolResult{"ping": {Content: "pong"}}}, nil
+	}
+	d.mu.Lock()
+	d.dials++
+	d.mu.Unlock()
+	d.started <- struct{}{}
+	<-d.release
+	return d.session, nil
+}
+
+// TestSlowDialDoesNotBlockOtherServers validates dials run outside the lock and are shared by concurrent callers.
+func TestSlowDialDoesNotBlockOtherServers(t *testing.T) {
+
+	dialer := &blockingDialer{started: make(chan struct{}, 2), release: make(chan struct{}), session: &fakeSession{}}
+	service := NewService([]mcpports.ServerConfig{
+		{Name: "slow", Transport: mcpports.TransportStdio, Command: "slow-server"},
+		{Name: "fast", Transport: mcpports.TransportStdio, Command: "fast-server"},
+	}, dialer, &fakeStore{}, nil)
+	ctx := context.Background()
+
+	results := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := service.session(ctx, "slow")
+			results <- err
+		}()
+	}
+	<-dialer.started
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := service.ListServers(ctx); err != nil {
+			t.Errorf("list servers: %v", err)
+		}
+		if output, err := service.CallTool(ctx, providergateway.ToolCall{Name: "fast__ping"}); err != nil || output != "pong" {
+			t.Errorf("unexpected fast call %q (%v)", output, err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("other servers were blocked by a slow dial")
+	}
+
+	close(dialer.release)
+	for range 2 {
+		if err := <-results; err != nil {
+			t.Fatalf("slow session: %v", err)
+		}
+	}
+	dialer.mu.Lock()
+	defer dialer.mu.Unlock()
+	if dialer.dials != 1 {
+		t.Fatalf("expected concurrent callers to share one dial, got %d", dialer.dials)
+	}
+}
+
+// TestRemoveDuringDialDiscardsSession validates a dial that finishes after its server is removed is closed.
+func TestRemoveDuringDialDiscardsSession(t *testing.T) {
+
+	dialer := &blockingDialer{started: make(chan struct{}, 1), release: make(chan struct{}), session: &fakeSession{}}
+	service := NewService([]mcpports.ServerConfig{{Name: "slow", Transport: mcpports.TransportStdio, Command: "slow-server"}}, dialer, &fakeStore{}, nil)
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := service.session(context.Background(), "slow")
+		result <- err
+	}()
+	<-dialer.started
+	if err := service.RemoveServer(context.Background(), "slow"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	close(dialer.release)
+
+	if err := <-result; err == nil || !strings.Contains(err.Error(), "removed or reconfigured") {
+		t.Fatalf("expected discarded dial, got %v", err)
+	}
+	if !dialer.session.closed {
+		t.Fatalf("expected the late session to be closed")
+	}
+}
+
+// TestCallToolRoutesByServerPrefix validates routing, tool-reported failures, and unknown prefixes.
+func TestCallToolRoutesByServerPrefix(t *testing.T) {
+
+	service, _, files := newTestService()
+	ctx := context.Background()
+
+	output, err := service.CallTool(ctx, providergateway.ToolCall{ID: "call-1", Name: "files__read_file", Arguments: map[string]interface{}{"path": "a.txt"}})
+	if err != nil || output != "hello" {
+		t.Fatalf("unexpected call result %q (%v)", output, err)
+	}
+	if len(files.calls) != 1 || files.calls[0] != "read_file" {
+		t.Fatalf("unexpected forwarded calls %#v", files.calls)
+	}
+
+	testCases := []struct {
+		name string
+		tool string
+		want string
+	}{
+		{name: "tool error", tool: "files__list_roots", want: "permission denied"},
+		{name: "no prefix", tool: "read_file", want: "not provided by an MCP server"},
+		{name: "unknown server", tool: "mail__send", want: "MCP server not found: mail"},
+		{name: "disabled server", tool: "web__fetch", want: "MCP server disabled: web"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := service.CallTool(ctx, providergateway.ToolCall{Name: testCase.tool})
+			if err == nil || !strings.Contains(err.Error(), testCase.want) {
+				t.Fatalf("expected %q, got %v", testCase.want, err)
+			}
+		})
+	}
+}
+
+// TestCallToolReconnectsAfterTransportFailure validates failed sessions are closed and redialed.
+func TestCallToolReconnectsAfterTransportFailure(t *testing.T) {
+
+	service, dialer, files := newTestService()
+	files.callErr = errors.New("broken pipe")
+
+	if _, err := service.CallTool(context.Background(), providergateway.ToolCall{Name: "files__read_file"}); err == nil {
+		t.Fatalf("expected transport error")
+	}
+	if !files.closed {
+		t.Fatalf("expected failed session to be closed")
+	}
+
+	files.callErr = nil
+	if _, err := service.CallTool(context.Background(), providergateway.ToolCall{Name: "files__read_file"}); err != nil {
+		t.Fatalf("call after reconnect: %v", err)
+	}
+	if dialer.dials["files"] != 2 {
+		t.Fatalf("expected redial, got %d dials", dialer.dials["files"])
+	}
+}
+
+// TestAddRemoveAndReloadServers validates persistence, duplicate checks, and session teardown.
+func TestAddRemoveAndReloadServers(t *testing.T) {
+
+	service, dialer, files := newTestService()
+	dialer.sessions["git"] = &fakeSession{}
+	store := service.store.(*fakeStore)
+	ctx := context.Background()
+
+	if err := service.AddServer(ctx, mcpports.ServerConfig{Name: "files", Transport: mcpports.TransportStdio, Command: "x"}); err == nil {
+		t.Fatalf("expected duplicate error")
+	}
+	if err := service.AddServer(ctx, mcpports.ServerConfig{Name: "git__tools", Transport: mcpports.TransportStdio, Command: "x"}); err == nil {
+		t.Fatalf("expected separator error")
+	}
+	if err := service.AddServer(ctx, mcpports.ServerConfig{Name: " git ", Transport: mcpports.TransportStdio, Command: "git-mcp"}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	servers, _ := service.ListServers(ctx)
+	if len(servers) != 3 || servers[2].Name != "git" || len(store.added) != 1 || store.added[0] != "git" {
+		t.Fatalf("unexpected servers %#v (store %#v)", servers, store.added)
+	}
+
+	if _, err := service.Tools(ctx); err != nil {
+		t.Fatalf("tools: %v", err)
+	}
+	if err := service.RemoveServer(ctx, "files"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if !files.closed || len(store.removed) != 1 {
+		t.Fatalf("expected removal to close the session and persist")
+	}
+	if err := service.RemoveServer(ctx, "files"); err == nil {
+		t.Fatalf("expected not found error")
+	}
+
+	files.closed = false
+	service.Reload([]mcpports.ServerConfig{{Name: "files", Transport: mcpports.TransportStdio, Command: "files-server"}})
+	if _, err := service.Tools(ctx); err != nil {
+		t.Fatalf("tools after reload: %v", err)
+	}
+	service.Reload([]mcpports.ServerConfig{{Name: "files", Transport: mcpports.TransportStdio, Command: "files-server", Args: []string{"--root", "/tmp"}}})
+	if !files.closed {
+		t.Fatalf("expected changed server session to be closed on reload")
+	}
+}
+
+// TestTestServerReportsTools validates a fresh connection is opened and closed.
+func TestTestServerReportsTools(t *testing.T) {
+
+	service, dialer, files := newTestService()
+
+	result, err := service.TestServer(context.Background(), "files")
+	if err != nil {
+		t.Fatalf("test server: %v", err)
+	}
+	if result.Server != "files" || result.Info.Name != "fake" || len(result.Tools) != 2 || !files.closed || dialer.dials["files"] != 1 {
+		t.Fatalf("unexpected test result %#v", result)
+	}
+	if _, err := service.TestServer(context.Background(), "missing"); err == nil {
+		t.Fatalf("expected not found error")
+	}
+}
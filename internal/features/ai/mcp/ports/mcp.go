@@ -0,0 +1,82 @@
+// mcp.go defines Model Context Protocol server management contracts and client session ports.
+// internal/features/ai/mcp/ports/mcp.go
+package ports
+
+import "context"
+
+// Server transports.
+const (
+	TransportStdio = "stdio"
+	TransportHTTP  = "http"
+)
+
+// ToolNameSeparator joins a server name and a tool name into the name shown to models.
+// Server names cannot contain it, so the first occurrence splits the two back apart.
+const ToolNameSeparator = "__"
+
+// MCPInterface defines MCP server management shared across transports.
+type MCPInterface interface {
+	ListServers(ctx context.Context) ([]ServerConfig, error)
+	AddServer(ctx context.Context, server ServerConfig) error
+	RemoveServer(ctx context.Context, name string) error
+	TestServer(ctx context.Context, name string) (TestResult, error)
+	Close() error
+}
+
+// ServerConfig describes how to reach one MCP server.
+type ServerConfig struct {
+	Name      string            `json:"name"`
+	Transport string            `json:"transport"`
+	Command   string            `json:"command,omitempty"`
+	Args      []string          `json:"args,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+	URL       string            `json:"url,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Disabled  bool              `json:"disabled,omitempty"`
+}
+
+// ServerInfo identifies a connected server as reported during initialization.
+type ServerInfo struct {
+	Name            string `json:"name,omitempty"`
+	Version         string `json:"version,omitempty"`
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
+}
+
+// ToolDescriptor is one tool as listed by a server.
+type ToolDescriptor struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	InputSchema map[string]interface{} `json:"inputSchema,omitempty"`
+}
+
+// ToolResult is the flattened output of a tool call; IsError marks failures reported by the tool itself.
+type ToolResult struct {
+	Content string `json:"content"`
+	IsError bool   `json:"isError,omitempty"`
+}
+
+// TestResult reports a successful connection and the tools the server offers.
+type TestResult struct {
+	Server string           `json:"server"`
+	Info   ServerInfo       `json:"info"`
+	Tools  []ToolDescriptor `json:"tools"`
+}
+
+// Session is an initialized connection to one MCP server.
+type Session interface {
+	Info() ServerInfo
+	ListTools(ctx context.Context) ([]ToolDescriptor, error)
+	CallTool(ctx context.Context, name string, arguments map[string]interface{}) (ToolResult, error)
+	Close() error
+}
+
+// Dialer connects to a server and completes the protocol handshake.
+type Dialer interface {
+	Dial(ctx context.Context, server ServerConfig) (Session, error)
+}
+
+// ServerStore persists server configuration added or removed at runtime.
+type ServerStore interface {
+	AddServer(server ServerConfig) error
+	RemoveServer(name string) error
+}
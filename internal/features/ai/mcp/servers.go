@@ -0,0 +1,83 @@
+// servers.go maps configured MCP servers to client configs and persists runtime changes to the config store.
+// internal/features/ai/mcp/servers.go
+package mcp
+
+import (
+	"fmt"
+
+	config "github.com/MadeByDoug/wls-chatbot/internal/core/config"
+	mcpports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/mcp/ports"
+)
+
+// ServersFromConfig converts the merged configuration's MCP servers into client server configs.
+func ServersFromConfig(cfg config.AppConfig) []mcpports.ServerConfig {
+
+	servers := make([]mcpports.ServerConfig, 0, len(cfg.MCPServers))
+	for _, server := range cfg.MCPServers {
+		servers = append(servers, mcpports.ServerConfig{
+			Name:      server.Name,
+			Transport: server.Transport,
+			Command:   server.Command,
+			Args:      append([]string(nil), server.Args...),
+			Env:       server.Env,
+			URL:       server.URL,
+			Headers:   server.Headers,
+			Disabled:  server.Disabled,
+		})
+	}
+	return servers
+}
+
+// ConfigServerStore persists MCP servers added or removed at runtime in the config store.
+// Servers declared in the config file are not stored there and must be edited in the file.
+type ConfigServerStore struct {
+	store config.Store
+}
+
+var _ mcpports.ServerStore = (*ConfigServerStore)(nil)
+
+// NewConfigServerStore creates a server store backed by the config store.
+func NewConfigServerStore(store config.Store) *ConfigServerStore {
+
+	return &ConfigServerStore{store: store}
+}
+
+// AddServer validates and saves a new server.
+func (s *ConfigServerStore) AddServer(server mcpports.ServerConfig) error {
+
+	stored, err := config.LoadConfig(s.store)
+	if err != nil {
+		return err
+	}
+	updated, err := config.AddMCPServer(stored, config.MCPServerConfig{
+		Name:      server.Name,
+		Transport: server.Transport,
+		Command:   server.Command,
+		Args:      server.Args,
+		Env:       server.Env,
+		URL:       server.URL,
+		Headers:   server.Headers,
+		Disabled:  server.Disabled,
+	})
+	if err != nil {
+		return err
+	}
+	return s.store.Save(updated)
+}
+
+// RemoveServer deletes a stored server.
+func (s *ConfigServerStore) RemoveServer(name string) error {
+
+	stored, err := config.LoadConfig(s.store)
+	if err != nil {
+		return err
+	}
+	if _, ok := stored.MCPServer(name); !ok {
+		return fmt.Errorf("MCP server %s is declared in the config file; remove it there", name)
+	}
+	updated, err := config.RemoveMCPServer(stored, name)
+	if err != nil {
+		return err
+	}
+	return s.store.Save(updated)
+}
@@ -0,0 +1,62 @@
+// servers_test.go verifies MCP servers are read from config and persisted to the config store.
+// internal/features/ai/mcp/servers_test.go
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	config "github.com/MadeByDoug/wls-chatbot/internal/core/config"
+	mcpports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/mcp/ports"
+)
+
+// memoryConfigStore keeps configuration in memory.
+type memoryConfigStore struct {
+	cfg config.AppConfig
+}
+
+// Load returns the stored configuration.
+func (s *memoryConfigStore) Load() (config.AppConfig, error) {
+
+	return s.cfg, nil
+}
+
+// Save replaces the stored configuration.
+func (s *memoryConfigStore) Save(cfg config.AppConfig) error {
+
+	s.cfg = cfg
+	return nil
+}
+
+// TestConfigServerStorePersistsChanges validates add, remove, and file-declared server handling.
+func TestConfigServerStorePersistsChanges(t *testing.T) {
+
+	store := &memoryConfigStore{cfg: config.AppConfig{Providers: []config.ProviderConfig{{Type: "openai", Name: "openai"}}}}
+	servers := NewConfigServerStore(store)
+
+	if err := servers.AddServer(mcpports.ServerConfig{Name: "files", Transport: mcpports.TransportStdio, Command: "files-mcp", Args: []string{"--root", "/tmp"}}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := servers.AddServer(mcpports.ServerConfig{Name: "web", Transport: mcpports.TransportHTTP, URL: "ftp://example.com"}); err == nil {
+		t.Fatalf("expected invalid url to fail")
+	}
+	stored, ok := store.cfg.MCPServer("files")
+	if !ok || stored.Command != "files-mcp" || len(stored.Args) != 2 || len(store.cfg.Providers) != 1 {
+		t.Fatalf("unexpected stored config %#v", store.cfg)
+	}
+
+	converted := ServersFromConfig(store.cfg)
+	if len(converted) != 1 || converted[0].Name != "files" || converted[0].Transport != mcpports.TransportStdio || converted[0].Args[1] != "/tmp" {
+		t.Fatalf("unexpected converted servers %#v", converted)
+	}
+
+	if err := servers.RemoveServer("from-file"); err == nil || !strings.Contains(err.Error(), "config file") {
+		t.Fatalf("expected config file hint, got %v", err)
+	}
+	if err := servers.RemoveServer("files"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if len(store.cfg.MCPServers) != 0 {
+		t.Fatalf("expected server removal to be saved, got %#v", store.cfg.MCPServers)
+	}
+}
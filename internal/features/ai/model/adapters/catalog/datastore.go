@@ -0,0 +1,157 @@
+// datastore.go reads model catalog records for the model service from the SQLite datastore.
+// internal/features/ai/model/adapters/catalog/datastore.go
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/MadeByDoug/wls-chatbot/internal/core/datastore"
+	modelfeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/model/app/model"
+)
+
+// DatastoreCatalog serves catalog records from the shared datastore.
+type DatastoreCatalog struct {
+	db *sql.DB
+}
+
+var _ modelfeature.ModelCatalogOperations = (*DatastoreCatalog)(nil)
+
+// NewDatastoreCatalog creates a datastore-backed model catalog adapter.
+func NewDatastoreCatalog(db *sql.DB) *DatastoreCatalog {
+
+	return &DatastoreCatalog{db: db}
+}
+
+// ListModelSummaries returns every catalog entry with its capabilities, cost tier, and failed verifications.
+func (c *DatastoreCatalog) ListModelSummaries(ctx context.Context) ([]modelfeature.ModelSummaryRecord, error) {
+
+	if c.db == nil {
+		return nil, fmt.Errorf("list model summaries: database required")
+	}
+
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT e.id, e.endpoint_id, e.model_id, COALESCE(e.display_name, ''), e.source, e.approved,
+			e.availability_state, COALESCE(e.metadata_json, ''), COALESCE(sp.cost_tier, ''),
+			COALESCE(c.supports_streaming, 0), COALESCE(c.supports_tool_calling, 0),
+			COALESCE(c.supports_structured_output, 0), COALESCE(c.supports_vision, 0)
+		FROM model_catalog_entries e
+		LEFT JOIN model_capabilities c ON c.model_catalog_entry_id = e.id
+		LEFT JOIN model_system_profile sp ON sp.model_catalog_entry_id = e.id
+		ORDER BY e.endpoint_id, e.model_id`)
+	if err != nil {
+		return nil, fmt.Errorf("list model summaries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var records []modelfeature.ModelSummaryRecord
+	for rows.Next() {
+		var record modelfeature.ModelSummaryRecord
+		if err := rows.Scan(
+			&record.ID,
+			&record.EndpointID,
+			&record.ModelID,
+			&record.DisplayName,
+			&record.Source,
+			&record.Approved,
+			&record.AvailabilityState,
+			&record.MetadataJSON,
+			&record.CostTier,
+			&record.SupportsStreaming,
+			&record.SupportsToolCalling,
+			&record.SupportsStructuredOutput,
+			&record.SupportsVision,
+		); err != nil {
+			return nil, fmt.Errorf("list model summaries: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list model summaries: %w", err)
+	}
+
+	inputModalities, err := c.listEntryValues(ctx, "model_capabilities_input_modalities", "modality")
+	if err != nil {
+		return nil, err
+	}
+	outputModalities, err := c.listEntryValues(ctx, "model_capabilities_output_modalities", "modality")
+	if err != nil {
+		return nil, err
+	}
+	brokenCapabilities, err := datastore.ListBrokenCapabilities(c.db)
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range records {
+		records[index].InputModalities = inputModalities[records[index].ID]
+		records[index].OutputModalities = outputModalities[records[index].ID]
+		records[index].BrokenCapabilities = brokenCapabilities[records[index].ID]
+	}
+	return records, nil
+}
+
+// ListModelSystemTags returns system tags keyed by catalog entry ID.
+func (c *DatastoreCatalog) ListModelSystemTags(ctx context.Context) (map[string][]string, error) {
+
+	if c.db == nil {
+		return nil, fmt.Errorf("list model system tags: database required")
+	}
+	return c.listEntryValues(ctx, "model_system_tags", "tag")
+}
+
+// ListEndpoints returns catalog endpoints with the name of the provider that owns them.
+func (c *DatastoreCatalog) ListEndpoints(ctx context.Context) ([]modelfeature.EndpointRecord, error) {
+
+	if c.db == nil {
+		return nil, fmt.Errorf("list endpoints: database required")
+	}
+
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT ep.id, p.name FROM catalog_endpoints ep
+		JOIN catalog_providers p ON p.id = ep.provider_id
+		ORDER BY ep.id`)
+	if err != nil {
+		return nil, fmt.Errorf("list endpoints: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var endpoints []modelfeature.EndpointRecord
+	for rows.Next() {
+		var endpoint modelfeature.EndpointRecord
+		if err := rows.Scan(&endpoint.ID, &endpoint.ProviderName); err != nil {
+			return nil, fmt.Errorf("list endpoints: %w", err)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list endpoints: %w", err)
+	}
+	return endpoints, nil
+}
+
+// listEntryValues reads a per-entry value table into a map keyed by catalog entry ID.
+// Table and column names are fixed by callers, never user input.
+func (c *DatastoreCatalog) listEntryValues(ctx context.Context, table, column string) (map[string][]string, error) {
+
+	rows, err := c.db.QueryContext(ctx, fmt.Sprintf(
+		"SELECT model_catalog_entry_id, %s FROM %s ORDER BY model_catalog_entry_id, %s", column, table, column))
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	values := make(map[string][]string)
+	for rows.Next() {
+		var entryID, value string
+		if err := rows.Scan(&entryID, &value); err != nil {
+			return nil, fmt.Errorf("list %s: %w", table, err)
+		}
+		values[entryID] = append(values[entryID], value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list %s: %w", table, err)
+	}
+	return values, nil
+}
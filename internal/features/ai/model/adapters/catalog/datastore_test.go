@@ -0,0 +1,86 @@
+// datastore_test.go verifies catalog records read from the datastore reach model summaries.
+// internal/features/ai/model/adapters/catalog/datastore_test.go
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/MadeByDoug/wls-chatbot/internal/core/datastore"
+	modelfeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/model/app/model"
+	modelports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/model/ports"
+)
+
+// TestListModelsReportsBrokenCapabilities verifies failed verifications appear in model summaries and hide the model from ExcludeBroken lists.
+func TestListModelsReportsBrokenCapabilities(t *testing.T) {
+
+	db, err := datastore.OpenSQLite(filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	entryID, providerName, modelID := firstCatalogEntry(t, db)
+	if _, err := datastore.RecordCapabilityVerifications(db, []datastore.CapabilityVerification{
+		{ProviderName: providerName, ModelID: modelID, Capability: "chat", Passed: true, Source: "modeltest", CheckedAt: time.Now()},
+		{ProviderName: providerName, ModelID: modelID, Capability: "streaming", Error: "HTTP 500", Source: "modeltest", CheckedAt: time.Now()},
+	}); err != nil {
+		t.Fatalf("record verifications: %v", err)
+	}
+
+	service := modelfeature.NewModelService(NewDatastoreCatalog(db), db, "test", nil, nil, nil)
+	summaries, err := service.ListModels(context.Background(), modelports.ModelListFilter{})
+	if err != nil {
+		t.Fatalf("list models: %v", err)
+	}
+
+	var found *modelports.ModelSummary
+	for index := range summaries {
+		if summaries[index].ID == entryID {
+			found = &summaries[index]
+		}
+	}
+	if found == nil {
+		t.Fatalf("expected entry %s in %d summaries", entryID, len(summaries))
+	}
+	if found.ProviderName != providerName || found.ModelID != modelID {
+		t.Fatalf("expected %s/%s, got %s/%s", providerName, modelID, found.ProviderName, found.ModelID)
+	}
+	if len(found.BrokenCapabilities) != 1 || found.BrokenCapabilities[0] != "streaming" {
+		t.Fatalf("expected streaming to be broken, got %v", found.BrokenCapabilities)
+	}
+
+	healthy, err := service.ListModels(context.Background(), modelports.ModelListFilter{ExcludeBroken: true})
+	if err != nil {
+		t.Fatalf("list healthy models: %v", err)
+	}
+	if len(healthy) != len(summaries)-1 {
+		t.Fatalf("expected ExcludeBroken to drop one of %d models, got %d", len(summaries), len(healthy))
+	}
+	for _, summary := range healthy {
+		if summary.ID == entryID {
+			t.Fatalf("expected broken entry %s to be excluded", entryID)
+		}
+	}
+}
+
+// firstCatalogEntry returns a seeded catalog entry with its provider name and model ID.
+func firstCatalogEntry(t *testing.T, db *sql.DB) (string, string, string) {
+
+	t.Helper()
+	var entryID, providerName, modelID string
+	err := db.QueryRow(`
+		SELECT e.id, p.name, e.model_id FROM model_catalog_entries e
+		JOIN catalog_endpoints ep ON ep.id = e.endpoint_id
+		JOIN catalog_providers p ON p.id = ep.provider_id
+		ORDER BY e.id LIMIT 1`).Scan(&entryID, &providerName, &modelID)
+	if err != nil {
+		t.Fatalf("select seeded entry: %v", err)
+	}
+	return entryID, providerName, modelID
+}
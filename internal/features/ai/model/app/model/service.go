@@ -14,6 +14,9 @@ import (
 	aiinterfaces "github.com/MadeByDoug/wls-chatbot/internal/features/ai/model/ports"
 )
 
+// availabilityStateBroken marks catalog entries that failed their last live verification.
+const availabilityStateBroken = "broken"
+
 // ModelCapabilitiesRecord defines catalog capability fields required by model service filters.
 type ModelCapabilitiesRecord struct {
 	SupportsStreaming        bool
@@ -36,6 +39,9 @@ type ModelSummaryRecord struct {
 	AvailabilityState string
 	MetadataJSON      string
 	CostTier          string
+
+	// BrokenCapabilities lists capabilities whose last live verification failed.
+	BrokenCapabilities []string
 }
 
 // EndpointRecord defines catalog endpoint fields required by model service operations.
@@ -110,6 +116,9 @@ func (s *ModelService) ListModels(ctx context.Context, filter aiinterfaces.Model
 		if !matchesSourceFilter(record.Source, filter.Source) {
 			continue
 		}
+		if filter.ExcludeBroken && isBroken(record) {
+			continue
+		}
 
 		profile := buildCapabilityProfile(record, systemTagsByEntryID[record.ID])
 		if !matchesModelFilter(profile, filter) {
@@ -117,15 +126,16 @@ func (s *ModelService) ListModels(ctx context.Context, filter aiinterfaces.Model
 		}
 
 		summaries = append(summaries, aiinterfaces.ModelSummary{
-			ID:                record.ID,
-			ModelID:           record.ModelID,
-			DisplayName:       firstNonEmpty(record.DisplayName, record.ModelID),
-			ProviderName:      providerByEndpointID[record.EndpointID],
-			Source:            record.Source,
-			Approved:          record.Approved,
-			AvailabilityState: record.AvailabilityState,
-			ContextWindow:     parseContextWindowFromMetadata(record.MetadataJSON),
-			CostTier:          record.CostTier,
+			ID:                 record.ID,
+			ModelID:            record.ModelID,
+			DisplayName:        firstNonEmpty(record.DisplayName, record.ModelID),
+			ProviderName:       providerByEndpointID[record.EndpointID],
+			Source:             record.Source,
+			Approved:           record.Approved,
+			AvailabilityState:  record.AvailabilityState,
+			BrokenCapabilities: uniqueNormalized(record.BrokenCapabilities),
+			ContextWindow:      parseContextWindowFromMetadata(record.MetadataJSON),
+			CostTier:           record.CostTier,
 			Capabilities: aiinterfaces.ModelCapabilities{
 				SupportsStreaming:        profile.SupportsStreaming,
 				SupportsToolCalling:      profile.SupportsToolCalling,
@@ -251,6 +261,8 @@ type modelCapabilityProfile struct {
 // semanticCapabilityBySystemTag maps provider/system tags to semantic capability identifiers.
 var semanticCapabilityBySystemTag = map[string][]string{
 	"image_edit":                []string{"vision.edit.image"},
+	"image_variation":           []string{"gen.image.variation"},
+	"image_upscale":             []string{"vision.upscale.image"},
 	"vision_segmentation_image": []string{"vision.segmentation.promptable_image"},
 	"speech_asr":                []string{"speech.asr"},
 	"speech_tts":                []string{"speech.tts"},
@@ -351,6 +363,12 @@ func containsAll(haystack []string, needles []string) bool {
 	return true
 }
 
+// isBroken reports whether a model failed its last live verification.
+func isBroken(record ModelSummaryRecord) bool {
+
+	return strings.EqualFold(strings.TrimSpace(record.AvailabilityState), availabilityStateBroken) || len(record.BrokenCapabilities) > 0
+}
+
 // matchesSourceFilter reports whether a source value matches the optional source filter.
 func matchesSourceFilter(source string, requested string) bool {
 
@@ -3,6 +3,7 @@
 package model
 
 import (
+	"context"
 	"testing"
 
 	aiinterfaces "github.com/MadeByDoug/wls-chatbot/internal/features/ai/model/ports"
@@ -69,3 +70,51 @@ func TestMatchesModelFilterByCapabilities(t *testing.T) {
 		t.Fatalf("expected model profile to fail mismatched capability filter")
 	}
 }
+
+// fakeModelCatalog serves fixed catalog records to the model service.
+type fakeModelCatalog struct {
+	records []ModelSummaryRecord
+}
+
+// ListModelSummaries returns the fixed records.
+func (c fakeModelCatalog) ListModelSummaries(context.Context) ([]ModelSummaryRecord, error) {
+
+	return c.records, nil
+}
+
+// ListModelSystemTags returns no tags.
+func (fakeModelCatalog) ListModelSystemTags(context.Context) (map[string][]string, error) {
+
+	return map[string][]string{}, nil
+}
+
+// ListEndpoints returns a single endpoint.
+func (fakeModelCatalog) ListEndpoints(context.Context) ([]EndpointRecord, error) {
+
+	return []EndpointRecord{{ID: "ep", ProviderName: "openai"}}, nil
+}
+
+// TestListModelsFlagsAndExcludesBrokenModels validates failed live verifications are surfaced or filtered.
+func TestListModelsFlagsAndExcludesBrokenModels(t *testing.T) {
+
+	service := NewModelService(fakeModelCatalog{records: []ModelSummaryRecord{
+		{ID: "a", EndpointID: "ep", ModelID: "good", AvailabilityState: "verified"},
+		{ID: "b", EndpointID: "ep", ModelID: "bad", AvailabilityState: "broken", BrokenCapabilities: []string{"streaming", "chat"}},
+	}}, nil, "", nil, nil, nil)
+
+	all, err := service.ListModels(context.Background(), aiinterfaces.ModelListFilter{})
+	if err != nil {
+		t.Fatalf("list models: %v", err)
+	}
+	if len(all) != 2 || len(all[1].BrokenCapabilities) != 2 || all[1].BrokenCapabilities[0] != "chat" {
+		t.Fatalf("expected broken model to be flagged with sorted capabilities, got %#v", all)
+	}
+
+	healthy, err := service.ListModels(context.Background(), aiinterfaces.ModelListFilter{ExcludeBroken: true})
+	if err != nil {
+		t.Fatalf("list models: %v", err)
+	}
+	if len(healthy) != 1 || healthy[0].ModelID != "good" {
+		t.Fatalf("expected broken model to be excluded, got %#v", healthy)
+	}
+}
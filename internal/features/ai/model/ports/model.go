@@ -73,6 +73,7 @@ type ModelListFilter struct {
 	RequiresToolCalling      *bool    `json:"requiresToolCalling,omitempty"`
 	RequiresStructuredOutput *bool    `json:"requiresStructuredOutput,omitempty"`
 	RequiresVision           *bool    `json:"requiresVision,omitempty"`
+	ExcludeBroken            bool     `json:"excludeBroken,omitempty"`
 }
 
 // ModelCapabilities contains model feature and semantic capability metadata.
@@ -89,16 +90,17 @@ type ModelCapabilities struct {
 
 // ModelSummary contains model listing fields used by adapters.
 type ModelSummary struct {
-	ID                string            `json:"id"`
-	ModelID           string            `json:"modelId"`
-	DisplayName       string            `json:"displayName"`
-	ProviderName      string            `json:"providerName"`
-	Source            string            `json:"source"`
-	Approved          bool              `json:"approved"`
-	AvailabilityState string            `json:"availabilityState"`
-	ContextWindow     int               `json:"contextWindow"`
-	CostTier          string            `json:"costTier"`
-	Capabilities      ModelCapabilities `json:"capabilities"`
+	ID                 string            `json:"id"`
+	ModelID            string            `json:"modelId"`
+	DisplayName        string            `json:"displayName"`
+	ProviderName       string            `json:"providerName"`
+	Source             string            `json:"source"`
+	Approved           bool              `json:"approved"`
+	AvailabilityState  string            `json:"availabilityState"`
+	BrokenCapabilities []string          `json:"brokenCapabilities,omitempty"`
+	ContextWindow      int               `json:"contextWindow"`
+	CostTier           string            `json:"costTier"`
+	Capabilities       ModelCapabilities `json:"capabilities"`
 }
 
 // ImportModelsRequest contains model import inputs.
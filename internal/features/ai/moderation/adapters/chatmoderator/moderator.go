@@ -0,0 +1,46 @@
+// moderator.go adapts moderation screening to the chat content moderator port.
+// internal/features/ai/moderation/adapters/chatmoderator/moderator.go
+package chatmoderator
+
+import (
+	"context"
+	"fmt"
+
+	chatports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/ports"
+	moderationports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/moderation/ports"
+)
+
+// Moderator serves chat screening from a moderation service.
+type Moderator struct {
+	moderation moderationports.ModerationInterface
+}
+
+var _ chatports.ContentModerator = (*Moderator)(nil)
+
+// New creates a chat content moderator backed by a moderation service.
+func New(moderation moderationports.ModerationInterface) *Moderator {
+
+	return &Moderator{moderation: moderation}
+}
+
+// Moderate screens one message text with the configured provider and model.
+func (m *Moderator) Moderate(ctx context.Context, providerName, model, text string) (chatports.ModerationVerdict, error) {
+
+	if m.moderation == nil {
+		return chatports.ModerationVerdict{}, fmt.Errorf("moderation service not configured")
+	}
+	result, err := m.moderation.Moderate(ctx, moderationports.ModerateRequest{
+		ProviderName: providerName,
+		ModelName:    model,
+		Inputs:       []string{text},
+	})
+	if err != nil {
+		return chatports.ModerationVerdict{}, err
+	}
+	verdict := result.Verdicts[0]
+	return chatports.ModerationVerdict{
+		Flagged:    verdict.Flagged,
+		Categories: verdict.Categories,
+		Model:      result.Model,
+	}, nil
+}
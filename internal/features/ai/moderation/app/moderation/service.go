@@ -0,0 +1,74 @@
+// service.go provides content safety screening backend operations.
+// internal/features/ai/moderation/app/moderation/service.go
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	moderationports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/moderation/ports"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// ModerationProviderOperations defines the screening operation required by the moderation backend service.
+type ModerationProviderOperations interface {
+	Moderate(ctx context.Context, name string, options providergateway.ModerationOptions) (*providergateway.ModerationResult, error)
+}
+
+// Service handles moderation operations for transport adapters.
+type Service struct {
+	providers ModerationProviderOperations
+}
+
+var _ moderationports.ModerationInterface = (*Service)(nil)
+
+// NewService creates a moderation backend service from provider dependencies.
+func NewService(providers ModerationProviderOperations) *Service {
+
+	return &Service{providers: providers}
+}
+
+// Moderate screens each input against the provider's safety categories.
+func (s *Service) Moderate(ctx context.Context, request moderationports.ModerateRequest) (moderationports.ModerateResult, error) {
+
+	if s.providers == nil {
+		return moderationports.ModerateResult{}, fmt.Errorf("backend service: providers not configured")
+	}
+	if len(request.Inputs) == 0 {
+		return moderationports.ModerateResult{}, fmt.Errorf("moderate: at least one input is required")
+	}
+	for index, input := range request.Inputs {
+		if strings.TrimSpace(input) == "" {
+			return moderationports.ModerateResult{}, fmt.Errorf("moderate: input %d is empty", index)
+		}
+	}
+
+	model := strings.TrimSpace(request.ModelName)
+	result, err := s.providers.Moderate(ctx, request.ProviderName, providergateway.ModerationOptions{
+		Model: model,
+		Input: request.Inputs,
+	})
+	if err != nil {
+		return moderationports.ModerateResult{}, err
+	}
+	if len(result.Verdicts) != len(request.Inputs) {
+		return moderationports.ModerateResult{}, fmt.Errorf("moderate: provider returned %d verdicts for %d inputs", len(result.Verdicts), len(request.Inputs))
+	}
+
+	output := moderationports.ModerateResult{
+		Model:    result.Model,
+		Verdicts: make([]moderationports.Verdict, 0, len(result.Verdicts)),
+	}
+	if output.Model == "" {
+		output.Model = model
+	}
+	for _, verdict := range result.Verdicts {
+		output.Verdicts = append(output.Verdicts, moderationports.Verdict{
+			Flagged:    verdict.Flagged,
+			Categories: verdict.Categories,
+			Scores:     verdict.Scores,
+		})
+	}
+	return output, nil
+}
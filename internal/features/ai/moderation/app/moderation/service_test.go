@@ -0,0 +1,80 @@
+// service_test.go verifies moderation request validation and result mapping.
+// internal/features/ai/moderation/app/moderation/service_test.go
+package moderation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	moderationports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/moderation/ports"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// recordingProviders captures the last moderation options and returns configured verdicts.
+type recordingProviders struct {
+	name     string
+	options  providergateway.ModerationOptions
+	verdicts []providergateway.ModerationVerdict
+}
+
+// Moderate records its inputs and returns the configured verdicts.
+func (p *recordingProviders) Moderate(_ context.Context, name string, options providergateway.ModerationOptions) (*providergateway.ModerationResult, error) {
+
+	p.name = name
+	p.options = options
+	return &providergateway.ModerationResult{Verdicts: p.verdicts}, nil
+}
+
+// TestModerateMapsVerdicts validates request forwarding, model fallback, and verdict mapping.
+func TestModerateMapsVerdicts(t *testing.T) {
+
+	providers := &recordingProviders{verdicts: []providergateway.ModerationVerdict{
+		{},
+		{Flagged: true, Categories: []string{"hate"}, Scores: map[string]float64{"hate": 0.91}},
+	}}
+	service := NewService(providers)
+
+	result, err := service.Moderate(context.Background(), moderationports.ModerateRequest{
+		ProviderName: "openai",
+		ModelName:    " omni-moderation-latest ",
+		Inputs:       []string{"hello", "something hateful"},
+	})
+	if err != nil {
+		t.Fatalf("moderate: %v", err)
+	}
+	if providers.name != "openai" || providers.options.Model != "omni-moderation-latest" || len(providers.options.Input) != 2 {
+		t.Fatalf("unexpected provider call %q %#v", providers.name, providers.options)
+	}
+	if result.Model != "omni-moderation-latest" || len(result.Verdicts) != 2 {
+		t.Fatalf("unexpected result %#v", result)
+	}
+	if result.Verdicts[0].Flagged || !result.Verdicts[1].Flagged || result.Verdicts[1].Categories[0] != "hate" || result.Verdicts[1].Scores["hate"] != 0.91 {
+		t.Fatalf("unexpected verdicts %#v", result.Verdicts)
+	}
+}
+
+// TestModerateRejectsInvalidRequests validates input checks and verdict count enforcement.
+func TestModerateRejectsInvalidRequests(t *testing.T) {
+
+	testCases := []struct {
+		name     string
+		inputs   []string
+		verdicts []providergateway.ModerationVerdict
+		want     string
+	}{
+		{name: "no inputs", want: "at least one input"},
+		{name: "blank input", inputs: []string{"ok", "  "}, want: "input 1 is empty"},
+		{name: "verdict mismatch", inputs: []string{"a", "b"}, verdicts: []providergateway.ModerationVerdict{{}}, want: "1 verdicts for 2 inputs"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			service := NewService(&recordingProviders{verdicts: testCase.verdicts})
+			_, err := service.Moderate(context.Background(), moderationports.ModerateRequest{ProviderName: "openai", Inputs: testCase.inputs})
+			if err == nil || !strings.Contains(err.Error(), testCase.want) {
+				t.Fatalf("expected %q error, got %v", testCase.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// moderation.go defines content safety screening transport contracts.
+// internal/features/ai/moderation/ports/moderation.go
+package ports
+
+import "context"
+
+// ModerationInterface defines content screening shared across transports.
+type ModerationInterface interface {
+	Moderate(ctx context.Context, request ModerateRequest) (ModerateResult, error)
+}
+
+// ModerateRequest contains the texts to screen with one provider.
+// ModelName selects the moderation model, or the judge chat model for providers without a moderation endpoint.
+type ModerateRequest struct {
+	ProviderName string   `json:"providerName"`
+	ModelName    string   `json:"modelName,omitempty"`
+	Inputs       []string `json:"inputs"`
+}
+
+// Verdict is the screening result for one input.
+type Verdict struct {
+	Flagged    bool               `json:"flagged"`
+	Categories []string           `json:"categories,omitempty"`
+	Scores     map[string]float64 `json:"scores,omitempty"`
+}
+
+// ModerateResult contains one verdict per input, in input order.
+type ModerateResult struct {
+	Model    string    `json:"model,omitempty"`
+	Verdicts []Verdict `json:"verdicts"`
+}
@@ -184,6 +184,9 @@ func (a *Anthropic) ListResources(ctx context.Context) ([]Model, error) {
 // Chat implements streaming chat completion.
 func (a *Anthropic) Chat(ctx context.Context, messages []ProviderMessage, opts ChatOptions) (<-chan Chunk, error) {
 
+	if err := providergateway.RejectToolTurns(messages); err != nil {
+		return nil, err
+	}
 	if strings.TrimSpace(opts.Model) == "" {
 		return nil, fmt.Errorf("model required")
 	}
@@ -0,0 +1,504 @@
+// provider.go implements the Azure OpenAI provider adapter over deployment-scoped endpoints.
+// internal/features/ai/providers/adapters/azureopenai/provider.go
+package azureopenai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	providerhttp "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/httpcompat"
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+type Model = providercore.Model
+type Config = providercore.ProviderConfig
+type ChatOptions = providergateway.ChatOptions
+type ProviderMessage = providergateway.ProviderMessage
+type Chunk = providergateway.Chunk
+type CredentialField = providercore.CredentialField
+type Provider = providercore.Provider
+type HTTPClient = providerhttp.Client
+
+const (
+	CredentialEndpoint    = providercore.CredentialEndpoint
+	CredentialAPIVersion  = providercore.CredentialAPIVersion
+	CredentialAPIKey      = providercore.CredentialAPIKey
+	CredentialToken       = providercore.CredentialToken
+	CredentialDeployments = providercore.CredentialDeployments
+)
+
+// DefaultAPIVersion is the GA data-plane API version used when none is configured.
+const DefaultAPIVersion = "2024-10-21"
+
+// legacyDeploymentsAPIVersion is the last API version that lists deployments on the data plane.
+const legacyDeploymentsAPIVersion = "2022-12-01"
+
+// AzureOpenAI implements the Provider interface for Azure OpenAI resources.
+type AzureOpenAI struct {
+	name        string
+	displayName string
+	endpoint    string
+	apiVersion  string
+	apiKey      string
+	token       string
+	deployments map[string]string
+	models      []Model
+	client      HTTPClient
+	// configErr keeps a credential error so connection tests and requests report it instead of misrouting.
+	configErr error
+}
+
+var _ Provider = (*AzureOpenAI)(nil)
+
+// New creates a new Azure OpenAI provider; the resource endpoint may come from the base URL or credentials.
+func New(config Config) *AzureOpenAI {
+
+	provider := &AzureOpenAI{
+		name:        config.Name,
+		displayName: config.DisplayName,
+		endpoint:    normalizeEndpoint(config.BaseURL),
+		apiVersion:  DefaultAPIVersion,
+		apiKey:      strings.TrimSpace(config.APIKey),
+		models:      config.Models,
+		client:      providerhttp.NewDefaultClient(),
+	}
+	if err := provider.Configure(Config{Credentials: config.Credentials}); err != nil {
+		if config.Logger != nil {
+			config.Logger.Warn("Invalid provider credentials", err, corelogger.LogField{Key: "provider", Value: config.Name})
+		}
+	}
+	return provider
+}
+
+// Name returns the provider identifier.
+func (a *AzureOpenAI) Name() string {
+
+	return a.name
+}
+
+// DisplayName returns the human-readable provider name.
+func (a *AzureOpenAI) DisplayName() string {
+
+	return a.displayName
+}
+
+// Models returns the available models.
+func (a *AzureOpenAI) Models() []Model {
+
+	return a.models
+}
+
+// CredentialFields returns the expected credential inputs.
+func (a *AzureOpenAI) CredentialFields() []CredentialField {
+
+	return []CredentialField{
+		{
+			Name:        CredentialEndpoint,
+			Label:       "Resource Endpoint",
+			Required:    true,
+			Secret:      false,
+			Placeholder: "https://my-resource.openai.azure.com",
+			Help:        "The endpoint shown under Keys and Endpoint for the Azure OpenAI resource.",
+		},
+		{
+			Name:        CredentialAPIVersion,
+			Label:       "API Version (optional)",
+			Required:    false,
+			Secret:      false,
+			Placeholder: DefaultAPIVersion,
+		},
+		{
+			Name:        CredentialAPIKey,
+			Label:       "API Key",
+			Required:    false,
+			Secret:      true,
+			Placeholder: "Azure OpenAI key",
+			Help:        "Sent in the api-key header. Provide this or a bearer token.",
+		},
+		{
+			Name:        CredentialToken,
+			Label:       "Bearer Token (optional)",
+			Required:    false,
+			Secret:      true,
+			Placeholder: "Microsoft Entra ID access token",
+			Help:        "Used instead of the API key when set. Entra ID tokens expire after about an hour and an exec: reference runs once per app run, so restart the app to fetch a new token.",
+		},
+		{
+			Name:        CredentialDeployments,
+			Label:       "Deployments",
+			Required:    false,
+			Secret:      false,
+			Placeholder: "gpt-4o=prod-gpt4o, dall-e-3=images",
+			Help:        "Model-to-deployment mapping as model=deployment pairs separated by commas.",
+		},
+	}
+}
+
+// Configure updates the provider configuration.
+func (a *AzureOpenAI) Configure(config Config) error {
+
+	if config.Credentials != nil {
+		if value, ok := config.Credentials[CredentialEndpoint]; ok && strings.TrimSpace(value) != "" {
+			a.endpoint = normalizeEndpoint(value)
+		}
+		if value, ok := config.Credentials[CredentialAPIVersion]; ok && strings.TrimSpace(value) != "" {
+			a.apiVersion = strings.TrimSpace(value)
+		}
+		if value, ok := config.Credentials[CredentialAPIKey]; ok {
+			a.apiKey = strings.TrimSpace(value)
+		}
+		if value, ok := config.Credentials[CredentialToken]; ok {
+			a.token = strings.TrimSpace(value)
+		}
+		if value, ok := config.Credentials[CredentialDeployments]; ok {
+			deployments, err := ParseDeployments(value)
+			if err != nil {
+				a.configErr = fmt.Errorf("azure openai provider %s: %w", a.name, err)
+				return err
+			}
+			a.deployments = deployments
+		}
+		a.configErr = nil
+	}
+	if strings.TrimSpace(config.APIKey) != "" {
+		a.apiKey = strings.TrimSpace(config.APIKey)
+	}
+	if config.BaseURL != "" {
+		a.endpoint = normalizeEndpoint(config.BaseURL)
+	}
+	if config.Models != nil {
+		a.models = config.Models
+	}
+	return nil
+}
+
+// ParseDeployments parses "model=deployment" pairs separated by commas or new lines.
+func ParseDeployments(raw string) (map[string]string, error) {
+
+	entries := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == '\n'
+	})
+	deployments := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		model, deployment, ok := strings.Cut(entry, "=")
+		model = strings.TrimSpace(model)
+		deployment = strings.TrimSpace(deployment)
+		if !ok || model == "" || deployment == "" {
+			return nil, fmt.Errorf("invalid deployment mapping %q: expected model=deployment", entry)
+		}
+		deployments[model] = deployment
+	}
+	if len(deployments) == 0 {
+		return nil, nil
+	}
+	return deployments, nil
+}
+
+// normalizeEndpoint trims whitespace, trailing slashes, and an /openai suffix.
+func normalizeEndpoint(raw string) string {
+
+	normalized := strings.TrimRight(strings.TrimSpace(raw), "/")
+	if strings.HasSuffix(strings.ToLower(normalized), "/openai") {
+		normalized = strings.TrimRight(normalized[:len(normalized)-len("/openai")], "/")
+	}
+	return normalized
+}
+
+// SetHTTPClient overrides the HTTP client used by the provider.
+func (a *AzureOpenAI) SetHTTPClient(client HTTPClient) {
+
+	if client != nil {
+		a.client = client
+	}
+}
+
+// httpClient returns the configured HTTP client or a default client.
+func (a *AzureOpenAI) httpClient() HTTPClient {
+
+	if a.client == nil {
+		a.client = providerhttp.NewDefaultClient()
+	}
+	return a.client
+}
+
+// TestConnection verifies the resource is reachable with the configured credentials.
+func (a *AzureOpenAI) TestConnection(ctx context.Context) error {
+
+	if a.configErr != nil {
+		return a.configErr
+	}
+	_, err := a.ListResources(ctx)
+	return err
+}
+
+// ListResources returns the mapped deployments, or the resource's deployments when no mapping is configured.
+func (a *AzureOpenAI) ListResources(ctx context.Context) ([]Model, error) {
+
+	if a.configErr != nil {
+		return nil, a.configErr
+	}
+	if len(a.deployments) == 0 {
+		return a.listDeployments(ctx)
+	}
+
+	// The models endpoint validates credentials and reports which base models can chat.
+	var catalog struct {
+		Data []struct {
+			ID           string `json:"id"`
+			Capabilities struct {
+				ChatCompletion bool `json:"chat_completion"`
+			} `json:"capabilities"`
+		} `json:"data"`
+	}
+	if err := a.getJSON(ctx, a.resourceURL("/openai/models", a.apiVersion), &catalog); err != nil {
+		return nil, err
+	}
+	chatModels := make(map[string]bool, len(catalog.Data))
+	for _, item := range catalog.Data {
+		chatModels[item.ID] = item.Capabilities.ChatCompletion
+	}
+
+	models := make([]Model, 0, len(a.deployments))
+	for model, deployment := range a.deployments {
+		models = append(models, Model{
+			ID:                model,
+			Name:              fmt.Sprintf("%s (%s)", model, deployment),
+			SupportsStreaming: chatModels[model],
+		})
+	}
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].ID < models[j].ID
+	})
+	return models, nil
+}
+
+// listDeployments reads deployments from the legacy data-plane listing, which newer resources may not serve.
+func (a *AzureOpenAI) listDeployments(ctx context.Context) ([]Model, error) {
+
+	var listing struct {
+		Data []struct {
+			ID    string `json:"id"`
+			Model string `json:"model"`
+		} `json:"data"`
+	}
+	if err := a.getJSON(ctx, a.resourceURL("/openai/deployments", legacyDeploymentsAPIVersion), &listing); err != nil {
+		var apiErr *providerhttp.APIError
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+			return nil, fmt.Errorf("azure openai: set %s as model=deployment pairs; this resource does not list deployments", CredentialDeployments)
+		}
+		return nil, err
+	}
+
+	models := make([]Model, 0, len(listing.Data))
+	for _, item := range listing.Data {
+		if item.ID == "" {
+			continue
+		}
+		models = append(models, Model{ID: item.ID, Name: fmt.Sprintf("%s (%s)", item.ID, item.Model), SupportsStreaming: true})
+	}
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].ID < models[j].ID
+	})
+	return models, nil
+}
+
+// Chat streams chat completions through the deployment that serves opts.Model.
+func (a *AzureOpenAI) Chat(ctx context.Context, messages []ProviderMessage, opts ChatOptions) (<-chan Chunk, error) {
+
+	deployment, err := a.deploymentFor(opts.Model)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := a.deploymentURL(deployment, "/chat/completions")
+	chunks, err := providerhttp.ChatOpenAICompatEndpoint(ctx, a.httpClient(), endpoint, a.authHeaders(), messages, opts)
+	if err != nil {
+		return nil, wrapAzureError(err)
+	}
+	return chunks, nil
+}
+
+// GenerateImage generates images through the deployment that serves opts.Model.
+func (a *AzureOpenAI) GenerateImage(ctx context.Context, opts providergateway.ImageGenerationOptions) (*providergateway.ImageResult, error) {
+
+	deployment, err := a.deploymentFor(opts.Model)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(opts.Prompt) == "" {
+		return nil, fmt.Errorf("prompt required")
+	}
+
+	payload := map[string]any{"prompt": opts.Prompt}
+	if opts.N > 0 {
+		payload["n"] = opts.N
+	}
+	for key, value := range map[string]string{
+		"size":            opts.Size,
+		"quality":         opts.Quality,
+		"style":           opts.Style,
+		"response_format": opts.ResponseFormat,
+		"user":            opts.User,
+	} {
+		if value != "" {
+			payload[key] = value
+		}
+	}
+
+	var result providergateway.ImageResult
+	if err := a.postJSON(ctx, a.deploymentURL(deployment, "/images/generations"), payload, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// EditImage returns not supported error.
+func (a *AzureOpenAI) EditImage(ctx context.Context, opts providergateway.ImageEditOptions) (*providergateway.ImageResult, error) {
+	return nil, fmt.Errorf("edit image not supported by this provider")
+}
+
+// deploymentFor maps a model to its deployment; unmapped names are treated as deployment names.
+// A deployment map that failed to parse fails every request rather than sending mapped models to the wrong deployment.
+func (a *AzureOpenAI) deploymentFor(model string) (string, error) {
+
+	if a.configErr != nil {
+		return "", a.configErr
+	}
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return "", fmt.Errorf("model required")
+	}
+	if deployment, ok := a.deployments[model]; ok {
+		return deployment, nil
+	}
+	return model, nil
+}
+
+// deploymentURL builds a deployment-scoped data-plane URL.
+func (a *AzureOpenAI) deploymentURL(deployment, operation string) string {
+
+	return a.resourceURL("/openai/deployments/"+url.PathEscape(deployment)+operation, a.apiVersion)
+}
+
+// resourceURL joins the endpoint with path and the api-version query parameter.
+func (a *AzureOpenAI) resourceURL(path, apiVersion string) string {
+
+	return a.endpoint + path + "?api-version=" + url.QueryEscape(apiVersion)
+}
+
+// authHeaders returns the bearer token header when set, otherwise the api-key header.
+func (a *AzureOpenAI) authHeaders() map[string]string {
+
+	if a.token != "" {
+		return map[string]string{"Authorization": "Bearer " + a.token}
+	}
+	if a.apiKey != "" {
+		return map[string]string{"api-key": a.apiKey}
+	}
+	return nil
+}
+
+// getJSON issues an authenticated GET and decodes the JSON response.
+func (a *AzureOpenAI) getJSON(ctx context.Context, endpoint string, target any) error {
+
+	return a.doJSON(ctx, http.MethodGet, endpoint, nil, target)
+}
+
+// postJSON issues an authenticated JSON POST and decodes the JSON response.
+func (a *AzureOpenAI) postJSON(ctx context.Context, endpoint string, payload any, target any) error {
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+	return a.doJSON(ctx, http.MethodPost, endpoint, bytes.NewReader(body), target)
+}
+
+// doJSON sends a request, mapping error statuses through wrapAzureError.
+func (a *AzureOpenAI) doJSON(ctx context.Context, method, endpoint string, body io.Reader, target any) error {
+
+	if a.endpoint == "" {
+		return fmt.Errorf("azure openai: resource endpoint required")
+	}
+	if a.token == "" && a.apiKey == "" {
+		return fmt.Errorf("azure openai: API key or bearer token required")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range a.authHeaders() {
+		request.Header.Set(name, value)
+	}
+
+	response, err := a.httpClient().Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		data, _ := io.ReadAll(io.LimitReader(response.Body, 64*1024))
+		return wrapAzureError(&providerhttp.APIError{Code: response.StatusCode, Message: string(data)})
+	}
+	if err := json.NewDecoder(response.Body).Decode(target); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// contentFilterCodes are Azure error codes raised when a prompt or output trips the content filter.
+var contentFilterCodes = map[string]struct{}{
+	"content_filter":                 {},
+	"contentfilter":                  {},
+	"content_policy_violation":       {},
+	"responsibleaipolicyviolation":   {},
+	"contentfilteredbyresponsibleai": {},
+}
+
+// wrapAzureError extracts the Azure error message and marks content-filter rejections with ErrorTypeContentFilter.
+func wrapAzureError(err error) error {
+
+	var apiErr *providerhttp.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	var envelope struct {
+		Error struct {
+			Code       string `json:"code"`
+			Message    string `json:"message"`
+			InnerError struct {
+				Code string `json:"code"`
+			} `json:"innererror"`
+		} `json:"error"`
+	}
+	if json.Unmarshal([]byte(apiErr.Message), &envelope) != nil || envelope.Error.Message == "" {
+		return err
+	}
+
+	wrapped := &providerhttp.APIError{Code: apiErr.Code, Type: apiErr.Type, Message: envelope.Error.Message}
+	for _, code := range []string{envelope.Error.Code, envelope.Error.InnerError.Code} {
+		if _, ok := contentFilterCodes[strings.ToLower(code)]; ok {
+			wrapped.Type = providerhttp.ErrorTypeContentFilter
+		}
+	}
+	return wrapped
+}
@@ -0,0 +1,227 @@
+// provider_test.go verifies Azure OpenAI deployment routing, auth headers, and content-filter errors.
+// internal/features/ai/providers/adapters/azureopenai/provider_test.go
+package azureopenai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	providerhttp "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/httpcompat"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+const contentFilterBody = `{"error":{"code":"content_filter","message":"The response was filtered due to the prompt triggering Azure OpenAI's content management policy.","innererror":{"code":"ResponsibleAIPolicyViolation"}}}`
+
+// fakeAzure serves deployment-scoped routes and records the last request.
+func fakeAzure(t *testing.T, last *http.Request) *httptest.Server {
+
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*last = *r.Clone(context.Background())
+		if r.URL.Query().Get("api-version") == "" {
+			t.Errorf("missing api-version on %s", r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/openai/models":
+			_, _ = w.Write([]byte(`{"data":[{"id":"gpt-4o","capabilities":{"chat_completion":true}},{"id":"dall-e-3","capabilities":{"chat_completion":false}}]}`))
+		case "/openai/deployments/prod-gpt4o/chat/completions":
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("data: {\"choices\":[{\"delta\":{\"content\":\"hi\"}}]}\n\ndata: [DONE]\n\n"))
+		case "/openai/deployments/blocked/chat/completions":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(contentFilterBody))
+		case "/openai/deployments/images/images/generations":
+			_, _ = w.Write([]byte(`{"created":1,"data":[{"url":"https://example.test/a.png","revised_prompt":"a cat"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":"DeploymentNotFound","message":"The API deployment for this resource does not exist."}}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestProvider builds an adapter for the fake resource with the given credentials.
+func newTestProvider(server *httptest.Server, credentials map[string]string) *AzureOpenAI {
+
+	values := map[string]string{
+		CredentialEndpoint:    server.URL + "/openai/",
+		CredentialDeployments: "gpt-4o=prod-gpt4o, dall-e-3=images, gpt-bad=blocked",
+	}
+	for key, value := range credentials {
+		values[key] = value
+	}
+	provider := New(Config{Name: "azure", DisplayName: "Azure OpenAI", Credentials: values})
+	provider.SetHTTPClient(server.Client())
+	return provider
+}
+
+// TestAzureRoutesThroughDeployments verifies chat, images, and listing use deployment URLs and the api-key header.
+func TestAzureRoutesThroughDeployments(t *testing.T) {
+
+	var last http.Request
+	provider := newTestProvider(fakeAzure(t, &last), map[string]string{CredentialAPIKey: "azure-key", CredentialAPIVersion: "2025-01-01-preview"})
+	ctx := context.Background()
+
+	models, err := provider.ListResources(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(models) != 3 || models[1].ID != "gpt-4o" || !models[1].SupportsStreaming || models[0].SupportsStreaming {
+		t.Fatalf("unexpected models %#v", models)
+	}
+	if last.Header.Get("api-key") != "azure-key" || last.URL.Query().Get("api-version") != "2025-01-01-preview" {
+		t.Fatalf("expected api-key header and configured version, got %v %s", last.Header, last.URL.RawQuery)
+	}
+
+	chunks, err := provider.Chat(ctx, []ProviderMessage{{Role: providergateway.RoleUser, Content: "hello"}}, ChatOptions{Model: "gpt-4o", Stream: true})
+	if err != nil {
+		t.Fatalf("chat: %v", err)
+	}
+	content := ""
+	for chunk := range chunks {
+		content += chunk.Content
+	}
+	if content != "hi" {
+		t.Fatalf("expected streamed content, got %q", content)
+	}
+
+	result, err := provider.GenerateImage(ctx, providergateway.ImageGenerationOptions{Model: "dall-e-3", Prompt: "a cat"})
+	if err != nil {
+		t.Fatalf("generate image: %v", err)
+	}
+	if len(result.Data) != 1 || result.Data[0].RevisedPrompt != "a cat" {
+		t.Fatalf("unexpected image result %#v", result)
+	}
+
+	_, err = provider.Chat(ctx, []ProviderMessage{{Role: providergateway.RoleUser, Content: "hello"}}, ChatOptions{Model: "unmapped", Stream: true})
+	var apiErr *providerhttp.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound || !strings.Contains(apiErr.Message, "does not exist") {
+		t.Fatalf("expected unmapped model to route as a deployment name and fail with its message, got %v", err)
+	}
+}
+
+// TestAzureBearerTokenAndContentFilter verifies bearer auth takes precedence and content-filter errors are typed.
+func TestAzureBearerTokenAndContentFilter(t *testing.T) {
+
+	var last http.Request
+	provider := newTestProvider(fakeAzure(t, &last), map[string]string{CredentialAPIKey: "azure-key", CredentialToken: "entra-token"})
+
+	_, err := provider.Chat(context.Background(), []ProviderMessage{{Role: providergateway.RoleUser, Content: "bad"}}, ChatOptions{Model: "gpt-bad", Stream: true})
+	if !providerhttp.IsContentFilterError(err) {
+		t.Fatalf("expected content filter error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "(content_filter)") || !strings.Contains(err.Error(), "content management policy") {
+		t.Fatalf("unexpected error text %q", err.Error())
+	}
+	if last.Header.Get("Authorization") != "Bearer entra-token" || last.Header.Get("api-key") != "" {
+		t.Fatalf("expected bearer auth only, got %v", last.Header)
+	}
+}
+
+// TestNewReportsInvalidDeployments verifies a malformed deployment map is logged and fails connection tests and requests.
+func TestNewReportsInvalidDeployments(t *testing.T) {
+
+	var last http.Request
+	server := fakeAzure(t, &last)
+	logger := &warnRecorder{}
+	provider := New(Config{
+		Name:        "azure",
+		Credentials: map[string]string{CredentialEndpoint: server.URL, CredentialAPIKey: "key", CredentialDeployments: "gpt-4o"},
+		Logger:      logger,
+	})
+	provider.SetHTTPClient(server.Client())
+
+	if len(logger.warnings) != 1 || !strings.Contains(logger.warnings[0], "invalid deployment mapping") {
+		t.Fatalf("expected one invalid mapping warning, got %v", logger.warnings)
+	}
+	if err := provider.TestConnection(context.Background()); err == nil || !strings.Contains(err.Error(), "invalid deployment mapping") {
+		t.Fatalf("expected invalid mapping error, got %v", err)
+	}
+	messages := []ProviderMessage{{Role: providergateway.RoleUser, Content: "hi"}}
+	if _, err := provider.Chat(context.Background(), messages, ChatOptions{Model: "gpt-4o", Stream: true}); err == nil || !strings.Contains(err.Error(), "invalid deployment mapping") {
+		t.Fatalf("expected chat to fail with the mapping error, got %v", err)
+	}
+	if last.URL != nil {
+		t.Fatalf("expected no request with invalid configuration, got %s", last.URL.Path)
+	}
+
+	if err := provider.Configure(Config{Credentials: map[string]string{CredentialDeployments: "gpt-4o=prod-gpt4o"}}); err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	if err := provider.TestConnection(context.Background()); err != nil {
+		t.Fatalf("expected corrected configuration to connect, got %v", err)
+	}
+}
+
+// TestParseDeployments verifies mapping parsing and rejection of malformed entries.
+func TestParseDeployments(t *testing.T) {
+
+	testCases := []struct {
+		name    string
+		raw     string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "empty", raw: "", want: nil},
+		{name: "pairs", raw: "gpt-4o=prod, gpt-4o-mini = mini\n", want: map[string]string{"gpt-4o": "prod", "gpt-4o-mini": "mini"}},
+		{name: "missing deployment", raw: "gpt-4o=", wantErr: true},
+		{name: "missing separator", raw: "gpt-4o", wantErr: true},
+	}
+	for _, testCase := range testCases {
+		got, err := ParseDeployments(testCase.raw)
+		if testCase.wantErr {
+			if err == nil {
+				t.Fatalf("%s: expected error", testCase.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", testCase.name, err)
+		}
+		if len(got) != len(testCase.want) {
+			t.Fatalf("%s: expected %v, got %v", testCase.name, testCase.want, got)
+		}
+		for model, deployment := range testCase.want {
+			if got[model] != deployment {
+				t.Fatalf("%s: expected %s=%s, got %q", testCase.name, model, deployment, got[model])
+			}
+		}
+	}
+}
+
+// warnRecorder captures warning errors logged by the provider.
+type warnRecorder struct {
+	warnings []string
+}
+
+// Trace ignores trace messages.
+func (w *warnRecorder) Trace(string, ...corelogger.LogField) {
+
+}
+
+// Debug ignores debug messages.
+func (w *warnRecorder) Debug(string, ...corelogger.LogField) {
+
+}
+
+// Info ignores info messages.
+func (w *warnRecorder) Info(string, ...corelogger.LogField) {
+
+}
+
+// Warn records the warning error.
+func (w *warnRecorder) Warn(_ string, err error, _ ...corelogger.LogField) {
+
+	w.warnings = append(w.warnings, err.Error())
+}
+
+// Error ignores error messages.
+func (w *warnRecorder) Error(string, error, ...corelogger.LogField) {
+
+}
@@ -0,0 +1,175 @@
+// audio.go implements Workers AI speech-to-text and text-to-speech for the Cloudflare adapter.
+// internal/features/ai/providers/adapters/cloudflare/audio.go
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	providerhttp "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/httpcompat"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+const (
+	defaultTranscriptionModel = "@cf/openai/whisper-large-v3-turbo"
+	defaultSpeechModel        = "@cf/myshell-ai/melotts"
+	defaultSpeechLanguage     = "en"
+)
+
+var _ providergateway.Transcriber = (*Cloudflare)(nil)
+var _ providergateway.Synthesizer = (*Cloudflare)(nil)
+
+// Transcribe converts recorded speech to text with a Workers AI Whisper model.
+func (c *Cloudflare) Transcribe(ctx context.Context, opts providergateway.TranscriptionOptions) (*providergateway.TranscriptionResult, error) {
+
+	if len(opts.Audio) == 0 {
+		return nil, fmt.Errorf("audio input required")
+	}
+	model := resolveModelName(opts.Model)
+	if model == "" {
+		model = defaultTranscriptionModel
+	}
+
+	// whisper-large-v3-turbo takes base64 audio; the original whisper models take a byte array.
+	input := map[string]interface{}{}
+	if strings.Contains(model, "large-v3-turbo") {
+		input["audio"] = base64.StdEncoding.EncodeToString(opts.Audio)
+		if opts.Language != "" {
+			input["language"] = opts.Language
+		}
+		if opts.Prompt != "" {
+			input["initial_prompt"] = opts.Prompt
+		}
+	} else {
+		samples := make([]int, len(opts.Audio))
+		for i, value := range opts.Audio {
+			samples[i] = int(value)
+		}
+		input["audio"] = samples
+	}
+
+	var output struct {
+		Text              string `json:"text"`
+		TranscriptionInfo struct {
+			Language string  `json:"language"`
+			Duration float64 `json:"duration"`
+		} `json:"transcription_info"`
+	}
+	if err := c.runJSONModel(ctx, model, input, &output); err != nil {
+		return nil, err
+	}
+
+	language := output.TranscriptionInfo.Language
+	if language == "" {
+		language = opts.Language
+	}
+	return &providergateway.TranscriptionResult{
+		Model:    model,
+		Text:     strings.TrimSpace(output.Text),
+		Language: language,
+		Duration: output.TranscriptionInfo.Duration,
+	}, nil
+}
+
+// Synthesize converts text to speech with the Workers AI MeloTTS model, which returns MP3 audio.
+func (c *Cloudflare) Synthesize(ctx context.Context, opts providergateway.SpeechOptions) (*providergateway.SpeechResult, error) {
+
+	if strings.TrimSpace(opts.Input) == "" {
+		return nil, fmt.Errorf("speech input required")
+	}
+	format := strings.ToLower(strings.TrimSpace(opts.Format))
+	if format != "" && format != "mp3" {
+		return nil, fmt.Errorf("workers AI speech supports mp3 output, not %s", format)
+	}
+	model := resolveModelName(opts.Model)
+	if model == "" {
+		model = defaultSpeechModel
+	}
+
+	// MeloTTS selects its speaker by language, so a voice option names the language.
+	language := strings.TrimSpace(opts.Voice)
+	if language == "" {
+		language = defaultSpeechLanguage
+	}
+	var output struct {
+		Audio string `json:"audio"`
+	}
+	if err := c.runJSONModel(ctx, model, map[string]interface{}{"prompt": opts.Input, "lang": language}, &output); err != nil {
+		return nil, err
+	}
+	audio, err := base64.StdEncoding.DecodeString(output.Audio)
+	if err != nil {
+		return nil, fmt.Errorf("decode speech audio: %w", err)
+	}
+	if len(audio) == 0 {
+		return nil, fmt.Errorf("no audio in speech response")
+	}
+
+	return &providergateway.SpeechResult{
+		Model:    model,
+		Audio:    audio,
+		Format:   "mp3",
+		MIMEType: providergateway.AudioMIMEType("mp3"),
+	}, nil
+}
+
+// runJSONModel posts a JSON Workers AI request and decodes the result field of the response envelope.
+func (c *Cloudflare) runJSONModel(ctx context.Context, model string, input interface{}, result interface{}) error {
+
+	if c.accountID == "" || c.cloudflareToken == "" {
+		return fmt.Errorf("account ID and Cloudflare token required")
+	}
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+	endpoint := fmt.Sprintf("%s/accounts/%s/ai/run/%s", strings.TrimSuffix(c.apiBaseURL, "/"), c.accountID, model)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+c.cloudflareToken)
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	var envelope struct {
+		Success bool `json:"success"`
+		Errors  []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		if response.StatusCode >= http.StatusBadRequest {
+			return &providerhttp.APIError{Code: response.StatusCode, Message: strings.TrimSpace(string(body))}
+		}
+		return fmt.Errorf("parse response: %w", err)
+	}
+	if response.StatusCode >= http.StatusBadRequest || !envelope.Success {
+		message := "workers AI request failed"
+		if len(envelope.Errors) > 0 {
+			message = envelope.Errors[0].Message
+		}
+		return &providerhttp.APIError{Code: response.StatusCode, Message: message}
+	}
+	if err := json.Unmarshal(envelope.Result, result); err != nil {
+		return fmt.Errorf("parse result: %w", err)
+	}
+	return nil
+}
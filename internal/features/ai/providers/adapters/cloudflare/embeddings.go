@@ -0,0 +1,97 @@
+// embeddings.go implements Workers AI text embeddings for the Cloudflare adapter.
+// internal/features/ai/providers/adapters/cloudflare/embeddings.go
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	providerhttp "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/httpcompat"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+const (
+	defaultEmbeddingModel = "@cf/baai/bge-base-en-v1.5"
+	// embeddingBatchLimit is the maximum number of texts Workers AI embedding models accept per run.
+	embeddingBatchLimit = 100
+)
+
+// Embed creates text embeddings with a Workers AI embedding model.
+func (c *Cloudflare) Embed(ctx context.Context, opts providergateway.EmbeddingOptions) (*providergateway.EmbeddingResult, error) {
+
+	if c.accountID == "" || c.cloudflareToken == "" {
+		return nil, fmt.Errorf("account ID and Cloudflare token required")
+	}
+	if len(opts.Input) == 0 {
+		return nil, fmt.Errorf("embedding input required")
+	}
+	model := resolveModelName(opts.Model)
+	if model == "" {
+		model = defaultEmbeddingModel
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{"text": opts.Input})
+	if err != nil {
+		return nil, fmt.Errorf("marshal embedding request: %w", err)
+	}
+	endpoint := fmt.Sprintf("%s/accounts/%s/ai/run/%s", strings.TrimSuffix(c.apiBaseURL, "/"), c.accountID, model)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+c.cloudflareToken)
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	var envelope struct {
+		Success bool `json:"success"`
+		Errors  []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+		Result struct {
+			Data [][]float32 `json:"data"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		if response.StatusCode >= http.StatusBadRequest {
+			return nil, &providerhttp.APIError{Code: response.StatusCode, Message: strings.TrimSpace(string(body))}
+		}
+		return nil, fmt.Errorf("parse embedding response: %w", err)
+	}
+	if response.StatusCode >= http.StatusBadRequest || !envelope.Success {
+		message := "embedding request failed"
+		if len(envelope.Errors) > 0 {
+			message = envelope.Errors[0].Message
+		}
+		return nil, &providerhttp.APIError{Code: response.StatusCode, Message: message}
+	}
+	if len(envelope.Result.Data) != len(opts.Input) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(opts.Input), len(envelope.Result.Data))
+	}
+
+	return &providergateway.EmbeddingResult{
+		Model:      model,
+		Embeddings: envelope.Result.Data,
+	}, nil
+}
+
+// MaxEmbeddingBatch returns the Workers AI per-run text limit.
+func (c *Cloudflare) MaxEmbeddingBatch() int {
+
+	return embeddingBatchLimit
+}
@@ -0,0 +1,110 @@
+// moderation.go implements Llama Guard content moderation for the Cloudflare adapter.
+// internal/features/ai/providers/adapters/cloudflare/moderation.go
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+const defaultModerationModel = "@cf/meta/llama-guard-3-8b"
+
+var _ providergateway.Moderator = (*Cloudflare)(nil)
+
+// llamaGuardCategories names the Llama Guard 3 hazard codes.
+var llamaGuardCategories = map[string]string{
+	"S1":  "violent-crimes",
+	"S2":  "non-violent-crimes",
+	"S3":  "sex-related-crimes",
+	"S4":  "child-sexual-exploitation",
+	"S5":  "defamation",
+	"S6":  "specialized-advice",
+	"S7":  "privacy",
+	"S8":  "intellectual-property",
+	"S9":  "indiscriminate-weapons",
+	"S10": "hate",
+	"S11": "suicide-self-harm",
+	"S12": "sexual-content",
+	"S13": "elections",
+	"S14": "code-interpreter-abuse",
+}
+
+// Moderate classifies each input with a Workers AI Llama Guard model, one run per input.
+func (c *Cloudflare) Moderate(ctx context.Context, opts providergateway.ModerationOptions) (*providergateway.ModerationResult, error) {
+
+	if len(opts.Input) == 0 {
+		return nil, fmt.Errorf("moderation input required")
+	}
+	model := resolveModelName(opts.Model)
+	if model == "" {
+		model = defaultModerationModel
+	}
+
+	result := &providergateway.ModerationResult{Model: model, Verdicts: make([]providergateway.ModerationVerdict, 0, len(opts.Input))}
+	for index, text := range opts.Input {
+		var output struct {
+			Response json.RawMessage `json:"response"`
+		}
+		input := map[string]interface{}{
+			"messages": []map[string]string{{"role": "user", "content": text}},
+		}
+		if err := c.runJSONModel(ctx, model, input, &output); err != nil {
+			return nil, fmt.Errorf("moderate input %d: %w", index, err)
+		}
+		verdict, err := parseLlamaGuardResponse(output.Response)
+		if err != nil {
+			return nil, fmt.Errorf("moderate input %d: %w", index, err)
+		}
+		result.Verdicts = append(result.Verdicts, verdict)
+	}
+	return result, nil
+}
+
+// parseLlamaGuardResponse accepts the structured {"safe", "categories"} form and the raw "unsafe\nS1,S2" text form.
+func parseLlamaGuardResponse(raw json.RawMessage) (providergateway.ModerationVerdict, error) {
+
+	var codes []string
+	var structured struct {
+		Safe       *bool    `json:"safe"`
+		Categories []string `json:"categories"`
+	}
+	var text string
+	switch {
+	case json.Unmarshal(raw, &structured) == nil && structured.Safe != nil:
+		if *structured.Safe {
+			return providergateway.ModerationVerdict{}, nil
+		}
+		codes = structured.Categories
+	case json.Unmarshal(raw, &text) == nil:
+		lines := strings.Split(strings.TrimSpace(text), "\n")
+		switch strings.ToLower(strings.TrimSpace(lines[0])) {
+		case "safe":
+			return providergateway.ModerationVerdict{}, nil
+		case "unsafe":
+		default:
+			return providergateway.ModerationVerdict{}, fmt.Errorf("unexpected llama guard response %q", text)
+		}
+		if len(lines) > 1 {
+			codes = strings.Split(lines[1], ",")
+		}
+	default:
+		return providergateway.ModerationVerdict{}, fmt.Errorf("unexpected llama guard response %s", string(raw))
+	}
+
+	verdict := providergateway.ModerationVerdict{Flagged: true}
+	for _, code := range codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if name, ok := llamaGuardCategories[code]; ok {
+			verdict.Categories = append(verdict.Categories, name)
+		} else if code != "" {
+			verdict.Categories = append(verdict.Categories, strings.ToLower(code))
+		}
+	}
+	sort.Strings(verdict.Categories)
+	return verdict, nil
+}
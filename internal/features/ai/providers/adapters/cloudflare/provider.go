@@ -3,9 +3,14 @@
 package cloudflare
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
 	"sort"
 	"strings"
 
@@ -36,11 +41,20 @@ const (
 	CredentialToken           = providercore.CredentialToken
 )
 
+const (
+	workersAIBaseURL         = "https://api.cloudflare.com/client/v4"
+	defaultVariationModel    = "@cf/runwayml/stable-diffusion-v1-5-img2img"
+	defaultVariationPrompt   = "a variation of the input image"
+	defaultVariationSteps    = 20
+	defaultVariationStrength = 0.6
+)
+
 // Cloudflare implements the Provider interface for Cloudflare AI Gateway.
 type Cloudflare struct {
 	name            string
 	displayName     string
 	baseURL         string
+	apiBaseURL      string
 	accountID       string
 	cloudflareToken string
 	logger          Logger
@@ -49,6 +63,9 @@ type Cloudflare struct {
 }
 
 var _ Provider = (*Cloudflare)(nil)
+var _ providergateway.CapabilityAdvertiser = (*Cloudflare)(nil)
+var _ providergateway.ImageVariationProvider = (*Cloudflare)(nil)
+var _ providergateway.Embedder = (*Cloudflare)(nil)
 
 // New creates a new Cloudflare provider.
 func New(config Config) *Cloudflare {
@@ -57,6 +74,7 @@ func New(config Config) *Cloudflare {
 		name:        config.Name,
 		displayName: config.DisplayName,
 		baseURL:     config.BaseURL,
+		apiBaseURL:  workersAIBaseURL,
 		models:      config.Models,
 		client:      providerhttp.NewDefaultClient(),
 	}
@@ -282,6 +300,9 @@ func (c *Cloudflare) ListResources(ctx context.Context) ([]Model, error) {
 // Chat implements streaming chat completion.
 func (c *Cloudflare) Chat(ctx context.Context, messages []ProviderMessage, opts ChatOptions) (<-chan Chunk, error) {
 
+	if err := providergateway.RejectToolTurns(messages); err != nil {
+		return nil, err
+	}
 	// Strict SDK / Workers AI logic
 	if c.accountID == "" {
 		return nil, fmt.Errorf("account ID required")
@@ -368,3 +389,156 @@ func (c *Cloudflare) GenerateImage(ctx context.Context, opts providergateway.Ima
 func (c *Cloudflare) EditImage(ctx context.Context, opts providergateway.ImageEditOptions) (*providergateway.ImageResult, error) {
 	return nil, fmt.Errorf("edit image not supported by this provider")
 }
+
+// CreateImageVariation creates image variations with a Workers AI image-to-image model.
+// The run endpoint returns raw PNG bytes, so the request bypasses the SDK's JSON-only Raw helper.
+func (c *Cloudflare) CreateImageVariation(ctx context.Context, opts providergateway.ImageVariationOptions) (*providergateway.ImageResult, error) {
+
+	if c.accountID == "" || c.cloudflareToken == "" {
+		return nil, fmt.Errorf("account ID and Cloudflare token required")
+	}
+
+	source, err := os.ReadFile(opts.Image)
+	if err != nil {
+		return nil, fmt.Errorf("read image: %w", err)
+	}
+	pixels := make([]int, len(source))
+	for index, value := range source {
+		pixels[index] = int(value)
+	}
+
+	model := resolveModelName(opts.Model)
+	if model == "" {
+		model = defaultVariationModel
+	}
+	prompt := strings.TrimSpace(opts.Prompt)
+	if prompt == "" {
+		prompt = defaultVariationPrompt
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"prompt":    prompt,
+		"image":     pixels,
+		"strength":  defaultVariationStrength,
+		"num_steps": defaultVariationSteps,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal variation request: %w", err)
+	}
+
+	count := opts.N
+	if count <= 0 {
+		count = 1
+	}
+	endpoint := fmt.Sprintf("%s/accounts/%s/ai/run/%s", strings.TrimSuffix(c.apiBaseURL, "/"), c.accountID, model)
+	result := &providergateway.ImageResult{Data: make([]providergateway.ImageData, 0, count)}
+	for index := 0; index < count; index++ {
+		image, err := c.runBinaryModel(ctx, endpoint, payload)
+		if err != nil {
+			return nil, err
+		}
+		result.Data = append(result.Data, providergateway.ImageData{
+			B64JSON: base64.StdEncoding.EncodeToString(image),
+		})
+	}
+
+	return result, nil
+}
+
+// GatewayCapabilities describes the semantic capabilities served by the Cloudflare adapter.
+func (c *Cloudflare) GatewayCapabilities() []providergateway.CapabilityDescriptor {
+
+	return []providergateway.CapabilityDescriptor{
+		{
+			ID:          providergateway.CapabilityChatText,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputText},
+			Interaction: providergateway.InteractionSingle,
+		},
+		{
+			ID:          providergateway.CapabilityGenerateImageVariation,
+			Inputs:      []providergateway.InputType{providergateway.InputImage, providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputImage},
+			Interaction: providergateway.InteractionSingle,
+			Controls: []providergateway.ControlDescriptor{
+				{Name: "prompt", Type: "string", Description: "Optional guidance for the image-to-image model."},
+			},
+		},
+		{
+			ID:          providergateway.CapabilityRetrievalEmbedText,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputEmbedding},
+			Interaction: providergateway.InteractionBatch,
+		},
+		{
+			ID:          providergateway.CapabilitySpeechASR,
+			Inputs:      []providergateway.InputType{providergateway.InputAudio},
+			Outputs:     []providergateway.OutputType{providergateway.OutputText},
+			Interaction: providergateway.InteractionSingle,
+			Controls: []providergateway.ControlDescriptor{
+				{Name: "language", Type: "string", Description: "Language hint (whisper-large-v3-turbo only)."},
+			},
+		},
+		{
+			ID:          providergateway.CapabilitySpeechTTS,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputAudio},
+			Interaction: providergateway.InteractionSingle,
+			Controls: []providergateway.ControlDescriptor{
+				{Name: "voice", Type: "string", Description: "MeloTTS language code such as en, es, fr, ja, or zh."},
+			},
+		},
+		{
+			ID:          providergateway.CapabilitySafetyModeration,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputSafetyLabels},
+			Interaction: providergateway.InteractionSingle,
+		},
+		{
+			ID:          providergateway.CapabilityRankRerank,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputRankingScores},
+			Interaction: providergateway.InteractionBatch,
+		},
+	}
+}
+
+// runBinaryModel posts a Workers AI request whose successful response body is binary output.
+func (c *Cloudflare) runBinaryModel(ctx context.Context, endpoint string, payload []byte) ([]byte, error) {
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+c.cloudflareToken)
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	if response.StatusCode >= http.StatusBadRequest {
+		var failure struct {
+			Errors []struct {
+				Message string `json:"message"`
+			} `json:"errors"`
+		}
+		message := strings.TrimSpace(string(body))
+		if err := json.Unmarshal(body, &failure); err == nil && len(failure.Errors) > 0 {
+			message = failure.Errors[0].Message
+		}
+		return nil, &providerhttp.APIError{Code: response.StatusCode, Message: message}
+	}
+	if strings.HasPrefix(response.Header.Get("Content-Type"), "application/json") {
+		return nil, fmt.Errorf("unexpected JSON response from image model")
+	}
+
+	return body, nil
+}
@@ -4,10 +4,13 @@ package cloudflare
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
@@ -178,3 +181,258 @@ func TestCloudflareListResourcesOmitsAuthHeader(t *testing.T) {
 		t.Fatalf("unexpected models: %+v", models)
 	}
 }
+
+// TestCloudflareCreateImageVariationReturnsBinaryImages verifies img2img runs use the injected HTTP client.
+func TestCloudflareCreateImageVariationReturnsBinaryImages(t *testing.T) {
+
+	var gotAuth string
+	var gotPath string
+	var gotImageLength int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotPath = r.URL.Path
+		var payload struct {
+			Prompt string `json:"prompt"`
+			Image  []int  `json:"image"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		gotImageLength = len(payload.Image)
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("png-bytes"))
+	}))
+	defer server.Close()
+
+	imagePath := filepath.Join(t.TempDir(), "source.png")
+	if err := os.WriteFile(imagePath, []byte{1, 2, 3, 4}, 0o600); err != nil {
+		t.Fatalf("write image: %v", err)
+	}
+
+	provider := New(Config{
+		Name: "cloudflare",
+		Credentials: ProviderCredentials{
+			CredentialAccountID:       "account",
+			CredentialCloudflareToken: "cf-token",
+		},
+	})
+	provider.apiBaseURL = server.URL
+	provider.SetHTTPClient(server.Client())
+
+	result, err := provider.CreateImageVariation(context.Background(), providergateway.ImageVariationOptions{
+		Image: imagePath,
+		N:     2,
+	})
+	if err != nil {
+		t.Fatalf("create variation: %v", err)
+	}
+
+	if gotAuth != "Bearer cf-token" {
+		t.Fatalf("expected cloudflare auth header, got %q", gotAuth)
+	}
+	if gotPath != "/accounts/account/ai/run/"+defaultVariationModel {
+		t.Fatalf("unexpected path: %s", gotPath)
+	}
+	if gotImageLength != 4 {
+		t.Fatalf("expected 4 image bytes in payload, got %d", gotImageLength)
+	}
+	if len(result.Data) != 2 {
+		t.Fatalf("expected 2 variations, got %d", len(result.Data))
+	}
+	if result.Data[0].B64JSON != base64.StdEncoding.EncodeToString([]byte("png-bytes")) {
+		t.Fatalf("unexpected image payload: %q", result.Data[0].B64JSON)
+	}
+}
+
+// TestCloudflareEmbedRunsWorkersAIModel verifies the Workers AI embedding request and error envelope handling.
+func TestCloudflareEmbedRunsWorkersAIModel(t *testing.T) {
+
+	var gotPath string
+	var gotText []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		var payload struct {
+			Text []string `json:"text"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		gotText = payload.Text
+		if len(payload.Text) > 1 && payload.Text[1] == "fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"message":"AiError: input too long"}],"result":null}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"errors":[],"result":{"shape":[2,2],"data":[[1,2],[3,4]]}}`))
+	}))
+	defer server.Close()
+
+	provider := New(Config{
+		Name: "cloudflare",
+		Credentials: ProviderCredentials{
+			CredentialAccountID:       "account",
+			CredentialCloudflareToken: "cf-token",
+		},
+	})
+	provider.apiBaseURL = server.URL
+	provider.SetHTTPClient(server.Client())
+
+	result, err := provider.Embed(context.Background(), providergateway.EmbeddingOptions{Input: []string{"a", "b"}})
+	if err != nil {
+		t.Fatalf("embed: %v", err)
+	}
+	if gotPath != "/accounts/account/ai/run/"+defaultEmbeddingModel || len(gotText) != 2 {
+		t.Fatalf("unexpected request %s %v", gotPath, gotText)
+	}
+	if len(result.Embeddings) != 2 || result.Embeddings[1][0] != 3 {
+		t.Fatalf("unexpected embeddings %v", result.Embeddings)
+	}
+
+	_, err = provider.Embed(context.Background(), providergateway.EmbeddingOptions{Input: []string{"a", "fail"}})
+	if err == nil || !strings.Contains(err.Error(), "input too long") {
+		t.Fatalf("expected workers AI error, got %v", err)
+	}
+}
+
+// TestCloudflareAudioRunsWhisperAndMeloTTS verifies Workers AI transcription and speech requests.
+func TestCloudflareAudioRunsWhisperAndMeloTTS(t *testing.T) {
+
+	var gotPaths []string
+	var transcribeInput map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPaths = append(gotPaths, r.URL.Path)
+		if strings.HasSuffix(r.URL.Path, defaultTranscriptionModel) {
+			_ = json.NewDecoder(r.Body).Decode(&transcribeInput)
+			_, _ = w.Write([]byte(`{"success":true,"errors":[],"result":{"text":" hello there ","transcription_info":{"language":"en","duration":1.5}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"errors":[],"result":{"audio":"` + base64.StdEncoding.EncodeToString([]byte("mp3-bytes")) + `"}}`))
+	}))
+	defer server.Close()
+
+	provider := New(Config{
+		Name: "cloudflare",
+		Credentials: ProviderCredentials{
+			CredentialAccountID:       "account",
+			CredentialCloudflareToken: "cf-token",
+		},
+	})
+	provider.apiBaseURL = server.URL
+	provider.SetHTTPClient(server.Client())
+
+	transcript, err := provider.Transcribe(context.Background(), providergateway.TranscriptionOptions{Audio: []byte("wav"), Language: "en"})
+	if err != nil {
+		t.Fatalf("transcribe: %v", err)
+	}
+	if transcript.Text != "hello there" || transcript.Duration != 1.5 {
+		t.Fatalf("unexpected transcript %#v", transcript)
+	}
+	if transcribeInput["audio"] != base64.StdEncoding.EncodeToString([]byte("wav")) || transcribeInput["language"] != "en" {
+		t.Fatalf("unexpected transcription input %#v", transcribeInput)
+	}
+
+	speech, err := provider.Synthesize(context.Background(), providergateway.SpeechOptions{Input: "hi"})
+	if err != nil {
+		t.Fatalf("synthesize: %v", err)
+	}
+	if string(speech.Audio) != "mp3-bytes" || speech.MIMEType != "audio/mpeg" {
+		t.Fatalf("unexpected speech %#v", speech)
+	}
+	if len(gotPaths) != 2 || gotPaths[1] != "/accounts/account/ai/run/"+defaultSpeechModel {
+		t.Fatalf("unexpected requests %v", gotPaths)
+	}
+
+	if _, err := provider.Synthesize(context.Background(), providergateway.SpeechOptions{Input: "hi", Format: "wav"}); err == nil {
+		t.Fatalf("expected unsupported format error")
+	}
+}
+
+// TestCloudflareModerateParsesLlamaGuardVerdicts verifies structured and text Llama Guard responses.
+func TestCloudflareModerateParsesLlamaGuardVerdicts(t *testing.T) {
+
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		var payload struct {
+			Messages []map[string]string `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		switch payload.Messages[0]["content"] {
+		case "hello":
+			_, _ = w.Write([]byte(`{"success":true,"errors":[],"result":{"response":{"safe":true,"categories":[]}}}`))
+		case "threat":
+			_, _ = w.Write([]byte(`{"success":true,"errors":[],"result":{"response":{"safe":false,"categories":["S1","S10"]}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"success":true,"errors":[],"result":{"response":"unsafe\nS11"}}`))
+		}
+	}))
+	defer server.Close()
+
+	provider := New(Config{
+		Name: "cloudflare",
+		Credentials: ProviderCredentials{
+			CredentialAccountID:       "account",
+			CredentialCloudflareToken: "cf-token",
+		},
+	})
+	provider.apiBaseURL = server.URL
+	provider.SetHTTPClient(server.Client())
+
+	result, err := provider.Moderate(context.Background(), providergateway.ModerationOptions{Input: []string{"hello", "threat", "sad"}})
+	if err != nil {
+		t.Fatalf("moderate: %v", err)
+	}
+	if gotPath != "/accounts/account/ai/run/"+defaultModerationModel {
+		t.Fatalf("unexpected path %s", gotPath)
+	}
+	if len(result.Verdicts) != 3 || result.Verdicts[0].Flagged {
+		t.Fatalf("unexpected verdicts %#v", result.Verdicts)
+	}
+	if !result.Verdicts[1].Flagged || strings.Join(result.Verdicts[1].Categories, ",") != "hate,violent-crimes" {
+		t.Fatalf("unexpected structured verdict %#v", result.Verdicts[1])
+	}
+	if !result.Verdicts[2].Flagged || strings.Join(result.Verdicts[2].Categories, ",") != "suicide-self-harm" {
+		t.Fatalf("unexpected text verdict %#v", result.Verdicts[2])
+	}
+}
+
+// TestCloudflareRerankRunsRerankerModel verifies the Workers AI rerank input and id-to-index mapping.
+func TestCloudflareRerankRunsRerankerModel(t *testing.T) {
+
+	var gotPath string
+	var payload struct {
+		Query    string              `json:"query"`
+		Contexts []map[string]string `json:"contexts"`
+		TopK     int                 `json:"top_k"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		_, _ = w.Write([]byte(`{"success":true,"errors":[],"result":{"response":[{"id":1,"score":0.2},{"id":0,"score":0.8}]}}`))
+	}))
+	defer server.Close()
+
+	provider := New(Config{
+		Name: "cloudflare",
+		Credentials: ProviderCredentials{
+			CredentialAccountID:       "account",
+			CredentialCloudflareToken: "cf-token",
+		},
+	})
+	provider.apiBaseURL = server.URL
+	provider.SetHTTPClient(server.Client())
+
+	result, err := provider.Rerank(context.Background(), providergateway.RerankOptions{Query: "paris", Documents: []string{"Paris, France", "Bananas"}, TopN: 2})
+	if err != nil {
+		t.Fatalf("rerank: %v", err)
+	}
+	if gotPath != "/accounts/account/ai/run/"+defaultRerankModel {
+		t.Fatalf("unexpected path %s", gotPath)
+	}
+	if payload.Query != "paris" || len(payload.Contexts) != 2 || payload.Contexts[1]["text"] != "Bananas" || payload.TopK != 2 {
+		t.Fatalf("unexpected payload %#v", payload)
+	}
+	if len(result.Results) != 2 || result.Results[0].Index != 0 || result.Results[0].Score != 0.8 {
+		t.Fatalf("unexpected result %#v", result)
+	}
+}
@@ -0,0 +1,57 @@
+// rerank.go implements Workers AI reranking for the Cloudflare adapter.
+// internal/features/ai/providers/adapters/cloudflare/rerank.go
+package cloudflare
+
+import (
+	"context"
+
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+const defaultRerankModel = "@cf/baai/bge-reranker-base"
+
+var _ providergateway.Reranker = (*Cloudflare)(nil)
+
+// Rerank scores documents against the query with a Workers AI reranker model in one run.
+func (c *Cloudflare) Rerank(ctx context.Context, opts providergateway.RerankOptions) (*providergateway.RerankResult, error) {
+
+	if err := providergateway.ValidateRerankOptions(opts); err != nil {
+		return nil, err
+	}
+	model := resolveModelName(opts.Model)
+	if model == "" {
+		model = defaultRerankModel
+	}
+
+	contexts := make([]map[string]string, 0, len(opts.Documents))
+	for _, document := range opts.Documents {
+		contexts = append(contexts, map[string]string{"text": document})
+	}
+	input := map[string]interface{}{
+		"query":    opts.Query,
+		"contexts": contexts,
+	}
+	if opts.TopN > 0 {
+		input["top_k"] = opts.TopN
+	}
+
+	var output struct {
+		Response []struct {
+			ID    int     `json:"id"`
+			Score float64 `json:"score"`
+		} `json:"response"`
+	}
+	if err := c.runJSONModel(ctx, model, input, &output); err != nil {
+		return nil, err
+	}
+
+	scores := make([]providergateway.RerankScore, 0, len(output.Response))
+	for _, item := range output.Response {
+		scores = append(scores, providergateway.RerankScore{Index: item.ID, Score: item.Score})
+	}
+	sorted, err := providergateway.SortRerankScores(scores, len(opts.Documents), opts.TopN)
+	if err != nil {
+		return nil, err
+	}
+	return &providergateway.RerankResult{Model: model, Results: sorted}, nil
+}
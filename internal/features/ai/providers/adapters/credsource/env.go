@@ -0,0 +1,184 @@
+// env.go resolves provider credentials from conventional environment variables and .env files.
+// internal/features/ai/providers/adapters/credsource/env.go
+package credsource
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+)
+
+// Source names reported for credentials supplied by this package.
+const (
+	SourceEnv    = "env"
+	SourceDotEnv = "dotenv"
+)
+
+// EnvDotEnvFile names the .env file to read; no .env file is read when it is unset, so a .env
+// in whatever directory the app was started from is never picked up implicitly.
+const EnvDotEnvFile = "WLS_ENV_FILE"
+
+// envAliases lists widely used variable names that differ from the <PROVIDER>_<FIELD> convention.
+var envAliases = map[string][]string{
+	"GEMINI_API_KEY": {"GOOGLE_API_KEY"},
+	"GROK_API_KEY":   {"XAI_API_KEY"},
+}
+
+// VariableSource looks credentials up in a key/value lookup using conventional variable names.
+type VariableSource struct {
+	name   string
+	lookup func(key string) (string, bool)
+}
+
+var _ providercore.CredentialSource = (*VariableSource)(nil)
+
+// NewEnvSource creates a source backed by the process environment.
+func NewEnvSource() *VariableSource {
+
+	return &VariableSource{name: SourceEnv, lookup: os.LookupEnv}
+}
+
+// NewMapSource creates a source backed by a fixed variable map.
+func NewMapSource(name string, values map[string]string) *VariableSource {
+
+	return &VariableSource{
+		name: name,
+		lookup: func(key string) (string, bool) {
+			value, ok := values[key]
+			return value, ok
+		},
+	}
+}
+
+// NewDotEnvSource parses a .env file; a missing file yields an empty source.
+func NewDotEnvSource(path string) (*VariableSource, error) {
+
+	values, err := ParseDotEnvFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewMapSource(SourceDotEnv, values), nil
+}
+
+// Name returns the source label shown to users.
+func (s *VariableSource) Name() string {
+
+	return s.name
+}
+
+// LookupProviderCredential returns the first non-empty conventional variable for the provider field.
+func (s *VariableSource) LookupProviderCredential(providerName, fieldName string) (string, bool) {
+
+	for _, key := range VariableNames(providerName, fieldName) {
+		if value, ok := s.lookup(key); ok && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value), true
+		}
+	}
+	return "", false
+}
+
+// VariableNames returns the variable names consulted for a provider field, most specific first.
+// Names follow <PROVIDER>_<FIELD> in upper snake case, e.g. OPENAI_API_KEY, without repeating a
+// provider prefix already present in the field (cloudflare_api_token becomes CLOUDFLARE_API_TOKEN).
+func VariableNames(providerName, fieldName string) []string {
+
+	provider := envToken(providerName)
+	field := envToken(fieldName)
+	if provider == "" || field == "" {
+		return nil
+	}
+
+	primary := provider + "_" + field
+	if strings.HasPrefix(field, provider+"_") {
+		primary = field
+	}
+	return append([]string{primary}, envAliases[primary]...)
+}
+
+// envToken upper-cases a name and replaces non-alphanumeric runs with underscores.
+func envToken(value string) string {
+
+	var builder strings.Builder
+	underscore := false
+	for _, r := range strings.ToUpper(strings.TrimSpace(value)) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			builder.WriteRune(r)
+			underscore = false
+			continue
+		}
+		if !underscore && builder.Len() > 0 {
+			builder.WriteByte('_')
+			underscore = true
+		}
+	}
+	return strings.TrimSuffix(builder.String(), "_")
+}
+
+// ParseDotEnvFile reads KEY=value lines, ignoring blanks, comments and an optional export prefix.
+// Double-quoted values support Go escape sequences; single-quoted values are literal.
+func ParseDotEnvFile(path string) (map[string]string, error) {
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	values := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%s:%d: expected KEY=value", path, lineNumber)
+		}
+		parsed, err := parseDotEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNumber, err)
+		}
+		values[key] = parsed
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// parseDotEnvValue unquotes a value or strips a trailing inline comment from a bare value.
+func parseDotEnvValue(value string) (string, error) {
+
+	switch {
+	case strings.HasPrefix(value, `"`):
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid double-quoted value")
+		}
+		return unquoted, nil
+	case strings.HasPrefix(value, "'"):
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return "", fmt.Errorf("unterminated single-quoted value")
+		}
+		return value[1 : len(value)-1], nil
+	default:
+		if index := strings.Index(value, " #"); index >= 0 {
+			value = value[:index]
+		}
+		return strings.TrimSpace(value), nil
+	}
+}
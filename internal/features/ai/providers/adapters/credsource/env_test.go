@@ -0,0 +1,132 @@
+// env_test.go verifies conventional variable names and .env parsing.
+// internal/features/ai/providers/adapters/credsource/env_test.go
+package credsource
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// TestVariableNamesFollowProviderConvention verifies provider/field pairs map to conventional env var names.
+func TestVariableNamesFollowProviderConvention(t *testing.T) {
+
+	testCases := []struct {
+		provider string
+		field    string
+		expected string
+	}{
+		{provider: "openai", field: "api_key", expected: "OPENAI_API_KEY"},
+		{provider: "anthropic", field: "api_key", expected: "ANTHROPIC_API_KEY"},
+		{provider: "cloudflare", field: "cloudflare_api_token", expected: "CLOUDFLARE_API_TOKEN"},
+		{provider: "cloudflare", field: "account_id", expected: "CLOUDFLARE_ACCOUNT_ID"},
+		{provider: "gemini", field: "api_key", expected: "GEMINI_API_KEY,GOOGLE_API_KEY"},
+		{provider: "my-openai.eu", field: "api_key", expected: "MY_OPENAI_EU_API_KEY"},
+		{provider: "", field: "api_key", expected: ""},
+	}
+	for _, testCase := range testCases {
+		got := strings.Join(VariableNames(testCase.provider, testCase.field), ",")
+		if got != testCase.expected {
+			t.Fatalf("%s/%s: expected %q, got %q", testCase.provider, testCase.field, testCase.expected, got)
+		}
+	}
+}
+
+// TestEnvSourceLooksUpProcessEnvironment verifies env lookups use aliases and ignore blank values.
+func TestEnvSourceLooksUpProcessEnvironment(t *testing.T) {
+
+	t.Setenv("GEMINI_API_KEY", " ")
+	t.Setenv("GOOGLE_API_KEY", "google-key")
+	t.Setenv("OPENAI_API_KEY", "sk-env")
+
+	source := NewEnvSource()
+	if source.Name() != SourceEnv {
+		t.Fatalf("unexpected source name %q", source.Name())
+	}
+	if value, ok := source.LookupProviderCredential("openai", "api_key"); !ok || value != "sk-env" {
+		t.Fatalf("expected OPENAI_API_KEY, got %q %v", value, ok)
+	}
+	if value, ok := source.LookupProviderCredential("gemini", "api_key"); !ok || value != "google-key" {
+		t.Fatalf("expected GOOGLE_API_KEY alias after blank GEMINI_API_KEY, got %q %v", value, ok)
+	}
+	if _, ok := source.LookupProviderCredential("wls-unset-provider", "api_key"); ok {
+		t.Fatalf("expected no credential for unset variable")
+	}
+}
+
+// TestParseDotEnvFileHandlesQuotingAndComments verifies .env syntax and missing-file behavior.
+func TestParseDotEnvFileHandlesQuotingAndComments(t *testing.T) {
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+	content := strings.Join([]string{
+		"# provider keys",
+		"OPENAI_API_KEY=sk-dotenv # inline comment",
+		"export ANTHROPIC_API_KEY='sk-ant #literal'",
+		`CLOUDFLARE_API_TOKEN="cf\ttoken"`,
+		"GROK_API_KEY=exec:pass show grok",
+		"",
+	}, "\n")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write .env: %v", err)
+	}
+
+	source, err := NewDotEnvSource(path)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	testCases := []struct {
+		provider string
+		field    string
+		expected string
+	}{
+		{provider: "openai", field: "api_key", expected: "sk-dotenv"},
+		{provider: "anthropic", field: "api_key", expected: "sk-ant #literal"},
+		{provider: "cloudflare", field: "cloudflare_api_token", expected: "cf\ttoken"},
+		{provider: "grok", field: "api_key", expected: "exec:pass show grok"},
+	}
+	for _, testCase := range testCases {
+		value, ok := source.LookupProviderCredential(testCase.provider, testCase.field)
+		if !ok || value != testCase.expected {
+			t.Fatalf("%s: expected %q, got %q", testCase.provider, testCase.expected, value)
+		}
+	}
+
+	missing, err := ParseDotEnvFile(filepath.Join(dir, "absent.env"))
+	if err != nil || len(missing) != 0 {
+		t.Fatalf("expected missing file to be empty, got %v (%v)", missing, err)
+	}
+
+	badPath := filepath.Join(dir, "bad.env")
+	if err := os.WriteFile(badPath, []byte("NOT A PAIR\n"), 0o600); err != nil {
+		t.Fatalf("write bad .env: %v", err)
+	}
+	if _, err := ParseDotEnvFile(badPath); err == nil || !strings.Contains(err.Error(), ":1:") {
+		t.Fatalf("expected line-numbered parse error, got %v", err)
+	}
+}
+
+// TestExecRunnerReturnsFirstOutputLine verifies command output handling and failures.
+func TestExecRunnerReturnsFirstOutputLine(t *testing.T) {
+
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX printf")
+	}
+
+	runner := NewExecRunner(0)
+	value, err := runner.RunCredentialCommand(`printf sk-from-pass\nurl:example`)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if value != "sk-from-pass" {
+		t.Fatalf("expected first line, got %q", value)
+	}
+	if _, err := runner.RunCredentialCommand("false"); err == nil {
+		t.Fatalf("expected failing command to error")
+	}
+	if _, err := runner.RunCredentialCommand("   "); err == nil {
+		t.Fatalf("expected empty command to error")
+	}
+}
@@ -0,0 +1,76 @@
+// exec.go runs exec: credential commands such as "pass show openai".
+// internal/features/ai/providers/adapters/credsource/exec.go
+package credsource
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+)
+
+// DefaultExecTimeout bounds how long a credential command may run.
+const DefaultExecTimeout = 30 * time.Second
+
+// ExecRunner runs credential commands directly (no shell) and caches their output for the process lifetime.
+// Short-lived values such as access tokens are therefore not refreshed until the app restarts.
+type ExecRunner struct {
+	timeout time.Duration
+	mu      sync.Mutex
+	cache   map[string]string
+}
+
+var _ providercore.CredentialCommandRunner = (*ExecRunner)(nil)
+
+// NewExecRunner creates a command runner; a non-positive timeout uses DefaultExecTimeout.
+func NewExecRunner(timeout time.Duration) *ExecRunner {
+
+	if timeout <= 0 {
+		timeout = DefaultExecTimeout
+	}
+	return &ExecRunner{timeout: timeout, cache: map[string]string{}}
+}
+
+// RunCredentialCommand splits command on whitespace, runs it, and returns trimmed stdout.
+func (r *ExecRunner) RunCredentialCommand(command string) (string, error) {
+
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return "", fmt.Errorf("credential command is empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if value, ok := r.cache[command]; ok {
+		return value, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		detail := strings.TrimSpace(stderr.String())
+		if detail != "" {
+			return "", fmt.Errorf("run credential command %q: %w: %s", args[0], err, detail)
+		}
+		return "", fmt.Errorf("run credential command %q: %w", args[0], err)
+	}
+
+	// Tools like pass print the secret on the first line and metadata after it.
+	value, _, _ := strings.Cut(strings.TrimSpace(stdout.String()), "\n")
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("credential command %q printed no value", args[0])
+	}
+	r.cache[command] = value
+	return value, nil
+}
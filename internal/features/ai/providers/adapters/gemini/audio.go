@@ -0,0 +1,235 @@
+// audio.go implements Gemini speech-to-text and text-to-speech through the generateContent REST method.
+// internal/features/ai/providers/adapters/gemini/audio.go
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	providerhttp "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/httpcompat"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+const (
+	defaultTranscriptionModel = "gemini-2.5-flash"
+	defaultSpeechModel        = "gemini-2.5-flash-preview-tts"
+	defaultSpeechVoice        = "Kore"
+	// speechSampleRate is used when the PCM MIME type omits a rate parameter.
+	speechSampleRate  = 24000
+	transcribeRequest = "Transcribe this audio verbatim. Reply with the transcript only, without commentary or timestamps."
+)
+
+var _ providergateway.Transcriber = (*Gemini)(nil)
+var _ providergateway.Synthesizer = (*Gemini)(nil)
+
+// contentPart is one text or inline-data part of a generateContent request or response.
+type contentPart struct {
+	Text       string      `json:"text,omitempty"`
+	InlineData *inlineData `json:"inlineData,omitempty"`
+}
+
+// inlineData carries base64-encoded media in a content part.
+type inlineData struct {
+	MimeType string `json:"mimeType"`
+	Data     string `json:"data"`
+}
+
+// Transcribe converts recorded speech to text by prompting a multimodal model with inline audio.
+func (g *Gemini) Transcribe(ctx context.Context, opts providergateway.TranscriptionOptions) (*providergateway.TranscriptionResult, error) {
+
+	if len(opts.Audio) == 0 {
+		return nil, fmt.Errorf("audio input required")
+	}
+	model := strings.TrimPrefix(strings.TrimSpace(opts.Model), "models/")
+	if model == "" {
+		model = defaultTranscriptionModel
+	}
+	mimeType := providergateway.AudioMIMEType(opts.Filename)
+	if mimeType == "" {
+		mimeType = "audio/wav"
+	}
+
+	instruction := transcribeRequest
+	if opts.Language != "" {
+		instruction += " The speech is in " + opts.Language + "."
+	}
+	if opts.Prompt != "" {
+		instruction += " Context: " + opts.Prompt
+	}
+	request := map[string]interface{}{
+		"contents": []map[string]interface{}{{
+			"role": "user",
+			"parts": []contentPart{
+				{Text: instruction},
+				{InlineData: &inlineData{MimeType: mimeType, Data: base64.StdEncoding.EncodeToString(opts.Audio)}},
+			},
+		}},
+	}
+
+	parts, err := g.generateContentParts(ctx, model, request)
+	if err != nil {
+		return nil, err
+	}
+	var text strings.Builder
+	for _, part := range parts {
+		text.WriteString(part.Text)
+	}
+	return &providergateway.TranscriptionResult{
+		Model:    model,
+		Text:     strings.TrimSpace(text.String()),
+		Language: opts.Language,
+	}, nil
+}
+
+// Synthesize converts text to speech with a Gemini TTS model and wraps the returned PCM in a WAV container.
+func (g *Gemini) Synthesize(ctx context.Context, opts providergateway.SpeechOptions) (*providergateway.SpeechResult, error) {
+
+	if strings.TrimSpace(opts.Input) == "" {
+		return nil, fmt.Errorf("speech input required")
+	}
+	format := strings.ToLower(strings.TrimSpace(opts.Format))
+	if format != "" && format != "wav" && format != "pcm" {
+		return nil, fmt.Errorf("gemini speech supports wav or pcm output, not %s", format)
+	}
+	if format == "" {
+		format = "wav"
+	}
+	model := strings.TrimPrefix(strings.TrimSpace(opts.Model), "models/")
+	if model == "" {
+		model = defaultSpeechModel
+	}
+	voice := opts.Voice
+	if voice == "" {
+		voice = defaultSpeechVoice
+	}
+
+	request := map[string]interface{}{
+		"contents": []map[string]interface{}{{
+			"role":  "user",
+			"parts": []contentPart{{Text: opts.Input}},
+		}},
+		"generationConfig": map[string]interface{}{
+			"responseModalities": []string{"AUDIO"},
+			"speechConfig": map[string]interface{}{
+				"voiceConfig": map[string]interface{}{
+					"prebuiltVoiceConfig": map[string]string{"voiceName": voice},
+				},
+			},
+		},
+	}
+
+	parts, err := g.generateContentParts(ctx, model, request)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range parts {
+		if part.InlineData == nil {
+			continue
+		}
+		pcm, err := base64.StdEncoding.DecodeString(part.InlineData.Data)
+		if err != nil {
+			return nil, fmt.Errorf("decode speech audio: %w", err)
+		}
+		audio := pcm
+		if format == "wav" {
+			audio = wrapPCMAsWAV(pcm, pcmSampleRate(part.InlineData.MimeType))
+		}
+		return &providergateway.SpeechResult{
+			Model:    model,
+			Audio:    audio,
+			Format:   format,
+			MIMEType: providergateway.AudioMIMEType(format),
+		}, nil
+	}
+	return nil, fmt.Errorf("no audio in speech response")
+}
+
+// generateContentParts posts a generateContent request and returns the first candidate's parts.
+func (g *Gemini) generateContentParts(ctx context.Context, model string, request map[string]interface{}) ([]contentPart, error) {
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshal generateContent request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/models/%s:generateContent", g.restBaseURL(), model)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", g.apiKey)
+
+	resp, err := g.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &providerhttp.APIError{Code: resp.StatusCode, Message: string(body)}
+	}
+
+	var payload struct {
+		Candidates []struct {
+			Content struct {
+				Parts []contentPart `json:"parts"`
+			} `json:"content"`
+		} `json:"candidates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("parse generateContent response: %w", err)
+	}
+	if len(payload.Candidates) == 0 {
+		return nil, fmt.Errorf("no candidates in generateContent response")
+	}
+	return payload.Candidates[0].Content.Parts, nil
+}
+
+// pcmSampleRate reads the rate parameter from a MIME type such as "audio/L16;codec=pcm;rate=24000".
+func pcmSampleRate(mimeType string) int {
+
+	for _, param := range strings.Split(mimeType, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || !strings.EqualFold(key, "rate") {
+			continue
+		}
+		if rate, err := strconv.Atoi(value); err == nil && rate > 0 {
+			return rate
+		}
+	}
+	return speechSampleRate
+}
+
+// wrapPCMAsWAV prefixes 16-bit mono little-endian PCM samples with a RIFF/WAVE header.
+func wrapPCMAsWAV(pcm []byte, sampleRate int) []byte {
+
+	const channels, bitsPerSample = 1, 16
+	blockAlign := channels * bitsPerSample / 8
+
+	var out bytes.Buffer
+	out.Grow(44 + len(pcm))
+	out.WriteString("RIFF")
+	_ = binary.Write(&out, binary.LittleEndian, uint32(36+len(pcm)))
+	out.WriteString("WAVEfmt ")
+	_ = binary.Write(&out, binary.LittleEndian, uint32(16))
+	_ = binary.Write(&out, binary.LittleEndian, uint16(1))
+	_ = binary.Write(&out, binary.LittleEndian, uint16(channels))
+	_ = binary.Write(&out, binary.LittleEndian, uint32(sampleRate))
+	_ = binary.Write(&out, binary.LittleEndian, uint32(sampleRate*blockAlign))
+	_ = binary.Write(&out, binary.LittleEndian, uint16(blockAlign))
+	_ = binary.Write(&out, binary.LittleEndian, uint16(bitsPerSample))
+	out.WriteString("data")
+	_ = binary.Write(&out, binary.LittleEndian, uint32(len(pcm)))
+	out.Write(pcm)
+	return out.Bytes()
+}
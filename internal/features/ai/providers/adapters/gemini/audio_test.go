@@ -0,0 +1,93 @@
+// audio_test.go verifies Gemini transcription and speech generateContent requests.
+// internal/features/ai/providers/adapters/gemini/audio_test.go
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// TestTranscribeSendsInlineAudio verifies the audio part, MIME type, and joined transcript text.
+func TestTranscribeSendsInlineAudio(t *testing.T) {
+
+	var gotPath string
+	var payload struct {
+		Contents []struct {
+			Parts []contentPart `json:"parts"`
+		} `json:"contents"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		_, _ = w.Write([]byte(`{"candidates":[{"content":{"parts":[{"text":"Hello "},{"text":"world.\n"}]}}]}`))
+	}))
+	defer server.Close()
+
+	provider := New(Config{Name: "gemini", BaseURL: server.URL, APIKey: "g-key"})
+	provider.SetHTTPClient(server.Client())
+
+	result, err := provider.Transcribe(context.Background(), providergateway.TranscriptionOptions{
+		Audio:    []byte("mp3"),
+		Filename: "note.MP3",
+		Language: "English",
+	})
+	if err != nil {
+		t.Fatalf("transcribe: %v", err)
+	}
+	if gotPath != "/models/"+defaultTranscriptionModel+":generateContent" {
+		t.Fatalf("unexpected path %s", gotPath)
+	}
+	parts := payload.Contents[0].Parts
+	if len(parts) != 2 || !strings.Contains(parts[0].Text, "in English") || parts[1].InlineData == nil {
+		t.Fatalf("unexpected parts %#v", parts)
+	}
+	if parts[1].InlineData.MimeType != "audio/mpeg" || parts[1].InlineData.Data != base64.StdEncoding.EncodeToString([]byte("mp3")) {
+		t.Fatalf("unexpected inline audio %#v", parts[1].InlineData)
+	}
+	if result.Text != "Hello world." {
+		t.Fatalf("unexpected transcript %q", result.Text)
+	}
+}
+
+// TestSynthesizeWrapsPCMAsWAV verifies the speech config and the WAV header built from the returned sample rate.
+func TestSynthesizeWrapsPCMAsWAV(t *testing.T) {
+
+	var payload map[string]interface{}
+	pcm := []byte{1, 0, 2, 0}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		_, _ = w.Write([]byte(`{"candidates":[{"content":{"parts":[{"inlineData":{"mimeType":"audio/L16;codec=pcm;rate=16000","data":"` + base64.StdEncoding.EncodeToString(pcm) + `"}}]}}]}`))
+	}))
+	defer server.Close()
+
+	provider := New(Config{Name: "gemini", BaseURL: server.URL, APIKey: "g-key"})
+	provider.SetHTTPClient(server.Client())
+
+	result, err := provider.Synthesize(context.Background(), providergateway.SpeechOptions{Input: "Hi", Voice: "Puck"})
+	if err != nil {
+		t.Fatalf("synthesize: %v", err)
+	}
+	config, _ := json.Marshal(payload["generationConfig"])
+	if !strings.Contains(string(config), `"responseModalities":["AUDIO"]`) || !strings.Contains(string(config), `"voiceName":"Puck"`) {
+		t.Fatalf("unexpected generation config %s", config)
+	}
+	if result.Format != "wav" || result.MIMEType != "audio/wav" || len(result.Audio) != 44+len(pcm) {
+		t.Fatalf("unexpected result format=%s mime=%s len=%d", result.Format, result.MIMEType, len(result.Audio))
+	}
+	if !bytes.HasPrefix(result.Audio, []byte("RIFF")) || binary.LittleEndian.Uint32(result.Audio[24:28]) != 16000 || !bytes.HasSuffix(result.Audio, pcm) {
+		t.Fatalf("unexpected WAV header %v", result.Audio[:44])
+	}
+
+	if _, err := provider.Synthesize(context.Background(), providergateway.SpeechOptions{Input: "Hi", Format: "mp3"}); err == nil {
+		t.Fatalf("expected unsupported format error")
+	}
+}
@@ -0,0 +1,124 @@
+// embeddings.go implements Gemini text embeddings through the batchEmbedContents REST method.
+// internal/features/ai/providers/adapters/gemini/embeddings.go
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	providerhttp "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/httpcompat"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// embedPart is one text part of an embedding request.
+type embedPart struct {
+	Text string `json:"text"`
+}
+
+// embedContentRequest is one entry of a batchEmbedContents request.
+type embedContentRequest struct {
+	Model   string `json:"model"`
+	Content struct {
+		Parts []embedPart `json:"parts"`
+	} `json:"content"`
+	TaskType             string `json:"taskType,omitempty"`
+	OutputDimensionality int    `json:"outputDimensionality,omitempty"`
+}
+
+// Embed creates text embeddings with a Gemini embedding model.
+func (g *Gemini) Embed(ctx context.Context, opts providergateway.EmbeddingOptions) (*providergateway.EmbeddingResult, error) {
+
+	if len(opts.Input) == 0 {
+		return nil, fmt.Errorf("embedding input required")
+	}
+	model := strings.TrimPrefix(strings.TrimSpace(opts.Model), "models/")
+	if model == "" {
+		model = defaultEmbeddingModel
+	}
+
+	requests := make([]embedContentRequest, len(opts.Input))
+	for i, text := range opts.Input {
+		requests[i].Model = "models/" + model
+		requests[i].Content.Parts = []embedPart{{Text: text}}
+		requests[i].TaskType = embeddingTaskType(opts.InputType)
+		requests[i].OutputDimensionality = opts.Dimensions
+	}
+	body, err := json.Marshal(map[string]interface{}{"requests": requests})
+	if err != nil {
+		return nil, fmt.Errorf("marshal embedding request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/models/%s:batchEmbedContents", g.restBaseURL(), model)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", g.apiKey)
+
+	resp, err := g.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &providerhttp.APIError{Code: resp.StatusCode, Message: string(body)}
+	}
+
+	var payload struct {
+		Embeddings []struct {
+			Values []float32 `json:"values"`
+		} `json:"embeddings"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("parse embedding response: %w", err)
+	}
+	if len(payload.Embeddings) != len(opts.Input) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(opts.Input), len(payload.Embeddings))
+	}
+
+	result := &providergateway.EmbeddingResult{
+		Model:      model,
+		Embeddings: make([][]float32, len(payload.Embeddings)),
+	}
+	for i, item := range payload.Embeddings {
+		result.Embeddings[i] = item.Values
+	}
+	return result, nil
+}
+
+// MaxEmbeddingBatch returns the batchEmbedContents request limit.
+func (g *Gemini) MaxEmbeddingBatch() int {
+
+	return embeddingBatchLimit
+}
+
+// restBaseURL returns the configured REST base URL or the public v1beta endpoint.
+func (g *Gemini) restBaseURL() string {
+
+	baseURL := strings.TrimRight(strings.TrimSpace(g.baseURL), "/")
+	if baseURL == "" {
+		return defaultRESTBaseURL
+	}
+	return baseURL
+}
+
+// embeddingTaskType maps a gateway input type to a Gemini retrieval task type.
+func embeddingTaskType(inputType providergateway.EmbeddingInputType) string {
+
+	switch inputType {
+	case providergateway.EmbeddingInputDocument:
+		return "RETRIEVAL_DOCUMENT"
+	case providergateway.EmbeddingInputQuery:
+		return "RETRIEVAL_QUERY"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,53 @@
+// embeddings_test.go verifies Gemini batchEmbedContents requests.
+// internal/features/ai/providers/adapters/gemini/embeddings_test.go
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// TestEmbedSendsTaskTypeAndDimensions verifies request shape, auth header, and vector mapping.
+func TestEmbedSendsTaskTypeAndDimensions(t *testing.T) {
+
+	var gotPath, gotKey string
+	var payload struct {
+		Requests []embedContentRequest `json:"requests"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotKey = r.Header.Get("x-goog-api-key")
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		_, _ = w.Write([]byte(`{"embeddings":[{"values":[0.5,0.25]},{"values":[1,0]}]}`))
+	}))
+	defer server.Close()
+
+	provider := New(Config{Name: "gemini", BaseURL: server.URL + "/v1beta/", APIKey: "g-key"})
+	provider.SetHTTPClient(server.Client())
+
+	result, err := provider.Embed(context.Background(), providergateway.EmbeddingOptions{
+		Input:      []string{"what is rust", "rust is a language"},
+		Dimensions: 2,
+		InputType:  providergateway.EmbeddingInputQuery,
+	})
+	if err != nil {
+		t.Fatalf("embed: %v", err)
+	}
+	if gotPath != "/v1beta/models/"+defaultEmbeddingModel+":batchEmbedContents" || gotKey != "g-key" {
+		t.Fatalf("unexpected request %s key=%q", gotPath, gotKey)
+	}
+	if len(payload.Requests) != 2 || payload.Requests[0].TaskType != "RETRIEVAL_QUERY" || payload.Requests[1].OutputDimensionality != 2 {
+		t.Fatalf("unexpected payload %#v", payload.Requests)
+	}
+	if payload.Requests[1].Content.Parts[0].Text != "rust is a language" || payload.Requests[0].Model != "models/"+defaultEmbeddingModel {
+		t.Fatalf("unexpected request content %#v", payload.Requests)
+	}
+	if len(result.Embeddings) != 2 || result.Embeddings[0][1] != 0.25 {
+		t.Fatalf("unexpected embeddings %v", result.Embeddings)
+	}
+}
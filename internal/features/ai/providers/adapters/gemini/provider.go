@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"sort"
 	"strings"
 
@@ -43,6 +44,18 @@ type Gemini struct {
 }
 
 var _ Provider = (*Gemini)(nil)
+var _ providergateway.CapabilityAdvertiser = (*Gemini)(nil)
+var _ providergateway.ImageVariationProvider = (*Gemini)(nil)
+var _ providergateway.Embedder = (*Gemini)(nil)
+
+const (
+	defaultVariationModel = "gemini-2.5-flash-image"
+	defaultEmbeddingModel = "gemini-embedding-001"
+	defaultRESTBaseURL    = "https://generativelanguage.googleapis.com/v1beta"
+	// embeddingBatchLimit is the maximum number of requests batchEmbedContents accepts.
+	embeddingBatchLimit  = 100
+	defaultVariationHint = "Create a variation of this image that keeps its subject and composition but changes details, lighting, and style."
+)
 
 // New creates a new Gemini provider.
 func New(config Config) *Gemini {
@@ -208,6 +221,9 @@ func (g *Gemini) ListResources(ctx context.Context) ([]Model, error) {
 
 // Chat implements streaming chat completion for Gemini.
 func (g *Gemini) Chat(ctx context.Context, messages []ProviderMessage, opts ChatOptions) (<-chan Chunk, error) {
+	if err := providergateway.RejectToolTurns(messages); err != nil {
+		return nil, err
+	}
 	client, err := g.newSDKClient(ctx)
 	if err != nil {
 		return nil, err
@@ -397,6 +413,12 @@ func (g *Gemini) generateContentImage(ctx context.Context, model string, opts pr
 		return nil, fmt.Errorf("generate content failed: %w", err)
 	}
 
+	return contentImageResult(resp)
+}
+
+// contentImageResult extracts inline or URI image parts from a GenerateContent response.
+func contentImageResult(resp *genai.GenerateContentResponse) (*providergateway.ImageResult, error) {
+
 	if len(resp.Candidates) == 0 {
 		return nil, fmt.Errorf("no candidates returned")
 	}
@@ -406,6 +428,9 @@ func (g *Gemini) generateContentImage(ctx context.Context, model string, opts pr
 	}
 
 	for _, cand := range resp.Candidates {
+		if cand.Content == nil {
+			continue
+		}
 		for _, part := range cand.Content.Parts {
 			if part.InlineData != nil {
 				result.Data = append(result.Data, providergateway.ImageData{
@@ -435,3 +460,123 @@ func (g *Gemini) EditImage(ctx context.Context, opts providergateway.ImageEditOp
 	// We will attempt to use the genai client if possible, but standard 'GenerateImages' is text-to-image.
 	return nil, fmt.Errorf("image editing is not currently supported by the genai library for Imagen 4 models")
 }
+
+// CreateImageVariation creates image variations by sending the source image to a Gemini image model.
+func (g *Gemini) CreateImageVariation(ctx context.Context, opts providergateway.ImageVariationOptions) (*providergateway.ImageResult, error) {
+
+	model := opts.Model
+	if model == "" {
+		model = defaultVariationModel
+	}
+	if !strings.HasPrefix(model, "gemini") {
+		return nil, providergateway.NewCapabilityError(g.name, providergateway.CapabilityGenerateImageVariation, "variations require a Gemini image model")
+	}
+
+	image, err := readImageFile(opts.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := g.newSDKClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt := strings.TrimSpace(opts.Prompt)
+	if prompt == "" {
+		prompt = defaultVariationHint
+	}
+	parts := []*genai.Part{
+		genai.NewPartFromBytes(image.ImageBytes, image.MIMEType),
+		{Text: prompt},
+	}
+
+	count := opts.N
+	if count <= 0 {
+		count = 1
+	}
+	result := &providergateway.ImageResult{Data: make([]providergateway.ImageData, 0, count)}
+	for len(result.Data) < count {
+		resp, err := client.Models.GenerateContent(ctx, model, []*genai.Content{{Role: "user", Parts: parts}}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("generate content failed: %w", err)
+		}
+		variation, err := contentImageResult(resp)
+		if err != nil {
+			return nil, err
+		}
+		result.Data = append(result.Data, variation.Data...)
+	}
+
+	return result, nil
+}
+
+// GatewayCapabilities describes the semantic capabilities served by the Gemini adapter.
+func (g *Gemini) GatewayCapabilities() []providergateway.CapabilityDescriptor {
+
+	return []providergateway.CapabilityDescriptor{
+		{
+			ID:          providergateway.CapabilityChatText,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputText},
+			Interaction: providergateway.InteractionStreaming,
+		},
+		{
+			ID:          providergateway.CapabilityGenerateImage,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputImage},
+			Interaction: providergateway.InteractionSingle,
+		},
+		{
+			ID:          providergateway.CapabilityGenerateImageVariation,
+			Inputs:      []providergateway.InputType{providergateway.InputImage, providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputImage},
+			Interaction: providergateway.InteractionSingle,
+			Controls: []providergateway.ControlDescriptor{
+				{Name: "prompt", Type: "string", Description: "Optional guidance for the variation."},
+			},
+		},
+		{
+			ID:          providergateway.CapabilityRetrievalEmbedText,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputEmbedding},
+			Interaction: providergateway.InteractionBatch,
+			Controls: []providergateway.ControlDescriptor{
+				{Name: "dimensions", Type: "integer", Description: "Truncated output vector size."},
+				{Name: "inputType", Type: "string", Description: "document or query; selects the retrieval task type."},
+			},
+		},
+		{
+			ID:          providergateway.CapabilitySpeechASR,
+			Inputs:      []providergateway.InputType{providergateway.InputAudio},
+			Outputs:     []providergateway.OutputType{providergateway.OutputText},
+			Interaction: providergateway.InteractionSingle,
+			Controls: []providergateway.ControlDescriptor{
+				{Name: "language", Type: "string", Description: "Spoken language hint added to the transcription prompt."},
+			},
+		},
+		{
+			ID:          providergateway.CapabilitySpeechTTS,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputAudio},
+			Interaction: providergateway.InteractionSingle,
+			Controls: []providergateway.ControlDescriptor{
+				{Name: "voice", Type: "string", Description: "Prebuilt voice name such as Kore, Puck, or Charon."},
+				{Name: "format", Type: "string", Description: "wav or pcm (24 kHz 16-bit mono)."},
+			},
+		},
+	}
+}
+
+// readImageFile loads an image file into a genai image with a detected MIME type.
+func readImageFile(path string) (*genai.Image, error) {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read image: %w", err)
+	}
+	return &genai.Image{
+		ImageBytes: data,
+		MIMEType:   http.DetectContentType(data),
+	}, nil
+}
@@ -0,0 +1,139 @@
+// provider_test.go verifies Gemini image variation and upscale handling.
+// internal/features/ai/providers/adapters/gemini/provider_test.go
+package gemini
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// TestCreateImageVariationSendsImageToGeminiModel verifies the source image and prompt reach generateContent once per variation.
+func TestCreateImageVariationSendsImageToGeminiModel(t *testing.T) {
+
+	var paths []string
+	var gotKey, gotImage, gotPrompt string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		gotKey = r.Header.Get("x-goog-api-key")
+		var payload struct {
+			Contents []struct {
+				Parts []struct {
+					Text       string `json:"text"`
+					InlineData *struct {
+						Data string `json:"data"`
+					} `json:"inlineData"`
+				} `json:"parts"`
+			} `json:"contents"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		if len(payload.Contents) == 1 && len(payload.Contents[0].Parts) == 2 && payload.Contents[0].Parts[0].InlineData != nil {
+			gotImage = payload.Contents[0].Parts[0].InlineData.Data
+			gotPrompt = payload.Contents[0].Parts[1].Text
+		}
+		_, _ = w.Write([]byte(`{"candidates":[{"content":{"role":"model","parts":[{"inlineData":{"mimeType":"image/png","data":"` +
+			base64.StdEncoding.EncodeToString([]byte("variation")) + `"}}]}}]}`))
+	}))
+	defer server.Close()
+
+	provider := New(Config{Name: "gemini", APIKey: "g-key"})
+	provider.SetHTTPClient(redirectedClient(t, server.URL))
+
+	result, err := provider.CreateImageVariation(context.Background(), providergateway.ImageVariationOptions{
+		Image:  writeTestImage(t),
+		Prompt: "make it blue",
+		N:      2,
+	})
+	if err != nil {
+		t.Fatalf("create variation: %v", err)
+	}
+
+	if len(paths) != 2 || paths[0] != "/v1beta/models/"+defaultVariationModel+":generateContent" {
+		t.Fatalf("expected two generateContent calls, got %v", paths)
+	}
+	if gotKey != "g-key" || gotPrompt != "make it blue" {
+		t.Fatalf("unexpected request key=%q prompt=%q", gotKey, gotPrompt)
+	}
+	if gotImage != base64.StdEncoding.EncodeToString(testImageBytes) {
+		t.Fatalf("expected source image in request, got %q", gotImage)
+	}
+	if len(result.Data) != 2 || result.Data[0].B64JSON != base64.StdEncoding.EncodeToString([]byte("variation")) {
+		t.Fatalf("unexpected result %#v", result.Data)
+	}
+}
+
+// TestCreateImageVariationRejectsImagenModels verifies non-Gemini models are refused before any request.
+func TestCreateImageVariationRejectsImagenModels(t *testing.T) {
+
+	provider := New(Config{Name: "gemini", APIKey: "g-key"})
+	_, err := provider.CreateImageVariation(context.Background(), providergateway.ImageVariationOptions{
+		Image: writeTestImage(t),
+		Model: "imagen-4.0-generate-001",
+	})
+	if !errors.Is(err, providergateway.ErrCapabilityUnsupported) {
+		t.Fatalf("expected unsupported capability error, got %v", err)
+	}
+}
+
+// TestUpscaleImageIsUnsupported verifies upscaling is neither implemented nor advertised.
+func TestUpscaleImageIsUnsupported(t *testing.T) {
+
+	var provider any = New(Config{Name: "gemini", APIKey: "g-key"})
+	if _, ok := provider.(providergateway.ImageUpscaleProvider); ok {
+		t.Fatalf("expected gemini not to implement image upscaling")
+	}
+	if providergateway.AdvertisesCapability(provider, providergateway.CapabilityVisionUpscaleImage) {
+		t.Fatalf("expected upscaling not to be advertised")
+	}
+}
+
+// testImageBytes is a PNG signature, enough for content type detection.
+var testImageBytes = []byte("\x89PNG\r\n\x1a\n0000")
+
+// writeTestImage writes a small image file and returns its path.
+func writeTestImage(t *testing.T) string {
+
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "source.png")
+	if err := os.WriteFile(path, testImageBytes, 0o600); err != nil {
+		t.Fatalf("write image: %v", err)
+	}
+	return path
+}
+
+// redirectedClient returns an HTTP client that sends every request to target, keeping the path and query.
+func redirectedClient(t *testing.T, target string) *http.Client {
+
+	t.Helper()
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		t.Fatalf("parse target: %v", err)
+	}
+	return &http.Client{Transport: redirectTransport{target: targetURL}}
+}
+
+// redirectTransport rewrites request hosts to a test server.
+type redirectTransport struct {
+	target *url.URL
+}
+
+// RoundTrip sends the request to the test server.
+func (r redirectTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+
+	redirected := request.Clone(request.Context())
+	redirected.URL.Scheme = r.target.Scheme
+	redirected.URL.Host = r.target.Host
+	redirected.Host = r.target.Host
+	return http.DefaultTransport.RoundTrip(redirected)
+}
@@ -58,7 +58,6 @@ func New(config Config) *Grok {
 		baseURL:     baseURL,
 		apiKey:      config.APIKey,
 		models:      config.Models,
-		client:      providerhttp.NewDefaultClient(),
 	}
 }
 
@@ -127,6 +126,9 @@ func (g *Grok) ListResources(ctx context.Context) ([]Model, error) {
 
 // Chat implements streaming chat completion.
 func (g *Grok) Chat(ctx context.Context, messages []ProviderMessage, opts ChatOptions) (<-chan Chunk, error) {
+	if err := providergateway.RejectToolTurns(messages); err != nil {
+		return nil, err
+	}
 	return g.chatSDK(ctx, messages, opts)
 }
 
@@ -200,6 +202,9 @@ func (g *Grok) newSDKClient() openaisdk.Client {
 		option.WithAPIKey(g.apiKey),
 		option.WithBaseURL(g.normalizeBaseURL()),
 	}
+	if g.client != nil {
+		opts = append(opts, option.WithHTTPClient(g.client))
+	}
 	return openaisdk.NewClient(opts...)
 }
 
@@ -2,11 +2,18 @@
 // internal/features/ai/providers/adapters/httpcompat/api_error.go
 package providerhttp
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
-// APIError represents an HTTP API error with a status code.
+// ErrorTypeContentFilter marks requests or responses blocked by a provider's content filter.
+const ErrorTypeContentFilter = "content_filter"
+
+// APIError represents an HTTP API error with a status code and an optional provider-specific type.
 type APIError struct {
 	Code    int
+	Type    string
 	Message string
 }
 
@@ -16,10 +23,14 @@ func (e *APIError) Error() string {
 	if e == nil {
 		return ""
 	}
+	code := fmt.Sprintf("%d", e.Code)
+	if e.Type != "" {
+		code += " (" + e.Type + ")"
+	}
 	if e.Message == "" {
-		return fmt.Sprintf("API error: %d", e.Code)
+		return "API error: " + code
 	}
-	return fmt.Sprintf("API error: %d - %s", e.Code, e.Message)
+	return fmt.Sprintf("API error: %s - %s", code, e.Message)
 }
 
 // StatusCode returns the HTTP status code for this error.
@@ -30,3 +41,10 @@ func (e *APIError) StatusCode() int {
 	}
 	return e.Code
 }
+
+// IsContentFilterError reports whether err is an APIError raised by a content filter.
+func IsContentFilterError(err error) bool {
+
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Type == ErrorTypeContentFilter
+}
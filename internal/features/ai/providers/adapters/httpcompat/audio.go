@@ -0,0 +1,150 @@
+// audio.go handles OpenAI-compatible transcription and speech requests shared by provider adapters.
+// internal/features/ai/providers/adapters/httpcompat/audio.go
+package providerhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// TranscribeOpenAICompat executes an OpenAI-compatible multipart request against {baseURL}/audio/transcriptions.
+func TranscribeOpenAICompat(ctx context.Context, client Client, baseURL string, headers map[string]string, opts providergateway.TranscriptionOptions) (*providergateway.TranscriptionResult, error) {
+
+	baseURL = normalizeCompatBaseURL(baseURL)
+	if baseURL == "" {
+		return nil, fmt.Errorf("base URL required")
+	}
+	if len(opts.Audio) == 0 {
+		return nil, fmt.Errorf("audio input required")
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	filename := opts.Filename
+	if filename == "" {
+		filename = "audio.wav"
+	}
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := part.Write(opts.Audio); err != nil {
+		return nil, fmt.Errorf("failed to write audio: %w", err)
+	}
+	fields := map[string]string{
+		"model":           opts.Model,
+		"language":        opts.Language,
+		"prompt":          opts.Prompt,
+		"response_format": "verbose_json",
+	}
+	for _, name := range []string{"model", "language", "prompt", "response_format"} {
+		if fields[name] == "" {
+			continue
+		}
+		if err := writer.WriteField(name, fields[name]); err != nil {
+			return nil, fmt.Errorf("failed to write form field: %w", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close form: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/audio/transcriptions", &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	setHeaders(req, headers)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{Code: resp.StatusCode, Message: string(body)}
+	}
+
+	var payload struct {
+		Text     string  `json:"text"`
+		Language string  `json:"language"`
+		Duration float64 `json:"duration"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("failed to parse transcription response: %w", err)
+	}
+	return &providergateway.TranscriptionResult{
+		Model:    opts.Model,
+		Text:     strings.TrimSpace(payload.Text),
+		Language: payload.Language,
+		Duration: payload.Duration,
+	}, nil
+}
+
+// SynthesizeOpenAICompat executes an OpenAI-compatible request against {baseURL}/audio/speech.
+func SynthesizeOpenAICompat(ctx context.Context, client Client, baseURL string, headers map[string]string, opts providergateway.SpeechOptions) (*providergateway.SpeechResult, error) {
+
+	baseURL = normalizeCompatBaseURL(baseURL)
+	if baseURL == "" {
+		return nil, fmt.Errorf("base URL required")
+	}
+	if strings.TrimSpace(opts.Input) == "" {
+		return nil, fmt.Errorf("speech input required")
+	}
+
+	format := opts.Format
+	if format == "" {
+		format = "mp3"
+	}
+	reqBody := map[string]interface{}{
+		"model":           opts.Model,
+		"input":           opts.Input,
+		"voice":           opts.Voice,
+		"response_format": format,
+	}
+	if opts.Speed > 0 {
+		reqBody["speed"] = opts.Speed
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/audio/speech", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, headers)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	audio, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read speech response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{Code: resp.StatusCode, Message: string(audio)}
+	}
+
+	return &providergateway.SpeechResult{
+		Model:    opts.Model,
+		Audio:    audio,
+		Format:   format,
+		MIMEType: providergateway.AudioMIMEType(format),
+	}, nil
+}
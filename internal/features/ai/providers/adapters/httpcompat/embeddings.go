@@ -0,0 +1,136 @@
+// embeddings.go handles OpenAI-compatible embedding requests shared by provider adapters.
+// internal/features/ai/providers/adapters/httpcompat/embeddings.go
+package providerhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// OpenAICompatEmbeddingBatchLimit is the maximum number of inputs the OpenAI embeddings API accepts per request.
+const OpenAICompatEmbeddingBatchLimit = 2048
+
+// EmbedOpenAICompat executes an OpenAI-compatible embeddings request against {baseURL}/embeddings.
+func EmbedOpenAICompat(ctx context.Context, client Client, baseURL string, headers map[string]string, opts providergateway.EmbeddingOptions) (*providergateway.EmbeddingResult, error) {
+
+	baseURL = normalizeCompatBaseURL(baseURL)
+	if baseURL == "" {
+		return nil, fmt.Errorf("base URL required")
+	}
+	return EmbedOpenAICompatEndpoint(ctx, client, baseURL+"/embeddings", headers, opts)
+}
+
+// EmbedOpenAICompatEndpoint executes an OpenAI-compatible embeddings request against a full endpoint URL.
+// The OpenAI wire format has no input type, so opts.InputType is not sent.
+func EmbedOpenAICompatEndpoint(ctx context.Context, client Client, endpoint string, headers map[string]string, opts providergateway.EmbeddingOptions) (*providergateway.EmbeddingResult, error) {
+
+	if len(opts.Input) == 0 {
+		return nil, fmt.Errorf("embedding input required")
+	}
+
+	reqBody := map[string]interface{}{
+		"model":           opts.Model,
+		"input":           opts.Input,
+		"encoding_format": "float",
+	}
+	if opts.Dimensions > 0 {
+		reqBody["dimensions"] = opts.Dimensions
+	}
+	if opts.User != "" {
+		reqBody["user"] = opts.User
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, headers)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{Code: resp.StatusCode, Message: string(body)}
+	}
+
+	var payload struct {
+		Model string `json:"model"`
+		Data  []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+		Usage *struct {
+			PromptTokens int `json:"prompt_tokens"`
+			TotalTokens  int `json:"total_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("failed to parse embeddings response: %w", err)
+	}
+
+	items := make([]IndexedEmbedding, 0, len(payload.Data))
+	for _, item := range payload.Data {
+		items = append(items, IndexedEmbedding{Index: item.Index, Vector: item.Embedding})
+	}
+	embeddings, err := OrderEmbeddings(len(opts.Input), items)
+	if err != nil {
+		return nil, err
+	}
+	result := &providergateway.EmbeddingResult{
+		Model:      payload.Model,
+		Embeddings: embeddings,
+	}
+	if payload.Usage != nil {
+		result.Usage = &providergateway.UsageStats{
+			PromptTokens: payload.Usage.PromptTokens,
+			TotalTokens:  payload.Usage.TotalTokens,
+		}
+	}
+	return result, nil
+}
+
+// IndexedEmbedding is one returned vector tagged with the position of its input.
+type IndexedEmbedding struct {
+	Index  int
+	Vector []float32
+}
+
+// OrderEmbeddings places vectors in input order, failing when an index is out of range,
+// repeated, or missing so a malformed response never yields misaligned or empty vectors.
+func OrderEmbeddings(inputs int, items []IndexedEmbedding) ([][]float32, error) {
+
+	embeddings := make([][]float32, inputs)
+	for _, item := range items {
+		if item.Index < 0 || item.Index >= inputs {
+			return nil, fmt.Errorf("embedding index %d out of range", item.Index)
+		}
+		if embeddings[item.Index] != nil {
+			return nil, fmt.Errorf("embedding index %d returned more than once", item.Index)
+		}
+		if item.Vector == nil {
+			return nil, fmt.Errorf("embedding %d missing from response", item.Index)
+		}
+		embeddings[item.Index] = item.Vector
+	}
+	for index, vector := range embeddings {
+		if vector == nil {
+			return nil, fmt.Errorf("embedding %d missing from response", index)
+		}
+	}
+	return embeddings, nil
+}
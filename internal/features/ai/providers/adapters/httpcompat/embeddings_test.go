@@ -0,0 +1,85 @@
+// embeddings_test.go verifies OpenAI-compatible embedding requests and response index validation.
+// internal/features/ai/providers/adapters/httpcompat/embeddings_test.go
+package providerhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// TestEmbedOpenAICompatOrdersVectorsByIndex verifies the request body and that vectors land in their input slots.
+func TestEmbedOpenAICompatOrdersVectorsByIndex(t *testing.T) {
+
+	var gotPath, gotAuth string
+	var gotBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"model":"embed","data":[{"index":1,"embedding":[2]},{"index":0,"embedding":[1]}],"usage":{"prompt_tokens":3,"total_tokens":3}}`))
+	}))
+	defer server.Close()
+
+	result, err := EmbedOpenAICompat(context.Background(), server.Client(), server.URL+"/v1/", map[string]string{"Authorization": "Bearer key"}, providergateway.EmbeddingOptions{
+		Model:      "embed",
+		Input:      []string{"first", "second"},
+		Dimensions: 8,
+	})
+	if err != nil {
+		t.Fatalf("embed: %v", err)
+	}
+
+	if gotPath != "/v1/embeddings" || gotAuth != "Bearer key" {
+		t.Fatalf("unexpected request path=%s auth=%q", gotPath, gotAuth)
+	}
+	if gotBody["model"] != "embed" || gotBody["encoding_format"] != "float" || gotBody["dimensions"] != float64(8) {
+		t.Fatalf("unexpected request body %v", gotBody)
+	}
+	if len(result.Embeddings) != 2 || result.Embeddings[0][0] != 1 || result.Embeddings[1][0] != 2 {
+		t.Fatalf("unexpected embeddings %v", result.Embeddings)
+	}
+	if result.Usage == nil || result.Usage.TotalTokens != 3 {
+		t.Fatalf("unexpected usage %#v", result.Usage)
+	}
+}
+
+// TestEmbedOpenAICompatRejectsMalformedIndices verifies duplicate, missing, and out-of-range indices fail the request.
+func TestEmbedOpenAICompatRejectsMalformedIndices(t *testing.T) {
+
+	testCases := []struct {
+		name      string
+		data      string
+		wantError string
+	}{
+		{name: "duplicate index", data: `[{"index":0,"embedding":[1]},{"index":0,"embedding":[2]}]`, wantError: "returned more than once"},
+		{name: "missing index", data: `[{"index":1,"embedding":[2]}]`, wantError: "embedding 0 missing"},
+		{name: "missing vector", data: `[{"index":0,"embedding":[1]},{"index":1}]`, wantError: "embedding 1 missing"},
+		{name: "index out of range", data: `[{"index":0,"embedding":[1]},{"index":2,"embedding":[2]}]`, wantError: "out of range"},
+		{name: "negative index", data: `[{"index":-1,"embedding":[1]},{"index":1,"embedding":[2]}]`, wantError: "out of range"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"model":"embed","data":` + testCase.data + `}`))
+			}))
+			defer server.Close()
+
+			_, err := EmbedOpenAICompat(context.Background(), server.Client(), server.URL, nil, providergateway.EmbeddingOptions{
+				Model: "embed",
+				Input: []string{"first", "second"},
+			})
+			if err == nil || !strings.Contains(err.Error(), testCase.wantError) {
+				t.Fatalf("expected error containing %q, got %v", testCase.wantError, err)
+			}
+		})
+	}
+}
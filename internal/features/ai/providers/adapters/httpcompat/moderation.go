@@ -0,0 +1,101 @@
+// moderation.go handles OpenAI-compatible moderation requests shared by provider adapters.
+// internal/features/ai/providers/adapters/httpcompat/moderation.go
+package providerhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// openAICompatModeration is one entry of an OpenAI moderation response.
+type openAICompatModeration struct {
+	Flagged        bool               `json:"flagged"`
+	Categories     map[string]bool    `json:"categories"`
+	CategoryScores map[string]float64 `json:"category_scores"`
+}
+
+// ModerateOpenAICompat executes an OpenAI-compatible moderation request against {baseURL}/moderations.
+func ModerateOpenAICompat(ctx context.Context, client Client, baseURL string, headers map[string]string, opts providergateway.ModerationOptions) (*providergateway.ModerationResult, error) {
+
+	baseURL = normalizeCompatBaseURL(baseURL)
+	if baseURL == "" {
+		return nil, fmt.Errorf("base URL required")
+	}
+	if len(opts.Input) == 0 {
+		return nil, fmt.Errorf("moderation input required")
+	}
+
+	reqBody := map[string]interface{}{"input": opts.Input}
+	if opts.Model != "" {
+		reqBody["model"] = opts.Model
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/moderations", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, headers)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{Code: resp.StatusCode, Message: string(body)}
+	}
+
+	var payload struct {
+		Model   string                   `json:"model"`
+		Results []openAICompatModeration `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("failed to parse moderation response: %w", err)
+	}
+	if len(payload.Results) != len(opts.Input) {
+		return nil, fmt.Errorf("expected %d moderation results, got %d", len(opts.Input), len(payload.Results))
+	}
+
+	result := &providergateway.ModerationResult{Model: payload.Model}
+	for _, item := range payload.Results {
+		result.Verdicts = append(result.Verdicts, item.verdict())
+	}
+	return result, nil
+}
+
+// ParseOpenAICompatModeration converts one raw OpenAI moderation result object into a gateway verdict.
+func ParseOpenAICompatModeration(raw []byte) (providergateway.ModerationVerdict, error) {
+
+	var item openAICompatModeration
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return providergateway.ModerationVerdict{}, fmt.Errorf("failed to parse moderation result: %w", err)
+	}
+	return item.verdict(), nil
+}
+
+// verdict lists the triggered categories in sorted order.
+func (m openAICompatModeration) verdict() providergateway.ModerationVerdict {
+
+	verdict := providergateway.ModerationVerdict{Flagged: m.Flagged, Scores: m.CategoryScores}
+	for category, triggered := range m.Categories {
+		if triggered {
+			verdict.Categories = append(verdict.Categories, category)
+		}
+	}
+	sort.Strings(verdict.Categories)
+	return verdict
+}
@@ -77,13 +77,22 @@ func ChatOpenAICompat(ctx context.Context, client Client, baseURL string, header
 	if baseURL == "" {
 		return nil, fmt.Errorf("base URL required")
 	}
+	return ChatOpenAICompatEndpoint(ctx, client, baseURL+"/chat/completions", headers, messages, opts)
+}
+
+// ChatOpenAICompatEndpoint executes an OpenAI-compatible chat completion against a full endpoint URL,
+// for APIs that scope chat to a deployment path or require query parameters.
+func ChatOpenAICompatEndpoint(ctx context.Context, client Client, endpoint string, headers map[string]string, messages []providergateway.ProviderMessage, opts providergateway.ChatOptions) (<-chan providergateway.Chunk, error) {
 
+	if err := providergateway.RejectToolTurns(messages); err != nil {
+		return nil, err
+	}
 	body, err := MarshalOpenAICompatBody(opts.Model, messages, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/chat/completions", bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
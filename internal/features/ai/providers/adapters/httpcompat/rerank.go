@@ -0,0 +1,114 @@
+// rerank.go handles Cohere- and Jina-style rerank requests shared by provider adapters.
+// internal/features/ai/providers/adapters/httpcompat/rerank.go
+package providerhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// rerankCompatResult is one scored document; Cohere and Jina send relevance_score, TEI-style servers send score.
+type rerankCompatResult struct {
+	Index          int      `json:"index"`
+	RelevanceScore *float64 `json:"relevance_score"`
+	Score          *float64 `json:"score"`
+}
+
+// RerankCompat executes a rerank request against {baseURL}/rerank.
+// The request body follows Cohere and Jina: model, query, documents as strings, and top_n.
+func RerankCompat(ctx context.Context, client Client, baseURL string, headers map[string]string, opts providergateway.RerankOptions) (*providergateway.RerankResult, error) {
+
+	baseURL = normalizeCompatBaseURL(baseURL)
+	if baseURL == "" {
+		return nil, fmt.Errorf("base URL required")
+	}
+	if err := providergateway.ValidateRerankOptions(opts); err != nil {
+		return nil, err
+	}
+
+	reqBody := map[string]interface{}{
+		"query":     opts.Query,
+		"documents": opts.Documents,
+	}
+	if opts.Model != "" {
+		reqBody["model"] = opts.Model
+	}
+	if opts.TopN > 0 {
+		reqBody["top_n"] = opts.TopN
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/rerank", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, headers)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rerank response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{Code: resp.StatusCode, Message: string(raw)}
+	}
+
+	model, items, err := parseRerankCompatResponse(raw)
+	if err != nil {
+		return nil, err
+	}
+	scores := make([]providergateway.RerankScore, 0, len(items))
+	for _, item := range items {
+		score := providergateway.RerankScore{Index: item.Index}
+		switch {
+		case item.RelevanceScore != nil:
+			score.Score = *item.RelevanceScore
+		case item.Score != nil:
+			score.Score = *item.Score
+		default:
+			return nil, fmt.Errorf("rerank result %d has no score", item.Index)
+		}
+		scores = append(scores, score)
+	}
+
+	sorted, err := providergateway.SortRerankScores(scores, len(opts.Documents), opts.TopN)
+	if err != nil {
+		return nil, err
+	}
+	if model == "" {
+		model = opts.Model
+	}
+	return &providergateway.RerankResult{Model: model, Results: sorted}, nil
+}
+
+// parseRerankCompatResponse accepts the {"results": [...]} envelope and a bare result array.
+func parseRerankCompatResponse(raw []byte) (string, []rerankCompatResult, error) {
+
+	var envelope struct {
+		Model   string               `json:"model"`
+		Results []rerankCompatResult `json:"results"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err == nil && envelope.Results != nil {
+		return envelope.Model, envelope.Results, nil
+	}
+	var items []rerankCompatResult
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return "", nil, fmt.Errorf("failed to parse rerank response: %w", err)
+	}
+	return "", items, nil
+}
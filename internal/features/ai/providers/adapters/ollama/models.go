@@ -0,0 +1,242 @@
+// models.go lists installed Ollama models with their details and manages pull and load state.
+// internal/features/ai/providers/adapters/ollama/models.go
+package ollama
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// tagsResponse is the /api/tags body listing installed models.
+type tagsResponse struct {
+	Models []struct {
+		Name  string `json:"name"`
+		Model string `json:"model"`
+		Size  int64  `json:"size"`
+	} `json:"models"`
+}
+
+// showResponse is the subset of /api/show used for model details.
+type showResponse struct {
+	Capabilities []string       `json:"capabilities"`
+	ModelInfo    map[string]any `json:"model_info"`
+	Details      struct {
+		Family        string   `json:"family"`
+		Families      []string `json:"families"`
+		ParameterSize string   `json:"parameter_size"`
+	} `json:"details"`
+}
+
+// psResponse is the /api/ps body listing models loaded into memory.
+type psResponse struct {
+	Models []struct {
+		Name      string    `json:"name"`
+		Model     string    `json:"model"`
+		Size      int64     `json:"size"`
+		SizeVRAM  int64     `json:"size_vram"`
+		ExpiresAt time.Time `json:"expires_at"`
+	} `json:"models"`
+}
+
+// pullResponse is one NDJSON line of /api/pull progress.
+type pullResponse struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest"`
+	Total     int64  `json:"total"`
+	Completed int64  `json:"completed"`
+	Error     string `json:"error"`
+}
+
+// ListResources lists installed models, reading context length and vision/tool support from /api/show.
+func (o *Ollama) ListResources(ctx context.Context) ([]Model, error) {
+
+	var tags tagsResponse
+	if err := o.getJSON(ctx, "/api/tags", &tags); err != nil {
+		return nil, err
+	}
+
+	models := make([]Model, 0, len(tags.Models))
+	for _, installed := range tags.Models {
+		id := firstNonEmpty(installed.Model, installed.Name)
+		if id == "" {
+			continue
+		}
+		model := Model{ID: id, Name: firstNonEmpty(installed.Name, id), SupportsStreaming: true}
+
+		var details showResponse
+		if err := o.postJSON(ctx, "/api/show", map[string]string{"model": id}, &details); err != nil {
+			// Keep the model listed; details are best-effort when a manifest is mid-update.
+			models = append(models, model)
+			continue
+		}
+		model.ContextWindow = contextLength(details.ModelInfo)
+		model.SupportsVision = hasCapability(details.Capabilities, "vision") || containsFold(details.Details.Families, "clip")
+		model.SupportsTools = hasCapability(details.Capabilities, "tools")
+		models = append(models, model)
+	}
+
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].ID < models[j].ID
+	})
+	return models, nil
+}
+
+// contextLength reads "<architecture>.context_length" from model info, falling back to any context_length key.
+func contextLength(info map[string]any) int {
+
+	if architecture, ok := info["general.architecture"].(string); ok {
+		if value, ok := info[architecture+".context_length"].(float64); ok {
+			return int(value)
+		}
+	}
+	for key, raw := range info {
+		if value, ok := raw.(float64); ok && strings.HasSuffix(key, ".context_length") {
+			return int(value)
+		}
+	}
+	return 0
+}
+
+// hasCapability reports whether /api/show advertises a capability.
+func hasCapability(capabilities []string, capability string) bool {
+
+	return containsFold(capabilities, capability)
+}
+
+// containsFold reports whether values contains target ignoring case.
+func containsFold(values []string, target string) bool {
+
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// LocalModelStates reports installed models and which of them are loaded into memory.
+func (o *Ollama) LocalModelStates(ctx context.Context) ([]providergateway.LocalModelState, error) {
+
+	var tags tagsResponse
+	if err := o.getJSON(ctx, "/api/tags", &tags); err != nil {
+		return nil, err
+	}
+	var running psResponse
+	if err := o.getJSON(ctx, "/api/ps", &running); err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]*providergateway.LocalModelState, len(tags.Models))
+	for _, installed := range tags.Models {
+		id := firstNonEmpty(installed.Model, installed.Name)
+		states[id] = &providergateway.LocalModelState{Model: id, Installed: true, SizeBytes: installed.Size}
+	}
+	for _, loaded := range running.Models {
+		id := firstNonEmpty(loaded.Model, loaded.Name)
+		state, ok := states[id]
+		if !ok {
+			state = &providergateway.LocalModelState{Model: id}
+			states[id] = state
+		}
+		state.Loaded = true
+		state.SizeVRAM = loaded.SizeVRAM
+		state.ExpiresAt = loaded.ExpiresAt
+		if state.SizeBytes == 0 {
+			state.SizeBytes = loaded.Size
+		}
+	}
+
+	result := make([]providergateway.LocalModelState, 0, len(states))
+	for _, state := range states {
+		result = append(result, *state)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Model < result[j].Model
+	})
+	return result, nil
+}
+
+// PullModel downloads a model, reporting each NDJSON progress line until the pull succeeds.
+func (o *Ollama) PullModel(ctx context.Context, model string, progress func(providergateway.ModelPullProgress)) error {
+
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return fmt.Errorf("model required")
+	}
+	response, err := o.post(ctx, "/api/pull", map[string]any{"model": model, "stream": true})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLine)
+	status := ""
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var event pullResponse
+		if err := json.Unmarshal(line, &event); err != nil {
+			return fmt.Errorf("decode pull progress: %w", err)
+		}
+		if event.Error != "" {
+			return fmt.Errorf("pull %s: %s", model, event.Error)
+		}
+		status = event.Status
+		if progress != nil {
+			progress(providergateway.ModelPullProgress{
+				Status:    event.Status,
+				Digest:    event.Digest,
+				Total:     event.Total,
+				Completed: event.Completed,
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read pull progress: %w", err)
+	}
+	if status != "success" {
+		return fmt.Errorf("pull %s: ended with status %q", model, status)
+	}
+	return nil
+}
+
+// LoadModel loads a model into memory ahead of the first chat so the first reply is not delayed.
+func (o *Ollama) LoadModel(ctx context.Context, model string) error {
+
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return fmt.Errorf("model required")
+	}
+	var result struct {
+		DoneReason string `json:"done_reason"`
+	}
+	if err := o.postJSON(ctx, "/api/generate", map[string]any{"model": model, "stream": false}, &result); err != nil {
+		return err
+	}
+	if result.DoneReason != "" && result.DoneReason != "load" {
+		return fmt.Errorf("load %s: unexpected done reason %q", model, result.DoneReason)
+	}
+	return nil
+}
+
+// firstNonEmpty returns the first non-empty value.
+func firstNonEmpty(values ...string) string {
+
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
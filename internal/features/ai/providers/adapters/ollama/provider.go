@@ -0,0 +1,493 @@
+// provider.go implements the Ollama local model provider adapter over the native API.
+// internal/features/ai/providers/adapters/ollama/provider.go
+package ollama
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	providerhttp "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/httpcompat"
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+type Model = providercore.Model
+type Config = providercore.ProviderConfig
+type ChatOptions = providergateway.ChatOptions
+type ProviderMessage = providergateway.ProviderMessage
+type Chunk = providergateway.Chunk
+type CredentialField = providercore.CredentialField
+type UsageStats = providergateway.UsageStats
+type Provider = providercore.Provider
+type HTTPClient = providerhttp.Client
+
+const (
+	CredentialAPIKey = providercore.CredentialAPIKey
+	DefaultBaseURL   = "http://localhost:11434"
+)
+
+// maxStreamLine bounds one NDJSON line; tool call arguments can be large.
+const maxStreamLine = 4 * 1024 * 1024
+
+// Ollama implements the Provider interface for a local or remote Ollama server.
+type Ollama struct {
+	name        string
+	displayName string
+	baseURL     string
+	apiKey      string
+	models      []Model
+	client      HTTPClient
+}
+
+var _ Provider = (*Ollama)(nil)
+var _ providergateway.CapabilityAdvertiser = (*Ollama)(nil)
+var _ providergateway.LocalModelManager = (*Ollama)(nil)
+
+// New creates a new Ollama provider.
+func New(config Config) *Ollama {
+
+	baseURL := normalizeBaseURL(config.BaseURL)
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	provider := &Ollama{
+		name:        config.Name,
+		displayName: config.DisplayName,
+		baseURL:     baseURL,
+		apiKey:      strings.TrimSpace(config.APIKey),
+		models:      config.Models,
+		// Cold model loads and long generations outlast the shared default timeout; requests rely on ctx instead.
+		client: &http.Client{},
+	}
+	_ = provider.Configure(Config{Credentials: config.Credentials})
+	return provider
+}
+
+// Name returns the provider identifier.
+func (o *Ollama) Name() string {
+
+	return o.name
+}
+
+// DisplayName returns the human-readable provider name.
+func (o *Ollama) DisplayName() string {
+
+	return o.displayName
+}
+
+// Models returns the available models.
+func (o *Ollama) Models() []Model {
+
+	return o.models
+}
+
+// CredentialFields returns the expected credential inputs; a local server needs none.
+func (o *Ollama) CredentialFields() []CredentialField {
+
+	return []CredentialField{
+		{
+			Name:        CredentialAPIKey,
+			Label:       "API Key (optional)",
+			Required:    false,
+			Secret:      true,
+			Placeholder: "Bearer token",
+			Help:        "Only needed when Ollama runs behind an authenticating proxy or a hosted endpoint.",
+		},
+	}
+}
+
+// Configure updates the provider configuration.
+func (o *Ollama) Configure(config Config) error {
+
+	if config.Credentials != nil {
+		if value, ok := config.Credentials[CredentialAPIKey]; ok {
+			o.apiKey = strings.TrimSpace(value)
+		}
+	}
+	if strings.TrimSpace(config.APIKey) != "" {
+		o.apiKey = strings.TrimSpace(config.APIKey)
+	}
+	if config.BaseURL != "" {
+		o.baseURL = normalizeBaseURL(config.BaseURL)
+	}
+	if config.Models != nil {
+		o.models = config.Models
+	}
+	return nil
+}
+
+// normalizeBaseURL strips trailing slashes and an /api or /v1 suffix so native paths can be appended.
+func normalizeBaseURL(raw string) string {
+
+	normalized := strings.TrimRight(strings.TrimSpace(raw), "/")
+	for _, suffix := range []string{"/api", "/v1"} {
+		if strings.HasSuffix(strings.ToLower(normalized), suffix) {
+			normalized = strings.TrimRight(normalized[:len(normalized)-len(suffix)], "/")
+		}
+	}
+	return normalized
+}
+
+// SetHTTPClient overrides the HTTP client used by the provider.
+func (o *Ollama) SetHTTPClient(client HTTPClient) {
+
+	if client != nil {
+		o.client = client
+	}
+}
+
+// TestConnection verifies the server is reachable.
+func (o *Ollama) TestConnection(ctx context.Context) error {
+
+	var version struct {
+		Version string `json:"version"`
+	}
+	return o.getJSON(ctx, "/api/version", &version)
+}
+
+// GatewayCapabilities describes the semantic capabilities served by the Ollama adapter.
+func (o *Ollama) GatewayCapabilities() []providergateway.CapabilityDescriptor {
+
+	return []providergateway.CapabilityDescriptor{
+		{
+			ID:          providergateway.CapabilityChatText,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputText},
+			Interaction: providergateway.InteractionStreaming,
+		},
+		{
+			ID:          providergateway.CapabilityAgentToolUse,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputText, providergateway.OutputToolCalls},
+			Interaction: providergateway.InteractionStreaming,
+		},
+	}
+}
+
+// chatRequest is the native /api/chat request body.
+type chatRequest struct {
+	Model    string         `json:"model"`
+	Messages []chatMessage  `json:"messages"`
+	Stream   bool           `json:"stream"`
+	Tools    []chatTool     `json:"tools,omitempty"`
+	Options  map[string]any `json:"options,omitempty"`
+}
+
+// chatMessage is a native chat message, including tool calls returned by the model and the
+// tool name a tool result answers.
+type chatMessage struct {
+	Role      string         `json:"role"`
+	Content   string         `json:"content"`
+	ToolCalls []chatToolCall `json:"tool_calls,omitempty"`
+	ToolName  string         `json:"tool_name,omitempty"`
+}
+
+// chatTool declares a function the model may call.
+type chatTool struct {
+	Type     string           `json:"type"`
+	Function chatToolFunction `json:"function"`
+}
+
+// chatToolFunction describes a callable function.
+type chatToolFunction struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+// chatToolCall is a function call emitted by the model.
+type chatToolCall struct {
+	Function struct {
+		Name      string         `json:"name"`
+		Arguments map[string]any `json:"arguments"`
+	} `json:"function"`
+}
+
+// chatResponse is one NDJSON line of a native chat response.
+type chatResponse struct {
+	Model           string      `json:"model"`
+	Message         chatMessage `json:"message"`
+	Done            bool        `json:"done"`
+	DoneReason      string      `json:"done_reason"`
+	PromptEvalCount int         `json:"prompt_eval_count"`
+	EvalCount       int         `json:"eval_count"`
+	Error           string      `json:"error"`
+}
+
+// Chat streams a completion through the native /api/chat NDJSON endpoint.
+func (o *Ollama) Chat(ctx context.Context, messages []ProviderMessage, opts ChatOptions) (<-chan Chunk, error) {
+
+	if strings.TrimSpace(opts.Model) == "" {
+		return nil, fmt.Errorf("model required")
+	}
+
+	request := chatRequest{
+		Model:    opts.Model,
+		Messages: make([]chatMessage, 0, len(messages)),
+		Stream:   opts.Stream,
+		Options:  chatModelOptions(opts),
+	}
+	request.Messages = toChatMessages(messages)
+	if len(request.Messages) == 0 {
+		return nil, fmt.Errorf("messages required")
+	}
+	for _, tool := range opts.Tools {
+		request.Tools = append(request.Tools, chatTool{
+			Type:     "function",
+			Function: chatToolFunction{Name: tool.Name, Description: tool.Description, Parameters: tool.Parameters},
+		})
+	}
+
+	response, err := o.post(ctx, "/api/chat", request)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(chan Chunk, 100)
+	go func() {
+		defer close(chunks)
+		defer func() { _ = response.Body.Close() }()
+		o.streamChat(ctx, response.Body, chunks)
+	}()
+	return chunks, nil
+}
+
+// toChatMessages converts provider messages into native messages.
+// Ollama matches tool results to calls by function name, so each tool message is named after
+// the call with its ToolCallID in the preceding assistant turn.
+func toChatMessages(messages []ProviderMessage) []chatMessage {
+
+	converted := make([]chatMessage, 0, len(messages))
+	callNames := make(map[string]string)
+	for _, msg := range messages {
+		if strings.TrimSpace(msg.Content) == "" && len(msg.ToolCalls) == 0 && msg.Role != providergateway.RoleTool {
+			continue
+		}
+		message := chatMessage{Role: string(msg.Role), Content: msg.Content}
+		for _, call := range msg.ToolCalls {
+			var native chatToolCall
+			native.Function.Name = call.Name
+			native.Function.Arguments = call.Arguments
+			if native.Function.Arguments == nil {
+				native.Function.Arguments = map[string]any{}
+			}
+			message.ToolCalls = append(message.ToolCalls, native)
+			callNames[call.ID] = call.Name
+		}
+		if msg.Role == providergateway.RoleTool {
+			message.ToolName = callNames[msg.ToolCallID]
+		}
+		converted = append(converted, message)
+	}
+	return converted
+}
+
+// chatModelOptions maps chat options onto Ollama model options.
+func chatModelOptions(opts ChatOptions) map[string]any {
+
+	options := make(map[string]any)
+	if opts.Temperature > 0 {
+		options["temperature"] = opts.Temperature
+	}
+	if opts.MaxTokens > 0 {
+		options["num_predict"] = opts.MaxTokens
+	}
+	if len(opts.StopWords) > 0 {
+		options["stop"] = opts.StopWords
+	}
+	if len(options) == 0 {
+		return nil
+	}
+	return options
+}
+
+// streamChat decodes NDJSON chat lines into chunks until the final line or an error.
+// Ollama sends tool calls without IDs, so each call is numbered across the whole response under a
+// random per-response prefix, keeping IDs unique between lines and between tool rounds.
+func (o *Ollama) streamChat(ctx context.Context, body io.Reader, chunks chan<- Chunk) {
+
+	send := func(chunk Chunk) bool {
+		select {
+		case chunks <- chunk:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	callPrefix := newToolCallPrefix()
+	callCount := 0
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLine)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var event chatResponse
+		if err := json.Unmarshal(line, &event); err != nil {
+			send(Chunk{Error: fmt.Errorf("decode chat stream: %w", err)})
+			return
+		}
+		if event.Error != "" {
+			send(Chunk{Error: fmt.Errorf("ollama: %s", event.Error)})
+			return
+		}
+
+		chunk := Chunk{Content: event.Message.Content, Model: event.Model}
+		for _, call := range event.Message.ToolCalls {
+			callCount++
+			chunk.ToolCalls = append(chunk.ToolCalls, providergateway.ToolCall{
+				ID:        fmt.Sprintf("call_%s_%d", callPrefix, callCount),
+				Name:      call.Function.Name,
+				Arguments: call.Function.Arguments,
+			})
+		}
+		if event.Done {
+			chunk.FinishReason = event.DoneReason
+			if chunk.FinishReason == "" {
+				chunk.FinishReason = "stop"
+			}
+			if event.PromptEvalCount > 0 || event.EvalCount > 0 {
+				chunk.Usage = &UsageStats{
+					PromptTokens:     event.PromptEvalCount,
+					CompletionTokens: event.EvalCount,
+					TotalTokens:      event.PromptEvalCount + event.EvalCount,
+				}
+			}
+			send(chunk)
+			return
+		}
+		if chunk.Content == "" && len(chunk.ToolCalls) == 0 {
+			continue
+		}
+		if !send(chunk) {
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		send(Chunk{Error: fmt.Errorf("read chat stream: %w", err)})
+		return
+	}
+	send(Chunk{Error: fmt.Errorf("chat stream ended before completion")})
+}
+
+// newToolCallPrefix returns a random prefix for the tool-call IDs of one response.
+func newToolCallPrefix() string {
+
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// GenerateImage returns not supported error.
+func (o *Ollama) GenerateImage(ctx context.Context, opts providergateway.ImageGenerationOptions) (*providergateway.ImageResult, error) {
+	return nil, fmt.Errorf("ollama provider does not support image generation")
+}
+
+// EditImage returns not supported error.
+func (o *Ollama) EditImage(ctx context.Context, opts providergateway.ImageEditOptions) (*providergateway.ImageResult, error) {
+	return nil, fmt.Errorf("edit image not supported by this provider")
+}
+
+// getJSON issues a GET request and decodes the JSON response.
+func (o *Ollama) getJSON(ctx context.Context, path string, target any) error {
+
+	request, err := o.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	response, err := o.do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+	if err := json.NewDecoder(response.Body).Decode(target); err != nil {
+		return fmt.Errorf("decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// postJSON issues a POST request and decodes a single JSON response.
+func (o *Ollama) postJSON(ctx context.Context, path string, payload any, target any) error {
+
+	response, err := o.post(ctx, path, payload)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+	if target == nil {
+		_, _ = io.Copy(io.Discard, response.Body)
+		return nil
+	}
+	if err := json.NewDecoder(response.Body).Decode(target); err != nil {
+		return fmt.Errorf("decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// post issues a JSON POST request and returns the open response for the caller to read.
+func (o *Ollama) post(ctx context.Context, path string, payload any) (*http.Response, error) {
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s request: %w", path, err)
+	}
+	request, err := o.newRequest(ctx, http.MethodPost, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	return o.do(request)
+}
+
+// newRequest builds a request against the configured server with optional bearer auth.
+func (o *Ollama) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+
+	request, err := http.NewRequestWithContext(ctx, method, o.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	if o.apiKey != "" {
+		request.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
+	return request, nil
+}
+
+// do sends a request and converts error statuses into APIError using Ollama's {"error": "..."} body.
+func (o *Ollama) do(request *http.Request) (*http.Response, error) {
+
+	if o.client == nil {
+		o.client = &http.Client{}
+	}
+	response, err := o.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < http.StatusBadRequest {
+		return response, nil
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 64*1024))
+	message := strings.TrimSpace(string(body))
+	var failure struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &failure); err == nil && failure.Error != "" {
+		message = failure.Error
+	}
+	return nil, &providerhttp.APIError{Code: response.StatusCode, Message: message}
+}
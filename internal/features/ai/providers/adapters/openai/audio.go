@@ -0,0 +1,112 @@
+// audio.go implements OpenAI speech-to-text and text-to-speech for the OpenAI adapter.
+// internal/features/ai/providers/adapters/openai/audio.go
+package openai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	providerhttp "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/httpcompat"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+	openaisdk "github.com/openai/openai-go"
+)
+
+const (
+	defaultTranscriptionModel = "whisper-1"
+	defaultSpeechModel        = "tts-1"
+	defaultSpeechVoice        = "alloy"
+	defaultSpeechFormat       = "mp3"
+)
+
+var _ providergateway.Transcriber = (*OpenAI)(nil)
+var _ providergateway.Synthesizer = (*OpenAI)(nil)
+
+// Transcribe converts recorded speech to text, using the SDK for the OpenAI API and the compat helper elsewhere.
+func (o *OpenAI) Transcribe(ctx context.Context, opts providergateway.TranscriptionOptions) (*providergateway.TranscriptionResult, error) {
+
+	if len(opts.Audio) == 0 {
+		return nil, fmt.Errorf("audio input required")
+	}
+	if strings.TrimSpace(opts.Model) == "" {
+		opts.Model = defaultTranscriptionModel
+	}
+	if opts.Filename == "" {
+		opts.Filename = "audio.wav"
+	}
+	if !o.usesOpenAISDK() {
+		return providerhttp.TranscribeOpenAICompat(ctx, o.httpClient(), o.baseURL, o.authHeaders(), opts)
+	}
+
+	params := openaisdk.AudioTranscriptionNewParams{
+		File:  openaisdk.File(bytes.NewReader(opts.Audio), opts.Filename, providergateway.AudioMIMEType(opts.Filename)),
+		Model: openaisdk.AudioModel(opts.Model),
+	}
+	if opts.Language != "" {
+		params.Language = openaisdk.String(opts.Language)
+	}
+	if opts.Prompt != "" {
+		params.Prompt = openaisdk.String(opts.Prompt)
+	}
+
+	client := o.newSDKClient()
+	resp, err := client.Audio.Transcriptions.New(ctx, params)
+	if err != nil {
+		return nil, o.wrapOpenAIError(err)
+	}
+	return &providergateway.TranscriptionResult{
+		Model:    opts.Model,
+		Text:     strings.TrimSpace(resp.Text),
+		Language: opts.Language,
+	}, nil
+}
+
+// Synthesize converts text to spoken audio, using the SDK for the OpenAI API and the compat helper elsewhere.
+func (o *OpenAI) Synthesize(ctx context.Context, opts providergateway.SpeechOptions) (*providergateway.SpeechResult, error) {
+
+	if strings.TrimSpace(opts.Input) == "" {
+		return nil, fmt.Errorf("speech input required")
+	}
+	if strings.TrimSpace(opts.Model) == "" {
+		opts.Model = defaultSpeechModel
+	}
+	if opts.Voice == "" {
+		opts.Voice = defaultSpeechVoice
+	}
+	if opts.Format == "" {
+		opts.Format = defaultSpeechFormat
+	}
+	if !o.usesOpenAISDK() {
+		return providerhttp.SynthesizeOpenAICompat(ctx, o.httpClient(), o.baseURL, o.authHeaders(), opts)
+	}
+
+	params := openaisdk.AudioSpeechNewParams{
+		Input:          opts.Input,
+		Model:          openaisdk.SpeechModel(opts.Model),
+		Voice:          openaisdk.AudioSpeechNewParamsVoice(opts.Voice),
+		ResponseFormat: openaisdk.AudioSpeechNewParamsResponseFormat(opts.Format),
+	}
+	if opts.Speed > 0 {
+		params.Speed = openaisdk.Float(opts.Speed)
+	}
+
+	client := o.newSDKClient()
+	resp, err := client.Audio.Speech.New(ctx, params)
+	if err != nil {
+		return nil, o.wrapOpenAIError(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	audio, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read speech response: %w", err)
+	}
+	return &providergateway.SpeechResult{
+		Model:    opts.Model,
+		Audio:    audio,
+		Format:   opts.Format,
+		MIMEType: providergateway.AudioMIMEType(opts.Format),
+	}, nil
+}
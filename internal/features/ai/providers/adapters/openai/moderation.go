@@ -0,0 +1,53 @@
+// moderation.go implements content moderation for the OpenAI adapter.
+// internal/features/ai/providers/adapters/openai/moderation.go
+package openai
+
+import (
+	"context"
+	"fmt"
+
+	providerhttp "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/httpcompat"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+	openaisdk "github.com/openai/openai-go"
+)
+
+const defaultModerationModel = "omni-moderation-latest"
+
+var _ providergateway.Moderator = (*OpenAI)(nil)
+
+// Moderate classifies text with the moderation endpoint, using the SDK for the OpenAI API and the compat helper elsewhere.
+func (o *OpenAI) Moderate(ctx context.Context, opts providergateway.ModerationOptions) (*providergateway.ModerationResult, error) {
+
+	if len(opts.Input) == 0 {
+		return nil, fmt.Errorf("moderation input required")
+	}
+	if !o.usesOpenAISDK() {
+		return providerhttp.ModerateOpenAICompat(ctx, o.httpClient(), o.baseURL, o.authHeaders(), opts)
+	}
+
+	model := opts.Model
+	if model == "" {
+		model = defaultModerationModel
+	}
+	client := o.newSDKClient()
+	resp, err := client.Moderations.New(ctx, openaisdk.ModerationNewParams{
+		Input: openaisdk.ModerationNewParamsInputUnion{OfStringArray: opts.Input},
+		Model: openaisdk.ModerationModel(model),
+	})
+	if err != nil {
+		return nil, o.wrapOpenAIError(err)
+	}
+	if len(resp.Results) != len(opts.Input) {
+		return nil, fmt.Errorf("expected %d moderation results, got %d", len(opts.Input), len(resp.Results))
+	}
+
+	result := &providergateway.ModerationResult{Model: resp.Model}
+	for _, item := range resp.Results {
+		verdict, err := providerhttp.ParseOpenAICompatModeration([]byte(item.RawJSON()))
+		if err != nil {
+			return nil, err
+		}
+		result.Verdicts = append(result.Verdicts, verdict)
+	}
+	return result, nil
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 
@@ -45,6 +46,9 @@ type OpenAI struct {
 }
 
 var _ Provider = (*OpenAI)(nil)
+var _ providergateway.CapabilityAdvertiser = (*OpenAI)(nil)
+var _ providergateway.ImageVariationProvider = (*OpenAI)(nil)
+var _ providergateway.Embedder = (*OpenAI)(nil)
 
 // New creates a new OpenAI provider.
 func New(config Config) *OpenAI {
@@ -60,7 +64,6 @@ func New(config Config) *OpenAI {
 		baseURL:     baseURL,
 		apiKey:      config.APIKey,
 		models:      config.Models,
-		client:      providerhttp.NewDefaultClient(),
 	}
 }
 
@@ -124,10 +127,11 @@ func (o *OpenAI) SetHTTPClient(client HTTPClient) {
 }
 
 // httpClient returns the configured HTTP client or a default client.
+// The default is not cached so SDK calls keep the SDK's own client unless one was injected.
 func (o *OpenAI) httpClient() HTTPClient {
 
 	if o.client == nil {
-		o.client = providerhttp.NewDefaultClient()
+		return providerhttp.NewDefaultClient()
 	}
 	return o.client
 }
@@ -158,6 +162,9 @@ func (o *OpenAI) ListResources(ctx context.Context) ([]Model, error) {
 // Chat implements streaming chat completion.
 func (o *OpenAI) Chat(ctx context.Context, messages []ProviderMessage, opts ChatOptions) (<-chan Chunk, error) {
 
+	if err := providergateway.RejectToolTurns(messages); err != nil {
+		return nil, err
+	}
 	if o.usesOpenAISDK() {
 		return o.chatSDK(ctx, messages, opts)
 	}
@@ -203,6 +210,9 @@ func (o *OpenAI) newSDKClient() openaisdk.Client {
 	if o.baseURL != "" {
 		opts = append(opts, option.WithBaseURL(o.normalizeBaseURL()))
 	}
+	if o.client != nil {
+		opts = append(opts, option.WithHTTPClient(o.client))
+	}
 	return openaisdk.NewClient(opts...)
 }
 
@@ -418,6 +428,172 @@ func (o *OpenAI) GenerateImage(ctx context.Context, opts providergateway.ImageGe
 		return nil, o.wrapOpenAIError(err)
 	}
 
+	return o.toImageResult(resp), nil
+}
+
+// CreateImageVariation creates variations of an existing image using the OpenAI SDK.
+func (o *OpenAI) CreateImageVariation(ctx context.Context, opts providergateway.ImageVariationOptions) (*providergateway.ImageResult, error) {
+
+	if !o.usesOpenAISDK() {
+		return nil, providergateway.NewCapabilityError(o.name, providergateway.CapabilityGenerateImageVariation, "image variations require the OpenAI API")
+	}
+
+	image, err := os.Open(opts.Image)
+	if err != nil {
+		return nil, fmt.Errorf("open image: %w", err)
+	}
+	defer func() { _ = image.Close() }()
+
+	params := openaisdk.ImageNewVariationParams{
+		Image: image,
+		Model: openaisdk.ImageModelDallE2,
+	}
+	if opts.Model != "" {
+		params.Model = openaisdk.ImageModel(opts.Model)
+	}
+	if opts.N > 0 {
+		params.N = openaisdk.Int(int64(opts.N))
+	}
+	if opts.Size != "" {
+		params.Size = openaisdk.ImageNewVariationParamsSize(opts.Size)
+	}
+	if opts.ResponseFormat != "" {
+		params.ResponseFormat = openaisdk.ImageNewVariationParamsResponseFormat(opts.ResponseFormat)
+	}
+	if opts.User != "" {
+		params.User = openaisdk.String(opts.User)
+	}
+
+	client := o.newSDKClient()
+	resp, err := client.Images.NewVariation(ctx, params)
+	if err != nil {
+		return nil, o.wrapOpenAIError(err)
+	}
+
+	return o.toImageResult(resp), nil
+}
+
+// Embed creates text embeddings, using the SDK for the OpenAI API and the compat helper elsewhere.
+func (o *OpenAI) Embed(ctx context.Context, opts providergateway.EmbeddingOptions) (*providergateway.EmbeddingResult, error) {
+
+	if !o.usesOpenAISDK() {
+		return providerhttp.EmbedOpenAICompat(ctx, o.httpClient(), o.baseURL, o.authHeaders(), opts)
+	}
+
+	params := openaisdk.EmbeddingNewParams{
+		Model:          openaisdk.EmbeddingModel(opts.Model),
+		Input:          openaisdk.EmbeddingNewParamsInputUnion{OfArrayOfStrings: opts.Input},
+		EncodingFormat: openaisdk.EmbeddingNewParamsEncodingFormatFloat,
+	}
+	if opts.Dimensions > 0 {
+		params.Dimensions = openaisdk.Int(int64(opts.Dimensions))
+	}
+	if opts.User != "" {
+		params.User = openaisdk.String(opts.User)
+	}
+
+	client := o.newSDKClient()
+	resp, err := client.Embeddings.New(ctx, params)
+	if err != nil {
+		return nil, o.wrapOpenAIError(err)
+	}
+
+	items := make([]providerhttp.IndexedEmbedding, 0, len(resp.Data))
+	for _, item := range resp.Data {
+		vector := make([]float32, len(item.Embedding))
+		for i, value := range item.Embedding {
+			vector[i] = float32(value)
+		}
+		items = append(items, providerhttp.IndexedEmbedding{Index: int(item.Index), Vector: vector})
+	}
+	embeddings, err := providerhttp.OrderEmbeddings(len(opts.Input), items)
+	if err != nil {
+		return nil, err
+	}
+	return &providergateway.EmbeddingResult{
+		Model:      resp.Model,
+		Embeddings: embeddings,
+		Usage: &UsageStats{
+			PromptTokens: int(resp.Usage.PromptTokens),
+			TotalTokens:  int(resp.Usage.TotalTokens),
+		},
+	}, nil
+}
+
+// MaxEmbeddingBatch returns the OpenAI per-request input limit.
+func (o *OpenAI) MaxEmbeddingBatch() int {
+
+	return providerhttp.OpenAICompatEmbeddingBatchLimit
+}
+
+// GatewayCapabilities describes the semantic capabilities served by the OpenAI adapter.
+func (o *OpenAI) GatewayCapabilities() []providergateway.CapabilityDescriptor {
+
+	return []providergateway.CapabilityDescriptor{
+		{
+			ID:          providergateway.CapabilityChatText,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputText},
+			Interaction: providergateway.InteractionStreaming,
+		},
+		{
+			ID:          providergateway.CapabilityGenerateImage,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputImage},
+			Interaction: providergateway.InteractionSingle,
+		},
+		{
+			ID:          providergateway.CapabilityGenerateImageVariation,
+			Inputs:      []providergateway.InputType{providergateway.InputImage},
+			Outputs:     []providergateway.OutputType{providergateway.OutputImage},
+			Interaction: providergateway.InteractionSingle,
+			Controls: []providergateway.ControlDescriptor{
+				{Name: "n", Type: "integer", Description: "Number of variations (1-10)."},
+				{Name: "size", Type: "string", Description: "256x256, 512x512, or 1024x1024."},
+			},
+		},
+		{
+			ID:          providergateway.CapabilityRetrievalEmbedText,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputEmbedding},
+			Interaction: providergateway.InteractionBatch,
+			Controls: []providergateway.ControlDescriptor{
+				{Name: "dimensions", Type: "integer", Description: "Output vector size (text-embedding-3 models only)."},
+			},
+		},
+		{
+			ID:          providergateway.CapabilitySpeechASR,
+			Inputs:      []providergateway.InputType{providergateway.InputAudio},
+			Outputs:     []providergateway.OutputType{providergateway.OutputText},
+			Interaction: providergateway.InteractionSingle,
+			Controls: []providergateway.ControlDescriptor{
+				{Name: "language", Type: "string", Description: "ISO-639-1 language hint."},
+				{Name: "prompt", Type: "string", Description: "Text that guides spelling and style."},
+			},
+		},
+		{
+			ID:          providergateway.CapabilitySpeechTTS,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputAudio},
+			Interaction: providergateway.InteractionSingle,
+			Controls: []providergateway.ControlDescriptor{
+				{Name: "voice", Type: "string", Description: "alloy, ash, coral, echo, sage, shimmer, and others."},
+				{Name: "format", Type: "string", Description: "mp3, opus, aac, flac, wav, or pcm."},
+				{Name: "speed", Type: "number", Description: "Playback speed from 0.25 to 4.0."},
+			},
+		},
+		{
+			ID:          providergateway.CapabilitySafetyModeration,
+			Inputs:      []providergateway.InputType{providergateway.InputText},
+			Outputs:     []providergateway.OutputType{providergateway.OutputSafetyLabels},
+			Interaction: providergateway.InteractionBatch,
+		},
+	}
+}
+
+// toImageResult converts an SDK images response into a gateway image result.
+func (o *OpenAI) toImageResult(resp *openaisdk.ImagesResponse) *providergateway.ImageResult {
+
 	result := &providergateway.ImageResult{
 		Created: resp.Created,
 		Data:    make([]providergateway.ImageData, len(resp.Data)),
@@ -431,7 +607,7 @@ func (o *OpenAI) GenerateImage(ctx context.Context, opts providergateway.ImageGe
 		}
 	}
 
-	return result, nil
+	return result
 }
 
 // EditImage returns not supported error.
@@ -0,0 +1,189 @@
+// provider_test.go verifies OpenAI image variation and embedding requests.
+// internal/features/ai/providers/adapters/openai/provider_test.go
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// TestCreateImageVariationUploadsImage verifies the multipart upload, parameters, and result mapping.
+func TestCreateImageVariationUploadsImage(t *testing.T) {
+
+	var gotPath, gotAuth, gotModel, gotN, gotFormat string
+	var gotImage []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("parse multipart: %v", err)
+		}
+		gotModel, gotN, gotFormat = r.FormValue("model"), r.FormValue("n"), r.FormValue("response_format")
+		if file, _, err := r.FormFile("image"); err == nil {
+			gotImage, _ = io.ReadAll(file)
+			_ = file.Close()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"created":7,"data":[{"b64_json":"AAA="},{"b64_json":"BBB="}]}`))
+	}))
+	defer server.Close()
+
+	provider := New(Config{Name: "openai", APIKey: "sk-test"})
+	provider.SetHTTPClient(redirectedClient(t, server.URL))
+
+	result, err := provider.CreateImageVariation(context.Background(), providergateway.ImageVariationOptions{
+		Image:          writeTestImage(t),
+		N:              2,
+		ResponseFormat: "b64_json",
+	})
+	if err != nil {
+		t.Fatalf("create variation: %v", err)
+	}
+
+	if gotPath != "/v1/images/variations" || gotAuth != "Bearer sk-test" {
+		t.Fatalf("unexpected request path=%s auth=%q", gotPath, gotAuth)
+	}
+	if gotModel != "dall-e-2" || gotN != "2" || gotFormat != "b64_json" {
+		t.Fatalf("unexpected parameters model=%q n=%q format=%q", gotModel, gotN, gotFormat)
+	}
+	if string(gotImage) != string(testImageBytes) {
+		t.Fatalf("expected uploaded image bytes, got %q", gotImage)
+	}
+	if result.Created != 7 || len(result.Data) != 2 || result.Data[1].B64JSON != "BBB=" {
+		t.Fatalf("unexpected result %#v", result)
+	}
+}
+
+// TestCreateImageVariationRequiresOpenAIAPI verifies compatible endpoints report the capability as unsupported.
+func TestCreateImageVariationRequiresOpenAIAPI(t *testing.T) {
+
+	provider := New(Config{Name: "groq", BaseURL: "https://api.groq.com/openai/v1", APIKey: "key"})
+	_, err := provider.CreateImageVariation(context.Background(), providergateway.ImageVariationOptions{Image: writeTestImage(t)})
+	if !errors.Is(err, providergateway.ErrCapabilityUnsupported) {
+		t.Fatalf("expected unsupported capability error, got %v", err)
+	}
+}
+
+// TestOpenAIDoesNotUpscale verifies the adapter neither implements nor advertises upscaling.
+func TestOpenAIDoesNotUpscale(t *testing.T) {
+
+	var provider any = New(Config{Name: "openai"})
+	if _, ok := provider.(providergateway.ImageUpscaleProvider); ok {
+		t.Fatalf("expected OpenAI not to implement upscaling")
+	}
+	if providergateway.AdvertisesCapability(provider, providergateway.CapabilityVisionUpscaleImage) {
+		t.Fatalf("expected upscaling not to be advertised")
+	}
+}
+
+// TestEmbedMapsVectorsByIndex verifies out-of-order vectors land in their input slots.
+func TestEmbedMapsVectorsByIndex(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"model":"text-embedding-3-small","data":[` +
+			`{"index":1,"embedding":[2]},{"index":0,"embedding":[1]}],"usage":{"prompt_tokens":4,"total_tokens":4}}`))
+	}))
+	defer server.Close()
+
+	provider := New(Config{Name: "openai", APIKey: "sk-test"})
+	provider.SetHTTPClient(redirectedClient(t, server.URL))
+
+	result, err := provider.Embed(context.Background(), providergateway.EmbeddingOptions{
+		Model: "text-embedding-3-small",
+		Input: []string{"first", "second"},
+	})
+	if err != nil {
+		t.Fatalf("embed: %v", err)
+	}
+	if len(result.Embeddings) != 2 || result.Embeddings[0][0] != 1 || result.Embeddings[1][0] != 2 {
+		t.Fatalf("unexpected embeddings %v", result.Embeddings)
+	}
+}
+
+// TestEmbedRejectsMalformedResponses verifies bad indices and short responses fail instead of leaving nil vectors.
+func TestEmbedRejectsMalformedResponses(t *testing.T) {
+
+	testCases := []struct {
+		name      string
+		data      string
+		wantError string
+	}{
+		{name: "short response", data: `[{"index":0,"embedding":[1]}]`, wantError: "embedding 1 missing"},
+		{name: "index out of range", data: `[{"index":0,"embedding":[1]},{"index":2,"embedding":[2]}]`, wantError: "out of range"},
+		{name: "negative index", data: `[{"index":-1,"embedding":[1]},{"index":1,"embedding":[2]}]`, wantError: "out of range"},
+		{name: "duplicate index", data: `[{"index":0,"embedding":[1]},{"index":0,"embedding":[2]}]`, wantError: "more than once"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"model":"text-embedding-3-small","data":` + testCase.data + `}`))
+			}))
+			defer server.Close()
+
+			provider := New(Config{Name: "openai", APIKey: "sk-test"})
+			provider.SetHTTPClient(redirectedClient(t, server.URL))
+
+			_, err := provider.Embed(context.Background(), providergateway.EmbeddingOptions{
+				Model: "text-embedding-3-small",
+				Input: []string{"first", "second"},
+			})
+			if err == nil || !strings.Contains(err.Error(), testCase.wantError) {
+				t.Fatalf("expected error containing %q, got %v", testCase.wantError, err)
+			}
+		})
+	}
+}
+
+// testImageBytes is a PNG signature, enough for content type detection.
+var testImageBytes = []byte("\x89PNG\r\n\x1a\n0000")
+
+// writeTestImage writes a small image file and returns its path.
+func writeTestImage(t *testing.T) string {
+
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "source.png")
+	if err := os.WriteFile(path, testImageBytes, 0o600); err != nil {
+		t.Fatalf("write image: %v", err)
+	}
+	return path
+}
+
+// redirectedClient returns an HTTP client that sends every request to target, keeping the path and query.
+func redirectedClient(t *testing.T, target string) *http.Client {
+
+	t.Helper()
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		t.Fatalf("parse target: %v", err)
+	}
+	return &http.Client{Transport: redirectTransport{target: targetURL}}
+}
+
+// redirectTransport rewrites request hosts to a test server.
+type redirectTransport struct {
+	target *url.URL
+}
+
+// RoundTrip sends the request to the test server.
+func (r redirectTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+
+	redirected := request.Clone(request.Context())
+	redirected.URL.Scheme = r.target.Scheme
+	redirected.URL.Host = r.target.Host
+	redirected.Host = r.target.Host
+	return http.DefaultTransport.RoundTrip(redirected)
+}
@@ -0,0 +1,361 @@
+// provider.go implements a configurable adapter for any endpoint that speaks the OpenAI wire format.
+// internal/features/ai/providers/adapters/openaicompat/provider.go
+package openaicompat
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	providerhttp "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/httpcompat"
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+type Model = providercore.Model
+type Config = providercore.ProviderConfig
+type ChatOptions = providergateway.ChatOptions
+type ProviderMessage = providergateway.ProviderMessage
+type Chunk = providergateway.Chunk
+type CredentialField = providercore.CredentialField
+type Provider = providercore.Provider
+type HTTPClient = providerhttp.Client
+
+const (
+	CredentialAPIKey       = providercore.CredentialAPIKey
+	CredentialAuthHeader   = providercore.CredentialAuthHeader
+	CredentialAuthScheme   = providercore.CredentialAuthScheme
+	CredentialExtraHeaders = providercore.CredentialExtraHeaders
+	CredentialModelIDs     = providercore.CredentialModelIDs
+)
+
+const (
+	defaultAuthHeader = "Authorization"
+	defaultAuthScheme = "Bearer"
+	// authSchemeNone sends the API key as the bare header value.
+	authSchemeNone = "none"
+)
+
+// OpenAICompat implements the Provider interface for user-configured OpenAI-compatible endpoints
+// such as LM Studio, vLLM, llama.cpp server, Together, Groq, or DeepSeek.
+type OpenAICompat struct {
+	name         string
+	displayName  string
+	baseURL      string
+	apiKey       string
+	authHeader   string
+	authScheme   string
+	extraHeaders map[string]string
+	modelIDs     []string
+	models       []Model
+	client       HTTPClient
+	// configErr keeps a construction-time credential error so TestConnection can report it.
+	configErr error
+}
+
+var _ Provider = (*OpenAICompat)(nil)
+var _ providergateway.Embedder = (*OpenAICompat)(nil)
+var _ providergateway.Reranker = (*OpenAICompat)(nil)
+
+// New creates a new OpenAI-compatible provider; the base URL comes from configuration.
+func New(config Config) *OpenAICompat {
+
+	provider := &OpenAICompat{
+		name:        config.Name,
+		displayName: config.DisplayName,
+		baseURL:     strings.TrimSpace(config.BaseURL),
+		apiKey:      strings.TrimSpace(config.APIKey),
+		authHeader:  defaultAuthHeader,
+		authScheme:  defaultAuthScheme,
+		models:      config.Models,
+		client:      providerhttp.NewDefaultClient(),
+	}
+	if err := provider.Configure(Config{Credentials: config.Credentials}); err != nil {
+		provider.configErr = fmt.Errorf("openai-compatible provider %s: %w", config.Name, err)
+		if config.Logger != nil {
+			config.Logger.Warn("Invalid provider credentials", err, corelogger.LogField{Key: "provider", Value: config.Name})
+		}
+	}
+	return provider
+}
+
+// Name returns the provider identifier.
+func (o *OpenAICompat) Name() string {
+
+	return o.name
+}
+
+// DisplayName returns the human-readable provider name.
+func (o *OpenAICompat) DisplayName() string {
+
+	return o.displayName
+}
+
+// Models returns the available models.
+func (o *OpenAICompat) Models() []Model {
+
+	return o.models
+}
+
+// CredentialFields returns the expected credential inputs; every field is optional because local servers often need none.
+func (o *OpenAICompat) CredentialFields() []CredentialField {
+
+	return []CredentialField{
+		{
+			Name:        CredentialAPIKey,
+			Label:       "API Key (optional)",
+			Required:    false,
+			Secret:      true,
+			Placeholder: "API key",
+			Help:        "Sent in the auth header. Leave empty for local servers without authentication.",
+		},
+		{
+			Name:        CredentialAuthHeader,
+			Label:       "Auth Header (optional)",
+			Required:    false,
+			Secret:      false,
+			Placeholder: defaultAuthHeader,
+			Help:        "Header that carries the API key, for example api-key or X-API-Key.",
+		},
+		{
+			Name:        CredentialAuthScheme,
+			Label:       "Auth Scheme (optional)",
+			Required:    false,
+			Secret:      false,
+			Placeholder: defaultAuthScheme,
+			Help:        "Prefix placed before the API key. Use \"none\" to send the key on its own.",
+		},
+		{
+			Name:        CredentialExtraHeaders,
+			Label:       "Extra Headers (optional)",
+			Required:    false,
+			Secret:      false,
+			Placeholder: "X-Team: research; X-Env: prod",
+			Help:        "Static headers sent with every request, as Name: value pairs separated by ';' or new lines.",
+		},
+		{
+			Name:        CredentialModelIDs,
+			Label:       "Model IDs (optional)",
+			Required:    false,
+			Secret:      false,
+			Placeholder: "model-a, model-b",
+			Help:        "Comma-separated models to offer when the server has no /models endpoint.",
+		},
+	}
+}
+
+// Configure updates the provider configuration.
+func (o *OpenAICompat) Configure(config Config) error {
+
+	if config.Credentials != nil {
+		if value, ok := config.Credentials[CredentialAPIKey]; ok {
+			o.apiKey = strings.TrimSpace(value)
+		}
+		if value, ok := config.Credentials[CredentialAuthHeader]; ok {
+			o.authHeader = strings.TrimSpace(value)
+			if o.authHeader == "" {
+				o.authHeader = defaultAuthHeader
+			}
+		}
+		if value, ok := config.Credentials[CredentialAuthScheme]; ok {
+			o.authScheme = strings.TrimSpace(value)
+			if o.authScheme == "" {
+				o.authScheme = defaultAuthScheme
+			}
+		}
+		if value, ok := config.Credentials[CredentialExtraHeaders]; ok {
+			headers, err := ParseHeaders(value)
+			if err != nil {
+				return err
+			}
+			o.extraHeaders = headers
+		}
+		if value, ok := config.Credentials[CredentialModelIDs]; ok {
+			o.modelIDs = parseModelIDs(value)
+		}
+	}
+	if strings.TrimSpace(config.APIKey) != "" {
+		o.apiKey = strings.TrimSpace(config.APIKey)
+	}
+	if config.BaseURL != "" {
+		o.baseURL = strings.TrimSpace(config.BaseURL)
+	}
+	if config.Models != nil {
+		o.models = config.Models
+	}
+	o.configErr = nil
+	return nil
+}
+
+// ParseHeaders parses "Name: value" pairs separated by ';' or new lines.
+func ParseHeaders(raw string) (map[string]string, error) {
+
+	entries := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ';' || r == '\n'
+	})
+	headers := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("invalid header %q: expected Name: value", entry)
+		}
+		headers[http.CanonicalHeaderKey(name)] = strings.TrimSpace(value)
+	}
+	if len(headers) == 0 {
+		return nil, nil
+	}
+	return headers, nil
+}
+
+// parseModelIDs splits a comma- or whitespace-separated model list.
+func parseModelIDs(raw string) []string {
+
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	})
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+// SetHTTPClient overrides the HTTP client used by the provider.
+func (o *OpenAICompat) SetHTTPClient(client HTTPClient) {
+
+	if client != nil {
+		o.client = client
+	}
+}
+
+// httpClient returns the configured HTTP client or a default client.
+func (o *OpenAICompat) httpClient() HTTPClient {
+
+	if o.client == nil {
+		o.client = providerhttp.NewDefaultClient()
+	}
+	return o.client
+}
+
+// TestConnection verifies the endpoint is reachable and accepts the credentials.
+func (o *OpenAICompat) TestConnection(ctx context.Context) error {
+
+	if o.configErr != nil {
+		return o.configErr
+	}
+	_, err := o.ListResources(ctx)
+	return err
+}
+
+// ListResources lists models from /models, or returns the configured model IDs after checking the server answers.
+func (o *OpenAICompat) ListResources(ctx context.Context) ([]Model, error) {
+
+	if len(o.modelIDs) == 0 {
+		return providerhttp.ListOpenAICompatModels(ctx, o.httpClient(), o.baseURL, o.requestHeaders())
+	}
+	if err := o.probe(ctx); err != nil {
+		return nil, err
+	}
+
+	models := make([]Model, 0, len(o.modelIDs))
+	for _, id := range o.modelIDs {
+		models = append(models, Model{ID: id, Name: id, SupportsStreaming: true})
+	}
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].ID < models[j].ID
+	})
+	return models, nil
+}
+
+// probe checks that a server without a /models endpoint is reachable and does not reject the credentials.
+func (o *OpenAICompat) probe(ctx context.Context) error {
+
+	baseURL := strings.TrimRight(o.baseURL, "/")
+	if baseURL == "" {
+		return fmt.Errorf("base URL required")
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/models", nil)
+	if err != nil {
+		return err
+	}
+	for name, value := range o.requestHeaders() {
+		request.Header.Set(name, value)
+	}
+
+	response, err := o.httpClient().Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	switch {
+	case response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden,
+		response.StatusCode >= http.StatusInternalServerError:
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 64*1024))
+		return &providerhttp.APIError{Code: response.StatusCode, Message: strings.TrimSpace(string(body))}
+	default:
+		return nil
+	}
+}
+
+// Chat implements streaming chat completion.
+func (o *OpenAICompat) Chat(ctx context.Context, messages []ProviderMessage, opts ChatOptions) (<-chan Chunk, error) {
+
+	return providerhttp.ChatOpenAICompat(ctx, o.httpClient(), o.baseURL, o.requestHeaders(), messages, opts)
+}
+
+// Embed creates text embeddings through the endpoint's /embeddings route.
+func (o *OpenAICompat) Embed(ctx context.Context, opts providergateway.EmbeddingOptions) (*providergateway.EmbeddingResult, error) {
+
+	return providerhttp.EmbedOpenAICompat(ctx, o.httpClient(), o.baseURL, o.requestHeaders(), opts)
+}
+
+// MaxEmbeddingBatch returns the OpenAI input limit; servers with lower limits reject the request with their own error.
+func (o *OpenAICompat) MaxEmbeddingBatch() int {
+
+	return providerhttp.OpenAICompatEmbeddingBatchLimit
+}
+
+// Rerank scores documents through a Cohere- or Jina-style /rerank route, as served by Jina, Cohere's compatibility base URL, vLLM, or TEI.
+func (o *OpenAICompat) Rerank(ctx context.Context, opts providergateway.RerankOptions) (*providergateway.RerankResult, error) {
+
+	return providerhttp.RerankCompat(ctx, o.httpClient(), o.baseURL, o.requestHeaders(), opts)
+}
+
+// requestHeaders combines static headers with the configured auth header; auth wins on conflicts.
+func (o *OpenAICompat) requestHeaders() map[string]string {
+
+	headers := make(map[string]string, len(o.extraHeaders)+1)
+	for name, value := range o.extraHeaders {
+		headers[name] = value
+	}
+	if o.apiKey != "" {
+		value := o.apiKey
+		if !strings.EqualFold(o.authScheme, authSchemeNone) {
+			value = o.authScheme + " " + o.apiKey
+		}
+		headers[http.CanonicalHeaderKey(o.authHeader)] = value
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+	return headers
+}
+
+// GenerateImage returns not supported error.
+func (o *OpenAICompat) GenerateImage(ctx context.Context, opts providergateway.ImageGenerationOptions) (*providergateway.ImageResult, error) {
+	return nil, fmt.Errorf("openai-compatible provider does not support image generation")
+}
+
+// EditImage returns not supported error.
+func (o *OpenAICompat) EditImage(ctx context.Context, opts providergateway.ImageEditOptions) (*providergateway.ImageResult, error) {
+	return nil, fmt.Errorf("edit image not supported by this provider")
+}
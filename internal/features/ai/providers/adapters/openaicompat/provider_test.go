@@ -0,0 +1,286 @@
+// provider_test.go verifies the configurable OpenAI-compatible adapter.
+// internal/features/ai/providers/adapters/openaicompat/provider_test.go
+package openaicompat
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	providerhttp "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/httpcompat"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// TestChatSendsConfiguredAuthAndHeaders verifies custom auth header, scheme, and static headers reach the server.
+func TestChatSendsConfiguredAuthAndHeaders(t *testing.T) {
+
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		switch r.URL.Path {
+		case "/v1/models":
+			_, _ = w.Write([]byte(`{"data":[{"id":"b-model"},{"id":"a-model"}]}`))
+		case "/v1/chat/completions":
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("data: {\"model\":\"a-model\",\"choices\":[{\"delta\":{\"content\":\"hi\"}}]}\n\ndata: [DONE]\n\n"))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	provider := New(Config{
+		Name:    "gateway",
+		BaseURL: server.URL + "/v1/",
+		Credentials: map[string]string{
+			CredentialAPIKey:       "secret",
+			CredentialAuthHeader:   "x-api-key",
+			CredentialAuthScheme:   "none",
+			CredentialExtraHeaders: "X-Team: research; x-env: prod",
+		},
+	})
+	provider.SetHTTPClient(server.Client())
+
+	models, err := provider.ListResources(context.Background())
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(models) != 2 || models[0].ID != "a-model" {
+		t.Fatalf("unexpected models %#v", models)
+	}
+
+	chunks, err := provider.Chat(context.Background(), []ProviderMessage{{Role: providergateway.RoleUser, Content: "hello"}}, ChatOptions{Model: "a-model", Stream: true})
+	if err != nil {
+		t.Fatalf("chat: %v", err)
+	}
+	content := ""
+	for chunk := range chunks {
+		content += chunk.Content
+	}
+	if content != "hi" {
+		t.Fatalf("expected streamed content, got %q", content)
+	}
+	if got.Get("X-Api-Key") != "secret" || got.Get("Authorization") != "" || got.Get("X-Team") != "research" || got.Get("X-Env") != "prod" {
+		t.Fatalf("unexpected headers %v", got)
+	}
+}
+
+// TestListResourcesUsesModelOverride verifies servers without /models use configured IDs after a reachability probe.
+func TestListResourcesUsesModelOverride(t *testing.T) {
+
+	status := http.StatusNotFound
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sk-test" {
+			t.Errorf("expected default bearer auth, got %q", r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	provider := New(Config{
+		Name:        "llamacpp",
+		BaseURL:     server.URL,
+		Credentials: map[string]string{CredentialAPIKey: "sk-test", CredentialModelIDs: "qwen, llama\nmistral"},
+	})
+	provider.SetHTTPClient(server.Client())
+
+	models, err := provider.ListResources(context.Background())
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(models) != 3 || models[0].ID != "llama" || models[2].ID != "qwen" {
+		t.Fatalf("unexpected override models %#v", models)
+	}
+
+	status = http.StatusUnauthorized
+	err = provider.TestConnection(context.Background())
+	if apiErr, ok := err.(*providerhttp.APIError); !ok || apiErr.StatusCode() != http.StatusUnauthorized {
+		t.Fatalf("expected unauthorized API error, got %v", err)
+	}
+}
+
+// TestParseHeaders verifies header parsing and rejection of malformed entries.
+func TestParseHeaders(t *testing.T) {
+
+	testCases := []struct {
+		name    string
+		raw     string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "empty", raw: "  ", want: nil},
+		{name: "semicolons", raw: "x-a: 1; X-B: two words", want: map[string]string{"X-A": "1", "X-B": "two words"}},
+		{name: "newlines", raw: "X-A: 1\nX-C: a:b", want: map[string]string{"X-A": "1", "X-C": "a:b"}},
+		{name: "missing colon", raw: "X-A 1", wantErr: true},
+		{name: "space in name", raw: "X A: 1", wantErr: true},
+	}
+	for _, testCase := range testCases {
+		got, err := ParseHeaders(testCase.raw)
+		if testCase.wantErr {
+			if err == nil {
+				t.Fatalf("%s: expected error", testCase.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", testCase.name, err)
+		}
+		if len(got) != len(testCase.want) {
+			t.Fatalf("%s: expected %v, got %v", testCase.name, testCase.want, got)
+		}
+		for name, value := range testCase.want {
+			if got[name] != value {
+				t.Fatalf("%s: expected %s=%q, got %q", testCase.name, name, value, got[name])
+			}
+		}
+	}
+}
+
+// TestNewReportsInvalidExtraHeaders verifies a malformed extra_headers credential is logged and fails the connection test.
+func TestNewReportsInvalidExtraHeaders(t *testing.T) {
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	logger := &warnRecorder{}
+	provider := New(Config{
+		Name:        "gateway",
+		BaseURL:     server.URL + "/v1",
+		Credentials: map[string]string{CredentialExtraHeaders: "no colon"},
+		Logger:      logger,
+	})
+	provider.SetHTTPClient(server.Client())
+
+	if len(logger.warnings) != 1 || !strings.Contains(logger.warnings[0], "invalid header") {
+		t.Fatalf("expected one invalid header warning, got %v", logger.warnings)
+	}
+	err := provider.TestConnection(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "invalid header") {
+		t.Fatalf("expected invalid header error, got %v", err)
+	}
+	if requests != 0 {
+		t.Fatalf("expected no request with invalid configuration, got %d", requests)
+	}
+
+	if err := provider.Configure(Config{Credentials: map[string]string{CredentialExtraHeaders: "X-Team: research"}}); err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	if err := provider.TestConnection(context.Background()); err != nil {
+		t.Fatalf("expected corrected configuration to connect, got %v", err)
+	}
+}
+
+// TestEmbedPostsOpenAIEmbeddingsRequest verifies the compat embeddings body and index-ordered vectors.
+func TestEmbedPostsOpenAIEmbeddingsRequest(t *testing.T) {
+
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"model":"embed-small","data":[{"index":1,"embedding":[0.3,0.4]},{"index":0,"embedding":[0.1,0.2]}],"usage":{"prompt_tokens":5,"total_tokens":5}}`))
+	}))
+	defer server.Close()
+
+	provider := New(Config{Name: "gateway", BaseURL: server.URL + "/v1"})
+	provider.SetHTTPClient(server.Client())
+
+	result, err := provider.Embed(context.Background(), providergateway.EmbeddingOptions{Model: "embed-small", Input: []string{"a", "b"}, Dimensions: 2})
+	if err != nil {
+		t.Fatalf("embed: %v", err)
+	}
+	if body["dimensions"] != float64(2) || body["model"] != "embed-small" || len(body["input"].([]interface{})) != 2 {
+		t.Fatalf("unexpected request body %v", body)
+	}
+	if len(result.Embeddings) != 2 || result.Embeddings[0][0] != float32(0.1) || result.Embeddings[1][1] != float32(0.4) {
+		t.Fatalf("unexpected embeddings %v", result.Embeddings)
+	}
+	if result.Usage == nil || result.Usage.PromptTokens != 5 {
+		t.Fatalf("unexpected usage %#v", result.Usage)
+	}
+}
+
+// TestRerankPostsCohereStyleRequest verifies the rerank body, score field fallback, and relevance ordering.
+func TestRerankPostsCohereStyleRequest(t *testing.T) {
+
+	testCases := []struct {
+		name     string
+		response string
+	}{
+		{name: "results envelope", response: `{"model":"jina-reranker-v2","results":[{"index":0,"relevance_score":0.12},{"index":2,"relevance_score":0.93}]}`},
+		{name: "bare array", response: `[{"index":2,"score":0.93},{"index":0,"score":0.12}]`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var body map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/rerank" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				_, _ = w.Write([]byte(testCase.response))
+			}))
+			defer server.Close()
+
+			provider := New(Config{Name: "jina", BaseURL: server.URL + "/v1"})
+			provider.SetHTTPClient(server.Client())
+
+			result, err := provider.Rerank(context.Background(), providergateway.RerankOptions{
+				Model:     "jina-reranker-v2",
+				Query:     "capital of France",
+				Documents: []string{"bananas", "spain", "paris"},
+				TopN:      2,
+			})
+			if err != nil {
+				t.Fatalf("rerank: %v", err)
+			}
+			if body["query"] != "capital of France" || body["top_n"] != float64(2) || len(body["documents"].([]interface{})) != 3 {
+				t.Fatalf("unexpected request body %v", body)
+			}
+			if result.Model != "jina-reranker-v2" || len(result.Results) != 2 || result.Results[0].Index != 2 || result.Results[1].Score != 0.12 {
+				t.Fatalf("unexpected result %#v", result)
+			}
+		})
+	}
+}
+
+// warnRecorder captures warning errors logged by the provider.
+type warnRecorder struct {
+	warnings []string
+}
+
+// Trace ignores trace messages.
+func (w *warnRecorder) Trace(string, ...corelogger.LogField) {
+
+}
+
+// Debug ignores debug messages.
+func (w *warnRecorder) Debug(string, ...corelogger.LogField) {
+
+}
+
+// Info ignores info messages.
+func (w *warnRecorder) Info(string, ...corelogger.LogField) {
+
+}
+
+// Warn records the warning error.
+func (w *warnRecorder) Warn(_ string, err error, _ ...corelogger.LogField) {
+
+	w.warnings = append(w.warnings, err.Error())
+}
+
+// Error ignores error messages.
+func (w *warnRecorder) Error(string, error, ...corelogger.LogField) {
+
+}
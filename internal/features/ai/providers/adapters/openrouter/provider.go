@@ -40,6 +40,7 @@ type OpenRouter struct {
 }
 
 var _ Provider = (*OpenRouter)(nil)
+var _ providergateway.Embedder = (*OpenRouter)(nil)
 
 // New creates a new OpenRouter provider.
 func New(config Config) *OpenRouter {
@@ -171,6 +172,19 @@ func (o *OpenRouter) Chat(ctx context.Context, messages []ProviderMessage, opts
 	return providerhttp.ChatOpenAICompat(ctx, o.httpClient(), o.baseURL, headers, messages, opts)
 }
 
+// Embed creates text embeddings through OpenRouter's OpenAI-compatible embeddings endpoint.
+func (o *OpenRouter) Embed(ctx context.Context, opts providergateway.EmbeddingOptions) (*providergateway.EmbeddingResult, error) {
+
+	headers := o.authHeaders()
+	return providerhttp.EmbedOpenAICompat(ctx, o.httpClient(), o.baseURL, headers, opts)
+}
+
+// MaxEmbeddingBatch returns the per-request input limit OpenRouter forwards upstream.
+func (o *OpenRouter) MaxEmbeddingBatch() int {
+
+	return providerhttp.OpenAICompatEmbeddingBatchLimit
+}
+
 func (o *OpenRouter) authHeaders() map[string]string {
 
 	headers := make(map[string]string)
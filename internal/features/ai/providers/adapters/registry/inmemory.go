@@ -12,6 +12,7 @@ import (
 type Registry struct {
 	mu        sync.RWMutex
 	providers map[string]providercore.Provider
+	types     map[string]string
 	active    string
 	order     []string
 }
@@ -21,20 +22,64 @@ func New() *Registry {
 
 	return &Registry{
 		providers: make(map[string]providercore.Provider),
+		types:     make(map[string]string),
 	}
 }
 
 var _ providercore.ProviderRegistry = (*Registry)(nil)
 
-// Register adds a provider to the registry.
+// Register adds a provider to the registry without recording its type.
 func (r *Registry) Register(p providercore.Provider) {
 
+	r.RegisterInstance(providercore.ProviderInstance{Provider: p})
+}
+
+// RegisterInstance adds or replaces a named provider instance and records its type.
+func (r *Registry) RegisterInstance(instance providercore.ProviderInstance) {
+
+	if instance.Provider == nil {
+		return
+	}
+	name := instance.Provider.Name()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	if _, ok := r.providers[p.Name()]; !ok {
-		r.order = append(r.order, p.Name())
+	if _, ok := r.providers[name]; !ok {
+		r.order = append(r.order, name)
+	}
+	r.providers[name] = instance.Provider
+	if instance.Type != "" {
+		r.types[name] = instance.Type
+	}
+}
+
+// InstanceType returns the provider type an instance was registered with, or "" when unknown.
+func (r *Registry) InstanceType(name string) string {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.types[name]
+}
+
+// Unregister removes a provider and clears the active selection when it was active.
+func (r *Registry) Unregister(name string) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.providers[name]; !ok {
+		return
+	}
+	delete(r.providers, name)
+	delete(r.types, name)
+	for index, existing := range r.order {
+		if existing == name {
+			r.order = append(r.order[:index], r.order[index+1:]...)
+			break
+		}
+	}
+	if r.active == name {
+		r.active = ""
 	}
-	r.providers[p.Name()] = p
 }
 
 // Get retrieves a provider by name.
@@ -0,0 +1,355 @@
+// encrypted_file.go stores provider secrets in a passphrase-encrypted file for hosts without a keyring.
+// internal/features/ai/providers/adapters/secretstore/encrypted_file.go
+package securestore
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+const (
+	encryptedFileVersion = 1
+	kdfArgon2id          = "argon2id"
+	secretKeySize        = 32
+	secretNonceSize      = 24
+	secretSaltSize       = 16
+
+	// maxKDFTime and maxKDFMemory (1 GiB, in KiB) bound parameters read from a file so a tampered header
+	// cannot stall or exhaust the host.
+	maxKDFTime   = 64
+	maxKDFMemory = 1024 * 1024
+)
+
+// ErrSecretNotFound reports that no secret is stored for a provider field.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// ErrDecryptSecrets reports that the secrets file could not be opened with the configured key material.
+var ErrDecryptSecrets = errors.New("decrypt secrets file: wrong passphrase or corrupted file")
+
+// KDFParams configures Argon2id key derivation for the secrets file.
+type KDFParams struct {
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+// DefaultKDFParams returns the Argon2id parameters recommended by RFC 9106 for memory-constrained hosts.
+func DefaultKDFParams() KDFParams {
+
+	return KDFParams{Time: 3, Memory: 64 * 1024, Threads: 4}
+}
+
+// EncryptedFileOptions configures an encrypted file secret store.
+// Exactly one of Passphrase or KeyFile is required; key file contents are used as the KDF input.
+type EncryptedFileOptions struct {
+	Path       string
+	Passphrase string
+	KeyFile    string
+	KDF        KDFParams
+}
+
+// EncryptedFileStore stores provider credentials in a NaCl secretbox sealed with an Argon2id-derived key.
+type EncryptedFileStore struct {
+	mu         sync.Mutex
+	path       string
+	passphrase []byte
+	kdf        KDFParams
+	salt       []byte
+	key        *[secretKeySize]byte
+	secrets    map[string]string
+	loaded     bool
+}
+
+var _ providercore.SecretStore = (*EncryptedFileStore)(nil)
+
+// encryptedFileEnvelope is the on-disk JSON layout of the secrets file.
+type encryptedFileEnvelope struct {
+	Version    int              `json:"version"`
+	KDF        encryptedFileKDF `json:"kdf"`
+	Nonce      []byte           `json:"nonce"`
+	Ciphertext []byte           `json:"ciphertext"`
+}
+
+// encryptedFileKDF records the key derivation settings alongside the ciphertext.
+type encryptedFileKDF struct {
+	Algorithm string `json:"algorithm"`
+	Salt      []byte `json:"salt"`
+	KDFParams
+}
+
+// NewEncryptedFileStore creates a secret store backed by an encrypted file; the file is created on first save.
+func NewEncryptedFileStore(options EncryptedFileOptions) (*EncryptedFileStore, error) {
+
+	if strings.TrimSpace(options.Path) == "" {
+		return nil, fmt.Errorf("encrypted secret store: path required")
+	}
+
+	passphrase, err := resolvePassphrase(options.Passphrase, options.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("encrypted secret store: %w", err)
+	}
+
+	kdf := options.KDF
+	if kdf == (KDFParams{}) {
+		kdf = DefaultKDFParams()
+	}
+	if err := validateKDFParams(kdf); err != nil {
+		return nil, fmt.Errorf("encrypted secret store: %w", err)
+	}
+
+	return &EncryptedFileStore{
+		path:       options.Path,
+		passphrase: passphrase,
+		kdf:        kdf,
+	}, nil
+}
+
+// Path returns the secrets file location.
+func (s *EncryptedFileStore) Path() string {
+
+	return s.path
+}
+
+// SaveProviderSecret stores a provider secret field and rewrites the encrypted file.
+func (s *EncryptedFileStore) SaveProviderSecret(providerName, fieldName, value string) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+	s.secrets[credentialKey(providerName, fieldName)] = value
+	return s.persist()
+}
+
+// GetProviderSecret retrieves a provider secret field from the encrypted file.
+func (s *EncryptedFileStore) GetProviderSecret(providerName, fieldName string) (string, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return "", err
+	}
+	value, ok := s.secrets[credentialKey(providerName, fieldName)]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return value, nil
+}
+
+// HasProviderSecret returns true when a provider secret field is stored.
+func (s *EncryptedFileStore) HasProviderSecret(providerName, fieldName string) bool {
+
+	_, err := s.GetProviderSecret(providerName, fieldName)
+	return err == nil
+}
+
+// DeleteProviderSecret removes a stored provider secret field.
+func (s *EncryptedFileStore) DeleteProviderSecret(providerName, fieldName string) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+	key := credentialKey(providerName, fieldName)
+	if _, ok := s.secrets[key]; !ok {
+		return ErrSecretNotFound
+	}
+	delete(s.secrets, key)
+	return s.persist()
+}
+
+// load reads and decrypts the secrets file once; a missing file starts an empty store.
+func (s *EncryptedFileStore) load() error {
+
+	if s.loaded {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		salt := make([]byte, secretSaltSize)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return fmt.Errorf("generate salt: %w", err)
+		}
+		s.salt = salt
+		s.key = deriveSecretKey(s.passphrase, salt, s.kdf)
+		s.secrets = map[string]string{}
+		s.loaded = true
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read secrets file: %w", err)
+	}
+
+	var envelope encryptedFileEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return fmt.Errorf("parse secrets file: %w", err)
+	}
+	if envelope.Version != encryptedFileVersion {
+		return fmt.Errorf("unsupported secrets file version %d", envelope.Version)
+	}
+	if envelope.KDF.Algorithm != kdfArgon2id {
+		return fmt.Errorf("unsupported secrets file kdf %q", envelope.KDF.Algorithm)
+	}
+	if err := envelope.KDF.validate(); err != nil {
+		return fmt.Errorf("secrets file: %w", err)
+	}
+	if len(envelope.Nonce) != secretNonceSize {
+		return ErrDecryptSecrets
+	}
+
+	var nonce [secretNonceSize]byte
+	copy(nonce[:], envelope.Nonce)
+	key := deriveSecretKey(s.passphrase, envelope.KDF.Salt, envelope.KDF.KDFParams)
+	plaintext, ok := secretbox.Open(nil, envelope.Ciphertext, &nonce, key)
+	if !ok {
+		return ErrDecryptSecrets
+	}
+
+	secrets := map[string]string{}
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return fmt.Errorf("parse decrypted secrets: %w", err)
+	}
+
+	s.salt = envelope.KDF.Salt
+	s.kdf = envelope.KDF.KDFParams
+	s.key = key
+	s.secrets = secrets
+	s.loaded = true
+	return nil
+}
+
+// persist seals the current secrets with a fresh nonce and atomically replaces the file.
+func (s *EncryptedFileStore) persist() error {
+
+	plaintext, err := json.Marshal(s.secrets)
+	if err != nil {
+		return fmt.Errorf("encode secrets: %w", err)
+	}
+
+	var nonce [secretNonceSize]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return fmt.Errorf("generate nonce: %w", err)
+	}
+
+	envelope := encryptedFileEnvelope{
+		Version: encryptedFileVersion,
+		KDF: encryptedFileKDF{
+			Algorithm: kdfArgon2id,
+			Salt:      s.salt,
+			KDFParams: s.kdf,
+		},
+		Nonce:      nonce[:],
+		Ciphertext: secretbox.Seal(nil, plaintext, &nonce, s.key),
+	}
+	data, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode secrets file: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create secrets dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".secrets-*")
+	if err != nil {
+		return fmt.Errorf("create secrets temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if err := tmp.Chmod(0o600); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("chmod secrets file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write secrets file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close secrets file: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("replace secrets file: %w", err)
+	}
+	return nil
+}
+
+// resolvePassphrase returns the KDF input from a passphrase or key file.
+func resolvePassphrase(passphrase, keyFile string) ([]byte, error) {
+
+	hasPassphrase := passphrase != ""
+	hasKeyFile := strings.TrimSpace(keyFile) != ""
+	switch {
+	case hasPassphrase && hasKeyFile:
+		return nil, fmt.Errorf("passphrase and key file are mutually exclusive")
+	case hasPassphrase:
+		return []byte(passphrase), nil
+	case hasKeyFile:
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("read key file: %w", err)
+		}
+		material := strings.TrimSpace(string(data))
+		if material == "" {
+			return nil, fmt.Errorf("key file %s is empty", keyFile)
+		}
+		return []byte(material), nil
+	default:
+		return nil, fmt.Errorf("passphrase or key file required")
+	}
+}
+
+// validate rejects a stored salt of the wrong size and stored parameters that validateKDFParams rejects.
+func (kdf encryptedFileKDF) validate() error {
+
+	if len(kdf.Salt) != secretSaltSize {
+		return fmt.Errorf("invalid kdf salt of %d bytes: must be %d", len(kdf.Salt), secretSaltSize)
+	}
+	return validateKDFParams(kdf.KDFParams)
+}
+
+// validateKDFParams rejects Argon2id parameters that would panic or exceed the supported bounds.
+func validateKDFParams(params KDFParams) error {
+
+	switch {
+	case params.Time == 0 || params.Time > maxKDFTime:
+		return fmt.Errorf("invalid kdf time %d: must be between 1 and %d", params.Time, maxKDFTime)
+	case params.Threads == 0:
+		return fmt.Errorf("invalid kdf threads %d: must be at least 1", params.Threads)
+	case params.Memory < 8*uint32(params.Threads) || params.Memory > maxKDFMemory:
+		return fmt.Errorf("invalid kdf memory %d KiB: must be between %d and %d", params.Memory, 8*uint32(params.Threads), maxKDFMemory)
+	default:
+		return nil
+	}
+}
+
+// deriveSecretKey derives the secretbox key with Argon2id.
+func deriveSecretKey(passphrase, salt []byte, params KDFParams) *[secretKeySize]byte {
+
+	var key [secretKeySize]byte
+	copy(key[:], argon2.IDKey(passphrase, salt, params.Time, params.Memory, params.Threads, secretKeySize))
+	return &key
+}
+
+// credentialKey builds the storage key for a provider secret field.
+func credentialKey(providerName, fieldName string) string {
+
+	return providerName + ":" + fieldName
+}
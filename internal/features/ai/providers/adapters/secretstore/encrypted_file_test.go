@@ -0,0 +1,200 @@
+// encrypted_file_test.go verifies the encrypted file secret store without a keyring daemon.
+// internal/features/ai/providers/adapters/secretstore/encrypted_file_test.go
+package securestore
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// testKDFParams keeps Argon2id cheap in tests.
+var testKDFParams = KDFParams{Time: 1, Memory: 1024, Threads: 1}
+
+// TestEncryptedFileStoreRoundTripsAcrossInstances verifies secrets persist encrypted and reload with the same passphrase.
+func TestEncryptedFileStoreRoundTripsAcrossInstances(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "nested", "secrets.enc")
+	store, err := NewEncryptedFileStore(EncryptedFileOptions{Path: path, Passphrase: "correct horse", KDF: testKDFParams})
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+
+	if store.HasProviderSecret("openai", "apiKey") {
+		t.Fatalf("expected empty store before first save")
+	}
+	if err := store.SaveProviderSecret("openai", "apiKey", "sk-secret-value"); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := store.SaveProviderSecret("cloudflare", "apiToken", "cf-token"); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+	if strings.Contains(string(data), "sk-secret-value") {
+		t.Fatalf("expected ciphertext on disk, found plaintext secret")
+	}
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("stat: %v", err)
+		}
+		if info.Mode().Perm() != 0o600 {
+			t.Fatalf("expected 0600 permissions, got %o", info.Mode().Perm())
+		}
+	}
+
+	reopened, err := NewEncryptedFileStore(EncryptedFileOptions{Path: path, Passphrase: "correct horse"})
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	value, err := reopened.GetProviderSecret("openai", "apiKey")
+	if err != nil || value != "sk-secret-value" {
+		t.Fatalf("expected stored secret, got %q (%v)", value, err)
+	}
+
+	if err := reopened.DeleteProviderSecret("openai", "apiKey"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := reopened.GetProviderSecret("openai", "apiKey"); !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("expected ErrSecretNotFound after delete, got %v", err)
+	}
+	if err := reopened.DeleteProviderSecret("openai", "apiKey"); !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("expected ErrSecretNotFound deleting missing secret, got %v", err)
+	}
+	if !reopened.HasProviderSecret("cloudflare", "apiToken") {
+		t.Fatalf("expected unrelated secret to survive delete")
+	}
+}
+
+// TestEncryptedFileStoreRejectsWrongKeyMaterial verifies a different passphrase cannot decrypt the file.
+func TestEncryptedFileStoreRejectsWrongKeyMaterial(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	store, err := NewEncryptedFileStore(EncryptedFileOptions{Path: path, Passphrase: "right", KDF: testKDFParams})
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	if err := store.SaveProviderSecret("openai", "apiKey", "sk"); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	wrong, err := NewEncryptedFileStore(EncryptedFileOptions{Path: path, Passphrase: "wrong"})
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	if _, err := wrong.GetProviderSecret("openai", "apiKey"); !errors.Is(err, ErrDecryptSecrets) {
+		t.Fatalf("expected ErrDecryptSecrets, got %v", err)
+	}
+	if err := wrong.SaveProviderSecret("openai", "apiKey", "overwrite"); !errors.Is(err, ErrDecryptSecrets) {
+		t.Fatalf("expected save to refuse overwriting undecryptable file, got %v", err)
+	}
+}
+
+// TestEncryptedFileStoreRejectsInvalidStoredKDFParams verifies tampered KDF parameters and salts return an error instead of reaching argon2.
+func TestEncryptedFileStoreRejectsInvalidStoredKDFParams(t *testing.T) {
+
+	salt := make([]byte, secretSaltSize)
+	testCases := []struct {
+		name   string
+		params KDFParams
+		salt   []byte
+	}{
+		{name: "zero threads", params: KDFParams{Time: 1, Memory: 1024, Threads: 0}, salt: salt},
+		{name: "zero memory", params: KDFParams{Time: 1, Memory: 0, Threads: 1}, salt: salt},
+		{name: "zero time", params: KDFParams{Time: 0, Memory: 1024, Threads: 1}, salt: salt},
+		{name: "memory above cap", params: KDFParams{Time: 1, Memory: maxKDFMemory + 1, Threads: 1}, salt: salt},
+		{name: "empty salt", params: testKDFParams},
+		{name: "short salt", params: testKDFParams, salt: salt[:4]},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			path := filepath.Join(t.TempDir(), "secrets.enc")
+			store, err := NewEncryptedFileStore(EncryptedFileOptions{Path: path, Passphrase: "right", KDF: testKDFParams})
+			if err != nil {
+				t.Fatalf("new store: %v", err)
+			}
+			if err := store.SaveProviderSecret("openai", "apiKey", "sk"); err != nil {
+				t.Fatalf("save: %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read file: %v", err)
+			}
+			var envelope encryptedFileEnvelope
+			if err := json.Unmarshal(data, &envelope); err != nil {
+				t.Fatalf("parse file: %v", err)
+			}
+			envelope.KDF.KDFParams = testCase.params
+			envelope.KDF.Salt = testCase.salt
+			data, err = json.Marshal(envelope)
+			if err != nil {
+				t.Fatalf("encode file: %v", err)
+			}
+			if err := os.WriteFile(path, data, 0o600); err != nil {
+				t.Fatalf("write file: %v", err)
+			}
+
+			reopened, err := NewEncryptedFileStore(EncryptedFileOptions{Path: path, Passphrase: "right"})
+			if err != nil {
+				t.Fatalf("new store: %v", err)
+			}
+			if _, err := reopened.GetProviderSecret("openai", "apiKey"); err == nil || !strings.Contains(err.Error(), "invalid kdf") {
+				t.Fatalf("expected invalid kdf error, got %v", err)
+			}
+		})
+	}
+}
+
+// TestEncryptedFileStoreUsesKeyFile verifies key file contents unlock the store and option validation.
+func TestEncryptedFileStoreUsesKeyFile(t *testing.T) {
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "secrets.key")
+	if err := os.WriteFile(keyFile, []byte("0123456789abcdef0123456789abcdef\n"), 0o600); err != nil {
+		t.Fatalf("write key file: %v", err)
+	}
+	path := filepath.Join(dir, "secrets.enc")
+
+	store, err := NewEncryptedFileStore(EncryptedFileOptions{Path: path, KeyFile: keyFile, KDF: testKDFParams})
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	if err := store.SaveProviderSecret("gemini", "apiKey", "g-key"); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	// Trailing whitespace in the key file is ignored, so the trimmed contents work as a passphrase.
+	viaPassphrase, err := NewEncryptedFileStore(EncryptedFileOptions{Path: path, Passphrase: "0123456789abcdef0123456789abcdef"})
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	if value, err := viaPassphrase.GetProviderSecret("gemini", "apiKey"); err != nil || value != "g-key" {
+		t.Fatalf("expected key file secret, got %q (%v)", value, err)
+	}
+
+	testCases := []struct {
+		name    string
+		options EncryptedFileOptions
+	}{
+		{name: "missing path", options: EncryptedFileOptions{Passphrase: "p"}},
+		{name: "missing key material", options: EncryptedFileOptions{Path: path}},
+		{name: "both key sources", options: EncryptedFileOptions{Path: path, Passphrase: "p", KeyFile: keyFile}},
+		{name: "missing key file", options: EncryptedFileOptions{Path: path, KeyFile: filepath.Join(dir, "absent.key")}},
+	}
+	for _, testCase := range testCases {
+		if _, err := NewEncryptedFileStore(testCase.options); err == nil {
+			t.Fatalf("%s: expected error", testCase.name)
+		}
+	}
+}
@@ -21,27 +21,21 @@ var _ providercore.SecretStore = (*KeyringStore)(nil)
 
 // SaveProviderSecret stores a provider secret field in the OS keychain.
 func (s *KeyringStore) SaveProviderSecret(providerName, fieldName, value string) error {
-	return keyring.Set(s.serviceName, s.credentialKey(providerName, fieldName), value)
+	return keyring.Set(s.serviceName, credentialKey(providerName, fieldName), value)
 }
 
 // GetProviderSecret retrieves a provider secret field from the OS keychain.
 func (s *KeyringStore) GetProviderSecret(providerName, fieldName string) (string, error) {
-	return keyring.Get(s.serviceName, s.credentialKey(providerName, fieldName))
+	return keyring.Get(s.serviceName, credentialKey(providerName, fieldName))
 }
 
 // HasProviderSecret returns true when a provider secret field is stored.
 func (s *KeyringStore) HasProviderSecret(providerName, fieldName string) bool {
-	_, err := keyring.Get(s.serviceName, s.credentialKey(providerName, fieldName))
+	_, err := keyring.Get(s.serviceName, credentialKey(providerName, fieldName))
 	return err == nil
 }
 
 // DeleteProviderSecret removes a stored provider secret field.
 func (s *KeyringStore) DeleteProviderSecret(providerName, fieldName string) error {
-	return keyring.Delete(s.serviceName, s.credentialKey(providerName, fieldName))
-}
-
-// credentialKey builds the keyring entry key for a provider secret field.
-func (s *KeyringStore) credentialKey(providerName, fieldName string) string {
-
-	return providerName + ":" + fieldName
+	return keyring.Delete(s.serviceName, credentialKey(providerName, fieldName))
 }
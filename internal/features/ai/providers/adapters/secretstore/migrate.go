@@ -0,0 +1,70 @@
+// migrate.go copies provider secrets between secret store backends.
+// internal/features/ai/providers/adapters/secretstore/migrate.go
+package securestore
+
+import (
+	"errors"
+	"fmt"
+
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+	"github.com/zalando/go-keyring"
+)
+
+// SecretRef identifies one provider secret field.
+type SecretRef struct {
+	ProviderName string
+	FieldName    string
+}
+
+// String renders the ref as provider:field.
+func (r SecretRef) String() string {
+
+	return credentialKey(r.ProviderName, r.FieldName)
+}
+
+// MigrationResult lists which refs were copied and which were absent from the source.
+type MigrationResult struct {
+	Migrated []SecretRef
+	Skipped  []SecretRef
+}
+
+// Migrate copies each stored ref from source to target and optionally deletes it from source afterwards.
+// Keyrings cannot enumerate entries, so callers pass the refs to consider.
+// Only refs the source reports as not found are skipped; any other read error, such as a wrong passphrase, fails the migration.
+func Migrate(source, target providercore.SecretStore, refs []SecretRef, deleteSource bool) (MigrationResult, error) {
+
+	var result MigrationResult
+	if source == nil || target == nil {
+		return result, fmt.Errorf("migrate secrets: source and target stores required")
+	}
+
+	for _, ref := range refs {
+		value, err := source.GetProviderSecret(ref.ProviderName, ref.FieldName)
+		if isSecretNotFound(err) {
+			result.Skipped = append(result.Skipped, ref)
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("migrate secrets: read %s: %w", ref, err)
+		}
+		if err := target.SaveProviderSecret(ref.ProviderName, ref.FieldName, value); err != nil {
+			return result, fmt.Errorf("migrate secrets: write %s: %w", ref, err)
+		}
+		result.Migrated = append(result.Migrated, ref)
+	}
+
+	if deleteSource {
+		for _, ref := range result.Migrated {
+			if err := source.DeleteProviderSecret(ref.ProviderName, ref.FieldName); err != nil {
+				return result, fmt.Errorf("migrate secrets: delete %s from source: %w", ref, err)
+			}
+		}
+	}
+	return result, nil
+}
+
+// isSecretNotFound reports whether err means the store has no value for a ref.
+func isSecretNotFound(err error) bool {
+
+	return errors.Is(err, ErrSecretNotFound) || errors.Is(err, keyring.ErrNotFound)
+}
@@ -0,0 +1,141 @@
+// select.go chooses a secret store backend, falling back to the encrypted file when no keyring is reachable.
+// internal/features/ai/providers/adapters/secretstore/select.go
+package securestore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+	"github.com/MadeByDoug/wls-chatbot/internal/platform"
+	"github.com/zalando/go-keyring"
+)
+
+// Backend names a secret store implementation.
+type Backend string
+
+const (
+	BackendAuto    Backend = "auto"
+	BackendKeyring Backend = "keyring"
+	BackendFile    Backend = "file"
+)
+
+// Environment variables read by OptionsFromEnv.
+const (
+	EnvSecretStore       = "WLS_SECRET_STORE"
+	EnvSecretsFile       = "WLS_SECRETS_FILE"
+	EnvSecretsPassphrase = "WLS_SECRETS_PASSPHRASE"
+	EnvSecretsKeyFile    = "WLS_SECRETS_KEY_FILE"
+)
+
+// DefaultFileName is the encrypted secrets file name inside the app data directory.
+const DefaultFileName = "secrets.enc"
+
+// keyringProbeKey is looked up to detect whether a keyring daemon answers.
+const keyringProbeKey = "__wls_probe__"
+
+// Options configures secret store selection.
+type Options struct {
+	Backend            Backend
+	KeyringServiceName string
+	File               EncryptedFileOptions
+}
+
+// keyringAvailable reports whether the OS keyring answers requests; tests replace it.
+var keyringAvailable = func(serviceName string) bool {
+
+	_, err := keyring.Get(serviceName, keyringProbeKey)
+	return err == nil || errors.Is(err, keyring.ErrNotFound)
+}
+
+// ParseBackend validates a backend name; empty selects auto.
+func ParseBackend(value string) (Backend, error) {
+
+	switch backend := Backend(strings.ToLower(strings.TrimSpace(value))); backend {
+	case "":
+		return BackendAuto, nil
+	case BackendAuto, BackendKeyring, BackendFile:
+		return backend, nil
+	default:
+		return "", fmt.Errorf("unknown secret store backend %q (expected auto, keyring or file)", value)
+	}
+}
+
+// OptionsFromEnv builds selection options from WLS_SECRET_* variables, using defaultFilePath when none is set.
+func OptionsFromEnv(keyringServiceName, defaultFilePath string) (Options, error) {
+
+	backend, err := ParseBackend(os.Getenv(EnvSecretStore))
+	if err != nil {
+		return Options{}, err
+	}
+
+	path := strings.TrimSpace(os.Getenv(EnvSecretsFile))
+	if path == "" {
+		path = defaultFilePath
+	}
+
+	return Options{
+		Backend:            backend,
+		KeyringServiceName: keyringServiceName,
+		File: EncryptedFileOptions{
+			Path:       path,
+			Passphrase: os.Getenv(EnvSecretsPassphrase),
+			KeyFile:    strings.TrimSpace(os.Getenv(EnvSecretsKeyFile)),
+		},
+	}, nil
+}
+
+// DefaultFilePath returns the encrypted secrets file location in the app data directory.
+func DefaultFilePath(appName string) (string, error) {
+
+	appDataDir, err := platform.ResolveAppDataDir(appName)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDataDir, DefaultFileName), nil
+}
+
+// Open creates the secret store for an explicit keyring or file backend.
+func Open(backend Backend, options Options) (providercore.SecretStore, error) {
+
+	switch backend {
+	case BackendKeyring:
+		if strings.TrimSpace(options.KeyringServiceName) == "" {
+			return nil, fmt.Errorf("keyring secret store: service name required")
+		}
+		return NewKeyringStore(options.KeyringServiceName), nil
+	case BackendFile:
+		return NewEncryptedFileStore(options.File)
+	default:
+		return nil, fmt.Errorf("open secret store: backend %q must be keyring or file", backend)
+	}
+}
+
+// Select resolves the configured backend and returns the store with the backend actually used.
+// Auto prefers the keyring and falls back to the encrypted file when the keyring is unreachable and
+// key material is configured; otherwise it keeps the keyring so behavior matches earlier releases.
+func Select(options Options) (providercore.SecretStore, Backend, error) {
+
+	backend := options.Backend
+	if backend == "" || backend == BackendAuto {
+		backend = BackendKeyring
+		if !keyringAvailable(options.KeyringServiceName) && hasFileKeyMaterial(options.File) {
+			backend = BackendFile
+		}
+	}
+
+	store, err := Open(backend, options)
+	if err != nil {
+		return nil, "", err
+	}
+	return store, backend, nil
+}
+
+// hasFileKeyMaterial reports whether the encrypted file backend can be unlocked.
+func hasFileKeyMaterial(options EncryptedFileOptions) bool {
+
+	return strings.TrimSpace(options.Path) != "" && (options.Passphrase != "" || strings.TrimSpace(options.KeyFile) != "")
+}
@@ -0,0 +1,161 @@
+// select_test.go verifies backend selection and secret migration without a keyring daemon.
+// internal/features/ai/providers/adapters/secretstore/select_test.go
+package securestore
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+// stubKeyringAvailable replaces the keyring probe for the duration of a test.
+func stubKeyringAvailable(t *testing.T, available bool) {
+
+	t.Helper()
+	original := keyringAvailable
+	keyringAvailable = func(string) bool { return available }
+	t.Cleanup(func() { keyringAvailable = original })
+}
+
+// TestSelectFallsBackToEncryptedFile verifies auto selection only leaves the keyring when it is unreachable and the file can be unlocked.
+func TestSelectFallsBackToEncryptedFile(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	testCases := []struct {
+		name      string
+		available bool
+		options   Options
+		expected  Backend
+	}{
+		{
+			name:      "keyring reachable",
+			available: true,
+			options:   Options{KeyringServiceName: "svc", File: EncryptedFileOptions{Path: path, Passphrase: "p"}},
+			expected:  BackendKeyring,
+		},
+		{
+			name:      "keyring unreachable with passphrase",
+			available: false,
+			options:   Options{Backend: BackendAuto, KeyringServiceName: "svc", File: EncryptedFileOptions{Path: path, Passphrase: "p"}},
+			expected:  BackendFile,
+		},
+		{
+			name:      "keyring unreachable without key material",
+			available: false,
+			options:   Options{KeyringServiceName: "svc", File: EncryptedFileOptions{Path: path}},
+			expected:  BackendKeyring,
+		},
+		{
+			name:      "explicit file",
+			available: true,
+			options:   Options{Backend: BackendFile, KeyringServiceName: "svc", File: EncryptedFileOptions{Path: path, Passphrase: "p"}},
+			expected:  BackendFile,
+		},
+	}
+	for _, testCase := range testCases {
+		stubKeyringAvailable(t, testCase.available)
+		store, backend, err := Select(testCase.options)
+		if err != nil {
+			t.Fatalf("%s: select: %v", testCase.name, err)
+		}
+		if backend != testCase.expected {
+			t.Fatalf("%s: expected %s, got %s", testCase.name, testCase.expected, backend)
+		}
+		switch store.(type) {
+		case *KeyringStore:
+			if backend != BackendKeyring {
+				t.Fatalf("%s: keyring store returned for %s", testCase.name, backend)
+			}
+		case *EncryptedFileStore:
+			if backend != BackendFile {
+				t.Fatalf("%s: file store returned for %s", testCase.name, backend)
+			}
+		}
+	}
+
+	if _, _, err := Select(Options{Backend: BackendFile, File: EncryptedFileOptions{Path: path}}); err == nil {
+		t.Fatalf("expected explicit file backend without key material to fail")
+	}
+}
+
+// TestOptionsFromEnvReadsVariables verifies environment configuration and backend validation.
+func TestOptionsFromEnvReadsVariables(t *testing.T) {
+
+	t.Setenv(EnvSecretStore, "FILE")
+	t.Setenv(EnvSecretsFile, "")
+	t.Setenv(EnvSecretsPassphrase, "hunter2")
+	t.Setenv(EnvSecretsKeyFile, "")
+
+	options, err := OptionsFromEnv("svc", "/tmp/default.enc")
+	if err != nil {
+		t.Fatalf("options: %v", err)
+	}
+	if options.Backend != BackendFile || options.File.Path != "/tmp/default.enc" || options.File.Passphrase != "hunter2" {
+		t.Fatalf("unexpected options %#v", options)
+	}
+
+	t.Setenv(EnvSecretStore, "vault")
+	if _, err := OptionsFromEnv("svc", "/tmp/default.enc"); err == nil {
+		t.Fatalf("expected unknown backend to fail")
+	}
+}
+
+// TestMigrateCopiesSecretsBetweenStores verifies migration from a mocked keyring into the encrypted file.
+func TestMigrateCopiesSecretsBetweenStores(t *testing.T) {
+
+	keyring.MockInit()
+	source := NewKeyringStore("svc")
+	if err := source.SaveProviderSecret("openai", "apiKey", "sk-openai"); err != nil {
+		t.Fatalf("seed keyring: %v", err)
+	}
+	target, err := NewEncryptedFileStore(EncryptedFileOptions{Path: filepath.Join(t.TempDir(), "secrets.enc"), Passphrase: "p", KDF: testKDFParams})
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+
+	refs := []SecretRef{{ProviderName: "openai", FieldName: "apiKey"}, {ProviderName: "gemini", FieldName: "apiKey"}}
+	result, err := Migrate(source, target, refs, true)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if len(result.Migrated) != 1 || result.Migrated[0].String() != "openai:apiKey" {
+		t.Fatalf("unexpected migrated refs %v", result.Migrated)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].ProviderName != "gemini" {
+		t.Fatalf("unexpected skipped refs %v", result.Skipped)
+	}
+	if value, err := target.GetProviderSecret("openai", "apiKey"); err != nil || value != "sk-openai" {
+		t.Fatalf("expected migrated secret, got %q (%v)", value, err)
+	}
+	if source.HasProviderSecret("openai", "apiKey") {
+		t.Fatalf("expected source secret deleted")
+	}
+}
+
+// TestMigrateFailsWhenSourceCannotBeDecrypted verifies a wrong passphrase is reported instead of skipping every ref.
+func TestMigrateFailsWhenSourceCannotBeDecrypted(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	seeded, err := NewEncryptedFileStore(EncryptedFileOptions{Path: path, Passphrase: "right", KDF: testKDFParams})
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	if err := seeded.SaveProviderSecret("openai", "apiKey", "sk-openai"); err != nil {
+		t.Fatalf("seed file: %v", err)
+	}
+	source, err := NewEncryptedFileStore(EncryptedFileOptions{Path: path, Passphrase: "wrong"})
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+
+	keyring.MockInit()
+	result, err := Migrate(source, NewKeyringStore("svc"), []SecretRef{{ProviderName: "openai", FieldName: "apiKey"}}, false)
+	if !errors.Is(err, ErrDecryptSecrets) {
+		t.Fatalf("expected ErrDecryptSecrets, got %v", err)
+	}
+	if len(result.Migrated) != 0 || len(result.Skipped) != 0 {
+		t.Fatalf("expected no refs processed, got %#v", result)
+	}
+}
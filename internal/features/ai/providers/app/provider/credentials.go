@@ -0,0 +1,127 @@
+// credentials.go layers provider credentials from input, external sources, the secret store, and config.
+// internal/features/ai/providers/app/provider/credentials.go
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+)
+
+// Credential source labels for values that do not come from an external CredentialSource.
+const (
+	CredentialSourceInput       = "input"
+	CredentialSourceSecretStore = "secret-store"
+	CredentialSourceConfig      = "config"
+)
+
+// CredentialLayers resolves credentials with a fixed precedence, highest first:
+// explicit input, each external source in slice order (env before .env), the secret store, then config inputs.
+// Only input, secret store and config values may hold an exec: reference, which Runner expands when the
+// value is used; values from external sources such as env and .env are always plain strings, so an
+// ambient variable cannot make the app run a command.
+type CredentialLayers struct {
+	Sources []providercore.CredentialSource
+	Runner  providercore.CredentialCommandRunner
+}
+
+// LayeredCredentials holds credential values with the label of the layer that supplied each field.
+type LayeredCredentials struct {
+	Values  providercore.ProviderCredentials
+	Sources map[string]string
+}
+
+// Layer merges all layers for a provider without running exec: commands.
+func (l CredentialLayers) Layer(providerName string, fields []providercore.CredentialField, config, stored, input providercore.ProviderCredentials) LayeredCredentials {
+
+	layered := LayeredCredentials{
+		Values:  make(providercore.ProviderCredentials),
+		Sources: make(map[string]string),
+	}
+	apply := func(source string, values providercore.ProviderCredentials) {
+		for key, value := range values {
+			trimmed := strings.TrimSpace(value)
+			if trimmed == "" {
+				continue
+			}
+			layered.Values[key] = trimmed
+			layered.Sources[key] = source
+		}
+	}
+
+	apply(CredentialSourceConfig, config)
+	apply(CredentialSourceSecretStore, stored)
+	for index := len(l.Sources) - 1; index >= 0; index-- {
+		source := l.Sources[index]
+		if source == nil {
+			continue
+		}
+		found := make(providercore.ProviderCredentials)
+		for _, field := range fields {
+			if value, ok := source.LookupProviderCredential(providerName, field.Name); ok {
+				found[field.Name] = value
+			}
+		}
+		apply(source.Name(), found)
+	}
+	apply(CredentialSourceInput, input)
+
+	if len(layered.Values) == 0 {
+		return LayeredCredentials{}
+	}
+	return layered
+}
+
+// Expand returns the credential values with exec: references replaced by command output.
+func (l CredentialLayers) Expand(layered LayeredCredentials) (providercore.ProviderCredentials, error) {
+
+	if len(layered.Values) == 0 {
+		return nil, nil
+	}
+
+	expanded := make(providercore.ProviderCredentials, len(layered.Values))
+	for key, value := range layered.Values {
+		command, ok := layered.ExecCommand(key)
+		if !ok {
+			expanded[key] = value
+			continue
+		}
+		if l.Runner == nil {
+			return nil, fmt.Errorf("credential %s from %s uses exec: but command sources are not enabled", key, layered.Sources[key])
+		}
+		output, err := l.Runner.RunCredentialCommand(command)
+		if err != nil {
+			return nil, fmt.Errorf("credential %s from %s: %w", key, layered.Sources[key], err)
+		}
+		expanded[key] = output
+	}
+	return expanded, nil
+}
+
+// DisplaySources returns per-field source labels, marking exec: references as exec(<layer>); values are never included.
+func (layered LayeredCredentials) DisplaySources() map[string]string {
+
+	if len(layered.Sources) == 0 {
+		return nil
+	}
+	display := make(map[string]string, len(layered.Sources))
+	for key, source := range layered.Sources {
+		if _, ok := layered.ExecCommand(key); ok {
+			source = "exec(" + source + ")"
+		}
+		display[key] = source
+	}
+	return display
+}
+
+// ExecCommand returns the exec: command for a field when its layer is allowed to run commands.
+func (layered LayeredCredentials) ExecCommand(key string) (string, bool) {
+
+	switch layered.Sources[key] {
+	case CredentialSourceInput, CredentialSourceSecretStore, CredentialSourceConfig:
+		return providercore.CredentialExecCommand(layered.Values[key])
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,164 @@
+// credentials_test.go verifies credential layer precedence, exec expansion, and source display.
+// internal/features/ai/providers/app/provider/credentials_test.go
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+)
+
+// mapCredentialSource serves fixed provider credentials under a source name.
+type mapCredentialSource struct {
+	name   string
+	values map[string]string
+}
+
+// Name returns the source label.
+func (s mapCredentialSource) Name() string {
+
+	return s.name
+}
+
+// LookupProviderCredential returns the value stored under provider:field.
+func (s mapCredentialSource) LookupProviderCredential(providerName, fieldName string) (string, bool) {
+
+	value, ok := s.values[providerName+":"+fieldName]
+	return value, ok
+}
+
+// recordingRunner returns canned command output and records commands it ran.
+type recordingRunner struct {
+	outputs map[string]string
+	ran     []string
+}
+
+// RunCredentialCommand returns the canned output or an error for unknown commands.
+func (r *recordingRunner) RunCredentialCommand(command string) (string, error) {
+
+	r.ran = append(r.ran, command)
+	value, ok := r.outputs[command]
+	if !ok {
+		return "", errors.New("command failed")
+	}
+	return value, nil
+}
+
+// TestCredentialLayersApplyPrecedence verifies input > env > dotenv > secret store > config.
+func TestCredentialLayersApplyPrecedence(t *testing.T) {
+
+	fields := []providercore.CredentialField{
+		{Name: "api_key", Secret: true},
+		{Name: "account_id"},
+		{Name: "gateway_id"},
+		{Name: "token", Secret: true},
+	}
+	layers := CredentialLayers{Sources: []providercore.CredentialSource{
+		mapCredentialSource{name: "env", values: map[string]string{"cf:api_key": "from-env"}},
+		mapCredentialSource{name: "dotenv", values: map[string]string{"cf:api_key": "from-dotenv", "cf:account_id": "acct-dotenv"}},
+	}}
+
+	layered := layers.Layer(
+		"cf",
+		fields,
+		providercore.ProviderCredentials{"account_id": "acct-config", "gateway_id": "gw-config"},
+		providercore.ProviderCredentials{"api_key": "from-store", "token": "tok-store"},
+		providercore.ProviderCredentials{"gateway_id": "gw-input"},
+	)
+
+	testCases := []struct {
+		field  string
+		value  string
+		source string
+	}{
+		{field: "api_key", value: "from-env", source: "env"},
+		{field: "account_id", value: "acct-dotenv", source: "dotenv"},
+		{field: "gateway_id", value: "gw-input", source: CredentialSourceInput},
+		{field: "token", value: "tok-store", source: CredentialSourceSecretStore},
+	}
+	for _, testCase := range testCases {
+		if layered.Values[testCase.field] != testCase.value || layered.Sources[testCase.field] != testCase.source {
+			t.Fatalf("%s: expected %q from %s, got %q from %s", testCase.field, testCase.value, testCase.source, layered.Values[testCase.field], layered.Sources[testCase.field])
+		}
+	}
+
+	empty := layers.Layer("other", fields, nil, nil, nil)
+	if empty.Values != nil || empty.DisplaySources() != nil {
+		t.Fatalf("expected empty layering, got %#v", empty)
+	}
+}
+
+// TestCredentialLayersExpandExecReferences verifies exec: values run the command and display sources hide values.
+func TestCredentialLayersExpandExecReferences(t *testing.T) {
+
+	runner := &recordingRunner{outputs: map[string]string{"pass show openai": "sk-pass"}}
+	layers := CredentialLayers{Runner: runner}
+	fields := []providercore.CredentialField{{Name: "api_key", Secret: true}}
+
+	layered := layers.Layer(
+		"openai",
+		fields,
+		providercore.ProviderCredentials{"base": "https://example.test"},
+		providercore.ProviderCredentials{"api_key": "exec: pass show openai"},
+		nil,
+	)
+	if len(runner.ran) != 0 {
+		t.Fatalf("expected layering not to run commands")
+	}
+	sources := layered.DisplaySources()
+	if sources["api_key"] != "exec("+CredentialSourceSecretStore+")" || sources["base"] != CredentialSourceConfig {
+		t.Fatalf("unexpected display sources %v", sources)
+	}
+	for _, source := range sources {
+		if source == "sk-pass" || source == "exec: pass show openai" {
+			t.Fatalf("display sources leaked a value: %v", sources)
+		}
+	}
+
+	expanded, err := layers.Expand(layered)
+	if err != nil {
+		t.Fatalf("expand: %v", err)
+	}
+	if expanded["api_key"] != "sk-pass" || expanded["base"] != "https://example.test" {
+		t.Fatalf("unexpected expanded values %v", expanded)
+	}
+
+	failing := CredentialLayers{Runner: runner}
+	bad := failing.Layer("openai", fields, nil, providercore.ProviderCredentials{"api_key": "exec:missing"}, nil)
+	if _, err := failing.Expand(bad); err == nil {
+		t.Fatalf("expected failing command to error")
+	}
+	if _, err := (CredentialLayers{}).Expand(bad); err == nil {
+		t.Fatalf("expected exec without runner to error")
+	}
+}
+
+// TestCredentialLayersTreatExternalExecValuesAsPlain verifies env and .env values never run commands.
+func TestCredentialLayersTreatExternalExecValuesAsPlain(t *testing.T) {
+
+	runner := &recordingRunner{outputs: map[string]string{"touch pwned": "ran"}}
+	layers := CredentialLayers{
+		Sources: []providercore.CredentialSource{
+			mapCredentialSource{name: "env", values: map[string]string{"openai:api_key": "exec:touch pwned"}},
+			mapCredentialSource{name: "dotenv", values: map[string]string{"openai:org": "exec:touch pwned"}},
+		},
+		Runner: runner,
+	}
+	fields := []providercore.CredentialField{{Name: "api_key", Secret: true}, {Name: "org"}}
+
+	layered := layers.Layer("openai", fields, nil, nil, nil)
+	expanded, err := layers.Expand(layered)
+	if err != nil {
+		t.Fatalf("expand: %v", err)
+	}
+	if len(runner.ran) != 0 {
+		t.Fatalf("expected no commands to run, ran %v", runner.ran)
+	}
+	if expanded["api_key"] != "exec:touch pwned" || expanded["org"] != "exec:touch pwned" {
+		t.Fatalf("expected external values to stay literal, got %v", expanded)
+	}
+	if sources := layered.DisplaySources(); sources["api_key"] != "env" || sources["org"] != "dotenv" {
+		t.Fatalf("unexpected display sources %v", sources)
+	}
+}
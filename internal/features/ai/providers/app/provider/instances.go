@@ -0,0 +1,108 @@
+// instances.go clones provider instances so one provider type can run under several names.
+// internal/features/ai/providers/app/provider/instances.go
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+)
+
+// ClonedInstance is a newly built provider instance and the refresh interval for its resources.
+type ClonedInstance struct {
+	Instance        providercore.ProviderInstance
+	UpdateFrequency time.Duration
+}
+
+// InstanceCloner persists a copy of a provider's configuration under a new name and builds the instance.
+type InstanceCloner interface {
+	CloneInstance(source, name, displayName string) (ClonedInstance, error)
+}
+
+// SetInstanceCloner configures how provider clones are persisted and built; call before serving requests.
+func (s *Service) SetInstanceCloner(cloner InstanceCloner) {
+
+	s.instanceCloner = cloner
+}
+
+// CloneProvider registers a new instance of source's type under name.
+// Non-secret inputs are always copied; secrets are copied only when copySecrets is set.
+// The clone keeps its own credentials, model selection, status, and resource cache from then on.
+func (s *Service) CloneProvider(source, name, displayName string, copySecrets bool) (Info, error) {
+
+	s.providerOpsMu.Lock()
+	defer s.providerOpsMu.Unlock()
+
+	if s.instanceCloner == nil {
+		return Info{}, errors.New("provider cloning not configured")
+	}
+	name = strings.TrimSpace(name)
+	original := s.registry.Get(source)
+	if original == nil {
+		return Info{}, fmt.Errorf("provider not found: %s", source)
+	}
+	if s.registry.Get(name) != nil {
+		return Info{}, fmt.Errorf("provider already exists: %s", name)
+	}
+
+	cloned, err := s.instanceCloner.CloneInstance(source, name, displayName)
+	if err != nil {
+		return Info{}, err
+	}
+	p := cloned.Instance.Provider
+	if p == nil || p.Name() != name {
+		return Info{}, fmt.Errorf("provider clone built an unexpected instance for %s", name)
+	}
+	s.registry.RegisterInstance(cloned.Instance)
+
+	s.mu.Lock()
+	s.enabledModelIDs[name] = extractModelIDs(p.Models())
+	if cloned.UpdateFrequency > 0 {
+		if s.updateFrequency == nil {
+			s.updateFrequency = make(map[string]time.Duration)
+		}
+		s.updateFrequency[name] = cloned.UpdateFrequency
+	}
+	s.mu.Unlock()
+
+	if err := s.copyStoredCredentials(source, name, s.providerCredentialFields(original), copySecrets); err != nil {
+		s.logWarn("Failed to copy credentials to cloned provider", err, corelogger.LogField{Key: "provider", Value: name})
+	}
+	fields := s.providerCredentialFields(p)
+	if s.isProviderConfigured(name, fields) {
+		if err := s.ensureProviderConfiguredLocked(name); err != nil {
+			s.logWarn("Failed to apply credentials to cloned provider", err, corelogger.LogField{Key: "provider", Value: name})
+		}
+	}
+
+	s.logInfo("Provider cloned", corelogger.LogField{Key: "provider", Value: name}, corelogger.LogField{Key: "source", Value: source})
+	return s.buildInfo(p, s.registry.GetActive()), nil
+}
+
+// copyStoredCredentials copies stored inputs, and optionally secrets, from one instance to another.
+func (s *Service) copyStoredCredentials(source, target string, fields []providercore.CredentialField, copySecrets bool) error {
+
+	if inputs := s.loadProviderInputs(source); len(inputs) > 0 && s.inputsStore != nil {
+		merged := mergeCredentialValues(inputs, s.loadProviderInputs(target))
+		if err := s.inputsStore.SaveProviderInputs(target, merged); err != nil {
+			return err
+		}
+	}
+	if !copySecrets {
+		return nil
+	}
+	secrets := s.loadProviderSecrets(source, fields)
+	if len(secrets) > 0 && s.secrets == nil {
+		return errors.New("secret store not configured")
+	}
+	for field, value := range secrets {
+		if err := s.secrets.SaveProviderSecret(target, field, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
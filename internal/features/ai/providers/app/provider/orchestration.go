@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	coreevents "github.com/MadeByDoug/wls-chatbot/internal/core/events"
 	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
@@ -130,6 +131,190 @@ func (o *Orchestrator) EditImage(ctx context.Context, name string, options provi
 	return prov.EditImage(ctx, options)
 }
 
+// VaryImage creates image variations through a provider that supports them.
+func (o *Orchestrator) VaryImage(ctx context.Context, name string, options providergateway.ImageVariationOptions) (*providergateway.ImageResult, error) {
+
+	prov, err := o.providerByName(name)
+	if err != nil {
+		return nil, err
+	}
+	variator, ok := prov.(providergateway.ImageVariationProvider)
+	if !ok || !providergateway.AdvertisesCapability(prov, providergateway.CapabilityGenerateImageVariation) {
+		return nil, providergateway.NewCapabilityError(prov.Name(), providergateway.CapabilityGenerateImageVariation, "")
+	}
+	if options.N <= 0 {
+		options.N = 1
+	}
+	return variator.CreateImageVariation(ctx, options)
+}
+
+// UpscaleImage upscales an image through a provider that supports it.
+func (o *Orchestrator) UpscaleImage(ctx context.Context, name string, options providergateway.ImageUpscaleOptions) (*providergateway.ImageResult, error) {
+
+	prov, err := o.providerByName(name)
+	if err != nil {
+		return nil, err
+	}
+	upscaler, ok := prov.(providergateway.ImageUpscaleProvider)
+	if !ok || !providergateway.AdvertisesCapability(prov, providergateway.CapabilityVisionUpscaleImage) {
+		return nil, providergateway.NewCapabilityError(prov.Name(), providergateway.CapabilityVisionUpscaleImage, "")
+	}
+	if options.Factor <= 0 {
+		options.Factor = 2
+	}
+	return upscaler.UpscaleImage(ctx, options)
+}
+
+// Embed creates embeddings through a provider that supports them, batching under the provider's request limit.
+func (o *Orchestrator) Embed(ctx context.Context, name string, options providergateway.EmbeddingOptions) (*providergateway.EmbeddingResult, error) {
+
+	prov, err := o.providerByName(name)
+	if err != nil {
+		return nil, err
+	}
+	embedder, ok := prov.(providergateway.Embedder)
+	if !ok || !providergateway.AdvertisesCapability(prov, providergateway.CapabilityRetrievalEmbedText) {
+		return nil, providergateway.NewCapabilityError(prov.Name(), providergateway.CapabilityRetrievalEmbedText, "")
+	}
+	return providergateway.EmbedBatched(ctx, embedder, options)
+}
+
+// Transcribe converts recorded speech to text using a provider that supports speech recognition.
+func (o *Orchestrator) Transcribe(ctx context.Context, name string, options providergateway.TranscriptionOptions) (*providergateway.TranscriptionResult, error) {
+
+	prov, err := o.providerByName(name)
+	if err != nil {
+		return nil, err
+	}
+	transcriber, ok := prov.(providergateway.Transcriber)
+	if !ok || !providergateway.AdvertisesCapability(prov, providergateway.CapabilitySpeechASR) {
+		return nil, providergateway.NewCapabilityError(prov.Name(), providergateway.CapabilitySpeechASR, "")
+	}
+	return transcriber.Transcribe(ctx, options)
+}
+
+// Synthesize converts text to spoken audio using a provider that supports speech synthesis.
+func (o *Orchestrator) Synthesize(ctx context.Context, name string, options providergateway.SpeechOptions) (*providergateway.SpeechResult, error) {
+
+	prov, err := o.providerByName(name)
+	if err != nil {
+		return nil, err
+	}
+	synthesizer, ok := prov.(providergateway.Synthesizer)
+	if !ok || !providergateway.AdvertisesCapability(prov, providergateway.CapabilitySpeechTTS) {
+		return nil, providergateway.NewCapabilityError(prov.Name(), providergateway.CapabilitySpeechTTS, "")
+	}
+	return synthesizer.Synthesize(ctx, options)
+}
+
+// Moderate screens text with a provider's moderation endpoint, falling back to an LLM judge
+// built on the provider's chat model when no dedicated endpoint is available.
+func (o *Orchestrator) Moderate(ctx context.Context, name string, options providergateway.ModerationOptions) (*providergateway.ModerationResult, error) {
+
+	prov, err := o.providerByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if moderator, ok := prov.(providergateway.Moderator); ok && providergateway.AdvertisesCapability(prov, providergateway.CapabilitySafetyModeration) {
+		return moderator.Moderate(ctx, options)
+	}
+	if options.Model == "" {
+		return nil, providergateway.NewCapabilityError(prov.Name(), providergateway.CapabilitySafetyModeration, "set a chat model to use as a moderation judge")
+	}
+	return providergateway.NewJudgeModerator(prov, options.Model).Moderate(ctx, options)
+}
+
+// Rerank scores documents against a query with the provider's reranker, or with an LLM-scoring fallback
+// that rates each document using options.Model as the chat model.
+func (o *Orchestrator) Rerank(ctx context.Context, name string, options providergateway.RerankOptions) (*providergateway.RerankResult, error) {
+
+	prov, err := o.providerByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if reranker, ok := prov.(providergateway.Reranker); ok && providergateway.AdvertisesCapability(prov, providergateway.CapabilityRankRerank) {
+		return reranker.Rerank(ctx, options)
+	}
+	if options.Model == "" {
+		return nil, providergateway.NewCapabilityError(prov.Name(), providergateway.CapabilityRankRerank, "set a chat model to use for LLM scoring")
+	}
+	return providergateway.NewJudgeReranker(prov, options.Model).Rerank(ctx, options)
+}
+
+// LocalModelStates reports installed and loaded models for a provider that hosts models locally.
+func (o *Orchestrator) LocalModelStates(ctx context.Context, name string) ([]providergateway.LocalModelState, error) {
+
+	manager, err := o.localModelManager(name)
+	if err != nil {
+		return nil, err
+	}
+	return manager.LocalModelStates(ctx)
+}
+
+// PullModel downloads a model through a provider that hosts models locally and refreshes its resources.
+func (o *Orchestrator) PullModel(ctx context.Context, name, model string, progress func(providergateway.ModelPullProgress)) error {
+
+	manager, err := o.localModelManager(name)
+	if err != nil {
+		return err
+	}
+	if err := manager.PullModel(ctx, model, progress); err != nil {
+		return err
+	}
+	return o.RefreshProviderResources(ctx, name)
+}
+
+// LoadModel loads a model into memory through a provider that hosts models locally.
+func (o *Orchestrator) LoadModel(ctx context.Context, name, model string) error {
+
+	manager, err := o.localModelManager(name)
+	if err != nil {
+		return err
+	}
+	return manager.LoadModel(ctx, model)
+}
+
+// localModelManager resolves a provider that can pull and load local models.
+func (o *Orchestrator) localModelManager(name string) (providergateway.LocalModelManager, error) {
+
+	prov, err := o.providerByName(name)
+	if err != nil {
+		return nil, err
+	}
+	manager, ok := prov.(providergateway.LocalModelManager)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not manage local models", prov.Name())
+	}
+	return manager, nil
+}
+
+// ReloadProviders replaces registered providers after a configuration reload and notifies listeners.
+func (o *Orchestrator) ReloadProviders(instances []providercore.ProviderInstance, updateFrequency map[string]time.Duration) {
+
+	o.providers.ReplaceProviders(instances, updateFrequency)
+	o.emitProvidersUpdated()
+	o.ensureActiveProviderAsync()
+}
+
+// CloneProvider creates a new named instance from an existing provider and notifies listeners.
+func (o *Orchestrator) CloneProvider(source, name, displayName string, copySecrets bool) (Info, error) {
+
+	normalizedSource, err := normalizedProviderName(source)
+	if err != nil {
+		return Info{}, err
+	}
+	normalizedName, err := normalizedProviderName(name)
+	if err != nil {
+		return Info{}, err
+	}
+
+	info, err := o.providers.CloneProvider(normalizedSource, normalizedName, displayName, copySecrets)
+	if err == nil {
+		o.emitProvidersUpdated()
+	}
+	return info, err
+}
+
 // RefreshProviderResources fetches the latest resources from a provider.
 func (o *Orchestrator) RefreshProviderResources(ctx context.Context, name string) error {
 
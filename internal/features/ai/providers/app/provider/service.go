@@ -16,15 +16,17 @@ import (
 
 // Info represents provider information for the frontend.
 type Info struct {
-	Name             string                         `json:"name"`
-	DisplayName      string                         `json:"displayName"`
-	CredentialFields []providercore.CredentialField `json:"credentialFields,omitempty"`
-	CredentialValues map[string]string              `json:"credentialValues,omitempty"`
-	Models           []providercore.Model           `json:"models"`
-	Resources        []providercore.Model           `json:"resources"`
-	IsConnected      bool                           `json:"isConnected"`
-	IsActive         bool                           `json:"isActive"`
-	Status           *Status                        `json:"status,omitempty"`
+	Name              string                         `json:"name"`
+	Type              string                         `json:"type,omitempty"`
+	DisplayName       string                         `json:"displayName"`
+	CredentialFields  []providercore.CredentialField `json:"credentialFields,omitempty"`
+	CredentialValues  map[string]string              `json:"credentialValues,omitempty"`
+	CredentialSources map[string]string              `json:"credentialSources,omitempty"`
+	Models            []providercore.Model           `json:"models"`
+	Resources         []providercore.Model           `json:"resources"`
+	IsConnected       bool                           `json:"isConnected"`
+	IsActive          bool                           `json:"isActive"`
+	Status            *Status                        `json:"status,omitempty"`
 }
 
 // Status represents the last known health check for a provider.
@@ -54,6 +56,8 @@ type Service struct {
 	mu                sync.RWMutex
 	inputsStore       providercore.ProviderInputsStore
 	secrets           providercore.SecretStore
+	credentialLayers  CredentialLayers
+	instanceCloner    InstanceCloner
 	logger            corelogger.Logger
 	providerOpsMu     sync.Mutex
 }
@@ -99,6 +103,54 @@ func NewService(registry providercore.ProviderRegistry, cache providercore.Provi
 	return s
 }
 
+// SetCredentialLayers configures external credential sources and the exec: command runner; call before serving requests.
+func (s *Service) SetCredentialLayers(layers CredentialLayers) {
+
+	s.credentialLayers = layers
+}
+
+// ReplaceProviders swaps the registered providers for a reloaded configuration.
+// Providers missing from the new set are unregistered; cached resources and stored credentials are reapplied.
+func (s *Service) ReplaceProviders(instances []providercore.ProviderInstance, updateFrequency map[string]time.Duration) {
+
+	s.providerOpsMu.Lock()
+	defer s.providerOpsMu.Unlock()
+
+	if s.registry == nil {
+		return
+	}
+
+	keep := make(map[string]struct{}, len(instances))
+	for _, instance := range instances {
+		keep[instance.Provider.Name()] = struct{}{}
+	}
+	for _, existing := range s.registry.List() {
+		if _, ok := keep[existing.Name()]; !ok {
+			s.registry.Unregister(existing.Name())
+		}
+	}
+	for _, instance := range instances {
+		s.registry.RegisterInstance(instance)
+	}
+
+	enabled := captureEnabledModelIDs(s.registry)
+	s.mu.Lock()
+	s.enabledModelIDs = enabled
+	s.updateFrequency = copyUpdateFrequency(updateFrequency)
+	s.mu.Unlock()
+
+	for _, instance := range instances {
+		p := instance.Provider
+		s.applyEnabledModels(p.Name(), s.GetResources(p.Name()))
+		if !s.isProviderConfigured(p.Name(), s.providerCredentialFields(p)) {
+			continue
+		}
+		if err := s.ensureProviderConfiguredLocked(p.Name()); err != nil {
+			s.logWarn("Failed to apply credentials after reload", err, corelogger.LogField{Key: "provider", Value: p.Name()})
+		}
+	}
+}
+
 // loadCache loads cached provider resources from disk.
 func (s *Service) loadCache() {
 
@@ -136,7 +188,9 @@ func (s *Service) applyEnabledModelsFromCache() {
 // applyEnabledModels updates provider models using enabled IDs and available resources.
 func (s *Service) applyEnabledModels(name string, resources []providercore.Model) {
 
+	s.mu.RLock()
 	enabledIDs := s.enabledModelIDs[name]
+	s.mu.RUnlock()
 	if p := s.registry.Get(name); p != nil {
 		if len(resources) == 0 {
 			_ = p.Configure(providercore.ProviderConfig{Models: buildFallbackModels(enabledIDs)})
@@ -303,15 +357,20 @@ func filterCredentialValues(fields []providercore.CredentialField, credentials p
 	return filtered
 }
 
-// resolveCredentials merges stored and incoming credentials with validation.
+// layerCredentials merges config inputs, stored secrets, external sources, and incoming values by precedence.
+func (s *Service) layerCredentials(name string, fields []providercore.CredentialField, input providercore.ProviderCredentials) LayeredCredentials {
+
+	return s.credentialLayers.Layer(name, fields, s.loadProviderInputs(name), s.loadProviderSecrets(name, fields), input)
+}
+
+// resolveCredentials merges layered credentials, validates them, and expands exec: references.
 func (s *Service) resolveCredentials(name string, fields []providercore.CredentialField, input providercore.ProviderCredentials) (providercore.ProviderCredentials, error) {
 
-	stored := mergeCredentialValues(s.loadProviderInputs(name), s.loadProviderSecrets(name, fields))
-	merged := mergeCredentialValues(stored, input)
-	if err := validateRequiredCredentials(fields, merged); err != nil {
+	layered := s.layerCredentials(name, fields, input)
+	if err := validateRequiredCredentials(fields, layered.Values); err != nil {
 		return nil, err
 	}
-	return merged, nil
+	return s.credentialLayers.Expand(layered)
 }
 
 // persistCredentials saves provided credential values to storage.
@@ -396,8 +455,7 @@ func (s *Service) clearStoredCredentials(name string, fields []providercore.Cred
 // isProviderConfigured returns true when required credential fields are present.
 func (s *Service) isProviderConfigured(name string, fields []providercore.CredentialField) bool {
 
-	credentials := mergeCredentialValues(s.loadProviderInputs(name), s.loadProviderSecrets(name, fields))
-	return validateRequiredCredentials(fields, credentials) == nil
+	return validateRequiredCredentials(fields, s.layerCredentials(name, fields, nil).Values) == nil
 }
 
 // refreshResourcesIfStale launches a background refresh when cache is outdated.
@@ -444,6 +502,8 @@ func (s *Service) shouldRefreshResources(name string) bool {
 // getUpdateFrequency returns the configured update cadence for a provider.
 func (s *Service) getUpdateFrequency(name string) time.Duration {
 
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	if s.updateFrequency == nil {
 		return 0
 	}
@@ -523,25 +583,34 @@ func (s *Service) List() []Info {
 	for i, p := range providers {
 		// Trigger stale-check; method schedules background refresh only when needed.
 		s.refreshResourcesIfStale(p.Name())
-		fields := s.providerCredentialFields(p)
-		isConfigured := s.isProviderConfigured(p.Name(), fields)
-		hasHealthyStatus := s.hasSuccessfulStatus(p.Name())
-		// Skip loading inputs during list to avoid blocking - credentials are only needed on connect/configure
-		info[i] = Info{
-			Name:             p.Name(),
-			DisplayName:      p.DisplayName(),
-			CredentialFields: fields,
-			CredentialValues: nil, // Load on demand, not during list
-			Models:           p.Models(),
-			Resources:        s.GetResources(p.Name()),
-			IsConnected:      isConfigured || hasHealthyStatus,
-			IsActive:         active != nil && active.Name() == p.Name(),
-			Status:           s.GetStatus(p.Name()),
-		}
+		info[i] = s.buildInfo(p, active)
 	}
 	return info
 }
 
+// buildInfo summarizes a registered provider for listing.
+func (s *Service) buildInfo(p, active providercore.Provider) Info {
+
+	fields := s.providerCredentialFields(p)
+	layered := s.layerCredentials(p.Name(), fields, nil)
+	isConfigured := validateRequiredCredentials(fields, layered.Values) == nil
+	hasHealthyStatus := s.hasSuccessfulStatus(p.Name())
+	// Skip loading inputs during list to avoid blocking - credentials are only needed on connect/configure
+	return Info{
+		Name:              p.Name(),
+		Type:              s.registry.InstanceType(p.Name()),
+		DisplayName:       p.DisplayName(),
+		CredentialFields:  fields,
+		CredentialValues:  nil, // Load on demand, not during list
+		CredentialSources: layered.DisplaySources(),
+		Models:            p.Models(),
+		Resources:         s.GetResources(p.Name()),
+		IsConnected:       isConfigured || hasHealthyStatus,
+		IsActive:          active != nil && active.Name() == p.Name(),
+		Status:            s.GetStatus(p.Name()),
+	}
+}
+
 // Connect configures, validates, and persists a provider connection.
 func (s *Service) Connect(ctx context.Context, name string, credentials providercore.ProviderCredentials) (Info, error) {
 
@@ -606,15 +675,17 @@ func (s *Service) Connect(ctx context.Context, name string, credentials provider
 	s.SetStatus(name, true, "")
 	inputs := s.loadProviderInputs(p.Name())
 	return Info{
-		Name:             p.Name(),
-		DisplayName:      p.DisplayName(),
-		CredentialFields: fields,
-		CredentialValues: filterCredentialValues(fields, inputs, false),
-		Models:           p.Models(),
-		Resources:        s.GetResources(p.Name()),
-		IsConnected:      s.isProviderConfigured(p.Name(), fields),
-		IsActive:         active != nil && active.Name() == p.Name(),
-		Status:           s.GetStatus(p.Name()),
+		Name:              p.Name(),
+		Type:              s.registry.InstanceType(p.Name()),
+		DisplayName:       p.DisplayName(),
+		CredentialFields:  fields,
+		CredentialValues:  filterCredentialValues(fields, inputs, false),
+		CredentialSources: s.layerCredentials(p.Name(), fields, nil).DisplaySources(),
+		Models:            p.Models(),
+		Resources:         s.GetResources(p.Name()),
+		IsConnected:       s.isProviderConfigured(p.Name(), fields),
+		IsActive:          active != nil && active.Name() == p.Name(),
+		Status:            s.GetStatus(p.Name()),
 	}, nil
 }
 
@@ -0,0 +1,67 @@
+// instances.go persists cloned provider instances to the config store and builds their adapters.
+// internal/features/ai/providers/instances.go
+package providers
+
+import (
+	"fmt"
+
+	config "github.com/MadeByDoug/wls-chatbot/internal/core/config"
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	providerusecase "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/app/provider"
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+)
+
+// ConfigInstanceCloner clones provider configuration in the config store.
+// The source is looked up in the merged configuration so providers declared only in the config file can be cloned.
+type ConfigInstanceCloner struct {
+	store   config.Store
+	source  config.FileSource
+	secrets providercore.SecretStore
+	layers  providerusecase.CredentialLayers
+	logger  corelogger.Logger
+}
+
+var _ providerusecase.InstanceCloner = (*ConfigInstanceCloner)(nil)
+
+// NewConfigInstanceCloner creates an instance cloner backed by store and the optional config file source.
+func NewConfigInstanceCloner(store config.Store, source config.FileSource, secrets providercore.SecretStore, layers providerusecase.CredentialLayers, logger corelogger.Logger) *ConfigInstanceCloner {
+
+	return &ConfigInstanceCloner{store: store, source: source, secrets: secrets, layers: layers, logger: logger}
+}
+
+// CloneInstance saves a copy of source's configuration under name and builds the new provider instance.
+func (c *ConfigInstanceCloner) CloneInstance(source, name, displayName string) (providerusecase.ClonedInstance, error) {
+
+	merged, err := config.LoadWithFile(c.store, c.source)
+	if err != nil {
+		return providerusecase.ClonedInstance{}, err
+	}
+	_, clone, err := config.CloneProvider(merged, source, name, displayName)
+	if err != nil {
+		return providerusecase.ClonedInstance{}, err
+	}
+	single := config.AppConfig{Providers: []config.ProviderConfig{clone}}
+
+	instances, err := InstancesFromConfig(single, c.secrets, c.layers, c.logger)
+	if err != nil {
+		return providerusecase.ClonedInstance{}, err
+	}
+	frequencies, err := config.ResolveUpdateFrequencies(single)
+	if err != nil {
+		return providerusecase.ClonedInstance{}, err
+	}
+
+	stored, err := config.LoadConfig(c.store)
+	if err != nil {
+		return providerusecase.ClonedInstance{}, err
+	}
+	if _, exists := stored.Provider(name); exists {
+		return providerusecase.ClonedInstance{}, fmt.Errorf("provider already exists: %s", name)
+	}
+	stored.Providers = append(stored.Providers, clone)
+	if err := c.store.Save(stored); err != nil {
+		return providerusecase.ClonedInstance{}, err
+	}
+
+	return providerusecase.ClonedInstance{Instance: instances[0], UpdateFrequency: frequencies[name]}, nil
+}
@@ -0,0 +1,128 @@
+// instances_test.go verifies provider instances of the same type can be cloned and kept independent.
+// internal/features/ai/providers/instances_test.go
+package providers
+
+import (
+	"errors"
+	"testing"
+
+	config "github.com/MadeByDoug/wls-chatbot/internal/core/config"
+	providerusecase "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/app/provider"
+)
+
+// TestCloneProviderRegistersIndependentInstance verifies a clone is persisted, typed, and keeps its own secrets.
+func TestCloneProviderRegistersIndependentInstance(t *testing.T) {
+
+	store := &memoryConfigStore{cfg: config.AppConfig{Providers: []config.ProviderConfig{
+		{Type: "openai", Name: "openai", DisplayName: "OpenAI", Models: []config.ModelConfig{{ID: "gpt-4o", Enabled: true}}},
+	}}}
+	secrets := memorySecretStore{"openai/api_key": "sk-personal"}
+
+	service, registry, err := BuildProviderService(store.cfg, nil, secrets, store, providerusecase.CredentialLayers{}, nil)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	service.SetInstanceCloner(NewConfigInstanceCloner(store, config.FileSource{}, secrets, providerusecase.CredentialLayers{}, nil))
+
+	info, err := service.CloneProvider("openai", "openai-work", "OpenAI Work", false)
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	if info.Type != "openai" || info.DisplayName != "OpenAI Work" || info.IsConnected {
+		t.Fatalf("unexpected clone info %#v", info)
+	}
+	if len(registry.List()) != 2 || registry.InstanceType("openai") != "openai" || registry.InstanceType("openai-work") != "openai" {
+		t.Fatalf("expected two openai instances, got %d providers", len(registry.List()))
+	}
+	if _, ok := store.cfg.Provider("openai-work"); !ok {
+		t.Fatalf("expected clone to be saved to the config store")
+	}
+	if _, ok := secrets["openai-work/api_key"]; ok {
+		t.Fatalf("expected secrets to stay with the source without --copy-credentials")
+	}
+
+	copied, err := service.CloneProvider("openai", "openai-team", "", true)
+	if err != nil {
+		t.Fatalf("clone with secrets: %v", err)
+	}
+	if !copied.IsConnected || secrets["openai-team/api_key"] != "sk-personal" {
+		t.Fatalf("expected copied secret to configure the clone, got %#v", copied)
+	}
+
+	if _, err := service.CloneProvider("openai", "openai-work", "", false); err == nil {
+		t.Fatalf("expected duplicate instance name to fail")
+	}
+
+	if _, err := InstancesFromConfig(config.AppConfig{Providers: []config.ProviderConfig{
+		{Type: "openai", Name: "dup"},
+		{Type: "gemini", Name: "dup"},
+	}}, nil, providerusecase.CredentialLayers{}, nil); err == nil {
+		t.Fatalf("expected duplicate provider names to fail")
+	}
+}
+
+// memoryConfigStore keeps configuration and provider inputs in memory.
+type memoryConfigStore struct {
+	cfg config.AppConfig
+}
+
+func (s *memoryConfigStore) Load() (config.AppConfig, error) {
+
+	return s.cfg, nil
+}
+
+func (s *memoryConfigStore) Save(cfg config.AppConfig) error {
+
+	s.cfg = cfg
+	return nil
+}
+
+func (s *memoryConfigStore) LoadProviderInputs(providerName string) (map[string]string, error) {
+
+	provider, ok := s.cfg.Provider(providerName)
+	if !ok {
+		return nil, errors.New("provider not found")
+	}
+	return provider.Inputs, nil
+}
+
+func (s *memoryConfigStore) SaveProviderInputs(providerName string, inputs map[string]string) error {
+
+	for index := range s.cfg.Providers {
+		if s.cfg.Providers[index].Name == providerName {
+			s.cfg.Providers[index].Inputs = inputs
+			return nil
+		}
+	}
+	return errors.New("provider not found")
+}
+
+// memorySecretStore keys secrets by provider/field.
+type memorySecretStore map[string]string
+
+func (s memorySecretStore) SaveProviderSecret(providerName, fieldName, value string) error {
+
+	s[providerName+"/"+fieldName] = value
+	return nil
+}
+
+func (s memorySecretStore) GetProviderSecret(providerName, fieldName string) (string, error) {
+
+	value, ok := s[providerName+"/"+fieldName]
+	if !ok {
+		return "", errors.New("secret not found")
+	}
+	return value, nil
+}
+
+func (s memorySecretStore) HasProviderSecret(providerName, fieldName string) bool {
+
+	_, ok := s[providerName+"/"+fieldName]
+	return ok
+}
+
+func (s memorySecretStore) DeleteProviderSecret(providerName, fieldName string) error {
+
+	delete(s, providerName+"/"+fieldName)
+	return nil
+}
@@ -0,0 +1,281 @@
+// driver.go runs modeltest capability scenarios through the production provider adapters.
+// internal/features/ai/providers/modeltestdriver/driver.go
+package modeltestdriver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	config "github.com/MadeByDoug/wls-chatbot/internal/core/config"
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	"github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers"
+	providerhttp "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/httpcompat"
+	providerusecase "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/app/provider"
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+	"github.com/MadeByDoug/wls-chatbot/pkg/models/modeltest"
+)
+
+// placeholderAPIKey satisfies adapters that refuse to start without a key when replaying goldens.
+const placeholderAPIKey = "modeltest-placeholder-key"
+
+// placeholderAccountID satisfies adapters that require an account identifier when replaying goldens.
+const placeholderAccountID = "modeltest-placeholder-account"
+
+// Driver builds adapters with providers.ProvidersFromConfig and routes their traffic through an injected transport.
+type Driver struct {
+	logger corelogger.Logger
+}
+
+// httpClientSetter is implemented by adapters that accept an injected HTTP client.
+type httpClientSetter interface {
+	SetHTTPClient(client providerhttp.Client)
+}
+
+var _ modeltest.AdapterDriver = (*Driver)(nil)
+
+// New creates an adapter driver.
+func New(logger corelogger.Logger) *Driver {
+
+	return &Driver{logger: logger}
+}
+
+// Supports reports whether the gateway contract can express a capability scenario.
+// Tool results, response schemas, and image input are not part of the gateway chat contract yet.
+func (d *Driver) Supports(capability modeltest.Capability) bool {
+
+	switch capability {
+	case modeltest.CapabilityTestConnection,
+		modeltest.CapabilityChat,
+		modeltest.CapabilityStreaming,
+		modeltest.CapabilityMultiTurn,
+		modeltest.CapabilityImageGen:
+		return true
+	default:
+		return false
+	}
+}
+
+// Test runs one capability scenario against a freshly built adapter.
+func (d *Driver) Test(ctx context.Context, prov modeltest.ProviderConfig, model string, capability modeltest.Capability, transport http.RoundTripper) modeltest.TestResult {
+
+	result := modeltest.TestResult{
+		Provider:   prov.Name,
+		Capability: capability,
+		Model:      model,
+	}
+
+	provider, err := d.buildProvider(prov, model, transport)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	switch capability {
+	case modeltest.CapabilityTestConnection:
+		err = provider.TestConnection(ctx)
+	case modeltest.CapabilityChat:
+		err = runChat(ctx, provider, model, []providergateway.ProviderMessage{
+			{Role: providergateway.RoleUser, Content: "Say 'test successful' and nothing else."},
+		}, false, "")
+	case modeltest.CapabilityStreaming:
+		err = runChat(ctx, provider, model, []providergateway.ProviderMessage{
+			{Role: providergateway.RoleUser, Content: "Count from 1 to 5, separated by spaces, and nothing else."},
+		}, true, "")
+	case modeltest.CapabilityMultiTurn:
+		err = runChat(ctx, provider, model, []providergateway.ProviderMessage{
+			{Role: providergateway.RoleUser, Content: "Remember this: my favorite color is teal."},
+			{Role: providergateway.RoleAssistant, Content: "Got it, your favorite color is teal."},
+			{Role: providergateway.RoleUser, Content: "What is my favorite color? Answer with one word."},
+		}, false, "teal")
+	case modeltest.CapabilityImageGen:
+		err = runImageGeneration(ctx, provider, model)
+	default:
+		err = fmt.Errorf("capability %q is not supported by the adapter driver", capability)
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Success = true
+	return result
+}
+
+// buildProvider constructs the production adapter for a provider and injects the transport.
+func (d *Driver) buildProvider(prov modeltest.ProviderConfig, model string, transport http.RoundTripper) (providercore.Provider, error) {
+
+	secrets, inputs := resolveCredentials(prov)
+	cfg := config.AppConfig{
+		Providers: []config.ProviderConfig{
+			{
+				Type:         adapterType(prov),
+				Name:         prov.Name,
+				DisplayName:  prov.Name,
+				DefaultModel: model,
+				Models:       []config.ModelConfig{{ID: model, Enabled: true}},
+				Inputs:       inputs,
+			},
+		},
+	}
+
+	built, err := providers.ProvidersFromConfig(cfg, staticSecrets(secrets), providerusecase.CredentialLayers{}, d.logger)
+	if err != nil {
+		return nil, fmt.Errorf("build adapter: %w", err)
+	}
+	if len(built) != 1 {
+		return nil, fmt.Errorf("build adapter: expected 1 provider, got %d", len(built))
+	}
+
+	setter, ok := built[0].(httpClientSetter)
+	if !ok {
+		return nil, fmt.Errorf("adapter %q does not accept an injected HTTP client", prov.Name)
+	}
+	setter.SetHTTPClient(&http.Client{Transport: transport})
+	return built[0], nil
+}
+
+// runChat sends messages through the adapter and validates the assembled chunks.
+func runChat(ctx context.Context, provider providercore.Provider, model string, messages []providergateway.ProviderMessage, stream bool, expect string) error {
+
+	chunks, err := provider.Chat(ctx, messages, providergateway.ChatOptions{
+		Model:     model,
+		MaxTokens: 256,
+		Stream:    stream,
+	})
+	if err != nil {
+		return fmt.Errorf("chat: %w", err)
+	}
+
+	var content strings.Builder
+	finishReason := ""
+	count := 0
+	for chunk := range chunks {
+		if chunk.Error != nil {
+			return fmt.Errorf("chat chunk: %w", chunk.Error)
+		}
+		count++
+		content.WriteString(chunk.Content)
+		if chunk.FinishReason != "" {
+			finishReason = chunk.FinishReason
+		}
+	}
+
+	switch {
+	case strings.TrimSpace(content.String()) == "":
+		return fmt.Errorf("adapter produced no content from %d chunks", count)
+	case stream && finishReason == "":
+		return fmt.Errorf("adapter stream ended without a finish reason")
+	case expect != "" && !strings.Contains(strings.ToLower(content.String()), expect):
+		return fmt.Errorf("expected answer to mention %q, got %q", expect, content.String())
+	}
+	return nil
+}
+
+// runImageGeneration requests a single image through the adapter.
+func runImageGeneration(ctx context.Context, provider providercore.Provider, model string) error {
+
+	result, err := provider.GenerateImage(ctx, providergateway.ImageGenerationOptions{
+		Model:          model,
+		Prompt:         "A simple red square on white background",
+		N:              1,
+		ResponseFormat: "b64_json",
+	})
+	if err != nil {
+		return fmt.Errorf("generate image: %w", err)
+	}
+	if result == nil || len(result.Data) == 0 {
+		return fmt.Errorf("adapter returned no images")
+	}
+	if result.Data[0].B64JSON == "" && result.Data[0].URL == "" {
+		return fmt.Errorf("adapter returned an empty image")
+	}
+	return nil
+}
+
+// adapterType maps a modeltest provider onto the application's provider type.
+func adapterType(prov modeltest.ProviderConfig) string {
+
+	switch prov.Name {
+	case "openai", "anthropic", "gemini", "grok", "openrouter", "cloudflare":
+		return prov.Name
+	default:
+		return string(prov.Type)
+	}
+}
+
+// resolveAPIKey returns the plan key, then <PROVIDER>_API_KEY, then a placeholder for offline replay.
+func resolveAPIKey(prov modeltest.ProviderConfig) string {
+
+	if key := strings.TrimSpace(prov.APIKey); key != "" {
+		return key
+	}
+	if key := strings.TrimSpace(os.Getenv(strings.ToUpper(prov.Name) + "_API_KEY")); key != "" {
+		return key
+	}
+	return placeholderAPIKey
+}
+
+// resolveCredentials returns the secrets and plain inputs the provider's adapter requires.
+// Cloudflare also needs an account ID and an API token, read from CLOUDFLARE_ACCOUNT_ID and CLOUDFLARE_API_TOKEN;
+// the token falls back to the resolved API key.
+func resolveCredentials(prov modeltest.ProviderConfig) (map[string]string, map[string]string) {
+
+	apiKey := resolveAPIKey(prov)
+	secrets := map[string]string{providercore.CredentialAPIKey: apiKey}
+	if adapterType(prov) != "cloudflare" {
+		return secrets, nil
+	}
+
+	secrets[providercore.CredentialCloudflareToken] = firstEnv(apiKey, "CLOUDFLARE_API_TOKEN")
+	inputs := map[string]string{providercore.CredentialAccountID: firstEnv(placeholderAccountID, "CLOUDFLARE_ACCOUNT_ID")}
+	return secrets, inputs
+}
+
+// firstEnv returns the first non-empty environment variable, or fallback when none is set.
+func firstEnv(fallback string, names ...string) string {
+
+	for _, name := range names {
+		if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+			return value
+		}
+	}
+	return fallback
+}
+
+// staticSecrets serves fixed secret values to ProvidersFromConfig, keyed by credential field.
+type staticSecrets map[string]string
+
+var _ providercore.SecretStore = staticSecrets{}
+
+// SaveProviderSecret rejects writes; the driver never persists credentials.
+func (s staticSecrets) SaveProviderSecret(providerName, fieldName, value string) error {
+
+	return fmt.Errorf("modeltest driver: secrets are read-only")
+}
+
+// GetProviderSecret returns the value served for a credential field.
+func (s staticSecrets) GetProviderSecret(providerName, fieldName string) (string, error) {
+
+	value, ok := s[fieldName]
+	if !ok {
+		return "", fmt.Errorf("modeltest driver: no secret %q", fieldName)
+	}
+	return value, nil
+}
+
+// HasProviderSecret reports whether a value is served for a credential field.
+func (s staticSecrets) HasProviderSecret(providerName, fieldName string) bool {
+
+	_, ok := s[fieldName]
+	return ok
+}
+
+// DeleteProviderSecret rejects deletes; the driver never persists credentials.
+func (s staticSecrets) DeleteProviderSecret(providerName, fieldName string) error {
+
+	return fmt.Errorf("modeltest driver: secrets are read-only")
+}
@@ -0,0 +1,127 @@
+// driver_test.go verifies adapter-driven modeltest scenarios replay golden files offline.
+// internal/features/ai/providers/modeltestdriver/driver_test.go
+package modeltestdriver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+	"github.com/MadeByDoug/wls-chatbot/pkg/models/modeltest"
+	"github.com/rs/zerolog"
+)
+
+// TestDriverReplaysGoldenThroughAdapters ensures production adapters parse recorded responses via the injected transport.
+func TestDriverReplaysGoldenThroughAdapters(t *testing.T) {
+
+	testCases := []struct {
+		name        string
+		provider    modeltest.ProviderConfig
+		capability  modeltest.Capability
+		response    modeltest.RecordedResponse
+		wantSuccess bool
+	}{
+		{
+			name:       "openai chat",
+			provider:   modeltest.ProviderConfig{Name: "openai", Type: modeltest.ProviderTypeOpenAI},
+			capability: modeltest.CapabilityChat,
+			response: modeltest.RecordedResponse{
+				Status:  200,
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body: json.RawMessage(`{"id":"c1","object":"chat.completion","created":1,"model":"gpt-test",` +
+					`"choices":[{"index":0,"message":{"role":"assistant","content":"test successful"},"finish_reason":"stop"}]}`),
+			},
+			wantSuccess: true,
+		},
+		{
+			name:       "anthropic streaming",
+			provider:   modeltest.ProviderConfig{Name: "anthropic", Type: modeltest.ProviderTypeAnthropic},
+			capability: modeltest.CapabilityStreaming,
+			response: modeltest.RecordedResponse{
+				Status:  200,
+				Headers: map[string]string{"Content-Type": "text/event-stream"},
+				RawBody: "event: message_start\ndata: {\"type\":\"message_start\",\"message\":{\"id\":\"m1\",\"type\":\"message\",\"role\":\"assistant\",\"model\":\"claude-test\",\"content\":[],\"usage\":{\"input_tokens\":1,\"output_tokens\":0}}}\n\n" +
+					"event: content_block_start\ndata: {\"type\":\"content_block_start\",\"index\":0,\"content_block\":{\"type\":\"text\",\"text\":\"\"}}\n\n" +
+					"event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"text_delta\",\"text\":\"1 2 3 4 5\"}}\n\n" +
+					"event: content_block_stop\ndata: {\"type\":\"content_block_stop\",\"index\":0}\n\n" +
+					"event: message_delta\ndata: {\"type\":\"message_delta\",\"delta\":{\"stop_reason\":\"end_turn\"},\"usage\":{\"output_tokens\":5}}\n\n" +
+					"event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n",
+			},
+			wantSuccess: true,
+		},
+		{
+			name:       "openai error status",
+			provider:   modeltest.ProviderConfig{Name: "openai", Type: modeltest.ProviderTypeOpenAI},
+			capability: modeltest.CapabilityChat,
+			response: modeltest.RecordedResponse{
+				Status:  401,
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    json.RawMessage(`{"error":{"message":"invalid key","type":"invalid_request_error"}}`),
+			},
+		},
+	}
+
+	driver := New(corelogger.NewAdapter(zerolog.Nop()))
+	for _, testCase := range testCases {
+		golden := &modeltest.GoldenFile{
+			Metadata: modeltest.GoldenMetadata{
+				Provider:   testCase.provider.Name,
+				Capability: string(testCase.capability),
+				Model:      "m",
+				Driver:     modeltest.DriverAdapter,
+			},
+			Response: testCase.response,
+		}
+		transport := modeltest.NewCapabilityMockTransport(modeltest.NewGoldenIndex([]*modeltest.GoldenFile{golden}), testCase.provider.Name, "m", testCase.capability)
+		transport.SetDriver(modeltest.DriverAdapter)
+
+		result := driver.Test(context.Background(), testCase.provider, "m", testCase.capability, transport)
+		if result.Success != testCase.wantSuccess {
+			t.Fatalf("%s: expected success=%v, got %+v", testCase.name, testCase.wantSuccess, result)
+		}
+	}
+}
+
+// TestDriverServesCloudflareCredentials ensures the Cloudflare adapter receives its account ID and API token.
+func TestDriverServesCloudflareCredentials(t *testing.T) {
+
+	t.Setenv("CLOUDFLARE_ACCOUNT_ID", "")
+	t.Setenv("CLOUDFLARE_API_TOKEN", "")
+	t.Setenv("CLOUDFLARE_API_KEY", "")
+
+	driver := New(corelogger.NewAdapter(zerolog.Nop()))
+	provider := modeltest.ProviderConfig{Name: "cloudflare", Type: modeltest.ProviderTypeOpenAI}
+	result := driver.Test(context.Background(), provider, "@cf/meta/llama", modeltest.CapabilityTestConnection, nil)
+	if !result.Success {
+		t.Fatalf("expected cloudflare test connection to succeed, got %+v", result)
+	}
+
+	secrets, inputs := resolveCredentials(provider)
+	if secrets[providercore.CredentialCloudflareToken] != placeholderAPIKey {
+		t.Fatalf("expected token to fall back to the API key, got %q", secrets[providercore.CredentialCloudflareToken])
+	}
+	if inputs[providercore.CredentialAccountID] != placeholderAccountID {
+		t.Fatalf("expected placeholder account ID, got %q", inputs[providercore.CredentialAccountID])
+	}
+
+	t.Setenv("CLOUDFLARE_ACCOUNT_ID", "acct-123")
+	t.Setenv("CLOUDFLARE_API_TOKEN", "cf-token")
+	secrets, inputs = resolveCredentials(provider)
+	if secrets[providercore.CredentialCloudflareToken] != "cf-token" || inputs[providercore.CredentialAccountID] != "acct-123" {
+		t.Fatalf("expected environment credentials, got secrets=%v inputs=%v", secrets, inputs)
+	}
+}
+
+// TestDriverSupportsOnlyGatewayExpressibleCapabilities ensures scenarios beyond the gateway contract are skipped.
+func TestDriverSupportsOnlyGatewayExpressibleCapabilities(t *testing.T) {
+
+	driver := New(nil)
+	if !driver.Supports(modeltest.CapabilityStreaming) {
+		t.Fatalf("expected streaming to be supported")
+	}
+	if driver.Supports(modeltest.CapabilityToolCalling) || driver.Supports(modeltest.CapabilityVision) {
+		t.Fatalf("expected tool calling and vision to be unsupported")
+	}
+}
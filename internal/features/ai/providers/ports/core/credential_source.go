@@ -0,0 +1,29 @@
+// credential_source.go defines external credential source contracts layered over stored credentials.
+// internal/features/ai/providers/ports/core/credential_source.go
+package core
+
+import "strings"
+
+// CredentialExecPrefix marks a credential value as a command whose output is the real value, e.g. "exec:pass show openai".
+const CredentialExecPrefix = "exec:"
+
+// CredentialSource supplies provider credential values from outside persistent storage, such as env vars or a .env file.
+type CredentialSource interface {
+	Name() string
+	LookupProviderCredential(providerName, fieldName string) (string, bool)
+}
+
+// CredentialCommandRunner runs exec: credential commands and returns their trimmed output.
+type CredentialCommandRunner interface {
+	RunCredentialCommand(command string) (string, error)
+}
+
+// CredentialExecCommand returns the command of an exec: credential reference.
+func CredentialExecCommand(value string) (string, bool) {
+
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, CredentialExecPrefix) {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(trimmed, CredentialExecPrefix)), true
+}
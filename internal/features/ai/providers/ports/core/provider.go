@@ -40,6 +40,13 @@ const (
 	CredentialCloudflareToken   = "cloudflare_api_token"
 	CredentialOpenRouterReferer = "openrouter_referer"
 	CredentialOpenRouterTitle   = "openrouter_title"
+	CredentialAuthHeader        = "auth_header"
+	CredentialAuthScheme        = "auth_scheme"
+	CredentialExtraHeaders      = "extra_headers"
+	CredentialModelIDs          = "model_ids"
+	CredentialEndpoint          = "endpoint"
+	CredentialAPIVersion        = "api_version"
+	CredentialDeployments       = "deployments"
 )
 
 // ProviderConfig holds provider configuration.
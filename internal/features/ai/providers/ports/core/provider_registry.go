@@ -2,9 +2,19 @@
 // internal/features/ai/providers/ports/core/provider_registry.go
 package core
 
+// ProviderInstance pairs a provider adapter with the provider type it was built from.
+// Several instances may share a type, e.g. a personal and a team OpenAI account.
+type ProviderInstance struct {
+	Type     string
+	Provider Provider
+}
+
 // ProviderRegistry manages provider instances and active selection.
 type ProviderRegistry interface {
 	Register(p Provider)
+	RegisterInstance(instance ProviderInstance)
+	Unregister(name string)
+	InstanceType(name string) string
 	Get(name string) Provider
 	GetActive() Provider
 	SetActive(name string) bool
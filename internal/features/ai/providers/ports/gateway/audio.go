@@ -0,0 +1,87 @@
+// audio.go defines the optional gateway contracts for speech-to-text and text-to-speech.
+// internal/features/ai/providers/ports/gateway/audio.go
+package gateway
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+)
+
+// TranscriptionOptions contains inputs for one speech-to-text request.
+// Filename carries the original name so providers can infer the container format.
+type TranscriptionOptions struct {
+	Model    string `json:"model"`
+	Audio    []byte `json:"-"`
+	Filename string `json:"filename,omitempty"`
+	Language string `json:"language,omitempty"`
+	Prompt   string `json:"prompt,omitempty"`
+}
+
+// TranscriptionResult contains the recognized text for one audio input.
+type TranscriptionResult struct {
+	Model    string  `json:"model,omitempty"`
+	Text     string  `json:"text"`
+	Language string  `json:"language,omitempty"`
+	Duration float64 `json:"duration,omitempty"`
+}
+
+// Transcriber is implemented by providers that turn recorded speech into text.
+type Transcriber interface {
+	Transcribe(ctx context.Context, opts TranscriptionOptions) (*TranscriptionResult, error)
+}
+
+// SpeechOptions contains inputs for one text-to-speech request.
+type SpeechOptions struct {
+	Model  string  `json:"model"`
+	Input  string  `json:"input"`
+	Voice  string  `json:"voice,omitempty"`
+	Format string  `json:"format,omitempty"`
+	Speed  float64 `json:"speed,omitempty"`
+}
+
+// SpeechResult contains synthesized audio and its container format.
+type SpeechResult struct {
+	Model    string `json:"model,omitempty"`
+	Audio    []byte `json:"-"`
+	Format   string `json:"format"`
+	MIMEType string `json:"mimeType"`
+}
+
+// Synthesizer is implemented by providers that turn text into spoken audio.
+type Synthesizer interface {
+	Synthesize(ctx context.Context, opts SpeechOptions) (*SpeechResult, error)
+}
+
+// audioMIMETypes maps audio format names and file extensions to MIME types.
+var audioMIMETypes = map[string]string{
+	"mp3":  "audio/mpeg",
+	"mpga": "audio/mpeg",
+	"mpeg": "audio/mpeg",
+	"wav":  "audio/wav",
+	"ogg":  "audio/ogg",
+	"oga":  "audio/ogg",
+	"opus": "audio/opus",
+	"flac": "audio/flac",
+	"aac":  "audio/aac",
+	"m4a":  "audio/mp4",
+	"mp4":  "audio/mp4",
+	"webm": "audio/webm",
+	"pcm":  "audio/pcm",
+}
+
+// AudioMIMEType returns the MIME type for a format name or file name, or "" when unknown.
+func AudioMIMEType(formatOrFilename string) string {
+
+	format := strings.ToLower(strings.TrimSpace(formatOrFilename))
+	if ext := filepath.Ext(format); ext != "" {
+		format = strings.TrimPrefix(ext, ".")
+	}
+	return audioMIMETypes[format]
+}
+
+// AudioFormatFromFilename returns the lower-case extension of an audio file name without the dot.
+func AudioFormatFromFilename(filename string) string {
+
+	return strings.TrimPrefix(strings.ToLower(filepath.Ext(strings.TrimSpace(filename))), ".")
+}
@@ -9,6 +9,8 @@ const (
 	CapabilityChatText                 CapabilityID = "chat.text"
 	CapabilityChatMultimodalToText     CapabilityID = "chat.multimodal_to_text"
 	CapabilityGenerateImage            CapabilityID = "gen.image"
+	CapabilityGenerateImageVariation   CapabilityID = "gen.image.variation"
+	CapabilityVisionUpscaleImage       CapabilityID = "vision.upscale.image"
 	CapabilityGenerateVideo            CapabilityID = "gen.video"
 	CapabilitySpeechASR                CapabilityID = "speech.asr"
 	CapabilitySpeechTTS                CapabilityID = "speech.tts"
@@ -43,3 +45,19 @@ type CapabilityDescriptor struct {
 type CapabilityAdvertiser interface {
 	GatewayCapabilities() []CapabilityDescriptor
 }
+
+// AdvertisesCapability reports whether a provider supports a capability according to its descriptors.
+// Providers without capability metadata are assumed to support every capability they implement.
+func AdvertisesCapability(provider any, id CapabilityID) bool {
+
+	advertiser, ok := provider.(CapabilityAdvertiser)
+	if !ok {
+		return true
+	}
+	for _, descriptor := range advertiser.GatewayCapabilities() {
+		if descriptor.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+// embeddings.go defines the optional gateway contract for text embeddings.
+// internal/features/ai/providers/ports/gateway/embeddings.go
+package gateway
+
+import (
+	"context"
+	"fmt"
+)
+
+// EmbeddingInputType hints how embedded text will be used so providers can pick an asymmetric encoding.
+type EmbeddingInputType string
+
+const (
+	EmbeddingInputDocument EmbeddingInputType = "document"
+	EmbeddingInputQuery    EmbeddingInputType = "query"
+)
+
+// EmbeddingOptions contains inputs for one embedding request.
+type EmbeddingOptions struct {
+	Model      string             `json:"model"`
+	Input      []string           `json:"input"`
+	Dimensions int                `json:"dimensions,omitempty"`
+	InputType  EmbeddingInputType `json:"inputType,omitempty"`
+	User       string             `json:"user,omitempty"`
+}
+
+// EmbeddingResult contains one vector per input, in input order.
+type EmbeddingResult struct {
+	Model      string      `json:"model,omitempty"`
+	Embeddings [][]float32 `json:"embeddings"`
+	Usage      *UsageStats `json:"usage,omitempty"`
+}
+
+// Embedder is implemented by providers that turn text into embedding vectors.
+// MaxEmbeddingBatch reports how many inputs one request may carry; callers split larger inputs.
+type Embedder interface {
+	Embed(ctx context.Context, opts EmbeddingOptions) (*EmbeddingResult, error)
+	MaxEmbeddingBatch() int
+}
+
+// EmbedBatched splits opts.Input into requests no larger than the embedder's batch limit
+// and joins the vectors in input order, summing usage across requests.
+func EmbedBatched(ctx context.Context, embedder Embedder, opts EmbeddingOptions) (*EmbeddingResult, error) {
+
+	if len(opts.Input) == 0 {
+		return nil, fmt.Errorf("embedding input required")
+	}
+
+	limit := embedder.MaxEmbeddingBatch()
+	if limit <= 0 {
+		limit = len(opts.Input)
+	}
+	combined := &EmbeddingResult{Embeddings: make([][]float32, 0, len(opts.Input))}
+	for start := 0; start < len(opts.Input); start += limit {
+		end := min(start+limit, len(opts.Input))
+		batch := opts
+		batch.Input = opts.Input[start:end]
+		result, err := embedder.Embed(ctx, batch)
+		if err != nil {
+			return nil, fmt.Errorf("embed inputs %d-%d: %w", start, end-1, err)
+		}
+		if len(result.Embeddings) != len(batch.Input) {
+			return nil, fmt.Errorf("embed inputs %d-%d: expected %d vectors, got %d", start, end-1, len(batch.Input), len(result.Embeddings))
+		}
+		combined.Embeddings = append(combined.Embeddings, result.Embeddings...)
+		if combined.Model == "" {
+			combined.Model = result.Model
+		}
+		if result.Usage != nil {
+			if combined.Usage == nil {
+				combined.Usage = &UsageStats{}
+			}
+			combined.Usage.PromptTokens += result.Usage.PromptTokens
+			combined.Usage.CompletionTokens += result.Usage.CompletionTokens
+			combined.Usage.TotalTokens += result.Usage.TotalTokens
+		}
+	}
+	return combined, nil
+}
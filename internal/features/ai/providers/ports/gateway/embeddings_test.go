@@ -0,0 +1,96 @@
+// embeddings_test.go verifies embedding batching under provider request limits.
+// internal/features/ai/providers/ports/gateway/embeddings_test.go
+package gateway
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// batchingEmbedder records batch sizes and returns one single-value vector per input.
+type batchingEmbedder struct {
+	limit   int
+	batches []int
+	failAt  int
+}
+
+// Embed encodes each input as its length so callers can check ordering.
+func (e *batchingEmbedder) Embed(_ context.Context, opts EmbeddingOptions) (*EmbeddingResult, error) {
+
+	e.batches = append(e.batches, len(opts.Input))
+	if e.failAt > 0 && len(e.batches) == e.failAt {
+		return nil, errors.New("rate limited")
+	}
+	result := &EmbeddingResult{Model: opts.Model, Usage: &UsageStats{PromptTokens: len(opts.Input), TotalTokens: len(opts.Input)}}
+	for _, text := range opts.Input {
+		result.Embeddings = append(result.Embeddings, []float32{float32(len(text))})
+	}
+	return result, nil
+}
+
+// MaxEmbeddingBatch returns the configured limit.
+func (e *batchingEmbedder) MaxEmbeddingBatch() int {
+
+	return e.limit
+}
+
+// TestEmbedBatchedSplitsUnderLimit validates batch sizes, vector order, and summed usage.
+func TestEmbedBatchedSplitsUnderLimit(t *testing.T) {
+
+	testCases := []struct {
+		name    string
+		limit   int
+		inputs  int
+		batches []int
+	}{
+		{name: "single batch", limit: 10, inputs: 4, batches: []int{4}},
+		{name: "exact multiple", limit: 2, inputs: 4, batches: []int{2, 2}},
+		{name: "remainder", limit: 3, inputs: 7, batches: []int{3, 3, 1}},
+		{name: "no limit", limit: 0, inputs: 5, batches: []int{5}},
+	}
+
+	for _, testCase := range testCases {
+		embedder := &batchingEmbedder{limit: testCase.limit}
+		input := make([]string, testCase.inputs)
+		for i := range input {
+			input[i] = strings.Repeat("x", i+1)
+		}
+
+		result, err := EmbedBatched(context.Background(), embedder, EmbeddingOptions{Model: "m", Input: input})
+		if err != nil {
+			t.Fatalf("%s: %v", testCase.name, err)
+		}
+		if len(embedder.batches) != len(testCase.batches) {
+			t.Fatalf("%s: expected batches %v, got %v", testCase.name, testCase.batches, embedder.batches)
+		}
+		for i, size := range testCase.batches {
+			if embedder.batches[i] != size {
+				t.Fatalf("%s: expected batches %v, got %v", testCase.name, testCase.batches, embedder.batches)
+			}
+		}
+		for i, vector := range result.Embeddings {
+			if vector[0] != float32(i+1) {
+				t.Fatalf("%s: vector %d out of order: %v", testCase.name, i, vector)
+			}
+		}
+		if result.Model != "m" || result.Usage == nil || result.Usage.TotalTokens != testCase.inputs {
+			t.Fatalf("%s: unexpected model or usage %#v", testCase.name, result)
+		}
+	}
+}
+
+// TestEmbedBatchedReportsFailingBatch validates empty input and per-batch error context.
+func TestEmbedBatchedReportsFailingBatch(t *testing.T) {
+
+	if _, err := EmbedBatched(context.Background(), &batchingEmbedder{limit: 2}, EmbeddingOptions{}); err == nil {
+		t.Fatalf("expected error for empty input")
+	}
+
+	embedder := &batchingEmbedder{limit: 2, failAt: 2}
+	_, err := EmbedBatched(context.Background(), embedder, EmbeddingOptions{Input: []string{"a", "b", "c", "d"}})
+	if err == nil || !strings.Contains(err.Error(), "embed inputs 2-3") || !strings.Contains(err.Error(), "rate limited") {
+		t.Fatalf("expected batch error context, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+// image_capabilities.go defines optional gateway contracts for image variation and upscaling.
+// internal/features/ai/providers/ports/gateway/image_capabilities.go
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCapabilityUnsupported is matched by every CapabilityError via errors.Is.
+var ErrCapabilityUnsupported = errors.New("capability not supported")
+
+// ImageVariationProvider is implemented by providers that create variations of an existing image.
+type ImageVariationProvider interface {
+	CreateImageVariation(ctx context.Context, opts ImageVariationOptions) (*ImageResult, error)
+}
+
+// ImageUpscaleProvider is implemented by providers that upscale an existing image.
+type ImageUpscaleProvider interface {
+	UpscaleImage(ctx context.Context, opts ImageUpscaleOptions) (*ImageResult, error)
+}
+
+// CapabilityError reports that a provider cannot serve a requested gateway capability.
+type CapabilityError struct {
+	Provider   string
+	Capability CapabilityID
+	Reason     string
+}
+
+// NewCapabilityError creates a capability error for a provider and capability.
+func NewCapabilityError(provider string, capability CapabilityID, reason string) *CapabilityError {
+
+	return &CapabilityError{
+		Provider:   provider,
+		Capability: capability,
+		Reason:     strings.TrimSpace(reason),
+	}
+}
+
+// Error returns a readable capability error message.
+func (e *CapabilityError) Error() string {
+
+	message := fmt.Sprintf("provider %q does not support %s", e.Provider, e.Capability)
+	if e.Reason != "" {
+		message += ": " + e.Reason
+	}
+	return message
+}
+
+// Unwrap exposes ErrCapabilityUnsupported for errors.Is checks.
+func (e *CapabilityError) Unwrap() error {
+
+	return ErrCapabilityUnsupported
+}
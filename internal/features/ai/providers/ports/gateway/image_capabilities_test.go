@@ -0,0 +1,61 @@
+// image_capabilities_test.go verifies capability advertisement checks and typed capability errors.
+// internal/features/ai/providers/ports/gateway/image_capabilities_test.go
+package gateway
+
+import (
+	"errors"
+	"testing"
+)
+
+// advertisingProvider reports a fixed set of capability descriptors.
+type advertisingProvider struct {
+	ids []CapabilityID
+}
+
+// GatewayCapabilities returns descriptors for the configured capability ids.
+func (p advertisingProvider) GatewayCapabilities() []CapabilityDescriptor {
+
+	descriptors := make([]CapabilityDescriptor, 0, len(p.ids))
+	for _, id := range p.ids {
+		descriptors = append(descriptors, CapabilityDescriptor{ID: id})
+	}
+	return descriptors
+}
+
+// TestAdvertisesCapabilityUsesDescriptors validates advertised and non-advertising providers.
+func TestAdvertisesCapabilityUsesDescriptors(t *testing.T) {
+
+	testCases := []struct {
+		name     string
+		provider any
+		id       CapabilityID
+		expected bool
+	}{
+		{name: "advertised", provider: advertisingProvider{ids: []CapabilityID{CapabilityGenerateImageVariation}}, id: CapabilityGenerateImageVariation, expected: true},
+		{name: "not advertised", provider: advertisingProvider{ids: []CapabilityID{CapabilityChatText}}, id: CapabilityVisionUpscaleImage, expected: false},
+		{name: "no metadata", provider: struct{}{}, id: CapabilityVisionUpscaleImage, expected: true},
+	}
+
+	for _, testCase := range testCases {
+		if got := AdvertisesCapability(testCase.provider, testCase.id); got != testCase.expected {
+			t.Fatalf("%s: expected %v, got %v", testCase.name, testCase.expected, got)
+		}
+	}
+}
+
+// TestCapabilityErrorMatchesSentinel validates typed capability errors unwrap to the shared sentinel.
+func TestCapabilityErrorMatchesSentinel(t *testing.T) {
+
+	err := error(NewCapabilityError("anthropic", CapabilityVisionUpscaleImage, ""))
+
+	if !errors.Is(err, ErrCapabilityUnsupported) {
+		t.Fatalf("expected capability error to match ErrCapabilityUnsupported")
+	}
+	var capabilityErr *CapabilityError
+	if !errors.As(err, &capabilityErr) || capabilityErr.Capability != CapabilityVisionUpscaleImage {
+		t.Fatalf("expected typed capability error, got %#v", err)
+	}
+	if err.Error() != `provider "anthropic" does not support vision.upscale.image` {
+		t.Fatalf("unexpected message: %s", err.Error())
+	}
+}
@@ -24,6 +24,24 @@ type ImageEditOptions struct {
 	Size   string `json:"size,omitempty"`
 }
 
+// ImageVariationOptions defines parameters for image variation requests.
+type ImageVariationOptions struct {
+	Model          string `json:"model"`
+	Image          string `json:"image"`            // Path to the source image
+	Prompt         string `json:"prompt,omitempty"` // Optional guidance for prompt-driven variation models
+	N              int    `json:"n,omitempty"`
+	Size           string `json:"size,omitempty"`
+	ResponseFormat string `json:"response_format,omitempty"` // url, b64_json
+	User           string `json:"user,omitempty"`
+}
+
+// ImageUpscaleOptions defines parameters for image upscaling requests.
+type ImageUpscaleOptions struct {
+	Model  string `json:"model"`
+	Image  string `json:"image"`            // Path to the source image
+	Factor int    `json:"factor,omitempty"` // Upscale multiplier, for example 2 or 4
+}
+
 // ImageResult represents the result of an image generation request.
 type ImageResult struct {
 	Created int64       `json:"created"`
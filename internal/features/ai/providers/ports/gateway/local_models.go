@@ -0,0 +1,33 @@
+// local_models.go defines contracts for providers that host models on the local machine.
+// internal/features/ai/providers/ports/gateway/local_models.go
+package gateway
+
+import (
+	"context"
+	"time"
+)
+
+// LocalModelState reports whether a locally hosted model is installed and loaded into memory.
+type LocalModelState struct {
+	Model     string    `json:"model"`
+	Installed bool      `json:"installed"`
+	Loaded    bool      `json:"loaded"`
+	SizeBytes int64     `json:"sizeBytes,omitempty"`
+	SizeVRAM  int64     `json:"sizeVram,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+}
+
+// ModelPullProgress reports one step of a model download.
+type ModelPullProgress struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest,omitempty"`
+	Total     int64  `json:"total,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
+}
+
+// LocalModelManager is implemented by providers that can pull models and load them into memory.
+type LocalModelManager interface {
+	LocalModelStates(ctx context.Context) ([]LocalModelState, error)
+	PullModel(ctx context.Context, model string, progress func(ModelPullProgress)) error
+	LoadModel(ctx context.Context, model string) error
+}
@@ -13,7 +13,11 @@ const (
 )
 
 // ProviderMessage represents a provider-ready chat message.
+// Assistant messages may carry the tool calls the model made; tool messages carry the result of
+// one call and name it through ToolCallID.
 type ProviderMessage struct {
-	Role    Role   `json:"role"`
-	Content string `json:"content"`
+	Role       Role       `json:"role"`
+	Content    string     `json:"content"`
+	ToolCalls  []ToolCall `json:"toolCalls,omitempty"`
+	ToolCallID string     `json:"toolCallId,omitempty"`
 }
@@ -0,0 +1,149 @@
+// moderation.go defines the optional gateway contract for content safety screening.
+// internal/features/ai/providers/ports/gateway/moderation.go
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ModerationOptions contains inputs for one moderation request.
+type ModerationOptions struct {
+	Model string   `json:"model,omitempty"`
+	Input []string `json:"input"`
+}
+
+// ModerationVerdict is the safety classification of one input.
+// Categories lists the triggered categories in sorted order.
+type ModerationVerdict struct {
+	Flagged    bool               `json:"flagged"`
+	Categories []string           `json:"categories,omitempty"`
+	Scores     map[string]float64 `json:"scores,omitempty"`
+}
+
+// ModerationResult contains one verdict per input, in input order.
+type ModerationResult struct {
+	Model    string              `json:"model,omitempty"`
+	Verdicts []ModerationVerdict `json:"verdicts"`
+}
+
+// Moderator is implemented by providers that classify text against safety categories.
+type Moderator interface {
+	Moderate(ctx context.Context, opts ModerationOptions) (*ModerationResult, error)
+}
+
+// ChatCompleter is the chat call an LLM judge needs from a provider.
+type ChatCompleter interface {
+	Chat(ctx context.Context, messages []ProviderMessage, opts ChatOptions) (<-chan Chunk, error)
+}
+
+// judgeCategories are the categories a judge model may report; they follow the OpenAI moderation names.
+var judgeCategories = []string{
+	"harassment", "harassment/threatening", "hate", "hate/threatening", "illicit", "illicit/violent",
+	"self-harm", "self-harm/instructions", "self-harm/intent", "sexual", "sexual/minors", "violence", "violence/graphic",
+}
+
+const judgeSystemPrompt = `You are a content safety classifier. Decide whether the user's text violates any of these categories: %s.
+Reply with a single JSON object and nothing else: {"flagged": true|false, "categories": ["category", ...]}.
+Use only the listed category names. Report "flagged": false with an empty list for safe text.`
+
+// JudgeModerator screens text by asking a general chat model to act as a safety classifier.
+// It is the fallback for providers without a dedicated moderation endpoint.
+type JudgeModerator struct {
+	chat  ChatCompleter
+	model string
+}
+
+var _ Moderator = (*JudgeModerator)(nil)
+
+// NewJudgeModerator creates an LLM-judge moderator that classifies with the given chat model.
+func NewJudgeModerator(chat ChatCompleter, model string) *JudgeModerator {
+
+	return &JudgeModerator{chat: chat, model: strings.TrimSpace(model)}
+}
+
+// Moderate classifies each input with one non-streaming chat request.
+func (j *JudgeModerator) Moderate(ctx context.Context, opts ModerationOptions) (*ModerationResult, error) {
+
+	if len(opts.Input) == 0 {
+		return nil, fmt.Errorf("moderation input required")
+	}
+	model := strings.TrimSpace(opts.Model)
+	if model == "" {
+		model = j.model
+	}
+	if model == "" {
+		return nil, fmt.Errorf("moderation judge needs a chat model")
+	}
+
+	result := &ModerationResult{Model: model, Verdicts: make([]ModerationVerdict, 0, len(opts.Input))}
+	for index, text := range opts.Input {
+		reply, err := j.complete(ctx, model, text)
+		if err != nil {
+			return nil, fmt.Errorf("judge input %d: %w", index, err)
+		}
+		verdict, err := ParseJudgeVerdict(reply)
+		if err != nil {
+			return nil, fmt.Errorf("judge input %d: %w", index, err)
+		}
+		result.Verdicts = append(result.Verdicts, verdict)
+	}
+	return result, nil
+}
+
+// complete sends one classification request and joins the streamed reply.
+func (j *JudgeModerator) complete(ctx context.Context, model, text string) (string, error) {
+
+	chunks, err := j.chat.Chat(ctx, []ProviderMessage{
+		{Role: RoleSystem, Content: fmt.Sprintf(judgeSystemPrompt, strings.Join(judgeCategories, ", "))},
+		{Role: RoleUser, Content: text},
+	}, ChatOptions{Model: model, MaxTokens: 200})
+	if err != nil {
+		return "", err
+	}
+
+	var reply strings.Builder
+	for chunk := range chunks {
+		if chunk.Error != nil {
+			return "", chunk.Error
+		}
+		reply.WriteString(chunk.Content)
+	}
+	return reply.String(), nil
+}
+
+// ParseJudgeVerdict extracts the JSON verdict from a judge reply, tolerating surrounding prose or code fences.
+// Categories outside the judge list are dropped.
+func ParseJudgeVerdict(reply string) (ModerationVerdict, error) {
+
+	start := strings.Index(reply, "{")
+	end := strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return ModerationVerdict{}, fmt.Errorf("judge reply has no JSON verdict: %q", strings.TrimSpace(reply))
+	}
+
+	var payload struct {
+		Flagged    bool     `json:"flagged"`
+		Categories []string `json:"categories"`
+	}
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &payload); err != nil {
+		return ModerationVerdict{}, fmt.Errorf("parse judge verdict: %w", err)
+	}
+
+	known := make(map[string]bool, len(judgeCategories))
+	for _, category := range judgeCategories {
+		known[category] = true
+	}
+	verdict := ModerationVerdict{Flagged: payload.Flagged}
+	for _, category := range payload.Categories {
+		category = strings.ToLower(strings.TrimSpace(category))
+		if known[category] {
+			verdict.Categories = append(verdict.Categories, category)
+		}
+	}
+	sort.Strings(verdict.Categories)
+	return verdict, nil
+}
@@ -0,0 +1,86 @@
+// moderation_test.go verifies LLM-judge moderation requests and verdict parsing.
+// internal/features/ai/providers/ports/gateway/moderation_test.go
+package gateway
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// scriptedChat replies with a fixed judge verdict and records the messages it received.
+type scriptedChat struct {
+	reply    string
+	messages []ProviderMessage
+	model    string
+}
+
+// Chat records the request and streams the scripted reply in two chunks.
+func (c *scriptedChat) Chat(_ context.Context, messages []ProviderMessage, opts ChatOptions) (<-chan Chunk, error) {
+
+	c.messages = messages
+	c.model = opts.Model
+	chunks := make(chan Chunk, 2)
+	half := len(c.reply) / 2
+	chunks <- Chunk{Content: c.reply[:half]}
+	chunks <- Chunk{Content: c.reply[half:]}
+	close(chunks)
+	return chunks, nil
+}
+
+// TestJudgeModeratorClassifiesWithChatModel validates the judge prompt and streamed verdict assembly.
+func TestJudgeModeratorClassifiesWithChatModel(t *testing.T) {
+
+	chat := &scriptedChat{reply: `{"flagged": true, "categories": ["violence"]}`}
+	judge := NewJudgeModerator(chat, "gpt-4o-mini")
+
+	result, err := judge.Moderate(context.Background(), ModerationOptions{Input: []string{"I will hurt him"}})
+	if err != nil {
+		t.Fatalf("moderate: %v", err)
+	}
+	if chat.model != "gpt-4o-mini" || len(chat.messages) != 2 || chat.messages[1].Content != "I will hurt him" {
+		t.Fatalf("unexpected judge request model=%q messages=%#v", chat.model, chat.messages)
+	}
+	if !strings.Contains(chat.messages[0].Content, "self-harm/intent") {
+		t.Fatalf("judge prompt should list categories: %q", chat.messages[0].Content)
+	}
+	if len(result.Verdicts) != 1 || !result.Verdicts[0].Flagged || !reflect.DeepEqual(result.Verdicts[0].Categories, []string{"violence"}) {
+		t.Fatalf("unexpected result %#v", result)
+	}
+}
+
+// TestParseJudgeVerdict validates tolerant JSON extraction and category filtering.
+func TestParseJudgeVerdict(t *testing.T) {
+
+	testCases := []struct {
+		name       string
+		reply      string
+		flagged    bool
+		categories []string
+		wantErr    bool
+	}{
+		{name: "plain", reply: `{"flagged": false, "categories": []}`},
+		{name: "fenced", reply: "```json\n{\"flagged\": true, \"categories\": [\"Hate\", \"sexual\"]}\n```", flagged: true, categories: []string{"hate", "sexual"}},
+		{name: "unknown category dropped", reply: `Verdict: {"flagged": true, "categories": ["spam", "harassment"]}`, flagged: true, categories: []string{"harassment"}},
+		{name: "no json", reply: "I cannot help with that.", wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			verdict, err := ParseJudgeVerdict(testCase.reply)
+			if testCase.wantErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if verdict.Flagged != testCase.flagged || !reflect.DeepEqual(verdict.Categories, testCase.categories) {
+				t.Fatalf("unexpected verdict %#v", verdict)
+			}
+		})
+	}
+}
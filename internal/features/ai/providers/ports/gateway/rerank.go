@@ -0,0 +1,163 @@
+// rerank.go defines the optional gateway contract for query-document reranking.
+// internal/features/ai/providers/ports/gateway/rerank.go
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RerankOptions contains a query and the candidate documents to score against it.
+// TopN limits the returned results; zero returns every document.
+type RerankOptions struct {
+	Model     string   `json:"model,omitempty"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"topN,omitempty"`
+}
+
+// RerankScore is the relevance of the document at Index in RerankOptions.Documents.
+type RerankScore struct {
+	Index int     `json:"index"`
+	Score float64 `json:"score"`
+}
+
+// RerankResult lists scored documents from most to least relevant.
+type RerankResult struct {
+	Model   string        `json:"model,omitempty"`
+	Results []RerankScore `json:"results"`
+}
+
+// Reranker is implemented by providers that score documents for relevance to a query.
+type Reranker interface {
+	Rerank(ctx context.Context, opts RerankOptions) (*RerankResult, error)
+}
+
+// ValidateRerankOptions checks the query and documents shared by every reranker.
+func ValidateRerankOptions(opts RerankOptions) error {
+
+	if strings.TrimSpace(opts.Query) == "" {
+		return fmt.Errorf("rerank query required")
+	}
+	if len(opts.Documents) == 0 {
+		return fmt.Errorf("rerank documents required")
+	}
+	if opts.TopN < 0 {
+		return fmt.Errorf("rerank top n must not be negative")
+	}
+	return nil
+}
+
+// SortRerankScores orders scores by relevance, breaking ties by document order, and applies topN.
+// Indexes outside [0, documents) are rejected so callers can map results back safely.
+func SortRerankScores(scores []RerankScore, documents, topN int) ([]RerankScore, error) {
+
+	for _, score := range scores {
+		if score.Index < 0 || score.Index >= documents {
+			return nil, fmt.Errorf("rerank result index %d out of range for %d documents", score.Index, documents)
+		}
+	}
+	sorted := append([]RerankScore(nil), scores...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Score != sorted[j].Score {
+			return sorted[i].Score > sorted[j].Score
+		}
+		return sorted[i].Index < sorted[j].Index
+	})
+	if topN > 0 && topN < len(sorted) {
+		sorted = sorted[:topN]
+	}
+	return sorted, nil
+}
+
+const judgeRerankPrompt = `You rate how relevant a document is to a search query.
+Reply with a single integer from 0 (unrelated) to 10 (directly answers the query) and nothing else.`
+
+// JudgeReranker scores documents by asking a general chat model to rate each one.
+// It is the fallback for providers without a dedicated rerank model.
+type JudgeReranker struct {
+	chat  ChatCompleter
+	model string
+}
+
+var _ Reranker = (*JudgeReranker)(nil)
+
+// NewJudgeReranker creates an LLM-scoring reranker that rates with the given chat model.
+func NewJudgeReranker(chat ChatCompleter, model string) *JudgeReranker {
+
+	return &JudgeReranker{chat: chat, model: strings.TrimSpace(model)}
+}
+
+// Rerank rates each document with one chat request and normalizes the ratings to [0, 1].
+func (j *JudgeReranker) Rerank(ctx context.Context, opts RerankOptions) (*RerankResult, error) {
+
+	if err := ValidateRerankOptions(opts); err != nil {
+		return nil, err
+	}
+	model := strings.TrimSpace(opts.Model)
+	if model == "" {
+		model = j.model
+	}
+	if model == "" {
+		return nil, fmt.Errorf("rerank judge needs a chat model")
+	}
+
+	scores := make([]RerankScore, 0, len(opts.Documents))
+	for index, document := range opts.Documents {
+		reply, err := j.complete(ctx, model, opts.Query, document)
+		if err != nil {
+			return nil, fmt.Errorf("judge document %d: %w", index, err)
+		}
+		rating, err := ParseJudgeRating(reply)
+		if err != nil {
+			return nil, fmt.Errorf("judge document %d: %w", index, err)
+		}
+		scores = append(scores, RerankScore{Index: index, Score: rating / 10})
+	}
+
+	sorted, err := SortRerankScores(scores, len(opts.Documents), opts.TopN)
+	if err != nil {
+		return nil, err
+	}
+	return &RerankResult{Model: model, Results: sorted}, nil
+}
+
+// complete sends one rating request and joins the streamed reply.
+func (j *JudgeReranker) complete(ctx context.Context, model, query, document string) (string, error) {
+
+	chunks, err := j.chat.Chat(ctx, []ProviderMessage{
+		{Role: RoleSystem, Content: judgeRerankPrompt},
+		{Role: RoleUser, Content: "Query: " + query + "\n\nDocument:\n" + document},
+	}, ChatOptions{Model: model, MaxTokens: 8})
+	if err != nil {
+		return "", err
+	}
+
+	var reply strings.Builder
+	for chunk := range chunks {
+		if chunk.Error != nil {
+			return "", chunk.Error
+		}
+		reply.WriteString(chunk.Content)
+	}
+	return reply.String(), nil
+}
+
+// ParseJudgeRating reads the first number in a judge reply and clamps it to the 0-10 scale.
+func ParseJudgeRating(reply string) (float64, error) {
+
+	fields := strings.FieldsFunc(reply, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	for _, candidate := range fields {
+		rating, err := strconv.ParseFloat(strings.Trim(candidate, "."), 64)
+		if err != nil {
+			continue
+		}
+		return min(max(rating, 0), 10), nil
+	}
+	return 0, fmt.Errorf("judge reply has no rating: %q", strings.TrimSpace(reply))
+}
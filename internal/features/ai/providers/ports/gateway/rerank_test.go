@@ -0,0 +1,94 @@
+// rerank_test.go verifies LLM-scoring reranking, rating parsing, and score ordering.
+// internal/features/ai/providers/ports/gateway/rerank_test.go
+package gateway
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ratingChat replies with the rating configured for the document in each request.
+type ratingChat struct {
+	ratings map[string]string
+	calls   int
+}
+
+// Chat looks up the rating for the document named in the user message.
+func (c *ratingChat) Chat(_ context.Context, messages []ProviderMessage, _ ChatOptions) (<-chan Chunk, error) {
+
+	c.calls++
+	chunks := make(chan Chunk, 1)
+	for document, rating := range c.ratings {
+		if strings.HasSuffix(messages[1].Content, "\n"+document) {
+			chunks <- Chunk{Content: rating}
+		}
+	}
+	close(chunks)
+	return chunks, nil
+}
+
+// TestJudgeRerankerOrdersByRating validates per-document rating, normalization, and top n.
+func TestJudgeRerankerOrdersByRating(t *testing.T) {
+
+	chat := &ratingChat{ratings: map[string]string{
+		"Paris is the capital of France.": "10",
+		"Bananas are yellow.":             "Rating: 1",
+		"France borders Spain.":           "6.",
+	}}
+	judge := NewJudgeReranker(chat, "gpt-4o-mini")
+
+	result, err := judge.Rerank(context.Background(), RerankOptions{
+		Query:     "capital of France",
+		Documents: []string{"Bananas are yellow.", "France borders Spain.", "Paris is the capital of France."},
+		TopN:      2,
+	})
+	if err != nil {
+		t.Fatalf("rerank: %v", err)
+	}
+	want := []RerankScore{{Index: 2, Score: 1}, {Index: 1, Score: 0.6}}
+	if chat.calls != 3 || result.Model != "gpt-4o-mini" || !reflect.DeepEqual(result.Results, want) {
+		t.Fatalf("unexpected result %#v after %d calls", result, chat.calls)
+	}
+
+	if _, err := judge.Rerank(context.Background(), RerankOptions{Query: " ", Documents: []string{"a"}}); err == nil {
+		t.Fatalf("expected empty query to fail")
+	}
+}
+
+// TestParseJudgeRating validates number extraction and clamping.
+func TestParseJudgeRating(t *testing.T) {
+
+	testCases := []struct {
+		reply string
+		want  float64
+		ok    bool
+	}{
+		{reply: "7", want: 7, ok: true},
+		{reply: "Score: 8.5/10", want: 8.5, ok: true},
+		{reply: "12", want: 10, ok: true},
+		{reply: "not relevant", ok: false},
+	}
+	for _, testCase := range testCases {
+		rating, err := ParseJudgeRating(testCase.reply)
+		if (err == nil) != testCase.ok || rating != testCase.want {
+			t.Fatalf("%q: expected %v (ok=%v), got %v (%v)", testCase.reply, testCase.want, testCase.ok, rating, err)
+		}
+	}
+}
+
+// TestSortRerankScoresRejectsUnknownIndexes validates tie ordering and index bounds.
+func TestSortRerankScoresRejectsUnknownIndexes(t *testing.T) {
+
+	sorted, err := SortRerankScores([]RerankScore{{Index: 1, Score: 0.5}, {Index: 0, Score: 0.5}, {Index: 2, Score: 0.9}}, 3, 0)
+	if err != nil {
+		t.Fatalf("sort: %v", err)
+	}
+	if !reflect.DeepEqual(sorted, []RerankScore{{Index: 2, Score: 0.9}, {Index: 0, Score: 0.5}, {Index: 1, Score: 0.5}}) {
+		t.Fatalf("unexpected order %#v", sorted)
+	}
+	if _, err := SortRerankScores([]RerankScore{{Index: 3}}, 3, 0); err == nil {
+		t.Fatalf("expected out of range index to fail")
+	}
+}
@@ -2,6 +2,8 @@
 // internal/features/ai/providers/ports/gateway/tools.go
 package gateway
 
+import "errors"
+
 // Tool represents a function the AI can call.
 type Tool struct {
 	Name        string                 `json:"name"`
@@ -15,3 +17,18 @@ type ToolCall struct {
 	Name      string                 `json:"name"`
 	Arguments map[string]interface{} `json:"arguments"`
 }
+
+// ErrToolTurnsUnsupported reports tool calls or tool results sent to a provider that does not implement tool calling.
+var ErrToolTurnsUnsupported = errors.New("tool calls and tool results are not supported by this provider")
+
+// RejectToolTurns returns ErrToolTurnsUnsupported when messages carry tool calls or tool results.
+// Adapters without tool calling use it so tool output is never passed on under another role.
+func RejectToolTurns(messages []ProviderMessage) error {
+
+	for _, message := range messages {
+		if message.Role == RoleTool || len(message.ToolCalls) > 0 {
+			return ErrToolTurnsUnsupported
+		}
+	}
+	return nil
+}
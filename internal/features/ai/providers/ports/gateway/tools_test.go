@@ -0,0 +1,27 @@
+// tools_test.go verifies tool turn detection for providers without tool calling.
+// internal/features/ai/providers/ports/gateway/tools_test.go
+package gateway
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestRejectToolTurns validates tool calls and tool results are rejected while plain turns pass.
+func TestRejectToolTurns(t *testing.T) {
+
+	plain := []ProviderMessage{{Role: RoleSystem, Content: "Be brief."}, {Role: RoleUser, Content: "Hi"}, {Role: RoleAssistant, Content: "Hello"}}
+	if err := RejectToolTurns(plain); err != nil {
+		t.Fatalf("expected plain turns to pass, got %v", err)
+	}
+
+	testCases := [][]ProviderMessage{
+		{{Role: RoleAssistant, ToolCalls: []ToolCall{{ID: "call_1", Name: "lookup"}}}},
+		{{Role: RoleTool, Content: "42", ToolCallID: "call_1"}},
+	}
+	for _, messages := range testCases {
+		if err := RejectToolTurns(messages); !errors.Is(err, ErrToolTurnsUnsupported) {
+			t.Fatalf("expected tool turns to be rejected for %#v, got %v", messages, err)
+		}
+	}
+}
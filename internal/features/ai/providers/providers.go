@@ -9,23 +9,47 @@ import (
 	config "github.com/MadeByDoug/wls-chatbot/internal/core/config"
 	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
 	anthropicadapter "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/anthropic"
+	azureopenaiadapter "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/azureopenai"
 	cloudflareadapter "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/cloudflare"
 	modelaccess "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/configmodels"
 	geminiadapter "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/gemini"
 	grokadapter "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/grok"
+	ollamaadapter "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/ollama"
 	openaiadapter "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/openai"
+	openaicompatadapter "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/openaicompat"
 	openrouteradapter "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/openrouter"
 	providerregistry "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/registry"
+	securestore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/secretstore"
 	providerusecase "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/app/provider"
 	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
 )
 
-// ProvidersFromConfig constructs providers from configuration.
-func ProvidersFromConfig(cfg config.AppConfig, secrets providercore.SecretStore, logger corelogger.Logger) ([]providercore.Provider, error) {
+// ProvidersFromConfig constructs providers from configuration, resolving credentials through layers.
+func ProvidersFromConfig(cfg config.AppConfig, secrets providercore.SecretStore, layers providerusecase.CredentialLayers, logger corelogger.Logger) ([]providercore.Provider, error) {
 
-	providers := make([]providercore.Provider, 0, len(cfg.Providers))
+	instances, err := InstancesFromConfig(cfg, secrets, layers, logger)
+	if err != nil {
+		return nil, err
+	}
+	providers := make([]providercore.Provider, 0, len(instances))
+	for _, instance := range instances {
+		providers = append(providers, instance.Provider)
+	}
+	return providers, nil
+}
+
+// InstancesFromConfig constructs one named provider instance per config entry; several entries may share a type.
+func InstancesFromConfig(cfg config.AppConfig, secrets providercore.SecretStore, layers providerusecase.CredentialLayers, logger corelogger.Logger) ([]providercore.ProviderInstance, error) {
+
+	instances := make([]providercore.ProviderInstance, 0, len(cfg.Providers))
+	seen := make(map[string]struct{}, len(cfg.Providers))
 	for _, p := range cfg.Providers {
-		credentials := buildProviderCredentials(p, secrets)
+		if _, duplicate := seen[p.Name]; duplicate {
+			return nil, fmt.Errorf("duplicate provider name: %s", p.Name)
+		}
+		seen[p.Name] = struct{}{}
+
+		credentials := buildProviderCredentials(p, secrets, layers)
 		apiKey := strings.TrimSpace(credentials[providercore.CredentialAPIKey])
 		enabledModels := modelaccess.ResolveEnabledModelsFromConfig(cfg, p.Name, p.DefaultModel)
 		providerConfig := providercore.ProviderConfig{
@@ -38,42 +62,61 @@ func ProvidersFromConfig(cfg config.AppConfig, secrets providercore.SecretStore,
 			Credentials:  credentials,
 			Logger:       logger,
 		}
+		var adapter providercore.Provider
 		switch p.Type {
 		case "openai":
-			providers = append(providers, openaiadapter.New(providerConfig))
+			adapter = openaiadapter.New(providerConfig)
 		case "anthropic":
-			providers = append(providers, anthropicadapter.New(providerConfig))
+			adapter = anthropicadapter.New(providerConfig)
 		case "gemini":
-			providers = append(providers, geminiadapter.New(providerConfig))
+			adapter = geminiadapter.New(providerConfig)
 		case "grok":
-			providers = append(providers, grokadapter.New(providerConfig))
+			adapter = grokadapter.New(providerConfig)
 		case "cloudflare":
-			providers = append(providers, cloudflareadapter.New(providerConfig))
+			adapter = cloudflareadapter.New(providerConfig)
 		case "openrouter":
-			providers = append(providers, openrouteradapter.New(providerConfig))
+			adapter = openrouteradapter.New(providerConfig)
+		case "ollama":
+			adapter = ollamaadapter.New(providerConfig)
+		case "openai-compatible":
+			adapter = openaicompatadapter.New(providerConfig)
+		case "azure-openai":
+			adapter = azureopenaiadapter.New(providerConfig)
 		default:
 			return nil, fmt.Errorf("unknown provider type: %s", p.Type)
 		}
+		instances = append(instances, providercore.ProviderInstance{Type: p.Type, Provider: adapter})
 	}
-	return providers, nil
+	return instances, nil
 }
 
-// buildProviderCredentials merges config inputs with stored secrets.
-func buildProviderCredentials(cfg config.ProviderConfig, secrets providercore.SecretStore) providercore.ProviderCredentials {
+// buildProviderCredentials merges config inputs, stored secrets, and external sources by layer precedence.
+// Fields whose exec: command fails are left unset so the provider service reports the error on connect.
+func buildProviderCredentials(cfg config.ProviderConfig, secrets providercore.SecretStore, layers providerusecase.CredentialLayers) providercore.ProviderCredentials {
 
-	credentials := make(providercore.ProviderCredentials)
-	for key, value := range cfg.Inputs {
-		if strings.TrimSpace(value) == "" {
+	secretFields := providerSecretFields(cfg.Type)
+	fields := make([]providercore.CredentialField, 0, len(secretFields)+len(cfg.Inputs))
+	stored := make(providercore.ProviderCredentials)
+	for _, field := range secretFields {
+		fields = append(fields, providercore.CredentialField{Name: field, Secret: true})
+		if secrets == nil {
 			continue
 		}
-		credentials[key] = value
+		if value, err := secrets.GetProviderSecret(cfg.Name, field); err == nil && strings.TrimSpace(value) != "" {
+			stored[field] = value
+		}
+	}
+	for key := range cfg.Inputs {
+		fields = append(fields, providercore.CredentialField{Name: key})
 	}
 
-	secretFields := providerSecretFields(cfg.Type)
-	if secrets != nil && len(secretFields) > 0 {
-		for _, field := range secretFields {
-			if value, err := secrets.GetProviderSecret(cfg.Name, field); err == nil && strings.TrimSpace(value) != "" {
-				credentials[field] = value
+	layered := layers.Layer(cfg.Name, fields, cfg.Inputs, stored, nil)
+	credentials, err := layers.Expand(layered)
+	if err != nil {
+		credentials = make(providercore.ProviderCredentials)
+		for key, value := range layered.Values {
+			if _, isExec := layered.ExecCommand(key); !isExec {
+				credentials[key] = value
 			}
 		}
 	}
@@ -84,14 +127,43 @@ func buildProviderCredentials(cfg config.ProviderConfig, secrets providercore.Se
 	return credentials
 }
 
+// ReloadProviders rebuilds providers from cfg and swaps them into the running orchestrator.
+func ReloadProviders(cfg config.AppConfig, orchestrator *providerusecase.Orchestrator, secrets providercore.SecretStore, layers providerusecase.CredentialLayers, logger corelogger.Logger) error {
+
+	built, err := InstancesFromConfig(cfg, secrets, layers, logger)
+	if err != nil {
+		return err
+	}
+	updateFrequency, err := config.ResolveUpdateFrequencies(cfg)
+	if err != nil {
+		return err
+	}
+	orchestrator.ReloadProviders(built, updateFrequency)
+	return nil
+}
+
+// ProviderSecretRefs lists the secret fields of every configured provider for store migration.
+func ProviderSecretRefs(cfg config.AppConfig) []securestore.SecretRef {
+
+	var refs []securestore.SecretRef
+	for _, provider := range cfg.Providers {
+		for _, field := range providerSecretFields(provider.Type) {
+			refs = append(refs, securestore.SecretRef{ProviderName: provider.Name, FieldName: field})
+		}
+	}
+	return refs
+}
+
 // providerSecretFields returns secret credential field names for a provider type.
 func providerSecretFields(providerType string) []string {
 
 	switch providerType {
 	case "openai", "anthropic", "gemini", "grok":
 		return []string{providercore.CredentialAPIKey}
-	case "openrouter":
+	case "openrouter", "ollama", "openai-compatible":
 		return []string{providercore.CredentialAPIKey}
+	case "azure-openai":
+		return []string{providercore.CredentialAPIKey, providercore.CredentialToken}
 	case "cloudflare":
 		return []string{
 			providercore.CredentialCloudflareToken,
@@ -104,18 +176,19 @@ func providerSecretFields(providerType string) []string {
 }
 
 // BuildProviderService wires provider adapters into the provider use case.
-func BuildProviderService(cfg config.AppConfig, cache providercore.ProviderCache, secrets providercore.SecretStore, inputs providercore.ProviderInputsStore, logger corelogger.Logger) (*providerusecase.Service, providercore.ProviderRegistry, error) {
+func BuildProviderService(cfg config.AppConfig, cache providercore.ProviderCache, secrets providercore.SecretStore, inputs providercore.ProviderInputsStore, layers providerusecase.CredentialLayers, logger corelogger.Logger) (*providerusecase.Service, providercore.ProviderRegistry, error) {
 
 	registry := providerregistry.New()
-	providerConfigs, providerErr := ProvidersFromConfig(cfg, secrets, logger)
+	instances, providerErr := InstancesFromConfig(cfg, secrets, layers, logger)
 	if providerErr == nil {
-		for _, p := range providerConfigs {
-			registry.Register(p)
+		for _, instance := range instances {
+			registry.RegisterInstance(instance)
 		}
 	}
 
 	updateFrequency, frequencyErr := config.ResolveUpdateFrequencies(cfg)
 	service := providerusecase.NewService(registry, cache, secrets, inputs, updateFrequency, logger)
+	service.SetCredentialLayers(layers)
 	if providerErr != nil {
 		return service, registry, providerErr
 	}
@@ -0,0 +1,57 @@
+// reload_test.go verifies config reloads swap providers in the running registry.
+// internal/features/ai/providers/reload_test.go
+package providers
+
+import (
+	"testing"
+
+	config "github.com/MadeByDoug/wls-chatbot/internal/core/config"
+	providerusecase "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/app/provider"
+)
+
+// TestReloadProvidersSwapsRegistryContents verifies added, changed, and removed providers after a reload.
+func TestReloadProvidersSwapsRegistryContents(t *testing.T) {
+
+	initial := config.AppConfig{Providers: []config.ProviderConfig{
+		{Type: "openai", Name: "openai", DisplayName: "OpenAI"},
+		{Type: "gemini", Name: "gemini", DisplayName: "Gemini"},
+	}}
+	service, registry, err := BuildProviderService(initial, nil, nil, nil, providerusecase.CredentialLayers{}, nil)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if !registry.SetActive("gemini") {
+		t.Fatalf("expected gemini to be registered")
+	}
+	orchestrator := providerusecase.NewOrchestrator(service, nil)
+
+	reloaded := config.AppConfig{Providers: []config.ProviderConfig{
+		{Type: "openai", Name: "openai", DisplayName: "OpenAI (work)", UpdateFrequency: config.UpdateFrequencyDaily},
+		{Type: "anthropic", Name: "anthropic", DisplayName: "Anthropic"},
+	}}
+	if err := ReloadProviders(reloaded, orchestrator, nil, providerusecase.CredentialLayers{}, nil); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+
+	providers := registry.List()
+	if len(providers) != 2 || providers[0].Name() != "openai" || providers[1].Name() != "anthropic" {
+		t.Fatalf("unexpected providers after reload: %v", providers)
+	}
+	if providers[0].DisplayName() != "OpenAI (work)" {
+		t.Fatalf("expected reloaded display name, got %q", providers[0].DisplayName())
+	}
+	if registry.Get("gemini") != nil {
+		t.Fatalf("expected gemini to be unregistered")
+	}
+	if active := registry.GetActive(); active != nil && active.Name() == "gemini" {
+		t.Fatalf("expected removed provider to lose active selection")
+	}
+
+	invalid := config.AppConfig{Providers: []config.ProviderConfig{{Type: "unknown", Name: "x"}}}
+	if err := ReloadProviders(invalid, orchestrator, nil, providerusecase.CredentialLayers{}, nil); err == nil {
+		t.Fatalf("expected unknown provider type to fail")
+	}
+	if len(registry.List()) != 2 {
+		t.Fatalf("expected failed reload to keep previous providers")
+	}
+}
@@ -0,0 +1,78 @@
+// service.go provides query-document reranking backend operations.
+// internal/features/ai/rerank/app/rerank/service.go
+package rerank
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+	rerankports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/rerank/ports"
+)
+
+// RerankProviderOperations defines the scoring operation required by the rerank backend service.
+type RerankProviderOperations interface {
+	Rerank(ctx context.Context, name string, options providergateway.RerankOptions) (*providergateway.RerankResult, error)
+}
+
+// Service handles rerank operations for transport adapters.
+type Service struct {
+	providers RerankProviderOperations
+}
+
+var _ rerankports.RerankInterface = (*Service)(nil)
+
+// NewService creates a rerank backend service from provider dependencies.
+func NewService(providers RerankProviderOperations) *Service {
+
+	return &Service{providers: providers}
+}
+
+// Rerank orders documents by relevance to the query using a configured provider.
+func (s *Service) Rerank(ctx context.Context, request rerankports.RerankRequest) (rerankports.RerankResult, error) {
+
+	if s.providers == nil {
+		return rerankports.RerankResult{}, fmt.Errorf("backend service: providers not configured")
+	}
+	query := strings.TrimSpace(request.Query)
+	if query == "" {
+		return rerankports.RerankResult{}, fmt.Errorf("rerank: query is required")
+	}
+	if len(request.Documents) == 0 {
+		return rerankports.RerankResult{}, fmt.Errorf("rerank: at least one document is required")
+	}
+	if request.TopN < 0 {
+		return rerankports.RerankResult{}, fmt.Errorf("rerank: top n must not be negative")
+	}
+
+	model := strings.TrimSpace(request.ModelName)
+	result, err := s.providers.Rerank(ctx, request.ProviderName, providergateway.RerankOptions{
+		Model:     model,
+		Query:     query,
+		Documents: request.Documents,
+		TopN:      request.TopN,
+	})
+	if err != nil {
+		return rerankports.RerankResult{}, err
+	}
+
+	output := rerankports.RerankResult{
+		Model:   result.Model,
+		Results: make([]rerankports.RankedDocument, 0, len(result.Results)),
+	}
+	if output.Model == "" {
+		output.Model = model
+	}
+	for _, score := range result.Results {
+		if score.Index < 0 || score.Index >= len(request.Documents) {
+			return rerankports.RerankResult{}, fmt.Errorf("rerank: provider returned unknown document index %d", score.Index)
+		}
+		output.Results = append(output.Results, rerankports.RankedDocument{
+			Index:    score.Index,
+			Score:    score.Score,
+			Document: request.Documents[score.Index],
+		})
+	}
+	return output, nil
+}
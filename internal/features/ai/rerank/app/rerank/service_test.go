@@ -0,0 +1,77 @@
+// service_test.go verifies rerank request validation and result mapping.
+// internal/features/ai/rerank/app/rerank/service_test.go
+package rerank
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+	rerankports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/rerank/ports"
+)
+
+// recordingProviders captures the last rerank options and returns configured scores.
+type recordingProviders struct {
+	name    string
+	options providergateway.RerankOptions
+	scores  []providergateway.RerankScore
+}
+
+// Rerank records its inputs and returns the configured scores.
+func (p *recordingProviders) Rerank(_ context.Context, name string, options providergateway.RerankOptions) (*providergateway.RerankResult, error) {
+
+	p.name = name
+	p.options = options
+	return &providergateway.RerankResult{Results: p.scores}, nil
+}
+
+// TestRerankMapsDocuments validates request forwarding, model fallback, and document lookup.
+func TestRerankMapsDocuments(t *testing.T) {
+
+	providers := &recordingProviders{scores: []providergateway.RerankScore{{Index: 1, Score: 0.9}, {Index: 0, Score: 0.1}}}
+	service := NewService(providers)
+
+	result, err := service.Rerank(context.Background(), rerankports.RerankRequest{
+		ProviderName: "cloudflare",
+		ModelName:    " @cf/baai/bge-reranker-base ",
+		Query:        " capital of France ",
+		Documents:    []string{"Bananas", "Paris"},
+		TopN:         2,
+	})
+	if err != nil {
+		t.Fatalf("rerank: %v", err)
+	}
+	if providers.name != "cloudflare" || providers.options.Model != "@cf/baai/bge-reranker-base" || providers.options.Query != "capital of France" || providers.options.TopN != 2 {
+		t.Fatalf("unexpected provider call %q %#v", providers.name, providers.options)
+	}
+	if result.Model != "@cf/baai/bge-reranker-base" || len(result.Results) != 2 || result.Results[0].Document != "Paris" || result.Results[1].Index != 0 {
+		t.Fatalf("unexpected result %#v", result)
+	}
+}
+
+// TestRerankRejectsInvalidRequests validates input checks and unknown provider indexes.
+func TestRerankRejectsInvalidRequests(t *testing.T) {
+
+	testCases := []struct {
+		name    string
+		request rerankports.RerankRequest
+		scores  []providergateway.RerankScore
+		want    string
+	}{
+		{name: "no query", request: rerankports.RerankRequest{Documents: []string{"a"}}, want: "query is required"},
+		{name: "no documents", request: rerankports.RerankRequest{Query: "q"}, want: "at least one document"},
+		{name: "negative top n", request: rerankports.RerankRequest{Query: "q", Documents: []string{"a"}, TopN: -1}, want: "must not be negative"},
+		{name: "unknown index", request: rerankports.RerankRequest{Query: "q", Documents: []string{"a"}}, scores: []providergateway.RerankScore{{Index: 4}}, want: "unknown document index 4"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			service := NewService(&recordingProviders{scores: testCase.scores})
+			_, err := service.Rerank(context.Background(), testCase.request)
+			if err == nil || !strings.Contains(err.Error(), testCase.want) {
+				t.Fatalf("expected %q error, got %v", testCase.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// rerank.go defines query-document reranking transport contracts.
+// internal/features/ai/rerank/ports/rerank.go
+package ports
+
+import "context"
+
+// RerankInterface defines document reranking shared across transports and retrieval features.
+type RerankInterface interface {
+	Rerank(ctx context.Context, request RerankRequest) (RerankResult, error)
+}
+
+// RerankRequest contains a query and the candidate documents to order.
+// ModelName selects the rerank model, or the scoring chat model for providers without one.
+type RerankRequest struct {
+	ProviderName string   `json:"providerName"`
+	ModelName    string   `json:"modelName,omitempty"`
+	Query        string   `json:"query"`
+	Documents    []string `json:"documents"`
+	TopN         int      `json:"topN,omitempty"`
+}
+
+// RankedDocument is one document with its position in the request and relevance score.
+type RankedDocument struct {
+	Index    int     `json:"index"`
+	Score    float64 `json:"score"`
+	Document string  `json:"document"`
+}
+
+// RerankResult lists documents from most to least relevant.
+type RerankResult struct {
+	Model   string           `json:"model,omitempty"`
+	Results []RankedDocument `json:"results"`
+}
@@ -0,0 +1,114 @@
+// audio_command.go defines AI CLI adapters for speech-to-text and text-to-speech.
+// internal/ui/adapters/cli/ai/audio_command.go
+package ai
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	audioports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/audio/ports"
+	"github.com/spf13/cobra"
+)
+
+// newAudioCommand creates the parent 'audio' command.
+func newAudioCommand(deps Dependencies) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "audio",
+		Short: "Transcribe speech and synthesize spoken audio",
+	}
+	cmd.AddCommand(newAudioTranscribeCommand(deps))
+	cmd.AddCommand(newAudioSpeakCommand(deps))
+	return cmd
+}
+
+// newAudioTranscribeCommand transcribes an audio file.
+func newAudioTranscribeCommand(deps Dependencies) *cobra.Command {
+
+	var request audioports.TranscribeRequest
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "transcribe <file>",
+		Short: "Convert speech in an audio file to text",
+		Long:  "Transcribe an mp3, wav, m4a, ogg, flac, or webm file (up to 25 MB) with the provider's speech recognition model.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			request.AudioPath = args[0]
+			transcript, err := applicationFacade.Audio.Transcribe(cmd.Context(), request)
+			if err != nil {
+				return err
+			}
+
+			if outputPath != "" {
+				if err := os.WriteFile(outputPath, []byte(transcript.Text+"\n"), 0o644); err != nil {
+					return fmt.Errorf("failed to write output file: %w", err)
+				}
+				fmt.Printf("Wrote transcript to %s.\n", outputPath)
+				return nil
+			}
+			fmt.Println(transcript.Text)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&request.ProviderName, "provider", "", "Provider name")
+	_ = cmd.MarkFlagRequired("provider")
+	cmd.Flags().StringVar(&request.ModelName, "model", "", "Speech recognition model (defaults to the provider's default)")
+	cmd.Flags().StringVar(&request.Language, "language", "", "Spoken language hint, e.g. en")
+	cmd.Flags().StringVar(&request.Prompt, "prompt", "", "Text that guides spelling of names and terms")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Write the transcript to a file instead of stdout")
+	return cmd
+}
+
+// newAudioSpeakCommand synthesizes speech to an audio file.
+func newAudioSpeakCommand(deps Dependencies) *cobra.Command {
+
+	var request audioports.SpeakRequest
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "speak <text>...",
+		Short: "Convert text to spoken audio",
+		Long:  "Synthesize speech and write it to --output. The audio format follows --format, or the output file extension when --format is not set.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			request.Text = strings.Join(args, " ")
+			if request.Format == "" {
+				request.Format = strings.TrimPrefix(strings.ToLower(filepath.Ext(outputPath)), ".")
+			}
+			speech, err := applicationFacade.Audio.Speak(cmd.Context(), request)
+			if err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(outputPath, speech.Audio, 0o644); err != nil {
+				return fmt.Errorf("failed to write output file: %w", err)
+			}
+			fmt.Printf("Wrote %d bytes of %s audio to %s.\n", len(speech.Audio), speech.Format, outputPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&request.ProviderName, "provider", "", "Provider name")
+	_ = cmd.MarkFlagRequired("provider")
+	cmd.Flags().StringVar(&request.ModelName, "model", "", "Speech synthesis model (defaults to the provider's default)")
+	cmd.Flags().StringVar(&request.Voice, "voice", "", "Voice name (provider specific)")
+	cmd.Flags().StringVar(&request.Format, "format", "", "Audio format: mp3, wav, opus, aac, flac, or pcm")
+	cmd.Flags().Float64Var(&request.Speed, "speed", 0, "Playback speed, where supported (e.g. 0.25-4.0 for OpenAI)")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Audio file to write")
+	_ = cmd.MarkFlagRequired("output")
+	return cmd
+}
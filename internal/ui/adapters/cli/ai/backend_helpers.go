@@ -28,3 +28,11 @@ func loadApp(deps Dependencies) (*application.App, error) {
 		KeyringServiceName: deps.KeyringServiceName,
 	})
 }
+
+// closeMCP closes the MCP sessions opened by chat tool rounds, stopping stdio server child processes.
+func closeMCP(applicationFacade *application.App) {
+
+	if applicationFacade != nil && applicationFacade.MCP != nil {
+		_ = applicationFacade.MCP.Close()
+	}
+}
@@ -0,0 +1,78 @@
+// config_command.go defines AI CLI adapters for the declarative config file.
+// internal/ui/adapters/cli/ai/config_command.go
+package ai
+
+import (
+	"fmt"
+	"strings"
+
+	config "github.com/MadeByDoug/wls-chatbot/internal/core/config"
+	"github.com/spf13/cobra"
+)
+
+// newConfigCommand creates the parent 'config' command.
+func newConfigCommand(deps Dependencies) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect and validate the declarative config file",
+	}
+	cmd.AddCommand(newConfigSchemaCommand())
+	cmd.AddCommand(newConfigValidateCommand(deps))
+	return cmd
+}
+
+// newConfigSchemaCommand prints the published JSON Schema.
+func newConfigSchemaCommand() *cobra.Command {
+
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the config file JSON Schema",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, err := cmd.OutOrStdout().Write(config.Schema())
+			return err
+		},
+	}
+}
+
+// newConfigValidateCommand validates a config file and the selected profile against stored configuration.
+func newConfigValidateCommand(deps Dependencies) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "validate [file]",
+		Short: "Validate a config file and show the merged providers",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source := deps.ConfigSource
+			if len(args) == 1 {
+				source.Path = args[0]
+			}
+			if source.Path == "" {
+				return fmt.Errorf("no config file: pass a path, --config, or set %s", config.EnvConfigFile)
+			}
+
+			file, err := config.LoadFile(source.Path)
+			if err != nil {
+				return err
+			}
+			merged, err := config.Merge(deps.Config, file, source.Profile)
+			if err != nil {
+				return err
+			}
+
+			profile := source.Profile
+			if profile == "" {
+				profile = "(none)"
+			}
+			fmt.Printf("%s is valid. Profile: %s. Profiles: %s\n", source.Path, profile, strings.Join(file.ProfileNames(), ", "))
+			fmt.Printf("%-20s %-12s %-10s %s\n", "NAME", "TYPE", "FREQUENCY", "DEFAULT MODEL")
+			fmt.Println(strings.Repeat("-", 70))
+			for _, provider := range merged.Providers {
+				fmt.Printf("%-20s %-12s %-10s %s\n", provider.Name, provider.Type, provider.UpdateFrequency, provider.DefaultModel)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"strings"
 
+	chatdomain "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/domain"
+	knowledgeports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/ports"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +28,7 @@ func newConversationCommand(deps Dependencies) *cobra.Command {
 	cmd.AddCommand(newConversationSetActiveCommand(deps))
 	cmd.AddCommand(newConversationUpdateModelCommand(deps))
 	cmd.AddCommand(newConversationUpdateProviderCommand(deps))
+	cmd.AddCommand(newConversationAttachKnowledgeCommand(deps))
 	cmd.AddCommand(newConversationDeleteCommand(deps))
 	cmd.AddCommand(newConversationRestoreCommand(deps))
 	cmd.AddCommand(newConversationPurgeCommand(deps))
@@ -159,6 +162,9 @@ func newConversationGetCommand(deps Dependencies) *cobra.Command {
 			fmt.Printf("Title:    %s\n", conversation.Title)
 			fmt.Printf("Provider: %s\n", conversation.Settings.Provider)
 			fmt.Printf("Model:    %s\n", conversation.Settings.Model)
+			if conversation.Settings.KnowledgeCollection != "" {
+				fmt.Printf("Knowledge: %s\n", conversation.Settings.KnowledgeCollection)
+			}
 			fmt.Printf("Messages: %d\n", len(conversation.Messages))
 			return nil
 		},
@@ -285,6 +291,71 @@ func newConversationUpdateProviderCommand(deps Dependencies) *cobra.Command {
 	return cmd
 }
 
+// newConversationAttachKnowledgeCommand attaches or detaches a knowledge-base collection.
+func newConversationAttachKnowledgeCommand(deps Dependencies) *cobra.Command {
+
+	var id string
+	var collection string
+	var topK int
+	var detach bool
+
+	cmd := &cobra.Command{
+		Use:   "attach-kb",
+		Short: "Ground replies in a knowledge-base collection",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if detach {
+				collection = ""
+			} else if strings.TrimSpace(collection) == "" {
+				return fmt.Errorf("--collection is required unless --detach is set")
+			}
+
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			if !detach {
+				if err := ensureCollectionExists(cmd.Context(), applicationFacade.Knowledge, collection); err != nil {
+					return err
+				}
+			}
+			if !applicationFacade.Conversations.UpdateConversationKnowledge(id, collection, topK) {
+				return fmt.Errorf("failed to update knowledge base for conversation: %s", id)
+			}
+
+			if detach {
+				fmt.Println("Knowledge base detached.")
+				return nil
+			}
+			fmt.Printf("Knowledge base %s attached.\n", collection)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "id", "", "Conversation ID")
+	_ = cmd.MarkFlagRequired("id")
+	cmd.Flags().StringVar(&collection, "collection", "", "Knowledge-base collection name")
+	cmd.Flags().IntVar(&topK, "top-k", 0, "Passages to retrieve per message (default 4)")
+	cmd.Flags().BoolVar(&detach, "detach", false, "Detach the current collection")
+	return cmd
+}
+
+// ensureCollectionExists reports an error when a knowledge-base collection is unknown.
+func ensureCollectionExists(ctx context.Context, knowledge knowledgeports.KnowledgeInterface, name string) error {
+
+	collections, err := knowledge.ListCollections(ctx)
+	if err != nil {
+		return err
+	}
+	for _, collection := range collections {
+		if collection.Name == strings.TrimSpace(name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("knowledge collection not found: %s", name)
+}
+
 // newConversationDeleteCommand deletes a conversation.
 func newConversationDeleteCommand(deps Dependencies) *cobra.Command {
 
@@ -377,18 +448,30 @@ func newConversationSendCommand(deps Dependencies) *cobra.Command {
 
 	var id string
 	var content string
+	var voicePath string
+	var voiceProvider string
 
 	cmd := &cobra.Command{
 		Use:   "send",
 		Short: "Send a message to a conversation",
+		Long:  "Send typed content, a voice attachment, or both. A --voice file is transcribed and the transcript is appended to the message.",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			if strings.TrimSpace(content) == "" && voicePath == "" {
+				return fmt.Errorf("provide --content or --voice")
+			}
 			applicationFacade, err := loadApp(deps)
 			if err != nil {
 				return err
 			}
+			defer closeMCP(applicationFacade)
 
-			message, err := applicationFacade.Conversations.SendMessage(context.Background(), id, content)
+			var message *chatdomain.Message
+			if voicePath != "" {
+				message, err = applicationFacade.Conversations.SendVoiceMessage(context.Background(), id, content, voicePath, voiceProvider)
+			} else {
+				message, err = applicationFacade.Conversations.SendMessage(context.Background(), id, content)
+			}
 			if err != nil {
 				return err
 			}
@@ -405,7 +488,8 @@ func newConversationSendCommand(deps Dependencies) *cobra.Command {
 	cmd.Flags().StringVar(&id, "id", "", "Conversation ID")
 	_ = cmd.MarkFlagRequired("id")
 	cmd.Flags().StringVar(&content, "content", "", "Message content")
-	_ = cmd.MarkFlagRequired("content")
+	cmd.Flags().StringVar(&voicePath, "voice", "", "Audio file to transcribe into the message")
+	cmd.Flags().StringVar(&voiceProvider, "voice-provider", "", "Provider used for transcription (defaults to the conversation's provider)")
 	return cmd
 }
 
@@ -0,0 +1,136 @@
+// embed_command.go defines the AI CLI adapter for text embeddings.
+// internal/ui/adapters/cli/ai/embed_command.go
+package ai
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	embeddingports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/embedding/ports"
+	"github.com/spf13/cobra"
+)
+
+// newEmbedCommand creates the 'embed' command.
+func newEmbedCommand(deps Dependencies) *cobra.Command {
+
+	var providerName string
+	var modelName string
+	var inputFile string
+	var dimensions int
+	var inputType string
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "embed [text...]",
+		Short: "Create embedding vectors for text",
+		Long:  "Create embedding vectors for each text argument, or for each non-empty line of --file. Large inputs are split into batches under the provider's request limit.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input := append([]string(nil), args...)
+			if inputFile != "" {
+				lines, err := readEmbedLines(inputFile)
+				if err != nil {
+					return err
+				}
+				input = append(input, lines...)
+			}
+			if len(input) == 0 {
+				return fmt.Errorf("provide text arguments or --file")
+			}
+
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			result, err := applicationFacade.Embeddings.Embed(cmd.Context(), embeddingports.EmbedRequest{
+				ProviderName: providerName,
+				ModelName:    modelName,
+				Input:        input,
+				Dimensions:   dimensions,
+				InputType:    embeddingports.EmbeddingInputType(inputType),
+			})
+			if err != nil {
+				return err
+			}
+
+			if outputPath != "" {
+				data, err := json.Marshal(result)
+				if err != nil {
+					return fmt.Errorf("encode embeddings: %w", err)
+				}
+				if err := os.WriteFile(outputPath, data, 0o644); err != nil {
+					return fmt.Errorf("failed to write output file: %w", err)
+				}
+				fmt.Printf("Wrote %d embeddings (%d dimensions) to %s.\n", len(result.Embeddings), result.Dimensions, outputPath)
+				return nil
+			}
+
+			fmt.Printf("Model: %s  Dimensions: %d  Tokens: %d\n", result.Model, result.Dimensions, result.TotalTokens)
+			for index, vector := range result.Embeddings {
+				fmt.Printf("%-4d %-40s %s\n", index, truncateEmbedText(input[index], 40), formatVectorPreview(vector))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&providerName, "provider", "", "Provider name")
+	_ = cmd.MarkFlagRequired("provider")
+	cmd.Flags().StringVar(&modelName, "model", "", "Embedding model (defaults to the provider's default when it has one)")
+	cmd.Flags().StringVar(&inputFile, "file", "", "File with one text per line")
+	cmd.Flags().IntVar(&dimensions, "dimensions", 0, "Requested vector size, when the model supports truncation")
+	cmd.Flags().StringVar(&inputType, "input-type", "", "document or query")
+	cmd.Flags().StringVar(&outputPath, "output", "", "Write the full result as JSON to this path")
+	return cmd
+}
+
+// readEmbedLines reads the non-empty lines of a file.
+func readEmbedLines(path string) ([]string, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open input file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read input file: %w", err)
+	}
+	return lines, nil
+}
+
+// truncateEmbedText shortens text for a single table column.
+func truncateEmbedText(text string, width int) string {
+
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+// formatVectorPreview renders the first few vector components.
+func formatVectorPreview(vector []float32) string {
+
+	const previewSize = 4
+	parts := make([]string, 0, previewSize+1)
+	for index, value := range vector {
+		if index == previewSize {
+			parts = append(parts, "...")
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%.4f", value))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
@@ -6,6 +6,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	imageports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/image/ports"
 	"github.com/spf13/cobra"
@@ -20,6 +22,9 @@ func newImageCommand(deps Dependencies) *cobra.Command {
 	}
 	cmd.AddCommand(newImageGenerateCommand(deps))
 	cmd.AddCommand(newImageEditCommand(deps))
+	cmd.AddCommand(newImageVaryCommand(deps))
+	cmd.AddCommand(newImageUpscaleCommand(deps))
+	cmd.AddCommand(newImageMaskCommand(deps))
 	return cmd
 }
 
@@ -84,12 +89,18 @@ func newImageEditCommand(deps Dependencies) *cobra.Command {
 	var outputPath string
 	var imagePath string
 	var maskPath string
+	var maskRects []string
 
 	cmd := &cobra.Command{
 		Use:   "edit",
 		Short: "Edit an image",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			rects, err := parseMaskRects(maskRects)
+			if err != nil {
+				return err
+			}
+
 			applicationFacade, err := loadApp(deps)
 			if err != nil {
 				return err
@@ -102,6 +113,7 @@ func newImageEditCommand(deps Dependencies) *cobra.Command {
 				Prompt:       prompt,
 				ImagePath:    imagePath,
 				MaskPath:     maskPath,
+				MaskRects:    rects,
 				N:            1,
 			})
 			if err != nil {
@@ -129,7 +141,242 @@ func newImageEditCommand(deps Dependencies) *cobra.Command {
 	cmd.Flags().StringVar(&imagePath, "image", "", "Input image path")
 	_ = cmd.MarkFlagRequired("image")
 	cmd.Flags().StringVar(&maskPath, "mask", "", "Input mask path (optional)")
+	cmd.Flags().StringArrayVar(&maskRects, "mask-rect", nil, "Editable region as x,y,width,height (repeat flag; replaces --mask)")
+	cmd.Flags().StringVar(&outputPath, "output", "", "Output path for the generated image")
+
+	return cmd
+}
+
+// newImageVaryCommand creates the 'image vary' command.
+func newImageVaryCommand(deps Dependencies) *cobra.Command {
+
+	var providerName string
+	var modelName string
+	var prompt string
+	var imagePath string
+	var size string
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "vary",
+		Short: "Create a variation of an image",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			deps.BaseLogger.Info().Str("provider", providerName).Str("model", modelName).Msg("Creating image variation...")
+			result, err := applicationFacade.Images.VaryImage(cmd.Context(), imageports.VaryImageRequest{
+				ProviderName: providerName,
+				ModelName:    modelName,
+				ImagePath:    imagePath,
+				Prompt:       prompt,
+				N:            1,
+				Size:         size,
+			})
+			if err != nil {
+				return fmt.Errorf("variation failed: %w", err)
+			}
+
+			return writeImageResult(deps, outputPath, result)
+		},
+	}
+
+	cmd.Flags().StringVar(&providerName, "provider", "", "Provider name (e.g. openai, gemini, cloudflare)")
+	_ = cmd.MarkFlagRequired("provider")
+	cmd.Flags().StringVar(&modelName, "model", "", "Model name (optional)")
+	cmd.Flags().StringVar(&imagePath, "image", "", "Input image path")
+	_ = cmd.MarkFlagRequired("image")
+	cmd.Flags().StringVar(&prompt, "prompt", "", "Optional guidance for prompt-driven variation models")
+	cmd.Flags().StringVar(&size, "size", "", "Output size (optional)")
 	cmd.Flags().StringVar(&outputPath, "output", "", "Output path for the generated image")
 
 	return cmd
 }
+
+// newImageUpscaleCommand creates the 'image upscale' command.
+func newImageUpscaleCommand(deps Dependencies) *cobra.Command {
+
+	var providerName string
+	var modelName string
+	var imagePath string
+	var factor int
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "upscale",
+		Short: "Upscale an image",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			deps.BaseLogger.Info().Str("provider", providerName).Str("model", modelName).Int("factor", factor).Msg("Upscaling image...")
+			result, err := applicationFacade.Images.UpscaleImage(cmd.Context(), imageports.UpscaleImageRequest{
+				ProviderName: providerName,
+				ModelName:    modelName,
+				ImagePath:    imagePath,
+				Factor:       factor,
+			})
+			if err != nil {
+				return fmt.Errorf("upscale failed: %w", err)
+			}
+
+			return writeImageResult(deps, outputPath, result)
+		},
+	}
+
+	cmd.Flags().StringVar(&providerName, "provider", "", "Provider name that advertises image upscaling")
+	_ = cmd.MarkFlagRequired("provider")
+	cmd.Flags().StringVar(&modelName, "model", "", "Model name (optional)")
+	cmd.Flags().StringVar(&imagePath, "image", "", "Input image path")
+	_ = cmd.MarkFlagRequired("image")
+	cmd.Flags().IntVar(&factor, "factor", 2, "Upscale factor")
+	cmd.Flags().StringVar(&outputPath, "output", "", "Output path for the upscaled image")
+
+	return cmd
+}
+
+// writeImageResult saves an image result when an output path is set, otherwise logs its size.
+func writeImageResult(deps Dependencies, outputPath string, result imageports.ImageBinaryResult) error {
+
+	if outputPath == "" {
+		deps.BaseLogger.Info().Int("bytes", len(result.Bytes)).Msg("Image generated (use --output to save)")
+		return nil
+	}
+	if err := os.WriteFile(outputPath, result.Bytes, 0o644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	deps.BaseLogger.Info().Str("path", outputPath).Msg("Image saved")
+	return nil
+}
+
+// newImageMaskCommand creates the 'image mask' command.
+func newImageMaskCommand(deps Dependencies) *cobra.Command {
+
+	var imagePath string
+	var outputPath string
+	var rectEntries []string
+	var polygonEntries []string
+	var editColor string
+	var colorTolerance int
+	var baseMaskPath string
+	var invert bool
+
+	cmd := &cobra.Command{
+		Use:   "mask",
+		Short: "Build an edit mask sized to a source image",
+		Long: `Build a PNG alpha mask for 'image edit'.
+
+Transparent pixels mark the region the provider may edit; opaque pixels are preserved.
+Regions from --rect, --polygon and --edit-color are combined, optionally starting
+from an existing mask given with --from-mask. Use --invert to flip the result.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			rects, err := parseMaskRects(rectEntries)
+			if err != nil {
+				return err
+			}
+			polygons, err := parseMaskPolygons(polygonEntries)
+			if err != nil {
+				return err
+			}
+
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			result, err := applicationFacade.Images.BuildMask(cmd.Context(), imageports.BuildMaskRequest{
+				ImagePath:      imagePath,
+				Rects:          rects,
+				Polygons:       polygons,
+				EditColor:      editColor,
+				ColorTolerance: colorTolerance,
+				BaseMaskPath:   baseMaskPath,
+				Invert:         invert,
+			})
+			if err != nil {
+				return fmt.Errorf("mask failed: %w", err)
+			}
+
+			if err := os.WriteFile(outputPath, result.Bytes, 0o644); err != nil {
+				return fmt.Errorf("failed to write output file: %w", err)
+			}
+			deps.BaseLogger.Info().Str("path", outputPath).Int("width", result.Width).Int("height", result.Height).Msg("Mask saved")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&imagePath, "image", "", "Source image path used for dimensions and color matching")
+	_ = cmd.MarkFlagRequired("image")
+	cmd.Flags().StringVar(&outputPath, "output", "", "Output path for the mask PNG")
+	_ = cmd.MarkFlagRequired("output")
+	cmd.Flags().StringArrayVar(&rectEntries, "rect", nil, "Editable region as x,y,width,height (repeat flag)")
+	cmd.Flags().StringArrayVar(&polygonEntries, "polygon", nil, "Editable polygon as \"x1,y1 x2,y2 x3,y3\" (repeat flag)")
+	cmd.Flags().StringVar(&editColor, "edit-color", "", "Make pixels of this color editable and preserve everything else (#rrggbb)")
+	cmd.Flags().IntVar(&colorTolerance, "tolerance", 0, "Per-channel tolerance for --edit-color (0-255)")
+	cmd.Flags().StringVar(&baseMaskPath, "from-mask", "", "Existing mask to start from")
+	cmd.Flags().BoolVar(&invert, "invert", false, "Invert the resulting mask")
+
+	return cmd
+}
+
+// parseMaskRects parses repeated x,y,width,height CLI values into mask rectangles.
+func parseMaskRects(entries []string) ([]imageports.MaskRect, error) {
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	rects := make([]imageports.MaskRect, 0, len(entries))
+	for _, entry := range entries {
+		values, err := parseIntList(entry, ",")
+		if err != nil || len(values) != 4 {
+			return nil, fmt.Errorf("invalid rectangle %q: expected x,y,width,height", entry)
+		}
+		rects = append(rects, imageports.MaskRect{X: values[0], Y: values[1], Width: values[2], Height: values[3]})
+	}
+	return rects, nil
+}
+
+// parseMaskPolygons parses repeated space-separated x,y point lists into mask polygons.
+func parseMaskPolygons(entries []string) ([]imageports.MaskPolygon, error) {
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	polygons := make([]imageports.MaskPolygon, 0, len(entries))
+	for _, entry := range entries {
+		polygon := imageports.MaskPolygon{}
+		for _, pair := range strings.Fields(entry) {
+			values, err := parseIntList(pair, ",")
+			if err != nil || len(values) != 2 {
+				return nil, fmt.Errorf("invalid polygon point %q: expected x,y", pair)
+			}
+			polygon.Points = append(polygon.Points, imageports.MaskPoint{X: values[0], Y: values[1]})
+		}
+		polygons = append(polygons, polygon)
+	}
+	return polygons, nil
+}
+
+// parseIntList parses a separator-delimited list of integers.
+func parseIntList(value string, separator string) ([]int, error) {
+
+	parts := strings.Split(value, separator)
+	parsed := make([]int, 0, len(parts))
+	for _, part := range parts {
+		number, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, number)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,219 @@
+// kb_command.go defines AI CLI adapters for knowledge-base collections.
+// internal/ui/adapters/cli/ai/kb_command.go
+package ai
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	knowledgeports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/knowledge/ports"
+	"github.com/spf13/cobra"
+)
+
+// newKnowledgeCommand creates the parent 'kb' command.
+func newKnowledgeCommand(deps Dependencies) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:     "kb",
+		Aliases: []string{"knowledge"},
+		Short:   "Manage local document knowledge bases",
+	}
+	cmd.AddCommand(newKnowledgeCreateCommand(deps))
+	cmd.AddCommand(newKnowledgeListCommand(deps))
+	cmd.AddCommand(newKnowledgeIngestCommand(deps))
+	cmd.AddCommand(newKnowledgeSearchCommand(deps))
+	cmd.AddCommand(newKnowledgeDeleteCommand(deps))
+	return cmd
+}
+
+// newKnowledgeCreateCommand creates a collection.
+func newKnowledgeCreateCommand(deps Dependencies) *cobra.Command {
+
+	var request knowledgeports.CreateCollectionRequest
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a knowledge-base collection",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			request.Name = args[0]
+			collection, err := applicationFacade.Knowledge.CreateCollection(cmd.Context(), request)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Created collection %s (%s index, %s embeddings).\n", collection.Name, collection.Index, formatEmbeddingSource(collection))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&request.ProviderName, "provider", "", "Embedding provider name")
+	_ = cmd.MarkFlagRequired("provider")
+	cmd.Flags().StringVar(&request.ModelName, "model", "", "Embedding model (defaults to the provider's default when it has one)")
+	cmd.Flags().IntVar(&request.Dimensions, "dimensions", 0, "Requested vector size, when the model supports truncation")
+	cmd.Flags().StringVar(&request.Index, "index", "flat", "Vector index: flat (exact) or hnsw (approximate, for large collections)")
+	cmd.Flags().IntVar(&request.ChunkSize, "chunk-size", 0, "Maximum characters per chunk (default 1200)")
+	cmd.Flags().IntVar(&request.ChunkOverlap, "chunk-overlap", 0, "Characters of context repeated between chunks (default 200)")
+	return cmd
+}
+
+// newKnowledgeListCommand lists collections.
+func newKnowledgeListCommand(deps Dependencies) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List knowledge-base collections",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			collections, err := applicationFacade.Knowledge.ListCollections(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if len(collections) == 0 {
+				fmt.Println("No collections found.")
+				return nil
+			}
+
+			fmt.Printf("%-24s %-6s %-10s %-8s %-36s %s\n", "NAME", "INDEX", "DOCUMENTS", "CHUNKS", "EMBEDDINGS", "UPDATED")
+			fmt.Println(strings.Repeat("-", 110))
+			for _, collection := range collections {
+				fmt.Printf("%-24s %-6s %-10d %-8d %-36s %s\n",
+					collection.Name,
+					collection.Index,
+					collection.Documents,
+					collection.Chunks,
+					formatEmbeddingSource(collection),
+					time.UnixMilli(collection.UpdatedAt).Format(time.DateTime),
+				)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// newKnowledgeIngestCommand ingests files or directories into a collection.
+func newKnowledgeIngestCommand(deps Dependencies) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "ingest <collection> <path>...",
+		Short: "Add files or directories to a collection",
+		Long:  "Chunk and embed Markdown, text, source code, and PDF files. Directories are walked recursively; files whose content has not changed since the last ingest are skipped.",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			result, err := applicationFacade.Knowledge.Ingest(cmd.Context(), knowledgeports.IngestRequest{
+				Collection: args[0],
+				Paths:      args[1:],
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Ingested %d documents (%d chunks); %d unchanged.\n", result.Documents, result.Chunks, result.Unchanged)
+			if len(result.Skipped) > 0 {
+				fmt.Printf("Skipped %d unsupported or unreadable files:\n", len(result.Skipped))
+				for _, path := range result.Skipped {
+					fmt.Printf("  %s\n", path)
+				}
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// newKnowledgeSearchCommand searches a collection.
+func newKnowledgeSearchCommand(deps Dependencies) *cobra.Command {
+
+	var topK int
+	var rerankProvider string
+	var rerankModel string
+
+	cmd := &cobra.Command{
+		Use:   "search <collection> <query>...",
+		Short: "Find the passages most similar to a query",
+		Long:  "Search a collection by embedding similarity. With --rerank-provider, a wider candidate set is reordered by that provider's reranker before --top-k is applied.",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			passages, err := applicationFacade.Knowledge.Search(cmd.Context(), knowledgeports.SearchRequest{
+				Collection:     args[0],
+				Query:          strings.Join(args[1:], " "),
+				TopK:           topK,
+				RerankProvider: rerankProvider,
+				RerankModel:    rerankModel,
+			})
+			if err != nil {
+				return err
+			}
+			if len(passages) == 0 {
+				fmt.Println("No passages found.")
+				return nil
+			}
+
+			for index, passage := range passages {
+				fmt.Printf("[%d] %s:%d-%d  score %.3f\n", index+1, passage.SourcePath, passage.StartLine, passage.EndLine, passage.Score)
+				fmt.Printf("    %s\n", truncateEmbedText(passage.Content, 160))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&topK, "top-k", 0, "Number of passages to return (default 4)")
+	cmd.Flags().StringVar(&rerankProvider, "rerank-provider", "", "Provider that reranks the candidates")
+	cmd.Flags().StringVar(&rerankModel, "rerank-model", "", "Rerank model, or the scoring chat model for providers without one")
+	return cmd
+}
+
+// newKnowledgeDeleteCommand deletes a collection.
+func newKnowledgeDeleteCommand(deps Dependencies) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "delete <collection>",
+		Short: "Delete a collection and its stored chunks",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			if err := applicationFacade.Knowledge.DeleteCollection(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+
+			fmt.Printf("Collection %s deleted.\n", args[0])
+			return nil
+		},
+	}
+	return cmd
+}
+
+// formatEmbeddingSource renders a collection's provider and model.
+func formatEmbeddingSource(collection knowledgeports.Collection) string {
+
+	if collection.ModelName == "" {
+		return collection.ProviderName
+	}
+	return collection.ProviderName + "/" + collection.ModelName
+}
@@ -0,0 +1,158 @@
+// local_models_command.go defines AI CLI adapters for pulling and loading locally hosted models.
+// internal/ui/adapters/cli/ai/local_models_command.go
+package ai
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+	"github.com/spf13/cobra"
+)
+
+// newProviderPullCommand downloads a model through a local model provider.
+func newProviderPullCommand(deps Dependencies) *cobra.Command {
+
+	var name string
+	var model string
+
+	cmd := &cobra.Command{
+		Use:   "pull",
+		Short: "Download a model to a local provider such as Ollama",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			lastStatus := ""
+			err = applicationFacade.Providers.PullModel(cmd.Context(), name, model, func(progress providergateway.ModelPullProgress) {
+				line := progress.Status
+				if progress.Total > 0 {
+					line = fmt.Sprintf("%s %d%%", progress.Status, progress.Completed*100/progress.Total)
+				}
+				if line == lastStatus {
+					return
+				}
+				lastStatus = line
+				fmt.Println(line)
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Model %s pulled to %s.\n", model, name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Provider name")
+	cmd.Flags().StringVar(&model, "model", "", "Model to pull, for example llama3.2")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("model")
+	return cmd
+}
+
+// newProviderLoadCommand loads a model into memory through a local model provider.
+func newProviderLoadCommand(deps Dependencies) *cobra.Command {
+
+	var name string
+	var model string
+
+	cmd := &cobra.Command{
+		Use:   "load",
+		Short: "Load a model into memory on a local provider",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Loading %s on %s...\n", model, name)
+			if err := applicationFacade.Providers.LoadModel(cmd.Context(), name, model); err != nil {
+				return err
+			}
+			fmt.Println("Model loaded.")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Provider name")
+	cmd.Flags().StringVar(&model, "model", "", "Model to load")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("model")
+	return cmd
+}
+
+// newProviderPsCommand lists installed and loaded models on a local provider.
+func newProviderPsCommand(deps Dependencies) *cobra.Command {
+
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   "ps",
+		Short: "Show installed and loaded models on a local provider",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			states, err := applicationFacade.Providers.LocalModelStates(cmd.Context(), name)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("%-35s %-10s %-8s %-10s %s\n", "MODEL", "INSTALLED", "LOADED", "SIZE", "UNLOADS AT")
+			fmt.Println(strings.Repeat("-", 90))
+			for _, state := range states {
+				fmt.Printf("%-35s %-10s %-8s %-10s %s\n", state.Model, yesNo(state.Installed), yesNo(state.Loaded), formatBytes(state.SizeBytes), formatExpiry(state.ExpiresAt))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Provider name")
+	_ = cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+// yesNo renders a boolean as yes or no.
+func yesNo(value bool) string {
+
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+// formatBytes renders a byte count in binary units.
+func formatBytes(size int64) string {
+
+	if size <= 0 {
+		return "-"
+	}
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%dB", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// formatExpiry renders when a loaded model will be unloaded.
+func formatExpiry(expiresAt time.Time) string {
+
+	if expiresAt.IsZero() {
+		return "-"
+	}
+	return expiresAt.Local().Format(time.DateTime)
+}
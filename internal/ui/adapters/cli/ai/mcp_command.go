@@ -0,0 +1,210 @@
+// mcp_command.go defines AI CLI adapters for Model Context Protocol tool servers.
+// internal/ui/adapters/cli/ai/mcp_command.go
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	mcpports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/mcp/ports"
+	"github.com/spf13/cobra"
+)
+
+// newMCPCommand creates the parent 'mcp' command.
+func newMCPCommand(deps Dependencies) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Manage MCP tool servers offered to chat models",
+		Long:  "Model Context Protocol servers expose tools that chat models can call while replying. Tools are named <server>__<tool>.",
+	}
+	cmd.AddCommand(newMCPListCommand(deps))
+	cmd.AddCommand(newMCPAddCommand(deps))
+	cmd.AddCommand(newMCPRemoveCommand(deps))
+	cmd.AddCommand(newMCPTestCommand(deps))
+	return cmd
+}
+
+// newMCPListCommand lists configured servers.
+func newMCPListCommand(deps Dependencies) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List configured MCP servers",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			servers, err := applicationFacade.MCP.ListServers(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if len(servers) == 0 {
+				fmt.Println("No MCP servers configured.")
+				return nil
+			}
+
+			fmt.Printf("%-20s %-9s %-8s %s\n", "NAME", "TRANSPORT", "ENABLED", "TARGET")
+			fmt.Println(strings.Repeat("-", 80))
+			for _, server := range servers {
+				enabled := "yes"
+				if server.Disabled {
+					enabled = "no"
+				}
+				fmt.Printf("%-20s %-9s %-8s %s\n", server.Name, server.Transport, enabled, formatMCPTarget(server))
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// newMCPAddCommand adds a server to the stored configuration.
+func newMCPAddCommand(deps Dependencies) *cobra.Command {
+
+	var (
+		command     string
+		args        []string
+		envEntries  []string
+		url         string
+		headerPairs []string
+		disabled    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add an MCP server",
+		Long:  "Add a stdio server with --command (and --arg, --env), or a streamable HTTP server with --url (and --header).",
+		Example: "  wls-chatbot cli ai mcp add files --command npx --arg -y --arg @modelcontextprotocol/server-filesystem --arg ~/notes\n" +
+			"  wls-chatbot cli ai mcp add search --url https://mcp.example.com/mcp --header \"Authorization=Bearer $TOKEN\"",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, positional []string) error {
+			server := mcpports.ServerConfig{Name: positional[0], Disabled: disabled}
+			switch {
+			case command != "" && url != "":
+				return errors.New("use either --command or --url, not both")
+			case command != "":
+				if len(headerPairs) > 0 {
+					return errors.New("--header applies to --url servers")
+				}
+				env, err := parseKeyValuePairs(envEntries)
+				if err != nil {
+					return err
+				}
+				server.Transport, server.Command, server.Args, server.Env = mcpports.TransportStdio, command, args, env
+			case url != "":
+				if len(args) > 0 || len(envEntries) > 0 {
+					return errors.New("--arg and --env apply to --command servers")
+				}
+				headers, err := parseKeyValuePairs(headerPairs)
+				if err != nil {
+					return err
+				}
+				server.Transport, server.URL, server.Headers = mcpports.TransportHTTP, url, headers
+			default:
+				return errors.New("--command or --url required")
+			}
+
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+			if err := applicationFacade.MCP.AddServer(cmd.Context(), server); err != nil {
+				return err
+			}
+
+			fmt.Printf("Added MCP server %s (%s).\n", server.Name, server.Transport)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&command, "command", "", "Executable that runs a stdio server")
+	cmd.Flags().StringArrayVar(&args, "arg", nil, "Argument passed to --command (repeat flag)")
+	cmd.Flags().StringArrayVar(&envEntries, "env", nil, "Environment variable for --command as key=value (repeat flag)")
+	cmd.Flags().StringVar(&url, "url", "", "Endpoint of a streamable HTTP server")
+	cmd.Flags().StringArrayVar(&headerPairs, "header", nil, "HTTP header for --url as key=value (repeat flag)")
+	cmd.Flags().BoolVar(&disabled, "disabled", false, "Store the server without offering its tools to models")
+	return cmd
+}
+
+// newMCPRemoveCommand removes a server from the stored configuration.
+func newMCPRemoveCommand(deps Dependencies) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove an MCP server",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+			if err := applicationFacade.MCP.RemoveServer(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+
+			fmt.Printf("Removed MCP server %s.\n", args[0])
+			return nil
+		},
+	}
+	return cmd
+}
+
+// newMCPTestCommand connects to a server and lists its tools.
+func newMCPTestCommand(deps Dependencies) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "test <name>",
+		Short: "Connect to an MCP server and list its tools",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			result, err := applicationFacade.MCP.TestServer(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Connected to %s: %s %s (protocol %s)\n", result.Server, result.Info.Name, result.Info.Version, result.Info.ProtocolVersion)
+			if len(result.Tools) == 0 {
+				fmt.Println("The server offers no tools.")
+				return nil
+			}
+			fmt.Printf("%d tools:\n", len(result.Tools))
+			for _, tool := range result.Tools {
+				fmt.Printf("  %s", result.Server+mcpports.ToolNameSeparator+tool.Name)
+				if description := strings.TrimSpace(tool.Description); description != "" {
+					fmt.Printf("  %s", truncateEmbedText(description, 100))
+				}
+				fmt.Println()
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// formatMCPTarget describes where a server runs; header values are omitted because they often hold credentials.
+func formatMCPTarget(server mcpports.ServerConfig) string {
+
+	if server.Transport != mcpports.TransportHTTP {
+		return strings.TrimSpace(strings.Join(append([]string{server.Command}, server.Args...), " "))
+	}
+	if len(server.Headers) == 0 {
+		return server.URL
+	}
+	keys := make([]string, 0, len(server.Headers))
+	for key := range server.Headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf("%s (headers: %s)", server.URL, strings.Join(keys, ", "))
+}
@@ -33,6 +33,7 @@ func newModelListCommand(deps Dependencies) *cobra.Command {
 	var requiredOutputModalities []string
 	var requiredCapabilityIDs []string
 	var requiredSystemTags []string
+	var excludeBroken bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -50,19 +51,24 @@ func newModelListCommand(deps Dependencies) *cobra.Command {
 				RequiredOutputModalities: requiredOutputModalities,
 				RequiredCapabilityIDs:    requiredCapabilityIDs,
 				RequiredSystemTags:       requiredSystemTags,
+				ExcludeBroken:            excludeBroken,
 			})
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("%-40s %-15s %-12s %-10s\n", "MODEL ID", "PROVIDER", "SOURCE", "APPROVED")
-			fmt.Println(strings.Repeat("-", 80))
+			fmt.Printf("%-40s %-15s %-12s %-10s %s\n", "MODEL ID", "PROVIDER", "SOURCE", "APPROVED", "STATUS")
+			fmt.Println(strings.Repeat("-", 100))
 			for _, summary := range summaries {
 				approved := "no"
 				if summary.Approved {
 					approved = "yes"
 				}
-				fmt.Printf("%-40s %-15s %-12s %-10s\n", summary.ModelID, summary.ProviderName, summary.Source, approved)
+				status := summary.AvailabilityState
+				if len(summary.BrokenCapabilities) > 0 {
+					status = fmt.Sprintf("%s (%s)", status, strings.Join(summary.BrokenCapabilities, ", "))
+				}
+				fmt.Printf("%-40s %-15s %-12s %-10s %s\n", summary.ModelID, summary.ProviderName, summary.Source, approved, status)
 			}
 			return nil
 		},
@@ -72,6 +78,7 @@ func newModelListCommand(deps Dependencies) *cobra.Command {
 	cmd.Flags().StringSliceVar(&requiredOutputModalities, "requires-output-modality", nil, "Require one or more output modalities (repeat flag)")
 	cmd.Flags().StringSliceVar(&requiredCapabilityIDs, "requires-capability", nil, "Require one or more semantic capability IDs (repeat flag)")
 	cmd.Flags().StringSliceVar(&requiredSystemTags, "requires-system-tag", nil, "Require one or more model system tags (repeat flag)")
+	cmd.Flags().BoolVar(&excludeBroken, "exclude-broken", false, "Hide models that failed their last live modeltest verification")
 	return cmd
 }
 
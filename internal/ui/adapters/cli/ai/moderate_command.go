@@ -0,0 +1,95 @@
+// moderate_command.go defines the AI CLI adapter for content safety screening.
+// internal/ui/adapters/cli/ai/moderate_command.go
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	moderationports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/moderation/ports"
+	"github.com/spf13/cobra"
+)
+
+// newModerateCommand creates the 'moderate' command.
+func newModerateCommand(deps Dependencies) *cobra.Command {
+
+	var providerName string
+	var modelName string
+	var inputFile string
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "moderate [text...]",
+		Short: "Screen text against safety categories",
+		Long: "Classify each text argument, or each non-empty line of --file, with the provider's moderation model. " +
+			"Providers without a moderation endpoint use --model as an LLM judge. Exits non-zero when any input is flagged.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input := append([]string(nil), args...)
+			if inputFile != "" {
+				lines, err := readEmbedLines(inputFile)
+				if err != nil {
+					return err
+				}
+				input = append(input, lines...)
+			}
+			if len(input) == 0 {
+				return fmt.Errorf("provide text arguments or --file")
+			}
+
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			result, err := applicationFacade.Moderation.Moderate(cmd.Context(), moderationports.ModerateRequest{
+				ProviderName: providerName,
+				ModelName:    modelName,
+				Inputs:       input,
+			})
+			if err != nil {
+				return err
+			}
+
+			flagged := 0
+			for _, verdict := range result.Verdicts {
+				if verdict.Flagged {
+					flagged++
+				}
+			}
+
+			if outputPath != "" {
+				data, err := json.Marshal(result)
+				if err != nil {
+					return fmt.Errorf("encode moderation result: %w", err)
+				}
+				if err := os.WriteFile(outputPath, data, 0o644); err != nil {
+					return fmt.Errorf("failed to write output file: %w", err)
+				}
+				fmt.Printf("Wrote %d verdicts to %s.\n", len(result.Verdicts), outputPath)
+			} else {
+				fmt.Printf("Model: %s\n", result.Model)
+				for index, verdict := range result.Verdicts {
+					status := "safe"
+					if verdict.Flagged {
+						status = "flagged"
+					}
+					fmt.Printf("%-4d %-8s %-40s %s\n", index, status, truncateEmbedText(input[index], 40), strings.Join(verdict.Categories, ", "))
+				}
+			}
+
+			if flagged > 0 {
+				return fmt.Errorf("%d of %d inputs flagged", flagged, len(input))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&providerName, "provider", "", "Provider name")
+	_ = cmd.MarkFlagRequired("provider")
+	cmd.Flags().StringVar(&modelName, "model", "", "Moderation model, or the judge chat model for providers without a moderation endpoint")
+	cmd.Flags().StringVar(&inputFile, "file", "", "File with one text per line")
+	cmd.Flags().StringVar(&outputPath, "output", "", "Write the full result as JSON to this path")
+	return cmd
+}
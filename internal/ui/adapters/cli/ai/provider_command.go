@@ -4,6 +4,7 @@ package ai
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
@@ -20,11 +21,15 @@ func newProviderCommand(deps Dependencies) *cobra.Command {
 	cmd.AddCommand(newProviderListCommand(deps))
 	cmd.AddCommand(newProviderTestCommand(deps))
 	cmd.AddCommand(newProviderAddCommand(deps))
+	cmd.AddCommand(newProviderCloneCommand(deps))
 	cmd.AddCommand(newProviderRemoveCommand(deps))
 	cmd.AddCommand(newProviderCredentialsCommand(deps))
 	cmd.AddCommand(newProviderActiveCommand(deps))
 	cmd.AddCommand(newProviderSetActiveCommand(deps))
 	cmd.AddCommand(newProviderRefreshCommand(deps))
+	cmd.AddCommand(newProviderPullCommand(deps))
+	cmd.AddCommand(newProviderLoadCommand(deps))
+	cmd.AddCommand(newProviderPsCommand(deps))
 	return cmd
 }
 
@@ -43,8 +48,8 @@ func newProviderListCommand(deps Dependencies) *cobra.Command {
 
 			providers := applicationFacade.Providers.GetProviders()
 
-			fmt.Printf("%-15s %-25s %-10s %-10s\n", "NAME", "DISPLAY NAME", "CONNECTED", "ACTIVE")
-			fmt.Println(strings.Repeat("-", 70))
+			fmt.Printf("%-15s %-12s %-25s %-10s %-10s %s\n", "NAME", "TYPE", "DISPLAY NAME", "CONNECTED", "ACTIVE", "CREDENTIAL SOURCES")
+			fmt.Println(strings.Repeat("-", 103))
 			for _, provider := range providers {
 				connected := "no"
 				active := "no"
@@ -54,7 +59,7 @@ func newProviderListCommand(deps Dependencies) *cobra.Command {
 				if provider.IsActive {
 					active = "yes"
 				}
-				fmt.Printf("%-15s %-25s %-10s %-10s\n", provider.Name, provider.DisplayName, connected, active)
+				fmt.Printf("%-15s %-12s %-25s %-10s %-10s %s\n", provider.Name, provider.Type, provider.DisplayName, connected, active, formatCredentialSources(provider.CredentialSources))
 			}
 			return nil
 		},
@@ -62,6 +67,25 @@ func newProviderListCommand(deps Dependencies) *cobra.Command {
 	return cmd
 }
 
+// formatCredentialSources renders field=source pairs sorted by field without revealing values.
+func formatCredentialSources(sources map[string]string) string {
+
+	if len(sources) == 0 {
+		return "-"
+	}
+	fields := make([]string, 0, len(sources))
+	for field := range sources {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		parts = append(parts, field+"="+sources[field])
+	}
+	return strings.Join(parts, ", ")
+}
+
 // newProviderTestCommand tests a provider connection.
 func newProviderTestCommand(deps Dependencies) *cobra.Command {
 
@@ -129,6 +153,48 @@ func newProviderAddCommand(deps Dependencies) *cobra.Command {
 	return cmd
 }
 
+// newProviderCloneCommand creates a new named instance from an existing provider.
+func newProviderCloneCommand(deps Dependencies) *cobra.Command {
+
+	var source string
+	var name string
+	var displayName string
+	var copyCredentials bool
+
+	cmd := &cobra.Command{
+		Use:   "clone",
+		Short: "Create a new provider instance from an existing provider",
+		Long: "Clone copies a provider's type, base URL, models, and non-secret inputs into a new named instance\n" +
+			"with its own credentials and resource cache. Secrets are copied only with --copy-credentials.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			info, err := applicationFacade.Providers.CloneProvider(source, name, displayName, copyCredentials)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Provider %s (%s) cloned from %s.\n", info.Name, info.DisplayName, source)
+			if !info.IsConnected {
+				fmt.Printf("Add credentials with: ai provider add --name %s --credential key=value\n", info.Name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&source, "from", "", "Existing provider name to clone")
+	cmd.Flags().StringVar(&name, "name", "", "New provider instance name")
+	cmd.Flags().StringVar(&displayName, "display-name", "", "Display name for the new instance")
+	cmd.Flags().BoolVar(&copyCredentials, "copy-credentials", false, "Copy stored secrets to the new instance")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("name")
+	return cmd
+}
+
 // newProviderRemoveCommand disconnects a configured provider.
 func newProviderRemoveCommand(deps Dependencies) *cobra.Command {
 
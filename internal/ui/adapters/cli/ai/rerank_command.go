@@ -0,0 +1,86 @@
+// rerank_command.go defines the AI CLI adapter for query-document reranking.
+// internal/ui/adapters/cli/ai/rerank_command.go
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	rerankports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/rerank/ports"
+	"github.com/spf13/cobra"
+)
+
+// newRerankCommand creates the 'rerank' command.
+func newRerankCommand(deps Dependencies) *cobra.Command {
+
+	var providerName string
+	var modelName string
+	var inputFile string
+	var topN int
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "rerank <query> [document...]",
+		Short: "Order documents by relevance to a query",
+		Long: "Score each document argument, or each non-empty line of --file, against the query and print them from most to least relevant. " +
+			"Providers without a rerank model score documents with the chat model named by --model.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			documents := append([]string(nil), args[1:]...)
+			if inputFile != "" {
+				lines, err := readEmbedLines(inputFile)
+				if err != nil {
+					return err
+				}
+				documents = append(documents, lines...)
+			}
+			if len(documents) == 0 {
+				return fmt.Errorf("provide document arguments or --file")
+			}
+
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+
+			result, err := applicationFacade.Rerank.Rerank(cmd.Context(), rerankports.RerankRequest{
+				ProviderName: providerName,
+				ModelName:    modelName,
+				Query:        strings.TrimSpace(args[0]),
+				Documents:    documents,
+				TopN:         topN,
+			})
+			if err != nil {
+				return err
+			}
+
+			if outputPath != "" {
+				data, err := json.Marshal(result)
+				if err != nil {
+					return fmt.Errorf("encode rerank result: %w", err)
+				}
+				if err := os.WriteFile(outputPath, data, 0o644); err != nil {
+					return fmt.Errorf("failed to write output file: %w", err)
+				}
+				fmt.Printf("Wrote %d ranked documents to %s.\n", len(result.Results), outputPath)
+				return nil
+			}
+
+			fmt.Printf("Model: %s\n", result.Model)
+			for rank, ranked := range result.Results {
+				fmt.Printf("%-4d %-8.4f #%-4d %s\n", rank+1, ranked.Score, ranked.Index, truncateEmbedText(ranked.Document, 60))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&providerName, "provider", "", "Provider name")
+	_ = cmd.MarkFlagRequired("provider")
+	cmd.Flags().StringVar(&modelName, "model", "", "Rerank model, or the scoring chat model for providers without one")
+	cmd.Flags().StringVar(&inputFile, "file", "", "File with one document per line")
+	cmd.Flags().IntVar(&topN, "top-n", 0, "Number of documents to return (default all)")
+	cmd.Flags().StringVar(&outputPath, "output", "", "Write the full result as JSON to this path")
+	return cmd
+}
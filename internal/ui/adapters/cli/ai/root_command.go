@@ -27,8 +27,17 @@ func NewCommand(deps Dependencies) *cobra.Command {
 	cmd.AddCommand(newProviderCommand(deps))
 	cmd.AddCommand(newModelCommand(deps))
 	cmd.AddCommand(newImageCommand(deps))
+	cmd.AddCommand(newEmbedCommand(deps))
+	cmd.AddCommand(newModerateCommand(deps))
+	cmd.AddCommand(newRerankCommand(deps))
+	cmd.AddCommand(newKnowledgeCommand(deps))
+	cmd.AddCommand(newAudioCommand(deps))
 	cmd.AddCommand(newChatCommand(deps))
 	cmd.AddCommand(newConversationCommand(deps))
+	cmd.AddCommand(newMCPCommand(deps))
+	cmd.AddCommand(newServeCommand(deps))
+	cmd.AddCommand(newSecretsCommand(deps))
+	cmd.AddCommand(newConfigCommand(deps))
 
 	return cmd
 }
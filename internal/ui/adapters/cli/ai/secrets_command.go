@@ -0,0 +1,164 @@
+// secrets_command.go defines AI CLI adapters for provider secret storage workflows.
+// internal/ui/adapters/cli/ai/secrets_command.go
+package ai
+
+import (
+	"fmt"
+	"strings"
+
+	providersmodule "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers"
+	securestore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/adapters/secretstore"
+	"github.com/spf13/cobra"
+)
+
+// newSecretsCommand creates the parent 'secrets' command.
+func newSecretsCommand(deps Dependencies) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "Manage provider secret storage",
+	}
+	cmd.AddCommand(newSecretsStatusCommand(deps))
+	cmd.AddCommand(newSecretsMigrateCommand(deps))
+	return cmd
+}
+
+// newSecretsStatusCommand reports which secret store backend is selected.
+func newSecretsStatusCommand(deps Dependencies) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the selected secret store backend",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			options, err := secretStoreOptions(deps, "", "")
+			if err != nil {
+				return err
+			}
+			_, backend, err := securestore.Select(options)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Configured backend: %s\n", options.Backend)
+			fmt.Printf("Selected backend:   %s\n", backend)
+			if backend == securestore.BackendFile {
+				fmt.Printf("Secrets file:       %s\n", options.File.Path)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// newSecretsMigrateCommand copies configured provider secrets between backends.
+func newSecretsMigrateCommand(deps Dependencies) *cobra.Command {
+
+	var from string
+	var to string
+	var filePath string
+	var keyFile string
+	var providerName string
+	var deleteSource bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Move provider secrets between the keyring and the encrypted file",
+		Long: "Copies each configured provider secret from one store to another.\n" +
+			"The encrypted file is unlocked with " + securestore.EnvSecretsPassphrase + " or --key-file.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			fromBackend, err := parseExplicitBackend("--from", from)
+			if err != nil {
+				return err
+			}
+			toBackend, err := parseExplicitBackend("--to", to)
+			if err != nil {
+				return err
+			}
+			if fromBackend == toBackend {
+				return fmt.Errorf("--from and --to must differ")
+			}
+
+			options, err := secretStoreOptions(deps, filePath, keyFile)
+			if err != nil {
+				return err
+			}
+			source, err := securestore.Open(fromBackend, options)
+			if err != nil {
+				return err
+			}
+			target, err := securestore.Open(toBackend, options)
+			if err != nil {
+				return err
+			}
+
+			refs := providersmodule.ProviderSecretRefs(deps.Config)
+			if providerName != "" {
+				filtered := refs[:0]
+				for _, ref := range refs {
+					if ref.ProviderName == providerName {
+						filtered = append(filtered, ref)
+					}
+				}
+				refs = filtered
+				if len(refs) == 0 {
+					return fmt.Errorf("provider %s has no secret fields configured", providerName)
+				}
+			}
+
+			result, err := securestore.Migrate(source, target, refs, deleteSource)
+			for _, ref := range result.Migrated {
+				fmt.Printf("migrated %s\n", ref)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Migrated %d secret(s) from %s to %s; %d not stored in source.\n", len(result.Migrated), fromBackend, toBackend, len(result.Skipped))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "", "Source backend (keyring or file)")
+	cmd.Flags().StringVar(&to, "to", "", "Target backend (keyring or file)")
+	cmd.Flags().StringVar(&filePath, "file", "", "Encrypted secrets file path (defaults to "+securestore.EnvSecretsFile+" or the app data directory)")
+	cmd.Flags().StringVar(&keyFile, "key-file", "", "Key file used instead of "+securestore.EnvSecretsPassphrase)
+	cmd.Flags().StringVar(&providerName, "provider", "", "Only migrate secrets for this provider")
+	cmd.Flags().BoolVar(&deleteSource, "delete-source", false, "Delete secrets from the source after copying")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+// secretStoreOptions resolves secret store options from the environment with CLI overrides.
+func secretStoreOptions(deps Dependencies, filePath, keyFile string) (securestore.Options, error) {
+
+	defaultPath, err := securestore.DefaultFilePath(deps.AppName)
+	if err != nil {
+		return securestore.Options{}, err
+	}
+	options, err := securestore.OptionsFromEnv(deps.KeyringServiceName, defaultPath)
+	if err != nil {
+		return securestore.Options{}, err
+	}
+	if strings.TrimSpace(filePath) != "" {
+		options.File.Path = filePath
+	}
+	if strings.TrimSpace(keyFile) != "" {
+		options.File.KeyFile = keyFile
+		options.File.Passphrase = ""
+	}
+	return options, nil
+}
+
+// parseExplicitBackend parses a migrate endpoint, rejecting auto.
+func parseExplicitBackend(flag, value string) (securestore.Backend, error) {
+
+	backend, err := securestore.ParseBackend(value)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", flag, err)
+	}
+	if backend == securestore.BackendAuto {
+		return "", fmt.Errorf("%s must be keyring or file", flag)
+	}
+	return backend, nil
+}
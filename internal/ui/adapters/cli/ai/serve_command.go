@@ -0,0 +1,107 @@
+// serve_command.go defines the AI CLI adapter for the OpenAI-compatible local API server.
+// internal/ui/adapters/cli/ai/serve_command.go
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	"github.com/MadeByDoug/wls-chatbot/internal/ui/adapters/httpapi"
+	"github.com/spf13/cobra"
+)
+
+// defaultServeAddr binds to loopback so the API is not exposed to the network by default.
+const defaultServeAddr = "127.0.0.1:8765"
+
+// newServeCommand creates the 'serve' command.
+func newServeCommand(deps Dependencies) *cobra.Command {
+
+	var addr string
+	var token string
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve an OpenAI-compatible API backed by configured providers",
+		Long: "Serve /v1/chat/completions, /v1/models, /v1/images/generations and /v1/embeddings. " +
+			"Models are addressed as provider/model. Clients authenticate with a bearer token taken from --token, " +
+			httpapi.EnvToken + ", or generated and printed at startup.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			token = strings.TrimSpace(token)
+			if token == "" {
+				token = strings.TrimSpace(os.Getenv(httpapi.EnvToken))
+			}
+			generated := false
+			if token == "" {
+				value, err := httpapi.GenerateToken()
+				if err != nil {
+					return err
+				}
+				token = value
+				generated = true
+			}
+
+			applicationFacade, err := loadApp(deps)
+			if err != nil {
+				return err
+			}
+			defer closeMCP(applicationFacade)
+
+			server, err := httpapi.NewServer(httpapi.Services{
+				Chat:       applicationFacade.Chat,
+				Images:     applicationFacade.Images,
+				Embeddings: applicationFacade.Embeddings,
+				Providers:  applicationFacade.Providers,
+			}, token, corelogger.NewAdapter(deps.BaseLogger))
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			httpServer := &http.Server{
+				Addr:              addr,
+				Handler:           server.Handler(),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			errs := make(chan error, 1)
+			go func() {
+				errs <- httpServer.ListenAndServe()
+			}()
+
+			fmt.Printf("Serving OpenAI-compatible API at http://%s/v1\n", addr)
+			if generated {
+				fmt.Printf("Bearer token: %s\n", token)
+			}
+
+			select {
+			case err := <-errs:
+				if errors.Is(err, http.ErrServerClosed) {
+					return nil
+				}
+				return fmt.Errorf("serve api: %w", err)
+			case <-ctx.Done():
+			}
+
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := httpServer.Shutdown(shutdownCtx); err != nil {
+				return fmt.Errorf("shutdown api server: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", defaultServeAddr, "Listen address")
+	cmd.Flags().StringVar(&token, "token", "", "Bearer token clients must send (default: $"+httpapi.EnvToken+" or a generated token)")
+	return cmd
+}
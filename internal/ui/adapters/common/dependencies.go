@@ -18,6 +18,7 @@ type Dependencies struct {
 	BaseLogger         zerolog.Logger
 	DB                 *sql.DB
 	Config             config.AppConfig
+	ConfigSource       config.FileSource
 }
 
 // ValidateCore validates shared dependency fields required by all adapters.
@@ -0,0 +1,457 @@
+// chat_completions.go implements the OpenAI-compatible chat completions endpoint.
+// internal/ui/adapters/httpapi/chat_completions.go
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	chatports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/ports"
+	providergateway "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/gateway"
+)
+
+// chatCompletionRequest is the subset of the OpenAI chat completion request that is supported.
+type chatCompletionRequest struct {
+	Model       string             `json:"model"`
+	Messages    []chatMessageInput `json:"messages"`
+	Stream      bool               `json:"stream,omitempty"`
+	Temperature float64            `json:"temperature,omitempty"`
+	MaxTokens   int                `json:"max_tokens,omitempty"`
+	Stop        json.RawMessage    `json:"stop,omitempty"`
+	Tools       []chatToolInput    `json:"tools,omitempty"`
+}
+
+// chatMessageInput accepts string content or an array of text parts, plus the tool calls of an
+// assistant message or the call a tool message answers.
+type chatMessageInput struct {
+	Role       string          `json:"role"`
+	Content    json.RawMessage `json:"content"`
+	ToolCalls  []chatToolCall  `json:"tool_calls,omitempty"`
+	ToolCallID string          `json:"tool_call_id,omitempty"`
+}
+
+// chatToolInput is an OpenAI function tool declaration.
+type chatToolInput struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name        string                 `json:"name"`
+		Description string                 `json:"description,omitempty"`
+		Parameters  map[string]interface{} `json:"parameters,omitempty"`
+	} `json:"function"`
+}
+
+// chatCompletionMessage is an assistant message in a non-streaming response.
+type chatCompletionMessage struct {
+	Role      string         `json:"role,omitempty"`
+	Content   string         `json:"content,omitempty"`
+	ToolCalls []chatToolCall `json:"tool_calls,omitempty"`
+}
+
+// chatToolCall is an OpenAI function tool call.
+type chatToolCall struct {
+	Index    *int   `json:"index,omitempty"`
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+// chatCompletionChoice is one choice in a response or stream chunk.
+type chatCompletionChoice struct {
+	Index        int                    `json:"index"`
+	Message      *chatCompletionMessage `json:"message,omitempty"`
+	Delta        *chatCompletionMessage `json:"delta,omitempty"`
+	FinishReason *string                `json:"finish_reason"`
+}
+
+// chatCompletionUsage reports token accounting.
+type chatCompletionUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// chatCompletionResponse is a chat completion body or stream chunk.
+type chatCompletionResponse struct {
+	ID      string                 `json:"id"`
+	Object  string                 `json:"object"`
+	Created int64                  `json:"created"`
+	Model   string                 `json:"model"`
+	Choices []chatCompletionChoice `json:"choices"`
+	Usage   *chatCompletionUsage   `json:"usage,omitempty"`
+}
+
+// handleChatCompletions routes a chat completion through the chat service.
+func (s *Server) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+
+	if s.services.Chat == nil {
+		writeError(w, http.StatusServiceUnavailable, "unavailable", "chat service not configured")
+		return
+	}
+
+	var body chatCompletionRequest
+	if err := decodeRequest(w, r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	request, err := toChatRequest(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	chunks, err := s.services.Chat.Chat(r.Context(), request)
+	if errors.Is(err, providergateway.ErrToolTurnsUnsupported) {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "upstream_error", err.Error())
+		return
+	}
+
+	id := fmt.Sprintf("chatcmpl-%d", s.now().UnixNano())
+	if body.Stream {
+		s.streamChatCompletion(w, id, body.Model, chunks)
+		return
+	}
+	s.collectChatCompletion(w, id, body.Model, chunks)
+}
+
+// collectChatCompletion drains the stream into a single chat completion response.
+func (s *Server) collectChatCompletion(w http.ResponseWriter, id, model string, chunks <-chan chatports.ChatChunk) {
+
+	var content strings.Builder
+	var toolCalls []chatToolCall
+	var usage *chatCompletionUsage
+	finishReason := "stop"
+	for chunk := range chunks {
+		if chunk.Error != "" {
+			drain(chunks)
+			writeError(w, http.StatusBadGateway, "upstream_error", chunk.Error)
+			return
+		}
+		content.WriteString(chunk.Content)
+		toolCalls = append(toolCalls, toToolCalls(chunk.ToolCalls)...)
+		if chunk.FinishReason != "" {
+			finishReason = chunk.FinishReason
+		}
+		if chunk.Usage != nil {
+			usage = toUsage(chunk.Usage)
+		}
+	}
+	if len(toolCalls) > 0 && finishReason == "stop" {
+		finishReason = "tool_calls"
+	}
+
+	writeJSON(w, http.StatusOK, chatCompletionResponse{
+		ID:      id,
+		Object:  "chat.completion",
+		Created: s.now().Unix(),
+		Model:   model,
+		Choices: []chatCompletionChoice{{
+			Message: &chatCompletionMessage{
+				Role:      "assistant",
+				Content:   content.String(),
+				ToolCalls: toolCalls,
+			},
+			FinishReason: &finishReason,
+		}},
+		Usage: usage,
+	})
+}
+
+// streamChatCompletion relays chunks as server-sent events terminated by [DONE].
+func (s *Server) streamChatCompletion(w http.ResponseWriter, id, model string, chunks <-chan chatports.ChatChunk) {
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+
+	created := s.now().Unix()
+	send := func(payload any) {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return
+		}
+		_, _ = fmt.Fprintf(w, "data: %s\n\n", data)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	send(chatCompletionResponse{
+		ID:      id,
+		Object:  "chat.completion.chunk",
+		Created: created,
+		Model:   model,
+		Choices: []chatCompletionChoice{{Delta: &chatCompletionMessage{Role: "assistant"}}},
+	})
+
+	toolCallCount := 0
+	finished := false
+	for chunk := range chunks {
+		if chunk.Error != "" {
+			send(map[string]apiError{"error": {Message: chunk.Error, Type: "api_error", Code: "upstream_error"}})
+			drain(chunks)
+			return
+		}
+		toolCalls := toToolCalls(chunk.ToolCalls)
+		for index := range toolCalls {
+			position := toolCallCount
+			toolCalls[index].Index = &position
+			toolCallCount++
+		}
+		choice := chatCompletionChoice{Delta: &chatCompletionMessage{Content: chunk.Content, ToolCalls: toolCalls}}
+		if chunk.FinishReason != "" {
+			finishReason := chunk.FinishReason
+			choice.FinishReason = &finishReason
+			finished = true
+		}
+		if chunk.Content == "" && len(toolCalls) == 0 && choice.FinishReason == nil && chunk.Usage == nil {
+			continue
+		}
+		send(chatCompletionResponse{
+			ID:      id,
+			Object:  "chat.completion.chunk",
+			Created: created,
+			Model:   model,
+			Choices: []chatCompletionChoice{choice},
+			Usage:   toUsage(chunk.Usage),
+		})
+	}
+
+	if !finished {
+		finishReason := "stop"
+		if toolCallCount > 0 {
+			finishReason = "tool_calls"
+		}
+		send(chatCompletionResponse{
+			ID:      id,
+			Object:  "chat.completion.chunk",
+			Created: created,
+			Model:   model,
+			Choices: []chatCompletionChoice{{Delta: &chatCompletionMessage{}, FinishReason: &finishReason}},
+		})
+	}
+	_, _ = fmt.Fprint(w, "data: [DONE]\n\n")
+	if flusher != nil {
+		flusher.Flush()
+	}
+}
+
+// toChatRequest converts an OpenAI chat completion request into a chat service request.
+func toChatRequest(body chatCompletionRequest) (chatports.ChatRequest, error) {
+
+	providerName, modelName, err := splitModel(body.Model)
+	if err != nil {
+		return chatports.ChatRequest{}, err
+	}
+	if len(body.Messages) == 0 {
+		return chatports.ChatRequest{}, fmt.Errorf("messages required")
+	}
+
+	messages := make([]chatports.ChatMessage, 0, len(body.Messages))
+	callIDs := make(map[string]bool)
+	for index, message := range body.Messages {
+		converted, err := toChatMessage(message, callIDs)
+		if err != nil {
+			return chatports.ChatRequest{}, fmt.Errorf("messages[%d]: %w", index, err)
+		}
+		messages = append(messages, converted)
+	}
+
+	stopWords, err := stopSequences(body.Stop)
+	if err != nil {
+		return chatports.ChatRequest{}, err
+	}
+
+	var tools []chatports.ChatTool
+	for _, tool := range body.Tools {
+		if tool.Type != "" && tool.Type != "function" {
+			return chatports.ChatRequest{}, fmt.Errorf("unsupported tool type: %s", tool.Type)
+		}
+		tools = append(tools, chatports.ChatTool{
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			InputSchema: tool.Function.Parameters,
+		})
+	}
+
+	return chatports.ChatRequest{
+		ProviderName: providerName,
+		ModelName:    modelName,
+		Messages:     messages,
+		Options: chatports.ChatOptions{
+			Temperature: body.Temperature,
+			MaxTokens:   body.MaxTokens,
+			Stream:      body.Stream,
+			StopWords:   stopWords,
+			Tools:       tools,
+		},
+	}, nil
+}
+
+// toChatMessage validates one message's role and converts it, carrying tool call IDs so a tool
+// result is sent as the answer to the assistant call it names. callIDs collects the IDs of
+// assistant tool calls seen so far.
+func toChatMessage(message chatMessageInput, callIDs map[string]bool) (chatports.ChatMessage, error) {
+
+	content, err := messageText(message.Content)
+	if err != nil {
+		return chatports.ChatMessage{}, err
+	}
+	role := chatports.ChatRole(strings.TrimSpace(message.Role))
+	converted := chatports.ChatMessage{Role: role, Content: content}
+
+	switch role {
+	case chatports.ChatRoleSystem, chatports.ChatRoleUser:
+	case chatports.ChatRoleAssistant:
+		for _, call := range message.ToolCalls {
+			parsed, err := fromToolCall(call)
+			if err != nil {
+				return chatports.ChatMessage{}, err
+			}
+			callIDs[parsed.ID] = true
+			converted.ToolCalls = append(converted.ToolCalls, parsed)
+		}
+	case chatports.ChatRoleTool:
+		converted.ToolCallID = strings.TrimSpace(message.ToolCallID)
+		if converted.ToolCallID == "" {
+			return chatports.ChatMessage{}, fmt.Errorf("tool_call_id required for role tool")
+		}
+		if !callIDs[converted.ToolCallID] {
+			return chatports.ChatMessage{}, fmt.Errorf("tool_call_id %s does not match an earlier assistant tool call", converted.ToolCallID)
+		}
+	default:
+		return chatports.ChatMessage{}, fmt.Errorf("unsupported role: %q", message.Role)
+	}
+	if role != chatports.ChatRoleAssistant && len(message.ToolCalls) > 0 {
+		return chatports.ChatMessage{}, fmt.Errorf("tool_calls are only allowed on assistant messages")
+	}
+	if role != chatports.ChatRoleTool && strings.TrimSpace(message.ToolCallID) != "" {
+		return chatports.ChatMessage{}, fmt.Errorf("tool_call_id is only allowed on tool messages")
+	}
+	return converted, nil
+}
+
+// fromToolCall converts an OpenAI function tool call from the request history into a chat service tool call.
+func fromToolCall(call chatToolCall) (chatports.ChatToolCall, error) {
+
+	if call.Type != "" && call.Type != "function" {
+		return chatports.ChatToolCall{}, fmt.Errorf("unsupported tool call type: %s", call.Type)
+	}
+	id := strings.TrimSpace(call.ID)
+	if id == "" || strings.TrimSpace(call.Function.Name) == "" {
+		return chatports.ChatToolCall{}, fmt.Errorf("tool calls require an id and a function name")
+	}
+
+	var arguments map[string]interface{}
+	if raw := strings.TrimSpace(call.Function.Arguments); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &arguments); err != nil {
+			return chatports.ChatToolCall{}, fmt.Errorf("tool call %s: arguments must be a JSON object", id)
+		}
+	}
+	return chatports.ChatToolCall{ID: id, Name: call.Function.Name, Arguments: arguments}, nil
+}
+
+// messageText flattens string or text-part message content.
+func messageText(raw json.RawMessage) (string, error) {
+
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text, nil
+	}
+
+	var parts []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return "", fmt.Errorf("content must be a string or an array of parts")
+	}
+	texts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part.Type != "text" {
+			return "", fmt.Errorf("unsupported content part type: %s", part.Type)
+		}
+		texts = append(texts, part.Text)
+	}
+	return strings.Join(texts, "\n"), nil
+}
+
+// stopSequences accepts stop as a single string or an array of strings.
+func stopSequences(raw json.RawMessage) ([]string, error) {
+
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return []string{single}, nil
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("stop must be a string or an array of strings")
+	}
+	return list, nil
+}
+
+// toToolCalls converts chat service tool calls into OpenAI tool calls.
+func toToolCalls(calls []chatports.ChatToolCall) []chatToolCall {
+
+	if len(calls) == 0 {
+		return nil
+	}
+
+	converted := make([]chatToolCall, 0, len(calls))
+	for _, call := range calls {
+		arguments := "{}"
+		if len(call.Arguments) > 0 {
+			if data, err := json.Marshal(call.Arguments); err == nil {
+				arguments = string(data)
+			}
+		}
+		toolCall := chatToolCall{ID: call.ID, Type: "function"}
+		toolCall.Function.Name = call.Name
+		toolCall.Function.Arguments = arguments
+		converted = append(converted, toolCall)
+	}
+	return converted
+}
+
+// toUsage converts chat service token accounting into OpenAI usage.
+func toUsage(usage *chatports.ChatUsage) *chatCompletionUsage {
+
+	if usage == nil {
+		return nil
+	}
+	total := usage.TotalTokens
+	if total == 0 {
+		total = usage.InputTokens + usage.OutputTokens
+	}
+	return &chatCompletionUsage{
+		PromptTokens:     usage.InputTokens,
+		CompletionTokens: usage.OutputTokens,
+		TotalTokens:      total,
+	}
+}
+
+// drain consumes remaining chunks so the producer goroutine can exit.
+func drain(chunks <-chan chatports.ChatChunk) {
+
+	for range chunks {
+	}
+}
@@ -0,0 +1,243 @@
+// endpoints.go implements the OpenAI-compatible model, image and embedding endpoints.
+// internal/ui/adapters/httpapi/endpoints.go
+package httpapi
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	embeddingports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/embedding/ports"
+	imageports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/image/ports"
+)
+
+// modelObject is one entry in the model list response.
+type modelObject struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
+
+// imageGenerationRequest is the supported subset of the OpenAI image generation request.
+type imageGenerationRequest struct {
+	Model          string `json:"model"`
+	Prompt         string `json:"prompt"`
+	N              int    `json:"n,omitempty"`
+	Size           string `json:"size,omitempty"`
+	Quality        string `json:"quality,omitempty"`
+	Style          string `json:"style,omitempty"`
+	ResponseFormat string `json:"response_format,omitempty"`
+	User           string `json:"user,omitempty"`
+}
+
+// imageObject is one generated image in the response.
+type imageObject struct {
+	B64JSON       string `json:"b64_json,omitempty"`
+	URL           string `json:"url,omitempty"`
+	RevisedPrompt string `json:"revised_prompt,omitempty"`
+}
+
+// embeddingRequest is the supported subset of the OpenAI embeddings request.
+type embeddingRequest struct {
+	Model          string          `json:"model"`
+	Input          json.RawMessage `json:"input"`
+	Dimensions     int             `json:"dimensions,omitempty"`
+	EncodingFormat string          `json:"encoding_format,omitempty"`
+}
+
+// embeddingObject is one vector in the embeddings response; Embedding is a float array or base64 string.
+type embeddingObject struct {
+	Object    string `json:"object"`
+	Index     int    `json:"index"`
+	Embedding any    `json:"embedding"`
+}
+
+// handleListModels lists the enabled models of connected provider instances under their provider/model identifiers,
+// the same registry chat requests are routed through.
+func (s *Server) handleListModels(w http.ResponseWriter, _ *http.Request) {
+
+	if s.services.Providers == nil {
+		writeError(w, http.StatusServiceUnavailable, "unavailable", "provider registry not configured")
+		return
+	}
+
+	seen := make(map[string]bool)
+	models := make([]modelObject, 0)
+	for _, provider := range s.services.Providers.GetProviders() {
+		if !provider.IsConnected || provider.Name == "" {
+			continue
+		}
+		for _, model := range provider.Models {
+			modelID := strings.TrimSpace(model.ID)
+			if modelID == "" {
+				continue
+			}
+			id := joinModel(provider.Name, modelID)
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			models = append(models, modelObject{ID: id, Object: "model", OwnedBy: provider.Name})
+		}
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].ID < models[j].ID })
+
+	writeJSON(w, http.StatusOK, map[string]any{"object": "list", "data": models})
+}
+
+// handleImageGenerations generates an image through the image service.
+// The image service returns one image per request, so n above 1 is rejected rather than answered short.
+func (s *Server) handleImageGenerations(w http.ResponseWriter, r *http.Request) {
+
+	if s.services.Images == nil {
+		writeError(w, http.StatusServiceUnavailable, "unavailable", "image service not configured")
+		return
+	}
+
+	var body imageGenerationRequest
+	if err := decodeRequest(w, r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	providerName, modelName, err := splitModel(body.Model)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	if strings.TrimSpace(body.Prompt) == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "prompt required")
+		return
+	}
+	if body.N > 1 {
+		writeError(w, http.StatusBadRequest, "invalid_request", "n must be 1: only one image is generated per request")
+		return
+	}
+	if body.ResponseFormat != "" && body.ResponseFormat != "b64_json" && body.ResponseFormat != "url" {
+		writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("unsupported response_format: %s", body.ResponseFormat))
+		return
+	}
+
+	result, err := s.services.Images.GenerateImage(r.Context(), imageports.GenerateImageRequest{
+		ProviderName: providerName,
+		ModelName:    modelName,
+		Prompt:       body.Prompt,
+		N:            body.N,
+		Size:         body.Size,
+		Quality:      body.Quality,
+		Style:        body.Style,
+		User:         body.User,
+	})
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "upstream_error", err.Error())
+		return
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(result.Bytes)
+	image := imageObject{B64JSON: encoded, RevisedPrompt: result.RevisedPrompt}
+	if body.ResponseFormat == "url" {
+		// No files are hosted, so URL responses carry the image inline as a data URL.
+		image = imageObject{
+			URL:           "data:" + http.DetectContentType(result.Bytes) + ";base64," + encoded,
+			RevisedPrompt: result.RevisedPrompt,
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"created": s.now().Unix(),
+		"data":    []imageObject{image},
+	})
+}
+
+// handleEmbeddings embeds one or more texts through the embedding service.
+func (s *Server) handleEmbeddings(w http.ResponseWriter, r *http.Request) {
+
+	if s.services.Embeddings == nil {
+		writeError(w, http.StatusServiceUnavailable, "unavailable", "embedding service not configured")
+		return
+	}
+
+	var body embeddingRequest
+	if err := decodeRequest(w, r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	providerName, modelName, err := splitModel(body.Model)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	input, err := embeddingInput(body.Input)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	if body.EncodingFormat != "" && body.EncodingFormat != "float" && body.EncodingFormat != "base64" {
+		writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("unsupported encoding_format: %s", body.EncodingFormat))
+		return
+	}
+
+	result, err := s.services.Embeddings.Embed(r.Context(), embeddingports.EmbedRequest{
+		ProviderName: providerName,
+		ModelName:    modelName,
+		Input:        input,
+		Dimensions:   body.Dimensions,
+	})
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "upstream_error", err.Error())
+		return
+	}
+
+	data := make([]embeddingObject, 0, len(result.Embeddings))
+	for index, vector := range result.Embeddings {
+		var embedding any = vector
+		if body.EncodingFormat == "base64" {
+			embedding = encodeVector(vector)
+		}
+		data = append(data, embeddingObject{Object: "embedding", Index: index, Embedding: embedding})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"object": "list",
+		"data":   data,
+		"model":  body.Model,
+		"usage": map[string]int{
+			"prompt_tokens": result.PromptTokens,
+			"total_tokens":  result.TotalTokens,
+		},
+	})
+}
+
+// embeddingInput accepts input as a single string or an array of strings.
+func embeddingInput(raw json.RawMessage) ([]string, error) {
+
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		if strings.TrimSpace(single) == "" {
+			return nil, fmt.Errorf("input required")
+		}
+		return []string{single}, nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("input must be a string or an array of strings")
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("input required")
+	}
+	return list, nil
+}
+
+// encodeVector encodes a vector as base64 little-endian float32s, matching the OpenAI wire format.
+func encodeVector(vector []float32) string {
+
+	buffer := make([]byte, 4*len(vector))
+	for index, value := range vector {
+		binary.LittleEndian.PutUint32(buffer[4*index:], math.Float32bits(value))
+	}
+	return base64.StdEncoding.EncodeToString(buffer)
+}
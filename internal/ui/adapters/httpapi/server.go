@@ -0,0 +1,204 @@
+// server.go serves an OpenAI-compatible HTTP API backed by the application facade.
+// internal/ui/adapters/httpapi/server.go
+package httpapi
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	chatports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/ports"
+	embeddingports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/embedding/ports"
+	imageports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/image/ports"
+	providerfeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/app/provider"
+)
+
+// EnvToken names the environment variable holding the API bearer token.
+const EnvToken = "WLS_SERVE_TOKEN"
+
+// maxRequestBytes bounds request bodies so a misbehaving client cannot exhaust memory.
+const maxRequestBytes = 16 << 20
+
+// Services groups the feature ports exposed over HTTP.
+type Services struct {
+	Chat       chatports.ChatInterface
+	Images     imageports.ImageInterface
+	Embeddings embeddingports.EmbeddingInterface
+	Providers  ProviderLister
+}
+
+// ProviderLister lists the configured provider instances that chat requests are routed to.
+type ProviderLister interface {
+	GetProviders() []providerfeature.Info
+}
+
+// Server routes OpenAI-style requests to application services.
+type Server struct {
+	services Services
+	token    string
+	logger   corelogger.Logger
+	now      func() time.Time
+}
+
+// NewServer creates an API server that requires the given bearer token on every request.
+func NewServer(services Services, token string, logger corelogger.Logger) (*Server, error) {
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, fmt.Errorf("http api: bearer token required")
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("http api: logger required")
+	}
+	return &Server{
+		services: services,
+		token:    token,
+		logger:   logger,
+		now:      time.Now,
+	}, nil
+}
+
+// GenerateToken returns a random bearer token for servers started without one.
+func GenerateToken() (string, error) {
+
+	buffer := make([]byte, 24)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", fmt.Errorf("http api: generate token: %w", err)
+	}
+	return "wls-" + hex.EncodeToString(buffer), nil
+}
+
+// Handler returns the authenticated, request-logging HTTP handler.
+func (s *Server) Handler() http.Handler {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/chat/completions", s.handleChatCompletions)
+	mux.HandleFunc("GET /v1/models", s.handleListModels)
+	mux.HandleFunc("POST /v1/images/generations", s.handleImageGenerations)
+	mux.HandleFunc("POST /v1/embeddings", s.handleEmbeddings)
+	return s.logRequests(s.authenticate(mux))
+}
+
+// authenticate rejects requests without the configured bearer token.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+
+	expected := []byte("Bearer " + s.token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provided := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(provided, expected) != 1 {
+			writeError(w, http.StatusUnauthorized, "invalid_api_key", "missing or invalid bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// logRequests records method, path, status and duration for every request.
+func (s *Server) logRequests(next http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started := s.now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		s.logger.Info("HTTP API request",
+			corelogger.LogField{Key: "method", Value: r.Method},
+			corelogger.LogField{Key: "path", Value: r.URL.Path},
+			corelogger.LogField{Key: "status", Value: strconv.Itoa(recorder.status)},
+			corelogger.LogField{Key: "duration", Value: s.now().Sub(started).String()},
+		)
+	})
+}
+
+// statusRecorder captures the response status while keeping streaming support.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+// WriteHeader records the status before forwarding it.
+func (r *statusRecorder) WriteHeader(status int) {
+
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write marks the header as written before forwarding the body.
+func (r *statusRecorder) Write(data []byte) (int, error) {
+
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(data)
+}
+
+// Flush forwards flushes so server-sent events reach the client promptly.
+func (r *statusRecorder) Flush() {
+
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// apiError is the OpenAI error envelope body.
+type apiError struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+	Code    string `json:"code,omitempty"`
+}
+
+// writeError writes an OpenAI-style error envelope.
+func writeError(w http.ResponseWriter, status int, code, message string) {
+
+	errorType := "invalid_request_error"
+	if status >= http.StatusInternalServerError {
+		errorType = "api_error"
+	}
+	writeJSON(w, status, map[string]apiError{
+		"error": {Message: message, Type: errorType, Code: code},
+	})
+}
+
+// writeJSON writes a JSON response body with the given status.
+func writeJSON(w http.ResponseWriter, status int, body any) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// decodeRequest decodes a bounded JSON request body.
+func decodeRequest(w http.ResponseWriter, r *http.Request, target any) error {
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBytes)
+	if err := json.NewDecoder(r.Body).Decode(target); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+// splitModel splits a "provider/model" identifier; model names may contain further slashes.
+func splitModel(value string) (string, string, error) {
+
+	providerName, modelName, found := strings.Cut(strings.TrimSpace(value), "/")
+	providerName = strings.TrimSpace(providerName)
+	modelName = strings.TrimSpace(modelName)
+	if !found || providerName == "" || modelName == "" {
+		return "", "", fmt.Errorf("model must be in provider/model form: %q", value)
+	}
+	return providerName, modelName, nil
+}
+
+// joinModel builds the public "provider/model" identifier.
+func joinModel(providerName, modelName string) string {
+
+	return providerName + "/" + modelName
+}
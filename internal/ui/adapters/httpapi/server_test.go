@@ -0,0 +1,438 @@
+// server_test.go verifies authentication, routing and OpenAI wire mapping of the HTTP API.
+// internal/ui/adapters/httpapi/server_test.go
+package httpapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	chatports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/chat/ports"
+	embeddingports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/embedding/ports"
+	imageports "github.com/MadeByDoug/wls-chatbot/internal/features/ai/image/ports"
+	providerfeature "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/app/provider"
+	providercore "github.com/MadeByDoug/wls-chatbot/internal/features/ai/providers/ports/core"
+)
+
+const testToken = "secret-token"
+
+// fakeChat records the last chat request and replays configured chunks.
+type fakeChat struct {
+	request chatports.ChatRequest
+	chunks  []chatports.ChatChunk
+}
+
+// Chat records the request and streams the configured chunks.
+func (f *fakeChat) Chat(_ context.Context, request chatports.ChatRequest) (<-chan chatports.ChatChunk, error) {
+
+	f.request = request
+	out := make(chan chatports.ChatChunk, len(f.chunks))
+	for _, chunk := range f.chunks {
+		out <- chunk
+	}
+	close(out)
+	return out, nil
+}
+
+// fakeImages records the last generation request and returns fixed bytes.
+type fakeImages struct {
+	imageports.ImageInterface
+	request imageports.GenerateImageRequest
+}
+
+// GenerateImage records the request and returns a tiny PNG header.
+func (f *fakeImages) GenerateImage(_ context.Context, request imageports.GenerateImageRequest) (imageports.ImageBinaryResult, error) {
+
+	f.request = request
+	return imageports.ImageBinaryResult{Bytes: []byte("\x89PNG\r\n\x1a\n"), RevisedPrompt: "a red fox"}, nil
+}
+
+// fakeEmbeddings records the last embedding request and returns one vector per input.
+type fakeEmbeddings struct {
+	request embeddingports.EmbedRequest
+}
+
+// Embed records the request and returns constant vectors.
+func (f *fakeEmbeddings) Embed(_ context.Context, request embeddingports.EmbedRequest) (embeddingports.EmbedResult, error) {
+
+	f.request = request
+	vectors := make([][]float32, len(request.Input))
+	for index := range vectors {
+		vectors[index] = []float32{1, 0.5}
+	}
+	return embeddingports.EmbedResult{Model: request.ModelName, Dimensions: 2, Embeddings: vectors, PromptTokens: 3, TotalTokens: 3}, nil
+}
+
+// fakeProviders returns fixed provider instances.
+type fakeProviders struct {
+	providers []providerfeature.Info
+}
+
+// GetProviders returns the configured providers.
+func (f *fakeProviders) GetProviders() []providerfeature.Info {
+
+	return f.providers
+}
+
+// recordingLogger captures info messages.
+type recordingLogger struct {
+	messages []string
+}
+
+// Trace is ignored.
+func (l *recordingLogger) Trace(string, ...corelogger.LogField) {
+
+}
+
+// Debug is ignored.
+func (l *recordingLogger) Debug(string, ...corelogger.LogField) {
+
+}
+
+// Info records the message with its fields.
+func (l *recordingLogger) Info(message string, fields ...corelogger.LogField) {
+
+	parts := []string{message}
+	for _, field := range fields {
+		parts = append(parts, field.Key+"="+field.Value)
+	}
+	l.messages = append(l.messages, strings.Join(parts, " "))
+}
+
+// Warn is ignored.
+func (l *recordingLogger) Warn(string, error, ...corelogger.LogField) {
+
+}
+
+// Error is ignored.
+func (l *recordingLogger) Error(string, error, ...corelogger.LogField) {
+
+}
+
+// newTestServer builds a server over the given services.
+func newTestServer(t *testing.T, services Services) (http.Handler, *recordingLogger) {
+
+	t.Helper()
+	logger := &recordingLogger{}
+	server, err := NewServer(services, testToken, logger)
+	if err != nil {
+		t.Fatalf("new server: %v", err)
+	}
+	return server.Handler(), logger
+}
+
+// serve sends one authenticated request and returns the recorded response.
+func serve(handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	request.Header.Set("Authorization", "Bearer "+testToken)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// TestNewServerRequiresToken validates that the server never runs unauthenticated.
+func TestNewServerRequiresToken(t *testing.T) {
+
+	if _, err := NewServer(Services{}, " ", &recordingLogger{}); err == nil {
+		t.Fatalf("expected error for empty token")
+	}
+}
+
+// TestHandlerRejectsMissingOrWrongToken validates bearer-token checks and request logging.
+func TestHandlerRejectsMissingOrWrongToken(t *testing.T) {
+
+	testCases := []struct {
+		name   string
+		header string
+	}{
+		{name: "missing", header: ""},
+		{name: "wrong", header: "Bearer nope"},
+		{name: "not bearer", header: testToken},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			handler, logger := newTestServer(t, Services{Providers: &fakeProviders{}})
+			request := httptest.NewRequest(http.MethodGet, "/v1/models", nil)
+			if testCase.header != "" {
+				request.Header.Set("Authorization", testCase.header)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != http.StatusUnauthorized || !strings.Contains(recorder.Body.String(), "invalid_api_key") {
+				t.Fatalf("unexpected response %d %s", recorder.Code, recorder.Body.String())
+			}
+			if len(logger.messages) != 1 || !strings.Contains(logger.messages[0], "status=401") {
+				t.Fatalf("unexpected log %#v", logger.messages)
+			}
+		})
+	}
+}
+
+// TestChatCompletionsCollectsResponse validates request mapping and non-streaming aggregation.
+func TestChatCompletionsCollectsResponse(t *testing.T) {
+
+	chat := &fakeChat{chunks: []chatports.ChatChunk{
+		{Content: "Hello"},
+		{Content: " there", FinishReason: "stop", Usage: &chatports.ChatUsage{InputTokens: 4, OutputTokens: 2}},
+	}}
+	handler, _ := newTestServer(t, Services{Chat: chat})
+
+	recorder := serve(handler, http.MethodPost, "/v1/chat/completions", `{
+		"model": "cloudflare/@cf/meta/llama-3-8b",
+		"messages": [
+			{"role": "system", "content": "Be brief."},
+			{"role": "user", "content": [{"type": "text", "text": "Hi"}]}
+		],
+		"temperature": 0.2,
+		"max_tokens": 32,
+		"stop": "END"
+	}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d %s", recorder.Code, recorder.Body.String())
+	}
+
+	request := chat.request
+	if request.ProviderName != "cloudflare" || request.ModelName != "@cf/meta/llama-3-8b" {
+		t.Fatalf("unexpected routing %#v", request)
+	}
+	if len(request.Messages) != 2 || request.Messages[1].Content != "Hi" || request.Messages[0].Role != chatports.ChatRoleSystem {
+		t.Fatalf("unexpected messages %#v", request.Messages)
+	}
+	if request.Options.Temperature != 0.2 || request.Options.MaxTokens != 32 || len(request.Options.StopWords) != 1 || request.Options.Stream {
+		t.Fatalf("unexpected options %#v", request.Options)
+	}
+
+	var response chatCompletionResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if response.Object != "chat.completion" || response.Model != "cloudflare/@cf/meta/llama-3-8b" || len(response.Choices) != 1 {
+		t.Fatalf("unexpected response %#v", response)
+	}
+	choice := response.Choices[0]
+	if choice.Message == nil || choice.Message.Content != "Hello there" || choice.FinishReason == nil || *choice.FinishReason != "stop" {
+		t.Fatalf("unexpected choice %#v", choice)
+	}
+	if response.Usage == nil || response.Usage.TotalTokens != 6 {
+		t.Fatalf("unexpected usage %#v", response.Usage)
+	}
+}
+
+// TestChatCompletionsStreamsServerSentEvents validates SSE framing, tool calls and the done marker.
+func TestChatCompletionsStreamsServerSentEvents(t *testing.T) {
+
+	chat := &fakeChat{chunks: []chatports.ChatChunk{
+		{Content: "Checking"},
+		{ToolCalls: []chatports.ChatToolCall{{ID: "call_1", Name: "lookup", Arguments: map[string]interface{}{"q": "fox"}}}},
+	}}
+	handler, _ := newTestServer(t, Services{Chat: chat})
+
+	recorder := serve(handler, http.MethodPost, "/v1/chat/completions", `{"model":"openai/gpt-4o-mini","stream":true,"messages":[{"role":"user","content":"Hi"}]}`)
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected response %d %q", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	if !chat.request.Options.Stream {
+		t.Fatalf("expected streaming request")
+	}
+
+	var events []string
+	for _, line := range strings.Split(recorder.Body.String(), "\n") {
+		if strings.HasPrefix(line, "data: ") {
+			events = append(events, strings.TrimPrefix(line, "data: "))
+		}
+	}
+	if len(events) != 5 || events[len(events)-1] != "[DONE]" {
+		t.Fatalf("unexpected events %#v", events)
+	}
+
+	var toolChunk chatCompletionResponse
+	if err := json.Unmarshal([]byte(events[2]), &toolChunk); err != nil {
+		t.Fatalf("decode tool chunk: %v", err)
+	}
+	calls := toolChunk.Choices[0].Delta.ToolCalls
+	if len(calls) != 1 || calls[0].Index == nil || *calls[0].Index != 0 || calls[0].Function.Arguments != `{"q":"fox"}` {
+		t.Fatalf("unexpected tool calls %#v", calls)
+	}
+
+	var finalChunk chatCompletionResponse
+	if err := json.Unmarshal([]byte(events[3]), &finalChunk); err != nil {
+		t.Fatalf("decode final chunk: %v", err)
+	}
+	if reason := finalChunk.Choices[0].FinishReason; reason == nil || *reason != "tool_calls" {
+		t.Fatalf("unexpected finish reason %#v", finalChunk.Choices[0])
+	}
+}
+
+// TestChatCompletionsRejectsInvalidRequests validates model naming and content checks.
+func TestChatCompletionsRejectsInvalidRequests(t *testing.T) {
+
+	testCases := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "bare model", body: `{"model":"gpt-4o","messages":[{"role":"user","content":"Hi"}]}`, want: "provider/model"},
+		{name: "no messages", body: `{"model":"openai/gpt-4o","messages":[]}`, want: "messages required"},
+		{name: "image part", body: `{"model":"openai/gpt-4o","messages":[{"role":"user","content":[{"type":"image_url"}]}]}`, want: "unsupported content part"},
+		{name: "malformed", body: `{`, want: "invalid request body"},
+		{name: "unknown role", body: `{"model":"openai/gpt-4o","messages":[{"role":"developer","content":"Hi"}]}`, want: "unsupported role"},
+		{name: "tool without id", body: `{"model":"openai/gpt-4o","messages":[{"role":"tool","content":"42"}]}`, want: "tool_call_id required"},
+		{name: "tool with unknown id", body: `{"model":"openai/gpt-4o","messages":[{"role":"tool","tool_call_id":"call_9","content":"42"}]}`, want: "does not match"},
+		{name: "user tool calls", body: `{"model":"openai/gpt-4o","messages":[{"role":"user","content":"Hi","tool_calls":[{"id":"call_1","type":"function","function":{"name":"f","arguments":"{}"}}]}]}`, want: "only allowed on assistant"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			handler, _ := newTestServer(t, Services{Chat: &fakeChat{}})
+			recorder := serve(handler, http.MethodPost, "/v1/chat/completions", testCase.body)
+			if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), testCase.want) {
+				t.Fatalf("unexpected response %d %s", recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
+
+// TestChatCompletionsCarriesToolRoundTrip validates assistant tool calls and tool results keep their IDs and roles.
+func TestChatCompletionsCarriesToolRoundTrip(t *testing.T) {
+
+	chat := &fakeChat{chunks: []chatports.ChatChunk{{Content: "It is sunny.", FinishReason: "stop"}}}
+	handler, _ := newTestServer(t, Services{Chat: chat})
+
+	recorder := serve(handler, http.MethodPost, "/v1/chat/completions", `{
+		"model": "ollama/llama3.2",
+		"messages": [
+			{"role": "user", "content": "Weather in Oslo?"},
+			{"role": "assistant", "content": null, "tool_calls": [{"id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\":\"Oslo\"}"}}]},
+			{"role": "tool", "tool_call_id": "call_1", "content": "sunny"}
+		]
+	}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d %s", recorder.Code, recorder.Body.String())
+	}
+
+	messages := chat.request.Messages
+	if len(messages) != 3 {
+		t.Fatalf("unexpected messages %#v", messages)
+	}
+	calls := messages[1].ToolCalls
+	if messages[1].Role != chatports.ChatRoleAssistant || len(calls) != 1 || calls[0].ID != "call_1" || calls[0].Name != "get_weather" || calls[0].Arguments["city"] != "Oslo" {
+		t.Fatalf("unexpected assistant message %#v", messages[1])
+	}
+	if messages[2].Role != chatports.ChatRoleTool || messages[2].ToolCallID != "call_1" || messages[2].Content != "sunny" {
+		t.Fatalf("unexpected tool message %#v", messages[2])
+	}
+}
+
+// TestListModelsUsesProviderPrefixedIDs validates models come from connected instances, with id formatting and de-duplication.
+func TestListModelsUsesProviderPrefixedIDs(t *testing.T) {
+
+	providers := &fakeProviders{providers: []providerfeature.Info{
+		{Name: "openai-work", Type: "openai", IsConnected: true, Models: []providercore.Model{{ID: "gpt-4o"}, {ID: "gpt-4o"}}},
+		{Name: "ollama", Type: "ollama", IsConnected: true, Models: []providercore.Model{{ID: "llama3.2:latest"}}},
+		{Name: "anthropic", Type: "anthropic", Models: []providercore.Model{{ID: "claude-sonnet-4"}}},
+	}}
+	handler, _ := newTestServer(t, Services{Providers: providers})
+
+	recorder := serve(handler, http.MethodGet, "/v1/models", "")
+	var response struct {
+		Object string        `json:"object"`
+		Data   []modelObject `json:"data"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if response.Object != "list" || len(response.Data) != 2 || response.Data[0].ID != "ollama/llama3.2:latest" || response.Data[1].ID != "openai-work/gpt-4o" || response.Data[1].OwnedBy != "openai-work" {
+		t.Fatalf("unexpected models %#v", response)
+	}
+}
+
+// TestImageGenerationsReturnsBase64 validates image routing and b64_json output.
+func TestImageGenerationsReturnsBase64(t *testing.T) {
+
+	images := &fakeImages{}
+	handler, _ := newTestServer(t, Services{Images: images})
+
+	recorder := serve(handler, http.MethodPost, "/v1/images/generations", `{"model":"openai/gpt-image-1","prompt":"a fox","size":"1024x1024"}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d %s", recorder.Code, recorder.Body.String())
+	}
+	if images.request.ProviderName != "openai" || images.request.ModelName != "gpt-image-1" || images.request.Size != "1024x1024" {
+		t.Fatalf("unexpected request %#v", images.request)
+	}
+
+	var response struct {
+		Data []imageObject `json:"data"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(response.Data[0].B64JSON)
+	if err != nil || string(decoded) != "\x89PNG\r\n\x1a\n" || response.Data[0].RevisedPrompt != "a red fox" {
+		t.Fatalf("unexpected image %#v", response.Data)
+	}
+}
+
+// TestImageGenerationsRejectsMultipleImages validates n above 1 fails instead of returning fewer images.
+func TestImageGenerationsRejectsMultipleImages(t *testing.T) {
+
+	images := &fakeImages{}
+	handler, _ := newTestServer(t, Services{Images: images})
+
+	recorder := serve(handler, http.MethodPost, "/v1/images/generations", `{"model":"openai/gpt-image-1","prompt":"a fox","n":2}`)
+	if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), "n must be 1") {
+		t.Fatalf("unexpected response %d %s", recorder.Code, recorder.Body.String())
+	}
+	if images.request.Prompt != "" {
+		t.Fatalf("expected no generation request, got %#v", images.request)
+	}
+}
+
+// TestEmbeddingsAcceptsStringOrList validates input forms and base64 encoding.
+func TestEmbeddingsAcceptsStringOrList(t *testing.T) {
+
+	testCases := []struct {
+		name      string
+		body      string
+		wantInput int
+		wantKind  string
+	}{
+		{name: "single string", body: `{"model":"openai/text-embedding-3-small","input":"hello"}`, wantInput: 1, wantKind: "[1,0.5]"},
+		{name: "list", body: `{"model":"openai/text-embedding-3-small","input":["a","b"]}`, wantInput: 2, wantKind: "[1,0.5]"},
+		{name: "base64", body: `{"model":"openai/text-embedding-3-small","input":"hello","encoding_format":"base64"}`, wantInput: 1, wantKind: `"AACAPwAAAD8="`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			embeddings := &fakeEmbeddings{}
+			handler, _ := newTestServer(t, Services{Embeddings: embeddings})
+
+			recorder := serve(handler, http.MethodPost, "/v1/embeddings", testCase.body)
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("unexpected status %d %s", recorder.Code, recorder.Body.String())
+			}
+			if embeddings.request.ProviderName != "openai" || len(embeddings.request.Input) != testCase.wantInput {
+				t.Fatalf("unexpected request %#v", embeddings.request)
+			}
+
+			var response struct {
+				Data []struct {
+					Embedding json.RawMessage `json:"embedding"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if len(response.Data) != testCase.wantInput || string(response.Data[0].Embedding) != testCase.wantKind {
+				t.Fatalf("unexpected data %s", recorder.Body.String())
+			}
+		})
+	}
+}
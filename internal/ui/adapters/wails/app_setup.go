@@ -12,7 +12,7 @@ import (
 )
 
 // setupApp wires shared app services and Wails bridge adapters.
-func setupApp(log zerolog.Logger, cfg config.AppConfig, db *sql.DB, appName string, keyringServiceName string) (*Bridge, *wailslogger.Logger, error) {
+func setupApp(log zerolog.Logger, cfg config.AppConfig, configSource config.FileSource, db *sql.DB, appName string, keyringServiceName string) (*Bridge, *wailslogger.Logger, error) {
 
 	emitter := &Emitter{}
 	applicationFacade, err := appwire.NewApp(appwire.Dependencies{
@@ -22,6 +22,7 @@ func setupApp(log zerolog.Logger, cfg config.AppConfig, db *sql.DB, appName stri
 		AppName:            appName,
 		KeyringServiceName: keyringServiceName,
 		Events:             emitter,
+		ConfigSource:       configSource,
 	})
 	if err != nil {
 		return nil, nil, err
@@ -29,6 +29,36 @@ func (b *Bridge) EditImage(request imageports.EditImageRequest) (imageports.Imag
 	return b.app.Images.EditImage(b.ctxOrBackground(), request)
 }
 
+// VaryImage creates an image variation using the shared backend interface.
+func (b *Bridge) VaryImage(request imageports.VaryImageRequest) (imageports.ImageBinaryResult, error) {
+
+	if b.app == nil || b.app.Images == nil {
+		return imageports.ImageBinaryResult{}, fmt.Errorf("backend interface not configured")
+	}
+
+	return b.app.Images.VaryImage(b.ctxOrBackground(), request)
+}
+
+// UpscaleImage upscales an image using the shared backend interface.
+func (b *Bridge) UpscaleImage(request imageports.UpscaleImageRequest) (imageports.ImageBinaryResult, error) {
+
+	if b.app == nil || b.app.Images == nil {
+		return imageports.ImageBinaryResult{}, fmt.Errorf("backend interface not configured")
+	}
+
+	return b.app.Images.UpscaleImage(b.ctxOrBackground(), request)
+}
+
+// BuildImageMask renders an edit mask using the shared backend interface.
+func (b *Bridge) BuildImageMask(request imageports.BuildMaskRequest) (imageports.MaskResult, error) {
+
+	if b.app == nil || b.app.Images == nil {
+		return imageports.MaskResult{}, fmt.Errorf("backend interface not configured")
+	}
+
+	return b.app.Images.BuildMask(b.ctxOrBackground(), request)
+}
+
 // ListModels lists model catalog entries using the shared backend interface.
 func (b *Bridge) ListModels(source string) ([]modelinterfaces.ModelSummary, error) {
 
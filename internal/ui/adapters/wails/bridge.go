@@ -55,6 +55,14 @@ func (b *Bridge) Startup(ctx context.Context) {
 	if b.emitter != nil {
 		b.emitter.SetContext(appCtx)
 	}
+
+	if b.app != nil && b.app.ConfigWatch != nil {
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			b.app.ConfigWatch.Watch(appCtx)
+		}()
+	}
 }
 
 // Shutdown is called by Wails when the application shuts down.
@@ -74,6 +82,11 @@ func (b *Bridge) Shutdown(context.Context) {
 		cancel()
 	}
 	b.wg.Wait()
+
+	// MCP stdio servers are child processes; closing their sessions stops them with the app.
+	if b.app != nil && b.app.MCP != nil {
+		_ = b.app.MCP.Close()
+	}
 }
 
 // ctxOrBackground returns the app context or a canceled context when lifecycle context is unavailable.
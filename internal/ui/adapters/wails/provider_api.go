@@ -28,6 +28,15 @@ func (b *Bridge) ConnectProvider(name string, credentials providercore.ProviderC
 	return b.app.Providers.ConnectProvider(b.ctxOrBackground(), name, credentials)
 }
 
+// CloneProvider creates a new named instance of an existing provider, optionally copying its secrets.
+func (b *Bridge) CloneProvider(source, name, displayName string, copyCredentials bool) (providerfeature.Info, error) {
+
+	if b.app == nil || b.app.Providers == nil {
+		return providerfeature.Info{}, fmt.Errorf("provider interface not configured")
+	}
+	return b.app.Providers.CloneProvider(source, name, displayName, copyCredentials)
+}
+
 // ConfigureProvider updates a provider's credentials without full connection flow.
 func (b *Bridge) ConfigureProvider(name string, credentials providercore.ProviderCredentials) error {
 
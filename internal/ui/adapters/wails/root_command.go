@@ -40,7 +40,7 @@ func NewCommand(deps Dependencies) *cobra.Command {
 				return fmt.Errorf("wails adapter: %w", err)
 			}
 			deps.BaseLogger.Info().Msg("Starting Wails Lit Starter ChatBot UI...")
-			if err := runUI(deps.BaseLogger, deps.Config, deps.DB, deps.Assets, deps.AppName, deps.KeyringServiceName); err != nil {
+			if err := runUI(deps.BaseLogger, deps.Config, deps.ConfigSource, deps.DB, deps.Assets, deps.AppName, deps.KeyringServiceName); err != nil {
 				return fmt.Errorf("run UI: %w", err)
 			}
 			return nil
@@ -17,6 +17,15 @@ func (b *Bridge) SendMessage(conversationID, content string) (*chatdomain.Messag
 	return b.app.Conversations.SendMessage(b.ctxOrBackground(), conversationID, content)
 }
 
+// SendVoiceMessage transcribes a voice attachment into a user message and initiates a streaming response.
+func (b *Bridge) SendVoiceMessage(conversationID, content, audioPath, providerName string) (*chatdomain.Message, error) {
+
+	if b.app == nil || b.app.Conversations == nil {
+		return nil, fmt.Errorf("chat orchestrator not configured")
+	}
+	return b.app.Conversations.SendVoiceMessage(b.ctxOrBackground(), conversationID, content, audioPath, providerName)
+}
+
 // StopStream cancels the currently running stream.
 func (b *Bridge) StopStream() {
 
@@ -11,9 +11,9 @@ import (
 )
 
 // runUI launches Wails after resolving bridge dependencies from shared setup.
-func runUI(log zerolog.Logger, cfg config.AppConfig, db *sql.DB, assets fs.FS, appName string, keyringServiceName string) error {
+func runUI(log zerolog.Logger, cfg config.AppConfig, configSource config.FileSource, db *sql.DB, assets fs.FS, appName string, keyringServiceName string) error {
 
-	bridgeService, logBridge, err := setupApp(log, cfg, db, appName, keyringServiceName)
+	bridgeService, logBridge, err := setupApp(log, cfg, configSource, db, appName, keyringServiceName)
 	if err != nil {
 		return err
 	}
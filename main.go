@@ -36,6 +36,8 @@ func main() {
 	}
 	var dbPath string
 	var logLevel string
+	var configPath string
+	var profile string
 
 	root := &cobra.Command{
 		Use:          AppName,
@@ -50,12 +52,17 @@ func main() {
 				resolvedLevel = commonDependencies.DefaultLogLevel
 			}
 			commonDependencies.BaseLogger = logger.New(resolvedLevel)
-			db, cfg, err := loadCommandEnvironment(dbPath)
+			configSource, err := resolveConfigSource(configPath, profile)
+			if err != nil {
+				return err
+			}
+			db, cfg, err := loadCommandEnvironment(dbPath, configSource)
 			if err != nil {
 				return err
 			}
 			commonDependencies.DB = db
 			commonDependencies.Config = cfg
+			commonDependencies.ConfigSource = configSource
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -70,6 +77,8 @@ func main() {
 	}
 	root.PersistentFlags().StringVar(&dbPath, "db-path", "", "Path to the SQLite database file")
 	root.PersistentFlags().StringVar(&logLevel, "log-level", commonDependencies.DefaultLogLevel, "Log level (debug, info, warn, error)")
+	root.PersistentFlags().StringVar(&configPath, "config", "", "Path to a YAML config file (defaults to $"+config.EnvConfigFile+" or config.yaml in the app data directory)")
+	root.PersistentFlags().StringVar(&profile, "profile", "", "Config file profile to apply (defaults to $"+config.EnvConfigProfile+")")
 
 	root.AddCommand(cliadapter.NewCommand(cliadapter.Dependencies{
 		Dependencies: commonDependencies,
@@ -90,7 +99,7 @@ func main() {
 }
 
 // loadCommandEnvironment opens shared command dependencies from CLI flags.
-func loadCommandEnvironment(dbPath string) (*sql.DB, config.AppConfig, error) {
+func loadCommandEnvironment(dbPath string, configSource config.FileSource) (*sql.DB, config.AppConfig, error) {
 
 	databasePath, err := resolveDatabasePath(dbPath)
 	if err != nil {
@@ -108,7 +117,7 @@ func loadCommandEnvironment(dbPath string) (*sql.DB, config.AppConfig, error) {
 		return nil, config.AppConfig{}, err
 	}
 
-	cfg, err := config.LoadConfig(store)
+	cfg, err := config.LoadWithFile(store, configSource)
 	if err != nil {
 		_ = db.Close()
 		return nil, config.AppConfig{}, err
@@ -117,6 +126,16 @@ func loadCommandEnvironment(dbPath string) (*sql.DB, config.AppConfig, error) {
 	return db, cfg, nil
 }
 
+// resolveConfigSource resolves the declarative config file and profile from flags and environment.
+func resolveConfigSource(configPath, profile string) (config.FileSource, error) {
+
+	appDataDir, err := platform.ResolveAppDataDir(AppName)
+	if err != nil {
+		return config.FileSource{}, err
+	}
+	return config.ResolveFileSource(configPath, profile, appDataDir), nil
+}
+
 // resolveDatabasePath resolves the SQLite database path to use.
 func resolveDatabasePath(override string) (string, error) {
 
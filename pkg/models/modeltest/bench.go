@@ -0,0 +1,440 @@
+// bench.go measures streamed latency and throughput for planned models.
+// pkg/models/modeltest/bench.go
+package modeltest
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BenchmarkSource is the system_profile_source recorded for benchmark-derived tiers.
+const BenchmarkSource = "benchmark"
+
+// defaultBenchPrompt asks for a response long enough to measure throughput.
+const defaultBenchPrompt = "Write a 150-word paragraph about the history of the printing press."
+
+// BenchConfig configures a benchmark run.
+type BenchConfig struct {
+	Runs      int      // Streamed runs per model (default 5)
+	Parallel  int      // Max concurrent runs across all models (default 1)
+	Providers []string // Providers to benchmark (empty = all)
+	Models    []string // Model IDs to benchmark (empty = all streaming models)
+	Prompt    string
+	MaxTokens int
+	Timeout   time.Duration // Per-run timeout
+}
+
+// BenchRun is the measurement of a single streamed completion.
+type BenchRun struct {
+	TTFT            time.Duration `json:"ttft"`
+	Total           time.Duration `json:"total"`
+	OutputTokens    int           `json:"output_tokens"`
+	TokensEstimated bool          `json:"tokens_estimated,omitempty"`
+	TokensPerSecond float64       `json:"tokens_per_second"`
+	Error           string        `json:"error,omitempty"`
+}
+
+// Percentiles summarizes a sample distribution.
+type Percentiles struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// BenchResult aggregates the runs of one model.
+type BenchResult struct {
+	Provider            string      `json:"provider"`
+	Model               string      `json:"model"`
+	Runs                int         `json:"runs"`
+	Errors              int         `json:"errors"`
+	TTFTMS              Percentiles `json:"ttft_ms"`
+	TotalMS             Percentiles `json:"total_ms"`
+	TokensPerSecond     Percentiles `json:"tokens_per_second"`
+	LatencyTier         string      `json:"latency_tier"`
+	SystemProfileSource string      `json:"system_profile_source"`
+	AsOf                time.Time   `json:"as_of"`
+	Samples             []BenchRun  `json:"samples"`
+}
+
+// Benchmarker runs latency benchmarks against a plan.
+type Benchmarker struct {
+	config BenchConfig
+	plan   *TestPlan
+	now    func() time.Time
+}
+
+// NewBenchmarker creates a benchmarker with defaults applied.
+func NewBenchmarker(config BenchConfig, plan *TestPlan) *Benchmarker {
+
+	if config.Runs <= 0 {
+		config.Runs = 5
+	}
+	if config.Parallel <= 0 {
+		config.Parallel = 1
+	}
+	if strings.TrimSpace(config.Prompt) == "" {
+		config.Prompt = defaultBenchPrompt
+	}
+	if config.MaxTokens <= 0 {
+		config.MaxTokens = scenarioMaxTokens
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 60 * time.Second
+	}
+	return &Benchmarker{config: config, plan: plan, now: time.Now}
+}
+
+// Run benchmarks every selected streaming model and returns one result per model.
+func (b *Benchmarker) Run(ctx context.Context) ([]BenchResult, error) {
+
+	if b.plan == nil {
+		return nil, fmt.Errorf("no test plan loaded")
+	}
+
+	type target struct {
+		provider ProviderConfig
+		model    string
+	}
+	targets := make([]target, 0)
+	for _, prov := range b.plan.Providers {
+		if !containsOrEmpty(b.config.Providers, prov.Name) {
+			continue
+		}
+		for _, model := range prov.Models {
+			if containsOrEmpty(b.config.Models, model.ID) && hasCapability(model.Capabilities, CapabilityStreaming) {
+				targets = append(targets, target{provider: prov, model: model.ID})
+			}
+		}
+	}
+
+	runs := make([][]BenchRun, len(targets))
+	for index := range runs {
+		runs[index] = make([]BenchRun, b.config.Runs)
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, b.config.Parallel)
+	for targetIndex, current := range targets {
+		for runIndex := 0; runIndex < b.config.Runs; runIndex++ {
+			wg.Add(1)
+			slots <- struct{}{}
+			go func(targetIndex, runIndex int, current target) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				runs[targetIndex][runIndex] = b.measure(ctx, current.provider, current.model)
+			}(targetIndex, runIndex, current)
+		}
+	}
+	wg.Wait()
+
+	results := make([]BenchResult, 0, len(targets))
+	for index, current := range targets {
+		results = append(results, summarizeBench(current.provider.Name, current.model, runs[index]))
+	}
+	return results, nil
+}
+
+// measure performs one streamed completion and times it.
+func (b *Benchmarker) measure(ctx context.Context, prov ProviderConfig, model string) BenchRun {
+
+	ctx, cancel := context.WithTimeout(ctx, b.config.Timeout)
+	defer cancel()
+
+	client := NewClient(ClientConfig{
+		ProviderName: prov.Name,
+		ProviderType: prov.Type,
+		BaseURL:      prov.BaseURL,
+		APIKey:       providerAPIKey(prov),
+	})
+	request := buildTextChatRequest(prov.Type, model, []conversationTurn{{Role: "user", Text: b.config.Prompt}}, true)
+	switch prov.Type {
+	case ProviderTypeGemini:
+		request["generationConfig"] = map[string]interface{}{"maxOutputTokens": b.config.MaxTokens}
+	case ProviderTypeAnthropic:
+		request["max_tokens"] = b.config.MaxTokens
+	default:
+		// Usage arrives in a final chunk only when requested; without it token counts fall back to estimates.
+		request["max_tokens"] = b.config.MaxTokens
+		request["stream_options"] = map[string]interface{}{"include_usage": true}
+	}
+
+	started := b.now()
+	resp, err := client.Do(ctx, http.MethodPost, chatPath(prov.Type, model, true), request)
+	if err != nil {
+		return BenchRun{Error: fmt.Sprintf("request failed: %v", err)}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return BenchRun{Error: fmt.Sprintf("HTTP %d: %s", resp.StatusCode, truncate(string(body), 200))}
+	}
+
+	return timeStream(resp.Body, prov.Type, started, b.now)
+}
+
+// timeStream reads SSE events as they arrive, recording when the first content token appears.
+func timeStream(body io.Reader, providerType ProviderType, started time.Time, now func() time.Time) BenchRun {
+
+	run := BenchRun{}
+	reader := bufio.NewReader(body)
+	var data []string
+	var content strings.Builder
+	reportedTokens := 0
+
+	dispatch := func() {
+		if len(data) == 0 {
+			return
+		}
+		text, tokens := benchEventDelta(providerType, strings.Join(data, "\n"))
+		data = data[:0]
+		if text != "" && run.TTFT == 0 {
+			run.TTFT = now().Sub(started)
+		}
+		content.WriteString(text)
+		if tokens > 0 {
+			reportedTokens = tokens
+		}
+	}
+
+	for {
+		line, err := reader.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			dispatch()
+		} else if value, found := strings.CutPrefix(line, "data:"); found {
+			data = append(data, strings.TrimPrefix(value, " "))
+		}
+		if err != nil {
+			dispatch()
+			if err != io.EOF {
+				run.Error = fmt.Sprintf("read stream: %v", err)
+			}
+			break
+		}
+	}
+	run.Total = now().Sub(started)
+
+	if run.TTFT == 0 && run.Error == "" {
+		run.Error = "stream produced no content"
+		return run
+	}
+
+	run.OutputTokens = reportedTokens
+	if run.OutputTokens == 0 {
+		run.OutputTokens = estimateTokens(content.String())
+		run.TokensEstimated = true
+	}
+	if generation := run.Total - run.TTFT; generation > 0 {
+		run.TokensPerSecond = float64(run.OutputTokens) / generation.Seconds()
+	}
+	return run
+}
+
+// benchEventDelta extracts content text and the reported output token count from one event.
+func benchEventDelta(providerType ProviderType, data string) (string, int) {
+
+	switch providerType {
+	case ProviderTypeGemini:
+		var chunk struct {
+			geminiResponse
+			UsageMetadata struct {
+				CandidatesTokenCount int `json:"candidatesTokenCount"`
+			} `json:"usageMetadata"`
+		}
+		if json.Unmarshal([]byte(data), &chunk) != nil {
+			return "", 0
+		}
+		return chunk.text(), chunk.UsageMetadata.CandidatesTokenCount
+	case ProviderTypeAnthropic:
+		var event struct {
+			Type  string `json:"type"`
+			Delta struct {
+				Text string `json:"text"`
+			} `json:"delta"`
+			Usage struct {
+				OutputTokens int `json:"output_tokens"`
+			} `json:"usage"`
+		}
+		if json.Unmarshal([]byte(data), &event) != nil {
+			return "", 0
+		}
+		if event.Type == "content_block_delta" {
+			return event.Delta.Text, 0
+		}
+		return "", event.Usage.OutputTokens
+	default:
+		if data == "[DONE]" {
+			return "", 0
+		}
+		var chunk struct {
+			Choices []struct {
+				Delta struct {
+					Content string `json:"content"`
+				} `json:"delta"`
+			} `json:"choices"`
+			Usage *struct {
+				CompletionTokens int `json:"completion_tokens"`
+			} `json:"usage"`
+		}
+		if json.Unmarshal([]byte(data), &chunk) != nil {
+			return "", 0
+		}
+		text := ""
+		for _, choice := range chunk.Choices {
+			text += choice.Delta.Content
+		}
+		tokens := 0
+		if chunk.Usage != nil {
+			tokens = chunk.Usage.CompletionTokens
+		}
+		return text, tokens
+	}
+}
+
+// estimateTokens approximates token count at four characters per token when providers omit usage.
+func estimateTokens(text string) int {
+
+	if text == "" {
+		return 0
+	}
+	return int(math.Max(1, math.Round(float64(len(text))/4)))
+}
+
+// summarizeBench aggregates successful runs into percentiles and a latency tier.
+func summarizeBench(provider, model string, runs []BenchRun) BenchResult {
+
+	result := BenchResult{
+		Provider:            provider,
+		Model:               model,
+		Runs:                len(runs),
+		SystemProfileSource: BenchmarkSource,
+		AsOf:                time.Now().UTC(),
+		Samples:             runs,
+	}
+
+	ttft := make([]float64, 0, len(runs))
+	total := make([]float64, 0, len(runs))
+	throughput := make([]float64, 0, len(runs))
+	for _, run := range runs {
+		if run.Error != "" {
+			result.Errors++
+			continue
+		}
+		ttft = append(ttft, durationMS(run.TTFT))
+		total = append(total, durationMS(run.Total))
+		throughput = append(throughput, run.TokensPerSecond)
+	}
+	result.TTFTMS = ComputePercentiles(ttft)
+	result.TotalMS = ComputePercentiles(total)
+	result.TokensPerSecond = ComputePercentiles(throughput)
+	if len(ttft) > 0 {
+		result.LatencyTier = LatencyTier(result.TTFTMS.P50)
+	}
+	return result
+}
+
+// ComputePercentiles returns nearest-rank percentiles of values.
+func ComputePercentiles(values []float64) Percentiles {
+
+	if len(values) == 0 {
+		return Percentiles{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, value := range sorted {
+		sum += value
+	}
+	rank := func(percentile float64) float64 {
+		index := int(math.Ceil(percentile/100*float64(len(sorted)))) - 1
+		if index < 0 {
+			index = 0
+		}
+		return sorted[index]
+	}
+	return Percentiles{
+		Min:  sorted[0],
+		Mean: sum / float64(len(sorted)),
+		P50:  rank(50),
+		P90:  rank(90),
+		P99:  rank(99),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// LatencyTier maps median time-to-first-token to the catalog's low/medium/high latency tiers.
+func LatencyTier(ttftP50MS float64) string {
+
+	switch {
+	case ttftP50MS <= 800:
+		return "low"
+	case ttftP50MS <= 2000:
+		return "medium"
+	default:
+		return "high"
+	}
+}
+
+// durationMS converts a duration to fractional milliseconds.
+func durationMS(value time.Duration) float64 {
+
+	return float64(value) / float64(time.Millisecond)
+}
+
+// WriteBenchJSON writes results as indented JSON.
+func WriteBenchJSON(w io.Writer, results []BenchResult) error {
+
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal bench results: %w", err)
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// WriteBenchCSV writes one summary row per model.
+func WriteBenchCSV(w io.Writer, results []BenchResult) error {
+
+	writer := csv.NewWriter(w)
+	header := []string{
+		"provider", "model", "runs", "errors",
+		"ttft_p50_ms", "ttft_p90_ms", "ttft_p99_ms",
+		"total_p50_ms", "total_p90_ms", "total_p99_ms",
+		"tokens_per_second_p50", "tokens_per_second_p90", "tokens_per_second_p99",
+		"latency_tier",
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	format := func(value float64) string { return strconv.FormatFloat(value, 'f', 1, 64) }
+	for _, result := range results {
+		row := []string{
+			result.Provider, result.Model, strconv.Itoa(result.Runs), strconv.Itoa(result.Errors),
+			format(result.TTFTMS.P50), format(result.TTFTMS.P90), format(result.TTFTMS.P99),
+			format(result.TotalMS.P50), format(result.TotalMS.P90), format(result.TotalMS.P99),
+			format(result.TokensPerSecond.P50), format(result.TokensPerSecond.P90), format(result.TokensPerSecond.P99),
+			result.LatencyTier,
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,202 @@
+// bench_test.go verifies benchmark timing, percentile math, and report output.
+// pkg/models/modeltest/bench_test.go
+package modeltest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestComputePercentilesNearestRank ensures percentiles use the nearest-rank method.
+func TestComputePercentilesNearestRank(t *testing.T) {
+
+	testCases := []struct {
+		name   string
+		values []float64
+		want   Percentiles
+	}{
+		{name: "empty", values: nil, want: Percentiles{}},
+		{name: "single", values: []float64{7}, want: Percentiles{Min: 7, Mean: 7, P50: 7, P90: 7, P99: 7, Max: 7}},
+		{name: "ten unsorted", values: []float64{10, 1, 9, 2, 8, 3, 7, 4, 6, 5}, want: Percentiles{Min: 1, Mean: 5.5, P50: 5, P90: 9, P99: 10, Max: 10}},
+	}
+
+	for _, testCase := range testCases {
+		if got := ComputePercentiles(testCase.values); got != testCase.want {
+			t.Fatalf("%s: expected %+v, got %+v", testCase.name, testCase.want, got)
+		}
+	}
+}
+
+// TestBenchmarkerMeasuresFakeStream ensures TTFT, total latency, and tokens/sec come from the benchmark clock.
+func TestBenchmarkerMeasuresFakeStream(t *testing.T) {
+
+	const step = 100 * time.Millisecond
+	const completionTokens = 50
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"}}]}\n\n")
+		for index := 0; index < 5; index++ {
+			fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"word \"}}]}\n\n")
+		}
+		fmt.Fprintf(w, "data: {\"choices\":[],\"usage\":{\"completion_tokens\":%d}}\n\n", completionTokens)
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	plan := &TestPlan{Providers: []ProviderConfig{{Name: "fake", Type: ProviderTypeOpenAI, BaseURL: server.URL, APIKey: "test", Models: []ModelConfig{
+		{ID: "fast", Capabilities: []Capability{CapabilityChat, CapabilityStreaming}},
+		{ID: "no-stream", Capabilities: []Capability{CapabilityChat}},
+	}}}}
+	benchmarker := NewBenchmarker(BenchConfig{Runs: 3, Timeout: 5 * time.Second}, plan)
+	benchmarker.now = steppingClock(step)
+	results, err := benchmarker.Run(context.Background())
+	if err != nil {
+		t.Fatalf("run bench: %v", err)
+	}
+	if len(results) != 1 || results[0].Model != "fast" {
+		t.Fatalf("expected one result for the streaming model, got %+v", results)
+	}
+
+	result := results[0]
+	if result.Runs != 3 || result.Errors != 0 || len(result.Samples) != 3 {
+		t.Fatalf("unexpected run counts: %+v", result)
+	}
+	for index, run := range result.Samples {
+		if run.TTFT != step || run.Total != 2*step {
+			t.Fatalf("run %d: expected TTFT %v and total %v, got %+v", index, step, 2*step, run)
+		}
+		if run.OutputTokens != completionTokens || run.TokensEstimated {
+			t.Fatalf("run %d: expected reported usage tokens, got %+v", index, run)
+		}
+		if run.TokensPerSecond != float64(completionTokens)/step.Seconds() {
+			t.Fatalf("run %d: expected %.2f tokens/sec, got %.2f", index, float64(completionTokens)/step.Seconds(), run.TokensPerSecond)
+		}
+	}
+	if result.LatencyTier != "low" || result.SystemProfileSource != BenchmarkSource {
+		t.Fatalf("unexpected tier metadata: %+v", result)
+	}
+	if result.TTFTMS.P50 != durationMS(step) {
+		t.Fatalf("expected p50 TTFT %v, got %.1fms", step, result.TTFTMS.P50)
+	}
+
+	var csvOutput bytes.Buffer
+	if err := WriteBenchCSV(&csvOutput, results); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOutput.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[1], "fake,fast,3,0,") || !strings.HasSuffix(lines[1], ",low") {
+		t.Fatalf("unexpected csv output:\n%s", csvOutput.String())
+	}
+}
+
+// TestTimeStreamEstimatesTokensAndReportsEmptyStreams ensures usage-less streams fall back to estimates and empty streams error.
+func TestTimeStreamEstimatesTokensAndReportsEmptyStreams(t *testing.T) {
+
+	started := time.Unix(0, 0)
+	ticks := []time.Duration{200 * time.Millisecond, 700 * time.Millisecond}
+	now := func() time.Time {
+		tick := ticks[0]
+		ticks = ticks[1:]
+		return started.Add(tick)
+	}
+
+	stream := "event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"text\":\"abcdefgh\"}}\n\n"
+	run := timeStream(strings.NewReader(stream), ProviderTypeAnthropic, started, now)
+	if run.Error != "" || run.TTFT != 200*time.Millisecond || run.Total != 700*time.Millisecond {
+		t.Fatalf("unexpected timings: %+v", run)
+	}
+	if run.OutputTokens != 2 || !run.TokensEstimated || run.TokensPerSecond != 4 {
+		t.Fatalf("expected 2 estimated tokens at 4 tokens/sec, got %+v", run)
+	}
+
+	empty := timeStream(strings.NewReader("data: [DONE]\n\n"), ProviderTypeOpenAI, started, time.Now)
+	if empty.Error == "" {
+		t.Fatalf("expected an error for a stream without content")
+	}
+}
+
+// TestBenchmarkerRequestsUsageAndTokenLimit ensures each provider request asks for usage and carries the configured token limit.
+func TestBenchmarkerRequestsUsageAndTokenLimit(t *testing.T) {
+
+	testCases := []struct {
+		name         string
+		providerType ProviderType
+		stream       string
+		check        func(request map[string]interface{}) bool
+	}{
+		{
+			name:         "openai",
+			providerType: ProviderTypeOpenAI,
+			stream:       "data: {\"choices\":[{\"delta\":{\"content\":\"hi\"}}]}\n\ndata: [DONE]\n\n",
+			check: func(request map[string]interface{}) bool {
+				options, _ := request["stream_options"].(map[string]interface{})
+				return request["max_tokens"] == float64(42) && options["include_usage"] == true
+			},
+		},
+		{
+			name:         "gemini",
+			providerType: ProviderTypeGemini,
+			stream:       "data: {\"candidates\":[{\"content\":{\"parts\":[{\"text\":\"hi\"}]}}]}\n\n",
+			check: func(request map[string]interface{}) bool {
+				config, _ := request["generationConfig"].(map[string]interface{})
+				return config["maxOutputTokens"] == float64(42)
+			},
+		},
+		{
+			name:         "anthropic",
+			providerType: ProviderTypeAnthropic,
+			stream:       "data: {\"type\":\"content_block_delta\",\"delta\":{\"text\":\"hi\"}}\n\n",
+			check: func(request map[string]interface{}) bool {
+				return request["max_tokens"] == float64(42)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		var request map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Errorf("%s: decode request: %v", testCase.name, err)
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, testCase.stream)
+		}))
+
+		plan := &TestPlan{Providers: []ProviderConfig{{Name: testCase.name, Type: testCase.providerType, BaseURL: server.URL, APIKey: "test", Models: []ModelConfig{
+			{ID: "model", Capabilities: []Capability{CapabilityChat, CapabilityStreaming}},
+		}}}}
+		results, err := NewBenchmarker(BenchConfig{Runs: 1, MaxTokens: 42}, plan).Run(context.Background())
+		server.Close()
+		if err != nil {
+			t.Fatalf("%s: run bench: %v", testCase.name, err)
+		}
+		if len(results) != 1 || results[0].Errors != 0 {
+			t.Fatalf("%s: expected one successful run, got %+v", testCase.name, results)
+		}
+		if !testCase.check(request) {
+			t.Fatalf("%s: unexpected request %v", testCase.name, request)
+		}
+	}
+}
+
+// steppingClock returns a clock that advances by step on every reading.
+func steppingClock(step time.Duration) func() time.Time {
+
+	var mu sync.Mutex
+	current := time.Unix(0, 0)
+	return func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		current = current.Add(step)
+		return current
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 )
 
 // Capability identifies a testable capability.
@@ -16,6 +17,12 @@ const (
 	CapabilityImageGen       Capability = "image_gen"
 	CapabilityImageEdit      Capability = "image_edit"
 	CapabilityTestConnection Capability = "test_connection"
+
+	CapabilityStreaming        Capability = "streaming"
+	CapabilityToolCalling      Capability = "tool_calling"
+	CapabilityStructuredOutput Capability = "structured_output"
+	CapabilityVision           Capability = "vision"
+	CapabilityMultiTurn        Capability = "multi_turn"
 )
 
 // TestResult contains the outcome of a capability test.
@@ -26,6 +33,10 @@ type TestResult struct {
 	Success    bool       `json:"success"`
 	Error      string     `json:"error,omitempty"`
 	Recording  *Recording `json:"recording,omitempty"`
+
+	// Set by the runner for every driver.
+	StatusCodes []int         `json:"status_codes,omitempty"` // HTTP status of each exchange, in order
+	Duration    time.Duration `json:"duration_ns,omitempty"`
 }
 
 // CapabilityTester tests a specific capability.
@@ -225,6 +236,16 @@ func GetTester(cap Capability) CapabilityTester {
 		return &ImageGenTester{}
 	case CapabilityTestConnection:
 		return &TestConnectionTester{}
+	case CapabilityStreaming:
+		return &StreamingTester{}
+	case CapabilityToolCalling:
+		return &ToolCallingTester{}
+	case CapabilityStructuredOutput:
+		return &StructuredOutputTester{}
+	case CapabilityVision:
+		return &VisionTester{}
+	case CapabilityMultiTurn:
+		return &MultiTurnTester{}
 	default:
 		return nil
 	}
@@ -106,10 +106,22 @@ func providerConfigForName(name string) (ProviderType, string, error) {
 // deriveModelCapabilities derives modeltest capabilities from canonical family metadata.
 func deriveModelCapabilities(family modelcatalog.Family) []Capability {
 
-	capabilities := make([]Capability, 0, 4)
+	capabilities := make([]Capability, 0, 9)
 
 	if hasValue(family.Modalities.Output, "text") {
-		capabilities = append(capabilities, CapabilityChat)
+		capabilities = append(capabilities, CapabilityChat, CapabilityMultiTurn)
+		if family.Capabilities.Streaming {
+			capabilities = append(capabilities, CapabilityStreaming)
+		}
+		if family.Capabilities.ToolCalling {
+			capabilities = append(capabilities, CapabilityToolCalling)
+		}
+		if family.Capabilities.StructuredOutput {
+			capabilities = append(capabilities, CapabilityStructuredOutput)
+		}
+		if family.Capabilities.Vision || hasValue(family.Modalities.Input, "image") {
+			capabilities = append(capabilities, CapabilityVision)
+		}
 	}
 	if hasValue(family.Modalities.Output, "image") || hasValue(family.SystemTags, "image_gen") {
 		capabilities = append(capabilities, CapabilityImageGen)
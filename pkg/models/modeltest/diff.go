@@ -0,0 +1,489 @@
+// diff.go compares golden files structurally to detect provider response drift.
+// pkg/models/modeltest/diff.go
+package modeltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultIgnoreRules lists volatile fields whose presence varies between otherwise identical recordings.
+var DefaultIgnoreRules = []string{
+	"id",
+	"**.id",
+	"created",
+	"**.created",
+	"**.created_at",
+	"**.timestamp",
+	"system_fingerprint",
+	"**.system_fingerprint",
+	"responseId",
+	"**.responseId",
+	"modelVersion",
+	"**.modelVersion",
+	"usage.**",
+	"**.usage.**",
+	"usageMetadata.**",
+	"**.usageMetadata.**",
+	"**.*_tokens",
+	"**.*TokenCount",
+	"**.*_tokens_details.**",
+}
+
+// ChangeKind classifies one structural difference.
+type ChangeKind string
+
+const (
+	ChangeAdded       ChangeKind = "added"
+	ChangeRemoved     ChangeKind = "removed"
+	ChangeTypeChanged ChangeKind = "type_changed"
+)
+
+// FieldChange is a single structural difference between two response bodies.
+type FieldChange struct {
+	Exchange int        `json:"exchange"`
+	Path     string     `json:"path"`
+	Kind     ChangeKind `json:"kind"`
+	OldType  string     `json:"old_type,omitempty"`
+	NewType  string     `json:"new_type,omitempty"`
+	Breaking bool       `json:"breaking"`
+}
+
+// FileDiff summarizes drift for one golden key.
+type FileDiff struct {
+	Key     string        `json:"key"`
+	Status  string        `json:"status"` // unchanged, changed, new, missing
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// DiffReport is the result of comparing two golden directories.
+type DiffReport struct {
+	Files    []FileDiff `json:"files"`
+	Changed  int        `json:"changed"`
+	Breaking int        `json:"breaking"`
+}
+
+// DiffRules configures which paths are excluded from comparison.
+// Patterns are dot-separated paths; "[]" marks array elements, "*" matches within one segment, "**" matches any number of segments.
+type DiffRules struct {
+	Ignore []string `yaml:"ignore" json:"ignore"`
+}
+
+// NewDiffRules returns rules combining the defaults (when requested) with extra patterns.
+func NewDiffRules(includeDefaults bool, extra ...string) DiffRules {
+
+	rules := DiffRules{}
+	if includeDefaults {
+		rules.Ignore = append(rules.Ignore, DefaultIgnoreRules...)
+	}
+	rules.Ignore = append(rules.Ignore, extra...)
+	return rules
+}
+
+// LoadDiffRules reads ignore rules from a YAML file and appends them to base.
+func LoadDiffRules(filePath string, base DiffRules) (DiffRules, error) {
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return base, fmt.Errorf("read diff rules: %w", err)
+	}
+	var loaded DiffRules
+	if err := yaml.Unmarshal(data, &loaded); err != nil {
+		return base, fmt.Errorf("parse diff rules: %w", err)
+	}
+	base.Ignore = append(base.Ignore, loaded.Ignore...)
+	return base, nil
+}
+
+// Ignored reports whether a field path matches any ignore rule.
+func (r DiffRules) Ignored(fieldPath string) bool {
+
+	segments := splitPath(fieldPath)
+	for _, rule := range r.Ignore {
+		if matchSegments(splitPath(rule), segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffGoldenDirs compares the newest golden file per key in oldDir against newDir.
+func DiffGoldenDirs(oldDir, newDir string, rules DiffRules) (DiffReport, error) {
+
+	oldFiles, err := latestGoldenFiles(oldDir)
+	if err != nil {
+		return DiffReport{}, fmt.Errorf("load %s: %w", oldDir, err)
+	}
+	newFiles, err := latestGoldenFiles(newDir)
+	if err != nil {
+		return DiffReport{}, fmt.Errorf("load %s: %w", newDir, err)
+	}
+
+	keys := make([]string, 0, len(oldFiles)+len(newFiles))
+	for key := range oldFiles {
+		keys = append(keys, key)
+	}
+	for key := range newFiles {
+		if _, exists := oldFiles[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	report := DiffReport{Files: make([]FileDiff, 0, len(keys))}
+	for _, key := range keys {
+		oldGolden, newGolden := oldFiles[key], newFiles[key]
+		var fileDiff FileDiff
+		switch {
+		case newGolden == nil:
+			fileDiff = FileDiff{Key: key, Status: "missing"}
+		case oldGolden == nil:
+			fileDiff = FileDiff{Key: key, Status: "new"}
+		default:
+			fileDiff = DiffGoldenFiles(oldGolden, newGolden, rules)
+		}
+		report.add(fileDiff)
+	}
+	return report, nil
+}
+
+// DiffGoldenFiles compares every recorded response exchange of two golden files.
+func DiffGoldenFiles(oldGolden, newGolden *GoldenFile, rules DiffRules) FileDiff {
+
+	fileDiff := FileDiff{
+		Key:    goldenKey(newGolden.Metadata.Driver, newGolden.Metadata.Provider, newGolden.Metadata.Capability, newGolden.Metadata.Model),
+		Status: "unchanged",
+	}
+
+	exchanges := len(oldGolden.FollowUps) + 1
+	if count := len(newGolden.FollowUps) + 1; count > exchanges {
+		exchanges = count
+	}
+	for sequence := 0; sequence < exchanges; sequence++ {
+		oldResponse, oldOK := oldGolden.Exchange(sequence)
+		newResponse, newOK := newGolden.Exchange(sequence)
+		switch {
+		case !newOK:
+			fileDiff.Changes = append(fileDiff.Changes, FieldChange{Exchange: sequence, Path: "$", Kind: ChangeRemoved, OldType: "exchange", Breaking: true})
+			continue
+		case !oldOK:
+			fileDiff.Changes = append(fileDiff.Changes, FieldChange{Exchange: sequence, Path: "$", Kind: ChangeAdded, NewType: "exchange", Breaking: true})
+			continue
+		}
+		fileDiff.Changes = append(fileDiff.Changes, diffResponses(sequence, oldResponse, newResponse, rules)...)
+	}
+
+	if len(fileDiff.Changes) > 0 {
+		fileDiff.Status = "changed"
+	}
+	return fileDiff
+}
+
+// diffResponses compares status class and body shape for one exchange.
+func diffResponses(sequence int, oldResponse, newResponse RecordedResponse, rules DiffRules) []FieldChange {
+
+	changes := make([]FieldChange, 0)
+	if oldResponse.Status/100 != newResponse.Status/100 {
+		changes = append(changes, FieldChange{
+			Exchange: sequence,
+			Path:     "$status",
+			Kind:     ChangeTypeChanged,
+			OldType:  fmt.Sprintf("%d", oldResponse.Status),
+			NewType:  fmt.Sprintf("%d", newResponse.Status),
+			Breaking: true,
+		})
+	}
+
+	oldShape := responseShape(oldResponse)
+	newShape := responseShape(newResponse)
+	for _, fieldPath := range unionKeys(oldShape, newShape) {
+		if rules.Ignored(fieldPath) {
+			continue
+		}
+		oldType, inOld := oldShape[fieldPath]
+		newType, inNew := newShape[fieldPath]
+		switch {
+		case !inOld:
+			changes = append(changes, FieldChange{Exchange: sequence, Path: fieldPath, Kind: ChangeAdded, NewType: newType})
+		case !inNew:
+			changes = append(changes, FieldChange{Exchange: sequence, Path: fieldPath, Kind: ChangeRemoved, OldType: oldType, Breaking: true})
+		case oldType != newType:
+			changes = append(changes, FieldChange{
+				Exchange: sequence,
+				Path:     fieldPath,
+				Kind:     ChangeTypeChanged,
+				OldType:  oldType,
+				NewType:  newType,
+				Breaking: !nullableChange(oldType, newType),
+			})
+		}
+	}
+	return changes
+}
+
+// responseShape flattens a response body into path -> JSON type.
+// Streamed bodies are flattened per event under "events[]" so framing and chunk shapes are compared together.
+func responseShape(response RecordedResponse) map[string]string {
+
+	shape := make(map[string]string)
+	if response.IsStream() {
+		events, err := ParseSSE(response.BodyBytes())
+		if err != nil {
+			shape["events"] = "invalid_sse"
+			return shape
+		}
+		shape["events"] = "array"
+		for _, event := range events {
+			if event.Event != "" {
+				shape["events[].event:"+event.Event] = "string"
+			}
+			var value interface{}
+			if err := json.Unmarshal([]byte(event.Data), &value); err != nil {
+				mergeType(shape, "events[].data", "string")
+				continue
+			}
+			flattenShape(value, "events[].data", shape)
+		}
+		return shape
+	}
+
+	body := response.BodyBytes()
+	if len(body) == 0 {
+		return shape
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		shape["$"] = "text"
+		return shape
+	}
+	flattenShape(value, "", shape)
+	return shape
+}
+
+// flattenShape records the JSON type of every path; array elements share the "[]" path and their types are merged.
+func flattenShape(value interface{}, prefix string, shape map[string]string) {
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if prefix != "" {
+			mergeType(shape, prefix, "object")
+		}
+		for key, child := range typed {
+			flattenShape(child, joinPath(prefix, key), shape)
+		}
+	case []interface{}:
+		if prefix != "" {
+			mergeType(shape, prefix, "array")
+		}
+		for _, child := range typed {
+			flattenShape(child, prefix+"[]", shape)
+		}
+	default:
+		if prefix != "" {
+			mergeType(shape, prefix, jsonType(typed))
+		}
+	}
+}
+
+// mergeType adds a type to a path, joining distinct types with "|" in sorted order.
+func mergeType(shape map[string]string, fieldPath string, typeName string) {
+
+	existing, ok := shape[fieldPath]
+	if !ok {
+		shape[fieldPath] = typeName
+		return
+	}
+	types := strings.Split(existing, "|")
+	for _, current := range types {
+		if current == typeName {
+			return
+		}
+	}
+	types = append(types, typeName)
+	sort.Strings(types)
+	shape[fieldPath] = strings.Join(types, "|")
+}
+
+// jsonType names the JSON type of a decoded scalar.
+func jsonType(value interface{}) string {
+
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// nullableChange reports whether a type change only adds or drops null, which providers do for optional fields.
+func nullableChange(oldType, newType string) bool {
+
+	strip := func(value string) string {
+		parts := make([]string, 0)
+		for _, part := range strings.Split(value, "|") {
+			if part != "null" {
+				parts = append(parts, part)
+			}
+		}
+		return strings.Join(parts, "|")
+	}
+	oldStripped, newStripped := strip(oldType), strip(newType)
+	return oldStripped == "" || newStripped == "" || oldStripped == newStripped
+}
+
+// joinPath appends a key to a dot-separated path.
+func joinPath(prefix, key string) string {
+
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// splitPath splits a dot-separated path, treating "[]" suffixes as their own segment.
+func splitPath(fieldPath string) []string {
+
+	segments := make([]string, 0)
+	for _, part := range strings.Split(fieldPath, ".") {
+		for strings.HasSuffix(part, "[]") {
+			part = strings.TrimSuffix(part, "[]")
+			if part != "" {
+				segments = append(segments, part)
+			}
+			part = ""
+			segments = append(segments, "[]")
+		}
+		if part != "" {
+			segments = append(segments, part)
+		}
+	}
+	return segments
+}
+
+// matchSegments matches path segments against rule segments supporting "*" globs and "**" spans.
+func matchSegments(rule, segments []string) bool {
+
+	if len(rule) == 0 {
+		return len(segments) == 0
+	}
+	if rule[0] == "**" {
+		for skip := 0; skip <= len(segments); skip++ {
+			if matchSegments(rule[1:], segments[skip:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if rule[0] == "[]" || segments[0] == "[]" {
+		return rule[0] == segments[0] && matchSegments(rule[1:], segments[1:])
+	}
+	if matched, err := path.Match(rule[0], segments[0]); err != nil || !matched {
+		return false
+	}
+	return matchSegments(rule[1:], segments[1:])
+}
+
+// unionKeys returns the sorted union of two shapes' paths.
+func unionKeys(left, right map[string]string) []string {
+
+	keys := make([]string, 0, len(left)+len(right))
+	for key := range left {
+		keys = append(keys, key)
+	}
+	for key := range right {
+		if _, exists := left[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// latestGoldenFiles loads a directory keyed by golden key, keeping the newest recording per key.
+func latestGoldenFiles(dir string) (map[string]*GoldenFile, error) {
+
+	files, err := LoadGoldenFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	latest := make(map[string]*GoldenFile, len(files))
+	for _, file := range files {
+		key := goldenKey(file.Metadata.Driver, file.Metadata.Provider, file.Metadata.Capability, file.Metadata.Model)
+		if current, exists := latest[key]; !exists || file.Metadata.Timestamp.After(current.Metadata.Timestamp) {
+			latest[key] = file
+		}
+	}
+	return latest, nil
+}
+
+// add appends a file diff and updates the report totals.
+func (r *DiffReport) add(fileDiff FileDiff) {
+
+	r.Files = append(r.Files, fileDiff)
+	if fileDiff.Status != "unchanged" {
+		r.Changed++
+	}
+	for _, change := range fileDiff.Changes {
+		if change.Breaking {
+			r.Breaking++
+		}
+	}
+}
+
+// WriteText renders the report for terminals.
+func (r DiffReport) WriteText(w io.Writer) error {
+
+	for _, file := range r.Files {
+		if file.Status == "unchanged" {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s [%s]\n", file.Key, file.Status); err != nil {
+			return err
+		}
+		for _, change := range file.Changes {
+			marker := " "
+			if change.Breaking {
+				marker = "!"
+			}
+			detail := change.NewType
+			switch change.Kind {
+			case ChangeRemoved:
+				detail = change.OldType
+			case ChangeTypeChanged:
+				detail = change.OldType + " -> " + change.NewType
+			}
+			if _, err := fmt.Fprintf(w, "  %s #%d %-12s %s (%s)\n", marker, change.Exchange+1, change.Kind, change.Path, detail); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d file(s) compared, %d changed, %d breaking change(s)\n", len(r.Files), r.Changed, r.Breaking)
+	return err
+}
+
+// WriteJSON renders the report as indented JSON.
+func (r DiffReport) WriteJSON(w io.Writer) error {
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal diff report: %w", err)
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
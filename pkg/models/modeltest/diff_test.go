@@ -0,0 +1,137 @@
+// diff_test.go verifies structural golden file drift detection.
+// pkg/models/modeltest/diff_test.go
+package modeltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDiffGoldenFilesClassifiesChanges ensures added, removed, and type-changed fields are classified and volatile fields ignored.
+func TestDiffGoldenFilesClassifiesChanges(t *testing.T) {
+
+	oldBody := `{"id":"a","created":1,"choices":[{"message":{"content":"hi","refusal":null},"finish_reason":"stop"}],"usage":{"total_tokens":3}}`
+	testCases := []struct {
+		name         string
+		newBody      string
+		wantChanges  map[string]ChangeKind
+		wantBreaking int
+	}{
+		{
+			name:        "volatile values only",
+			newBody:     `{"id":"b","created":2,"choices":[{"message":{"content":"hello","refusal":null},"finish_reason":"stop"}],"usage":{"total_tokens":9,"prompt_tokens":1}}`,
+			wantChanges: map[string]ChangeKind{},
+		},
+		{
+			name:         "added and removed",
+			newBody:      `{"id":"b","created":2,"choices":[{"message":{"content":"hi","refusal":null,"annotations":[]}}]}`,
+			wantChanges:  map[string]ChangeKind{"choices[].message.annotations": ChangeAdded, "choices[].finish_reason": ChangeRemoved},
+			wantBreaking: 1,
+		},
+		{
+			name:         "type changed",
+			newBody:      `{"id":"b","created":2,"choices":[{"message":{"content":["hi"],"refusal":"no"},"finish_reason":"stop"}]}`,
+			wantChanges:  map[string]ChangeKind{"choices[].message.content": ChangeTypeChanged, "choices[].message.content[]": ChangeAdded, "choices[].message.refusal": ChangeTypeChanged},
+			wantBreaking: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		oldGolden := &GoldenFile{Metadata: GoldenMetadata{Provider: "p", Capability: "chat", Model: "m"}, Response: RecordedResponse{Status: 200, Body: json.RawMessage(oldBody)}}
+		newGolden := &GoldenFile{Metadata: GoldenMetadata{Provider: "p", Capability: "chat", Model: "m"}, Response: RecordedResponse{Status: 200, Body: json.RawMessage(testCase.newBody)}}
+
+		diff := DiffGoldenFiles(oldGolden, newGolden, NewDiffRules(true))
+		got := make(map[string]ChangeKind)
+		breaking := 0
+		for _, change := range diff.Changes {
+			got[change.Path] = change.Kind
+			if change.Breaking {
+				breaking++
+			}
+		}
+		if len(got) != len(testCase.wantChanges) || breaking != testCase.wantBreaking {
+			t.Fatalf("%s: expected %v (%d breaking), got %+v", testCase.name, testCase.wantChanges, testCase.wantBreaking, diff.Changes)
+		}
+		for fieldPath, kind := range testCase.wantChanges {
+			if got[fieldPath] != kind {
+				t.Fatalf("%s: expected %s to be %s, got %q", testCase.name, fieldPath, kind, got[fieldPath])
+			}
+		}
+	}
+}
+
+// TestDiffRulesIgnoredPatterns ensures glob and span patterns match field paths.
+func TestDiffRulesIgnoredPatterns(t *testing.T) {
+
+	rules := NewDiffRules(false, "**.id", "usage.**", "choices[].logprobs", "**.*_tokens")
+	testCases := []struct {
+		path string
+		want bool
+	}{
+		{path: "id", want: true},
+		{path: "choices[].message.tool_calls[].id", want: true},
+		{path: "usage", want: true},
+		{path: "usage.prompt_tokens_details.cached_tokens", want: true},
+		{path: "choices[].logprobs", want: true},
+		{path: "choices[].logprobs.content", want: false},
+		{path: "events[].data.message.output_tokens", want: true},
+		{path: "choices[].message.content", want: false},
+	}
+
+	for _, testCase := range testCases {
+		if got := rules.Ignored(testCase.path); got != testCase.want {
+			t.Fatalf("path %q: expected ignored=%v, got %v", testCase.path, testCase.want, got)
+		}
+	}
+}
+
+// TestDiffGoldenDirsReportsStreamDriftAndMissingFiles ensures directory diffs compare the newest recordings, streams, and coverage.
+func TestDiffGoldenDirsReportsStreamDriftAndMissingFiles(t *testing.T) {
+
+	oldDir, newDir := t.TempDir(), t.TempDir()
+	streamHeaders := map[string]string{"Content-Type": "text/event-stream"}
+	save := func(dir string, capability string, day int, response RecordedResponse) {
+		golden := &GoldenFile{
+			Metadata: GoldenMetadata{Provider: "p", Capability: capability, Model: "m", Timestamp: time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC), Version: GoldenFileVersion},
+			Response: response,
+		}
+		if err := golden.Save(dir); err != nil {
+			t.Fatalf("save golden: %v", err)
+		}
+	}
+
+	save(oldDir, "streaming", 1, RecordedResponse{Status: 200, Headers: streamHeaders, RawBody: "data: {\"choices\":[{\"delta\":{\"content\":\"1\"}}]}\n\ndata: [DONE]\n\n"})
+	save(oldDir, "chat", 1, RecordedResponse{Status: 200, Body: json.RawMessage(`{"ok":true}`)})
+	save(newDir, "streaming", 1, RecordedResponse{Status: 200, Headers: streamHeaders, RawBody: "data: {\"choices\":[{\"delta\":{\"content\":\"1\"}}]}\n\ndata: [DONE]\n\n"})
+	save(newDir, "streaming", 2, RecordedResponse{Status: 200, Headers: streamHeaders, RawBody: "data: {\"choices\":[{\"delta\":{\"text\":\"1\"}}]}\n\ndata: [DONE]\n\n"})
+
+	report, err := DiffGoldenDirs(oldDir, newDir, NewDiffRules(true))
+	if err != nil {
+		t.Fatalf("diff dirs: %v", err)
+	}
+	if report.Changed != 2 || report.Breaking != 1 {
+		t.Fatalf("expected 2 changed files and 1 breaking change, got %+v", report)
+	}
+
+	var text bytes.Buffer
+	if err := report.WriteText(&text); err != nil {
+		t.Fatalf("write text: %v", err)
+	}
+	for _, want := range []string{"p/chat/m [missing]", "removed", "events[].data.choices[].delta.content", "1 breaking change(s)"} {
+		if !strings.Contains(text.String(), want) {
+			t.Fatalf("expected text report to contain %q, got:\n%s", want, text.String())
+		}
+	}
+
+	var encoded bytes.Buffer
+	if err := report.WriteJSON(&encoded); err != nil {
+		t.Fatalf("write json: %v", err)
+	}
+	var decoded DiffReport
+	if err := json.Unmarshal(encoded.Bytes(), &decoded); err != nil || decoded.Breaking != 1 {
+		t.Fatalf("expected JSON report round trip, got %v / %+v", err, decoded)
+	}
+}
@@ -0,0 +1,33 @@
+// driver.go defines how capability tests reach a provider.
+// pkg/models/modeltest/driver.go
+package modeltest
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	// DriverHTTP runs testers through the package's own raw HTTP client.
+	DriverHTTP = "http"
+	// DriverAdapter runs testers through the application's production provider adapters.
+	DriverAdapter = "adapter"
+)
+
+// AdapterDriver exercises production provider adapters over an injected transport.
+// It is supplied by the host binary because the adapters live outside this package.
+type AdapterDriver interface {
+	// Supports reports whether the adapters can express a capability scenario.
+	Supports(capability Capability) bool
+	// Test runs one capability scenario with all provider HTTP traffic sent through transport.
+	Test(ctx context.Context, prov ProviderConfig, model string, capability Capability, transport http.RoundTripper) TestResult
+}
+
+// goldenDriver returns the golden namespace for a runner driver; the raw client keeps the legacy unprefixed names.
+func goldenDriver(driver string) string {
+
+	if driver == DriverAdapter {
+		return DriverAdapter
+	}
+	return ""
+}
@@ -0,0 +1,398 @@
+// eval.go runs prompt suites against planned models and aggregates scorecards.
+// pkg/models/modeltest/eval.go
+package modeltest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ScorecardSource is the system_profile_source recorded for eval-derived tiers.
+const ScorecardSource = "eval"
+
+// EvalConfig configures an eval run.
+type EvalConfig struct {
+	Mode      string   // "live" or "mock"
+	OutputDir string   // Golden file directory used for recording and replay
+	Providers []string // Providers to evaluate (empty = all)
+	Models    []string // Model IDs to evaluate (empty = all chat models)
+	Samples   int      // Overrides the suite sample count when > 0
+	Timeout   time.Duration
+}
+
+// Scorecard aggregates one model's results for a suite.
+type Scorecard struct {
+	Suite               string      `json:"suite"`
+	Provider            string      `json:"provider"`
+	Model               string      `json:"model"`
+	Samples             int         `json:"samples"`
+	Score               float64     `json:"score"`     // Weighted mean score, 0..1
+	PassRate            float64     `json:"pass_rate"` // Fraction of samples that passed
+	Errors              int         `json:"errors"`
+	MeanLatencyMS       int64       `json:"mean_latency_ms"`
+	ReliabilityTier     string      `json:"reliability_tier"`
+	SystemProfileSource string      `json:"system_profile_source"`
+	AsOf                time.Time   `json:"as_of"`
+	Cases               []CaseScore `json:"cases"`
+}
+
+// CaseScore aggregates the samples of one case.
+type CaseScore struct {
+	ID       string        `json:"id"`
+	Scorer   string        `json:"scorer"`
+	Weight   float64       `json:"weight"`
+	Score    float64       `json:"score"`
+	PassRate float64       `json:"pass_rate"`
+	Samples  []SampleScore `json:"samples"`
+}
+
+// SampleScore is the result of one sampled answer.
+type SampleScore struct {
+	Sample    int     `json:"sample"`
+	Score     float64 `json:"score"`
+	Passed    bool    `json:"passed"`
+	Answer    string  `json:"answer,omitempty"`
+	Detail    string  `json:"detail,omitempty"`
+	Error     string  `json:"error,omitempty"`
+	LatencyMS int64   `json:"latency_ms"`
+}
+
+// EvalRunner runs suites against a test plan.
+type EvalRunner struct {
+	config EvalConfig
+	plan   *TestPlan
+	index  *GoldenIndex
+}
+
+// NewEvalRunner creates an eval runner for a plan.
+func NewEvalRunner(config EvalConfig, plan *TestPlan) *EvalRunner {
+
+	if config.Timeout <= 0 {
+		config.Timeout = 60 * time.Second
+	}
+	return &EvalRunner{config: config, plan: plan}
+}
+
+// Run evaluates every selected chat model and returns one scorecard per model.
+func (r *EvalRunner) Run(ctx context.Context, suite *EvalSuite) ([]Scorecard, error) {
+
+	if r.plan == nil {
+		return nil, fmt.Errorf("no test plan loaded")
+	}
+	if r.config.Mode != "live" {
+		files, err := LoadGoldenFiles(r.config.OutputDir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("load golden files: %w", err)
+		}
+		r.index = NewGoldenIndex(files)
+	}
+
+	var judgeProvider *ProviderConfig
+	if suite.UsesJudge() {
+		judgeProvider = r.findProvider(suite.Judge.Provider)
+		if judgeProvider == nil {
+			return nil, fmt.Errorf("judge provider %q is not in the test plan", suite.Judge.Provider)
+		}
+	}
+
+	samples := suite.Samples
+	if r.config.Samples > 0 {
+		samples = r.config.Samples
+	}
+
+	scorecards := make([]Scorecard, 0)
+	for _, prov := range r.plan.Providers {
+		if !containsOrEmpty(r.config.Providers, prov.Name) {
+			continue
+		}
+		for _, model := range prov.Models {
+			if !containsOrEmpty(r.config.Models, model.ID) || !hasCapability(model.Capabilities, CapabilityChat) {
+				continue
+			}
+			scorecards = append(scorecards, r.evaluateModel(ctx, suite, samples, prov, model.ID, judgeProvider))
+		}
+	}
+	return scorecards, nil
+}
+
+// evaluateModel runs every case and sample for one model.
+func (r *EvalRunner) evaluateModel(ctx context.Context, suite *EvalSuite, samples int, prov ProviderConfig, model string, judgeProvider *ProviderConfig) Scorecard {
+
+	scorecard := Scorecard{
+		Suite:               suite.Name,
+		Provider:            prov.Name,
+		Model:               model,
+		Samples:             samples,
+		SystemProfileSource: ScorecardSource,
+		AsOf:                time.Now().UTC(),
+	}
+
+	for _, evalCase := range suite.Cases {
+		caseScore := CaseScore{ID: evalCase.ID, Scorer: evalCase.Scorer, Weight: evalCase.Weight}
+		for sample := 0; sample < samples; sample++ {
+			caseScore.Samples = append(caseScore.Samples, r.evaluateSample(ctx, suite, evalCase, sample, prov, model, judgeProvider))
+		}
+		caseScore.aggregate()
+		scorecard.Cases = append(scorecard.Cases, caseScore)
+	}
+	scorecard.aggregate()
+	return scorecard
+}
+
+// evaluateSample asks the model once and scores the answer.
+func (r *EvalRunner) evaluateSample(ctx context.Context, suite *EvalSuite, evalCase EvalCase, sample int, prov ProviderConfig, model string, judgeProvider *ProviderConfig) SampleScore {
+
+	ctx, cancel := context.WithTimeout(ctx, r.config.Timeout)
+	defer cancel()
+
+	result := SampleScore{Sample: sample + 1}
+	capability := evalCapability("eval", suite.Name, evalCase.ID, fmt.Sprint(sample+1))
+	client, finish := r.client(prov, model, capability)
+
+	started := time.Now()
+	answer, err := askEvalCase(ctx, client, model, evalCase)
+	result.LatencyMS = time.Since(started).Milliseconds()
+	if saveErr := finish(err == nil); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Answer = truncate(answer, 500)
+
+	var judge Judge
+	var finishJudge func(bool) error
+	if evalCase.Scorer == ScorerJudge {
+		judgeCapability := evalCapability("judge", suite.Name, evalCase.ID, fmt.Sprint(sample+1), prov.Name, model)
+		var judgeClient *Client
+		judgeClient, finishJudge = r.client(*judgeProvider, suite.Judge.Model, judgeCapability)
+		judge = &clientJudge{client: judgeClient, model: suite.Judge.Model}
+	}
+
+	scored, err := ScoreAnswer(ctx, evalCase, answer, judge)
+	if finishJudge != nil {
+		if saveErr := finishJudge(err == nil); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Score = scored.Score
+	result.Passed = scored.Passed
+	result.Detail = scored.Detail
+	return result
+}
+
+// client builds a recording or replaying client; finish saves live recordings when the exchange succeeded.
+func (r *EvalRunner) client(prov ProviderConfig, model string, capability string) (*Client, func(bool) error) {
+
+	client := NewClient(ClientConfig{
+		ProviderName: prov.Name,
+		ProviderType: prov.Type,
+		BaseURL:      prov.BaseURL,
+		APIKey:       providerAPIKey(prov),
+	})
+
+	if r.config.Mode != "live" {
+		client.SetTransport(NewCapabilityMockTransport(r.index, prov.Name, model, Capability(capability)))
+		return client, func(bool) error { return nil }
+	}
+
+	recorder := NewRecordingTransport(nil)
+	client.SetTransport(recorder)
+	return client, func(succeeded bool) error {
+		if !succeeded || len(recorder.Recordings()) == 0 {
+			return nil
+		}
+		golden := NewGoldenFileFromRecordings(prov.Name, capability, model, recorder.Recordings())
+		if err := golden.Save(r.config.OutputDir); err != nil {
+			return fmt.Errorf("save golden file: %w", err)
+		}
+		return nil
+	}
+}
+
+// findProvider returns the planned provider with a name.
+func (r *EvalRunner) findProvider(name string) *ProviderConfig {
+
+	for index := range r.plan.Providers {
+		if r.plan.Providers[index].Name == name {
+			return &r.plan.Providers[index]
+		}
+	}
+	return nil
+}
+
+// askEvalCase sends one case prompt and returns the answer text.
+func askEvalCase(ctx context.Context, client *Client, model string, evalCase EvalCase) (string, error) {
+
+	providerType := client.Config().ProviderType
+	request := buildTextChatRequest(providerType, model, []conversationTurn{{Role: "user", Text: evalCase.Prompt}}, false)
+	if strings.TrimSpace(evalCase.System) != "" {
+		applySystemPrompt(providerType, request, evalCase.System)
+	}
+
+	body, _, err := postJSON(ctx, client, chatPath(providerType, model, false), request)
+	if err != nil {
+		return "", err
+	}
+	return extractResponseText(providerType, body)
+}
+
+// applySystemPrompt adds a system prompt in each provider's native position.
+func applySystemPrompt(providerType ProviderType, request map[string]interface{}, system string) {
+
+	switch providerType {
+	case ProviderTypeGemini:
+		request["systemInstruction"] = map[string]interface{}{
+			"parts": []map[string]interface{}{{"text": system}},
+		}
+	case ProviderTypeAnthropic:
+		request["system"] = system
+	default:
+		messages, _ := request["messages"].([]map[string]interface{})
+		request["messages"] = append([]map[string]interface{}{{"role": "system", "content": system}}, messages...)
+	}
+}
+
+// aggregate computes the case score and pass rate from its samples.
+func (c *CaseScore) aggregate() {
+
+	if len(c.Samples) == 0 {
+		return
+	}
+	passed := 0
+	total := 0.0
+	for _, sample := range c.Samples {
+		total += sample.Score
+		if sample.Passed {
+			passed++
+		}
+	}
+	c.Score = total / float64(len(c.Samples))
+	c.PassRate = float64(passed) / float64(len(c.Samples))
+}
+
+// aggregate computes weighted totals, latency, and the reliability tier.
+func (s *Scorecard) aggregate() {
+
+	weightedScore, totalWeight := 0.0, 0.0
+	passed, sampleCount := 0, 0
+	var latencyTotal int64
+	for _, caseScore := range s.Cases {
+		weightedScore += caseScore.Score * caseScore.Weight
+		totalWeight += caseScore.Weight
+		for _, sample := range caseScore.Samples {
+			sampleCount++
+			latencyTotal += sample.LatencyMS
+			if sample.Passed {
+				passed++
+			}
+			if sample.Error != "" {
+				s.Errors++
+			}
+		}
+	}
+	if totalWeight > 0 {
+		s.Score = weightedScore / totalWeight
+	}
+	if sampleCount > 0 {
+		s.PassRate = float64(passed) / float64(sampleCount)
+		s.MeanLatencyMS = latencyTotal / int64(sampleCount)
+	}
+	s.ReliabilityTier = ReliabilityTier(s.PassRate, s.Errors)
+}
+
+// ReliabilityTier maps a pass rate to the catalog's low/medium/high reliability tiers; any error caps the tier at medium.
+func ReliabilityTier(passRate float64, errors int) string {
+
+	switch {
+	case passRate >= 0.9 && errors == 0:
+		return "high"
+	case passRate >= 0.7:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// Filename returns the scorecard file name within its provider directory.
+func (s Scorecard) Filename() string {
+
+	return evalCapability(s.Suite, s.Model) + ".json"
+}
+
+// SaveScorecards writes scorecards as <dir>/<provider>/<suite>_<model>.json.
+func SaveScorecards(dir string, scorecards []Scorecard) error {
+
+	for _, scorecard := range scorecards {
+		providerDir := filepath.Join(dir, scorecard.Provider)
+		if err := os.MkdirAll(providerDir, 0755); err != nil {
+			return fmt.Errorf("create scorecard directory: %w", err)
+		}
+		data, err := json.MarshalIndent(scorecard, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal scorecard: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(providerDir, scorecard.Filename()), data, 0644); err != nil {
+			return fmt.Errorf("write scorecard: %w", err)
+		}
+	}
+	return nil
+}
+
+// unsafeNameChars matches characters not allowed in golden keys and file names.
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// evalCapability builds a filesystem-safe golden capability name from parts.
+func evalCapability(parts ...string) string {
+
+	sanitized := make([]string, 0, len(parts))
+	for _, part := range parts {
+		sanitized = append(sanitized, strings.Trim(unsafeNameChars.ReplaceAllString(part, "_"), "_"))
+	}
+	return strings.Join(sanitized, "_")
+}
+
+// providerAPIKey returns the configured key or <PROVIDER>_API_KEY from the environment.
+func providerAPIKey(prov ProviderConfig) string {
+
+	if prov.APIKey != "" {
+		return prov.APIKey
+	}
+	return os.Getenv(strings.ToUpper(prov.Name) + "_API_KEY")
+}
+
+// containsOrEmpty reports whether a filter is empty or contains value.
+func containsOrEmpty(filter []string, value string) bool {
+
+	if len(filter) == 0 {
+		return true
+	}
+	for _, item := range filter {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCapability reports whether capabilities include target.
+func hasCapability(capabilities []Capability, target Capability) bool {
+
+	for _, capability := range capabilities {
+		if capability == target {
+			return true
+		}
+	}
+	return false
+}
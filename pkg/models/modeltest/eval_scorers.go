@@ -0,0 +1,282 @@
+// eval_scorers.go scores model answers for eval cases.
+// pkg/models/modeltest/eval_scorers.go
+package modeltest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ScoreResult is the outcome of scoring one answer.
+type ScoreResult struct {
+	Score  float64 `json:"score"` // 0..1
+	Passed bool    `json:"passed"`
+	Detail string  `json:"detail,omitempty"`
+}
+
+// Judge grades answers against a rubric.
+type Judge interface {
+	Grade(ctx context.Context, evalCase EvalCase, answer string) (ScoreResult, error)
+}
+
+// ScoreAnswer applies the case's scorer to an answer. The judge is only used by judge cases.
+func ScoreAnswer(ctx context.Context, evalCase EvalCase, answer string, judge Judge) (ScoreResult, error) {
+
+	switch evalCase.Scorer {
+	case ScorerExact:
+		return scoreExact(evalCase, answer), nil
+	case ScorerContains:
+		return scoreContains(evalCase, answer), nil
+	case ScorerRegex:
+		return scoreRegex(evalCase, answer)
+	case ScorerJSONSchema:
+		return scoreJSONSchema(evalCase, answer), nil
+	case ScorerJudge:
+		if judge == nil {
+			return ScoreResult{}, fmt.Errorf("judge scorer: no judge configured")
+		}
+		return judge.Grade(ctx, evalCase, answer)
+	default:
+		return ScoreResult{}, fmt.Errorf("unknown scorer %q", evalCase.Scorer)
+	}
+}
+
+// scoreExact passes when the trimmed answer equals any expected value.
+func scoreExact(evalCase EvalCase, answer string) ScoreResult {
+
+	answer = strings.TrimSpace(answer)
+	for _, expected := range evalCase.Expected {
+		expected = strings.TrimSpace(expected)
+		if answer == expected || (evalCase.IgnoreCase && strings.EqualFold(answer, expected)) {
+			return ScoreResult{Score: 1, Passed: true}
+		}
+	}
+	return ScoreResult{Detail: fmt.Sprintf("answer %q matched none of %q", truncate(answer, 80), []string(evalCase.Expected))}
+}
+
+// scoreContains scores the fraction of expected values found; all must be present to pass.
+func scoreContains(evalCase EvalCase, answer string) ScoreResult {
+
+	haystack := answer
+	if evalCase.IgnoreCase {
+		haystack = strings.ToLower(answer)
+	}
+	found := 0
+	missing := make([]string, 0)
+	for _, expected := range evalCase.Expected {
+		needle := expected
+		if evalCase.IgnoreCase {
+			needle = strings.ToLower(expected)
+		}
+		if strings.Contains(haystack, needle) {
+			found++
+			continue
+		}
+		missing = append(missing, expected)
+	}
+
+	result := ScoreResult{
+		Score:  float64(found) / float64(len(evalCase.Expected)),
+		Passed: len(missing) == 0,
+	}
+	if len(missing) > 0 {
+		result.Detail = fmt.Sprintf("missing %q", missing)
+	}
+	return result
+}
+
+// scoreRegex passes when the pattern matches the answer.
+func scoreRegex(evalCase EvalCase, answer string) (ScoreResult, error) {
+
+	pattern := evalCase.Pattern
+	if evalCase.IgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return ScoreResult{}, fmt.Errorf("regex scorer: %w", err)
+	}
+	if compiled.MatchString(answer) {
+		return ScoreResult{Score: 1, Passed: true}, nil
+	}
+	return ScoreResult{Detail: fmt.Sprintf("answer %q did not match %s", truncate(answer, 80), evalCase.Pattern)}, nil
+}
+
+// scoreJSONSchema passes when the answer (optionally fenced) parses and satisfies the schema.
+func scoreJSONSchema(evalCase EvalCase, answer string) ScoreResult {
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(stripCodeFence(answer)), &value); err != nil {
+		return ScoreResult{Detail: fmt.Sprintf("answer is not JSON: %v", err)}
+	}
+	if err := validateJSONSchema(value, evalCase.Schema, "$"); err != nil {
+		return ScoreResult{Detail: err.Error()}
+	}
+	return ScoreResult{Score: 1, Passed: true}
+}
+
+// validateJSONSchema checks the subset of JSON Schema used by suites: type, enum, required, properties, additionalProperties, and items.
+func validateJSONSchema(value interface{}, schema map[string]interface{}, location string) error {
+
+	if rawType, ok := schema["type"]; ok && !matchesSchemaType(value, rawType) {
+		return fmt.Errorf("%s: expected type %v, got %s", location, rawType, jsonType(value))
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		matched := false
+		for _, allowed := range enum {
+			if fmt.Sprint(allowed) == fmt.Sprint(value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s: value %v not in enum %v", location, value, enum)
+		}
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if _, exists := typed[fmt.Sprint(name)]; !exists {
+					return fmt.Errorf("%s: missing required property %q", location, name)
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			propertySchema, known := properties[key].(map[string]interface{})
+			if !known {
+				if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+					return fmt.Errorf("%s: unexpected property %q", location, key)
+				}
+				continue
+			}
+			if err := validateJSONSchema(typed[key], propertySchema, location+"."+key); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for index, item := range typed {
+				if err := validateJSONSchema(item, items, fmt.Sprintf("%s[%d]", location, index)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// matchesSchemaType reports whether a value satisfies a schema type name or list of names.
+func matchesSchemaType(value interface{}, rawType interface{}) bool {
+
+	names := make([]string, 0, 1)
+	switch typed := rawType.(type) {
+	case string:
+		names = append(names, typed)
+	case []interface{}:
+		for _, name := range typed {
+			names = append(names, fmt.Sprint(name))
+		}
+	}
+
+	actual := jsonType(value)
+	if _, ok := value.(map[string]interface{}); ok {
+		actual = "object"
+	}
+	if _, ok := value.([]interface{}); ok {
+		actual = "array"
+	}
+	for _, name := range names {
+		if name == actual {
+			return true
+		}
+		if number, ok := value.(float64); ok && name == "integer" && number == float64(int64(number)) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripCodeFence removes a surrounding markdown code fence from model output.
+func stripCodeFence(text string) string {
+
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "```") {
+		return trimmed
+	}
+	trimmed = strings.TrimPrefix(trimmed, "```")
+	if newline := strings.IndexByte(trimmed, '\n'); newline >= 0 {
+		trimmed = trimmed[newline+1:]
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(trimmed), "```"))
+}
+
+// truncate shortens text for report details.
+func truncate(text string, limit int) string {
+
+	if len(text) <= limit {
+		return text
+	}
+	return text[:limit] + "..."
+}
+
+// clientJudge grades answers by asking a model through a modeltest client.
+type clientJudge struct {
+	client *Client
+	model  string
+}
+
+// Grade asks the judge model for a JSON score between 0 and 1.
+func (j *clientJudge) Grade(ctx context.Context, evalCase EvalCase, answer string) (ScoreResult, error) {
+
+	prompt := fmt.Sprintf(`You are grading an AI assistant's answer.
+
+Rubric:
+%s
+
+Question:
+%s
+
+Answer:
+%s
+
+Reply with only a JSON object: {"score": <number from 0 to 1>, "reason": "<one sentence>"}`, evalCase.Rubric, evalCase.Prompt, answer)
+
+	providerType := j.client.Config().ProviderType
+	request := buildTextChatRequest(providerType, j.model, []conversationTurn{{Role: "user", Text: prompt}}, false)
+	body, _, err := postJSON(ctx, j.client, chatPath(providerType, j.model, false), request)
+	if err != nil {
+		return ScoreResult{}, fmt.Errorf("judge: %w", err)
+	}
+	text, err := extractResponseText(providerType, body)
+	if err != nil {
+		return ScoreResult{}, fmt.Errorf("judge: %w", err)
+	}
+
+	var verdict struct {
+		Score  float64 `json:"score"`
+		Reason string  `json:"reason"`
+	}
+	if err := json.Unmarshal([]byte(stripCodeFence(text)), &verdict); err != nil {
+		return ScoreResult{}, fmt.Errorf("judge returned invalid verdict %q: %w", truncate(text, 80), err)
+	}
+	if verdict.Score < 0 || verdict.Score > 1 {
+		return ScoreResult{}, fmt.Errorf("judge score %v outside 0..1", verdict.Score)
+	}
+	return ScoreResult{
+		Score:  verdict.Score,
+		Passed: verdict.Score >= evalCase.Threshold,
+		Detail: verdict.Reason,
+	}, nil
+}
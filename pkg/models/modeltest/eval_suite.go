@@ -0,0 +1,174 @@
+// eval_suite.go defines YAML prompt suites for model quality evaluation.
+// pkg/models/modeltest/eval_suite.go
+package modeltest
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scorer names supported by eval cases.
+const (
+	ScorerExact      = "exact"
+	ScorerContains   = "contains"
+	ScorerRegex      = "regex"
+	ScorerJSONSchema = "json_schema"
+	ScorerJudge      = "judge"
+)
+
+// defaultJudgeThreshold is the minimum judge score counted as a pass.
+const defaultJudgeThreshold = 0.5
+
+// EvalSuite is a named set of prompts scored against expectations.
+type EvalSuite struct {
+	Name    string       `yaml:"name"`
+	Samples int          `yaml:"samples"` // Repeated samples per case (default 1)
+	Judge   *JudgeConfig `yaml:"judge,omitempty"`
+	Cases   []EvalCase   `yaml:"cases"`
+}
+
+// JudgeConfig selects the provider and model used by the judge scorer.
+type JudgeConfig struct {
+	Provider string `yaml:"provider"`
+	Model    string `yaml:"model"`
+}
+
+// EvalCase is one prompt with its scoring rule.
+type EvalCase struct {
+	ID         string                 `yaml:"id"`
+	System     string                 `yaml:"system,omitempty"`
+	Prompt     string                 `yaml:"prompt"`
+	Scorer     string                 `yaml:"scorer"`
+	Expected   StringList             `yaml:"expected,omitempty"` // exact: any value matches; contains: all values must appear
+	Pattern    string                 `yaml:"pattern,omitempty"`
+	Schema     map[string]interface{} `yaml:"schema,omitempty"`
+	Rubric     string                 `yaml:"rubric,omitempty"`
+	Threshold  float64                `yaml:"threshold,omitempty"`
+	IgnoreCase bool                   `yaml:"ignore_case,omitempty"`
+	Weight     float64                `yaml:"weight,omitempty"` // Relative weight in the scorecard (default 1)
+}
+
+// LoadEvalSuite reads and validates a suite file.
+func LoadEvalSuite(path string) (*EvalSuite, error) {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read eval suite: %w", err)
+	}
+	return ParseEvalSuite(data)
+}
+
+// ParseEvalSuite decodes and validates suite YAML.
+func ParseEvalSuite(data []byte) (*EvalSuite, error) {
+
+	var suite EvalSuite
+	if err := yaml.Unmarshal(data, &suite); err != nil {
+		return nil, fmt.Errorf("parse eval suite: %w", err)
+	}
+	if err := suite.Validate(); err != nil {
+		return nil, err
+	}
+	return &suite, nil
+}
+
+// Validate checks suite structure and applies defaults.
+func (s *EvalSuite) Validate() error {
+
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" {
+		return fmt.Errorf("eval suite: name required")
+	}
+	if s.Samples <= 0 {
+		s.Samples = 1
+	}
+	if len(s.Cases) == 0 {
+		return fmt.Errorf("eval suite %q: at least one case required", s.Name)
+	}
+
+	seen := make(map[string]struct{}, len(s.Cases))
+	for index := range s.Cases {
+		evalCase := &s.Cases[index]
+		if strings.TrimSpace(evalCase.ID) == "" {
+			return fmt.Errorf("eval suite %q: case %d: id required", s.Name, index+1)
+		}
+		if _, exists := seen[evalCase.ID]; exists {
+			return fmt.Errorf("eval suite %q: duplicate case id %q", s.Name, evalCase.ID)
+		}
+		seen[evalCase.ID] = struct{}{}
+		if strings.TrimSpace(evalCase.Prompt) == "" {
+			return fmt.Errorf("eval suite %q: case %q: prompt required", s.Name, evalCase.ID)
+		}
+		if evalCase.Weight <= 0 {
+			evalCase.Weight = 1
+		}
+		if err := s.validateScorer(evalCase); err != nil {
+			return fmt.Errorf("eval suite %q: case %q: %w", s.Name, evalCase.ID, err)
+		}
+	}
+	return nil
+}
+
+// validateScorer checks that a case carries the fields its scorer needs.
+func (s *EvalSuite) validateScorer(evalCase *EvalCase) error {
+
+	switch evalCase.Scorer {
+	case ScorerExact, ScorerContains:
+		if len(evalCase.Expected) == 0 {
+			return fmt.Errorf("%s scorer requires expected values", evalCase.Scorer)
+		}
+	case ScorerRegex:
+		if _, err := regexp.Compile(evalCase.Pattern); err != nil || evalCase.Pattern == "" {
+			return fmt.Errorf("regex scorer requires a valid pattern")
+		}
+	case ScorerJSONSchema:
+		if len(evalCase.Schema) == 0 {
+			return fmt.Errorf("json_schema scorer requires a schema")
+		}
+	case ScorerJudge:
+		if strings.TrimSpace(evalCase.Rubric) == "" {
+			return fmt.Errorf("judge scorer requires a rubric")
+		}
+		if s.Judge == nil || s.Judge.Provider == "" || s.Judge.Model == "" {
+			return fmt.Errorf("judge scorer requires a suite judge provider and model")
+		}
+		if evalCase.Threshold <= 0 {
+			evalCase.Threshold = defaultJudgeThreshold
+		}
+	default:
+		return fmt.Errorf("unknown scorer %q", evalCase.Scorer)
+	}
+	return nil
+}
+
+// UsesJudge reports whether any case needs the judge provider.
+func (s *EvalSuite) UsesJudge() bool {
+
+	for _, evalCase := range s.Cases {
+		if evalCase.Scorer == ScorerJudge {
+			return true
+		}
+	}
+	return false
+}
+
+// StringList decodes either a single YAML string or a list of strings.
+type StringList []string
+
+// UnmarshalYAML accepts scalar and sequence nodes.
+func (l *StringList) UnmarshalYAML(node *yaml.Node) error {
+
+	if node.Kind == yaml.ScalarNode {
+		*l = StringList{node.Value}
+		return nil
+	}
+	var values []string
+	if err := node.Decode(&values); err != nil {
+		return err
+	}
+	*l = values
+	return nil
+}
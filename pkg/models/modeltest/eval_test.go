@@ -0,0 +1,171 @@
+// eval_test.go verifies eval suites, scorers, and mock-mode scorecards.
+// pkg/models/modeltest/eval_test.go
+package modeltest
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseEvalSuiteValidatesCases ensures suites reject cases missing scorer inputs.
+func TestParseEvalSuiteValidatesCases(t *testing.T) {
+
+	testCases := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "valid", yaml: "name: s\ncases:\n  - id: a\n    prompt: p\n    scorer: contains\n    expected: Paris\n"},
+		{name: "missing expected", yaml: "name: s\ncases:\n  - id: a\n    prompt: p\n    scorer: exact\n", wantErr: "expected values"},
+		{name: "bad regex", yaml: "name: s\ncases:\n  - id: a\n    prompt: p\n    scorer: regex\n    pattern: '('\n", wantErr: "valid pattern"},
+		{name: "judge without provider", yaml: "name: s\ncases:\n  - id: a\n    prompt: p\n    scorer: judge\n    rubric: r\n", wantErr: "judge provider"},
+		{name: "duplicate", yaml: "name: s\ncases:\n  - {id: a, prompt: p, scorer: regex, pattern: x}\n  - {id: a, prompt: p, scorer: regex, pattern: x}\n", wantErr: "duplicate"},
+	}
+
+	for _, testCase := range testCases {
+		suite, err := ParseEvalSuite([]byte(testCase.yaml))
+		if testCase.wantErr == "" {
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", testCase.name, err)
+			}
+			if suite.Samples != 1 || suite.Cases[0].Weight != 1 || len(suite.Cases[0].Expected) != 1 {
+				t.Fatalf("%s: expected defaults to be applied, got %+v", testCase.name, suite)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), testCase.wantErr) {
+			t.Fatalf("%s: expected error containing %q, got %v", testCase.name, testCase.wantErr, err)
+		}
+	}
+}
+
+// TestScoreAnswerDeterministicScorers ensures exact, contains, regex, and json_schema scorers grade answers.
+func TestScoreAnswerDeterministicScorers(t *testing.T) {
+
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"required":             []interface{}{"name"},
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{"type": "string"},
+			"tags": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		},
+	}
+	testCases := []struct {
+		name      string
+		evalCase  EvalCase
+		answer    string
+		wantScore float64
+		wantPass  bool
+	}{
+		{name: "exact ignore case", evalCase: EvalCase{Scorer: ScorerExact, Expected: StringList{"Paris"}, IgnoreCase: true}, answer: " paris ", wantScore: 1, wantPass: true},
+		{name: "exact mismatch", evalCase: EvalCase{Scorer: ScorerExact, Expected: StringList{"Paris"}}, answer: "paris"},
+		{name: "contains partial", evalCase: EvalCase{Scorer: ScorerContains, Expected: StringList{"red", "blue"}}, answer: "red only", wantScore: 0.5},
+		{name: "regex", evalCase: EvalCase{Scorer: ScorerRegex, Pattern: `^\d+$`}, answer: "42", wantScore: 1, wantPass: true},
+		{name: "schema fenced", evalCase: EvalCase{Scorer: ScorerJSONSchema, Schema: schema}, answer: "```json\n{\"name\":\"Ada\",\"tags\":[\"math\"]}\n```", wantScore: 1, wantPass: true},
+		{name: "schema item type", evalCase: EvalCase{Scorer: ScorerJSONSchema, Schema: schema}, answer: `{"name":"Ada","tags":[1]}`},
+		{name: "schema extra", evalCase: EvalCase{Scorer: ScorerJSONSchema, Schema: schema}, answer: `{"name":"Ada","age":36}`},
+	}
+
+	for _, testCase := range testCases {
+		result, err := ScoreAnswer(context.Background(), testCase.evalCase, testCase.answer, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", testCase.name, err)
+		}
+		if result.Score != testCase.wantScore || result.Passed != testCase.wantPass {
+			t.Fatalf("%s: expected score=%v pass=%v, got %+v", testCase.name, testCase.wantScore, testCase.wantPass, result)
+		}
+	}
+}
+
+// TestEvalRunnerReplaysGoldensIntoScorecards ensures mock mode replays model and judge goldens and persists scorecards.
+func TestEvalRunnerReplaysGoldensIntoScorecards(t *testing.T) {
+
+	goldenDir := t.TempDir()
+	suite, err := ParseEvalSuite([]byte(`
+name: basics
+samples: 2
+judge: {provider: openai, model: judge-model}
+cases:
+  - id: capital
+    prompt: What is the capital of France?
+    scorer: contains
+    expected: Paris
+  - id: polite
+    prompt: Decline politely.
+    scorer: judge
+    rubric: The answer is polite.
+    weight: 3
+`))
+	if err != nil {
+		t.Fatalf("parse suite: %v", err)
+	}
+
+	answers := map[string]string{
+		evalCapability("eval", "basics", "capital", "1"): "Paris.",
+		evalCapability("eval", "basics", "capital", "2"): "Lyon.",
+		evalCapability("eval", "basics", "polite", "1"):  "Sorry, I can't.",
+		evalCapability("eval", "basics", "polite", "2"):  "No.",
+	}
+	for capability, answer := range answers {
+		saveChatGolden(t, goldenDir, capability, "m", answer)
+	}
+	saveChatGolden(t, goldenDir, evalCapability("judge", "basics", "polite", "1", "openai", "m"), "judge-model", `{"score":0.9,"reason":"polite"}`)
+	saveChatGolden(t, goldenDir, evalCapability("judge", "basics", "polite", "2", "openai", "m"), "judge-model", `{"score":0.2,"reason":"curt"}`)
+
+	plan := &TestPlan{Providers: []ProviderConfig{{Name: "openai", Type: ProviderTypeOpenAI, Models: []ModelConfig{
+		{ID: "m", Capabilities: []Capability{CapabilityChat}},
+		{ID: "image-only", Capabilities: []Capability{CapabilityImageGen}},
+	}}}}
+	scorecards, err := NewEvalRunner(EvalConfig{Mode: "mock", OutputDir: goldenDir, Timeout: time.Second}, plan).Run(context.Background(), suite)
+	if err != nil {
+		t.Fatalf("run eval: %v", err)
+	}
+	if len(scorecards) != 1 {
+		t.Fatalf("expected one scorecard for the chat model, got %d", len(scorecards))
+	}
+
+	scorecard := scorecards[0]
+	wantScore := (0.5*1 + 0.55*3) / 4
+	if diff := scorecard.Score - wantScore; diff > 1e-9 || diff < -1e-9 || scorecard.PassRate != 0.5 || scorecard.Errors != 0 {
+		t.Fatalf("unexpected scorecard totals: %+v", scorecard)
+	}
+	if scorecard.ReliabilityTier != "low" || scorecard.SystemProfileSource != ScorecardSource {
+		t.Fatalf("unexpected scorecard tier metadata: %+v", scorecard)
+	}
+
+	scorecardDir := t.TempDir()
+	if err := SaveScorecards(scorecardDir, scorecards); err != nil {
+		t.Fatalf("save scorecards: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(scorecardDir, "openai", "basics_m.json"))
+	if err != nil {
+		t.Fatalf("read scorecard: %v", err)
+	}
+	var saved Scorecard
+	if err := json.Unmarshal(data, &saved); err != nil || len(saved.Cases) != 2 {
+		t.Fatalf("expected saved scorecard with two cases, got %v / %+v", err, saved)
+	}
+}
+
+// saveChatGolden writes an OpenAI chat completion golden file for an eval capability.
+func saveChatGolden(t *testing.T, dir string, capability string, model string, answer string) {
+
+	t.Helper()
+	content, _ := json.Marshal(answer)
+	golden := &GoldenFile{
+		Metadata: GoldenMetadata{Provider: "openai", Capability: capability, Model: model, Version: GoldenFileVersion},
+		Response: RecordedResponse{
+			Status: 200,
+			Body:   json.RawMessage(`{"choices":[{"message":{"role":"assistant","content":` + string(content) + `},"finish_reason":"stop"}]}`),
+		},
+	}
+	if err := golden.Save(dir); err != nil {
+		t.Fatalf("save golden: %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+// exchange.go provides shared request and response helpers for capability testers.
+// pkg/models/modeltest/exchange.go
+package modeltest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// scenarioMaxTokens bounds completion length for scenario requests.
+const scenarioMaxTokens = 256
+
+// newResult creates a failing test result for a client, capability, and model.
+func newResult(client *Client, capability Capability, model string) TestResult {
+
+	return TestResult{
+		Provider:   client.Config().ProviderName,
+		Capability: capability,
+		Model:      model,
+	}
+}
+
+// postJSON sends a JSON request and returns the response body, failing on transport or HTTP errors.
+func postJSON(ctx context.Context, client *Client, path string, body interface{}) ([]byte, http.Header, error) {
+
+	resp, err := client.Do(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(payload))
+	}
+	return payload, resp.Header, nil
+}
+
+// chatPath returns the provider-specific chat endpoint path.
+func chatPath(providerType ProviderType, model string, stream bool) string {
+
+	switch providerType {
+	case ProviderTypeGemini:
+		if stream {
+			return fmt.Sprintf("/v1beta/models/%s:streamGenerateContent?alt=sse", model)
+		}
+		return fmt.Sprintf("/v1beta/models/%s:generateContent", model)
+	case ProviderTypeAnthropic:
+		return "/v1/messages"
+	default:
+		return "/v1/chat/completions"
+	}
+}
+
+// conversationTurn is a provider-neutral text turn used to build scenario requests.
+type conversationTurn struct {
+	Role string
+	Text string
+}
+
+// buildTextChatRequest builds a provider-specific chat request for plain text turns.
+func buildTextChatRequest(providerType ProviderType, model string, turns []conversationTurn, stream bool) map[string]interface{} {
+
+	switch providerType {
+	case ProviderTypeGemini:
+		contents := make([]map[string]interface{}, 0, len(turns))
+		for _, turn := range turns {
+			role := "user"
+			if turn.Role == "assistant" {
+				role = "model"
+			}
+			contents = append(contents, map[string]interface{}{
+				"role":  role,
+				"parts": []map[string]interface{}{{"text": turn.Text}},
+			})
+		}
+		return map[string]interface{}{"contents": contents}
+	case ProviderTypeAnthropic:
+		messages := make([]map[string]interface{}, 0, len(turns))
+		for _, turn := range turns {
+			messages = append(messages, map[string]interface{}{"role": turn.Role, "content": turn.Text})
+		}
+		request := map[string]interface{}{
+			"model":      model,
+			"max_tokens": scenarioMaxTokens,
+			"messages":   messages,
+		}
+		if stream {
+			request["stream"] = true
+		}
+		return request
+	default:
+		messages := make([]map[string]interface{}, 0, len(turns))
+		for _, turn := range turns {
+			messages = append(messages, map[string]interface{}{"role": turn.Role, "content": turn.Text})
+		}
+		request := map[string]interface{}{
+			"model":      model,
+			"max_tokens": scenarioMaxTokens,
+			"messages":   messages,
+		}
+		if stream {
+			request["stream"] = true
+			request["stream_options"] = map[string]interface{}{"include_usage": true}
+		}
+		return request
+	}
+}
+
+// extractResponseText extracts assistant text from a non-streamed provider response.
+func extractResponseText(providerType ProviderType, body []byte) (string, error) {
+
+	switch providerType {
+	case ProviderTypeGemini:
+		var parsed geminiResponse
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return "", fmt.Errorf("parse gemini response: %w", err)
+		}
+		return parsed.text(), nil
+	case ProviderTypeAnthropic:
+		var parsed anthropicResponse
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return "", fmt.Errorf("parse anthropic response: %w", err)
+		}
+		return parsed.text(), nil
+	default:
+		var parsed openAIResponse
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return "", fmt.Errorf("parse openai response: %w", err)
+		}
+		if len(parsed.Choices) == 0 {
+			return "", fmt.Errorf("response contained no choices")
+		}
+		return parsed.Choices[0].Message.Content, nil
+	}
+}
+
+// containsFold reports whether text contains target, ignoring case.
+func containsFold(text string, target string) bool {
+
+	return strings.Contains(strings.ToLower(text), strings.ToLower(target))
+}
+
+// openAIResponse is the subset of an OpenAI chat completion used by testers.
+type openAIResponse struct {
+	Choices []struct {
+		Message struct {
+			Role      string           `json:"role"`
+			Content   string           `json:"content"`
+			ToolCalls []openAIToolCall `json:"tool_calls"`
+		} `json:"message"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+// openAIToolCall is an OpenAI tool call entry.
+type openAIToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+// anthropicResponse is the subset of an Anthropic message used by testers.
+type anthropicResponse struct {
+	Content    []anthropicContentBlock `json:"content"`
+	StopReason string                  `json:"stop_reason"`
+}
+
+// anthropicContentBlock is an Anthropic content block.
+type anthropicContentBlock struct {
+	Type  string          `json:"type"`
+	Text  string          `json:"text,omitempty"`
+	ID    string          `json:"id,omitempty"`
+	Name  string          `json:"name,omitempty"`
+	Input json.RawMessage `json:"input,omitempty"`
+}
+
+// text concatenates text blocks from an Anthropic message.
+func (r anthropicResponse) text() string {
+
+	var builder strings.Builder
+	for _, block := range r.Content {
+		if block.Type == "text" {
+			builder.WriteString(block.Text)
+		}
+	}
+	return builder.String()
+}
+
+// geminiResponse is the subset of a Gemini GenerateContent response used by testers.
+type geminiResponse struct {
+	Candidates []struct {
+		Content struct {
+			Role  string       `json:"role"`
+			Parts []geminiPart `json:"parts"`
+		} `json:"content"`
+		FinishReason string `json:"finishReason"`
+	} `json:"candidates"`
+}
+
+// geminiPart is a Gemini content part.
+type geminiPart struct {
+	Text         string              `json:"text,omitempty"`
+	FunctionCall *geminiFunctionCall `json:"functionCall,omitempty"`
+}
+
+// geminiFunctionCall is a Gemini function call part.
+type geminiFunctionCall struct {
+	Name string                 `json:"name"`
+	Args map[string]interface{} `json:"args"`
+}
+
+// text concatenates text parts from the first Gemini candidate.
+func (r geminiResponse) text() string {
+
+	if len(r.Candidates) == 0 {
+		return ""
+	}
+	var builder strings.Builder
+	for _, part := range r.Candidates[0].Content.Parts {
+		builder.WriteString(part.Text)
+	}
+	return builder.String()
+}
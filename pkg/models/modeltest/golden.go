@@ -20,11 +20,20 @@ type GoldenMetadata struct {
 	Model      string    `json:"model"`
 	Timestamp  time.Time `json:"timestamp"`
 	Version    string    `json:"version"`
+	Driver     string    `json:"driver,omitempty"` // empty for the raw HTTP client, DriverAdapter for production adapters
 }
 
 // GoldenFile represents a complete golden file with metadata and recording.
+// Multi-request scenarios such as tool-calling round trips keep later exchanges in FollowUps.
 type GoldenFile struct {
-	Metadata GoldenMetadata   `json:"metadata"`
+	Metadata  GoldenMetadata   `json:"metadata"`
+	Request   RecordedRequest  `json:"request"`
+	Response  RecordedResponse `json:"response"`
+	FollowUps []GoldenExchange `json:"follow_ups,omitempty"`
+}
+
+// GoldenExchange is one additional request/response pair within a golden file.
+type GoldenExchange struct {
 	Request  RecordedRequest  `json:"request"`
 	Response RecordedResponse `json:"response"`
 }
@@ -44,13 +53,45 @@ func NewGoldenFile(provider, capability, model string, rec Recording) *GoldenFil
 	}
 }
 
+// NewGoldenFileFromRecordings creates a golden file from every recording of a scenario, in request order.
+func NewGoldenFileFromRecordings(provider, capability, model string, recs []Recording) *GoldenFile {
+
+	if len(recs) == 0 {
+		return nil
+	}
+
+	golden := NewGoldenFile(provider, capability, model, recs[0])
+	for _, rec := range recs[1:] {
+		golden.FollowUps = append(golden.FollowUps, GoldenExchange{
+			Request:  rec.Request,
+			Response: rec.Response,
+		})
+	}
+	return golden
+}
+
+// Exchange returns the response recorded for the zero-based request sequence number.
+func (g *GoldenFile) Exchange(sequence int) (RecordedResponse, bool) {
+
+	if sequence == 0 {
+		return g.Response, true
+	}
+	if sequence < 0 || sequence > len(g.FollowUps) {
+		return RecordedResponse{}, false
+	}
+	return g.FollowUps[sequence-1].Response, true
+}
+
 // Filename generates the standard filename for this golden file.
 func (g *GoldenFile) Filename() string {
 	// Sanitize model name for filesystem
 	model := strings.ReplaceAll(g.Metadata.Model, "/", "_")
 	model = strings.ReplaceAll(model, ":", "_")
-	
+
 	date := g.Metadata.Timestamp.Format("20060102")
+	if g.Metadata.Driver != "" {
+		return fmt.Sprintf("%s_%s_%s_%s.json", g.Metadata.Driver, g.Metadata.Capability, model, date)
+	}
 	return fmt.Sprintf("%s_%s_%s.json", g.Metadata.Capability, model, date)
 }
 
@@ -127,14 +168,28 @@ func NewGoldenIndex(files []*GoldenFile) *GoldenIndex {
 		files: make(map[string]*GoldenFile),
 	}
 	for _, f := range files {
-		key := f.Metadata.Provider + "/" + f.Metadata.Capability + "/" + f.Metadata.Model
-		idx.files[key] = f
+		idx.files[goldenKey(f.Metadata.Driver, f.Metadata.Provider, f.Metadata.Capability, f.Metadata.Model)] = f
 	}
 	return idx
 }
 
-// Lookup finds a golden file by provider, capability, and model.
+// Lookup finds a raw HTTP client golden file by provider, capability, and model.
 func (idx *GoldenIndex) Lookup(provider, capability, model string) *GoldenFile {
+	return idx.LookupDriver("", provider, capability, model)
+}
+
+// LookupDriver finds a golden file recorded by a specific driver.
+func (idx *GoldenIndex) LookupDriver(driver, provider, capability, model string) *GoldenFile {
+
+	return idx.files[goldenKey(driver, provider, capability, model)]
+}
+
+// goldenKey builds the index key; drivers other than the raw client get their own namespace.
+func goldenKey(driver, provider, capability, model string) string {
+
 	key := provider + "/" + capability + "/" + model
-	return idx.files[key]
+	if driver != "" {
+		key = driver + ":" + key
+	}
+	return key
 }
@@ -7,13 +7,20 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 )
 
 // MockTransport replays responses from golden files.
 type MockTransport struct {
-	index    *GoldenIndex
-	provider string
-	model    string
+	index      *GoldenIndex
+	provider   string
+	model      string
+	capability string
+	driver     string
+
+	mu       sync.Mutex
+	sequence map[string]int
+	served   []int
 }
 
 // NewMockTransport creates a transport that serves responses from golden files.
@@ -22,37 +29,96 @@ func NewMockTransport(index *GoldenIndex, provider, model string) *MockTransport
 		index:    index,
 		provider: provider,
 		model:    model,
+		sequence: make(map[string]int),
 	}
 }
 
+// NewCapabilityMockTransport creates a transport pinned to one capability's golden file.
+// Capabilities that share an endpoint (chat, streaming, tool calling, ...) cannot be told apart by URL alone.
+func NewCapabilityMockTransport(index *GoldenIndex, provider, model string, capability Capability) *MockTransport {
+
+	transport := NewMockTransport(index, provider, model)
+	transport.capability = string(capability)
+	return transport
+}
+
 // RoundTrip implements http.RoundTripper by matching requests to golden files.
+// Successive requests for the same golden file replay its follow-up exchanges in order.
 func (t *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	capability := t.inferCapability(req)
+	capability := t.capability
+	if capability == "" {
+		capability = t.inferCapability(req)
+	}
 	if capability == "" {
 		return nil, fmt.Errorf("could not infer capability from request: %s %s", req.Method, req.URL.Path)
 	}
 
-	golden := t.index.Lookup(t.provider, capability, t.model)
+	golden := t.index.LookupDriver(t.driver, t.provider, capability, t.model)
 	if golden == nil {
-		return nil, fmt.Errorf("no golden file for %s/%s/%s", t.provider, capability, t.model)
+		return nil, fmt.Errorf("no golden file for %s", goldenKey(t.driver, t.provider, capability, t.model))
+	}
+
+	sequence := t.nextSequence(capability)
+	recorded, ok := golden.Exchange(sequence)
+	if !ok {
+		return nil, fmt.Errorf("golden file for %s/%s/%s has no exchange #%d", t.provider, capability, t.model, sequence+1)
 	}
 
 	// Build response from golden file
 	resp := &http.Response{
-		Status:     fmt.Sprintf("%d %s", golden.Response.Status, http.StatusText(golden.Response.Status)),
-		StatusCode: golden.Response.Status,
+		Status:     fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode: recorded.Status,
 		Header:     make(http.Header),
-		Body:       io.NopCloser(bytes.NewReader(golden.Response.Body)),
+		Body:       io.NopCloser(bytes.NewReader(recorded.BodyBytes())),
 		Request:    req,
 	}
 
-	for k, v := range golden.Response.Headers {
+	for k, v := range recorded.Headers {
 		resp.Header.Set(k, v)
 	}
 
+	t.mu.Lock()
+	t.served = append(t.served, recorded.Status)
+	t.mu.Unlock()
+
 	return resp, nil
 }
 
+// StatusCodes returns the status of every response served, in order.
+func (t *MockTransport) StatusCodes() []int {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]int(nil), t.served...)
+}
+
+// SetDriver selects which driver's golden files are replayed.
+func (t *MockTransport) SetDriver(driver string) {
+
+	t.driver = driver
+}
+
+// Reset rewinds exchange sequencing so scenarios can be replayed again.
+func (t *MockTransport) Reset() {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sequence = make(map[string]int)
+}
+
+// nextSequence returns the zero-based request number for a capability and advances it.
+func (t *MockTransport) nextSequence(capability string) int {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sequence == nil {
+		t.sequence = make(map[string]int)
+	}
+	sequence := t.sequence[capability]
+	t.sequence[capability] = sequence + 1
+	return sequence
+}
+
 // inferCapability determines the capability from the request URL.
 func (t *MockTransport) inferCapability(req *http.Request) string {
 	path := req.URL.Path
@@ -67,11 +133,14 @@ func (t *MockTransport) inferCapability(req *http.Request) string {
 	if strings.Contains(path, "/images/edits") {
 		return "image_edit"
 	}
-	if strings.Contains(path, "/models") {
+	if strings.Contains(path, "/models") && !strings.Contains(path, ":") {
 		return "test_connection"
 	}
 
 	// Gemini-style endpoints
+	if strings.Contains(path, ":streamGenerateContent") {
+		return "streaming"
+	}
 	if strings.Contains(path, ":generateContent") {
 		return "chat"
 	}
@@ -0,0 +1,45 @@
+// multi_turn.go tests that models use earlier conversation turns.
+// pkg/models/modeltest/multi_turn.go
+package modeltest
+
+import (
+	"context"
+	"fmt"
+)
+
+// MultiTurnTester tests recall of facts stated in earlier turns.
+type MultiTurnTester struct{}
+
+// Capability returns the capability covered by this tester.
+func (t *MultiTurnTester) Capability() Capability { return CapabilityMultiTurn }
+
+// Test sends a short history and verifies the answer depends on the first turn.
+func (t *MultiTurnTester) Test(ctx context.Context, client *Client, model string) TestResult {
+
+	result := newResult(client, CapabilityMultiTurn, model)
+	providerType := client.Config().ProviderType
+
+	request := buildTextChatRequest(providerType, model, []conversationTurn{
+		{Role: "user", Text: "Remember this: my favorite color is teal."},
+		{Role: "assistant", Text: "Got it, your favorite color is teal."},
+		{Role: "user", Text: "What is my favorite color? Answer with one word."},
+	}, false)
+
+	body, _, err := postJSON(ctx, client, chatPath(providerType, model, false), request)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	text, err := extractResponseText(providerType, body)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if !containsFold(text, "teal") {
+		result.Error = fmt.Sprintf("expected answer to recall teal, got %q", text)
+		return result
+	}
+
+	result.Success = true
+	return result
+}
@@ -21,10 +21,32 @@ type RecordedRequest struct {
 }
 
 // RecordedResponse contains a captured HTTP response.
+// JSON bodies are stored in Body; streamed (SSE) and other non-JSON bodies are stored verbatim in RawBody.
 type RecordedResponse struct {
 	Status  int               `json:"status"`
 	Headers map[string]string `json:"headers"`
 	Body    json.RawMessage   `json:"body,omitempty"`
+	RawBody string            `json:"raw_body,omitempty"`
+}
+
+// BodyBytes returns the recorded response body regardless of how it was stored.
+func (r RecordedResponse) BodyBytes() []byte {
+
+	if r.RawBody != "" {
+		return []byte(r.RawBody)
+	}
+	return r.Body
+}
+
+// IsStream reports whether the recorded response was a server-sent event stream.
+func (r RecordedResponse) IsStream() bool {
+
+	for key, value := range r.Headers {
+		if strings.EqualFold(key, "Content-Type") && strings.Contains(strings.ToLower(value), "text/event-stream") {
+			return true
+		}
+	}
+	return false
 }
 
 // Recording represents a single captured request/response pair.
@@ -146,7 +168,11 @@ func (t *RecordingTransport) captureResponse(resp *http.Response) RecordedRespon
 		bodyBytes, err := io.ReadAll(resp.Body)
 		if err == nil {
 			resp.Body = io.NopCloser(bytes.NewReader(bodyBytes)) // Reset body
-			rec.Body = json.RawMessage(bodyBytes)
+			if json.Valid(bodyBytes) {
+				rec.Body = json.RawMessage(bodyBytes)
+			} else if len(bodyBytes) > 0 {
+				rec.RawBody = string(bodyBytes)
+			}
 		}
 	}
 
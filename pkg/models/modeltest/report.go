@@ -0,0 +1,322 @@
+// report.go renders run reports as JUnit XML, JSON, and Markdown for CI.
+// pkg/models/modeltest/report.go
+package modeltest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Report formats accepted by ParseReportTargets.
+const (
+	ReportFormatJUnit    = "junit"
+	ReportFormatJSON     = "json"
+	ReportFormatMarkdown = "md"
+)
+
+// Reporter renders a run report in one output format.
+type Reporter interface {
+	Write(w io.Writer, report Report) error
+}
+
+// ReportTarget pairs a report format with its output path ("-" writes to stdout).
+type ReportTarget struct {
+	Format string
+	Path   string
+}
+
+// ParseReportTargets parses a spec such as "junit:out.xml,md:matrix.md".
+func ParseReportTargets(spec string) ([]ReportTarget, error) {
+
+	targets := make([]ReportTarget, 0)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		format, path, found := strings.Cut(part, ":")
+		format = strings.ToLower(strings.TrimSpace(format))
+		path = strings.TrimSpace(path)
+		if !found || path == "" {
+			return nil, fmt.Errorf("report %q: want format:path", part)
+		}
+		if format == "markdown" {
+			format = ReportFormatMarkdown
+		}
+		if _, err := GetReporter(format); err != nil {
+			return nil, err
+		}
+		targets = append(targets, ReportTarget{Format: format, Path: path})
+	}
+	return targets, nil
+}
+
+// GetReporter returns the reporter for a format.
+func GetReporter(format string) (Reporter, error) {
+
+	switch format {
+	case ReportFormatJUnit:
+		return JUnitReporter{}, nil
+	case ReportFormatJSON:
+		return JSONReporter{}, nil
+	case ReportFormatMarkdown:
+		return MarkdownReporter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown report format %q (want %s, %s, or %s)", format, ReportFormatJUnit, ReportFormatJSON, ReportFormatMarkdown)
+	}
+}
+
+// WriteReports renders the report to every target.
+func WriteReports(report Report, targets []ReportTarget) error {
+
+	for _, target := range targets {
+		reporter, err := GetReporter(target.Format)
+		if err != nil {
+			return err
+		}
+		if target.Path == "-" {
+			if err := reporter.Write(os.Stdout, report); err != nil {
+				return fmt.Errorf("write %s report: %w", target.Format, err)
+			}
+			continue
+		}
+		if err := writeReportFile(reporter, report, target.Path); err != nil {
+			return fmt.Errorf("write %s report: %w", target.Format, err)
+		}
+	}
+	return nil
+}
+
+// writeReportFile renders a report into a file, creating parent directories.
+func writeReportFile(reporter Reporter, report Report, path string) error {
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := reporter.Write(file, report); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// JSONReporter writes the full report, including per-exchange status codes.
+type JSONReporter struct{}
+
+// Write renders the report as indented JSON.
+func (JSONReporter) Write(w io.Writer, report Report) error {
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// JUnitReporter writes one testsuite per provider and one testcase per model and capability.
+type JUnitReporter struct{}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// Write renders the report as JUnit XML.
+func (JUnitReporter) Write(w io.Writer, report Report) error {
+
+	results := orderedResults(report)
+	root := junitTestSuites{Name: "modeltest", Tests: report.TotalTests, Failures: report.Failed}
+	totalSeconds := 0.0
+	for _, result := range results {
+		if len(root.Suites) == 0 || root.Suites[len(root.Suites)-1].Name != result.Provider {
+			root.Suites = append(root.Suites, junitTestSuite{Name: result.Provider, Timestamp: report.Timestamp.UTC().Format("2006-01-02T15:04:05")})
+		}
+		suite := &root.Suites[len(root.Suites)-1]
+
+		testCase := junitTestCase{
+			Name:      result.Model + "/" + string(result.Capability),
+			Classname: "modeltest." + result.Provider,
+			Time:      formatSeconds(result.Duration.Seconds()),
+		}
+		if len(result.StatusCodes) > 0 {
+			testCase.SystemOut = "status codes: " + formatStatusCodes(result.StatusCodes)
+		}
+		if !result.Success {
+			message := result.Error
+			if message == "" {
+				message = "capability test failed"
+			}
+			testCase.Failure = &junitFailure{Message: message, Body: message}
+			suite.Failures++
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, testCase)
+		totalSeconds += result.Duration.Seconds()
+	}
+	for index := range root.Suites {
+		seconds := 0.0
+		for _, result := range results {
+			if result.Provider == root.Suites[index].Name {
+				seconds += result.Duration.Seconds()
+			}
+		}
+		root.Suites[index].Time = formatSeconds(seconds)
+	}
+	root.Time = formatSeconds(totalSeconds)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(root); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// MarkdownReporter writes a provider/model by capability matrix for PR comments.
+type MarkdownReporter struct{}
+
+// Write renders the report as a Markdown capability matrix followed by failure details.
+func (MarkdownReporter) Write(w io.Writer, report Report) error {
+
+	results := orderedResults(report)
+	capabilitySet := make(map[Capability]struct{})
+	type row struct {
+		provider string
+		model    string
+		cells    map[Capability]TestResult
+	}
+	rows := make([]*row, 0)
+	for _, result := range results {
+		capabilitySet[result.Capability] = struct{}{}
+		if len(rows) == 0 || rows[len(rows)-1].provider != result.Provider || rows[len(rows)-1].model != result.Model {
+			rows = append(rows, &row{provider: result.Provider, model: result.Model, cells: make(map[Capability]TestResult)})
+		}
+		rows[len(rows)-1].cells[result.Capability] = result
+	}
+	capabilities := make([]Capability, 0, len(capabilitySet))
+	for capability := range capabilitySet {
+		capabilities = append(capabilities, capability)
+	}
+	sort.Slice(capabilities, func(i, j int) bool { return capabilities[i] < capabilities[j] })
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "## Model capability matrix\n\n")
+	fmt.Fprintf(&builder, "**%d passed, %d failed** of %d tests.\n\n", report.Passed, report.Failed, report.TotalTests)
+	if len(rows) > 0 {
+		builder.WriteString("| Provider | Model |")
+		for _, capability := range capabilities {
+			fmt.Fprintf(&builder, " %s |", capability)
+		}
+		builder.WriteString("\n| --- | --- |")
+		for range capabilities {
+			builder.WriteString(" :---: |")
+		}
+		builder.WriteString("\n")
+		for _, current := range rows {
+			fmt.Fprintf(&builder, "| %s | %s |", escapeMarkdownCell(current.provider), escapeMarkdownCell(current.model))
+			for _, capability := range capabilities {
+				result, tested := current.cells[capability]
+				switch {
+				case !tested:
+					builder.WriteString(" – |")
+				case result.Success:
+					builder.WriteString(" ✅ |")
+				default:
+					builder.WriteString(" ❌ |")
+				}
+			}
+			builder.WriteString("\n")
+		}
+	}
+
+	failureHeader := false
+	for _, result := range results {
+		if result.Success {
+			continue
+		}
+		if !failureHeader {
+			builder.WriteString("\n### Failures\n\n")
+			failureHeader = true
+		}
+		fmt.Fprintf(&builder, "- `%s/%s/%s`: %s", result.Provider, result.Model, result.Capability, escapeMarkdownCell(result.Error))
+		if len(result.StatusCodes) > 0 {
+			fmt.Fprintf(&builder, " (HTTP %s)", formatStatusCodes(result.StatusCodes))
+		}
+		builder.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// orderedResults returns the report's results sorted for stable output.
+func orderedResults(report Report) []TestResult {
+
+	results := append([]TestResult(nil), report.Results...)
+	SortResults(results)
+	return results
+}
+
+// formatSeconds renders a JUnit time attribute.
+func formatSeconds(seconds float64) string {
+
+	return fmt.Sprintf("%.3f", seconds)
+}
+
+// formatStatusCodes joins status codes for display.
+func formatStatusCodes(codes []int) string {
+
+	parts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		parts = append(parts, fmt.Sprint(code))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// escapeMarkdownCell keeps text from breaking a Markdown table row.
+func escapeMarkdownCell(text string) string {
+
+	text = strings.ReplaceAll(text, "\n", " ")
+	return strings.ReplaceAll(text, "|", `\|`)
+}
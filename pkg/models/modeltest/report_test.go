@@ -0,0 +1,133 @@
+// report_test.go verifies report target parsing and the JUnit, JSON, and Markdown reporters.
+// pkg/models/modeltest/report_test.go
+package modeltest
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseReportTargets ensures report specs map to known formats and paths.
+func TestParseReportTargets(t *testing.T) {
+
+	testCases := []struct {
+		name    string
+		spec    string
+		want    []ReportTarget
+		wantErr string
+	}{
+		{name: "multiple", spec: "junit:out.xml, md:matrix.md", want: []ReportTarget{{Format: "junit", Path: "out.xml"}, {Format: "md", Path: "matrix.md"}}},
+		{name: "markdown alias and stdout", spec: "markdown:-,json:r.json", want: []ReportTarget{{Format: "md", Path: "-"}, {Format: "json", Path: "r.json"}}},
+		{name: "missing path", spec: "junit", wantErr: "format:path"},
+		{name: "unknown format", spec: "html:r.html", wantErr: "unknown report format"},
+	}
+
+	for _, testCase := range testCases {
+		targets, err := ParseReportTargets(testCase.spec)
+		if testCase.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), testCase.wantErr) {
+				t.Fatalf("%s: expected error containing %q, got %v", testCase.name, testCase.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", testCase.name, err)
+		}
+		if len(targets) != len(testCase.want) {
+			t.Fatalf("%s: expected %+v, got %+v", testCase.name, testCase.want, targets)
+		}
+		for index := range targets {
+			if targets[index] != testCase.want[index] {
+				t.Fatalf("%s: expected %+v, got %+v", testCase.name, testCase.want, targets)
+			}
+		}
+	}
+}
+
+// TestWriteReportsFromParallelRun ensures parallel results are ordered deterministically and rendered by every reporter.
+func TestWriteReportsFromParallelRun(t *testing.T) {
+
+	goldenDir := t.TempDir()
+	for _, model := range []string{"m-a", "m-b", "m-c"} {
+		saveChatGolden(t, goldenDir, string(CapabilityChat), model, "Hello!")
+	}
+
+	runner := NewRunner(RunnerConfig{Mode: "mock", OutputDir: goldenDir, Parallel: 4, Timeout: time.Second})
+	runner.plan = &TestPlan{Providers: []ProviderConfig{{Name: "openai", Type: ProviderTypeOpenAI, Models: []ModelConfig{
+		{ID: "m-c", Capabilities: []Capability{CapabilityChat}},
+		{ID: "m-a", Capabilities: []Capability{CapabilityChat, CapabilityStreaming}},
+		{ID: "m-b", Capabilities: []Capability{CapabilityChat}},
+	}}}}
+	results, err := runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	order := make([]string, 0, len(results))
+	for _, result := range results {
+		order = append(order, result.Model+"/"+string(result.Capability))
+	}
+	if got := strings.Join(order, ","); got != "m-a/chat,m-a/streaming,m-b/chat,m-c/chat" {
+		t.Fatalf("unexpected result order: %s", got)
+	}
+	if len(results[0].StatusCodes) != 1 || results[0].StatusCodes[0] != 200 {
+		t.Fatalf("expected replayed status codes on results, got %+v", results[0])
+	}
+
+	outputDir := t.TempDir()
+	targets, err := ParseReportTargets("junit:" + filepath.Join(outputDir, "junit", "out.xml") + ",json:" + filepath.Join(outputDir, "report.json") + ",md:" + filepath.Join(outputDir, "matrix.md"))
+	if err != nil {
+		t.Fatalf("parse targets: %v", err)
+	}
+	if err := WriteReports(GenerateReport(results), targets); err != nil {
+		t.Fatalf("write reports: %v", err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(mustReadFile(t, filepath.Join(outputDir, "junit", "out.xml")), &suites); err != nil {
+		t.Fatalf("parse junit: %v", err)
+	}
+	if suites.Tests != 4 || suites.Failures != 1 || len(suites.Suites) != 1 || len(suites.Suites[0].Cases) != 4 {
+		t.Fatalf("unexpected junit totals: %+v", suites)
+	}
+	if failure := suites.Suites[0].Cases[1]; failure.Name != "m-a/streaming" || failure.Failure == nil || !strings.Contains(failure.Failure.Message, "no golden file") {
+		t.Fatalf("expected the streaming case to fail for a missing golden, got %+v", failure)
+	}
+
+	var decoded Report
+	if err := json.Unmarshal(mustReadFile(t, filepath.Join(outputDir, "report.json")), &decoded); err != nil {
+		t.Fatalf("parse json report: %v", err)
+	}
+	if decoded.Passed != 3 || decoded.Results[0].StatusCodes[0] != 200 {
+		t.Fatalf("unexpected json report: %+v", decoded)
+	}
+
+	matrix := string(mustReadFile(t, filepath.Join(outputDir, "matrix.md")))
+	for _, want := range []string{
+		"| Provider | Model | chat | streaming |",
+		"| openai | m-a | ✅ | ❌ |",
+		"| openai | m-b | ✅ | – |",
+		"- `openai/m-a/streaming`: ",
+	} {
+		if !strings.Contains(matrix, want) {
+			t.Fatalf("expected markdown to contain %q, got:\n%s", want, matrix)
+		}
+	}
+}
+
+// mustReadFile reads a file or fails the test.
+func mustReadFile(t *testing.T, path string) []byte {
+
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return data
+}
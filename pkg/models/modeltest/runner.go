@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sort"
 	"sync"
 	"time"
 )
@@ -19,6 +20,7 @@ type RunnerConfig struct {
 	Capabilities []Capability // Capabilities to test (empty = all)
 	Parallel     int          // Max parallel tests (0 = sequential)
 	Timeout      time.Duration
+	Driver       string // DriverHTTP (default) or DriverAdapter
 }
 
 // ProviderConfig describes a provider for testing.
@@ -43,9 +45,10 @@ type TestPlan struct {
 
 // Runner executes tests according to a plan.
 type Runner struct {
-	config RunnerConfig
-	plan   *TestPlan
-	index  *GoldenIndex
+	config  RunnerConfig
+	plan    *TestPlan
+	index   *GoldenIndex
+	adapter AdapterDriver
 }
 
 // NewRunner creates a test runner.
@@ -55,6 +58,12 @@ func NewRunner(config RunnerConfig) *Runner {
 	}
 }
 
+// SetAdapterDriver installs the driver used when RunnerConfig.Driver is DriverAdapter.
+func (r *Runner) SetAdapterDriver(driver AdapterDriver) {
+
+	r.adapter = driver
+}
+
 // LoadEmbeddedCatalogPlan derives and loads a test plan from the canonical model catalog.
 func (r *Runner) LoadEmbeddedCatalogPlan() error {
 
@@ -86,6 +95,10 @@ func (r *Runner) Run(ctx context.Context) ([]TestResult, error) {
 		return nil, fmt.Errorf("no test plan loaded")
 	}
 
+	if r.config.Driver == DriverAdapter && r.adapter == nil {
+		return nil, fmt.Errorf("adapter driver selected but not configured")
+	}
+
 	if r.config.Mode == "mock" {
 		if err := r.LoadGoldenFiles(); err != nil {
 			return nil, fmt.Errorf("load golden files: %w", err)
@@ -112,6 +125,9 @@ func (r *Runner) Run(ctx context.Context) ([]TestResult, error) {
 				if !r.shouldTestCapability(cap) {
 					continue
 				}
+				if r.config.Driver == DriverAdapter && !r.adapter.Supports(cap) {
+					continue
+				}
 				work = append(work, workItem{prov, model, cap})
 			}
 		}
@@ -144,6 +160,7 @@ func (r *Runner) Run(ctx context.Context) ([]TestResult, error) {
 	}
 	wg.Wait()
 
+	SortResults(results)
 	return results, nil
 }
 
@@ -152,45 +169,48 @@ func (r *Runner) runTest(ctx context.Context, prov ProviderConfig, model ModelCo
 	ctx, cancel := context.WithTimeout(ctx, r.config.Timeout)
 	defer cancel()
 
-	client := NewClient(ClientConfig{
-		ProviderName: prov.Name,
-		ProviderType: prov.Type,
-		BaseURL:      prov.BaseURL,
-		APIKey:       prov.APIKey,
-	})
-
+	driver := goldenDriver(r.config.Driver)
+	var recorder *RecordingTransport
+	var mockTransport *MockTransport
 	var transport http.RoundTripper
 	if r.config.Mode == "live" {
-		recorder := NewRecordingTransport(nil)
+		recorder = NewRecordingTransport(nil)
 		transport = recorder
-		client.SetTransport(transport)
-
-		tester := GetTester(cap)
-		if tester == nil {
-			return TestResult{
-				Provider:   prov.Name,
-				Capability: cap,
-				Model:      model.ID,
-				Error:      "unknown capability",
-			}
-		}
-
-		result := tester.Test(ctx, client, model.ID)
+	} else {
+		mockTransport = NewCapabilityMockTransport(r.index, prov.Name, model.ID, cap)
+		mockTransport.SetDriver(driver)
+		transport = mockTransport
+	}
 
-		// Save recording as golden file
-		if result.Success && len(recorder.Recordings()) > 0 {
-			golden := NewGoldenFile(prov.Name, string(cap), model.ID, recorder.Recordings()[0])
-			if err := golden.Save(r.config.OutputDir); err != nil {
-				result.Error = fmt.Sprintf("save golden file: %v", err)
-			}
+	started := time.Now()
+	result := r.execute(ctx, prov, model, cap, transport)
+	result.Duration = time.Since(started)
+	if recorder != nil {
+		for _, recording := range recorder.Recordings() {
+			result.StatusCodes = append(result.StatusCodes, recording.Response.Status)
 		}
+	} else {
+		result.StatusCodes = mockTransport.StatusCodes()
+	}
 
-		return result
+	// Save recording as golden file
+	if recorder != nil && result.Success && len(recorder.Recordings()) > 0 {
+		golden := NewGoldenFileFromRecordings(prov.Name, string(cap), model.ID, recorder.Recordings())
+		golden.Metadata.Driver = driver
+		if err := golden.Save(r.config.OutputDir); err != nil {
+			result.Error = fmt.Sprintf("save golden file: %v", err)
+		}
 	}
 
-	// Mock mode
-	mockTransport := NewMockTransport(r.index, prov.Name, model.ID)
-	client.SetTransport(mockTransport)
+	return result
+}
+
+// execute runs a capability through the configured driver over the given transport.
+func (r *Runner) execute(ctx context.Context, prov ProviderConfig, model ModelConfig, cap Capability, transport http.RoundTripper) TestResult {
+
+	if r.config.Driver == DriverAdapter {
+		return r.adapter.Test(ctx, prov, model.ID, cap, transport)
+	}
 
 	tester := GetTester(cap)
 	if tester == nil {
@@ -202,6 +222,13 @@ func (r *Runner) runTest(ctx context.Context, prov ProviderConfig, model ModelCo
 		}
 	}
 
+	client := NewClient(ClientConfig{
+		ProviderName: prov.Name,
+		ProviderType: prov.Type,
+		BaseURL:      prov.BaseURL,
+		APIKey:       prov.APIKey,
+	})
+	client.SetTransport(transport)
 	return tester.Test(ctx, client, model.ID)
 }
 
@@ -240,12 +267,14 @@ type Report struct {
 	Results    []TestResult `json:"results"`
 }
 
-// GenerateReport creates a summary report from test results.
+// GenerateReport creates a summary report from test results, ordered by provider, model, and capability.
 func GenerateReport(results []TestResult) Report {
+	ordered := append([]TestResult(nil), results...)
+	SortResults(ordered)
 	report := Report{
 		Timestamp:  time.Now(),
-		TotalTests: len(results),
-		Results:    results,
+		TotalTests: len(ordered),
+		Results:    ordered,
 	}
 	for _, r := range results {
 		if r.Success {
@@ -256,3 +285,17 @@ func GenerateReport(results []TestResult) Report {
 	}
 	return report
 }
+
+// SortResults orders results by provider, model, and capability so parallel runs report identically.
+func SortResults(results []TestResult) {
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Provider != results[j].Provider {
+			return results[i].Provider < results[j].Provider
+		}
+		if results[i].Model != results[j].Model {
+			return results[i].Model < results[j].Model
+		}
+		return results[i].Capability < results[j].Capability
+	})
+}
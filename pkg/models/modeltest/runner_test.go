@@ -0,0 +1,54 @@
+// runner_test.go verifies runner driver selection.
+// pkg/models/modeltest/runner_test.go
+package modeltest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// fakeAdapterDriver records the capabilities it is asked to run.
+type fakeAdapterDriver struct {
+	ran []Capability
+}
+
+// Supports accepts only chat.
+func (d *fakeAdapterDriver) Supports(capability Capability) bool {
+
+	return capability == CapabilityChat
+}
+
+// Test records the capability and reports success when the transport is the golden replayer.
+func (d *fakeAdapterDriver) Test(ctx context.Context, prov ProviderConfig, model string, capability Capability, transport http.RoundTripper) TestResult {
+
+	d.ran = append(d.ran, capability)
+	_, isMock := transport.(*MockTransport)
+	return TestResult{Provider: prov.Name, Capability: capability, Model: model, Success: isMock}
+}
+
+// TestRunnerAdapterDriverSkipsUnsupportedCapabilities ensures the adapter driver only receives capabilities it supports.
+func TestRunnerAdapterDriverSkipsUnsupportedCapabilities(t *testing.T) {
+
+	runner := NewRunner(RunnerConfig{Mode: "mock", OutputDir: t.TempDir(), Timeout: time.Second, Driver: DriverAdapter})
+	runner.plan = &TestPlan{Providers: []ProviderConfig{
+		{Name: "openai", Type: ProviderTypeOpenAI, Models: []ModelConfig{
+			{ID: "m", Capabilities: []Capability{CapabilityChat, CapabilityToolCalling}},
+		}},
+	}}
+
+	if _, err := runner.Run(context.Background()); err == nil {
+		t.Fatalf("expected error when the adapter driver is not configured")
+	}
+
+	driver := &fakeAdapterDriver{}
+	runner.SetAdapterDriver(driver)
+	results, err := runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(results) != 1 || !results[0].Success || len(driver.ran) != 1 || driver.ran[0] != CapabilityChat {
+		t.Fatalf("expected a single successful chat run, got results=%+v ran=%v", results, driver.ran)
+	}
+}
@@ -0,0 +1,163 @@
+// scenarios_test.go verifies multi-request and streamed scenario replay.
+// pkg/models/modeltest/scenarios_test.go
+package modeltest
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestParseSSERejectsMalformedFraming ensures SSE framing violations are reported.
+func TestParseSSERejectsMalformedFraming(t *testing.T) {
+
+	testCases := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{name: "valid", body: "event: ping\ndata: {}\n\ndata: [DONE]\n\n"},
+		{name: "missing separator", body: "data {}\n\n", wantErr: "missing field separator"},
+		{name: "unknown field", body: "payload: {}\n\n", wantErr: "unknown field"},
+		{name: "unterminated", body: "data: {}\n\ndata: [DONE]", wantErr: "without a blank line"},
+		{name: "empty", body: ": keep-alive\n\n", wantErr: "no events"},
+	}
+
+	for _, testCase := range testCases {
+		events, err := ParseSSE([]byte(testCase.body))
+		if testCase.wantErr == "" {
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", testCase.name, err)
+			}
+			if len(events) != 2 || events[0].Event != "ping" || events[1].Data != "[DONE]" {
+				t.Fatalf("%s: unexpected events %+v", testCase.name, events)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), testCase.wantErr) {
+			t.Fatalf("%s: expected error containing %q, got %v", testCase.name, testCase.wantErr, err)
+		}
+	}
+}
+
+// TestStreamingTesterReplaysRecordedStream ensures streamed goldens replay and validate per provider.
+func TestStreamingTesterReplaysRecordedStream(t *testing.T) {
+
+	testCases := []struct {
+		name         string
+		providerType ProviderType
+		body         string
+		wantSuccess  bool
+	}{
+		{
+			name:         "openai",
+			providerType: ProviderTypeOpenAI,
+			body: "data: {\"choices\":[{\"delta\":{\"content\":\"1 2 3\"},\"finish_reason\":null}]}\n\n" +
+				"data: {\"choices\":[{\"delta\":{},\"finish_reason\":\"stop\"}]}\n\n" +
+				"data: [DONE]\n\n",
+			wantSuccess: true,
+		},
+		{
+			name:         "openai missing done",
+			providerType: ProviderTypeOpenAI,
+			body:         "data: {\"choices\":[{\"delta\":{\"content\":\"1 2 3\"},\"finish_reason\":\"stop\"}]}\n\n",
+		},
+		{
+			name:         "anthropic",
+			providerType: ProviderTypeAnthropic,
+			body: "event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"1 2 3\"}}\n\n" +
+				"event: message_delta\ndata: {\"type\":\"message_delta\",\"delta\":{\"stop_reason\":\"end_turn\"}}\n\n" +
+				"event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n",
+			wantSuccess: true,
+		},
+		{
+			name:         "gemini",
+			providerType: ProviderTypeGemini,
+			body: "data: {\"candidates\":[{\"content\":{\"parts\":[{\"text\":\"1 2 \"}]}}]}\n\n" +
+				"data: {\"candidates\":[{\"content\":{\"parts\":[{\"text\":\"3\"}]},\"finishReason\":\"STOP\"}]}\n\n",
+			wantSuccess: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		golden := &GoldenFile{
+			Metadata: GoldenMetadata{Provider: "p", Capability: string(CapabilityStreaming), Model: "m"},
+			Response: RecordedResponse{
+				Status:  200,
+				Headers: map[string]string{"Content-Type": "text/event-stream"},
+				RawBody: testCase.body,
+			},
+		}
+		client := NewClient(ClientConfig{ProviderName: "p", ProviderType: testCase.providerType, BaseURL: "http://mock"})
+		client.SetTransport(NewCapabilityMockTransport(NewGoldenIndex([]*GoldenFile{golden}), "p", "m", CapabilityStreaming))
+
+		result := GetTester(CapabilityStreaming).Test(context.Background(), client, "m")
+		if result.Success != testCase.wantSuccess {
+			t.Fatalf("%s: expected success=%v, got %+v", testCase.name, testCase.wantSuccess, result)
+		}
+	}
+}
+
+// TestToolCallingTesterReplaysFollowUpExchange ensures tool-calling scenarios replay both recorded exchanges in order.
+func TestToolCallingTesterReplaysFollowUpExchange(t *testing.T) {
+
+	toolCall := `{"choices":[{"message":{"role":"assistant","tool_calls":[{"id":"call_1","type":"function",` +
+		`"function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}}]},"finish_reason":"tool_calls"}]}`
+	answer := `{"choices":[{"message":{"role":"assistant","content":"It is sunny and 21C in Paris."},"finish_reason":"stop"}]}`
+
+	golden := NewGoldenFileFromRecordings("p", string(CapabilityToolCalling), "m", []Recording{
+		{Response: RecordedResponse{Status: 200, Body: json.RawMessage(toolCall)}},
+		{Response: RecordedResponse{Status: 200, Body: json.RawMessage(answer)}},
+	})
+	transport := NewCapabilityMockTransport(NewGoldenIndex([]*GoldenFile{golden}), "p", "m", CapabilityToolCalling)
+	client := NewClient(ClientConfig{ProviderName: "p", ProviderType: ProviderTypeOpenAI, BaseURL: "http://mock"})
+	client.SetTransport(transport)
+
+	result := GetTester(CapabilityToolCalling).Test(context.Background(), client, "m")
+	if !result.Success {
+		t.Fatalf("expected tool-calling scenario to pass, got %+v", result)
+	}
+
+	result = GetTester(CapabilityToolCalling).Test(context.Background(), client, "m")
+	if result.Success || !strings.Contains(result.Error, "no exchange #3") {
+		t.Fatalf("expected exhausted golden to fail, got %+v", result)
+	}
+
+	transport.Reset()
+	result = GetTester(CapabilityToolCalling).Test(context.Background(), client, "m")
+	if !result.Success {
+		t.Fatalf("expected replay after reset to pass, got %+v", result)
+	}
+}
+
+// TestStructuredOutputValidatesSchema ensures schema violations fail the structured output scenario.
+func TestStructuredOutputValidatesSchema(t *testing.T) {
+
+	testCases := []struct {
+		name        string
+		content     string
+		wantSuccess bool
+	}{
+		{name: "valid", content: `{"name":"Ada Lovelace","age":36}`, wantSuccess: true},
+		{name: "string age", content: `{"name":"Ada Lovelace","age":"36"}`},
+		{name: "extra property", content: `{"name":"Ada Lovelace","age":36,"born":1815}`},
+		{name: "not json", content: `Ada Lovelace, 36`},
+	}
+
+	for _, testCase := range testCases {
+		content, _ := json.Marshal(testCase.content)
+		body := `{"choices":[{"message":{"role":"assistant","content":` + string(content) + `},"finish_reason":"stop"}]}`
+		golden := &GoldenFile{
+			Metadata: GoldenMetadata{Provider: "p", Capability: string(CapabilityStructuredOutput), Model: "m"},
+			Response: RecordedResponse{Status: 200, Body: json.RawMessage(body)},
+		}
+		client := NewClient(ClientConfig{ProviderName: "p", ProviderType: ProviderTypeOpenAI, BaseURL: "http://mock"})
+		client.SetTransport(NewCapabilityMockTransport(NewGoldenIndex([]*GoldenFile{golden}), "p", "m", CapabilityStructuredOutput))
+
+		result := GetTester(CapabilityStructuredOutput).Test(context.Background(), client, "m")
+		if result.Success != testCase.wantSuccess {
+			t.Fatalf("%s: expected success=%v, got %+v", testCase.name, testCase.wantSuccess, result)
+		}
+	}
+}
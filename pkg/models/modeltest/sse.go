@@ -0,0 +1,83 @@
+// sse.go parses and validates server-sent event framing in streamed responses.
+// pkg/models/modeltest/sse.go
+package modeltest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// SSEEvent is one dispatched server-sent event.
+type SSEEvent struct {
+	Event string `json:"event,omitempty"`
+	Data  string `json:"data"`
+	ID    string `json:"id,omitempty"`
+}
+
+// ParseSSE splits a streamed body into events and reports framing violations.
+// Lines must be comments (":"), blank separators, or one of the event, data, id, or retry fields.
+func ParseSSE(body []byte) ([]SSEEvent, error) {
+
+	events := make([]SSEEvent, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	var current SSEEvent
+	var dataLines []string
+	hasFields := false
+	lineNumber := 0
+
+	flush := func() {
+		if hasFields && len(dataLines) > 0 {
+			current.Data = strings.Join(dataLines, "\n")
+			events = append(events, current)
+		}
+		current = SSEEvent{}
+		dataLines = nil
+		hasFields = false
+	}
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			flush()
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, found := strings.Cut(line, ":")
+		if !found {
+			return nil, fmt.Errorf("sse line %d: missing field separator: %q", lineNumber, line)
+		}
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "data":
+			dataLines = append(dataLines, value)
+		case "event":
+			current.Event = value
+		case "id":
+			current.ID = value
+		case "retry":
+		default:
+			return nil, fmt.Errorf("sse line %d: unknown field %q", lineNumber, field)
+		}
+		hasFields = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("sse scan: %w", err)
+	}
+	if hasFields {
+		return nil, fmt.Errorf("sse stream ended without a blank line terminating the final event")
+	}
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf("sse stream contained no events")
+	}
+	return events, nil
+}
@@ -0,0 +1,184 @@
+// streaming.go tests streamed chat completions, validating SSE framing and finish reasons.
+// pkg/models/modeltest/streaming.go
+package modeltest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// StreamingTester tests streamed chat completion capability.
+type StreamingTester struct{}
+
+// streamSummary contains the assembled content and termination metadata of a stream.
+type streamSummary struct {
+	Content      string
+	FinishReason string
+	Events       int
+	Terminated   bool
+}
+
+// Capability returns the capability covered by this tester.
+func (t *StreamingTester) Capability() Capability { return CapabilityStreaming }
+
+// Test streams a short completion and verifies framing, content, and finish reason.
+func (t *StreamingTester) Test(ctx context.Context, client *Client, model string) TestResult {
+
+	result := newResult(client, CapabilityStreaming, model)
+	providerType := client.Config().ProviderType
+
+	request := buildTextChatRequest(providerType, model, []conversationTurn{
+		{Role: "user", Text: "Count from 1 to 5, separated by spaces, and nothing else."},
+	}, true)
+
+	body, headers, err := postJSON(ctx, client, chatPath(providerType, model, true), request)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if contentType := headers.Get("Content-Type"); !strings.Contains(contentType, "text/event-stream") {
+		result.Error = fmt.Sprintf("expected text/event-stream response, got %q", contentType)
+		return result
+	}
+
+	events, err := ParseSSE(body)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	summary, err := summarizeStream(providerType, events)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	switch {
+	case strings.TrimSpace(summary.Content) == "":
+		result.Error = "stream produced no content"
+	case summary.FinishReason == "":
+		result.Error = "stream ended without a finish reason"
+	case !summary.Terminated:
+		result.Error = "stream ended without a terminal event"
+	default:
+		result.Success = true
+	}
+	return result
+}
+
+// summarizeStream assembles provider-specific stream events into a summary.
+func summarizeStream(providerType ProviderType, events []SSEEvent) (streamSummary, error) {
+
+	switch providerType {
+	case ProviderTypeGemini:
+		return summarizeGeminiStream(events)
+	case ProviderTypeAnthropic:
+		return summarizeAnthropicStream(events)
+	default:
+		return summarizeOpenAIStream(events)
+	}
+}
+
+// summarizeOpenAIStream reads chat.completion.chunk events terminated by [DONE].
+func summarizeOpenAIStream(events []SSEEvent) (streamSummary, error) {
+
+	summary := streamSummary{Events: len(events)}
+	var builder strings.Builder
+	for index, event := range events {
+		if event.Data == "[DONE]" {
+			if index != len(events)-1 {
+				return summary, fmt.Errorf("stream event %d: [DONE] was not the final event", index)
+			}
+			summary.Terminated = true
+			continue
+		}
+
+		var chunk struct {
+			Choices []struct {
+				Delta struct {
+					Content string `json:"content"`
+				} `json:"delta"`
+				FinishReason *string `json:"finish_reason"`
+			} `json:"choices"`
+		}
+		if err := json.Unmarshal([]byte(event.Data), &chunk); err != nil {
+			return summary, fmt.Errorf("stream event %d: invalid JSON: %w", index, err)
+		}
+		for _, choice := range chunk.Choices {
+			builder.WriteString(choice.Delta.Content)
+			if choice.FinishReason != nil && *choice.FinishReason != "" {
+				summary.FinishReason = *choice.FinishReason
+			}
+		}
+	}
+	summary.Content = builder.String()
+	return summary, nil
+}
+
+// summarizeAnthropicStream reads typed message events terminated by message_stop.
+func summarizeAnthropicStream(events []SSEEvent) (streamSummary, error) {
+
+	summary := streamSummary{Events: len(events)}
+	var builder strings.Builder
+	for index, event := range events {
+		var payload struct {
+			Type  string `json:"type"`
+			Delta struct {
+				Type       string `json:"type"`
+				Text       string `json:"text"`
+				StopReason string `json:"stop_reason"`
+			} `json:"delta"`
+			Error *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(event.Data), &payload); err != nil {
+			return summary, fmt.Errorf("stream event %d: invalid JSON: %w", index, err)
+		}
+		if event.Event != "" && event.Event != payload.Type {
+			return summary, fmt.Errorf("stream event %d: event name %q does not match payload type %q", index, event.Event, payload.Type)
+		}
+
+		switch payload.Type {
+		case "content_block_delta":
+			builder.WriteString(payload.Delta.Text)
+		case "message_delta":
+			if payload.Delta.StopReason != "" {
+				summary.FinishReason = payload.Delta.StopReason
+			}
+		case "message_stop":
+			summary.Terminated = true
+		case "error":
+			if payload.Error != nil {
+				return summary, fmt.Errorf("stream error event: %s", payload.Error.Message)
+			}
+			return summary, fmt.Errorf("stream error event")
+		}
+	}
+	summary.Content = builder.String()
+	return summary, nil
+}
+
+// summarizeGeminiStream reads GenerateContent chunks; the chunk carrying finishReason terminates the stream.
+func summarizeGeminiStream(events []SSEEvent) (streamSummary, error) {
+
+	summary := streamSummary{Events: len(events)}
+	var builder strings.Builder
+	for index, event := range events {
+		var chunk geminiResponse
+		if err := json.Unmarshal([]byte(event.Data), &chunk); err != nil {
+			return summary, fmt.Errorf("stream event %d: invalid JSON: %w", index, err)
+		}
+		builder.WriteString(chunk.text())
+		for _, candidate := range chunk.Candidates {
+			if candidate.FinishReason != "" {
+				summary.FinishReason = candidate.FinishReason
+				summary.Terminated = index == len(events)-1
+			}
+		}
+	}
+	summary.Content = builder.String()
+	return summary, nil
+}
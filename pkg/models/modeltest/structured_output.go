@@ -0,0 +1,163 @@
+// structured_output.go tests schema-constrained JSON output.
+// pkg/models/modeltest/structured_output.go
+package modeltest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	scenarioSchemaName   = "person"
+	scenarioSchemaPrompt = "Extract the person from this sentence as JSON: \"Ada Lovelace was 36 years old.\""
+)
+
+// scenarioPersonSchema is the JSON schema the structured output scenario requests.
+var scenarioPersonSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"name": map[string]interface{}{"type": "string"},
+		"age":  map[string]interface{}{"type": "integer"},
+	},
+	"required":             []string{"name", "age"},
+	"additionalProperties": false,
+}
+
+// StructuredOutputTester tests JSON output constrained by a schema.
+type StructuredOutputTester struct{}
+
+// Capability returns the capability covered by this tester.
+func (t *StructuredOutputTester) Capability() Capability { return CapabilityStructuredOutput }
+
+// Test requests schema-constrained output and validates the returned object against the schema.
+func (t *StructuredOutputTester) Test(ctx context.Context, client *Client, model string) TestResult {
+
+	result := newResult(client, CapabilityStructuredOutput, model)
+	providerType := client.Config().ProviderType
+
+	body, _, err := postJSON(ctx, client, chatPath(providerType, model, false), t.buildRequest(providerType, model))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	payload, err := t.extractObject(providerType, body)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if err := validatePerson(payload); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Success = true
+	return result
+}
+
+// buildRequest builds the provider-specific structured output request.
+// Anthropic has no response schema parameter, so the scenario forces a tool whose input is the schema.
+func (t *StructuredOutputTester) buildRequest(providerType ProviderType, model string) map[string]interface{} {
+
+	switch providerType {
+	case ProviderTypeGemini:
+		return map[string]interface{}{
+			"contents": []map[string]interface{}{
+				{"role": "user", "parts": []map[string]interface{}{{"text": scenarioSchemaPrompt}}},
+			},
+			"generationConfig": map[string]interface{}{
+				"responseMimeType": "application/json",
+				"responseSchema": map[string]interface{}{
+					"type": "OBJECT",
+					"properties": map[string]interface{}{
+						"name": map[string]interface{}{"type": "STRING"},
+						"age":  map[string]interface{}{"type": "INTEGER"},
+					},
+					"required": []string{"name", "age"},
+				},
+			},
+		}
+	case ProviderTypeAnthropic:
+		return map[string]interface{}{
+			"model":      model,
+			"max_tokens": scenarioMaxTokens,
+			"messages": []map[string]interface{}{
+				{"role": "user", "content": scenarioSchemaPrompt},
+			},
+			"tools": []map[string]interface{}{
+				{"name": scenarioSchemaName, "description": "Record the extracted person", "input_schema": scenarioPersonSchema},
+			},
+			"tool_choice": map[string]interface{}{"type": "tool", "name": scenarioSchemaName},
+		}
+	default:
+		return map[string]interface{}{
+			"model":      model,
+			"max_tokens": scenarioMaxTokens,
+			"messages": []map[string]interface{}{
+				{"role": "user", "content": scenarioSchemaPrompt},
+			},
+			"response_format": map[string]interface{}{
+				"type": "json_schema",
+				"json_schema": map[string]interface{}{
+					"name":   scenarioSchemaName,
+					"strict": true,
+					"schema": scenarioPersonSchema,
+				},
+			},
+		}
+	}
+}
+
+// extractObject returns the JSON object produced by the model.
+func (t *StructuredOutputTester) extractObject(providerType ProviderType, body []byte) (map[string]interface{}, error) {
+
+	if providerType == ProviderTypeAnthropic {
+		var parsed anthropicResponse
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return nil, fmt.Errorf("parse anthropic response: %w", err)
+		}
+		for _, block := range parsed.Content {
+			if block.Type == "tool_use" && block.Name == scenarioSchemaName {
+				return decodeObject(string(block.Input))
+			}
+		}
+		return nil, fmt.Errorf("response contained no %s tool_use block", scenarioSchemaName)
+	}
+
+	text, err := extractResponseText(providerType, body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeObject(text)
+}
+
+// decodeObject parses a JSON object, tolerating surrounding whitespace.
+func decodeObject(text string) (map[string]interface{}, error) {
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(text)), &payload); err != nil {
+		return nil, fmt.Errorf("output is not a JSON object: %w", err)
+	}
+	return payload, nil
+}
+
+// validatePerson checks an object against the scenario's person schema.
+func validatePerson(payload map[string]interface{}) error {
+
+	name, ok := payload["name"].(string)
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("schema violation: name must be a non-empty string, got %v", payload["name"])
+	}
+	age, ok := payload["age"].(float64)
+	if !ok || age != float64(int(age)) {
+		return fmt.Errorf("schema violation: age must be an integer, got %v", payload["age"])
+	}
+	for key := range payload {
+		if key != "name" && key != "age" {
+			return fmt.Errorf("schema violation: unexpected property %q", key)
+		}
+	}
+	return nil
+}
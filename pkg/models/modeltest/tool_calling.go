@@ -0,0 +1,274 @@
+// tool_calling.go tests tool-calling round trips: tool request, tool result, and final answer.
+// pkg/models/modeltest/tool_calling.go
+package modeltest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	scenarioToolName   = "get_weather"
+	scenarioToolCity   = "Paris"
+	scenarioToolResult = `{"city":"Paris","temperature_c":21,"conditions":"sunny"}`
+	scenarioToolPrompt = "What is the weather in Paris right now? Use the get_weather tool, then answer in one sentence."
+)
+
+// scenarioToolSchema is the JSON schema for the weather tool's arguments.
+var scenarioToolSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"city": map[string]interface{}{"type": "string", "description": "City name"},
+	},
+	"required": []string{"city"},
+}
+
+// ToolCallingTester tests a complete tool-calling round trip.
+type ToolCallingTester struct{}
+
+// toolCallRound contains the tool call emitted by the model and the follow-up request that answers it.
+type toolCallRound struct {
+	Name      string
+	Arguments map[string]interface{}
+	FollowUp  map[string]interface{}
+}
+
+// Capability returns the capability covered by this tester.
+func (t *ToolCallingTester) Capability() Capability { return CapabilityToolCalling }
+
+// Test asks for a tool call, returns a canned tool result, and verifies the final answer uses it.
+func (t *ToolCallingTester) Test(ctx context.Context, client *Client, model string) TestResult {
+
+	result := newResult(client, CapabilityToolCalling, model)
+	providerType := client.Config().ProviderType
+	path := chatPath(providerType, model, false)
+
+	request := t.buildRequest(providerType, model)
+	body, _, err := postJSON(ctx, client, path, request)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	round, err := t.parseToolCall(providerType, request, body)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if round.Name != scenarioToolName {
+		result.Error = fmt.Sprintf("expected tool %q, got %q", scenarioToolName, round.Name)
+		return result
+	}
+	if city, _ := round.Arguments["city"].(string); !containsFold(city, scenarioToolCity) {
+		result.Error = fmt.Sprintf("expected tool argument city=%q, got %v", scenarioToolCity, round.Arguments)
+		return result
+	}
+
+	body, _, err = postJSON(ctx, client, path, round.FollowUp)
+	if err != nil {
+		result.Error = fmt.Sprintf("tool result round: %v", err)
+		return result
+	}
+	text, err := extractResponseText(providerType, body)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if !strings.Contains(text, "21") && !containsFold(text, "sunny") {
+		result.Error = fmt.Sprintf("final answer did not use the tool result: %q", text)
+		return result
+	}
+
+	result.Success = true
+	return result
+}
+
+// buildRequest builds the initial provider-specific request offering the weather tool.
+func (t *ToolCallingTester) buildRequest(providerType ProviderType, model string) map[string]interface{} {
+
+	switch providerType {
+	case ProviderTypeGemini:
+		return map[string]interface{}{
+			"contents": []map[string]interface{}{
+				{"role": "user", "parts": []map[string]interface{}{{"text": scenarioToolPrompt}}},
+			},
+			"tools": []map[string]interface{}{
+				{"functionDeclarations": []map[string]interface{}{
+					{"name": scenarioToolName, "description": "Get the current weather for a city", "parameters": scenarioToolSchema},
+				}},
+			},
+		}
+	case ProviderTypeAnthropic:
+		return map[string]interface{}{
+			"model":      model,
+			"max_tokens": scenarioMaxTokens,
+			"messages": []map[string]interface{}{
+				{"role": "user", "content": scenarioToolPrompt},
+			},
+			"tools": []map[string]interface{}{
+				{"name": scenarioToolName, "description": "Get the current weather for a city", "input_schema": scenarioToolSchema},
+			},
+		}
+	default:
+		return map[string]interface{}{
+			"model":      model,
+			"max_tokens": scenarioMaxTokens,
+			"messages": []map[string]interface{}{
+				{"role": "user", "content": scenarioToolPrompt},
+			},
+			"tools": []map[string]interface{}{
+				{"type": "function", "function": map[string]interface{}{
+					"name": scenarioToolName, "description": "Get the current weather for a city", "parameters": scenarioToolSchema,
+				}},
+			},
+		}
+	}
+}
+
+// parseToolCall extracts the first tool call and builds the follow-up request carrying the tool result.
+func (t *ToolCallingTester) parseToolCall(providerType ProviderType, request map[string]interface{}, body []byte) (toolCallRound, error) {
+
+	switch providerType {
+	case ProviderTypeGemini:
+		return t.parseGeminiToolCall(request, body)
+	case ProviderTypeAnthropic:
+		return t.parseAnthropicToolCall(request, body)
+	default:
+		return t.parseOpenAIToolCall(request, body)
+	}
+}
+
+// parseOpenAIToolCall handles OpenAI-compatible tool_calls responses.
+func (t *ToolCallingTester) parseOpenAIToolCall(request map[string]interface{}, body []byte) (toolCallRound, error) {
+
+	var parsed openAIResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return toolCallRound{}, fmt.Errorf("parse openai response: %w", err)
+	}
+	if len(parsed.Choices) == 0 || len(parsed.Choices[0].Message.ToolCalls) == 0 {
+		return toolCallRound{}, fmt.Errorf("response contained no tool calls")
+	}
+	if reason := parsed.Choices[0].FinishReason; reason != "tool_calls" && reason != "stop" {
+		return toolCallRound{}, fmt.Errorf("unexpected finish reason for tool call: %q", reason)
+	}
+
+	call := parsed.Choices[0].Message.ToolCalls[0]
+	arguments := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(call.Function.Arguments), &arguments); err != nil {
+		return toolCallRound{}, fmt.Errorf("tool arguments are not valid JSON: %w", err)
+	}
+
+	messages := append(cloneMessages(request["messages"]),
+		map[string]interface{}{
+			"role":       "assistant",
+			"content":    nil,
+			"tool_calls": parsed.Choices[0].Message.ToolCalls,
+		},
+		map[string]interface{}{
+			"role":         "tool",
+			"tool_call_id": call.ID,
+			"content":      scenarioToolResult,
+		},
+	)
+	return toolCallRound{
+		Name:      call.Function.Name,
+		Arguments: arguments,
+		FollowUp:  withField(request, "messages", messages),
+	}, nil
+}
+
+// parseAnthropicToolCall handles Anthropic tool_use content blocks.
+func (t *ToolCallingTester) parseAnthropicToolCall(request map[string]interface{}, body []byte) (toolCallRound, error) {
+
+	var parsed anthropicResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return toolCallRound{}, fmt.Errorf("parse anthropic response: %w", err)
+	}
+	if parsed.StopReason != "tool_use" {
+		return toolCallRound{}, fmt.Errorf("unexpected stop reason for tool call: %q", parsed.StopReason)
+	}
+
+	for _, block := range parsed.Content {
+		if block.Type != "tool_use" {
+			continue
+		}
+		arguments := make(map[string]interface{})
+		if err := json.Unmarshal(block.Input, &arguments); err != nil {
+			return toolCallRound{}, fmt.Errorf("tool input is not a JSON object: %w", err)
+		}
+		messages := append(cloneMessages(request["messages"]),
+			map[string]interface{}{"role": "assistant", "content": parsed.Content},
+			map[string]interface{}{"role": "user", "content": []map[string]interface{}{
+				{"type": "tool_result", "tool_use_id": block.ID, "content": scenarioToolResult},
+			}},
+		)
+		return toolCallRound{
+			Name:      block.Name,
+			Arguments: arguments,
+			FollowUp:  withField(request, "messages", messages),
+		}, nil
+	}
+	return toolCallRound{}, fmt.Errorf("response contained no tool_use block")
+}
+
+// parseGeminiToolCall handles Gemini functionCall parts.
+func (t *ToolCallingTester) parseGeminiToolCall(request map[string]interface{}, body []byte) (toolCallRound, error) {
+
+	var parsed geminiResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return toolCallRound{}, fmt.Errorf("parse gemini response: %w", err)
+	}
+	if len(parsed.Candidates) == 0 {
+		return toolCallRound{}, fmt.Errorf("response contained no candidates")
+	}
+
+	for _, part := range parsed.Candidates[0].Content.Parts {
+		if part.FunctionCall == nil {
+			continue
+		}
+		var toolResult map[string]interface{}
+		_ = json.Unmarshal([]byte(scenarioToolResult), &toolResult)
+		contents := append(cloneMessages(request["contents"]),
+			map[string]interface{}{"role": "model", "parts": parsed.Candidates[0].Content.Parts},
+			map[string]interface{}{"role": "user", "parts": []map[string]interface{}{
+				{"functionResponse": map[string]interface{}{"name": part.FunctionCall.Name, "response": toolResult}},
+			}},
+		)
+		return toolCallRound{
+			Name:      part.FunctionCall.Name,
+			Arguments: part.FunctionCall.Args,
+			FollowUp:  withField(request, "contents", contents),
+		}, nil
+	}
+	return toolCallRound{}, fmt.Errorf("response contained no functionCall part")
+}
+
+// cloneMessages copies a request's message list so follow-up requests do not alias it.
+func cloneMessages(value interface{}) []interface{} {
+
+	switch typed := value.(type) {
+	case []map[string]interface{}:
+		cloned := make([]interface{}, 0, len(typed)+2)
+		for _, item := range typed {
+			cloned = append(cloned, item)
+		}
+		return cloned
+	case []interface{}:
+		return append(make([]interface{}, 0, len(typed)+2), typed...)
+	default:
+		return nil
+	}
+}
+
+// withField returns a shallow copy of a request with one field replaced.
+func withField(request map[string]interface{}, key string, value interface{}) map[string]interface{} {
+
+	copied := make(map[string]interface{}, len(request))
+	for existingKey, existingValue := range request {
+		copied[existingKey] = existingValue
+	}
+	copied[key] = value
+	return copied
+}
@@ -0,0 +1,107 @@
+// vision.go tests image understanding with an inline generated image.
+// pkg/models/modeltest/vision.go
+package modeltest
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+const scenarioVisionPrompt = "What single color fills this image? Answer with one word."
+
+// VisionTester tests image input understanding.
+type VisionTester struct{}
+
+// Capability returns the capability covered by this tester.
+func (t *VisionTester) Capability() Capability { return CapabilityVision }
+
+// Test sends a solid red image and verifies the model names the color.
+func (t *VisionTester) Test(ctx context.Context, client *Client, model string) TestResult {
+
+	result := newResult(client, CapabilityVision, model)
+	providerType := client.Config().ProviderType
+
+	encoded, err := solidColorPNG(color.NRGBA{R: 0xff, A: 0xff})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	body, _, err := postJSON(ctx, client, chatPath(providerType, model, false), t.buildRequest(providerType, model, encoded))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	text, err := extractResponseText(providerType, body)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if !containsFold(text, "red") {
+		result.Error = fmt.Sprintf("expected answer to mention red, got %q", text)
+		return result
+	}
+
+	result.Success = true
+	return result
+}
+
+// buildRequest builds the provider-specific request carrying a base64 PNG.
+func (t *VisionTester) buildRequest(providerType ProviderType, model string, encoded string) map[string]interface{} {
+
+	switch providerType {
+	case ProviderTypeGemini:
+		return map[string]interface{}{
+			"contents": []map[string]interface{}{
+				{"role": "user", "parts": []map[string]interface{}{
+					{"inlineData": map[string]interface{}{"mimeType": "image/png", "data": encoded}},
+					{"text": scenarioVisionPrompt},
+				}},
+			},
+		}
+	case ProviderTypeAnthropic:
+		return map[string]interface{}{
+			"model":      model,
+			"max_tokens": scenarioMaxTokens,
+			"messages": []map[string]interface{}{
+				{"role": "user", "content": []map[string]interface{}{
+					{"type": "image", "source": map[string]interface{}{"type": "base64", "media_type": "image/png", "data": encoded}},
+					{"type": "text", "text": scenarioVisionPrompt},
+				}},
+			},
+		}
+	default:
+		return map[string]interface{}{
+			"model":      model,
+			"max_tokens": scenarioMaxTokens,
+			"messages": []map[string]interface{}{
+				{"role": "user", "content": []map[string]interface{}{
+					{"type": "text", "text": scenarioVisionPrompt},
+					{"type": "image_url", "image_url": map[string]interface{}{"url": "data:image/png;base64," + encoded}},
+				}},
+			},
+		}
+	}
+}
+
+// solidColorPNG renders a small single-color PNG and returns it base64-encoded.
+func solidColorPNG(fill color.NRGBA) (string, error) {
+
+	canvas := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			canvas.SetNRGBA(x, y, fill)
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, canvas); err != nil {
+		return "", fmt.Errorf("encode scenario image: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
+}
@@ -0,0 +1,75 @@
+// Package corelog implements the core Logger port on top of a zerologtest Recorder.
+// pkg/zerologtest/corelog/logger.go
+package corelog
+
+import (
+	"time"
+
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	"github.com/MadeByDoug/wls-chatbot/pkg/zerologtest"
+	"github.com/rs/zerolog"
+)
+
+// Logger records core Logger port calls with the same fields the zerolog adapter writes.
+type Logger struct {
+	recorder *zerologtest.Recorder
+}
+
+var _ corelogger.Logger = (*Logger)(nil)
+
+// New creates a core Logger port implementation that captures into recorder.
+func New(recorder *zerologtest.Recorder) *Logger {
+
+	return &Logger{recorder: recorder}
+}
+
+// Trace records a trace message.
+func (logger *Logger) Trace(message string, fields ...corelogger.LogField) {
+
+	logger.record(zerolog.TraceLevel, message, nil, fields)
+}
+
+// Debug records a debug message.
+func (logger *Logger) Debug(message string, fields ...corelogger.LogField) {
+
+	logger.record(zerolog.DebugLevel, message, nil, fields)
+}
+
+// Info records an info message.
+func (logger *Logger) Info(message string, fields ...corelogger.LogField) {
+
+	logger.record(zerolog.InfoLevel, message, nil, fields)
+}
+
+// Warn records a warning message with an optional error.
+func (logger *Logger) Warn(message string, err error, fields ...corelogger.LogField) {
+
+	logger.record(zerolog.WarnLevel, message, err, fields)
+}
+
+// Error records an error message with an optional error.
+func (logger *Logger) Error(message string, err error, fields ...corelogger.LogField) {
+
+	logger.record(zerolog.ErrorLevel, message, err, fields)
+}
+
+// record builds an entry from a port call and stores it.
+func (logger *Logger) record(level zerolog.Level, message string, err error, fields []corelogger.LogField) {
+
+	if logger == nil {
+		return
+	}
+
+	entryFields := map[string]any{
+		zerologtest.FieldLevel:   level.String(),
+		zerologtest.FieldMessage: message,
+		zerologtest.FieldTime:    time.Now().Format(time.RFC3339Nano),
+	}
+	if err != nil {
+		entryFields[zerolog.ErrorFieldName] = err.Error()
+	}
+	for _, field := range fields {
+		entryFields[field.Key] = field.Value
+	}
+	logger.recorder.Record(entryFields)
+}
@@ -0,0 +1,31 @@
+// logger_test.go verifies core Logger port calls are captured as recorder entries.
+// pkg/zerologtest/corelog/logger_test.go
+package corelog
+
+import (
+	"errors"
+	"testing"
+
+	corelogger "github.com/MadeByDoug/wls-chatbot/internal/core/logger"
+	"github.com/MadeByDoug/wls-chatbot/pkg/zerologtest"
+)
+
+// TestLoggerRecordsPortCalls verifies levels, messages, errors, and fields match the zerolog adapter's output.
+func TestLoggerRecordsPortCalls(t *testing.T) {
+
+	recorder := zerologtest.NewRecorder()
+	var logger corelogger.Logger = New(recorder)
+
+	logger.Info("connected", corelogger.LogField{Key: "provider", Value: "openai"})
+	logger.Warn("Failed to refresh stale resources", errors.New("timeout"), corelogger.LogField{Key: "provider", Value: "openai"})
+
+	zerologtest.AssertRecorderSequence(t, recorder, []zerologtest.SequenceStep{
+		zerologtest.Step(zerologtest.And(zerologtest.HasLevel("info"), zerologtest.FieldEq("provider", "openai"))).Once(),
+		zerologtest.Step(zerologtest.And(
+			zerologtest.HasLevel("warn"),
+			zerologtest.FieldEq(zerologtest.FieldMessage, "Failed to refresh stale resources"),
+			zerologtest.FieldEq("error", "timeout"),
+			zerologtest.HasField(zerologtest.FieldTime),
+		)).Once().Immediately(),
+	})
+}
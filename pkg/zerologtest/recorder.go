@@ -26,7 +26,7 @@ type Recorder struct {
 	ignoreFields       map[string]struct{}
 	presenceOnlyFields map[string]struct{}
 	transform          Transform
-	notifyCh           chan struct{}
+	updated            chan struct{} // created by waiters and closed when an entry is stored; nil when nobody waits
 }
 
 // NewRecorder creates a new structured log recorder.
@@ -35,7 +35,6 @@ func NewRecorder(options ...Option) *Recorder {
 	recorder := &Recorder{
 		ignoreFields:       map[string]struct{}{},
 		presenceOnlyFields: map[string]struct{}{},
-		notifyCh:           make(chan struct{}, 1),
 	}
 
 	for _, option := range options {
@@ -186,31 +185,54 @@ func (recorder *Recorder) WaitFor(ctx context.Context, predicate Predicate) (Ent
 		return Entry{}, errors.New("predicate is nil")
 	}
 
-	for {
-		if entry, ok := recorder.firstMatch(predicate); ok {
-			return entry, nil
+	var matched Entry
+	err := recorder.waitUntil(ctx, func(entries []Entry) bool {
+		for _, entry := range entries {
+			if predicate.Match(entry) {
+				matched = copyEntry(entry)
+				return true
+			}
 		}
+		return false
+	})
+	return matched, err
+}
 
-		select {
-		case <-ctx.Done():
-			return Entry{}, ctx.Err()
-		case <-recorder.notifyCh:
-		}
+// Record stores structured fields as an entry, applying the recorder's normalization options.
+func (recorder *Recorder) Record(fields map[string]any) {
+
+	if recorder == nil {
+		return
 	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	recorder.store(fields)
 }
 
-// firstMatch returns the first matching entry snapshot.
-func (recorder *Recorder) firstMatch(predicate Predicate) (Entry, bool) {
+// waitUntil blocks until check accepts the current entries or context cancellation occurs.
+// The check runs under the lock against the live slice and must not retain it.
+func (recorder *Recorder) waitUntil(ctx context.Context, check func(entries []Entry) bool) error {
 
-	recorder.mu.RLock()
-	defer recorder.mu.RUnlock()
+	for {
+		recorder.mu.Lock()
+		done := check(recorder.entries)
+		if recorder.updated == nil {
+			recorder.updated = make(chan struct{})
+		}
+		updated := recorder.updated
+		recorder.mu.Unlock()
+		if done {
+			return nil
+		}
 
-	for _, entry := range recorder.entries {
-		if predicate.Match(entry) {
-			return copyEntry(entry), true
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-updated:
 		}
 	}
-	return Entry{}, false
 }
 
 // captureLine decodes and stores one complete log line.
@@ -236,8 +258,13 @@ func (recorder *Recorder) captureLine(line []byte) {
 		}
 	}
 
-	normalized := recorder.normalizeFields(entryFields)
-	recorder.entries = append(recorder.entries, Entry{Fields: normalized})
+	recorder.store(entryFields)
+}
+
+// store normalizes and appends an entry, then wakes waiters. Callers hold the write lock.
+func (recorder *Recorder) store(fields map[string]any) {
+
+	recorder.entries = append(recorder.entries, Entry{Fields: recorder.normalizeFields(fields)})
 	recorder.signalWaiters()
 }
 
@@ -268,13 +295,14 @@ func (recorder *Recorder) normalizeFields(fields map[string]any) map[string]any
 	return normalized
 }
 
-// signalWaiters notifies async waiters that new entries were captured.
+// signalWaiters wakes every async waiter that new entries were captured; the next waiter creates a fresh channel.
 func (recorder *Recorder) signalWaiters() {
 
-	select {
-	case recorder.notifyCh <- struct{}{}:
-	default:
+	if recorder.updated == nil {
+		return
 	}
+	close(recorder.updated)
+	recorder.updated = nil
 }
 
 // String returns a concise snapshot count for quick diagnostics.
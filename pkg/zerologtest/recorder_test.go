@@ -144,6 +144,32 @@ func TestRecorderWaitForReturnsMatchingEntry(t *testing.T) {
 	}
 }
 
+// TestZeroValueRecorderCapturesAndWaits verifies a Recorder declared without NewRecorder stores entries and wakes waiters.
+func TestZeroValueRecorderCapturesAndWaits(t *testing.T) {
+
+	var recorder Recorder
+	recorder.Record(map[string]any{FieldEventCode: "FIRST"})
+
+	go func() {
+		time.Sleep(25 * time.Millisecond)
+		recorder.Record(map[string]any{FieldEventCode: "SECOND"})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	entry, err := recorder.WaitFor(ctx, HasEventCode("SECOND"))
+	if err != nil {
+		t.Fatalf("wait for second event: %v", err)
+	}
+	if entry.EventCode() != "SECOND" {
+		t.Fatalf("expected SECOND, got %q", entry.EventCode())
+	}
+	if got := len(recorder.Entries()); got != 2 {
+		t.Fatalf("expected 2 entries, got %d", got)
+	}
+}
+
 // TestRecorderWaitForHonorsContextCancel verifies wait timeout behavior.
 func TestRecorderWaitForHonorsContextCancel(t *testing.T) {
 
@@ -0,0 +1,256 @@
+// sequence.go matches ordered log flows with count, adjacency, and timing constraints.
+// pkg/zerologtest/sequence.go
+package zerologtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// SequenceStep is one ordered expectation in a log flow.
+// A plain step requires at least one matching entry after the previous step.
+type SequenceStep struct {
+	predicate   Predicate
+	minimum     int
+	maximum     int // -1 means unbounded
+	immediately bool
+	within      time.Duration
+}
+
+// Step creates a sequence step requiring at least one entry matching predicate.
+func Step(predicate Predicate) SequenceStep {
+
+	return SequenceStep{predicate: predicate, minimum: 1, maximum: -1}
+}
+
+// Once requires exactly one matching entry in the whole log, positioned after the previous step.
+func (step SequenceStep) Once() SequenceStep {
+
+	return step.Times(1)
+}
+
+// Times requires exactly n matching entries in the whole log, all positioned after the previous step.
+func (step SequenceStep) Times(n int) SequenceStep {
+
+	step.minimum = max(n, 1)
+	step.maximum = step.minimum
+	return step
+}
+
+// AtLeast requires n or more matching entries after the previous step; the step ends at the nth match.
+func (step SequenceStep) AtLeast(n int) SequenceStep {
+
+	step.minimum = max(n, 1)
+	step.maximum = -1
+	return step
+}
+
+// Immediately requires no entries between the previous step and this step's matches.
+func (step SequenceStep) Immediately() SequenceStep {
+
+	step.immediately = true
+	return step
+}
+
+// Within requires this step's first match to be logged no later than d after the previous step.
+func (step SequenceStep) Within(d time.Duration) SequenceStep {
+
+	step.within = d
+	return step
+}
+
+// String describes the step for diagnostics.
+func (step SequenceStep) String() string {
+
+	var builder strings.Builder
+	switch {
+	case step.maximum == step.minimum && step.minimum == 1:
+		builder.WriteString("once ")
+	case step.maximum == step.minimum:
+		builder.WriteString(fmt.Sprintf("exactly %d × ", step.minimum))
+	case step.minimum > 1:
+		builder.WriteString(fmt.Sprintf("at least %d × ", step.minimum))
+	}
+	builder.WriteString(describePredicate(step.predicate))
+	if step.immediately {
+		builder.WriteString(", immediately")
+	}
+	if step.within > 0 {
+		builder.WriteString(fmt.Sprintf(", within %s", step.within))
+	}
+	return builder.String()
+}
+
+// SequenceError reports the first failing step with a readable trace of the match attempt.
+type SequenceError struct {
+	Step    int // Zero-based index of the failing step
+	Reason  string
+	Details string
+}
+
+// Error returns the failure reason followed by the step and entry trace.
+func (err *SequenceError) Error() string {
+
+	return fmt.Sprintf("sequence failed at step %d: %s\n%s", err.Step+1, err.Reason, err.Details)
+}
+
+// MatchSequence checks that entries satisfy the steps in order and returns a *SequenceError when they do not.
+func MatchSequence(entries []Entry, steps ...SequenceStep) error {
+
+	return matchSequence(entries, steps, defaultFocusFields)
+}
+
+// AssertSequence fails the test when entries do not satisfy the steps in order.
+func AssertSequence(t testing.TB, entries []Entry, steps []SequenceStep, options ...AssertionOption) {
+
+	t.Helper()
+
+	config := buildAssertionConfig(options...)
+	if err := matchSequence(entries, steps, config.focusFields); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+// AssertRecorderSequence fails the test when recorder entries do not satisfy the steps in order.
+func AssertRecorderSequence(t testing.TB, recorder *Recorder, steps []SequenceStep, options ...AssertionOption) {
+
+	t.Helper()
+
+	AssertSequence(t, recorder.Entries(), steps, options...)
+}
+
+// matchSequence walks the steps with a cursor, consuming the minimum matches each step needs.
+func matchSequence(entries []Entry, steps []SequenceStep, focusFields []string) error {
+
+	consumedBy := make(map[int]int, len(entries))
+	cursor := 0
+	previousAnchor := -1
+
+	fail := func(stepIndex int, reason string) error {
+		return &SequenceError{
+			Step:    stepIndex,
+			Reason:  reason,
+			Details: renderSequenceTrace(entries, steps, stepIndex, consumedBy, focusFields),
+		}
+	}
+
+	for stepIndex, step := range steps {
+		if step.predicate == nil {
+			return fail(stepIndex, "step has a nil predicate")
+		}
+
+		matches := make([]int, 0, step.minimum)
+		for index := cursor; index < len(entries) && len(matches) < step.minimum; index++ {
+			if step.predicate.Match(entries[index]) {
+				matches = append(matches, index)
+			}
+		}
+		if len(matches) < step.minimum {
+			return fail(stepIndex, fmt.Sprintf("expected %d matching entries after entry %d, found %d", step.minimum, cursor, len(matches)))
+		}
+
+		if step.immediately && previousAnchor >= 0 {
+			for offset, index := range matches {
+				if index != cursor+offset {
+					return fail(stepIndex, fmt.Sprintf("entry [%d] appears between step %d and this step", cursor+offset, stepIndex))
+				}
+			}
+		}
+
+		if step.maximum >= 0 {
+			total := len(filterEntries(entries, step.predicate))
+			if total > step.maximum {
+				return fail(stepIndex, fmt.Sprintf("expected %d matching entries in the whole log, found %d", step.maximum, total))
+			}
+		}
+
+		if step.within > 0 && previousAnchor >= 0 {
+			previousTime, previousOK := entryTime(entries[previousAnchor])
+			currentTime, currentOK := entryTime(entries[matches[0]])
+			if !previousOK || !currentOK {
+				return fail(stepIndex, fmt.Sprintf("within %s requires %q on entries [%d] and [%d]", step.within, FieldTime, previousAnchor, matches[0]))
+			}
+			if elapsed := currentTime.Sub(previousTime); elapsed > step.within {
+				return fail(stepIndex, fmt.Sprintf("logged %s after step %d, expected within %s", elapsed, stepIndex, step.within))
+			}
+		}
+
+		for _, index := range matches {
+			consumedBy[index] = stepIndex
+		}
+		previousAnchor = matches[len(matches)-1]
+		cursor = previousAnchor + 1
+	}
+
+	return nil
+}
+
+// entryTime parses the zerolog time field as RFC3339 text or a Unix number in the configured unit.
+func entryTime(entry Entry) (time.Time, bool) {
+
+	value, ok := entry.Field(FieldTime)
+	if !ok {
+		return time.Time{}, false
+	}
+	if text, isText := value.(string); isText {
+		for _, layout := range []string{time.RFC3339Nano, zerolog.TimeFieldFormat} {
+			if parsed, err := time.Parse(layout, text); err == nil {
+				return parsed, true
+			}
+		}
+		return time.Time{}, false
+	}
+
+	number, isNumber := asFloat64(value)
+	if !isNumber {
+		return time.Time{}, false
+	}
+	switch zerolog.TimeFieldFormat {
+	case zerolog.TimeFormatUnixMs:
+		return time.UnixMilli(int64(number)), true
+	case zerolog.TimeFormatUnixMicro:
+		return time.UnixMicro(int64(number)), true
+	case zerolog.TimeFormatUnixNano:
+		return time.Unix(0, int64(number)), true
+	default:
+		return time.Unix(0, int64(number*float64(time.Second))), true
+	}
+}
+
+// renderSequenceTrace lists step outcomes and marks which entries each step consumed.
+func renderSequenceTrace(entries []Entry, steps []SequenceStep, failedStep int, consumedBy map[int]int, focusFields []string) string {
+
+	var builder strings.Builder
+	builder.WriteString("Steps:\n")
+	for index, step := range steps {
+		marker := "✓"
+		if index == failedStep {
+			marker = "✗"
+		} else if index > failedStep {
+			marker = "·"
+		}
+		builder.WriteString(fmt.Sprintf("  %s %d. %s\n", marker, index+1, step.String()))
+	}
+
+	builder.WriteString("Entries:\n")
+	if len(entries) == 0 {
+		builder.WriteString("  <no entries captured>")
+		return builder.String()
+	}
+	for index, entry := range entries {
+		label := "      "
+		if stepIndex, consumed := consumedBy[index]; consumed {
+			label = fmt.Sprintf("step %d", stepIndex+1)
+		}
+		rendered := strings.TrimPrefix(renderEntries([]Entry{entry}, focusFields), "[0]")
+		builder.WriteString(fmt.Sprintf("  [%d] %s%s", index, label, rendered))
+		if index < len(entries)-1 {
+			builder.WriteByte('\n')
+		}
+	}
+	return builder.String()
+}
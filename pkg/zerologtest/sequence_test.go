@@ -0,0 +1,89 @@
+// sequence_test.go verifies sequence step constraints and failure traces.
+// pkg/zerologtest/sequence_test.go
+package zerologtest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMatchSequenceConstraints verifies once, at least, immediately, and within constraints.
+func TestMatchSequenceConstraints(t *testing.T) {
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entry := func(eventCode string, offset time.Duration) Entry {
+		return Entry{Fields: map[string]any{FieldEventCode: eventCode, FieldTime: base.Add(offset).Format(time.RFC3339Nano)}}
+	}
+	entries := []Entry{
+		entry("CONNECT_STARTED", 0),
+		entry("CONNECT_RETRY", 100*time.Millisecond),
+		entry("CONNECT_RETRY", 300*time.Millisecond),
+		entry("CACHE_MISS", 350*time.Millisecond),
+		entry("CONNECT_OK", 400*time.Millisecond),
+	}
+	started := HasEventCode("CONNECT_STARTED")
+	retry := HasEventCode("CONNECT_RETRY")
+	ok := HasEventCode("CONNECT_OK")
+	cacheMiss := HasEventCode("CACHE_MISS")
+
+	testCases := []struct {
+		name       string
+		steps      []SequenceStep
+		wantStep   int
+		wantReason string
+	}{
+		{name: "happy path", steps: []SequenceStep{Step(started).Once(), Step(retry).AtLeast(2), Step(ok).Once().Within(time.Second)}, wantStep: -1},
+		{name: "at least too many", steps: []SequenceStep{Step(started), Step(retry).AtLeast(3)}, wantStep: 1, wantReason: "expected 3 matching entries after entry 1, found 2"},
+		{name: "once violated", steps: []SequenceStep{Step(retry).Once()}, wantStep: 0, wantReason: "expected 1 matching entries in the whole log, found 2"},
+		{name: "exact count", steps: []SequenceStep{Step(retry).Times(2), Step(ok)}, wantStep: -1},
+		{name: "immediately", steps: []SequenceStep{Step(started), Step(retry).AtLeast(2).Immediately(), Step(cacheMiss).Immediately()}, wantStep: -1},
+		{name: "entry between", steps: []SequenceStep{Step(retry).AtLeast(2), Step(ok).Immediately()}, wantStep: 1, wantReason: "entry [3] appears between step 1 and this step"},
+		{name: "too slow", steps: []SequenceStep{Step(started), Step(ok).Within(200 * time.Millisecond)}, wantStep: 1, wantReason: "logged 400ms after step 1, expected within 200ms"},
+		{name: "out of order", steps: []SequenceStep{Step(ok), Step(started)}, wantStep: 1, wantReason: "found 0"},
+	}
+
+	for _, testCase := range testCases {
+		err := MatchSequence(entries, testCase.steps...)
+		if testCase.wantStep < 0 {
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", testCase.name, err)
+			}
+			continue
+		}
+		var sequenceErr *SequenceError
+		if !errors.As(err, &sequenceErr) {
+			t.Fatalf("%s: expected *SequenceError, got %v", testCase.name, err)
+		}
+		if sequenceErr.Step != testCase.wantStep || !strings.Contains(sequenceErr.Reason, testCase.wantReason) {
+			t.Fatalf("%s: expected step %d with reason %q, got step %d: %s", testCase.name, testCase.wantStep, testCase.wantReason, sequenceErr.Step, sequenceErr.Reason)
+		}
+	}
+}
+
+// TestSequenceErrorRendersReadableTrace verifies failures mark step outcomes and consumed entries.
+func TestSequenceErrorRendersReadableTrace(t *testing.T) {
+
+	entries := []Entry{
+		{Fields: map[string]any{FieldEventCode: "A"}},
+		{Fields: map[string]any{FieldEventCode: "B"}},
+	}
+	err := MatchSequence(entries, Step(HasEventCode("A")), Step(HasEventCode("C")).Once(), Step(HasEventCode("B")))
+	if err == nil {
+		t.Fatalf("expected sequence failure")
+	}
+
+	for _, want := range []string{
+		"sequence failed at step 2",
+		`✓ 1. event_code == "A"`,
+		`✗ 2. once event_code == "C"`,
+		`· 3. event_code == "B"`,
+		`[0] step 1 event_code="A"`,
+		`[1]        event_code="B"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected trace to contain %q, got:\n%v", want, err)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// slog.go adapts log/slog records into recorder entries with zerolog field conventions.
+// pkg/zerologtest/slog.go
+package zerologtest
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// SlogHandler is a slog.Handler that stores records in a Recorder.
+// Records use the zerolog contract names: lowercase "level", "message", RFC3339Nano "time", and "caller" when AddSource is set.
+type SlogHandler struct {
+	recorder  *Recorder
+	options   slog.HandlerOptions
+	attrs     []slog.Attr
+	groups    []string
+	attrGroup []int // number of open groups when each attr in attrs was added
+}
+
+var _ slog.Handler = (*SlogHandler)(nil)
+
+// NewSlogHandler creates a slog handler that captures into recorder; nil options capture every level.
+func NewSlogHandler(recorder *Recorder, options *slog.HandlerOptions) *SlogHandler {
+
+	handler := &SlogHandler{recorder: recorder}
+	if options != nil {
+		handler.options = *options
+	}
+	if handler.options.Level == nil {
+		handler.options.Level = slog.LevelDebug - 4
+	}
+	return handler
+}
+
+// NewSlogLogger creates a slog logger that captures every level into recorder.
+func NewSlogLogger(recorder *Recorder) *slog.Logger {
+
+	return slog.New(NewSlogHandler(recorder, nil))
+}
+
+// Enabled reports whether the level meets the configured minimum.
+func (handler *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+
+	return level >= handler.options.Level.Level()
+}
+
+// Handle converts a record into entry fields and stores it.
+func (handler *SlogHandler) Handle(_ context.Context, record slog.Record) error {
+
+	fields := map[string]any{
+		FieldLevel:   slogLevelName(record.Level),
+		FieldMessage: record.Message,
+	}
+	if !record.Time.IsZero() {
+		fields[FieldTime] = record.Time.Format(time.RFC3339Nano)
+	}
+	if handler.options.AddSource && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		fields[FieldCaller] = frame.File + ":" + strconv.Itoa(frame.Line)
+	}
+
+	for index, attr := range handler.attrs {
+		addSlogAttr(fields, handler.groups[:handler.attrGroup[index]], attr)
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		addSlogAttr(fields, handler.groups, attr)
+		return true
+	})
+
+	handler.recorder.Record(fields)
+	return nil
+}
+
+// WithAttrs returns a handler that adds attrs to every record under the current groups.
+func (handler *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+
+	clone := handler.clone()
+	for _, attr := range attrs {
+		clone.attrs = append(clone.attrs, attr)
+		clone.attrGroup = append(clone.attrGroup, len(clone.groups))
+	}
+	return clone
+}
+
+// WithGroup returns a handler that nests subsequent attrs under name.
+func (handler *SlogHandler) WithGroup(name string) slog.Handler {
+
+	if name == "" {
+		return handler
+	}
+	clone := handler.clone()
+	clone.groups = append(clone.groups, name)
+	return clone
+}
+
+// clone copies the handler so derived handlers do not share slices.
+func (handler *SlogHandler) clone() *SlogHandler {
+
+	return &SlogHandler{
+		recorder:  handler.recorder,
+		options:   handler.options,
+		attrs:     append([]slog.Attr(nil), handler.attrs...),
+		groups:    append([]string(nil), handler.groups...),
+		attrGroup: append([]int(nil), handler.attrGroup...),
+	}
+}
+
+// addSlogAttr writes an attribute into fields, nesting groups as objects like slog.JSONHandler.
+func addSlogAttr(fields map[string]any, groups []string, attr slog.Attr) {
+
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	target := fields
+	for _, group := range groups {
+		nested, ok := target[group].(map[string]any)
+		if !ok {
+			nested = map[string]any{}
+			target[group] = nested
+		}
+		target = nested
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		groupAttrs := attr.Value.Group()
+		if len(groupAttrs) == 0 {
+			return
+		}
+		nestedGroups := []string{}
+		if attr.Key != "" {
+			nestedGroups = append(nestedGroups, attr.Key)
+		}
+		for _, groupAttr := range groupAttrs {
+			addSlogAttr(target, nestedGroups, groupAttr)
+		}
+		return
+	}
+
+	target[attr.Key] = slogValue(attr.Value)
+}
+
+// slogValue converts a slog value to the type JSON decoding would produce.
+func slogValue(value slog.Value) any {
+
+	switch value.Kind() {
+	case slog.KindString:
+		return value.String()
+	case slog.KindInt64:
+		return float64(value.Int64())
+	case slog.KindUint64:
+		return float64(value.Uint64())
+	case slog.KindFloat64:
+		return value.Float64()
+	case slog.KindBool:
+		return value.Bool()
+	case slog.KindDuration:
+		return float64(value.Duration())
+	case slog.KindTime:
+		return value.Time().Format(time.RFC3339Nano)
+	default:
+		if err, ok := value.Any().(error); ok {
+			return err.Error()
+		}
+		return value.String()
+	}
+}
+
+// slogLevelName maps slog levels onto zerolog level names.
+func slogLevelName(level slog.Level) string {
+
+	switch {
+	case level < slog.LevelDebug:
+		return "trace"
+	case level < slog.LevelInfo:
+		return "debug"
+	case level < slog.LevelWarn:
+		return "info"
+	case level < slog.LevelError:
+		return "warn"
+	default:
+		return "error"
+	}
+}
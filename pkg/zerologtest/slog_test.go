@@ -0,0 +1,55 @@
+// slog_test.go verifies slog records are captured with zerolog field conventions.
+// pkg/zerologtest/slog_test.go
+package zerologtest
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+// TestSlogHandlerCapturesRecords verifies levels, attrs, groups, errors, and sources map onto entry fields.
+func TestSlogHandlerCapturesRecords(t *testing.T) {
+
+	recorder := NewRecorder(WithPresenceOnlyFields(FieldTime))
+	logger := slog.New(NewSlogHandler(recorder, &slog.HandlerOptions{AddSource: true})).
+		With(FieldComponent, "provider").
+		WithGroup("request")
+
+	logger.Warn("retrying", slog.Int("attempt", 2), slog.Any("error", errors.New("timeout")), slog.Group("http", slog.Int("status", 503)))
+	slog.New(NewSlogHandler(recorder, &slog.HandlerOptions{Level: slog.LevelInfo})).Debug("filtered")
+
+	entries := recorder.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("expected debug record to be filtered, got %d entries", len(entries))
+	}
+	entry := entries[0]
+
+	testCases := []struct {
+		name      string
+		predicate Predicate
+	}{
+		{name: "level", predicate: HasLevel("warn")},
+		{name: "message", predicate: FieldEq(FieldMessage, "retrying")},
+		{name: "handler attr outside group", predicate: FieldEq(FieldComponent, "provider")},
+		{name: "time", predicate: FieldEq(FieldTime, PresenceOnlyValue)},
+		{name: "caller", predicate: FieldContains(FieldCaller, "slog_test.go:")},
+	}
+	for _, testCase := range testCases {
+		if !testCase.predicate.Match(entry) {
+			t.Fatalf("%s: expected %s to match %#v", testCase.name, testCase.predicate, entry.Fields)
+		}
+	}
+
+	request, ok := entry.Fields["request"].(map[string]any)
+	if !ok || !valuesEqual(request["attempt"], 2) || request["error"] != "timeout" {
+		t.Fatalf("expected grouped request attrs, got %#v", entry.Fields["request"])
+	}
+	httpGroup, ok := request["http"].(map[string]any)
+	if !ok || !valuesEqual(httpGroup["status"], 503) {
+		t.Fatalf("expected nested http group, got %#v", request["http"])
+	}
+	if slogLevelName(slog.LevelError+4) != "error" || slogLevelName(slog.LevelDebug-4) != "trace" {
+		t.Fatalf("unexpected level mapping")
+	}
+}
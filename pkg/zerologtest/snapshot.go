@@ -0,0 +1,151 @@
+// snapshot.go serializes normalized entries to golden files for whole-flow assertions.
+// pkg/zerologtest/snapshot.go
+package zerologtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// UpdateSnapshotsEnv rewrites golden snapshots when set to a non-empty value other than "0".
+const UpdateSnapshotsEnv = "ZEROLOGTEST_UPDATE"
+
+// updateFlagName is a boolean test flag honoured when the test package defines it; this package does not register it.
+const updateFlagName = "update"
+
+// snapshotPathPattern replaces characters unsafe for snapshot file names.
+var snapshotPathPattern = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// SnapshotPath returns the default golden file path for the running test.
+func SnapshotPath(t testing.TB) string {
+
+	return filepath.Join("testdata", "zerologtest", snapshotPathPattern.ReplaceAllString(t.Name(), "_")+".jsonl")
+}
+
+// MarshalSnapshot renders entries as one sorted-key JSON object per line.
+// Options are applied like recorder options; time and caller are presence-only by default.
+func MarshalSnapshot(entries []Entry, options ...Option) ([]byte, error) {
+
+	normalizer := NewRecorder(append([]Option{WithPresenceOnlyFields(FieldTime, FieldCaller)}, options...)...)
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	for index, entry := range entries {
+		if err := encoder.Encode(normalizer.normalizeFields(entry.Fields)); err != nil {
+			return nil, fmt.Errorf("marshal snapshot entry %d: %w", index, err)
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+// AssertSnapshot compares entries with the golden file at path, rewriting it when updates are enabled.
+func AssertSnapshot(t testing.TB, path string, entries []Entry, options ...Option) {
+
+	t.Helper()
+
+	actual, err := MarshalSnapshot(entries, options...)
+	if err != nil {
+		t.Fatalf("snapshot %s: %v", path, err)
+	}
+
+	if snapshotUpdateEnabled() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("snapshot %s: create directory: %v", path, err)
+		}
+		if err := os.WriteFile(path, actual, 0o644); err != nil {
+			t.Fatalf("snapshot %s: write: %v", path, err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("snapshot %s does not exist; run the test with %s=1 to create it", path, UpdateSnapshotsEnv)
+	}
+	if err != nil {
+		t.Fatalf("snapshot %s: read: %v", path, err)
+	}
+
+	if string(expected) == string(actual) {
+		return
+	}
+	t.Fatalf(
+		"snapshot %s does not match captured entries (-expected +actual); run with %s=1 to accept:\n%s",
+		path,
+		UpdateSnapshotsEnv,
+		diffLines(splitSnapshotLines(string(expected)), splitSnapshotLines(string(actual))),
+	)
+}
+
+// AssertRecorderSnapshot compares recorder entries with the golden file at path.
+func AssertRecorderSnapshot(t testing.TB, path string, recorder *Recorder, options ...Option) {
+
+	t.Helper()
+
+	AssertSnapshot(t, path, recorder.Entries(), options...)
+}
+
+// snapshotUpdateEnabled reports whether the environment override or a test-defined -update flag is set.
+func snapshotUpdateEnabled() bool {
+
+	if value := strings.TrimSpace(os.Getenv(UpdateSnapshotsEnv)); value != "" && value != "0" {
+		return true
+	}
+	updateFlag := flag.Lookup(updateFlagName)
+	return updateFlag != nil && updateFlag.Value.String() == "true"
+}
+
+// splitSnapshotLines splits serialized snapshot text into lines without the trailing newline.
+func splitSnapshotLines(text string) []string {
+
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// diffLines renders a line diff using the longest common subsequence of both inputs.
+func diffLines(expected []string, actual []string) string {
+
+	lengths := make([][]int, len(expected)+1)
+	for index := range lengths {
+		lengths[index] = make([]int, len(actual)+1)
+	}
+	for i := len(expected) - 1; i >= 0; i-- {
+		for j := len(actual) - 1; j >= 0; j-- {
+			if expected[i] == actual[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var builder strings.Builder
+	i, j := 0, 0
+	for i < len(expected) || j < len(actual) {
+		switch {
+		case i < len(expected) && j < len(actual) && expected[i] == actual[j]:
+			builder.WriteString("  " + expected[i] + "\n")
+			i++
+			j++
+		case j < len(actual) && (i == len(expected) || lengths[i][j+1] >= lengths[i+1][j]):
+			builder.WriteString("+ " + actual[j] + "\n")
+			j++
+		default:
+			builder.WriteString("- " + expected[i] + "\n")
+			i++
+		}
+	}
+	return strings.TrimSuffix(builder.String(), "\n")
+}
@@ -0,0 +1,62 @@
+// snapshot_test.go verifies golden snapshot serialization, updates, and diffs.
+// pkg/zerologtest/snapshot_test.go
+package zerologtest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestAssertRecorderSnapshotMatchesGolden verifies a provider connect flow against its committed golden file.
+func TestAssertRecorderSnapshotMatchesGolden(t *testing.T) {
+
+	recorder := NewRecorder()
+	logger := NewLogger(recorder)
+	logger.Info().Str(FieldEventCode, "PROVIDER_CONNECT_STARTED").Str(FieldComponent, "provider").Str("provider", "openai").Msg("connecting")
+	logger.Warn().Str(FieldEventCode, "PROVIDER_CONNECT_RETRY").Str(FieldComponent, "provider").Int("attempt", 1).Str("request_id", "req-83f1").Msg("retrying")
+	logger.Info().Str(FieldEventCode, "PROVIDER_CONNECT_OK").Str(FieldComponent, "provider").Int("models", 12).Msg("connected")
+
+	AssertRecorderSnapshot(t, SnapshotPath(t), recorder, WithPresenceOnlyFields("request_id"))
+}
+
+// TestAssertSnapshotWritesWhenUpdating verifies update mode creates the golden file that later runs compare against.
+func TestAssertSnapshotWritesWhenUpdating(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "nested", "flow.jsonl")
+	entries := []Entry{{Fields: map[string]any{FieldMessage: "hello", FieldTime: "2026-01-01T00:00:00Z", "secret": "x"}}}
+
+	t.Setenv(UpdateSnapshotsEnv, "1")
+	AssertSnapshot(t, path, entries, WithIgnoredFields("secret"))
+
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read written snapshot: %v", err)
+	}
+	if string(written) != `{"message":"hello","time":"<present>"}`+"\n" {
+		t.Fatalf("unexpected snapshot contents: %q", written)
+	}
+
+	t.Setenv(UpdateSnapshotsEnv, "0")
+	AssertSnapshot(t, path, entries, WithIgnoredFields("secret"))
+}
+
+// TestDiffLinesMarksChangedEntries verifies snapshot diffs show removed and added lines around shared context.
+func TestDiffLinesMarksChangedEntries(t *testing.T) {
+
+	diff := diffLines(
+		[]string{`{"event_code":"A"}`, `{"event_code":"B"}`, `{"event_code":"C"}`},
+		[]string{`{"event_code":"A"}`, `{"event_code":"X"}`, `{"event_code":"C"}`, `{"event_code":"D"}`},
+	)
+	want := strings.Join([]string{
+		`  {"event_code":"A"}`,
+		`+ {"event_code":"X"}`,
+		`- {"event_code":"B"}`,
+		`  {"event_code":"C"}`,
+		`+ {"event_code":"D"}`,
+	}, "\n")
+	if diff != want {
+		t.Fatalf("unexpected diff:\n%s\nwant:\n%s", diff, want)
+	}
+}
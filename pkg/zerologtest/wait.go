@@ -0,0 +1,137 @@
+// wait.go provides blocking waiters and Eventually-style assertions for asynchronous logging.
+// pkg/zerologtest/wait.go
+package zerologtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// WaitForCount blocks until at least n entries match or context cancellation occurs.
+func (recorder *Recorder) WaitForCount(ctx context.Context, predicate Predicate, n int) ([]Entry, error) {
+
+	if recorder == nil {
+		return nil, errors.New("recorder is nil")
+	}
+	if predicate == nil {
+		return nil, errors.New("predicate is nil")
+	}
+
+	var matched []Entry
+	err := recorder.waitUntil(ctx, func(entries []Entry) bool {
+		matched = matched[:0]
+		for _, entry := range entries {
+			if predicate.Match(entry) {
+				matched = append(matched, copyEntry(entry))
+			}
+		}
+		return len(matched) >= n
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matched, nil
+}
+
+// WaitForSequence blocks until the captured entries satisfy the steps or context cancellation occurs.
+// On cancellation the last *SequenceError is joined with the context error.
+func (recorder *Recorder) WaitForSequence(ctx context.Context, steps ...SequenceStep) error {
+
+	if recorder == nil {
+		return errors.New("recorder is nil")
+	}
+
+	var lastErr error
+	err := recorder.waitUntil(ctx, func(entries []Entry) bool {
+		lastErr = MatchSequence(entries, steps...)
+		return lastErr == nil
+	})
+	if err != nil {
+		return errors.Join(err, lastErr)
+	}
+	return nil
+}
+
+// Eventually waits up to timeout for an entry to match and fails the test otherwise.
+func Eventually(t testing.TB, recorder *Recorder, predicate Predicate, timeout time.Duration, options ...AssertionOption) Entry {
+
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	entry, err := recorder.WaitFor(ctx, predicate)
+	if err != nil {
+		config := buildAssertionConfig(options...)
+		t.Fatalf(
+			"expected an entry matching %s within %s: %v.\nRecent entries:\n%s",
+			describePredicate(predicate),
+			timeout,
+			err,
+			renderEntries(lastNEntries(recorder.Entries(), config.lastNEntries), config.focusFields),
+		)
+	}
+	return entry
+}
+
+// EventuallyCount waits up to timeout for at least n entries to match and fails the test otherwise.
+func EventuallyCount(t testing.TB, recorder *Recorder, predicate Predicate, n int, timeout time.Duration, options ...AssertionOption) []Entry {
+
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	entries, err := recorder.WaitForCount(ctx, predicate, n)
+	if err != nil {
+		config := buildAssertionConfig(options...)
+		t.Fatalf(
+			"expected %d entries matching %s within %s, found %d: %v.\nRecent entries:\n%s",
+			n,
+			describePredicate(predicate),
+			timeout,
+			len(recorder.Filter(predicate)),
+			err,
+			renderEntries(lastNEntries(recorder.Entries(), config.lastNEntries), config.focusFields),
+		)
+	}
+	return entries
+}
+
+// EventuallySequence waits up to timeout for the steps to be satisfied and fails the test with the last trace otherwise.
+func EventuallySequence(t testing.TB, recorder *Recorder, steps []SequenceStep, timeout time.Duration) {
+
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := recorder.WaitForSequence(ctx, steps...); err != nil {
+		t.Fatalf("expected sequence within %s: %v", timeout, err)
+	}
+}
+
+// Never fails the test if an entry matching predicate is captured during the window.
+func Never(t testing.TB, recorder *Recorder, predicate Predicate, window time.Duration, options ...AssertionOption) {
+
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), window)
+	defer cancel()
+
+	entry, err := recorder.WaitFor(ctx, predicate)
+	if err == nil {
+		config := buildAssertionConfig(options...)
+		t.Fatalf(
+			"expected no entry matching %s within %s, but found:\n%s",
+			describePredicate(predicate),
+			window,
+			renderEntries([]Entry{entry}, config.focusFields),
+		)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("wait for %s: %v", describePredicate(predicate), err)
+	}
+}
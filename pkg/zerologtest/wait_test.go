@@ -0,0 +1,91 @@
+// wait_test.go verifies blocking waiters and Eventually-style assertions.
+// pkg/zerologtest/wait_test.go
+package zerologtest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestRecorderWakesConcurrentWaiters verifies every waiter observes entries logged from another goroutine.
+func TestRecorderWakesConcurrentWaiters(t *testing.T) {
+
+	recorder := NewRecorder()
+	logger := NewLogger(recorder)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	const waiters = 4
+	var wg sync.WaitGroup
+	errs := make(chan error, waiters)
+	for index := 0; index < waiters; index++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := recorder.WaitForCount(ctx, HasEventCode("TICK"), 3)
+			errs <- err
+		}()
+	}
+
+	go func() {
+		for index := 0; index < 3; index++ {
+			logger.Info().Str(FieldEventCode, "TICK").Int("n", index).Msg("tick")
+		}
+	}()
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("waiter failed: %v", err)
+		}
+	}
+}
+
+// TestEventuallyHelpers verifies Eventually, EventuallyCount, EventuallySequence, and Never against async logging.
+func TestEventuallyHelpers(t *testing.T) {
+
+	recorder := NewRecorder()
+	logger := NewLogger(recorder)
+
+	go func() {
+		logger.Info().Str(FieldEventCode, "REFRESH_STARTED").Msg("refreshing")
+		logger.Info().Str(FieldEventCode, "REFRESH_PAGE").Msg("page")
+		logger.Info().Str(FieldEventCode, "REFRESH_PAGE").Msg("page")
+		logger.Info().Str(FieldEventCode, "REFRESH_DONE").Msg("done")
+	}()
+
+	entry := Eventually(t, recorder, HasEventCode("REFRESH_DONE"), time.Second)
+	if entry.Message() != "done" {
+		t.Fatalf("expected done entry, got %q", entry.Message())
+	}
+	if pages := EventuallyCount(t, recorder, HasEventCode("REFRESH_PAGE"), 2, time.Second); len(pages) != 2 {
+		t.Fatalf("expected two page entries, got %d", len(pages))
+	}
+	EventuallySequence(t, recorder, []SequenceStep{
+		Step(HasEventCode("REFRESH_STARTED")).Once(),
+		Step(HasEventCode("REFRESH_PAGE")).AtLeast(2).Immediately(),
+		Step(HasEventCode("REFRESH_DONE")).Once().Immediately(),
+	}, time.Second)
+	Never(t, recorder, HasLevel("error"), 20*time.Millisecond)
+}
+
+// TestWaitForSequenceReportsLastTraceOnTimeout verifies timeouts include the last sequence failure.
+func TestWaitForSequenceReportsLastTraceOnTimeout(t *testing.T) {
+
+	recorder := NewRecorder()
+	recorder.Record(map[string]any{FieldEventCode: "STARTED"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := recorder.WaitForSequence(ctx, Step(HasEventCode("STARTED")), Step(HasEventCode("FINISHED")))
+	var sequenceErr *SequenceError
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.As(err, &sequenceErr) || sequenceErr.Step != 1 {
+		t.Fatalf("expected deadline and step 2 sequence error, got %v", err)
+	}
+}